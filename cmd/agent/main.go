@@ -8,13 +8,14 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 	"winterflow-agent/internal/application"
 	certsEmbedded "winterflow-agent/internal/infra/winterflow/certs"
+	"winterflow-agent/pkg/files"
 	"winterflow-agent/pkg/log"
 
 	"winterflow-agent/internal/application/agent"
 	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/status"
 	"winterflow-agent/internal/application/version"
 	"winterflow-agent/internal/infra/winterflow/api"
 )
@@ -33,6 +34,9 @@ func main() {
 	register := flag.Bool("register", false, "Register the agent with the server. Optionally specify orchestrator as positional argument (e.g., --register docker_compose)")
 	// New flag to trigger data restoration flow
 	restore := flag.Bool("restore", false, "Restore agent data and templates after reinstall or migration")
+	restoreRollback := flag.Bool("restore-rollback", false, "Restore the application templates from the backup created by --restore")
+	showStatus := flag.Bool("status", false, "Show locally deployed apps and their container status")
+	safeMode := flag.Bool("safe-mode", false, "Start the agent in safe mode, rejecting mutating commands until safe mode is cleared")
 	flag.Parse()
 
 	// Show version if requested
@@ -50,6 +54,9 @@ func main() {
 		fmt.Println("  --config    Path to configuration file (default: agent.config.json)")
 		fmt.Println("  --register  Register the agent with the server. Optionally specify orchestrator as positional argument (e.g., --register docker_compose)")
 		fmt.Println("  --restore   Restore local state and notify the WinterFlow backend (used after agent re-installation)")
+		fmt.Println("  --restore-rollback  Restore the application templates from the backup created by --restore (the agent must be stopped)")
+		fmt.Println("  --status    Show locally deployed apps and their container status (works without a server connection)")
+		fmt.Println("  --safe-mode Start the agent in safe mode: it connects and answers queries but rejects mutating commands until safe mode is cleared")
 		os.Exit(0)
 	}
 
@@ -76,7 +83,36 @@ func main() {
 		return
 	}
 
+	// Handle rolling back a restoration if requested
+	if *restoreRollback {
+		if err := api.RollbackRestore(*configPath); err != nil {
+			fmt.Printf("Restore rollback failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Print the status of the local apps if requested
+	if *showStatus {
+		// Keep stdout for the table; only problems are logged.
+		log.SetOutput(os.Stderr)
+		log.InitLog("warn", log.FormatText)
+		if err := status.PrintLocalStatus(*configPath, os.Stdout); err != nil {
+			fmt.Printf("Status failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Printf("WinterFlow.io Agent initialization...")
+	// The lock is held while the process runs so that --restore-rollback does not replace the templates
+	// of a running agent. It is released when the process exits.
+	unlock, err := files.TryLock(config.GetLockPath(*configPath))
+	if err != nil {
+		fmt.Printf("\nFailed to lock %s, is another agent running? %v", config.GetLockPath(*configPath), err)
+		os.Exit(1)
+	}
+	defer unlock()
 	if err := syncEmbeddedFiles(*configPath); err != nil {
 		fmt.Printf("\nFailed to sync embedded files: %v", err)
 		os.Exit(1)
@@ -95,33 +131,29 @@ func main() {
 		log.Info("Received signal", "signal", sig.String())
 		log.Info("Initiating graceful shutdown")
 
-		// Cancel the context to abort operations
+		// Cancel the context to abort operations. The main function then shuts the
+		// agent down, waiting for in-flight commands up to the configured grace period.
 		cancel()
-
-		// The agent will be closed by the defer a.Close() statement
-		// which will handle graceful shutdown of the command bus
-
-		// The main function will exit naturally
-		// after the agent is closed and all commands have completed
-		// Having a timeout to quit if the agent stuck
-		time.Sleep(5 * time.Second)
-		log.Info("Shutting down agent")
-		os.Exit(0)
 	}()
 
 	// Start the agent with the given configuration
-	startAgent(ctx, cancel, *configPath)
+	startAgent(ctx, cancel, *configPath, *safeMode)
 
 	// Wait for context cancellation
 	<-ctx.Done()
 	log.Info("Context canceled, shutting down agent")
 
-	// Close the current agent if it exists
-	stopCurrentAgent()
+	// Shut down the current agent if it exists
+	if err := shutdownCurrentAgent(); err != nil {
+		log.Error("Graceful shutdown failed, forcing exit", "error", err)
+		os.Exit(1)
+	}
+	log.Info("Shutting down agent")
 }
 
-// startAgent initializes and starts the agent with the given configuration
-func startAgent(ctx context.Context, cancel context.CancelFunc, configPath string) {
+// startAgent initializes and starts the agent with the given configuration. With safeMode, the agent is put
+// into safe mode before it connects.
+func startAgent(ctx context.Context, cancel context.CancelFunc, configPath string, safeMode bool) {
 	// Load configuration
 	fmt.Printf("\nLoading configuration from %s", configPath)
 	cfg, err := config.WaitUntilReady(configPath)
@@ -130,10 +162,29 @@ func startAgent(ctx context.Context, cancel context.CancelFunc, configPath strin
 		os.Exit(1)
 	}
 
-	// Initialize logger with configured log level
-	log.InitLog(cfg.LogLevel)
+	// Initialize logger with configured log level, writing to a rotated file when configured
+	if logPath := cfg.GetLogFilePath(); logPath != "" {
+		logFile, err := log.OpenRotatingFile(logPath, cfg.GetLogRotateOptions())
+		if err != nil {
+			fmt.Printf("\nFailed to open log file: %v", err)
+			os.Exit(1)
+		}
+		log.SetOutput(logFile)
+	} else {
+		log.SetOutput(os.Stdout)
+	}
+	log.InitLog(cfg.LogLevel, cfg.LogFormat)
 	fmt.Printf("\nWinterFlow.io Agent initialized with Log Level \"%s\"\n", cfg.LogLevel)
 
+	if safeMode {
+		if err := cfg.EnableSafeMode(); err != nil {
+			log.Fatalf("Failed to enable safe mode: %v", err)
+		}
+	}
+	if cfg.SafeMode() {
+		log.Warn("Agent is in safe mode, mutating commands are rejected until safe mode is cleared", "safe_mode_file", cfg.GetSafeModePath())
+	}
+
 	// Create and initialize agent
 	log.Debug("Creating agent")
 	a, err := agent.NewAgent(ctx, cfg)
@@ -155,7 +206,7 @@ func startAgent(ctx context.Context, cancel context.CancelFunc, configPath strin
 	}
 
 	// Set up configuration file watcher
-	watcher := application.NewConfigWatcher(configPath, func(newConfig *config.Config) {
+	watcher := application.NewConfigWatcher(configPath, cfg, func(newConfig *config.Config) {
 		log.Info("Configuration changed, restarting agent")
 
 		// Create a new context for the new agent
@@ -165,7 +216,8 @@ func startAgent(ctx context.Context, cancel context.CancelFunc, configPath strin
 		cancel()
 
 		// Start a new agent with the new configuration
-		go startAgent(newCtx, newCancel, configPath)
+		// Safe mode, when still enabled, is kept by its file.
+		go startAgent(newCtx, newCancel, configPath, false)
 	})
 
 	if err := watcher.Start(ctx); err != nil {
@@ -173,16 +225,18 @@ func startAgent(ctx context.Context, cancel context.CancelFunc, configPath strin
 	}
 }
 
-// stopCurrentAgent safely stops the current agent if it exists
-func stopCurrentAgent() {
+// shutdownCurrentAgent safely shuts down the current agent if it exists
+func shutdownCurrentAgent() error {
 	agentMutex.Lock()
 	defer agentMutex.Unlock()
 
-	if currentAgent != nil {
-		log.Info("Closing current agent")
-		currentAgent.Close()
-		currentAgent = nil
+	if currentAgent == nil {
+		return nil
 	}
+	log.Info("Closing current agent")
+	err := currentAgent.Shutdown()
+	currentAgent = nil
+	return err
 }
 
 func syncEmbeddedFiles(configPath string) error {
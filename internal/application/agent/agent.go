@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 	"time"
 	"winterflow-agent/internal/application"
 	"winterflow-agent/internal/application/command"
@@ -9,8 +10,12 @@ import (
 	"winterflow-agent/pkg/log"
 
 	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/repository"
 	"winterflow-agent/internal/infra/winterflow/grpc/client"
+	"winterflow-agent/pkg/backoff"
+	"winterflow-agent/pkg/capabilities"
 	"winterflow-agent/pkg/cqrs"
+	"winterflow-agent/pkg/device"
 	"winterflow-agent/pkg/metrics"
 )
 
@@ -21,39 +26,62 @@ type Agent struct {
 	startTime         time.Time
 	metricsFactory    *metrics.MetricFactory
 	systemInfoFactory *metrics.MetricFactory
+	// deviceID returns the ID of the device the agent runs on.
+	deviceID func() (string, error)
+	// pingDocker checks that the Docker daemon responds, nil when the app repository does not use one.
+	pingDocker func(context.Context) error
 }
 
 // NewAgent creates a new agent instance
 func NewAgent(ctx context.Context, config *config.Config) (*Agent, error) {
+	start := time.Now()
+
 	appRepository := application.NewAppRepository(config)
 	registryRepository := application.NewRegistryRepository()
 	networkRepository := application.NewNetworkRepository()
+	systemInfoRepository := application.NewSystemInfoRepository()
+	connectionStatsRepository := application.NewConnectionStatsRepository(config)
 
-	// Create command bus and register handlers
 	commandBus := cqrs.NewCommandBus(ctx)
-	if err := command.RegisterCommandHandlers(commandBus, config, appRepository, registryRepository, networkRepository); err != nil {
-		log.Fatalf("Failed to register command handlers: %v", err)
-	}
 
 	// Create query bus and register handlers
 	queryBus := cqrs.NewQueryBus(ctx)
-	if err := query.RegisterQueryHandlers(queryBus, config, appRepository, registryRepository, networkRepository); err != nil {
+	queryBus.SetTimeout(query.Timeouts(config))
+	if err := query.RegisterQueryHandlers(queryBus, config, appRepository, registryRepository, networkRepository, systemInfoRepository, connectionStatsRepository, start); err != nil {
 		log.Fatalf("Failed to register query handlers: %v", err)
 	}
 
 	c, err := client.NewClient(ctx, config, commandBus, queryBus)
 	if err != nil {
-		return nil, log.Errorf("New GRPC client failed", err)
+		return nil, log.Errorf("New GRPC client failed: %v", err)
 	}
 
-	start := time.Now()
+	// Register command handlers; agent updates drain the client's in-flight app commands first.
+	if err := command.RegisterCommandHandlers(commandBus, config, appRepository, registryRepository, networkRepository, systemInfoRepository, c); err != nil {
+		log.Fatalf("Failed to register command handlers: %v", err)
+	}
+
+	metricsFactory := metrics.NewMetricsFactory(start)
+	metricsFactory.Add(metrics.NewSystemCpuUsageMetric(config.GetMetricsCPUSampleWindow()))
+	metricsFactory.Add(metrics.NewAgentConnectionStateMetric(func() string { return c.ConnectionState().String() }))
+	metricsFactory.Add(metrics.NewAgentHeartbeatRTTMetric(func() time.Duration { return c.HeartbeatRTT().Avg }))
+	if deployQueue, ok := appRepository.(repository.DeployQueue); ok {
+		metricsFactory.Add(metrics.NewAgentDeployQueueDepthMetric(deployQueue.DeployQueueDepth))
+	}
+
+	var pingDocker func(context.Context) error
+	if pinger, ok := appRepository.(repository.DockerPinger); ok {
+		pingDocker = pinger.PingDocker
+	}
 
 	return &Agent{
 		client:            c,
 		config:            config,
 		startTime:         start,
-		metricsFactory:    metrics.NewMetricsFactory(start),
+		metricsFactory:    metricsFactory,
 		systemInfoFactory: metrics.NewSystemInfoFactory(start),
+		deviceID:          device.GetDeviceID,
+		pingDocker:        pingDocker,
 	}, nil
 }
 
@@ -100,9 +128,43 @@ func (a *Agent) Close() {
 	}
 }
 
+// Shutdown closes the agent and waits for in-flight commands and queries to finish. It returns as soon
+// as the agent is closed, or an error once the configured shutdown grace period has elapsed.
+func (a *Agent) Shutdown() error {
+	return closeWithTimeout(a.Close, a.config.GetShutdownGracePeriod())
+}
+
+// closeWithTimeout runs closeFn and waits until it returns or timeout elapses, whichever comes first.
+func closeWithTimeout(closeFn func(), timeout time.Duration) error {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		closeFn()
+	}()
+
+	select {
+	case <-closed:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("agent did not shut down within %s", timeout)
+	}
+}
+
 // Run starts the agent's main loop
 func (a *Agent) Run(ctx context.Context) error {
-	capabilities := GetCapabilities().ToMap()
+	if err := a.checkDevice(); err != nil {
+		return log.Errorf("refusing to start: %v", err)
+	}
+	// The stream is stopped when the device check fails later on.
+	ctx, stop := context.WithCancel(ctx)
+	go a.watchDevice(ctx, stop)
+
+	if err := waitForDocker(ctx, a.pingDocker, a.config.GetDockerWaitTimeout(), backoff.New(dockerWaitRetryMin, dockerWaitRetryMax)); err != nil {
+		return log.Errorf("refusing to start: %v", err)
+	}
+
+	// Registration and the stream report the capabilities detected when the agent starts.
+	capabilities := capabilities.Collect(a.config)
 	log.Info("Registering agent with server", "server_address", a.config.GetGRPCServerAddress())
 	if err := a.registerAgent(ctx, capabilities); err != nil {
 		return log.Errorf("failed to register agent: %v", err)
@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloseWithTimeoutReturnsPromptly(t *testing.T) {
+	start := time.Now()
+	if err := closeWithTimeout(func() {}, 10*time.Second); err != nil {
+		t.Fatalf("Expected fast close to succeed, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected shutdown to finish without waiting for the grace period, took %s", elapsed)
+	}
+}
+
+func TestCloseWithTimeoutWaitsForInFlightWork(t *testing.T) {
+	done := false
+	if err := closeWithTimeout(func() {
+		time.Sleep(50 * time.Millisecond)
+		done = true
+	}, 10*time.Second); err != nil {
+		t.Fatalf("Expected close within grace period to succeed, got %v", err)
+	}
+	if !done {
+		t.Error("Expected shutdown to wait for the close function to finish")
+	}
+}
+
+func TestCloseWithTimeoutGivesUpAfterGracePeriod(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	if err := closeWithTimeout(func() { <-release }, 50*time.Millisecond); err == nil {
+		t.Fatal("Expected stuck close to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected timeout after the grace period, took %s", elapsed)
+	}
+}
@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"winterflow-agent/pkg/log"
+)
+
+// deviceCheckInterval is how often the running agent verifies that it still runs on the registered device.
+const deviceCheckInterval = 10 * time.Minute
+
+// ErrDeviceMismatch is returned when the agent runs on another device than the one it was registered on.
+var ErrDeviceMismatch = errors.New("the agent runs on another device than the one it was registered on; register it again with --register")
+
+// checkDevice compares the device the agent runs on with the one stored at registration. A mismatch is
+// logged loudly and returned as ErrDeviceMismatch when the agent must refuse to operate. Agents registered
+// before device IDs were recorded, and hosts whose device ID cannot be determined, are not checked.
+func (a *Agent) checkDevice() error {
+	if a.config.DeviceID == "" {
+		return nil
+	}
+	current, err := a.deviceID()
+	if err != nil {
+		log.Warn("Failed to determine the device ID, skipping the device check", "error", err)
+		return nil
+	}
+	if current == a.config.DeviceID {
+		return nil
+	}
+
+	log.Error("!!! DEVICE ID MISMATCH: this agent shares the identity of an agent registered on another device, e.g. because its disk image was cloned; register it again with --register !!!",
+		"agent_id", a.config.AgentID, "registered_device_id", a.config.DeviceID, "device_id", current)
+	if a.config.RefuseOnDeviceMismatch {
+		return ErrDeviceMismatch
+	}
+	return nil
+}
+
+// watchDevice re-checks the device periodically until ctx is done and calls stop once the agent must
+// refuse to operate.
+func (a *Agent) watchDevice(ctx context.Context, stop func()) {
+	ticker := time.NewTicker(deviceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.checkDevice(); err != nil {
+				log.Error("Disconnecting from the server", "error", err)
+				stop()
+				return
+			}
+		}
+	}
+}
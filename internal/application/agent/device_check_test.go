@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+func newDeviceCheckAgent(registered string, refuse bool, current string, currentErr error) *Agent {
+	return &Agent{
+		config:   &config.Config{AgentID: "agent", DeviceID: registered, RefuseOnDeviceMismatch: refuse},
+		deviceID: func() (string, error) { return current, currentErr },
+	}
+}
+
+func TestCheckDevice(t *testing.T) {
+	testCases := []struct {
+		name       string
+		registered string
+		refuse     bool
+		current    string
+		currentErr error
+		expected   error
+	}{
+		{name: "Matching device", registered: "device-a", refuse: true, current: "device-a"},
+		{name: "Mismatch is only logged by default", registered: "device-a", current: "device-b"},
+		{name: "Mismatch refused", registered: "device-a", refuse: true, current: "device-b", expected: ErrDeviceMismatch},
+		{name: "No device recorded at registration", refuse: true, current: "device-b"},
+		{name: "Unknown current device", registered: "device-a", refuse: true, currentErr: errors.New("no host identifier found")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newDeviceCheckAgent(tc.registered, tc.refuse, tc.current, tc.currentErr)
+			if err := a.checkDevice(); !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRunRefusesToStartOnAnotherDevice(t *testing.T) {
+	a := newDeviceCheckAgent("device-a", true, "device-b", nil)
+	// The check runs before the agent talks to the server, so no client is needed.
+	if err := a.Run(t.Context()); err == nil {
+		t.Fatal("Expected the agent to refuse to start on another device")
+	}
+}
@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"winterflow-agent/pkg/backoff"
+	"winterflow-agent/pkg/log"
+)
+
+// Bounds of the delay between pings of the Docker daemon on startup.
+const (
+	dockerWaitRetryMin = time.Second
+	dockerWaitRetryMax = 30 * time.Second
+)
+
+// waitForDocker pings the Docker daemon with backoff until it responds, so the agent does not register
+// while every app command would fail. It fails once timeout elapses or ctx is done; a timeout of 0 or a
+// nil ping skips the wait.
+func waitForDocker(ctx context.Context, ping func(context.Context) error, timeout time.Duration, retry *backoff.Backoff) error {
+	if ping == nil || timeout <= 0 {
+		return nil
+	}
+
+	log.Info("Waiting for the Docker daemon", "timeout", timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			if attempt > 1 {
+				log.Info("Docker daemon is ready", "attempts", attempt)
+			}
+			return nil
+		}
+
+		delay := retry.Next()
+		log.Warn("Docker daemon is not ready, retrying", "attempt", attempt, "retry_in", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("docker daemon did not become ready within %s: %w", timeout, err)
+		case <-time.After(delay):
+		}
+	}
+}
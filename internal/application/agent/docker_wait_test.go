@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"winterflow-agent/pkg/backoff"
+)
+
+// fakeDocker is a Docker daemon that responds to pings after the first failures pings failed.
+type fakeDocker struct {
+	failures int
+	pings    int
+}
+
+func (f *fakeDocker) Ping(context.Context) error {
+	f.pings++
+	if f.pings <= f.failures {
+		return errors.New("cannot connect to the docker daemon")
+	}
+	return nil
+}
+
+func TestWaitForDockerRetriesUntilReady(t *testing.T) {
+	docker := &fakeDocker{failures: 3}
+	if err := waitForDocker(t.Context(), docker.Ping, 5*time.Second, backoff.New(time.Millisecond, 2*time.Millisecond)); err != nil {
+		t.Fatalf("Expected the wait to succeed, got %v", err)
+	}
+	if docker.pings != 4 {
+		t.Errorf("Expected 4 pings, got %d", docker.pings)
+	}
+}
+
+func TestWaitForDockerGivesUpAfterTimeout(t *testing.T) {
+	docker := &fakeDocker{failures: 1 << 30}
+	start := time.Now()
+	err := waitForDocker(t.Context(), docker.Ping, 50*time.Millisecond, backoff.New(time.Millisecond, 5*time.Millisecond))
+	if err == nil {
+		t.Fatal("Expected the wait to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the wait to stop after the timeout, took %s", elapsed)
+	}
+	if docker.pings < 2 {
+		t.Errorf("Expected the daemon to be pinged repeatedly, got %d pings", docker.pings)
+	}
+}
+
+func TestWaitForDockerStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	docker := &fakeDocker{failures: 1 << 30}
+	if err := waitForDocker(ctx, docker.Ping, time.Minute, backoff.New(time.Second, time.Second)); err == nil {
+		t.Fatal("Expected the wait to fail once canceled")
+	}
+}
+
+func TestWaitForDockerDisabled(t *testing.T) {
+	docker := &fakeDocker{failures: 1 << 30}
+	if err := waitForDocker(t.Context(), docker.Ping, 0, backoff.New(time.Millisecond, time.Millisecond)); err != nil {
+		t.Fatalf("Expected a disabled wait to succeed, got %v", err)
+	}
+	if docker.pings != 0 {
+		t.Errorf("Expected no pings, got %d", docker.pings)
+	}
+}
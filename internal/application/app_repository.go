@@ -5,22 +5,61 @@ import (
 	pkgconfig "winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/repository"
 	"winterflow-agent/internal/infra/orchestrator/docker_compose"
+	"winterflow-agent/pkg/command"
 	"winterflow-agent/pkg/log"
 
 	"github.com/docker/docker/client"
 )
 
 func NewAppRepository(config *config.Config) repository.AppRepository {
-	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	// Make status and log queries talk to the same daemon the compose commands target.
+	if dockerContext := config.GetDockerContext(); dockerContext != "" {
+		host, err := docker_compose.ResolveDockerContextHost(command.NewExecRunner(), dockerContext)
+		if err != nil {
+			log.Fatal("Failed to validate Docker context", "docker_context", dockerContext, "error", err)
+		}
+		log.Info("Using Docker context", "docker_context", dockerContext, "host", host)
+	}
+	newClient := func() (*client.Client, error) {
+		return newDockerClient(config)
+	}
+
+	if config.GetOrchestrator() != pkgconfig.OrchestratorTypeDockerCompose.ToString() {
+		log.Warn("Unknown orchestrator type, defaulting to Docker Compose", "orchestrator", config.Orchestrator)
+	}
+	appRepository, err := docker_compose.NewComposeRepository(config, newClient, detectComposeCommand(config))
 	if err != nil {
 		log.Fatal("Failed to create Docker client", "error", err)
 	}
+	return appRepository
+}
 
-	switch config.GetOrchestrator() {
-	case pkgconfig.OrchestratorTypeDockerCompose.ToString():
-		return docker_compose.NewComposeRepository(config, dockerClient)
-	default:
-		log.Warn("Unknown orchestrator type, defaulting to Docker Compose", "orchestrator", config.Orchestrator)
-		return docker_compose.NewComposeRepository(config, dockerClient)
+// newDockerClient creates a client of the Docker daemon of the configured Docker context, or of the environment
+// when none is configured. It is called again to reconnect after the daemon restarted.
+func newDockerClient(config *config.Config) (*client.Client, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if dockerContext := config.GetDockerContext(); dockerContext != "" {
+		host, err := docker_compose.ResolveDockerContextHost(command.NewExecRunner(), dockerContext)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithHost(host))
+	}
+	return client.NewClientWithOpts(opts...)
+}
+
+// detectComposeCommand validates the configured compose command at startup. An explicitly configured
+// command that is unavailable is fatal; when detection finds neither the plugin nor the standalone
+// binary the plugin is assumed so that deploys report the failure.
+func detectComposeCommand(config *config.Config) docker_compose.ComposeCommand {
+	compose, err := docker_compose.DetectComposeCommand(command.NewExecRunner(), config.GetComposeCommand())
+	if err != nil {
+		if config.GetComposeCommand() != "" {
+			log.Fatal("Failed to validate compose command", "compose_command", config.GetComposeCommand(), "error", err)
+		}
+		log.Warn("Docker Compose is not available", "error", err)
+		return compose
 	}
+	log.Info("Using Docker Compose", "mode", compose.Mode(), "command", compose.String())
+	return compose
 }
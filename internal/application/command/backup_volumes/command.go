@@ -0,0 +1,21 @@
+package backup_volumes
+
+import "winterflow-agent/internal/domain/model"
+
+// BackupVolumesCommand represents a command to archive the named volumes of an application, e.g. before a
+// risky change, so that their data can be restored.
+type BackupVolumesCommand struct {
+	AppID string
+	// Result, when set, receives the archives written, also those written before a volume failed.
+	Result *BackupVolumesResult
+}
+
+// BackupVolumesResult describes the outcome of a BackupVolumesCommand.
+type BackupVolumesResult struct {
+	Backups []model.VolumeBackup
+}
+
+// Name returns the name of the command
+func (c BackupVolumesCommand) Name() string {
+	return "BackupVolumes"
+}
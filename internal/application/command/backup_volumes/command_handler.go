@@ -0,0 +1,39 @@
+package backup_volumes
+
+import (
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// BackupVolumesHandler handles the BackupVolumesCommand
+type BackupVolumesHandler struct {
+	backupper repository.VolumeBackupper
+}
+
+// Handle executes the BackupVolumesCommand
+func (h *BackupVolumesHandler) Handle(cmd BackupVolumesCommand) error {
+	log.Debug("Processing backup volumes request", "app_id", cmd.AppID)
+
+	// Validate the app ID
+	if cmd.AppID == "" {
+		return log.Errorf("app ID is required for backup volumes command")
+	}
+
+	backups, err := h.backupper.BackupVolumes(cmd.AppID)
+	if cmd.Result != nil {
+		cmd.Result.Backups = backups
+	}
+	if err != nil {
+		return log.Errorf("command failed with error: %v", err)
+	}
+
+	log.Info("Successfully backed up app volumes", "app_id", cmd.AppID, "volumes", len(backups))
+	return nil
+}
+
+// NewBackupVolumesHandler creates a new BackupVolumesHandler
+func NewBackupVolumesHandler(backupper repository.VolumeBackupper) *BackupVolumesHandler {
+	return &BackupVolumesHandler{
+		backupper: backupper,
+	}
+}
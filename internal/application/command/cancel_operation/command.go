@@ -0,0 +1,11 @@
+package cancel_operation
+
+// CancelOperationCommand represents a command to cancel the lifecycle operation running on an application
+type CancelOperationCommand struct {
+	AppID string
+}
+
+// Name returns the name of the command
+func (c CancelOperationCommand) Name() string {
+	return "CancelOperation"
+}
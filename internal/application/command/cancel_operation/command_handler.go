@@ -0,0 +1,40 @@
+package cancel_operation
+
+import (
+	"errors"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// ErrNoOperationRunning is returned when the app has no operation that could be canceled.
+var ErrNoOperationRunning = errors.New("no operation is running for the app")
+
+// CancelOperationHandler handles the CancelOperationCommand
+type CancelOperationHandler struct {
+	canceler repository.OperationCanceler
+}
+
+// Handle executes the CancelOperationCommand. It returns ErrNoOperationRunning when there was nothing to cancel.
+func (h *CancelOperationHandler) Handle(cmd CancelOperationCommand) error {
+	log.Debug("Processing cancel operation request", "app_id", cmd.AppID)
+
+	// Validate the app ID
+	if cmd.AppID == "" {
+		return log.Errorf("app ID is required for cancel operation command")
+	}
+
+	if !h.canceler.CancelOperation(cmd.AppID) {
+		log.Info("No running operation to cancel", "app_id", cmd.AppID)
+		return ErrNoOperationRunning
+	}
+
+	log.Info("Successfully canceled app operation", "app_id", cmd.AppID)
+	return nil
+}
+
+// NewCancelOperationHandler creates a new CancelOperationHandler
+func NewCancelOperationHandler(canceler repository.OperationCanceler) *CancelOperationHandler {
+	return &CancelOperationHandler{
+		canceler: canceler,
+	}
+}
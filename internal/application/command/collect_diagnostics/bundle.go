@@ -0,0 +1,131 @@
+package collect_diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// maxBundleSize caps the gzip tarball so that it fits into a single response of the agent stream.
+	maxBundleSize = 3 << 20
+	// maxLogTailSize is how much of the end of the agent log is collected.
+	maxLogTailSize = 8 << 20
+)
+
+// redactedValue replaces identifiers that must not leave the agent.
+const redactedValue = "[redacted]"
+
+// bundleEntry is a file of the diagnostics bundle.
+type bundleEntry struct {
+	name    string
+	content []byte
+}
+
+// bundle collects the files of the diagnostics bundle in the order they are added.
+type bundle struct {
+	entries []bundleEntry
+}
+
+// add adds a file to the bundle.
+func (b *bundle) add(name string, content []byte) {
+	b.entries = append(b.entries, bundleEntry{name: name, content: content})
+}
+
+// addJSON adds value encoded as indented JSON to the bundle under name.
+func (b *bundle) addJSON(name string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	b.add(name, data)
+	return nil
+}
+
+// names returns the names of the files of the bundle.
+func (b *bundle) names() []string {
+	names := make([]string, 0, len(b.entries))
+	for _, entry := range b.entries {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// archive returns the files of the bundle as a gzip tarball. It fails when the tarball exceeds maxSize bytes.
+func (b *bundle) archive(maxSize int) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, entry := range b.entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o600, Size: int64(len(entry.content)), ModTime: now, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", entry.name, err)
+		}
+		if buf.Len() > maxSize {
+			return nil, fmt.Errorf("the bundle exceeds the limit of %d bytes", maxSize)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > maxSize {
+		return nil, fmt.Errorf("the bundle of %d bytes exceeds the limit of %d bytes", buf.Len(), maxSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// readLogTail returns up to maxSize bytes from the end of the log file at path, starting at a line boundary.
+func readLogTail(path string, maxSize int64) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the agent logs to stdout; set log_file to include its log")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the agent log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat the agent log: %w", err)
+	}
+	offset := max(info.Size()-maxSize, 0)
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read the agent log: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(f, maxSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the agent log: %w", err)
+	}
+	if offset > 0 {
+		// Drop the line cut off by the offset.
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return data, nil
+}
+
+// redactIdentifiers replaces every occurrence of the non-empty identifiers in content.
+func redactIdentifiers(content []byte, identifiers ...string) []byte {
+	text := string(content)
+	for _, id := range identifiers {
+		if id != "" {
+			text = strings.ReplaceAll(text, id, redactedValue)
+		}
+	}
+	return []byte(text)
+}
@@ -0,0 +1,30 @@
+package collect_diagnostics
+
+// CollectDiagnosticsCommand represents a command to gather the diagnostics of the agent for a support ticket
+// into a gzip tarball: the tail of the agent log, the effective configuration, the status of the apps, the
+// Docker engine and the recent container events.
+type CollectDiagnosticsCommand struct {
+	// UploadURL, when set, is a signed HTTPS URL the bundle is uploaded to instead of being returned.
+	UploadURL string
+	// Result, when set, receives the collected bundle.
+	Result *CollectDiagnosticsResult
+}
+
+// CollectDiagnosticsResult describes the outcome of a CollectDiagnosticsCommand.
+type CollectDiagnosticsResult struct {
+	// Bundle is the gzip tarball; it is empty when the bundle was uploaded.
+	Bundle []byte
+	// Size is the size of the bundle in bytes.
+	Size int
+	// Entries are the names of the files in the bundle.
+	Entries []string
+	// Unavailable are the data sources that could not be read and are missing from the bundle.
+	Unavailable []string
+	// Uploaded reports whether the bundle was uploaded to the upload URL.
+	Uploaded bool
+}
+
+// Name returns the name of the command
+func (c CollectDiagnosticsCommand) Name() string {
+	return "CollectDiagnostics"
+}
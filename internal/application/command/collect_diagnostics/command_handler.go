@@ -0,0 +1,133 @@
+package collect_diagnostics
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/version"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// eventsWindow is how far back the container events are collected.
+	eventsWindow = 24 * time.Hour
+	// eventsTimeout bounds reading the recent container events from the Docker engine.
+	eventsTimeout = 30 * time.Second
+)
+
+// Names of the data sources reported in CollectDiagnosticsResult.Unavailable.
+const (
+	SourceAgentLog   = "agent_log"
+	SourceAppsStatus = "apps_status"
+	SourceDocker     = "docker"
+	SourceEvents     = "events"
+)
+
+// CollectDiagnosticsHandler handles the CollectDiagnosticsCommand
+type CollectDiagnosticsHandler struct {
+	config     *config.Config
+	apps       repository.AppRepository
+	systemInfo repository.SystemInfoRepository
+	// upload sends the bundle to a signed URL; it is replaced in tests.
+	upload func(url string, bundle []byte) error
+}
+
+// Handle executes the CollectDiagnosticsCommand. Failing data sources are logged, listed in the manifest of
+// the bundle and reported as unavailable instead of failing the command. Secrets are never collected: the
+// configuration is redacted as by config.Config.EffectiveSettings and the agent ID is removed from the log.
+func (h *CollectDiagnosticsHandler) Handle(cmd CollectDiagnosticsCommand) error {
+	log.Info("Processing collect diagnostics request", "upload", cmd.UploadURL != "")
+
+	b := &bundle{}
+	unavailable := make(map[string]string)
+	fail := func(source string, err error) {
+		log.Warn("Diagnostics source unavailable", "source", source, "error", err)
+		unavailable[source] = err.Error()
+	}
+
+	if logTail, err := readLogTail(h.config.GetLogFilePath(), maxLogTailSize); err != nil {
+		fail(SourceAgentLog, err)
+	} else {
+		b.add("agent.log", redactIdentifiers(logTail, h.config.AgentID, h.config.DeviceID))
+	}
+
+	if err := b.addJSON("config.json", h.config.EffectiveSettings()); err != nil {
+		return log.Errorf("failed to collect the agent config: %v", err)
+	}
+
+	if status, err := h.apps.GetAppsStatus(); err != nil {
+		fail(SourceAppsStatus, err)
+	} else if err := b.addJSON("apps_status.json", status.Apps); err != nil {
+		fail(SourceAppsStatus, err)
+	}
+
+	if engine, err := h.systemInfo.GetDockerEngine(); err != nil {
+		fail(SourceDocker, err)
+	} else if err := b.addJSON("docker.json", engine); err != nil {
+		fail(SourceDocker, err)
+	}
+
+	if history, ok := h.apps.(repository.ContainerEventHistory); !ok {
+		fail(SourceEvents, errors.New("the orchestrator does not report past container events"))
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), eventsTimeout)
+		recent, err := history.RecentContainerEvents(ctx, time.Now().Add(-eventsWindow))
+		cancel()
+		if err != nil {
+			fail(SourceEvents, err)
+		} else if err := b.addJSON("events.json", recent); err != nil {
+			fail(SourceEvents, err)
+		}
+	}
+
+	manifest := map[string]any{
+		"collected_at":  time.Now().UTC(),
+		"agent_version": version.GetVersion(),
+		"entries":       b.names(),
+		"unavailable":   unavailable,
+	}
+	if err := b.addJSON("manifest.json", manifest); err != nil {
+		return log.Errorf("failed to write the diagnostics manifest: %v", err)
+	}
+
+	archive, err := b.archive(maxBundleSize)
+	if err != nil {
+		return log.Errorf("failed to build the diagnostics bundle: %v", err)
+	}
+
+	result := &CollectDiagnosticsResult{Size: len(archive), Entries: b.names()}
+	for source := range unavailable {
+		result.Unavailable = append(result.Unavailable, source)
+	}
+	sort.Strings(result.Unavailable)
+
+	if cmd.UploadURL != "" {
+		if err := h.upload(cmd.UploadURL, archive); err != nil {
+			return log.Errorf("failed to upload the diagnostics bundle: %v", err)
+		}
+		result.Uploaded = true
+	} else {
+		result.Bundle = archive
+	}
+	if cmd.Result != nil {
+		*cmd.Result = *result
+	}
+
+	log.Info("Collected diagnostics", "size", result.Size, "entries", result.Entries, "unavailable", result.Unavailable, "uploaded", result.Uploaded)
+	return nil
+}
+
+// NewCollectDiagnosticsHandler creates a new CollectDiagnosticsHandler. Past container events are read from apps
+// when it implements repository.ContainerEventHistory.
+func NewCollectDiagnosticsHandler(cfg *config.Config, apps repository.AppRepository, systemInfo repository.SystemInfoRepository) *CollectDiagnosticsHandler {
+	return &CollectDiagnosticsHandler{
+		config:     cfg,
+		apps:       apps,
+		systemInfo: systemInfo,
+		upload:     uploadBundle,
+	}
+}
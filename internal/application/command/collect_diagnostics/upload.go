@@ -0,0 +1,44 @@
+package collect_diagnostics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// uploadTimeout bounds the upload of the bundle to the signed URL.
+const uploadTimeout = 2 * time.Minute
+
+// uploadBundle uploads bundle to the signed URL rawURL with an HTTP PUT. The URL must use HTTPS as it carries
+// the bundle off the host.
+func uploadBundle(rawURL string, bundle []byte) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid upload URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("upload URL must use https, got %q", u.Scheme)
+	}
+	return putBundle(&http.Client{Timeout: uploadTimeout}, u.String(), bundle)
+}
+
+// putBundle sends bundle to rawURL with client and checks that the server accepted it.
+func putBundle(client *http.Client, rawURL string, bundle []byte) error {
+	req, err := http.NewRequest(http.MethodPut, rawURL, bytes.NewReader(bundle))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("upload rejected with status %s", resp.Status)
+	}
+	return nil
+}
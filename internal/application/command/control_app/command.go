@@ -1,5 +1,10 @@
 package control_app
 
+import (
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
 // AppAction represents the action to perform on an application
 type AppAction int
 
@@ -14,6 +19,8 @@ const (
 	AppActionUpdate
 	// AppActionRedeploy redeploys the application by stopping and starting it with potentially updated configurations.
 	AppActionRedeploy
+	// AppActionRecreate recreates the application containers from the current images without pulling.
+	AppActionRecreate
 )
 
 // ControlAppCommand represents a command to control the state of an application
@@ -21,6 +28,16 @@ type ControlAppCommand struct {
 	AppID      string
 	AppVersion uint32
 	Action     AppAction
+	// Services, when set, limits a restart or recreate to the named services of the app. Other actions do not
+	// accept services.
+	Services []string
+	// Force runs a restart or redeploy even when the restart cooldown of the app has not elapsed yet.
+	Force bool
+	// Output, when set, receives the output of the docker compose commands run for the action while they
+	// run. It must not block.
+	Output func(model.OutputLine)
+	// Progress, when set, receives the stages the action reaches while it runs.
+	Progress repository.ProgressReporter
 }
 
 // Name returns the name of the command
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
+	"winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/domain/repository"
 	"winterflow-agent/internal/domain/service/app"
@@ -14,9 +16,13 @@ import (
 type ControlAppHandler struct {
 	repository     repository.AppRepository
 	VersionService app.RevisionServiceInterface
+	config         *config.Config
+	cooldowns      restartCooldowns
+	now            func() time.Time
 }
 
-// Handle executes the ControlAppCommand
+// Handle executes the ControlAppCommand. Restarts and redeploys within the restart cooldown of the previous one
+// fail with ErrRateLimited unless they are forced.
 func (h *ControlAppHandler) Handle(cmd ControlAppCommand) error {
 	log.Debug("Processing control app request", "app_id", cmd.AppID, "action", cmd.Action)
 
@@ -54,6 +60,32 @@ func (h *ControlAppHandler) Handle(cmd ControlAppCommand) error {
 		return log.Errorf("failed to load app config: %w", err)
 	}
 
+	if isRestart(cmd.Action) {
+		if remaining, ok := h.cooldowns.begin(cmd.AppID, h.now(), h.config.GetRestartCooldown(), cmd.Force); !ok {
+			log.Warn("Rejecting restart within the cooldown of the app", "app_id", cmd.AppID, "action", cmd.Action, "remaining", remaining)
+			return fmt.Errorf("%w: retry in %s or force the request", ErrRateLimited, remaining.Round(time.Second))
+		}
+	}
+
+	if cmd.Output != nil {
+		if streamer, ok := h.repository.(repository.OutputStreamer); ok {
+			defer streamer.StreamOutput(cmd.AppID, cmd.Output)()
+		} else {
+			log.Warn("App repository does not support streaming output", "app_id", cmd.AppID)
+		}
+	}
+	if cmd.Progress != nil {
+		if tracker, ok := h.repository.(repository.ProgressTracker); ok {
+			defer tracker.TrackProgress(cmd.AppID, cmd.Progress)()
+		} else {
+			log.Warn("App repository does not support progress reporting", "app_id", cmd.AppID)
+		}
+	}
+
+	if len(cmd.Services) > 0 {
+		return h.controlServices(cmd, appConfig)
+	}
+
 	// Determine the action to perform
 	var playbook string
 	var actionErr error
@@ -73,6 +105,9 @@ func (h *ControlAppHandler) Handle(cmd ControlAppCommand) error {
 	case AppActionRedeploy:
 		playbook = "redeploy_app"
 		actionErr = h.repository.DeployApp(cmd.AppID)
+	case AppActionRecreate:
+		playbook = "recreate_app"
+		actionErr = h.repository.RecreateApp(cmd.AppID)
 	default:
 		return log.Errorf("unsupported action: %d", cmd.Action)
 	}
@@ -85,6 +120,34 @@ func (h *ControlAppHandler) Handle(cmd ControlAppCommand) error {
 	return nil
 }
 
+// controlServices restarts or recreates the services named by the command.
+func (h *ControlAppHandler) controlServices(cmd ControlAppCommand, appConfig *model.AppConfig) error {
+	controller, ok := h.repository.(repository.ServiceController)
+	if !ok {
+		return log.Errorf("app repository does not support controlling single services")
+	}
+
+	var playbook string
+	var actionErr error
+	switch cmd.Action {
+	case AppActionRestart:
+		playbook = "restart_services"
+		actionErr = controller.RestartServices(cmd.AppID, cmd.Services)
+	case AppActionRecreate:
+		playbook = "recreate_services"
+		actionErr = controller.RecreateServices(cmd.AppID, cmd.Services)
+	default:
+		return log.Errorf("action %d does not accept services, only restart and recreate do", cmd.Action)
+	}
+
+	if actionErr != nil {
+		return log.Errorf("command failed with error: %v", actionErr)
+	}
+
+	log.Info("Successfully executed playbook", "playbook", playbook, "app_name", appConfig.Name, "services", cmd.Services)
+	return nil
+}
+
 // getAppConfig retrieves the app configuration for the given app ID
 func getAppConfig(versionService app.RevisionServiceInterface, appID string, version uint32) (*model.AppConfig, error) {
 	// Determine directory for the specified version.
@@ -95,25 +158,31 @@ func getAppConfig(versionService app.RevisionServiceInterface, appID string, ver
 		return nil, fmt.Errorf("app with ID %s and version %d does not exist", appID, version)
 	}
 
-	// Read config.json
-	configPath := filepath.Join(versionDir, "config.json")
-	configBytes, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("error reading app config: %w", err)
-	}
+	// Read the config in any of the accepted formats
+	for _, name := range model.AppConfigFileNames {
+		configBytes, err := os.ReadFile(filepath.Join(versionDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading app config: %w", err)
+		}
 
-	appConfig, err := model.ParseAppConfig(configBytes)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing app config: %w", err)
+		appConfig, err := model.ParseAppConfigFile(name, configBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing app config: %w", err)
+		}
+		return appConfig, nil
 	}
-
-	return appConfig, nil
+	return nil, fmt.Errorf("error reading app config: %w", os.ErrNotExist)
 }
 
 // NewControlAppHandler creates a new ControlAppHandler
-func NewControlAppHandler(repository repository.AppRepository, versionService app.RevisionServiceInterface) *ControlAppHandler {
+func NewControlAppHandler(repository repository.AppRepository, versionService app.RevisionServiceInterface, config *config.Config) *ControlAppHandler {
 	return &ControlAppHandler{
 		repository:     repository,
 		VersionService: versionService,
+		config:         config,
+		now:            time.Now,
 	}
 }
@@ -0,0 +1,124 @@
+package control_app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+const testAppID = "5d2c9e1a-3b7f-4e8d-a6c4-0f1e2d3c4b5a"
+
+// countingRepository counts the restarts and deploys of apps.
+type countingRepository struct {
+	repository.AppRepository
+	restarts int
+	deploys  int
+}
+
+func (r *countingRepository) RestartApp(string) error {
+	r.restarts++
+	return nil
+}
+
+func (r *countingRepository) DeployApp(string) error {
+	r.deploys++
+	return nil
+}
+
+// newHandler returns a handler for revision 1 of testAppID with a restart cooldown of cooldown seconds and a
+// clock that is advanced through the returned pointer.
+func newHandler(t *testing.T, cooldown int) (*ControlAppHandler, *countingRepository, *time.Time) {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir(), RestartCooldown: cooldown}
+	revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), testAppID, "1")
+	if err := os.MkdirAll(revisionDir, 0o755); err != nil {
+		t.Fatalf("Failed to create revision directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(revisionDir, "config.json"), []byte(`{"id":"`+testAppID+`","name":"demo","files":[],"variables":[]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write config.json: %v", err)
+	}
+
+	repo := &countingRepository{}
+	h := NewControlAppHandler(repo, app.NewRevisionService(cfg), cfg)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	return h, repo, &now
+}
+
+func TestControlAppRejectsRestartsWithinTheCooldown(t *testing.T) {
+	h, repo, now := newHandler(t, 60)
+
+	if err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRestart}); err != nil {
+		t.Fatalf("Expected the first restart to run, got %v", err)
+	}
+	*now = now.Add(30 * time.Second)
+	err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRedeploy})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected the redeploy within the cooldown to be rate limited, got %v", err)
+	}
+	if repo.restarts != 1 || repo.deploys != 0 {
+		t.Errorf("Expected only the first restart to run, got %d restarts and %d deploys", repo.restarts, repo.deploys)
+	}
+
+	*now = now.Add(30 * time.Second)
+	if err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRedeploy}); err != nil {
+		t.Fatalf("Expected the redeploy after the cooldown to run, got %v", err)
+	}
+	if repo.deploys != 1 {
+		t.Errorf("Expected the redeploy to run, got %d deploys", repo.deploys)
+	}
+}
+
+func TestControlAppForcedRestartsIgnoreTheCooldown(t *testing.T) {
+	h, repo, now := newHandler(t, 60)
+
+	for i := 0; i < 3; i++ {
+		if err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRestart, Force: true}); err != nil {
+			t.Fatalf("Expected forced restart %d to run, got %v", i+1, err)
+		}
+		*now = now.Add(time.Second)
+	}
+	if repo.restarts != 3 {
+		t.Errorf("Expected 3 restarts, got %d", repo.restarts)
+	}
+
+	// A forced restart starts a new cooldown.
+	if err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRestart}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected the restart after a forced one to be rate limited, got %v", err)
+	}
+}
+
+func TestControlAppDoesNotLimitRestartsWithoutACooldown(t *testing.T) {
+	h, repo, _ := newHandler(t, 0)
+
+	for i := 0; i < 3; i++ {
+		if err := h.Handle(ControlAppCommand{AppID: testAppID, Action: AppActionRestart}); err != nil {
+			t.Fatalf("Expected restart %d to run, got %v", i+1, err)
+		}
+	}
+	if repo.restarts != 3 {
+		t.Errorf("Expected 3 restarts, got %d", repo.restarts)
+	}
+}
+
+func TestRestartCooldownsArePerApp(t *testing.T) {
+	var cooldowns restartCooldowns
+	now := time.Now()
+
+	if _, ok := cooldowns.begin("app-1", now, time.Minute, false); !ok {
+		t.Fatal("Expected the first restart of app-1 to begin")
+	}
+	if _, ok := cooldowns.begin("app-2", now, time.Minute, false); !ok {
+		t.Error("Expected app-2 not to be limited by the restart of app-1")
+	}
+	remaining, ok := cooldowns.begin("app-1", now.Add(20*time.Second), time.Minute, false)
+	if ok || remaining != 40*time.Second {
+		t.Errorf("Expected app-1 to be limited for another 40s, got %v (begun: %v)", remaining, ok)
+	}
+}
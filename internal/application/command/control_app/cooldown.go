@@ -0,0 +1,39 @@
+package control_app
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a restart or redeploy of an app is requested within the restart cooldown of
+// the previous one and is not forced.
+var ErrRateLimited = errors.New("restart cooldown of the app has not elapsed")
+
+// restartCooldowns holds the time of the last restart or redeploy per app ID. The zero value is ready to use.
+type restartCooldowns struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// begin records a restart or redeploy of the app at now. Unless force is set, it fails with the time left when
+// the previous one began less than window ago; a window of zero never fails.
+func (c *restartCooldowns) begin(appID string, now time.Time, window time.Duration, force bool) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last == nil {
+		c.last = make(map[string]time.Time)
+	}
+	if last, ok := c.last[appID]; ok && !force && window > 0 {
+		if elapsed := now.Sub(last); elapsed < window {
+			return window - elapsed, false
+		}
+	}
+	c.last[appID] = now
+	return 0, true
+}
+
+// isRestart reports whether action restarts the containers of an app and is subject to the restart cooldown.
+func isRestart(action AppAction) bool {
+	return action == AppActionRestart || action == AppActionRedeploy
+}
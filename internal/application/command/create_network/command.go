@@ -3,6 +3,13 @@ package create_network
 // CreateNetworkCommand represents a command to create a Docker network.
 type CreateNetworkCommand struct {
 	NetworkName string // Name of the network to create
+	Driver      string // Network driver, e.g. bridge or overlay; the Docker default when empty
+	// Subnets lists IPv4 and IPv6 subnets in CIDR notation; Docker assigns one when empty.
+	Subnets []string
+	// Gateways lists gateway addresses, each within one of Subnets.
+	Gateways   []string
+	Attachable bool // Allow standalone containers to attach to a swarm scoped network
+	Internal   bool // Restrict external access to the network
 }
 
 // Name returns the unique command name for routing on the CQRS bus.
@@ -21,13 +21,25 @@ func (h *CreateNetworkHandler) Handle(cmd CreateNetworkCommand) error {
 		return log.Errorf("networks operations are disabled by configuration")
 	}
 
-	log.Info("Processing create network command", "network_name", cmd.NetworkName)
+	log.Info("Processing create network command", "network_name", cmd.NetworkName, "driver", cmd.Driver, "subnets", cmd.Subnets)
 
 	if cmd.NetworkName == "" {
 		return log.Errorf("network name is required")
 	}
 
-	if err := h.repository.CreateNetwork(model.Network{Name: cmd.NetworkName}); err != nil {
+	network := model.Network{
+		Name:       cmd.NetworkName,
+		Driver:     cmd.Driver,
+		Subnets:    cmd.Subnets,
+		Gateways:   cmd.Gateways,
+		Attachable: cmd.Attachable,
+		Internal:   cmd.Internal,
+	}
+	if _, err := network.SubnetGateways(); err != nil {
+		return log.Errorf("invalid network addressing: %v", err)
+	}
+
+	if err := h.repository.CreateNetwork(network); err != nil {
 		log.Error("Failed to create network", "network_name", cmd.NetworkName, "error", err)
 		return fmt.Errorf("failed to create network: %w", err)
 	}
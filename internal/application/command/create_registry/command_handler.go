@@ -38,7 +38,7 @@ func (h *CreateRegistryHandler) Handle(cmd CreateRegistryCommand) error {
 	}
 
 	if username != "" {
-		if dec, err := certs.DecryptWithPrivateKey(h.config.GetPrivateKeyPath(), username); err == nil {
+		if dec, err := certs.DecryptWithPrivateKeys(h.config.GetDecryptionKeyPaths(), username); err == nil {
 			username = dec
 		} else {
 			log.Warn("Failed to decrypt registry username", "error", err)
@@ -46,7 +46,7 @@ func (h *CreateRegistryHandler) Handle(cmd CreateRegistryCommand) error {
 	}
 
 	if password != "" {
-		if dec, err := certs.DecryptWithPrivateKey(h.config.GetPrivateKeyPath(), password); err == nil {
+		if dec, err := certs.DecryptWithPrivateKeys(h.config.GetDecryptionKeyPaths(), password); err == nil {
 			password = dec
 		} else {
 			log.Warn("Failed to decrypt registry password", "error", err)
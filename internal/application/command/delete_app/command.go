@@ -3,6 +3,8 @@ package delete_app
 // DeleteAppCommand represents a command to delete an application
 type DeleteAppCommand struct {
 	AppID string
+	// Purge also removes the named volumes of the app and thereby its data. By default volumes are kept.
+	Purge bool
 }
 
 // Name returns the name of the command
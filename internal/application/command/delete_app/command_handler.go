@@ -16,7 +16,7 @@ type DeleteAppHandler struct {
 // Handle executes the DeleteAppCommand
 func (h *DeleteAppHandler) Handle(cmd DeleteAppCommand) error {
 	appID := cmd.AppID
-	log.Debug("Processing delete app request", "app_id", appID)
+	log.Debug("Processing delete app request", "app_id", appID, "purge", cmd.Purge)
 
 	// Validate the app ID
 	if appID == "" {
@@ -27,11 +27,11 @@ func (h *DeleteAppHandler) Handle(cmd DeleteAppCommand) error {
 	appDir := filepath.Join(h.AppsTemplatesPath, appID)
 	if _, err := os.Stat(appDir); os.IsNotExist(err) {
 		log.Warn("App directory does not exist, it may have been already deleted", "app_id", appID)
-		log.Info("Successfully deleted app", "app_id", appID)
-		return nil
 	}
 
-	err := h.repository.DeleteApp(appID)
+	// The deployment is removed even without templates, so that nothing of it, such as its recorded
+	// storage path, is picked up by an app created again with the same ID.
+	err := h.repository.DeleteApp(appID, cmd.Purge)
 	if err != nil {
 		return log.Errorf("Deletion app command failed with error: %v", err)
 	}
@@ -0,0 +1,31 @@
+package delete_app
+
+import (
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/domain/repository"
+)
+
+// deletingRepository records the apps deleted through it.
+type deletingRepository struct {
+	repository.AppRepository
+	deleted []string
+}
+
+func (r *deletingRepository) DeleteApp(appID string, purge bool) error {
+	r.deleted = append(r.deleted, appID)
+	return nil
+}
+
+func TestDeleteAppWithoutTemplatesRemovesDeployment(t *testing.T) {
+	repo := &deletingRepository{}
+	h := NewDeleteAppHandler(repo, t.TempDir())
+
+	if err := h.Handle(DeleteAppCommand{AppID: "app"}); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if !reflect.DeepEqual(repo.deleted, []string{"app"}) {
+		t.Errorf("Expected the deployment of the app to be deleted, got %v", repo.deleted)
+	}
+}
@@ -1,14 +1,20 @@
 package command
 
 import (
+	"winterflow-agent/internal/application/command/backup_volumes"
+	"winterflow-agent/internal/application/command/cancel_operation"
+	"winterflow-agent/internal/application/command/collect_diagnostics"
 	"winterflow-agent/internal/application/command/control_app"
 	"winterflow-agent/internal/application/command/create_network"
 	"winterflow-agent/internal/application/command/create_registry"
 	"winterflow-agent/internal/application/command/delete_app"
 	"winterflow-agent/internal/application/command/delete_network"
 	"winterflow-agent/internal/application/command/delete_registry"
+	"winterflow-agent/internal/application/command/import_app"
+	"winterflow-agent/internal/application/command/reconcile_app"
 	"winterflow-agent/internal/application/command/rename_app"
 	"winterflow-agent/internal/application/command/save_app"
+	"winterflow-agent/internal/application/command/start_apps"
 	"winterflow-agent/internal/application/command/update_agent"
 	"winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/repository"
@@ -17,43 +23,77 @@ import (
 	"winterflow-agent/pkg/log"
 )
 
-func RegisterCommandHandlers(b cqrs.CommandBus, config *config.Config, appRepository repository.AppRepository, registryRepository repository.DockerRegistryRepository, networkRepository repository.DockerNetworkRepository) error {
+func RegisterCommandHandlers(b cqrs.CommandBus, config *config.Config, appRepository repository.AppRepository, registryRepository repository.DockerRegistryRepository, networkRepository repository.DockerNetworkRepository, systemInfoRepository repository.SystemInfoRepository, drainer update_agent.Drainer) error {
 	versionService := app.NewRevisionService(config)
 
-	if err := b.Register(save_app.NewSaveAppHandler(config.GetAppsTemplatesPath(), config.GetPrivateKeyPath(), versionService)); err != nil {
-		return log.Errorf("failed to register save app handler", "error", err)
+	saveAppHandler := save_app.NewSaveAppHandler(config.GetAppsTemplatesPath(), config.GetDecryptionKeyPaths(), config.BestEffortDecryption, versionService)
+	saveAppHandler.DirPerm = config.GetDirPerm()
+	saveAppHandler.FilePerm = config.GetFilePerm()
+	saveAppHandler.SensitiveFilePerm = config.GetSensitiveFilePerm()
+	if err := b.Register(saveAppHandler); err != nil {
+		return log.Errorf("failed to register save app handler: %v", err)
 	}
 
 	if err := b.Register(delete_app.NewDeleteAppHandler(appRepository, config.GetAppsTemplatesPath())); err != nil {
-		return log.Errorf("failed to register delete app handler", "error", err)
+		return log.Errorf("failed to register delete app handler: %v", err)
 	}
 
-	if err := b.Register(control_app.NewControlAppHandler(appRepository, versionService)); err != nil {
-		return log.Errorf("failed to register control app handler", "error", err)
+	if err := b.Register(control_app.NewControlAppHandler(appRepository, versionService, config)); err != nil {
+		return log.Errorf("failed to register control app handler: %v", err)
 	}
 
-	if err := b.Register(update_agent.NewUpdateAgentHandler(config)); err != nil {
-		return log.Errorf("failed to register update agent handler", "error", err)
+	if err := b.Register(start_apps.NewStartAppsHandler(appRepository, versionService, config)); err != nil {
+		return log.Errorf("failed to register start apps handler: %v", err)
+	}
+
+	if canceler, ok := appRepository.(repository.OperationCanceler); ok {
+		if err := b.Register(cancel_operation.NewCancelOperationHandler(canceler)); err != nil {
+			return log.Errorf("failed to register cancel operation handler: %v", err)
+		}
+	}
+
+	if reconciler, ok := appRepository.(repository.AppReconciler); ok {
+		if err := b.Register(reconcile_app.NewReconcileAppHandler(reconciler, versionService)); err != nil {
+			return log.Errorf("failed to register reconcile app handler: %v", err)
+		}
+	}
+
+	if backupper, ok := appRepository.(repository.VolumeBackupper); ok {
+		if err := b.Register(backup_volumes.NewBackupVolumesHandler(backupper)); err != nil {
+			return log.Errorf("failed to register backup volumes handler: %v", err)
+		}
+	}
+
+	if err := b.Register(update_agent.NewUpdateAgentHandler(config, drainer)); err != nil {
+		return log.Errorf("failed to register update agent handler: %v", err)
 	}
 
 	if err := b.Register(rename_app.NewRenameAppHandler(appRepository, config.GetAppsTemplatesPath(), versionService)); err != nil {
-		return log.Errorf("failed to register rename app handler", "error", err)
+		return log.Errorf("failed to register rename app handler: %v", err)
 	}
 
 	if err := b.Register(create_registry.NewCreateRegistryHandler(registryRepository, config)); err != nil {
-		return log.Errorf("failed to register create registry handler", "error", err)
+		return log.Errorf("failed to register create registry handler: %v", err)
 	}
 
 	if err := b.Register(delete_registry.NewDeleteRegistryHandler(registryRepository, config)); err != nil {
-		return log.Errorf("failed to register delete registry handler", "error", err)
+		return log.Errorf("failed to register delete registry handler: %v", err)
 	}
 
 	if err := b.Register(create_network.NewCreateNetworkHandler(networkRepository, config)); err != nil {
-		return log.Errorf("failed to register create network handler", "error", err)
+		return log.Errorf("failed to register create network handler: %v", err)
 	}
 
 	if err := b.Register(delete_network.NewDeleteNetworkHandler(networkRepository, config)); err != nil {
-		return log.Errorf("failed to register delete network handler", "error", err)
+		return log.Errorf("failed to register delete network handler: %v", err)
+	}
+
+	if err := b.Register(import_app.NewImportAppHandler(appRepository, config.GetAppsTemplatesPath(), versionService)); err != nil {
+		return log.Errorf("failed to register import app handler: %v", err)
+	}
+
+	if err := b.Register(collect_diagnostics.NewCollectDiagnosticsHandler(config, appRepository, systemInfoRepository)); err != nil {
+		return log.Errorf("failed to register collect diagnostics handler: %v", err)
 	}
 
 	return nil
@@ -0,0 +1,16 @@
+package import_app
+
+// ImportAppCommand represents a command to restore an application's template tree from a gzip
+// tarball as produced by the ExportApp query. Entry names are relative to the application's template
+// directory ("<revision>/config.json", "<revision>/files/...").
+type ImportAppCommand struct {
+	AppID   string
+	Archive []byte
+	// Deploy deploys the latest imported revision once the templates are in place.
+	Deploy bool
+}
+
+// Name returns a unique identifier of the command used by the CQRS bus.
+func (c ImportAppCommand) Name() string {
+	return "ImportApp"
+}
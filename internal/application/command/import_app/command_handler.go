@@ -0,0 +1,319 @@
+package import_app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/internal/domain/service/util"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+)
+
+// importArchiveLimits bounds the bundles that are imported, so that a small archive cannot expand into
+// enough files to fill the disk.
+var importArchiveLimits = util.ArchiveLimits{MaxEntries: 10000, MaxSize: 512 << 20}
+
+// encryptedPlaceholder is what ExportApp writes in place of encrypted variables and files.
+const encryptedPlaceholder = "<encrypted>"
+
+// ImportAppHandler handles the ImportAppCommand.
+type ImportAppHandler struct {
+	repository        repository.AppRepository
+	AppsTemplatesPath string
+	VersionService    app.RevisionServiceInterface
+}
+
+// Handle executes the ImportAppCommand.
+func (h *ImportAppHandler) Handle(cmd ImportAppCommand) error {
+	appID := strings.TrimSpace(cmd.AppID)
+	log.Debug("Processing import app request", "app_id", appID, "archive_size", len(cmd.Archive), "deploy", cmd.Deploy)
+
+	if appID == "" {
+		return log.Errorf("app ID is required for import app command")
+	}
+	// The app ID becomes a directory name that is replaced wholesale, so it must be a single path element.
+	if appID != filepath.Base(appID) || strings.HasPrefix(appID, ".") {
+		return log.Errorf("invalid app ID %q", appID)
+	}
+	if len(cmd.Archive) == 0 {
+		return log.Errorf("archive is required for import app command")
+	}
+	if err := util.CheckTarGz(bytes.NewReader(cmd.Archive), importArchiveLimits); err != nil {
+		return log.Errorf("invalid archive: %w", err)
+	}
+
+	if err := os.MkdirAll(h.AppsTemplatesPath, 0o755); err != nil {
+		return log.Errorf("failed to ensure apps templates directory: %w", err)
+	}
+
+	// Stage the archive next to the templates directory so that nothing is touched unless it is valid and
+	// the final move is a cheap rename on the same filesystem. Staging inside it would make the bundle show
+	// up as an app to everything that lists the templates directory.
+	stagingDir, err := os.MkdirTemp(filepath.Dir(h.AppsTemplatesPath), ".import-")
+	if err != nil {
+		return log.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := util.ExtractTarGz(bytes.NewReader(cmd.Archive), stagingDir); err != nil {
+		return log.Errorf("failed to extract archive: %w", err)
+	}
+
+	appConfig, latest, err := validateBundle(stagingDir)
+	if err != nil {
+		return log.Errorf("invalid app bundle: %w", err)
+	}
+
+	unique, err := h.isNameUnique(appConfig.Name, appID)
+	if err != nil {
+		return err
+	}
+	if !unique {
+		return log.Errorf("application name %q is already in use by another app", appConfig.Name)
+	}
+
+	if err := h.replaceTemplates(appID, stagingDir); err != nil {
+		return err
+	}
+	log.Info("Imported app templates", "app_id", appID, "app_name", appConfig.Name, "latest_revision", latest)
+
+	if cmd.Deploy {
+		if h.repository == nil {
+			return log.Errorf("appRepository is not configured")
+		}
+		if err := h.repository.DeployApp(appID); err != nil {
+			return log.Errorf("failed to deploy imported app: %w", err)
+		}
+		log.Info("Deployed imported app", "app_id", appID, "revision", latest)
+	}
+
+	return nil
+}
+
+// replaceTemplates replaces the templates of the app with the extracted bundle in stagingDir. Lifecycle
+// operations of the app are kept off while the secrets are restored and the templates are swapped, so
+// that a deploy never renders a half removed revision.
+func (h *ImportAppHandler) replaceTemplates(appID, stagingDir string) error {
+	if locker, ok := h.repository.(repository.AppLocker); ok {
+		defer locker.LockApp(appID)()
+	}
+
+	if err := h.restoreSecrets(appID, stagingDir); err != nil {
+		return log.Errorf("invalid app bundle: %w", err)
+	}
+
+	appDir := filepath.Join(h.AppsTemplatesPath, appID)
+	if err := os.RemoveAll(appDir); err != nil {
+		return log.Errorf("failed to remove existing templates of app %s: %w", appID, err)
+	}
+	if err := os.Rename(stagingDir, appDir); err != nil {
+		return log.Errorf("failed to move imported templates into place: %w", err)
+	}
+	return nil
+}
+
+// validateBundle checks that every top-level entry of an extracted bundle is a revision directory
+// holding a parseable configuration and returns the configuration of the latest revision.
+func validateBundle(dir string) (*model.AppConfig, uint32, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read extracted archive: %w", err)
+	}
+
+	var latest uint32
+	var latestConfig *model.AppConfig
+	for _, entry := range entries {
+		revision, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if !entry.IsDir() || err != nil || revision == 0 {
+			return nil, 0, fmt.Errorf("unexpected entry %q, expected revision directories only", entry.Name())
+		}
+
+		cfg, _, err := orchestrator.ReadAppConfig(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, 0, fmt.Errorf("revision %d has no valid configuration: %w", revision, err)
+		}
+
+		if uint32(revision) > latest {
+			latest = uint32(revision)
+			latestConfig = cfg
+		}
+	}
+
+	if latestConfig == nil {
+		return nil, 0, fmt.Errorf("archive contains no revisions")
+	}
+	return latestConfig, latest, nil
+}
+
+// restoreSecrets fills in the secrets an export leaves out with the ones of the latest revision of the
+// existing app: placeholders of encrypted variables and files are replaced with the current decrypted
+// values, and host secret files are copied into revisions that have none. A placeholder without a current
+// value fails the import, as the app would otherwise be deployed with the placeholder as its secret.
+func (h *ImportAppHandler) restoreSecrets(appID, stagingDir string) error {
+	existingDir := ""
+	if latest, err := h.VersionService.GetLatestAppRevision(appID); err == nil && latest != 0 {
+		existingDir = h.VersionService.GetRevisionDir(appID, latest)
+	}
+
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to read extracted archive: %w", err)
+	}
+	for _, entry := range entries {
+		revisionDir := filepath.Join(stagingDir, entry.Name())
+		cfg, _, err := orchestrator.ReadAppConfig(revisionDir)
+		if err != nil {
+			return fmt.Errorf("revision %s has no valid configuration: %w", entry.Name(), err)
+		}
+		if err := restoreVariables(cfg, revisionDir, existingDir); err != nil {
+			return fmt.Errorf("revision %s: %w", entry.Name(), err)
+		}
+		if err := restoreFiles(cfg, revisionDir, existingDir); err != nil {
+			return fmt.Errorf("revision %s: %w", entry.Name(), err)
+		}
+		if err := restoreHostSecrets(revisionDir, existingDir); err != nil {
+			return fmt.Errorf("revision %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// restoreVariables replaces the placeholders of encrypted variables in the values.json of revisionDir
+// with the values stored in existingDir.
+func restoreVariables(cfg *model.AppConfig, revisionDir, existingDir string) error {
+	valuesFile := filepath.Join(revisionDir, "vars", "values.json")
+	data, err := os.ReadFile(valuesFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read variables: %w", err)
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse variables: %w", err)
+	}
+
+	var existing map[string]interface{}
+	changed := false
+	for _, v := range cfg.Variables {
+		if !v.IsEncrypted || values[v.Name] != encryptedPlaceholder {
+			continue
+		}
+		if existing == nil && existingDir != "" {
+			if data, err := os.ReadFile(filepath.Join(existingDir, "vars", "values.json")); err == nil {
+				_ = json.Unmarshal(data, &existing)
+			}
+		}
+		value, ok := existing[v.Name]
+		if !ok || value == encryptedPlaceholder {
+			return fmt.Errorf("encrypted variable %s has no current value to replace the exported placeholder with", v.Name)
+		}
+		values[v.Name] = value
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	restored, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	if err := os.WriteFile(valuesFile, restored, 0o600); err != nil {
+		return fmt.Errorf("failed to write variables: %w", err)
+	}
+	return nil
+}
+
+// restoreFiles replaces encrypted files of revisionDir that hold the placeholder with the files stored in
+// existingDir.
+func restoreFiles(cfg *model.AppConfig, revisionDir, existingDir string) error {
+	for _, f := range cfg.Files {
+		if !f.IsEncrypted {
+			continue
+		}
+		name := filepath.FromSlash(strings.TrimLeft(path.Clean(filepath.ToSlash(f.Name)), "/"))
+		target := filepath.Join(revisionDir, "files", name)
+		if content, err := os.ReadFile(target); err != nil || string(content) != encryptedPlaceholder {
+			continue
+		}
+		if existingDir == "" {
+			return fmt.Errorf("encrypted file %s has no current content to replace the exported placeholder with", f.Name)
+		}
+		if err := util.CopyFile(filepath.Join(existingDir, "files", name), target); err != nil {
+			return fmt.Errorf("encrypted file %s has no current content to replace the exported placeholder with: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// restoreHostSecrets copies the host secret files of existingDir into revisionDir unless the revision
+// brings its own.
+func restoreHostSecrets(revisionDir, existingDir string) error {
+	if existingDir == "" {
+		return nil
+	}
+	src := filepath.Join(existingDir, "vars", "secrets")
+	dst := filepath.Join(revisionDir, "vars", "secrets")
+	if _, err := os.Stat(dst); err == nil {
+		return nil
+	}
+	if _, err := os.Stat(src); err != nil {
+		return nil
+	}
+	if err := util.CopyDirectory(src, dst); err != nil {
+		return fmt.Errorf("failed to copy host secrets: %w", err)
+	}
+	return nil
+}
+
+// isNameUnique checks that the provided application name is not used by any other application (except the current one).
+func (h *ImportAppHandler) isNameUnique(name, currentAppID string) (bool, error) {
+	entries, err := os.ReadDir(h.AppsTemplatesPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read apps templates directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		appID := entry.Name()
+		if appID == currentAppID {
+			// Re-importing an app over itself is allowed.
+			continue
+		}
+
+		latestVersion, err := h.VersionService.GetLatestAppRevision(appID)
+		if err != nil || latestVersion == 0 {
+			continue
+		}
+
+		cfg, _, err := orchestrator.ReadAppConfig(h.VersionService.GetRevisionDir(appID, latestVersion))
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(cfg.Name), strings.TrimSpace(name)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// NewImportAppHandler creates a new ImportAppHandler.
+func NewImportAppHandler(repository repository.AppRepository, appsTemplatesPath string, versionService app.RevisionServiceInterface) *ImportAppHandler {
+	return &ImportAppHandler{
+		repository:        repository,
+		AppsTemplatesPath: appsTemplatesPath,
+		VersionService:    versionService,
+	}
+}
@@ -0,0 +1,20 @@
+package reconcile_app
+
+// ReconcileAppCommand represents a command to reset an application to a clean state: its rendered directory
+// is deleted and the latest revision is rendered from scratch and redeployed.
+type ReconcileAppCommand struct {
+	AppID string
+	// Result, when set, receives the outcome of a successful reconciliation.
+	Result *ReconcileAppResult
+}
+
+// ReconcileAppResult describes the outcome of a ReconcileAppCommand.
+type ReconcileAppResult struct {
+	// Drifted reports whether the rendered directory differed from the templates of the latest revision.
+	Drifted bool
+}
+
+// Name returns the name of the command
+func (c ReconcileAppCommand) Name() string {
+	return "ReconcileApp"
+}
@@ -0,0 +1,50 @@
+package reconcile_app
+
+import (
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// ReconcileAppHandler handles the ReconcileAppCommand
+type ReconcileAppHandler struct {
+	reconciler     repository.AppReconciler
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the ReconcileAppCommand
+func (h *ReconcileAppHandler) Handle(cmd ReconcileAppCommand) error {
+	log.Debug("Processing reconcile app request", "app_id", cmd.AppID)
+
+	// Validate the app ID
+	if cmd.AppID == "" {
+		return log.Errorf("app ID is required for reconcile app command")
+	}
+
+	latest, err := h.VersionService.GetLatestAppRevision(cmd.AppID)
+	if err != nil {
+		return log.Errorf("failed to determine latest version for app %s: %w", cmd.AppID, err)
+	}
+	if latest == 0 {
+		return log.Errorf("no versions found for app %s", cmd.AppID)
+	}
+
+	drifted, err := h.reconciler.ReconcileApp(cmd.AppID)
+	if err != nil {
+		return log.Errorf("command failed with error: %v", err)
+	}
+	if cmd.Result != nil {
+		cmd.Result.Drifted = drifted
+	}
+
+	log.Info("Successfully reconciled app", "app_id", cmd.AppID, "revision", latest, "drifted", drifted)
+	return nil
+}
+
+// NewReconcileAppHandler creates a new ReconcileAppHandler
+func NewReconcileAppHandler(reconciler repository.AppReconciler, versionService app.RevisionServiceInterface) *ReconcileAppHandler {
+	return &ReconcileAppHandler{
+		reconciler:     reconciler,
+		VersionService: versionService,
+	}
+}
@@ -3,11 +3,10 @@ package rename_app
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
-	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/domain/repository"
 	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/internal/infra/orchestrator"
 	"winterflow-agent/pkg/log"
 )
 
@@ -38,19 +37,19 @@ func (h *RenameAppHandler) Handle(cmd RenameAppCommand) error {
 		return err
 	}
 	if !unique {
-		return log.Errorf("application name is already in use by another app", "app_name", newName)
+		return log.Errorf("application name %q is already in use by another app", newName)
 	}
 
 	// Create a new revision
 	newVersion, err := h.VersionService.CreateRevision(appID)
 	if err != nil {
-		return log.Errorf("failed to create new revision for app", "app_id", appID, "error", err)
+		return log.Errorf("failed to create new revision for app %s: %v", appID, err)
 	}
 	log.Debug("Created new revision for app", "app_id", appID, "new_revision", newVersion)
 
 	// Re-Deploy the new revision
 	if err := h.repository.RenameApp(appID, newName); err != nil {
-		return log.Errorf("repository rename failed", "error", err)
+		return log.Errorf("repository rename failed: %v", err)
 	}
 
 	log.Info("Successfully renamed app", "app_id", appID, "new_name", newName, "new_revision", newVersion)
@@ -80,14 +79,9 @@ func (h *RenameAppHandler) isNameUnique(name, currentAppID string) (bool, error)
 			continue
 		}
 
-		cfgPath := filepath.Join(h.VersionService.GetRevisionDir(appID, latestVersion), "config.json")
-		data, err := os.ReadFile(cfgPath)
-		if err != nil {
-			// Ignore missing or unreadable config files.
-			continue
-		}
-		cfg, err := model.ParseAppConfig(data)
+		cfg, _, err := orchestrator.ReadAppConfig(h.VersionService.GetRevisionDir(appID, latestVersion))
 		if err != nil {
+			// Ignore missing, unreadable or invalid config files.
 			continue
 		}
 		if strings.EqualFold(strings.TrimSpace(cfg.Name), strings.TrimSpace(name)) {
@@ -0,0 +1,52 @@
+package rename_app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+// renamingRepository records the renames of apps.
+type renamingRepository struct {
+	repository.AppRepository
+	renamed map[string]string
+}
+
+func (r *renamingRepository) RenameApp(appID, newName string) error {
+	r.renamed[appID] = newName
+	return nil
+}
+
+// writeYAMLRevision writes revision 1 of appID with a hand-edited YAML configuration.
+func writeYAMLRevision(t *testing.T, cfg *config.Config, appID, name string) {
+	t.Helper()
+	revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), appID, "1")
+	if err := os.MkdirAll(filepath.Join(revisionDir, "vars"), 0o755); err != nil {
+		t.Fatalf("Failed to create revision directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(revisionDir, "config.yaml"), []byte("id: "+appID+"\nname: "+name+"\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write config.yaml: %v", err)
+	}
+}
+
+func TestRenameAppChecksNamesOfYAMLConfigs(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeYAMLRevision(t, cfg, "app", "demo")
+	writeYAMLRevision(t, cfg, "other", "taken")
+	repo := &renamingRepository{renamed: map[string]string{}}
+	h := NewRenameAppHandler(repo, cfg.GetAppsTemplatesPath(), app.NewRevisionService(cfg))
+
+	if err := h.Handle(RenameAppCommand{AppID: "app", AppName: "Taken"}); err == nil {
+		t.Error("Expected the name of the other app's YAML config to be rejected")
+	}
+	if err := h.Handle(RenameAppCommand{AppID: "app", AppName: "fresh"}); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if repo.renamed["app"] != "fresh" {
+		t.Errorf("Expected the app to be renamed, got %v", repo.renamed)
+	}
+}
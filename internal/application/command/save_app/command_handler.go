@@ -9,11 +9,13 @@ import (
 	"strings"
 	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/internal/infra/orchestrator"
 	"winterflow-agent/pkg/certs"
 	"winterflow-agent/pkg/log"
+	"winterflow-agent/pkg/yaml"
 )
 
-// Default filesystem permissions. Adjust here if deployment requirements change.
+// Default filesystem permissions, used when the handler does not configure its own.
 const (
 	dirPerm           = 0o755 // default directory permission
 	filePerm          = 0o644 // default file permission (non-sensitive)
@@ -23,10 +25,43 @@ const (
 // SaveAppHandler handles the SaveAppCommand
 type SaveAppHandler struct {
 	AppsTemplatesPath string
-	PrivateKeyPath    string
+	// PrivateKeyPaths are the private keys tried in order to decrypt encrypted files and variables.
+	PrivateKeyPaths []string
+	// BestEffortDecryption stores encrypted files and variables that cannot be decrypted as received
+	// instead of failing the save.
+	BestEffortDecryption bool
+	// DirPerm, FilePerm and SensitiveFilePerm are the permissions of the directories, files and files that
+	// may contain secrets written for a revision. The defaults are used when zero.
+	DirPerm           os.FileMode
+	FilePerm          os.FileMode
+	SensitiveFilePerm os.FileMode
 	revisionService   app.RevisionServiceInterface
 }
 
+// dirMode returns the permission of the directories written for a revision.
+func (h *SaveAppHandler) dirMode() os.FileMode {
+	if h.DirPerm == 0 {
+		return dirPerm
+	}
+	return h.DirPerm
+}
+
+// fileMode returns the permission of the non-sensitive files written for a revision.
+func (h *SaveAppHandler) fileMode() os.FileMode {
+	if h.FilePerm == 0 {
+		return filePerm
+	}
+	return h.FilePerm
+}
+
+// sensitiveFileMode returns the permission of the files that may contain secrets.
+func (h *SaveAppHandler) sensitiveFileMode() os.FileMode {
+	if h.SensitiveFilePerm == 0 {
+		return sensitiveFilePerm
+	}
+	return h.SensitiveFilePerm
+}
+
 // Handle executes the SaveAppCommand
 func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 	if cmd.App == nil {
@@ -36,13 +71,18 @@ func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 
 	log.Info("Processing save app request", "app_id", app.ID)
 
+	// Reject invalid overrides before anything is written to disk
+	if err := validateComposeOverride(app.Config, app.ComposeOverride); err != nil {
+		return err
+	}
+
 	// Ensure the base directory for the application exists. This is required so that subsequent
 	// operations (like reading a previous config or creating revision directories) do not fail
 	// due to a missing parent path.
 	baseDir := filepath.Join(h.AppsTemplatesPath, app.ID)
 	isAppExists := false
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(baseDir, dirPerm); err != nil {
+		if err := ensureDir(baseDir, h.dirMode()); err != nil {
 			return fmt.Errorf("error creating base directory %s: %w", baseDir, err)
 		}
 	} else {
@@ -62,16 +102,31 @@ func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 	revisionDir := h.revisionService.GetRevisionDir(app.ID, newRevision)
 	log.Debug("Created new revision", "revision", newRevision, "app_id", app.ID)
 
-	existingCfgPath := filepath.Join(revisionDir, "config.json")
-	var prevFiles []model.AppFile
-	if data, err := os.ReadFile(existingCfgPath); err == nil {
-		if existingCfg, err := model.ParseAppConfig(data); err == nil {
-			if isAppExists && existingCfg.Name != "" {
-				// keep the old name
-				app.Config.Name = strings.TrimSpace(existingCfg.Name)
+	// Drop the new revision if the save fails so that a half-written revision is never deployed.
+	saved := false
+	defer func() {
+		if saved {
+			return
+		}
+		if !isAppExists {
+			if err := os.RemoveAll(baseDir); err != nil {
+				log.Warn("Failed to remove app after failed save", "app_id", app.ID, "error", err)
 			}
-			prevFiles = existingCfg.Files
+			return
 		}
+		if err := h.revisionService.DeleteAppRevision(app.ID, newRevision); err != nil {
+			log.Warn("Failed to remove revision after failed save", "app_id", app.ID, "revision", newRevision, "error", err)
+		}
+	}()
+
+	var prevFiles []model.AppFile
+	existingCfg, existingCfgPath, err := orchestrator.ReadAppConfig(revisionDir)
+	if err == nil {
+		if isAppExists && existingCfg.Name != "" {
+			// keep the old name
+			app.Config.Name = strings.TrimSpace(existingCfg.Name)
+		}
+		prevFiles = existingCfg.Files
 	}
 
 	// Validate that the (possibly overridden) application name is provided and unique
@@ -96,13 +151,13 @@ func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 
 	// 1. Ensure directory structure exists
 	for _, d := range dirs {
-		if err := os.MkdirAll(d, 0o755); err != nil {
+		if err := ensureDir(d, h.dirMode()); err != nil {
 			return fmt.Errorf("error creating directory %s: %w", d, err)
 		}
 	}
 
 	// 2. Persist config.json
-	if err := h.writeConfig(dirs["revision"], app.Config); err != nil {
+	if err := h.writeConfig(dirs["revision"], app.Config, existingCfgPath); err != nil {
 		return err
 	}
 
@@ -111,12 +166,24 @@ func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 		return err
 	}
 
+	// 3b. Write server supplied compose override next to the templates
+	if err := h.writeComposeOverride(dirs["files"], app.ComposeOverride); err != nil {
+		return err
+	}
+
 	// 4. Write vars JSON file (secrets are stored together with regular variables)
 	if err := h.writeVars(dirs["vars"], app.Config, app.Variables); err != nil {
 		return err
 	}
 
-	// 5. Clean up old revisions if we have a revision service
+	// 5. Trial render the YAML templates so that templates that cannot be deployed are rejected
+	if err := validateRenderedTemplates(dirs["files"], dirs["vars"]); err != nil {
+		return err
+	}
+
+	saved = true
+
+	// 6. Clean up old revisions if we have a revision service
 	if err := h.revisionService.DeleteOldRevisions(app.ID); err != nil {
 		log.Warn("Failed to clean up old revisions", "app_id", app.ID, "error", err)
 		// Don't fail the save operation if cleanup fails
@@ -127,16 +194,22 @@ func (h *SaveAppHandler) Handle(cmd SaveAppCommand) error {
 	return nil
 }
 
-// writeConfig marshals the AppConfig and writes it to config.json inside revisionDir.
-func (h *SaveAppHandler) writeConfig(revisionDir string, cfg *model.AppConfig) error {
-	configPath := filepath.Join(revisionDir, "config.json")
+// writeConfig marshals the AppConfig and writes it to config.json inside revisionDir. A hand-edited
+// YAML configuration at replacedPath, copied over from the previous revision, is removed.
+func (h *SaveAppHandler) writeConfig(revisionDir string, cfg *model.AppConfig, replacedPath string) error {
+	configPath := filepath.Join(revisionDir, model.AppConfigFileNames[0])
 	data, err := json.Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("error marshaling app config: %w", err)
 	}
-	if err := os.WriteFile(configPath, data, filePerm); err != nil {
+	if err := writeFile(configPath, data, h.fileMode()); err != nil {
 		return fmt.Errorf("error writing config file: %w", err)
 	}
+	if replacedPath != configPath {
+		if err := os.Remove(replacedPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing replaced config file: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -191,7 +264,7 @@ func (h *SaveAppHandler) syncTemplates(templatesDir string, cfg *model.AppConfig
 		newPath := filepath.Join(templatesDir, newRel)
 
 		// Ensure target directory.
-		if err := os.MkdirAll(filepath.Dir(newPath), dirPerm); err != nil {
+		if err := ensureDir(filepath.Dir(newPath), h.dirMode()); err != nil {
 			return fmt.Errorf("error creating directories for %s: %w", newPath, err)
 		}
 
@@ -202,14 +275,15 @@ func (h *SaveAppHandler) syncTemplates(templatesDir string, cfg *model.AppConfig
 			continue
 		}
 
-		mode := filePerm
+		mode := h.fileMode()
 		if newMeta.IsEncrypted {
-			mode = sensitiveFilePerm
+			mode = h.sensitiveFileMode()
 		}
-		if err := os.WriteFile(newPath, data, os.FileMode(mode)); err != nil {
+		written, err := writeFileIfChanged(newPath, data, mode)
+		if err != nil {
 			return fmt.Errorf("error writing renamed template %s: %w", newPath, err)
 		}
-		log.Debug("Copied template for rename", "source_path", oldPath, "target_path", newPath)
+		log.Debug("Copied template for rename", "source_path", oldPath, "target_path", newPath, "changed", written)
 	}
 
 	// ---------------------------------------------------------------------
@@ -277,7 +351,7 @@ func (h *SaveAppHandler) syncTemplates(templatesDir string, cfg *model.AppConfig
 		targetPath := filepath.Join(templatesDir, relFilename)
 
 		// Ensure the directory for the file exists.
-		if err := os.MkdirAll(filepath.Dir(targetPath), dirPerm); err != nil {
+		if err := ensureDir(filepath.Dir(targetPath), h.dirMode()); err != nil {
 			return fmt.Errorf("error creating directories for %s: %w", targetPath, err)
 		}
 
@@ -292,7 +366,7 @@ func (h *SaveAppHandler) syncTemplates(templatesDir string, cfg *model.AppConfig
 				}
 
 				// New file with placeholder – create an empty stub so that path exists on disk.
-				if err := os.WriteFile(targetPath, []byte("<encrypted>"), sensitiveFilePerm); err != nil {
+				if err := writeFile(targetPath, []byte("<encrypted>"), h.sensitiveFileMode()); err != nil {
 					return fmt.Errorf("error writing placeholder template %s: %w", targetPath, err)
 				}
 				log.Debug("Created placeholder for new encrypted file", "file_path", targetPath)
@@ -300,31 +374,76 @@ func (h *SaveAppHandler) syncTemplates(templatesDir string, cfg *model.AppConfig
 			}
 
 			plaintext := content
-			if h.PrivateKeyPath != "" {
-				if dec, err := certs.DecryptWithPrivateKey(h.PrivateKeyPath, string(content)); err == nil {
-					plaintext = []byte(dec)
-				} else {
-					log.Warn("Failed to decrypt file", "filename", fileMeta.Name, "error", err)
+			if len(h.PrivateKeyPaths) > 0 {
+				dec, err := h.decrypt("file", fileMeta.Name, string(content))
+				if err != nil {
+					return err
 				}
+				plaintext = []byte(dec)
 			}
 
-			if err := os.WriteFile(targetPath, plaintext, sensitiveFilePerm); err != nil {
+			written, err := writeFileIfChanged(targetPath, plaintext, h.sensitiveFileMode())
+			if err != nil {
 				return fmt.Errorf("error writing template %s: %w", targetPath, err)
 			}
-			log.Debug("Wrote decrypted template", "file_path", targetPath)
+			if written {
+				log.Debug("Wrote decrypted template", "file_path", targetPath)
+			} else {
+				log.Debug("Decrypted template unchanged, skipping write", "file_path", targetPath)
+			}
 			continue
 		}
 
-		// Non-encrypted files – write content as-is.
-		if err := os.WriteFile(targetPath, content, filePerm); err != nil {
+		// Non-encrypted files – write content as-is unless it is already on disk.
+		written, err := writeFileIfChanged(targetPath, content, h.fileMode())
+		if err != nil {
 			return fmt.Errorf("error writing template %s: %w", targetPath, err)
 		}
-		log.Debug("Wrote template", "file_path", targetPath)
+		if written {
+			log.Debug("Wrote template", "file_path", targetPath)
+		} else {
+			log.Debug("Template unchanged, skipping write", "file_path", targetPath)
+		}
 	}
 
 	return nil
 }
 
+// validateComposeOverride ensures a server supplied override is valid YAML and
+// does not clash with a template file of the same name.
+func validateComposeOverride(cfg *model.AppConfig, override []byte) error {
+	if len(override) == 0 {
+		return nil
+	}
+	if cfg != nil {
+		for _, f := range cfg.Files {
+			if rel, err := sanitizeTemplateFilename(f.Name); err == nil && rel == model.ComposeOverrideFileName {
+				return fmt.Errorf("compose override conflicts with template file %s", f.Name)
+			}
+		}
+	}
+	if _, err := yaml.UnmarshalMap(override); err != nil {
+		return fmt.Errorf("invalid compose override: %w", err)
+	}
+	return nil
+}
+
+// writeComposeOverride writes the compose override into the templates directory so
+// that it is rendered together with the other files. An empty override leaves the
+// directory untouched; any override copied from the previous revision has already
+// been removed by syncTemplates.
+func (h *SaveAppHandler) writeComposeOverride(templatesDir string, override []byte) error {
+	if len(override) == 0 {
+		return nil
+	}
+	targetPath := filepath.Join(templatesDir, model.ComposeOverrideFileName)
+	if err := writeFile(targetPath, override, h.fileMode()); err != nil {
+		return fmt.Errorf("error writing compose override %s: %w", targetPath, err)
+	}
+	log.Debug("Wrote compose override", "file_path", targetPath)
+	return nil
+}
+
 // writeVars writes all variables (including encrypted ones) into vars/values.json.
 func (h *SaveAppHandler) writeVars(varsDir string, cfg *model.AppConfig, input model.VariableMap) error {
 	varsFile := filepath.Join(varsDir, "values.json")
@@ -351,24 +470,19 @@ func (h *SaveAppHandler) writeVars(varsDir string, cfg *model.AppConfig, input m
 		// Handle encrypted variables.
 		if v.IsEncrypted {
 			if value == "<encrypted>" {
-				// Preserve existing value (if any) or use empty string to keep key present.
-				if existing, ok := existingVars[v.Name]; ok {
-					vars[v.Name] = existing
-				} else {
-					vars[v.Name] = ""
-				}
-			} else {
-				vars[v.Name] = value
+				// Preserve existing (already decrypted) value if any or use empty string to keep key present.
+				vars[v.Name] = existingVars[v.Name]
+				continue
 			}
 
-			// Attempt to decrypt before storing so the consumer gets plain text.
-			if h.PrivateKeyPath != "" && vars[v.Name] != "" {
-				dec, err := certs.DecryptWithPrivateKey(h.PrivateKeyPath, vars[v.Name])
-				if err == nil {
-					vars[v.Name] = dec
-				} else {
-					log.Warn("Failed to decrypt variable", "variable_name", v.Name, "error", err)
+			vars[v.Name] = value
+			// Decrypt before storing so the consumer gets plain text.
+			if len(h.PrivateKeyPaths) > 0 && value != "" {
+				dec, err := h.decrypt("variable", v.Name, value)
+				if err != nil {
+					return err
 				}
+				vars[v.Name] = dec
 			}
 		} else {
 			// Plain variable, just store the provided value.
@@ -381,13 +495,29 @@ func (h *SaveAppHandler) writeVars(varsDir string, cfg *model.AppConfig, input m
 	if err != nil {
 		return fmt.Errorf("error marshaling vars JSON: %w", err)
 	}
-	if err := os.WriteFile(varsFile, j, sensitiveFilePerm); err != nil {
+	if err := writeFile(varsFile, j, h.sensitiveFileMode()); err != nil {
 		return fmt.Errorf("error writing vars file: %w", err)
 	}
 
 	return nil
 }
 
+// decrypt decrypts value of the named encrypted file or variable with the first of PrivateKeyPaths that
+// succeeds. Values that
+// cannot be decrypted fail the save, unless BestEffortDecryption is set in which case they are returned
+// unchanged.
+func (h *SaveAppHandler) decrypt(kind, name, value string) (string, error) {
+	dec, err := certs.DecryptWithPrivateKeys(h.PrivateKeyPaths, value)
+	if err == nil {
+		return dec, nil
+	}
+	if h.BestEffortDecryption {
+		log.Warn("Failed to decrypt value, storing it as received", "kind", kind, "name", name, "error", err)
+		return value, nil
+	}
+	return "", fmt.Errorf("failed to decrypt %s %s: %w", kind, name, err)
+}
+
 // isNameUnique checks that the given application name is not used by any other application (different appID).
 func (h *SaveAppHandler) isNameUnique(name string, currentAppID string) (bool, error) {
 	entries, err := os.ReadDir(h.AppsTemplatesPath)
@@ -417,15 +547,9 @@ func (h *SaveAppHandler) isNameUnique(name string, currentAppID string) (bool, e
 			continue
 		}
 
-		cfgPath := filepath.Join(h.revisionService.GetRevisionDir(appID, latestRevision), "config.json")
-		data, err := os.ReadFile(cfgPath)
-		if err != nil {
-			continue // ignore missing configs or read errors – not critical for uniqueness check
-		}
-
-		cfg, err := model.ParseAppConfig(data)
+		cfg, _, err := orchestrator.ReadAppConfig(h.revisionService.GetRevisionDir(appID, latestRevision))
 		if err != nil {
-			continue // skip invalid configs
+			continue // ignore missing or invalid configs – not critical for uniqueness check
 		}
 
 		if strings.EqualFold(strings.TrimSpace(cfg.Name), strings.TrimSpace(name)) {
@@ -464,10 +588,11 @@ type SaveAppResult struct {
 }
 
 // NewSaveAppHandler creates a new SaveAppHandler
-func NewSaveAppHandler(appsTemplatesPath, privateKeyPath string, revisionService app.RevisionServiceInterface) *SaveAppHandler {
+func NewSaveAppHandler(appsTemplatesPath string, privateKeyPaths []string, bestEffortDecryption bool, revisionService app.RevisionServiceInterface) *SaveAppHandler {
 	return &SaveAppHandler{
-		AppsTemplatesPath: appsTemplatesPath,
-		PrivateKeyPath:    privateKeyPath,
-		revisionService:   revisionService,
+		AppsTemplatesPath:    appsTemplatesPath,
+		PrivateKeyPaths:      privateKeyPaths,
+		BestEffortDecryption: bestEffortDecryption,
+		revisionService:      revisionService,
 	}
 }
@@ -0,0 +1,466 @@
+package save_app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/certs"
+)
+
+func TestValidateComposeOverride(t *testing.T) {
+	cfg := &model.AppConfig{Files: []model.AppFile{{ID: "1", Name: "compose.yml"}}}
+
+	if err := validateComposeOverride(cfg, nil); err != nil {
+		t.Errorf("Expected empty override to be accepted, got %v", err)
+	}
+
+	valid := []byte("services:\n  web:\n    deploy:\n      resources:\n        limits:\n          memory: 512M\n")
+	if err := validateComposeOverride(cfg, valid); err != nil {
+		t.Errorf("Expected valid override to be accepted, got %v", err)
+	}
+
+	invalid := []byte("services:\n  web:\n\tdeploy: {}\n")
+	if err := validateComposeOverride(cfg, invalid); err == nil {
+		t.Error("Expected invalid YAML override to be rejected")
+	}
+
+	notMapping := []byte("- web\n- db\n")
+	if err := validateComposeOverride(cfg, notMapping); err == nil {
+		t.Error("Expected non-mapping override to be rejected")
+	}
+
+	conflicting := &model.AppConfig{Files: []model.AppFile{{ID: "1", Name: "./compose.override.yml"}}}
+	if err := validateComposeOverride(conflicting, valid); err == nil {
+		t.Error("Expected override conflicting with a template file to be rejected")
+	}
+}
+
+func TestWriteComposeOverride(t *testing.T) {
+	templatesDir := t.TempDir()
+	h := &SaveAppHandler{}
+
+	content := []byte("services:\n  web:\n    restart: always\n")
+	if err := h.writeComposeOverride(templatesDir, content); err != nil {
+		t.Fatalf("writeComposeOverride returned error: %v", err)
+	}
+
+	written, err := os.ReadFile(filepath.Join(templatesDir, model.ComposeOverrideFileName))
+	if err != nil {
+		t.Fatalf("Failed to read override: %v", err)
+	}
+	if string(written) != string(content) {
+		t.Errorf("Expected %q, got %q", content, written)
+	}
+}
+
+func TestSyncTemplatesSkipsUnchangedFiles(t *testing.T) {
+	templatesDir := t.TempDir()
+	h := &SaveAppHandler{}
+
+	unchangedPath := filepath.Join(templatesDir, "docker-compose.yml")
+	changedPath := filepath.Join(templatesDir, "config", "app.conf")
+	if err := os.MkdirAll(filepath.Dir(changedPath), dirPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(unchangedPath, []byte("services: {}\n"), filePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(changedPath, []byte("old\n"), filePerm); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, path := range []string{unchangedPath, changedPath} {
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := []model.AppFile{
+		{ID: "compose", Name: "docker-compose.yml"},
+		{ID: "conf", Name: "config/app.conf"},
+	}
+	cfg := &model.AppConfig{Files: files}
+	contentMap := model.FilesMap{
+		"compose": []byte("services: {}\n"),
+		"conf":    []byte("new\n"),
+	}
+	if err := h.syncTemplates(templatesDir, cfg, files, contentMap); err != nil {
+		t.Fatalf("syncTemplates returned error: %v", err)
+	}
+
+	info, err := os.Stat(unchangedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Errorf("Expected unchanged template to keep mtime %v, got %v", past, info.ModTime())
+	}
+
+	info, err = os.Stat(changedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ModTime().Equal(past) {
+		t.Errorf("Expected changed template to be rewritten")
+	}
+	content, err := os.ReadFile(changedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new\n" {
+		t.Errorf("Expected %q, got %q", "new\n", content)
+	}
+}
+
+func TestWriteFileIfChangedUpdatesPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.env")
+	if err := os.WriteFile(path, []byte("KEY=value"), filePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := writeFileIfChanged(path, []byte("KEY=value"), sensitiveFilePerm)
+	if err != nil {
+		t.Fatalf("writeFileIfChanged returned error: %v", err)
+	}
+	if written {
+		t.Errorf("Expected identical content not to be written")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != sensitiveFilePerm {
+		t.Errorf("Expected mode %o, got %o", sensitiveFilePerm, info.Mode().Perm())
+	}
+}
+
+func TestHandleKeepsModificationTimeOfUnchangedFiles(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	h := NewSaveAppHandler(cfg.GetAppsTemplatesPath(), nil, false, app.NewRevisionService(cfg))
+	if err := os.MkdirAll(h.AppsTemplatesPath, dirPerm); err != nil {
+		t.Fatal(err)
+	}
+	save := func(conf string) {
+		t.Helper()
+		err := h.Handle(SaveAppCommand{App: &model.App{
+			ID: "app",
+			Config: &model.AppConfig{
+				Name:  "demo",
+				Files: []model.AppFile{{ID: "f1", Name: "compose.yml"}, {ID: "f2", Name: "app.conf"}},
+			},
+			Files: model.FilesMap{"f1": []byte("services: {}\n"), "f2": []byte(conf)},
+		}})
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	save("old\n")
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, name := range []string{"compose.yml", "app.conf"} {
+		if err := os.Chtimes(filepath.Join(h.revisionService.GetFilesDir("app", 1), name), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("new\n")
+
+	filesDir := h.revisionService.GetFilesDir("app", 2)
+	info, err := os.Stat(filepath.Join(filesDir, "compose.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Errorf("Expected the unchanged file to keep mtime %v, got %v", past, info.ModTime())
+	}
+	info, err = os.Stat(filepath.Join(filesDir, "app.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ModTime().Equal(past) {
+		t.Error("Expected the changed file to be rewritten")
+	}
+}
+
+// newDecryptingHandler returns a handler with a freshly generated agent private key.
+func newDecryptingHandler(t *testing.T, bestEffort bool) *SaveAppHandler {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "agent.key")
+	if err := certs.GeneratePrivateKey(keyPath); err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	return &SaveAppHandler{PrivateKeyPaths: []string{keyPath}, BestEffortDecryption: bestEffort}
+}
+
+const badCiphertext = "bm90LWEtdmFsaWQtcGF5bG9hZA=="
+
+func TestSyncTemplatesDecryptionFailure(t *testing.T) {
+	files := []model.AppFile{{ID: "key", Name: "certs/tls.key", IsEncrypted: true}}
+	cfg := &model.AppConfig{Files: files}
+	contentMap := model.FilesMap{"key": []byte(badCiphertext)}
+
+	t.Run("strict", func(t *testing.T) {
+		templatesDir := t.TempDir()
+		err := newDecryptingHandler(t, false).syncTemplates(templatesDir, cfg, nil, contentMap)
+		if err == nil || !strings.Contains(err.Error(), "failed to decrypt file certs/tls.key") {
+			t.Fatalf("Expected decryption error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(templatesDir, "certs", "tls.key")); !os.IsNotExist(err) {
+			t.Errorf("Expected undecryptable file not to be written, got %v", err)
+		}
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		templatesDir := t.TempDir()
+		if err := newDecryptingHandler(t, true).syncTemplates(templatesDir, cfg, nil, contentMap); err != nil {
+			t.Fatalf("syncTemplates returned error: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(templatesDir, "certs", "tls.key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != badCiphertext {
+			t.Errorf("Expected content stored as received, got %q", content)
+		}
+	})
+}
+
+func TestWriteVarsDecryptionFailure(t *testing.T) {
+	cfg := &model.AppConfig{Variables: []model.AppVariable{
+		{ID: "v1", Name: "DB_PASSWORD", IsEncrypted: true},
+		{ID: "v2", Name: "API_TOKEN", IsEncrypted: true},
+	}}
+
+	t.Run("strict", func(t *testing.T) {
+		varsDir := t.TempDir()
+		h := newDecryptingHandler(t, false)
+		err := h.writeVars(varsDir, cfg, model.VariableMap{"v1": badCiphertext})
+		if err == nil || !strings.Contains(err.Error(), "failed to decrypt variable DB_PASSWORD") {
+			t.Fatalf("Expected decryption error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(varsDir, "values.json")); !os.IsNotExist(err) {
+			t.Errorf("Expected values.json not to be written, got %v", err)
+		}
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		varsDir := t.TempDir()
+		h := newDecryptingHandler(t, true)
+		encrypted, err := certs.EncryptWithPrivateKey(h.PrivateKeyPaths[0], "t0ken")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.writeVars(varsDir, cfg, model.VariableMap{"v1": badCiphertext, "v2": encrypted}); err != nil {
+			t.Fatalf("writeVars returned error: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(varsDir, "values.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values map[string]string
+		if err := json.Unmarshal(data, &values); err != nil {
+			t.Fatal(err)
+		}
+		if values["DB_PASSWORD"] != badCiphertext || values["API_TOKEN"] != "t0ken" {
+			t.Errorf("Unexpected values %v", values)
+		}
+	})
+}
+
+func TestWriteVarsKeepsDecryptedValueForPlaceholder(t *testing.T) {
+	varsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(varsDir, "values.json"), []byte(`{"DB_PASSWORD":"s3cret"}`), sensitiveFilePerm); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &model.AppConfig{Variables: []model.AppVariable{{ID: "v1", Name: "DB_PASSWORD", IsEncrypted: true}}}
+
+	// The stored value is already decrypted and must not be decrypted again.
+	if err := newDecryptingHandler(t, false).writeVars(varsDir, cfg, model.VariableMap{"v1": "<encrypted>"}); err != nil {
+		t.Fatalf("writeVars returned error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(varsDir, "values.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"s3cret"`) {
+		t.Errorf("Expected the existing value to be kept, got %s", data)
+	}
+}
+
+func TestWriteVarsKeepsValuesIntactNextToAnInterruptedWrite(t *testing.T) {
+	varsDir := t.TempDir()
+	// A crash during a previous save left a partially written temporary file.
+	if err := os.WriteFile(filepath.Join(varsDir, "values.json.123456.tmp"), []byte(`{"DB_`), sensitiveFilePerm); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &model.AppConfig{Variables: []model.AppVariable{{ID: "v1", Name: "DB_HOST"}}}
+
+	if err := newDecryptingHandler(t, false).writeVars(varsDir, cfg, model.VariableMap{"v1": "db"}); err != nil {
+		t.Fatalf("writeVars returned error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(varsDir, "values.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"DB_HOST": "db"`) {
+		t.Errorf("Expected the values to be written, got %s", data)
+	}
+	matches, _ := filepath.Glob(filepath.Join(varsDir, "values.json.*.tmp"))
+	if len(matches) != 1 {
+		t.Errorf("Expected no temporary file besides the stale one, got %v", matches)
+	}
+}
+
+func TestHandleDropsRevisionWhenDecryptionFails(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	h := newDecryptingHandler(t, false)
+	h.AppsTemplatesPath = cfg.GetAppsTemplatesPath()
+	h.revisionService = app.NewRevisionService(cfg)
+	if err := os.MkdirAll(h.AppsTemplatesPath, dirPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	err := h.Handle(SaveAppCommand{App: &model.App{
+		ID: "app",
+		Config: &model.AppConfig{
+			Name:      "demo",
+			Variables: []model.AppVariable{{ID: "v1", Name: "DB_PASSWORD", IsEncrypted: true}},
+		},
+		Variables: model.VariableMap{"v1": badCiphertext},
+	}})
+	if err == nil {
+		t.Fatal("Expected Handle to fail")
+	}
+	if _, err := os.Stat(filepath.Join(h.AppsTemplatesPath, "app")); !os.IsNotExist(err) {
+		t.Errorf("Expected the app of the failed save to be removed, got %v", err)
+	}
+}
+
+func TestWriteVarsDecryptsWithRotatedKey(t *testing.T) {
+	current := newDecryptingHandler(t, false)
+	previous := newDecryptingHandler(t, false)
+	encrypted, err := certs.EncryptWithPrivateKey(previous.PrivateKeyPaths[0], "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &SaveAppHandler{PrivateKeyPaths: append(current.PrivateKeyPaths, previous.PrivateKeyPaths...)}
+	varsDir := t.TempDir()
+	cfg := &model.AppConfig{Variables: []model.AppVariable{{ID: "v1", Name: "DB_PASSWORD", IsEncrypted: true}}}
+	if err := h.writeVars(varsDir, cfg, model.VariableMap{"v1": encrypted}); err != nil {
+		t.Fatalf("writeVars returned error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(varsDir, "values.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"s3cret"`) {
+		t.Errorf("Expected the value decrypted with the second key, got %s", data)
+	}
+}
+
+func TestHandleAppliesConfiguredPermissions(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	h := NewSaveAppHandler(cfg.GetAppsTemplatesPath(), nil, false, app.NewRevisionService(cfg))
+	h.DirPerm, h.FilePerm, h.SensitiveFilePerm = 0o750, 0o640, 0o400
+	if err := os.MkdirAll(h.AppsTemplatesPath, dirPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	err := h.Handle(SaveAppCommand{App: &model.App{
+		ID: "app",
+		Config: &model.AppConfig{
+			Name: "demo",
+			Files: []model.AppFile{
+				{ID: "f1", Name: "conf/compose.yml"},
+				{ID: "f2", Name: "conf/secret.env", IsEncrypted: true},
+			},
+		},
+		Files: model.FilesMap{"f1": []byte("services: {}\n"), "f2": []byte("KEY=value")},
+	}})
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	revisionDir := h.revisionService.GetRevisionDir("app", 1)
+	filesDir := h.revisionService.GetFilesDir("app", 1)
+	expected := map[string]os.FileMode{
+		filepath.Join(h.AppsTemplatesPath, "app"): 0o750,
+		revisionDir:                                                          0o750,
+		h.revisionService.GetVarsDir("app", 1):                               0o750,
+		filepath.Join(filesDir, "conf"):                                      0o750,
+		filepath.Join(revisionDir, "config.json"):                            0o640,
+		filepath.Join(filesDir, "conf", "compose.yml"):                       0o640,
+		filepath.Join(filesDir, "conf", "secret.env"):                        0o400,
+		filepath.Join(h.revisionService.GetVarsDir("app", 1), "values.json"): 0o400,
+	}
+	for path, mode := range expected {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("Expected %s to exist: %v", path, err)
+			continue
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("Expected mode %o for %s, got %o", mode, path, info.Mode().Perm())
+		}
+	}
+}
+
+func TestHandleReplacesYAMLConfig(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	h := NewSaveAppHandler(cfg.GetAppsTemplatesPath(), nil, false, app.NewRevisionService(cfg))
+	for appID, content := range map[string]string{
+		"app":   "id: app\nname: demo\nfiles:\n  - {id: f1, name: old.yml, is_encrypted: false, type: template}\n",
+		"other": "id: other\nname: taken\n",
+	} {
+		revisionDir := h.revisionService.GetRevisionDir(appID, 1)
+		if err := os.MkdirAll(filepath.Join(revisionDir, "files"), dirPerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(revisionDir, "config.yaml"), []byte(content), filePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(h.revisionService.GetFilesDir("app", 1), "old.yml"), []byte("services: {}\n"), filePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	err := h.Handle(SaveAppCommand{App: &model.App{ID: "new", Config: &model.AppConfig{Name: "Taken"}}})
+	if err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("Expected the name of the other app's YAML config to be rejected, got %v", err)
+	}
+
+	err = h.Handle(SaveAppCommand{App: &model.App{
+		ID:     "app",
+		Config: &model.AppConfig{Name: "renamed", Files: []model.AppFile{{ID: "f1", Name: "compose.yml"}}},
+		Files:  model.FilesMap{"f1": []byte("services: {}\n")},
+	}})
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	revisionDir := h.revisionService.GetRevisionDir("app", 2)
+	if _, err := os.Stat(filepath.Join(revisionDir, "config.yaml")); !os.IsNotExist(err) {
+		t.Errorf("Expected the YAML config to be replaced, stat error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(revisionDir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved model.AppConfig
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Name != "demo" {
+		t.Errorf("Expected the name of the YAML config to be kept, got %q", saved.Name)
+	}
+	if _, err := os.Stat(filepath.Join(h.revisionService.GetFilesDir("app", 2), "old.yml")); !os.IsNotExist(err) {
+		t.Errorf("Expected the renamed file of the YAML config to be removed, stat error: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package save_app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"winterflow-agent/pkg/files"
+)
+
+// writeFileIfChanged writes content to path unless the file already holds the same content, so that
+// unchanged templates keep their modification time. The permissions of an unchanged file are still
+// updated to perm. It reports whether the file was written.
+func writeFileIfChanged(path string, content []byte, perm os.FileMode) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		if bytes.Equal(existing, content) {
+			if err := ensureFileMode(path, perm); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return false, err
+	}
+	// WriteFile keeps the mode of an existing file; apply perm explicitly.
+	if err := ensureFileMode(path, perm); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ensureFileMode sets the permissions of path to perm when they differ.
+func ensureFileMode(path string, perm os.FileMode) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm() == perm.Perm() {
+		return nil
+	}
+	return os.Chmod(path, perm)
+}
+
+// writeFile replaces path with content and perm, regardless of the umask and the mode of an existing file.
+// The file is replaced atomically so that a crash never leaves a partially written config or values file.
+func writeFile(path string, content []byte, perm os.FileMode) error {
+	return files.WriteFileAtomic(path, content, perm)
+}
+
+// ensureDir creates path and its parents and sets the permissions of path to perm. The permissions of
+// existing parents are left unchanged.
+func ensureDir(path string, perm os.FileMode) error {
+	if err := os.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	return ensureFileMode(path, perm)
+}
@@ -0,0 +1,92 @@
+package save_app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"winterflow-agent/pkg/template"
+	"winterflow-agent/pkg/yaml"
+)
+
+// validateRenderedTemplates renders every YAML file below filesDir with the variables stored in varsDir,
+// the way deployment does, and rejects the first file whose output can no longer be parsed as YAML, e.g.
+// because a value broke an anchor, a merge key or an `x-` extension. Other files are not inspected.
+func validateRenderedTemplates(filesDir, varsDir string) error {
+	vars, err := loadTrialVariables(varsDir)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(filesDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yml", ".yaml":
+		default:
+			return nil
+		}
+
+		relPath, err := filepath.Rel(filesDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to calculate relative path: %w", err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading template %s: %w", relPath, err)
+		}
+		rendered, err := template.Substitute(string(content), vars)
+		if err != nil {
+			return fmt.Errorf("template %s cannot be rendered: %w", relPath, err)
+		}
+		if err := yaml.Validate([]byte(rendered)); err != nil {
+			return fmt.Errorf("template %s does not render to valid YAML: %w", relPath, err)
+		}
+		return nil
+	})
+}
+
+// loadTrialVariables returns the variables a deployment renders with: values.json, then the
+// host-specific overrides.json, then one file per variable in secrets/.
+func loadTrialVariables(varsDir string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, name := range []string{"values.json", "overrides.json"} {
+		data, err := os.ReadFile(filepath.Join(varsDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		for key, value := range raw {
+			vars[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	secretsDir := filepath.Join(varsDir, "secrets")
+	entries, err := os.ReadDir(secretsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading secrets directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(secretsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret %s: %w", entry.Name(), err)
+		}
+		vars[entry.Name()] = strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+	}
+	return vars, nil
+}
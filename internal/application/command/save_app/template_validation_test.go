@@ -0,0 +1,123 @@
+package save_app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+const anchoredCompose = `x-logging: &logging
+  driver: json-file
+  options:
+    max-size: ${LOG_SIZE:-10m}
+
+x-service: &service
+  restart: unless-stopped
+  logging: *logging
+
+services:
+  web:
+    <<: *service
+    image: ${IMAGE}
+    environment:
+      - TITLE=${TITLE}
+  worker:
+    <<: [*service]
+    image: ${IMAGE}
+`
+
+func writeTrialRevision(t *testing.T, files map[string]string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), filePerm); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return filepath.Join(dir, "files"), filepath.Join(dir, "vars")
+}
+
+func TestValidateRenderedTemplatesAcceptsAnchorsAndMergeKeys(t *testing.T) {
+	filesDir, varsDir := writeTrialRevision(t, map[string]string{
+		"files/compose.yml":          anchoredCompose,
+		"files/conf/nginx.conf":      "server { listen ${PORT}: [\n",
+		"vars/values.json":           `{"IMAGE":"nginx:1.27","TITLE":"Hello: world"}`,
+		"vars/overrides.json":        `{"LOG_SIZE":"50m"}`,
+		"vars/secrets/UNUSED_SECRET": "s3cret\n",
+	})
+
+	if err := validateRenderedTemplates(filesDir, varsDir); err != nil {
+		t.Errorf("Expected the templates to be valid, got %v", err)
+	}
+}
+
+func TestValidateRenderedTemplatesRejectsBrokenOutput(t *testing.T) {
+	testCases := []struct {
+		name   string
+		values string
+		secret string
+		errMsg string
+	}{
+		{name: "value breaks flow collection", values: `{"IMAGE":"[nginx","TITLE":"x"}`, errMsg: "does not render to valid YAML"},
+		{name: "secret breaks flow collection", values: `{"IMAGE":"nginx","TITLE":"x"}`, secret: "{broken\n", errMsg: "does not render to valid YAML"},
+		{name: "mandatory variable unset", values: `{"IMAGE":"nginx"}`, errMsg: "cannot be rendered"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			files := map[string]string{
+				"files/compose.yml":         anchoredCompose,
+				"files/services/extra.yaml": "services:\n  extra:\n    image: ${IMAGE}\n    command: ${TITLE:?TITLE is required}\n",
+				"vars/values.json":          tc.values,
+			}
+			if tc.secret != "" {
+				files["vars/secrets/IMAGE"] = tc.secret
+			}
+			filesDir, varsDir := writeTrialRevision(t, files)
+
+			err := validateRenderedTemplates(filesDir, varsDir)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("Expected %q in error, got %v", tc.errMsg, err)
+			}
+			if !strings.Contains(err.Error(), "compose.yml") && !strings.Contains(err.Error(), filepath.Join("services", "extra.yaml")) {
+				t.Errorf("Expected the error to name the template, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHandleRejectsTemplatesThatDoNotRenderToYAML(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	h := NewSaveAppHandler(cfg.GetAppsTemplatesPath(), nil, false, app.NewRevisionService(cfg))
+	if err := os.MkdirAll(h.AppsTemplatesPath, dirPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	err := h.Handle(SaveAppCommand{App: &model.App{
+		ID: "app",
+		Config: &model.AppConfig{
+			Name:      "demo",
+			Files:     []model.AppFile{{ID: "f1", Name: "compose.yml", Type: model.ContentTypeTemplate}},
+			Variables: []model.AppVariable{{ID: "v1", Name: "IMAGE"}, {ID: "v2", Name: "TITLE"}},
+		},
+		Files:     model.FilesMap{"f1": []byte(anchoredCompose)},
+		Variables: model.VariableMap{"v1": "[nginx", "v2": "x"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "template compose.yml") {
+		t.Fatalf("Expected the broken template to be reported, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(h.AppsTemplatesPath, "app")); !os.IsNotExist(err) {
+		t.Errorf("Expected the app of the rejected save to be removed, got %v", err)
+	}
+}
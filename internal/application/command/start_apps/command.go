@@ -0,0 +1,28 @@
+package start_apps
+
+// StartAppsCommand represents a command to start several applications at once. Apps that list other apps in
+// depends_on_apps are started only once those are running and healthy; apps of the batch are ordered so that
+// they start after the apps of the batch they depend on.
+type StartAppsCommand struct {
+	AppIDs []string
+	// Result, when set, receives the outcome of every app of the batch, also when some failed to start.
+	Result *StartAppsResult
+}
+
+// StartAppsResult describes the outcome of a StartAppsCommand.
+type StartAppsResult struct {
+	// Apps holds the outcome of every app of the batch in the order the apps were started.
+	Apps []AppStartResult
+}
+
+// AppStartResult is the outcome of starting one app of a batch.
+type AppStartResult struct {
+	AppID string
+	// Err is the reason the app was not started; nil when it was started.
+	Err error
+}
+
+// Name returns the name of the command
+func (c StartAppsCommand) Name() string {
+	return "StartApps"
+}
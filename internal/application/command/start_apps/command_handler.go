@@ -0,0 +1,158 @@
+package start_apps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// defaultDependencyPollInterval is how often the status of a dependency is checked while waiting for it.
+const defaultDependencyPollInterval = 2 * time.Second
+
+// StartAppsHandler handles the StartAppsCommand
+type StartAppsHandler struct {
+	repository     repository.AppRepository
+	VersionService app.RevisionServiceInterface
+	config         *config.Config
+	pollInterval   time.Duration
+}
+
+// Handle executes the StartAppsCommand. The batch is rejected with ErrDependencyCycle before any app is
+// started when its apps depend on each other in a cycle. An app whose dependency failed to start or did not
+// become healthy within the health wait timeout is not started; the other apps are.
+func (h *StartAppsHandler) Handle(cmd StartAppsCommand) error {
+	log.Debug("Processing start apps request", "app_ids", cmd.AppIDs)
+
+	if len(cmd.AppIDs) == 0 {
+		return log.Errorf("app IDs are required for start apps command")
+	}
+
+	dependencies := make(map[string][]string, len(cmd.AppIDs))
+	for _, appID := range cmd.AppIDs {
+		if appID == "" {
+			return log.Errorf("app ID is required for start apps command")
+		}
+		appConfig, err := h.latestAppConfig(appID)
+		if err != nil {
+			return log.Errorf("failed to load app config of app %s: %w", appID, err)
+		}
+		dependencies[appID] = appConfig.DependsOnApps
+	}
+
+	order, err := startOrder(cmd.AppIDs, dependencies)
+	if err != nil {
+		return log.Errorf("cannot order the apps: %w", err)
+	}
+	log.Info("Starting apps in dependency order", "order", order)
+
+	failed := make(map[string]error, len(order))
+	healthy := make(map[string]bool, len(order))
+	results := make([]AppStartResult, 0, len(order))
+	var errs []error
+	for _, appID := range order {
+		err := h.startApp(appID, dependencies[appID], failed, healthy)
+		if err != nil {
+			log.Warn("Failed to start app of the batch", "app_id", appID, "error", err)
+			failed[appID] = err
+			errs = append(errs, fmt.Errorf("app %s: %w", appID, err))
+		}
+		results = append(results, AppStartResult{AppID: appID, Err: err})
+	}
+	if cmd.Result != nil {
+		cmd.Result.Apps = results
+	}
+
+	if len(errs) > 0 {
+		return log.Errorf("failed to start %d of %d apps: %w", len(errs), len(order), errors.Join(errs...))
+	}
+	log.Info("Successfully started apps", "order", order)
+	return nil
+}
+
+// startApp starts appID once each of its dependencies is healthy. Dependencies that failed to start earlier
+// in the batch are not waited for.
+func (h *StartAppsHandler) startApp(appID string, dependencies []string, failed map[string]error, healthy map[string]bool) error {
+	for _, dependency := range dependencies {
+		if err, ok := failed[dependency]; ok {
+			return fmt.Errorf("dependency %s was not started: %w", dependency, err)
+		}
+		if healthy[dependency] {
+			continue
+		}
+		if err := h.waitForHealthy(dependency); err != nil {
+			return fmt.Errorf("dependency %s is not healthy: %w", dependency, err)
+		}
+		healthy[dependency] = true
+	}
+	return h.repository.StartApp(appID)
+}
+
+// waitForHealthy polls the status of the app until all of its containers are running, for at most the health
+// wait timeout.
+func (h *StartAppsHandler) waitForHealthy(appID string) error {
+	interval := h.pollInterval
+	if interval <= 0 {
+		interval = defaultDependencyPollInterval
+	}
+	timeout := h.config.GetHealthWaitTimeout()
+	deadline := time.Now().Add(timeout)
+
+	log.Info("Waiting for dependency to become healthy", "app_id", appID, "timeout", timeout)
+	for {
+		status, err := h.repository.GetAppStatus(appID)
+		code := model.ContainerStatusUnknown
+		if err == nil && status.App != nil {
+			code = status.App.StatusCode
+		}
+		if code == model.ContainerStatusActive {
+			return nil
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			if err != nil {
+				return fmt.Errorf("status unknown after %s: %w", timeout, err)
+			}
+			return fmt.Errorf("still %s after %s", code, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// latestAppConfig reads the app configuration of the latest revision of the app.
+func (h *StartAppsHandler) latestAppConfig(appID string) (*model.AppConfig, error) {
+	latest, err := h.VersionService.GetLatestAppRevision(appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine latest version: %w", err)
+	}
+	if latest == 0 {
+		return nil, fmt.Errorf("no versions found for app %s", appID)
+	}
+
+	versionDir := h.VersionService.GetRevisionDir(appID, latest)
+	for _, name := range model.AppConfigFileNames {
+		configBytes, err := os.ReadFile(filepath.Join(versionDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading app config: %w", err)
+		}
+		return model.ParseAppConfigFile(name, configBytes)
+	}
+	return nil, fmt.Errorf("error reading app config: %w", os.ErrNotExist)
+}
+
+// NewStartAppsHandler creates a new StartAppsHandler
+func NewStartAppsHandler(repository repository.AppRepository, versionService app.RevisionServiceInterface, config *config.Config) *StartAppsHandler {
+	return &StartAppsHandler{
+		repository:     repository,
+		VersionService: versionService,
+		config:         config,
+	}
+}
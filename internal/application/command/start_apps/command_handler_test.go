@@ -0,0 +1,133 @@
+package start_apps
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+// fakeRepository records the started apps and reports them active once the given number of status checks
+// after their start passed.
+type fakeRepository struct {
+	repository.AppRepository
+	started   []string
+	failing   map[string]bool
+	active    map[string]bool
+	checks    map[string]int
+	warmupFor int
+}
+
+func (r *fakeRepository) StartApp(appID string) error {
+	if r.failing[appID] {
+		return errors.New("compose up failed")
+	}
+	r.started = append(r.started, appID)
+	r.active[appID] = true
+	return nil
+}
+
+func (r *fakeRepository) GetAppStatus(appID string) (model.GetAppStatusResult, error) {
+	code := model.ContainerStatusStopped
+	if r.active[appID] {
+		r.checks[appID]++
+		code = model.ContainerStatusIdle
+		if r.checks[appID] > r.warmupFor {
+			code = model.ContainerStatusActive
+		}
+	}
+	return model.GetAppStatusResult{App: &model.ContainerApp{ID: appID, StatusCode: code}}, nil
+}
+
+// newHandler returns a handler for apps with a revision whose config depends on the listed apps.
+func newHandler(t *testing.T, apps map[string][]string) (*StartAppsHandler, *fakeRepository) {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir(), HealthWaitTimeout: 1}
+	for appID, dependencies := range apps {
+		revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), appID, "1")
+		if err := os.MkdirAll(revisionDir, 0o755); err != nil {
+			t.Fatalf("Failed to create revision directory: %v", err)
+		}
+		dependsOn := `"` + strings.Join(dependencies, `","`) + `"`
+		if len(dependencies) == 0 {
+			dependsOn = ""
+		}
+		content := `{"id":"` + appID + `","name":"` + appID + `","files":[],"variables":[],"depends_on_apps":[` + dependsOn + `]}`
+		if err := os.WriteFile(filepath.Join(revisionDir, "config.json"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config.json: %v", err)
+		}
+	}
+
+	repo := &fakeRepository{failing: map[string]bool{}, active: map[string]bool{}, checks: map[string]int{}}
+	h := NewStartAppsHandler(repo, app.NewRevisionService(cfg), cfg)
+	h.pollInterval = time.Millisecond
+	return h, repo
+}
+
+func TestStartAppsStartsDependenciesFirst(t *testing.T) {
+	h, repo := newHandler(t, map[string][]string{"web": {"api"}, "api": {"db"}, "db": nil})
+	repo.warmupFor = 2
+
+	result := &StartAppsResult{}
+	if err := h.Handle(StartAppsCommand{AppIDs: []string{"web", "api", "db"}, Result: result}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"db", "api", "web"}; !reflect.DeepEqual(repo.started, expected) {
+		t.Errorf("Expected the apps to start in order %v, got %v", expected, repo.started)
+	}
+	if repo.checks["db"] != 3 || repo.checks["api"] != 3 {
+		t.Errorf("Expected the dependencies to be waited for until they are active, got %v", repo.checks)
+	}
+	if len(result.Apps) != 3 || result.Apps[2].AppID != "web" || result.Apps[2].Err != nil {
+		t.Errorf("Expected a result per app, got %+v", result.Apps)
+	}
+}
+
+func TestStartAppsRejectsCyclesBeforeStartingApps(t *testing.T) {
+	h, repo := newHandler(t, map[string][]string{"web": {"db"}, "db": {"web"}, "cache": nil})
+
+	err := h.Handle(StartAppsCommand{AppIDs: []string{"cache", "web", "db"}})
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("Expected a dependency cycle, got %v", err)
+	}
+	if len(repo.started) != 0 {
+		t.Errorf("Expected no app to be started, got %v", repo.started)
+	}
+}
+
+func TestStartAppsSkipsAppsWhoseDependencyFailed(t *testing.T) {
+	h, repo := newHandler(t, map[string][]string{"web": {"db"}, "db": nil, "cache": nil})
+	repo.failing["db"] = true
+
+	result := &StartAppsResult{}
+	err := h.Handle(StartAppsCommand{AppIDs: []string{"web", "db", "cache"}, Result: result})
+	if err == nil || !strings.Contains(err.Error(), "failed to start 2 of 3 apps") {
+		t.Fatalf("Expected the failed apps to be reported, got %v", err)
+	}
+	if !reflect.DeepEqual(repo.started, []string{"cache"}) {
+		t.Errorf("Expected only the independent app to start, got %v", repo.started)
+	}
+	if web := result.Apps[1]; web.AppID != "web" || web.Err == nil || !strings.Contains(web.Err.Error(), "dependency db was not started") {
+		t.Errorf("Expected web to be skipped for its dependency, got %+v", web)
+	}
+}
+
+func TestStartAppsFailsWhenADependencyOutsideTheBatchIsNotHealthy(t *testing.T) {
+	h, repo := newHandler(t, map[string][]string{"web": {"db"}})
+
+	err := h.Handle(StartAppsCommand{AppIDs: []string{"web"}})
+	if err == nil || !strings.Contains(err.Error(), "dependency db is not healthy: still stopped after 1s") {
+		t.Fatalf("Expected the stopped dependency to be reported, got %v", err)
+	}
+	if len(repo.started) != 0 {
+		t.Errorf("Expected web not to be started, got %v", repo.started)
+	}
+}
@@ -0,0 +1,63 @@
+package start_apps
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrDependencyCycle is returned when apps of a batch depend on each other in a cycle, so they cannot be
+// ordered.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// startOrder returns appIDs ordered so that every app follows the apps of the batch it depends on, by the
+// dependencies of each app. Apps without an order between them keep the order of appIDs; duplicates are
+// dropped. Dependencies that are not part of the batch do not affect the order.
+func startOrder(appIDs []string, dependencies map[string][]string) ([]string, error) {
+	inBatch := make(map[string]bool, len(appIDs))
+	for _, appID := range appIDs {
+		inBatch[appID] = true
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(appIDs))
+	order := make([]string, 0, len(appIDs))
+	var path []string
+
+	var visit func(appID string) error
+	visit = func(appID string) error {
+		switch state[appID] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, appID):], appID)
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+
+		state[appID] = visiting
+		path = append(path, appID)
+		for _, dependency := range dependencies[appID] {
+			if !inBatch[dependency] {
+				continue
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[appID] = visited
+		order = append(order, appID)
+		return nil
+	}
+
+	for _, appID := range appIDs {
+		if err := visit(appID); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
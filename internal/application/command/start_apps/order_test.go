@@ -0,0 +1,57 @@
+package start_apps
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStartOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		appIDs       []string
+		dependencies map[string][]string
+		expected     []string
+	}{
+		{"independent apps keep their order", []string{"web", "db", "cache"}, nil, []string{"web", "db", "cache"}},
+		{"dependencies start first", []string{"web", "api", "db"}, map[string][]string{"web": {"api"}, "api": {"db"}}, []string{"db", "api", "web"}},
+		{"shared dependency starts once", []string{"web", "worker", "db"}, map[string][]string{"web": {"db"}, "worker": {"db"}}, []string{"db", "web", "worker"}},
+		{"dependencies outside the batch are ignored", []string{"web"}, map[string][]string{"web": {"db"}}, []string{"web"}},
+		{"duplicates are dropped", []string{"db", "web", "db"}, map[string][]string{"web": {"db"}}, []string{"db", "web"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := startOrder(tt.appIDs, tt.dependencies)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(order, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, order)
+			}
+		})
+	}
+}
+
+func TestStartOrderRejectsCycles(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string][]string
+		cycle        string
+	}{
+		{"self", map[string][]string{"web": {"web"}}, "web -> web"},
+		{"pair", map[string][]string{"web": {"db"}, "db": {"web"}}, "web -> db -> web"},
+		{"indirect", map[string][]string{"web": {"api"}, "api": {"db"}, "db": {"api"}}, "api -> db -> api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := startOrder([]string{"web", "api", "db"}, tt.dependencies)
+			if !errors.Is(err, ErrDependencyCycle) {
+				t.Fatalf("Expected a dependency cycle, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.cycle) {
+				t.Errorf("Expected the cycle %q to be reported, got %v", tt.cycle, err)
+			}
+		})
+	}
+}
@@ -2,19 +2,28 @@ package update_agent
 
 import (
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 	"winterflow-agent/internal/application/config"
 	agentversion "winterflow-agent/internal/application/version"
 	"winterflow-agent/pkg/log"
 )
 
+// Drainer stops the agent from accepting new app commands and waits for the running ones before the
+// agent restarts with a new binary.
+type Drainer interface {
+	// Drain pauses app commands and waits up to timeout for in-flight ones to finish.
+	Drain(timeout time.Duration) error
+	// Resume accepts app commands again after a drain that was not followed by a restart.
+	Resume()
+}
+
 // UpdateAgentHandler handles the UpdateAgentCommand
 type UpdateAgentHandler struct {
-	config *config.Config
+	config  *config.Config
+	drainer Drainer
 }
 
 // Handle executes the UpdateAgentCommand
@@ -50,7 +59,8 @@ func (h *UpdateAgentHandler) Handle(cmd UpdateAgentCommand) error {
 	defer os.RemoveAll(tempDir)
 
 	// Construct the download URL
-	// Format: {GitHubReleasesURL}/{targetVersion}/winterflow-agent-{os}-{arch}
+	// Format: {GitHubReleasesURL}/{targetVersion}/winterflow-agent-{os}-{arch}, verified against
+	// {GitHubReleasesURL}/{targetVersion}/checksums.txt (and its signature when a public key is pinned).
 	osName := runtime.GOOS
 	archName := runtime.GOARCH
 	binaryName := fmt.Sprintf("winterflow-agent-%s-%s", osName, archName)
@@ -58,28 +68,14 @@ func (h *UpdateAgentHandler) Handle(cmd UpdateAgentCommand) error {
 		return log.Errorf("windows is not supported")
 	}
 
-	downloadURL := fmt.Sprintf("%s/%s/%s", h.config.GetGitHubReleasesURL(), targetVersion, binaryName)
-	log.Debug("Downloading agent version", "target_version", targetVersion, "url", downloadURL)
-
-	// Download the binary
-	resp, err := http.Get(downloadURL)
-	if err != nil {
-		return log.Errorf("failed to download agent binary: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return log.Errorf("failed to download agent binary, status code: %d", resp.StatusCode)
-	}
+	releaseURL := fmt.Sprintf("%s/%s", h.config.GetGitHubReleasesURL(), targetVersion)
+	log.Debug("Downloading agent version", "target_version", targetVersion, "url", releaseURL+"/"+binaryName)
 
-	// Create a temporary file for the download
+	// Download the binary into a temporary file and verify it before touching the running executable.
 	tempFile := filepath.Join(tempDir, "winterflow-agent-new")
-
-	out, err := os.Create(tempFile)
-	if err != nil {
-		return log.Errorf("failed to create temporary file: %w", err)
+	if err := downloadVerifiedBinary(releaseURL, binaryName, h.config.GetUpdatePublicKey(), tempFile); err != nil {
+		return log.Errorf("failed to download verified agent binary, keeping current version: %w", err)
 	}
-	defer out.Close()
 
 	// Set the file permissions to match the current executable
 	info, err := os.Stat(execPath)
@@ -90,18 +86,21 @@ func (h *UpdateAgentHandler) Handle(cmd UpdateAgentCommand) error {
 		return log.Errorf("failed to set file permissions: %w", err)
 	}
 
-	// Copy the downloaded binary to the temporary file
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return log.Errorf("failed to write downloaded binary: %w", err)
-	}
-	out.Close()
-
 	log.Debug("Successfully downloaded agent version", "target_version", targetVersion, "file", tempFile)
 
+	// Let in-flight app operations such as deployments finish before the process exits.
+	if h.drainer != nil {
+		if err := h.drainer.Drain(h.config.GetUpdateDrainTimeout()); err != nil {
+			return log.Errorf("failed to drain in-flight operations, keeping current version: %w", err)
+		}
+	}
+
 	// On Unix-like systems, we can replace the executable and let systemd restart the service
 	log.Debug("Replacing current executable with new version", "executable_path", execPath)
 	if err := os.Rename(tempFile, execPath); err != nil {
+		if h.drainer != nil {
+			h.drainer.Resume()
+		}
 		return log.Errorf("failed to replace current executable: %w", err)
 	}
 
@@ -110,9 +109,11 @@ func (h *UpdateAgentHandler) Handle(cmd UpdateAgentCommand) error {
 	return nil
 }
 
-// NewUpdateAgentHandler creates a new UpdateAgentHandler
-func NewUpdateAgentHandler(config *config.Config) *UpdateAgentHandler {
+// NewUpdateAgentHandler creates a new UpdateAgentHandler. drainer may be nil, in which case the agent
+// restarts without waiting for in-flight operations.
+func NewUpdateAgentHandler(config *config.Config, drainer Drainer) *UpdateAgentHandler {
 	return &UpdateAgentHandler{
-		config: config,
+		config:  config,
+		drainer: drainer,
 	}
 }
@@ -0,0 +1,154 @@
+package update_agent
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// checksumsFileName is the release asset listing the SHA-256 checksum of every published binary
+	// in the `sha256sum` format ("<hex digest>  <file name>").
+	checksumsFileName = "checksums.txt"
+	// signatureFileName is the release asset holding the base64 encoded Ed25519 signature of the
+	// checksums file.
+	signatureFileName = checksumsFileName + ".sig"
+)
+
+// downloadFile fetches url and writes the response body to destPath.
+func downloadFile(url, destPath string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s, status code: %d", url, resp.StatusCode)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+	return out.Close()
+}
+
+// fetch downloads url into memory. Release metadata files are small, so no size limit is applied.
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s, status code: %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// downloadVerifiedBinary downloads binaryName of the given release into destPath and verifies it
+// against the published checksums. When publicKey is set the checksums file must also carry a valid
+// signature. On any verification failure destPath is removed and an error is returned.
+func downloadVerifiedBinary(releaseURL, binaryName, publicKey, destPath string) error {
+	checksums, err := fetch(releaseURL + "/" + checksumsFileName)
+	if err != nil {
+		return fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	if publicKey != "" {
+		signature, err := fetch(releaseURL + "/" + signatureFileName)
+		if err != nil {
+			return fmt.Errorf("failed to download checksums signature: %w", err)
+		}
+		if err := verifySignature(checksums, signature, publicKey); err != nil {
+			return err
+		}
+	}
+
+	if err := downloadFile(releaseURL+"/"+binaryName, destPath); err != nil {
+		return err
+	}
+
+	if err := verifyChecksum(destPath, binaryName, checksums); err != nil {
+		os.Remove(destPath)
+		return err
+	}
+	return nil
+}
+
+// verifyChecksum compares the SHA-256 digest of the file at path with the entry for binaryName in checksums.
+func verifyChecksum(path, binaryName string, checksums []byte) error {
+	expected, err := lookupChecksum(checksums, binaryName)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", binaryName, expected, actual)
+	}
+	return nil
+}
+
+// lookupChecksum returns the hex digest listed for binaryName in a sha256sum formatted file.
+func lookupChecksum(checksums []byte, binaryName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks binary mode entries with a leading '*'.
+		if strings.TrimPrefix(fields[1], "*") == binaryName {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read checksums: %w", err)
+	}
+	return "", fmt.Errorf("no checksum published for %s", binaryName)
+}
+
+// verifySignature checks the base64 encoded Ed25519 signature of data against the base64 encoded publicKey.
+func verifySignature(data, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid update public key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("failed to decode checksums signature: %w", err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), data, sig) {
+		return fmt.Errorf("checksums signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package update_agent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testBinaryName = "winterflow-agent-linux-amd64"
+
+var goodBinary = []byte("#!/bin/sh\necho winterflow-agent known-good build\n")
+
+// newReleaseServer serves a fake release containing binary, a checksums file for goodBinary and,
+// when privateKey is non-nil, a signature of the checksums file.
+func newReleaseServer(t *testing.T, binary []byte, privateKey ed25519.PrivateKey) *httptest.Server {
+	t.Helper()
+	digest := sha256.Sum256(goodBinary)
+	checksums := []byte(fmt.Sprintf("%s  %s\n%s  winterflow-agent-linux-arm64\n", hex.EncodeToString(digest[:]), testBinaryName, hex.EncodeToString(make([]byte, 32))))
+
+	files := map[string][]byte{
+		"/" + testBinaryName:    binary,
+		"/" + checksumsFileName: checksums,
+	}
+	if privateKey != nil {
+		files["/"+signatureFileName] = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, checksums)))
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newKeyPair(t *testing.T) (string, ed25519.PrivateKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key pair: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), privateKey
+}
+
+func TestDownloadVerifiedBinaryAcceptsKnownGoodBinary(t *testing.T) {
+	publicKey, privateKey := newKeyPair(t)
+
+	testCases := map[string]struct {
+		publicKey  string
+		privateKey ed25519.PrivateKey
+	}{
+		"Checksum only":          {},
+		"Checksum and signature": {publicKey: publicKey, privateKey: privateKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := newReleaseServer(t, goodBinary, tc.privateKey)
+			destPath := filepath.Join(t.TempDir(), "agent")
+
+			if err := downloadVerifiedBinary(server.URL, testBinaryName, tc.publicKey, destPath); err != nil {
+				t.Fatalf("downloadVerifiedBinary returned error: %v", err)
+			}
+			content, err := os.ReadFile(destPath)
+			if err != nil {
+				t.Fatalf("Failed to read downloaded binary: %v", err)
+			}
+			if string(content) != string(goodBinary) {
+				t.Errorf("Unexpected downloaded content %q", content)
+			}
+		})
+	}
+}
+
+func TestDownloadVerifiedBinaryRejectsTamperedRelease(t *testing.T) {
+	publicKey, privateKey := newKeyPair(t)
+	otherPublicKey, _ := newKeyPair(t)
+	tampered := append([]byte{}, goodBinary...)
+	tampered[len(tampered)-2] ^= 0xff
+
+	testCases := map[string]struct {
+		binary     []byte
+		publicKey  string
+		privateKey ed25519.PrivateKey
+	}{
+		"Tampered binary":             {binary: tampered},
+		"Tampered binary with signer": {binary: tampered, publicKey: publicKey, privateKey: privateKey},
+		"Missing signature":           {binary: goodBinary, publicKey: publicKey},
+		"Signature from other key":    {binary: goodBinary, publicKey: otherPublicKey, privateKey: privateKey},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := newReleaseServer(t, tc.binary, tc.privateKey)
+			destPath := filepath.Join(t.TempDir(), "agent")
+
+			if err := downloadVerifiedBinary(server.URL, testBinaryName, tc.publicKey, destPath); err == nil {
+				t.Fatal("Expected verification to fail")
+			}
+			if _, err := os.Stat(destPath); !os.IsNotExist(err) {
+				t.Errorf("Expected unverified binary to be removed, stat error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksumMissingEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent")
+	if err := os.WriteFile(path, goodBinary, 0o755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+
+	if err := verifyChecksum(path, testBinaryName, []byte("deadbeef  some-other-binary\n")); err == nil {
+		t.Error("Expected error for binary without a published checksum")
+	}
+}
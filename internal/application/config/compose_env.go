@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// envNamePattern matches the names of environment variables.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedComposeEnv lists the variables the agent sets for Docker Compose itself: the project name and
+// files of an app and the Docker daemon selected by docker_context.
+var reservedComposeEnv = map[string]bool{
+	"COMPOSE_PROJECT_NAME": true,
+	"COMPOSE_FILE":         true,
+	"DOCKER_CONTEXT":       true,
+	"DOCKER_HOST":          true,
+}
+
+// validateComposeEnv verifies that every variable of compose_env has a valid name the agent does not set
+// itself.
+func validateComposeEnv(cfg *Config) error {
+	for name := range cfg.ComposeEnv {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("%q is not a valid environment variable name", name)
+		}
+		if reservedComposeEnv[name] {
+			return fmt.Errorf("%s is set by the agent and cannot be overridden", name)
+		}
+	}
+	return nil
+}
+
+// GetComposeEnv returns the environment variables set for Docker Compose invocations as NAME=value entries
+// sorted by name.
+func (c *Config) GetComposeEnv() []string {
+	env := make([]string, 0, len(c.ComposeEnv))
+	for name, value := range c.ComposeEnv {
+		env = append(env, name+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateComposeEnv(t *testing.T) {
+	valid := &Config{ComposeEnv: map[string]string{"DOCKER_BUILDKIT": "1", "_private": "", "BUILDKIT_PROGRESS": "plain"}}
+	if err := validateComposeEnv(valid); err != nil {
+		t.Errorf("Expected the compose environment to be valid, got %v", err)
+	}
+
+	for _, name := range []string{"", "1ST", "WITH-DASH", "WITH=EQUALS", "WITH SPACE", "COMPOSE_PROJECT_NAME", "DOCKER_HOST"} {
+		if err := validateComposeEnv(&Config{ComposeEnv: map[string]string{name: "x"}}); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestGetComposeEnvSortsByName(t *testing.T) {
+	cfg := &Config{ComposeEnv: map[string]string{"DOCKER_BUILDKIT": "1", "COMPOSE_DOCKER_CLI_BUILD": "1", "BUILDKIT_PROGRESS": "plain"}}
+	expected := []string{"BUILDKIT_PROGRESS=plain", "COMPOSE_DOCKER_CLI_BUILD=1", "DOCKER_BUILDKIT=1"}
+	if env := cfg.GetComposeEnv(); !reflect.DeepEqual(env, expected) {
+		t.Errorf("Expected %v, got %v", expected, env)
+	}
+	if env := (&Config{}).GetComposeEnv(); len(env) != 0 {
+		t.Errorf("Expected no compose environment by default, got %v", env)
+	}
+}
+
+func TestLoadConfigRejectsReservedComposeEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.config.json")
+	if err := os.WriteFile(path, []byte(`{"compose_env": {"DOCKER_CONTEXT": "remote"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "DOCKER_CONTEXT is set by the agent") {
+		t.Fatalf("Expected a reserved compose_env variable to be rejected, got %v", err)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
+	"winterflow-agent/pkg/files"
 	"winterflow-agent/pkg/log"
 )
 
@@ -29,11 +31,23 @@ const (
 	defaultOrchestrator                            = OrchestratorTypeDockerCompose
 )
 
+// defaultComposeRetryPatterns match registry and network errors that typically resolve on their own.
+var defaultComposeRetryPatterns = []string{
+	`(?i)connection reset by peer`,
+	`(?i)TLS handshake timeout`,
+	`(?i)i/o timeout`,
+	`(?i)\b429\b|too many requests|toomanyrequests`,
+	`(?i)unexpected EOF`,
+}
+
 var (
 	grpcServerAddress string
 	apiBaseURL        string
 	basePath          string
 	orchestrator      OrchestratorType
+	// updatePublicKey is the base64 encoded Ed25519 public key used to verify the signature of
+	// agent release checksums. Signature verification is skipped when it is not set at build time.
+	updatePublicKey string
 )
 
 const (
@@ -45,9 +59,22 @@ const (
 	// Apps folders
 	appsFolder          = "apps"
 	appsTemplatesFolder = "apps_templates"
+	// secretsFolder is the default directory resolving secret:// variable references.
+	secretsFolder = "secrets"
+	// logsFolder holds diagnostic files of the agent when no log file is configured.
+	logsFolder = "logs"
+	// volumeBackupsFolder is the default directory receiving the archives of backed up app volumes.
+	volumeBackupsFolder = "backups"
+	// defaultVolumeBackupImage is the image whose tar archives app volumes when no image is configured.
+	defaultVolumeBackupImage = "busybox"
+	// connectionStatsFile persists the statistics of the connection to the server across restarts.
+	connectionStatsFile = "connection_stats.json"
 
 	// Apps versions
 	appsKeepRevisions = 3
+	// minAppsKeepRevisions is the lowest accepted revision retention; the
+	// current revision must always be kept.
+	minAppsKeepRevisions = 1
 
 	// certificatesFolder is the default directory path for storing certificates.
 	certificatesFolder = ".certs"
@@ -60,6 +87,50 @@ const (
 	// agentCACertificateFile is the default filesystem path for the trusted Certificate Authority (CA) certificate.
 	agentCACertificateFile = "ca.crt"
 
+	// defaultComposeRetryAttempts is the number of retries of a transient compose failure when not configured.
+	defaultComposeRetryAttempts = 3
+
+	// Defaults of the log file rotation.
+	defaultLogMaxSizeMB  = 100
+	defaultLogMaxBackups = 5
+	defaultLogMaxAgeDays = 30
+
+	// defaultDeployHookTimeout limits app deployment hooks when no timeout is configured.
+	defaultDeployHookTimeout = 5 * time.Minute
+	// defaultHealthWaitTimeout limits how long deployments wait for services to become healthy.
+	defaultHealthWaitTimeout = 5 * time.Minute
+
+	// defaultUpdateDrainTimeout limits how long an agent update waits for in-flight app commands.
+	defaultUpdateDrainTimeout = 10 * time.Minute
+	// defaultShutdownGracePeriod is used when no shutdown grace period is configured.
+	defaultShutdownGracePeriod = 5 * time.Second
+
+	// defaultQueryTimeout limits how long server queries such as GetAppLogs may take.
+	defaultQueryTimeout = 2 * time.Minute
+
+	// defaultMaxLogBytes keeps log responses below the default 4 MiB message limit of gRPC servers.
+	defaultMaxLogBytes = 3 << 20
+
+	// defaultDockerWaitTimeout limits how long the agent waits for the Docker daemon on startup.
+	defaultDockerWaitTimeout = 5 * time.Minute
+
+	// defaultConnectionTimeoutMin is the timeout of a connection attempt to the server after a success.
+	defaultConnectionTimeoutMin = 30 * time.Second
+	// defaultConnectionTimeoutMax bounds the timeout of connection attempts after consecutive failures.
+	defaultConnectionTimeoutMax = 5 * time.Minute
+
+	// defaultKeepaliveTime is the idle time after which the agent pings the server.
+	defaultKeepaliveTime = 10 * time.Second
+	// minKeepaliveTime is the smallest ping interval gRPC clients allow.
+	minKeepaliveTime = 10 * time.Second
+	// defaultKeepaliveTimeout is how long the agent waits for a ping to be acknowledged.
+	defaultKeepaliveTimeout = 3 * time.Second
+	// minKeepaliveTimeout keeps a slow acknowledgement from closing a healthy connection.
+	minKeepaliveTimeout = 1 * time.Second
+
+	// defaultMetricsCPUSampleWindow matches the interval at which metrics are sent to the server.
+	defaultMetricsCPUSampleWindow = 60 * time.Second
+
 	// gitHubReleasesURL is the default URL for GitHub releases where agent binaries can be downloaded.
 	gitHubReleasesURL = "https://github.com/flowmitry/winterflow-agent/releases/download"
 )
@@ -69,14 +140,177 @@ type Config struct {
 	AgentID     string          `json:"agent_id"`
 	AgentStatus AgentStatus     `json:"agent_status"`
 	Features    map[string]bool `json:"features"`
+	// DeviceID identifies the host the agent was registered on, see device.GetDeviceID. An agent whose host
+	// no longer matches, e.g. because its disk image was cloned to another host, shares the identity of
+	// the registered agent.
+	DeviceID string `json:"device_id,omitempty"`
+	// RefuseOnDeviceMismatch stops the agent from connecting to the server while DeviceID does not match the
+	// host, until the agent is registered again. By default the mismatch is only logged.
+	RefuseOnDeviceMismatch bool `json:"refuse_on_device_mismatch,omitempty"`
 	// BasePath specifies the root directory used to store application-related files and configurations.
 	BasePath string `json:"base_path,omitempty"`
 	// LogLevel specifies the minimum log level to output (debug, info, warn, error).
 	LogLevel string `json:"log_level,omitempty"`
+	// LogFormat selects the log output format: "json" (one JSON object per line) or "text".
+	LogFormat string `json:"log_format,omitempty"`
+	// LogFile optionally writes the agent log to this file instead of stdout. Relative paths are resolved
+	// against the base path.
+	LogFile string `json:"log_file,omitempty"`
+	// LogMaxSizeMB rotates the log file once it grows beyond this size (default 100).
+	LogMaxSizeMB int `json:"log_max_size_mb,omitempty"`
+	// LogMaxBackups is the number of rotated log files kept (default 5).
+	LogMaxBackups int `json:"log_max_backups,omitempty"`
+	// LogMaxAgeDays removes rotated log files older than this number of days (default 30).
+	LogMaxAgeDays int `json:"log_max_age_days,omitempty"`
 	// Orchestrator specifies the orchestration platform or tool used for managing deployments and configurations.
 	Orchestrator OrchestratorType `json:"orchestrator,omitempty"`
 	// CertificatesFolder specifies the directory where certificate files are stored.
 	CertificatesFolder string `json:"certificates_folder,omitempty"`
+	// RevisionRetention specifies how many revisions of each application are kept on disk (minimum 1).
+	RevisionRetention int `json:"revision_retention,omitempty"`
+	// DecryptionKeysDir optionally names a directory of additional EC private keys (PEM) tried after the agent's
+	// own key when decrypting app files, variables and registry credentials, e.g. the previous key during a key
+	// rotation. Relative paths are resolved against the base path.
+	DecryptionKeysDir string `json:"decryption_keys_dir,omitempty"`
+	// BestEffortDecryption stores app files and variables that cannot be decrypted with the agent's private key
+	// as received. By default such a save is rejected so that broken values are never deployed.
+	BestEffortDecryption bool `json:"best_effort_decryption,omitempty"`
+	// EncryptSecrets enables at-rest encryption of sensitive fields (e.g. the agent ID) with the agent's private key.
+	EncryptSecrets bool `json:"encrypt_secrets,omitempty"`
+	// CACertificatesDir optionally names a directory of additional PEM encoded CA certificates trusted for the
+	// server connection, e.g. the root of a corporate TLS inspection proxy.
+	CACertificatesDir string `json:"ca_certificates_dir,omitempty"`
+	// CACertificateURL optionally names an HTTPS URL the CA certificate is fetched from on startup, for
+	// deployments that rotate the CA. The fetched certificate replaces the embedded one only when its SHA-256
+	// fingerprint matches CACertificateFingerprint (hex, colons allowed); otherwise the embedded CA is used.
+	CACertificateURL         string `json:"ca_certificate_url,omitempty"`
+	CACertificateFingerprint string `json:"ca_certificate_fingerprint,omitempty"`
+	// UseSystemCAs additionally trusts the CA certificates of the operating system for the server connection.
+	UseSystemCAs bool `json:"use_system_cas,omitempty"`
+	// ServerSigningKeyPath names the PEM encoded public key (or certificate) of the server that mutating commands
+	// must be signed with when the command_signatures feature is enabled. Relative paths are resolved against
+	// the base path.
+	ServerSigningKeyPath string `json:"server_signing_key_path,omitempty"`
+	// DeployHookTimeout limits the run time of app deployment hooks in seconds (default 5 minutes).
+	DeployHookTimeout int `json:"deploy_hook_timeout,omitempty"`
+	// FailOnPostDeployHookError fails deployments whose post-deploy hook fails instead of only logging the failure.
+	FailOnPostDeployHookError bool `json:"fail_on_post_deploy_hook_error,omitempty"`
+	// BackupVolumesBeforeUpdate and BackupVolumesBeforePurge archive the named volumes of an app before it is
+	// updated or deleted with its volumes; a failed backup aborts the operation.
+	BackupVolumesBeforeUpdate bool `json:"backup_volumes_before_update,omitempty"`
+	BackupVolumesBeforePurge  bool `json:"backup_volumes_before_purge,omitempty"`
+	// VolumeBackupDir is the directory receiving the volume archives, one directory per app and backup.
+	// Relative paths are resolved against the base path (default "backups").
+	VolumeBackupDir string `json:"volume_backup_dir,omitempty"`
+	// VolumeBackupImage is the image whose tar archives the volumes (default "busybox").
+	VolumeBackupImage string `json:"volume_backup_image,omitempty"`
+	// HealthWaitTimeout is the maximum number of seconds a deployment of an app with wait_for_healthy
+	// waits for its services to become healthy (default 5 minutes).
+	HealthWaitTimeout int `json:"health_wait_timeout,omitempty"`
+	// SecretsDir is the directory resolving app variables valued secret://<path>; the referenced secret is
+	// read from <SecretsDir>/<path>. Relative paths are resolved against the base path (default "secrets").
+	SecretsDir string `json:"secrets_dir,omitempty"`
+	// AllowSecretsOnDisk renders resolved secret:// values into app files. By default they are only passed
+	// to `docker compose` through the process environment.
+	AllowSecretsOnDisk bool `json:"allow_secrets_on_disk,omitempty"`
+	// MaintenanceMode starts the agent with app commands paused; the connection, heartbeats and metrics
+	// are kept alive. The server can toggle the mode at runtime without changing this setting.
+	MaintenanceMode bool `json:"maintenance_mode,omitempty"`
+	// AllowedCommands lists the names of the server requests the agent processes, e.g. "GetAppsStatus" for a
+	// GetAppsStatusRequestV1; other requests are rejected as forbidden. DeniedCommands lists requests that are
+	// rejected even when allowed. By default every request is processed.
+	AllowedCommands []string `json:"allowed_commands,omitempty"`
+	DeniedCommands  []string `json:"denied_commands,omitempty"`
+	// DockerContext selects the Docker context (see `docker context ls`) that applications are deployed to.
+	// The default context is used when empty.
+	DockerContext string `json:"docker_context,omitempty"`
+	// ComposeCommand selects how Docker Compose is invoked: "docker compose" for the CLI plugin, or the name or
+	// path of a standalone binary such as "docker-compose". When empty the plugin is preferred and the standalone
+	// docker-compose binary is used if the plugin is not installed.
+	ComposeCommand string `json:"compose_command,omitempty"`
+	// ComposeEnv sets environment variables, e.g. DOCKER_BUILDKIT=1, for every Docker Compose invocation on top
+	// of the agent's own environment. The variables the agent sets to select projects and the Docker daemon may
+	// not be overridden.
+	ComposeEnv map[string]string `json:"compose_env,omitempty"`
+	// RestartPolicyOverride forces the restart policy (no, always, unless-stopped or on-failure[:N]) of every
+	// service of every app, regardless of the compose files. Apps may set their own override.
+	RestartPolicyOverride string `json:"restart_policy_override,omitempty"`
+	// RegistryMirror names a pull-through cache of Docker Hub, e.g. "mirror.example.com:5000". Service images
+	// hosted on Docker Hub are rewritten to be pulled through it; images of other registries are left as is.
+	RegistryMirror string `json:"registry_mirror,omitempty"`
+	// ServiceDNS lists DNS servers, e.g. "10.0.0.53", added to the dns of every service of every app. Servers a
+	// service already sets are kept and not repeated.
+	ServiceDNS []string `json:"service_dns,omitempty"`
+	// ServiceExtraHosts maps host names to the IP address (or "host-gateway") added to the extra_hosts of every
+	// service of every app. A host name a service already maps keeps the address of the service.
+	ServiceExtraHosts map[string]string `json:"service_extra_hosts,omitempty"`
+	// AllowedRegistries restricts the registries, e.g. "ghcr.io" or "registry.example.com:5000", the service images
+	// of apps may be pulled from. Docker Hub is named "docker.io". Images of any registry are allowed when empty.
+	AllowedRegistries []string `json:"allowed_registries,omitempty"`
+	// AllowedNetworks restricts the external networks app compose files may reference. Any existing network is
+	// allowed when empty.
+	AllowedNetworks []string `json:"allowed_networks,omitempty"`
+	// AllowedStoragePaths lists the absolute directories apps may be deployed below through the storage_path
+	// of their configuration. Apps can only be deployed to the apps directory when empty.
+	AllowedStoragePaths []string `json:"allowed_storage_paths,omitempty"`
+	// DirPerm, FilePerm and SensitiveFilePerm are octal permissions, e.g. "0750", of the directories, files and
+	// files that may contain secrets written for app revisions. Defaults to 0755, 0644 and 0600.
+	DirPerm           string `json:"dir_perm,omitempty"`
+	FilePerm          string `json:"file_perm,omitempty"`
+	SensitiveFilePerm string `json:"sensitive_file_perm,omitempty"`
+	// MaxConcurrentDeploys limits how many `docker compose` pull and up operations run at the same time across
+	// all apps; further operations wait for a free slot. Zero means no limit.
+	MaxConcurrentDeploys int `json:"max_concurrent_deploys,omitempty"`
+	// PullConcurrency is the number of services of an app whose images are pulled at the same time, each with
+	// its own `docker compose pull`, so that one slow image does not hold up the others. Zero or one pulls all
+	// images of the app with a single `docker compose pull`.
+	PullConcurrency int `json:"pull_concurrency,omitempty"`
+	// ComposeRetryPatterns lists regular expressions matched against the output of failed `docker compose`
+	// pull and up operations. Matching failures are considered transient and retried. Defaults are used when empty.
+	ComposeRetryPatterns []string `json:"compose_retry_patterns,omitempty"`
+	// ComposeRetryAttempts caps the number of retries of a transient compose failure (negative disables retries).
+	ComposeRetryAttempts int `json:"compose_retry_attempts,omitempty"`
+	// UpdateDrainTimeout is the maximum number of seconds an agent update waits for in-flight app commands
+	// before the binary is replaced (default 10 minutes). The update is aborted when they do not finish in time.
+	UpdateDrainTimeout int `json:"update_drain_timeout,omitempty"`
+	// ShutdownGracePeriod is the maximum number of seconds to wait for in-flight operations on shutdown.
+	ShutdownGracePeriod int `json:"shutdown_grace_period,omitempty"`
+	// RestartCooldown is the minimum number of seconds between two restarts or redeploys of the same app.
+	// Requests within the window are rejected as rate limited unless they are forced. Zero disables the cooldown.
+	RestartCooldown int `json:"restart_cooldown,omitempty"`
+	// QueryTimeout is the maximum number of seconds a query of the server, e.g. GetAppLogs, may take before it
+	// is canceled and answered with a timeout (default 120). QueryTimeouts overrides it by query name.
+	QueryTimeout  int            `json:"query_timeout,omitempty"`
+	QueryTimeouts map[string]int `json:"query_timeouts,omitempty"`
+	// DockerWaitTimeout is the maximum number of seconds the agent waits on startup for the Docker daemon to
+	// respond before it connects to the server (default 300, negative disables the wait).
+	DockerWaitTimeout int `json:"docker_wait_timeout,omitempty"`
+	// MaxLogBytes caps the serialized size of a GetAppLogs response (default 3 MiB, negative disables the cap).
+	// The oldest entries of larger responses are dropped and the response is marked as truncated.
+	MaxLogBytes int `json:"max_log_bytes,omitempty"`
+	// ConnectionTimeoutMin is the timeout of a connection attempt to the server in seconds (default 30). It
+	// doubles after every failed attempt up to ConnectionTimeoutMax seconds (default 300) and is reset once
+	// a connection succeeds.
+	ConnectionTimeoutMin int `json:"connection_timeout_min,omitempty"`
+	ConnectionTimeoutMax int `json:"connection_timeout_max,omitempty"`
+	// RegistrationMaxAttempts and RegistrationMaxDuration (in seconds) limit how often and how long the agent
+	// retries registering with the server before it gives up and exits with an error, so that a permanently
+	// misconfigured agent is detected. Registration is retried without limit when unset.
+	RegistrationMaxAttempts int `json:"registration_max_attempts,omitempty"`
+	RegistrationMaxDuration int `json:"registration_max_duration,omitempty"`
+	// PublicIPLookupURL is a service answering with the public IP address of the caller in plain text, e.g.
+	// "https://api.ipify.org". The public IP is looked up and reported as a capability only when set.
+	PublicIPLookupURL string `json:"public_ip_lookup_url,omitempty"`
+	// MetricsCPUSampleWindow is the number of seconds of /proc/stat history the reported CPU usage is
+	// averaged over (default 60, the metrics interval).
+	MetricsCPUSampleWindow int `json:"metrics_cpu_sample_window,omitempty"`
+	// KeepaliveTimeSeconds is the number of idle seconds after which the agent pings the server (default 10,
+	// minimum 10). KeepaliveTimeoutSeconds is the number of seconds a ping may remain unacknowledged before the
+	// connection is closed (default 3, minimum 1). KeepalivePermitWithoutStream allows pings while no stream
+	// is open (default true). Lower them on networks whose NAT or firewalls drop idle connections early.
+	KeepaliveTimeSeconds         int   `json:"keepalive_time_seconds,omitempty"`
+	KeepaliveTimeoutSeconds      int   `json:"keepalive_timeout_seconds,omitempty"`
+	KeepalivePermitWithoutStream *bool `json:"keepalive_permit_without_stream,omitempty"`
 }
 
 // prepareConfig ensures the configuration is valid by applying defaults and validating features
@@ -91,12 +325,29 @@ func prepareConfig(cfg *Config) {
 	if cfg.LogLevel == "" {
 		cfg.LogLevel = "info"
 	}
+	if cfg.LogFormat == "" {
+		cfg.LogFormat = log.FormatJSON
+	}
 	if cfg.Orchestrator == "" || !isValidOrchestratorType(cfg.Orchestrator) {
 		cfg.Orchestrator = defaultOrchestrator
 	}
 	if cfg.CertificatesFolder == "" {
 		cfg.CertificatesFolder = certificatesFolder
 	}
+	if cfg.RevisionRetention == 0 {
+		cfg.RevisionRetention = appsKeepRevisions
+	} else if cfg.RevisionRetention < minAppsKeepRevisions {
+		log.Warn("Invalid revision retention, using minimum", "revision_retention", cfg.RevisionRetention, "minimum", minAppsKeepRevisions)
+		cfg.RevisionRetention = minAppsKeepRevisions
+	}
+	if cfg.KeepaliveTimeSeconds > 0 && time.Duration(cfg.KeepaliveTimeSeconds)*time.Second < minKeepaliveTime {
+		log.Warn("Keepalive time is too short, using minimum", "keepalive_time_seconds", cfg.KeepaliveTimeSeconds, "minimum", seconds(minKeepaliveTime))
+		cfg.KeepaliveTimeSeconds = seconds(minKeepaliveTime)
+	}
+	if cfg.KeepaliveTimeoutSeconds < 0 {
+		log.Warn("Keepalive timeout is too short, using minimum", "keepalive_timeout_seconds", cfg.KeepaliveTimeoutSeconds, "minimum", seconds(minKeepaliveTimeout))
+		cfg.KeepaliveTimeoutSeconds = seconds(minKeepaliveTimeout)
+	}
 
 	// Validate and merge features
 	cfg.Features = validateAndMergeFeatures(cfg.Features)
@@ -144,6 +395,11 @@ func NewConfig() *Config {
 	return config
 }
 
+// GetLockPath returns the path of the file the running agent locks, next to its configuration file.
+func GetLockPath(configPath string) string {
+	return configPath + ".lock"
+}
+
 // LoadConfig loads the configuration from a JSON file
 func LoadConfig(configPath string) (*Config, error) {
 	config := NewConfig()
@@ -158,6 +414,18 @@ func LoadConfig(configPath string) (*Config, error) {
 			if err := json.Unmarshal(data, config); err == nil {
 				// Prepare the config (apply defaults and validate features)
 				prepareConfig(config)
+				if err := decryptSecrets(config); err != nil {
+					return nil, log.Errorf("failed to decrypt config secrets: %v", err)
+				}
+				if err := validatePermissions(config); err != nil {
+					return nil, log.Errorf("invalid config permissions: %v", err)
+				}
+				if err := validateComposeEnv(config); err != nil {
+					return nil, log.Errorf("invalid compose environment: %v", err)
+				}
+				if err := validateServiceHosts(config); err != nil {
+					return nil, log.Errorf("invalid service hosts: %v", err)
+				}
 				return config, nil
 			}
 		}
@@ -180,6 +448,18 @@ func WaitUntilReady(configPath string) (*Config, error) {
 					// Check if required fields are filled and agent is registered
 					if config.AgentID != "" && config.AgentStatus == AgentStatusRegistered {
 						prepareConfig(&config)
+						if err := decryptSecrets(&config); err != nil {
+							return nil, log.Errorf("failed to decrypt config secrets: %v", err)
+						}
+						if err := validatePermissions(&config); err != nil {
+							return nil, log.Errorf("invalid config permissions: %v", err)
+						}
+						if err := validateComposeEnv(&config); err != nil {
+							return nil, log.Errorf("invalid compose environment: %v", err)
+						}
+						if err := validateServiceHosts(&config); err != nil {
+							return nil, log.Errorf("invalid service hosts: %v", err)
+						}
 						return &config, nil
 					}
 				}
@@ -216,20 +496,29 @@ func SaveConfig(config *Config, configPath string) error {
 	}
 	configToSave.Features = filteredFeatures
 
+	// Encrypt sensitive fields on the copy so the in-memory config keeps plaintext values
+	if configToSave.EncryptSecrets {
+		if err := encryptSecrets(&configToSave); err != nil {
+			return log.Errorf("failed to encrypt config secrets: %v", err)
+		}
+	}
+
 	// Marshal config to JSON
 	data, err := json.MarshalIndent(configToSave, "", "  ")
 	if err != nil {
 		return log.Errorf("failed to marshal config: %v", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
+	// Replace the file atomically so that a crash never leaves a partially written config behind
+	if err := files.WriteFileAtomic(configPath, data, 0600); err != nil {
 		return log.Errorf("failed to write config file: %v", err)
 	}
 
 	return nil
 }
 
+// GetGRPCServerAddress returns the gRPC server endpoint. It may hold a
+// comma-separated list of endpoints which the client tries in order.
 func (c *Config) GetGRPCServerAddress() string {
 	if grpcServerAddress == "" {
 		return defaultGRPCServerAddress
@@ -281,6 +570,33 @@ func (c *Config) GetPrivateKeyPath() string {
 	return c.buildPath(c.GetCertificatesFolder(), agentPrivateKeyFile)
 }
 
+// GetDecryptionKeyPaths returns the private keys tried in order when decrypting server supplied content:
+// the agent's own key followed by the files of DecryptionKeysDir in lexical order.
+func (c *Config) GetDecryptionKeyPaths() []string {
+	paths := []string{c.GetPrivateKeyPath()}
+	if c.DecryptionKeysDir == "" {
+		return paths
+	}
+
+	dir := c.DecryptionKeysDir
+	if !filepath.IsAbs(dir) {
+		dir = c.buildPath(dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Warn("Failed to read decryption keys directory", "path", dir, "error", err)
+		return paths
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			if path := filepath.Join(dir, entry.Name()); path != paths[0] {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
 func (c *Config) GetCSRPath() string {
 	return c.buildPath(c.GetCertificatesFolder(), agentCSRFile)
 }
@@ -303,7 +619,7 @@ func isValidOrchestratorType(orchestratorType OrchestratorType) bool {
 
 func (o OrchestratorType) Validate() {
 	if !isValidOrchestratorType(o) {
-		panic(fmt.Sprintf("invalid orchestrator type: %s, must be one of: %s, %s",
+		panic(fmt.Sprintf("invalid orchestrator type: %s, must be one of: %s",
 			o, OrchestratorTypeDockerCompose))
 	}
 }
@@ -324,7 +640,297 @@ func (c *Config) GetGitHubReleasesURL() string {
 	return gitHubReleasesURL
 }
 
+// GetUpdatePublicKey returns the pinned public key for agent update signatures, or an empty
+// string when the agent was built without one.
+func (c *Config) GetUpdatePublicKey() string {
+	return updatePublicKey
+}
+
+// GetCACertificatesDir returns the directory of additional trusted CA certificates, or an empty string.
+func (c *Config) GetCACertificatesDir() string {
+	return c.CACertificatesDir
+}
+
+// GetServerSigningKeyPath returns the path of the server signing key, or an empty string when none is configured.
+func (c *Config) GetServerSigningKeyPath() string {
+	if c.ServerSigningKeyPath == "" || filepath.IsAbs(c.ServerSigningKeyPath) {
+		return c.ServerSigningKeyPath
+	}
+	return c.buildPath(c.ServerSigningKeyPath)
+}
+
+// GetDockerContext returns the configured Docker context name, or an empty string for the default context.
+func (c *Config) GetDockerContext() string {
+	return c.DockerContext
+}
+
+// GetComposeCommand returns the configured compose command, or an empty string to detect it.
+func (c *Config) GetComposeCommand() string {
+	return c.ComposeCommand
+}
+
+// GetComposeRetryPatterns returns the regular expressions identifying transient compose failures.
+func (c *Config) GetComposeRetryPatterns() []string {
+	if len(c.ComposeRetryPatterns) == 0 {
+		return defaultComposeRetryPatterns
+	}
+	return c.ComposeRetryPatterns
+}
+
+// GetComposeRetryAttempts returns how many times a transient compose failure is retried.
+func (c *Config) GetComposeRetryAttempts() int {
+	if c.ComposeRetryAttempts == 0 {
+		return defaultComposeRetryAttempts
+	}
+	if c.ComposeRetryAttempts < 0 {
+		return 0
+	}
+	return c.ComposeRetryAttempts
+}
+
+// GetDockerWaitTimeout returns how long the agent waits on startup for the Docker daemon, or 0 when it does
+// not wait.
+func (c *Config) GetDockerWaitTimeout() time.Duration {
+	if c.DockerWaitTimeout == 0 {
+		return defaultDockerWaitTimeout
+	}
+	if c.DockerWaitTimeout < 0 {
+		return 0
+	}
+	return time.Duration(c.DockerWaitTimeout) * time.Second
+}
+
+// GetMaxLogBytes returns the maximum serialized size of a logs response, or 0 when it is not capped.
+func (c *Config) GetMaxLogBytes() int {
+	if c.MaxLogBytes == 0 {
+		return defaultMaxLogBytes
+	}
+	if c.MaxLogBytes < 0 {
+		return 0
+	}
+	return c.MaxLogBytes
+}
+
+// GetSecretsDir returns the directory resolving secret:// variable references.
+func (c *Config) GetSecretsDir() string {
+	if c.SecretsDir == "" {
+		return c.buildPath(secretsFolder)
+	}
+	if filepath.IsAbs(c.SecretsDir) {
+		return c.SecretsDir
+	}
+	return c.buildPath(c.SecretsDir)
+}
+
+// GetVolumeBackupDir returns the directory receiving the archives of backed up app volumes.
+func (c *Config) GetVolumeBackupDir() string {
+	if c.VolumeBackupDir == "" {
+		return c.buildPath(volumeBackupsFolder)
+	}
+	if filepath.IsAbs(c.VolumeBackupDir) {
+		return c.VolumeBackupDir
+	}
+	return c.buildPath(c.VolumeBackupDir)
+}
+
+// GetVolumeBackupImage returns the image used to archive app volumes.
+func (c *Config) GetVolumeBackupImage() string {
+	if c.VolumeBackupImage == "" {
+		return defaultVolumeBackupImage
+	}
+	return c.VolumeBackupImage
+}
+
+// GetLogFilePath returns the path of the agent log file, or an empty string when logging to stdout.
+func (c *Config) GetLogFilePath() string {
+	if c.LogFile == "" || filepath.IsAbs(c.LogFile) {
+		return c.LogFile
+	}
+	return c.buildPath(c.LogFile)
+}
+
+// GetLogsPath returns the directory holding diagnostic files of the agent: the directory of the log file
+// when one is configured, otherwise the logs folder below the base path.
+func (c *Config) GetLogsPath() string {
+	if logFile := c.GetLogFilePath(); logFile != "" {
+		return filepath.Dir(logFile)
+	}
+	return c.buildPath(logsFolder)
+}
+
+// GetConnectionStatsPath returns the file persisting the statistics of the connection to the server.
+func (c *Config) GetConnectionStatsPath() string {
+	return filepath.Join(c.GetLogsPath(), connectionStatsFile)
+}
+
+// GetLogRotateOptions returns the rotation settings of the agent log file.
+func (c *Config) GetLogRotateOptions() log.RotateOptions {
+	maxSizeMB, maxBackups, maxAgeDays := c.LogMaxSizeMB, c.LogMaxBackups, c.LogMaxAgeDays
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultLogMaxSizeMB
+	}
+	if maxBackups <= 0 {
+		maxBackups = defaultLogMaxBackups
+	}
+	if maxAgeDays <= 0 {
+		maxAgeDays = defaultLogMaxAgeDays
+	}
+	return log.RotateOptions{
+		MaxSizeBytes: int64(maxSizeMB) << 20,
+		MaxBackups:   maxBackups,
+		MaxAge:       time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+}
+
+// GetDeployHookTimeout returns how long app deployment hooks may run.
+func (c *Config) GetDeployHookTimeout() time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if c.DeployHookTimeout <= 0 {
+		return defaultDeployHookTimeout
+	}
+	return time.Duration(c.DeployHookTimeout) * time.Second
+}
+
+// GetHealthWaitTimeout returns how long deployments wait for the services of an app to become healthy.
+func (c *Config) GetHealthWaitTimeout() time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if c.HealthWaitTimeout <= 0 {
+		return defaultHealthWaitTimeout
+	}
+	return time.Duration(c.HealthWaitTimeout) * time.Second
+}
+
+// GetUpdateDrainTimeout returns how long an agent update waits for in-flight app commands.
+func (c *Config) GetUpdateDrainTimeout() time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if c.UpdateDrainTimeout <= 0 {
+		return defaultUpdateDrainTimeout
+	}
+	return time.Duration(c.UpdateDrainTimeout) * time.Second
+}
+
+// GetShutdownGracePeriod returns how long the agent waits for in-flight operations before it is
+// forcefully stopped.
+func (c *Config) GetShutdownGracePeriod() time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if c.ShutdownGracePeriod <= 0 {
+		return defaultShutdownGracePeriod
+	}
+	return time.Duration(c.ShutdownGracePeriod) * time.Second
+}
+
+// GetRestartCooldown returns the minimum time between two restarts or redeploys of an app, zero when it is not
+// limited.
+func (c *Config) GetRestartCooldown() time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if c.RestartCooldown <= 0 {
+		return 0
+	}
+	return time.Duration(c.RestartCooldown) * time.Second
+}
+
+// GetQueryTimeout returns how long the named query may take.
+func (c *Config) GetQueryTimeout(queryName string) time.Duration {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
+	if seconds := c.QueryTimeouts[queryName]; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if c.QueryTimeout <= 0 {
+		return defaultQueryTimeout
+	}
+	return time.Duration(c.QueryTimeout) * time.Second
+}
+
+// GetConnectionTimeoutMin returns the timeout of a connection attempt after a successful connection.
+func (c *Config) GetConnectionTimeoutMin() time.Duration {
+	if c.ConnectionTimeoutMin <= 0 {
+		return defaultConnectionTimeoutMin
+	}
+	return time.Duration(c.ConnectionTimeoutMin) * time.Second
+}
+
+// GetConnectionTimeoutMax returns the timeout connection attempts grow to after consecutive failures. It
+// is never below GetConnectionTimeoutMin.
+func (c *Config) GetConnectionTimeoutMax() time.Duration {
+	maxTimeout := defaultConnectionTimeoutMax
+	if c.ConnectionTimeoutMax > 0 {
+		maxTimeout = time.Duration(c.ConnectionTimeoutMax) * time.Second
+	}
+	if minTimeout := c.GetConnectionTimeoutMin(); maxTimeout < minTimeout {
+		return minTimeout
+	}
+	return maxTimeout
+}
+
+// GetRegistrationMaxAttempts returns how many registration attempts are made before the agent gives up; zero
+// means no limit.
+func (c *Config) GetRegistrationMaxAttempts() int {
+	if c.RegistrationMaxAttempts <= 0 {
+		return 0
+	}
+	return c.RegistrationMaxAttempts
+}
+
+// GetRegistrationMaxDuration returns how long registration is retried before the agent gives up; zero means no
+// limit.
+func (c *Config) GetRegistrationMaxDuration() time.Duration {
+	if c.RegistrationMaxDuration <= 0 {
+		return 0
+	}
+	return time.Duration(c.RegistrationMaxDuration) * time.Second
+}
+
+// GetMetricsCPUSampleWindow returns the period the reported CPU usage is averaged over.
+func (c *Config) GetMetricsCPUSampleWindow() time.Duration {
+	if c.MetricsCPUSampleWindow <= 0 {
+		return defaultMetricsCPUSampleWindow
+	}
+	return time.Duration(c.MetricsCPUSampleWindow) * time.Second
+}
+
+// IsCommandAllowed reports whether the server request with the given name is processed.
+func (c *Config) IsCommandAllowed(name string) bool {
+	if slices.Contains(c.DeniedCommands, name) {
+		return false
+	}
+	return len(c.AllowedCommands) == 0 || slices.Contains(c.AllowedCommands, name)
+}
+
+// GetKeepaliveTime returns the idle time after which the agent pings the server. It is never below the
+// minimum gRPC clients allow.
+func (c *Config) GetKeepaliveTime() time.Duration {
+	if c.KeepaliveTimeSeconds <= 0 {
+		return defaultKeepaliveTime
+	}
+	return max(time.Duration(c.KeepaliveTimeSeconds)*time.Second, minKeepaliveTime)
+}
+
+// GetKeepaliveTimeout returns how long a ping may remain unacknowledged before the connection is closed.
+func (c *Config) GetKeepaliveTimeout() time.Duration {
+	if c.KeepaliveTimeoutSeconds == 0 {
+		return defaultKeepaliveTimeout
+	}
+	return max(time.Duration(c.KeepaliveTimeoutSeconds)*time.Second, minKeepaliveTimeout)
+}
+
+// GetKeepalivePermitWithoutStream reports whether the agent pings the server while no stream is open.
+func (c *Config) GetKeepalivePermitWithoutStream() bool {
+	return c.KeepalivePermitWithoutStream == nil || *c.KeepalivePermitWithoutStream
+}
+
 // GetKeepAppRevisions returns the number of application revisions to keep.
 func (c *Config) GetKeepAppRevisions() int {
-	return appsKeepRevisions
+	if c.RevisionRetention == 0 {
+		return appsKeepRevisions
+	}
+	if c.RevisionRetention < minAppsKeepRevisions {
+		return minAppsKeepRevisions
+	}
+	return c.RevisionRetention
 }
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetDecryptionKeyPaths(t *testing.T) {
+	cfg := &Config{BasePath: t.TempDir()}
+	if got := cfg.GetDecryptionKeyPaths(); !reflect.DeepEqual(got, []string{cfg.GetPrivateKeyPath()}) {
+		t.Errorf("Expected only the agent key without a keys directory, got %v", got)
+	}
+
+	cfg.DecryptionKeysDir = "keys"
+	keysDir := filepath.Join(cfg.BasePath, "keys")
+	if err := os.MkdirAll(filepath.Join(keysDir, "archive"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2024.key", "2023.key"} {
+		if err := os.WriteFile(filepath.Join(keysDir, name), []byte("key"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		cfg.GetPrivateKeyPath(),
+		filepath.Join(keysDir, "2023.key"),
+		filepath.Join(keysDir, "2024.key"),
+	}
+	if got := cfg.GetDecryptionKeyPaths(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestPrepareConfigRaisesKeepaliveSettingsToMinimums(t *testing.T) {
+	cfg := &Config{KeepaliveTimeSeconds: 5, KeepaliveTimeoutSeconds: -1}
+	prepareConfig(cfg)
+
+	if cfg.KeepaliveTimeSeconds != 10 || cfg.KeepaliveTimeoutSeconds != 1 {
+		t.Errorf("Expected keep-alive time 10 and timeout 1, got %d and %d", cfg.KeepaliveTimeSeconds, cfg.KeepaliveTimeoutSeconds)
+	}
+}
+
+func TestSaveConfigLeavesTheOriginalIntactAfterAnInterruptedWrite(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "agent.config.json")
+	if err := SaveConfig(&Config{AgentID: "agent-1", AgentStatus: AgentStatusRegistered, BasePath: t.TempDir()}, configPath); err != nil {
+		t.Fatalf("SaveConfig returned error: %v", err)
+	}
+
+	// A crash while saving leaves a partially written temporary file next to the config.
+	if err := os.WriteFile(configPath+".123456.tmp", []byte(`{"agent_id": "agent-2", "agent_st`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.AgentID != "agent-1" || cfg.AgentStatus != AgentStatusRegistered {
+		t.Errorf("Expected the original config, got agent %q with status %q", cfg.AgentID, cfg.AgentStatus)
+	}
+}
+
+func TestSaveConfigDoesNotCreateAPartialConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "agent.config.json")
+
+	// A crash before the first save completed leaves only the temporary file.
+	if err := os.WriteFile(configPath+".123456.tmp", []byte(`{"agent_id": "agent-1"`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no config before the first save, got %v", err)
+	}
+
+	if err := SaveConfig(&Config{AgentID: "agent-1", BasePath: t.TempDir()}, configPath); err != nil {
+		t.Fatalf("SaveConfig returned error: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil || cfg.AgentID != "agent-1" {
+		t.Fatalf("Expected the saved config, got %+v (%v)", cfg, err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "agent.config.json.*.tmp"))
+	if len(matches) != 1 {
+		t.Errorf("Expected only the stale temporary file to remain, got %v", matches)
+	}
+}
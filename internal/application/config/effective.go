@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces settings that must not leave the agent.
+const redactedValue = "[redacted]"
+
+// EffectiveSettings returns the settings of c by their JSON name as the agent applies them: unset settings
+// hold their defaults and disabled ones -1, so that the result may be saved as a config again. The agent
+// and device IDs are masked and credentials embedded in URLs are redacted.
+func (c *Config) EffectiveSettings() map[string]any {
+	hotSettingsMu.RLock()
+	effective := *c
+	hotSettingsMu.RUnlock()
+	e := &effective
+
+	e.DeployHookTimeout = seconds(e.GetDeployHookTimeout())
+	e.HealthWaitTimeout = seconds(e.GetHealthWaitTimeout())
+	e.UpdateDrainTimeout = seconds(e.GetUpdateDrainTimeout())
+	e.ShutdownGracePeriod = seconds(e.GetShutdownGracePeriod())
+	e.QueryTimeout = seconds(e.GetQueryTimeout(""))
+	e.DockerWaitTimeout = orDisabled(seconds(e.GetDockerWaitTimeout()))
+	e.ConnectionTimeoutMin = seconds(e.GetConnectionTimeoutMin())
+	e.ConnectionTimeoutMax = seconds(e.GetConnectionTimeoutMax())
+	e.MetricsCPUSampleWindow = seconds(e.GetMetricsCPUSampleWindow())
+	e.KeepaliveTimeSeconds = seconds(e.GetKeepaliveTime())
+	e.KeepaliveTimeoutSeconds = seconds(e.GetKeepaliveTimeout())
+	permitWithoutStream := e.GetKeepalivePermitWithoutStream()
+	e.KeepalivePermitWithoutStream = &permitWithoutStream
+	e.MaxLogBytes = orDisabled(e.GetMaxLogBytes())
+	e.ComposeRetryAttempts = orDisabled(e.GetComposeRetryAttempts())
+	e.ComposeRetryPatterns = e.GetComposeRetryPatterns()
+	e.RevisionRetention = e.GetKeepAppRevisions()
+	e.CertificatesFolder = e.GetCertificatesFolder()
+	e.SecretsDir = e.GetSecretsDir()
+	e.DirPerm = fmt.Sprintf("%04o", e.GetDirPerm())
+	e.FilePerm = fmt.Sprintf("%04o", e.GetFilePerm())
+	e.SensitiveFilePerm = fmt.Sprintf("%04o", e.GetSensitiveFilePerm())
+	rotate := e.GetLogRotateOptions()
+	e.LogMaxSizeMB = int(rotate.MaxSizeBytes >> 20)
+	e.LogMaxBackups = rotate.MaxBackups
+	e.LogMaxAgeDays = int(rotate.MaxAge / (24 * time.Hour))
+
+	e.AgentID = maskIdentifier(e.AgentID)
+	e.DeviceID = maskIdentifier(e.DeviceID)
+	e.CACertificateURL = redactURL(e.CACertificateURL)
+	e.RegistryMirror = redactURL(e.RegistryMirror)
+	e.PublicIPLookupURL = redactURL(e.PublicIPLookupURL)
+
+	settings := make(map[string]any)
+	value := reflect.ValueOf(effective)
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		settings[name] = value.Field(i).Interface()
+	}
+	return settings
+}
+
+// BuildOverrides returns the settings fixed when the agent was built, by name. Settings that were not set at
+// build time, and thus use their defaults, are omitted.
+func BuildOverrides() map[string]string {
+	overrides := make(map[string]string)
+	for name, value := range map[string]string{
+		"grpc_server_address": grpcServerAddress,
+		"api_base_url":        apiBaseURL,
+		"base_path":           basePath,
+		"orchestrator":        string(orchestrator),
+	} {
+		if value != "" {
+			overrides[name] = value
+		}
+	}
+	return overrides
+}
+
+// seconds returns d in whole seconds.
+func seconds(d time.Duration) int {
+	return int(d / time.Second)
+}
+
+// orDisabled returns -1, which disables a setting, for 0 and value otherwise.
+func orDisabled(value int) int {
+	if value == 0 {
+		return -1
+	}
+	return value
+}
+
+// maskIdentifier hides all but the last four characters of an identifier, which suffice to tell agents apart
+// in support requests.
+func maskIdentifier(id string) string {
+	if id == "" {
+		return ""
+	}
+	if len(id) <= 8 {
+		return redactedValue
+	}
+	return strings.Repeat("*", len(id)-4) + id[len(id)-4:]
+}
+
+// redactURL replaces the user information of a URL, which may hold a token or password. Values that do not parse are redacted entirely as they may still hold credentials.
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return redactedValue
+	}
+	if u.User != nil {
+		u.User = url.User("xxxxx")
+	}
+	return u.String()
+}
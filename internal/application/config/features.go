@@ -6,19 +6,25 @@ const (
 	FeatureDockerRegistries = "docker_registries"
 	FeatureDockerNetworks   = "docker_networks"
 	FeatureAppLogs          = "app_logs"
+	// FeatureCommandSignatures requires mutating server commands to be signed with the key configured by
+	// ServerSigningKeyPath.
+	FeatureCommandSignatures = "command_signatures"
 )
 
 // DefaultFeatureValues defines the default values for each feature
 var DefaultFeatureValues = map[string]bool{
-	FeatureAgentUpdate:      true,
-	FeatureEarlyAccess:      false,
-	FeatureDockerRegistries: true,
-	FeatureDockerNetworks:   true,
-	FeatureAppLogs:          true,
+	FeatureAgentUpdate:       true,
+	FeatureEarlyAccess:       false,
+	FeatureDockerRegistries:  true,
+	FeatureDockerNetworks:    true,
+	FeatureAppLogs:           true,
+	FeatureCommandSignatures: false,
 }
 
 // IsFeatureEnabled checks if a feature is enabled in the configuration.
 func (c *Config) IsFeatureEnabled(feature string) bool {
+	hotSettingsMu.RLock()
+	defer hotSettingsMu.RUnlock()
 	value, exists := c.Features[feature]
 	if !exists {
 		return DefaultFeatureValues[feature]
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Default permissions of the files written for app revisions.
+const (
+	defaultDirPerm           os.FileMode = 0o755
+	defaultFilePerm          os.FileMode = 0o644
+	defaultSensitiveFilePerm os.FileMode = 0o600
+)
+
+// ParseFileMode parses an octal permission such as "0750", "750" or "0o750". Only the permission bits
+// are accepted.
+func ParseFileMode(value string) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(value), "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || digits == "" {
+		return 0, fmt.Errorf("%q is not an octal permission", value)
+	}
+	if os.FileMode(mode)&^os.ModePerm != 0 {
+		return 0, fmt.Errorf("%q has bits beyond the permission bits", value)
+	}
+	return os.FileMode(mode), nil
+}
+
+// validatePermissions verifies that every configured permission parses.
+func validatePermissions(cfg *Config) error {
+	fields := []struct{ name, value string }{
+		{"dir_perm", cfg.DirPerm},
+		{"file_perm", cfg.FilePerm},
+		{"sensitive_file_perm", cfg.SensitiveFilePerm},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		if _, err := ParseFileMode(field.value); err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// fileModeOrDefault returns the parsed value, or def when it is empty or invalid.
+func fileModeOrDefault(value string, def os.FileMode) os.FileMode {
+	if value == "" {
+		return def
+	}
+	mode, err := ParseFileMode(value)
+	if err != nil {
+		return def
+	}
+	return mode
+}
+
+// GetDirPerm returns the permission of the directories written for app revisions.
+func (c *Config) GetDirPerm() os.FileMode {
+	return fileModeOrDefault(c.DirPerm, defaultDirPerm)
+}
+
+// GetFilePerm returns the permission of the non-sensitive files written for app revisions.
+func (c *Config) GetFilePerm() os.FileMode {
+	return fileModeOrDefault(c.FilePerm, defaultFilePerm)
+}
+
+// GetSensitiveFilePerm returns the permission of the files written for app revisions that may contain secrets.
+func (c *Config) GetSensitiveFilePerm() os.FileMode {
+	return fileModeOrDefault(c.SensitiveFilePerm, defaultSensitiveFilePerm)
+}
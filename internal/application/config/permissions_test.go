@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFileMode(t *testing.T) {
+	valid := map[string]os.FileMode{"0750": 0o750, "640": 0o640, "0o600": 0o600, "0777": 0o777}
+	for value, expected := range valid {
+		mode, err := ParseFileMode(value)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got %v", value, err)
+		} else if mode != expected {
+			t.Errorf("Expected %q to parse as %o, got %o", value, expected, mode)
+		}
+	}
+
+	for _, value := range []string{"", "rwx", "0789", "01777", "-1"} {
+		if _, err := ParseFileMode(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestPermissionGettersUseDefaults(t *testing.T) {
+	cfg := &Config{}
+	if cfg.GetDirPerm() != 0o755 || cfg.GetFilePerm() != 0o644 || cfg.GetSensitiveFilePerm() != 0o600 {
+		t.Errorf("Unexpected defaults %o, %o, %o", cfg.GetDirPerm(), cfg.GetFilePerm(), cfg.GetSensitiveFilePerm())
+	}
+
+	cfg = &Config{DirPerm: "0750", FilePerm: "0640", SensitiveFilePerm: "0400"}
+	if cfg.GetDirPerm() != 0o750 || cfg.GetFilePerm() != 0o640 || cfg.GetSensitiveFilePerm() != 0o400 {
+		t.Errorf("Unexpected permissions %o, %o, %o", cfg.GetDirPerm(), cfg.GetFilePerm(), cfg.GetSensitiveFilePerm())
+	}
+}
+
+func TestLoadConfigRejectsInvalidPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.config.json")
+	if err := os.WriteFile(path, []byte(`{"file_perm": "0899"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("Expected an invalid file_perm to be rejected")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"file_perm": "0640"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("Expected the config to load, got %v", err)
+	}
+	if cfg.GetFilePerm() != 0o640 {
+		t.Errorf("Expected file permission 0640, got %o", cfg.GetFilePerm())
+	}
+}
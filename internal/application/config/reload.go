@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// hotSettingsMu guards the hot settings of every Config, which are read while they are reloaded.
+var hotSettingsMu sync.RWMutex
+
+// hotSettings lists the settings that running agents apply in place with ApplyHotSettings. Changes of any
+// other setting require the agent to be restarted.
+var hotSettings = []string{
+	"log_level",
+	"log_format",
+	"features",
+	"deploy_hook_timeout",
+	"health_wait_timeout",
+	"update_drain_timeout",
+	"shutdown_grace_period",
+	"query_timeout",
+	"query_timeouts",
+	"restart_cooldown",
+}
+
+// coldFeatures lists the features that are only evaluated when the agent starts.
+var coldFeatures = []string{FeatureCommandSignatures}
+
+// RequiresRestart reports whether switching from current to next changes a setting that cannot be applied
+// in place, see hotSettings.
+func RequiresRestart(current, next *Config) bool {
+	for _, feature := range coldFeatures {
+		if current.IsFeatureEnabled(feature) != next.IsFeatureEnabled(feature) {
+			return true
+		}
+	}
+	currentSettings, err := coldSettings(current)
+	if err != nil {
+		return true
+	}
+	nextSettings, err := coldSettings(next)
+	if err != nil {
+		return true
+	}
+	return !reflect.DeepEqual(currentSettings, nextSettings)
+}
+
+// coldSettings returns the settings of cfg by their JSON name, without the hot settings.
+func coldSettings(cfg *Config) (map[string]any, error) {
+	hotSettingsMu.RLock()
+	data, err := json.Marshal(cfg)
+	hotSettingsMu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	for _, name := range hotSettings {
+		delete(settings, name)
+	}
+	return settings, nil
+}
+
+// ApplyHotSettings copies the hot settings of next into c. It is safe to call while c is in use.
+func (c *Config) ApplyHotSettings(next *Config) {
+	features := make(map[string]bool, len(next.Features))
+	for feature, enabled := range next.Features {
+		features[feature] = enabled
+	}
+	queryTimeouts := make(map[string]int, len(next.QueryTimeouts))
+	for name, seconds := range next.QueryTimeouts {
+		queryTimeouts[name] = seconds
+	}
+
+	hotSettingsMu.Lock()
+	defer hotSettingsMu.Unlock()
+	c.LogLevel, c.LogFormat = next.LogLevel, next.LogFormat
+	c.Features = features
+	c.DeployHookTimeout, c.HealthWaitTimeout = next.DeployHookTimeout, next.HealthWaitTimeout
+	c.UpdateDrainTimeout, c.ShutdownGracePeriod = next.UpdateDrainTimeout, next.ShutdownGracePeriod
+	c.QueryTimeout, c.QueryTimeouts = next.QueryTimeout, queryTimeouts
+	c.RestartCooldown = next.RestartCooldown
+}
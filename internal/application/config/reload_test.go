@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestRequiresRestart(t *testing.T) {
+	base := func() *Config {
+		return &Config{AgentID: "agent", LogLevel: "info", Features: map[string]bool{FeatureAppLogs: true}}
+	}
+
+	tests := []struct {
+		name   string
+		change func(*Config)
+		cold   bool
+	}{
+		{"unchanged", func(*Config) {}, false},
+		{"log level", func(c *Config) { c.LogLevel = "debug" }, false},
+		{"log format", func(c *Config) { c.LogFormat = "text" }, false},
+		{"feature flag", func(c *Config) { c.Features[FeatureAppLogs] = false }, false},
+		{"timeouts", func(c *Config) { c.DeployHookTimeout, c.ShutdownGracePeriod = 60, 10 }, false},
+		{"query timeouts", func(c *Config) { c.QueryTimeout, c.QueryTimeouts = 30, map[string]int{"GetAppLogs": 300} }, false},
+		{"restart cooldown", func(c *Config) { c.RestartCooldown = 60 }, false},
+		{"command signatures", func(c *Config) { c.Features[FeatureCommandSignatures] = true }, true},
+		{"agent id", func(c *Config) { c.AgentID = "other" }, true},
+		{"docker context", func(c *Config) { c.DockerContext = "remote" }, true},
+		{"connection timeout", func(c *Config) { c.ConnectionTimeoutMin = 5 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := base()
+			tt.change(next)
+			if got := RequiresRestart(base(), next); got != tt.cold {
+				t.Errorf("Expected RequiresRestart to be %v, got %v", tt.cold, got)
+			}
+		})
+	}
+}
+
+func TestApplyHotSettings(t *testing.T) {
+	current := &Config{AgentID: "agent", LogLevel: "info", Features: map[string]bool{FeatureAppLogs: true}}
+	next := &Config{AgentID: "agent", LogLevel: "debug", DeployHookTimeout: 60, Features: map[string]bool{FeatureAppLogs: false}}
+
+	current.ApplyHotSettings(next)
+	if current.LogLevel != "debug" || current.IsFeatureEnabled(FeatureAppLogs) || current.GetDeployHookTimeout().Seconds() != 60 {
+		t.Errorf("Expected the hot settings to be applied, got %+v", current)
+	}
+
+	next.Features[FeatureAppLogs] = true
+	if current.IsFeatureEnabled(FeatureAppLogs) {
+		t.Error("Expected the features to be copied")
+	}
+}
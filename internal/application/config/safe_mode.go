@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// safeModeFile marks the agent as being in safe mode while it exists below the base path.
+const safeModeFile = "safe_mode"
+
+// GetSafeModePath returns the path of the file that keeps the agent in safe mode while it exists.
+func (c *Config) GetSafeModePath() string {
+	return c.buildPath(safeModeFile)
+}
+
+// EnableSafeMode puts the agent into safe mode by creating the safe mode file. Safe mode outlives restarts of
+// the agent until ClearSafeMode is called or the file is removed.
+func (c *Config) EnableSafeMode() error {
+	content := fmt.Sprintf("safe mode enabled at %s\n", time.Now().UTC().Format(time.RFC3339))
+	if err := os.WriteFile(c.GetSafeModePath(), []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to enable safe mode: %w", err)
+	}
+	return nil
+}
+
+// SafeMode reports whether the agent is in safe mode, i.e. the safe mode file exists.
+func (c *Config) SafeMode() bool {
+	_, err := os.Stat(c.GetSafeModePath())
+	return err == nil
+}
+
+// ClearSafeMode leaves safe mode by removing the safe mode file and reports whether the agent was in safe mode.
+func (c *Config) ClearSafeMode() (bool, error) {
+	err := os.Remove(c.GetSafeModePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to clear safe mode: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestSafeModeLastsUntilCleared(t *testing.T) {
+	cfg := &Config{BasePath: t.TempDir()}
+	if cfg.SafeMode() {
+		t.Fatal("Expected the agent not to start in safe mode")
+	}
+
+	if err := cfg.EnableSafeMode(); err != nil {
+		t.Fatalf("EnableSafeMode returned error: %v", err)
+	}
+	if !(&Config{BasePath: cfg.BasePath}).SafeMode() {
+		t.Error("Expected safe mode to be kept for a restarted agent")
+	}
+
+	cleared, err := cfg.ClearSafeMode()
+	if err != nil || !cleared {
+		t.Fatalf("Expected safe mode to be cleared, got %v, %v", cleared, err)
+	}
+	if cfg.SafeMode() {
+		t.Error("Expected safe mode to be off after clearing it")
+	}
+	if cleared, err := cfg.ClearSafeMode(); err != nil || cleared {
+		t.Errorf("Expected clearing twice to report no safe mode, got %v, %v", cleared, err)
+	}
+}
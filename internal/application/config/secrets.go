@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"winterflow-agent/pkg/certs"
+	"winterflow-agent/pkg/log"
+)
+
+// encryptedValuePrefix marks config values that are stored encrypted with the
+// agent's private key. Values without the prefix are treated as plaintext,
+// which keeps configs written by older agents readable.
+const encryptedValuePrefix = "enc:"
+
+// secretFields returns pointers to the config fields that are encrypted at
+// rest when EncryptSecrets is enabled.
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"agent_id": &c.AgentID,
+	}
+}
+
+// encryptSecrets encrypts every secret field in place. Fields that are empty
+// or already encrypted are left untouched. When the private key does not
+// exist yet (e.g. before registration) the values stay in plaintext and are
+// encrypted on a later save.
+func encryptSecrets(cfg *Config) error {
+	keyPath := cfg.GetPrivateKeyPath()
+	if !certs.CertificateExists(keyPath) {
+		log.Warn("Private key not found, storing config secrets in plaintext", "path", keyPath)
+		return nil
+	}
+
+	for name, field := range cfg.secretFields() {
+		if *field == "" || strings.HasPrefix(*field, encryptedValuePrefix) {
+			continue
+		}
+		encrypted, err := certs.EncryptWithPrivateKey(keyPath, *field)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", name, err)
+		}
+		*field = encryptedValuePrefix + encrypted
+	}
+	return nil
+}
+
+// decryptSecrets decrypts every encrypted secret field in place. Plaintext
+// values are accepted as-is so that existing configs keep working and get
+// migrated the next time the config is saved.
+func decryptSecrets(cfg *Config) error {
+	keyPath := cfg.GetPrivateKeyPath()
+	for name, field := range cfg.secretFields() {
+		if !strings.HasPrefix(*field, encryptedValuePrefix) {
+			continue
+		}
+		decrypted, err := certs.DecryptWithPrivateKey(keyPath, strings.TrimPrefix(*field, encryptedValuePrefix))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", name, err)
+		}
+		*field = decrypted
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/pkg/certs"
+)
+
+// newEncryptedConfig returns a config rooted in a temporary directory with a
+// freshly generated agent private key and secret encryption enabled.
+func newEncryptedConfig(t *testing.T) (*Config, string) {
+	t.Helper()
+	baseDir := t.TempDir()
+	cfg := &Config{
+		AgentID:        "8c5e2a1e-7f0b-4a57-9d43-3d1c2f0e6b11",
+		AgentStatus:    AgentStatusRegistered,
+		BasePath:       baseDir,
+		EncryptSecrets: true,
+	}
+	if err := certs.GeneratePrivateKey(cfg.GetPrivateKeyPath()); err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	return cfg, filepath.Join(baseDir, "agent.config.json")
+}
+
+// readStoredAgentID returns the agent_id value exactly as written on disk.
+func readStoredAgentID(t *testing.T, configPath string) string {
+	t.Helper()
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	var stored struct {
+		AgentID string `json:"agent_id"`
+	}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		t.Fatalf("Failed to parse config file: %v", err)
+	}
+	return stored.AgentID
+}
+
+func TestEncryptedConfigRoundTrip(t *testing.T) {
+	cfg, configPath := newEncryptedConfig(t)
+	agentID := cfg.AgentID
+
+	if err := SaveConfig(cfg, configPath); err != nil {
+		t.Fatalf("SaveConfig returned error: %v", err)
+	}
+	if cfg.AgentID != agentID {
+		t.Errorf("Expected in-memory agent ID to stay plaintext, got %q", cfg.AgentID)
+	}
+
+	stored := readStoredAgentID(t, configPath)
+	if !strings.HasPrefix(stored, encryptedValuePrefix) || strings.Contains(stored, agentID) {
+		t.Errorf("Expected agent ID to be encrypted on disk, got %q", stored)
+	}
+
+	loaded, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if loaded.AgentID != agentID {
+		t.Errorf("Expected decrypted agent ID %q, got %q", agentID, loaded.AgentID)
+	}
+}
+
+func TestPlaintextConfigMigratesOnSave(t *testing.T) {
+	cfg, configPath := newEncryptedConfig(t)
+	agentID := cfg.AgentID
+
+	plaintext := `{"agent_id":"` + agentID + `","agent_status":"registered","base_path":"` + cfg.BasePath + `","encrypt_secrets":true}`
+	if err := os.WriteFile(configPath, []byte(plaintext), 0o600); err != nil {
+		t.Fatalf("Failed to write plaintext config: %v", err)
+	}
+
+	loaded, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if loaded.AgentID != agentID {
+		t.Fatalf("Expected plaintext agent ID %q, got %q", agentID, loaded.AgentID)
+	}
+
+	if err := SaveConfig(loaded, configPath); err != nil {
+		t.Fatalf("SaveConfig returned error: %v", err)
+	}
+	if stored := readStoredAgentID(t, configPath); !strings.HasPrefix(stored, encryptedValuePrefix) {
+		t.Errorf("Expected agent ID to be migrated to encrypted form, got %q", stored)
+	}
+}
+
+func TestConfigWithoutEncryptionStaysPlaintext(t *testing.T) {
+	cfg, configPath := newEncryptedConfig(t)
+	cfg.EncryptSecrets = false
+
+	if err := SaveConfig(cfg, configPath); err != nil {
+		t.Fatalf("SaveConfig returned error: %v", err)
+	}
+	if stored := readStoredAgentID(t, configPath); stored != cfg.AgentID {
+		t.Errorf("Expected plaintext agent ID %q, got %q", cfg.AgentID, stored)
+	}
+}
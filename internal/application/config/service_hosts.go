@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// hostGateway is the special extra_hosts address Docker resolves to the IP of the host.
+const hostGateway = "host-gateway"
+
+// validateServiceHosts verifies that service_dns lists IP addresses and that service_extra_hosts maps valid
+// host names to IP addresses or host-gateway.
+func validateServiceHosts(cfg *Config) error {
+	for _, server := range cfg.ServiceDNS {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("service_dns: %q is not an IP address", server)
+		}
+	}
+	for host, address := range cfg.ServiceExtraHosts {
+		if host == "" || strings.ContainsAny(host, ": \t=") {
+			return fmt.Errorf("service_extra_hosts: %q is not a valid host name", host)
+		}
+		if address != hostGateway && net.ParseIP(address) == nil {
+			return fmt.Errorf("service_extra_hosts: address %q of %s is neither an IP address nor %s", address, host, hostGateway)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestValidateServiceHosts(t *testing.T) {
+	valid := &Config{
+		ServiceDNS:        []string{"10.0.0.53", "2001:db8::53"},
+		ServiceExtraHosts: map[string]string{"db.internal": "10.0.0.5", "host.docker.internal": "host-gateway"},
+	}
+	if err := validateServiceHosts(valid); err != nil {
+		t.Errorf("Expected the service hosts to be valid, got %v", err)
+	}
+
+	invalid := []*Config{
+		{ServiceDNS: []string{"dns.example.com"}},
+		{ServiceExtraHosts: map[string]string{"": "10.0.0.5"}},
+		{ServiceExtraHosts: map[string]string{"db:internal": "10.0.0.5"}},
+		{ServiceExtraHosts: map[string]string{"db.internal": "not-an-ip"}},
+	}
+	for _, cfg := range invalid {
+		if err := validateServiceHosts(cfg); err == nil {
+			t.Errorf("Expected %+v to be rejected", cfg)
+		}
+	}
+}
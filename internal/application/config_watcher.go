@@ -8,15 +8,19 @@ import (
 	"winterflow-agent/pkg/log"
 )
 
-// ConfigWatcher watches a configuration file for changes
+// ConfigWatcher watches a configuration file for changes. Changes of hot settings, see
+// config.RequiresRestart, are applied to the current configuration in place; onChange is only called
+// for changes that require the agent to be restarted.
 type ConfigWatcher struct {
 	fileWatcher *files.FileWatcher
+	current     *config.Config
 	onChange    func(*config.Config)
 }
 
-// NewConfigWatcher creates a new configuration file watcher
-func NewConfigWatcher(configPath string, onChange func(*config.Config)) *ConfigWatcher {
+// NewConfigWatcher creates a new configuration file watcher for the configuration current was loaded from
+func NewConfigWatcher(configPath string, current *config.Config, onChange func(*config.Config)) *ConfigWatcher {
 	cw := &ConfigWatcher{
+		current:  current,
 		onChange: onChange,
 	}
 
@@ -49,12 +53,28 @@ func (w *ConfigWatcher) handleFileChange(filePath string) {
 		return
 	}
 
+	if w.current != nil && !config.RequiresRestart(w.current, newConfig) {
+		w.applyInPlace(newConfig)
+		return
+	}
+
 	// Call the onChange callback with the new configuration
 	if w.onChange != nil {
 		w.onChange(newConfig)
 	}
 }
 
+// applyInPlace applies the hot settings of newConfig to the current configuration without restarting
+// the agent.
+func (w *ConfigWatcher) applyInPlace(newConfig *config.Config) {
+	logChanged := w.current.LogLevel != newConfig.LogLevel || w.current.LogFormat != newConfig.LogFormat
+	w.current.ApplyHotSettings(newConfig)
+	if logChanged {
+		log.InitLog(newConfig.LogLevel, newConfig.LogFormat)
+	}
+	log.Info("Configuration reloaded in place", "log_level", newConfig.LogLevel, "log_format", newConfig.LogFormat)
+}
+
 // SetInterval sets the interval for checking file changes
 func (w *ConfigWatcher) SetInterval(interval time.Duration) {
 	w.fileWatcher.SetInterval(interval)
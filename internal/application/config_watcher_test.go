@@ -0,0 +1,44 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// writeConfigFile writes the JSON configuration to path.
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigWatcherAppliesHotChangesInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.config.json")
+	writeConfigFile(t, path, `{"agent_id":"agent","agent_status":"registered","log_level":"info"}`)
+	current, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarts := 0
+	w := NewConfigWatcher(path, current, func(*config.Config) { restarts++ })
+
+	writeConfigFile(t, path, `{"agent_id":"agent","agent_status":"registered","log_level":"debug","features":{"app_logs":false}}`)
+	w.handleFileChange(path)
+	if restarts != 0 {
+		t.Fatalf("Expected a log level change not to restart the agent, got %d restarts", restarts)
+	}
+	if current.LogLevel != "debug" || current.IsFeatureEnabled(config.FeatureAppLogs) {
+		t.Errorf("Expected the change to be applied to the current configuration, got log level %q", current.LogLevel)
+	}
+
+	writeConfigFile(t, path, `{"agent_id":"agent","agent_status":"registered","log_level":"debug","docker_context":"remote"}`)
+	w.handleFileChange(path)
+	if restarts != 1 {
+		t.Errorf("Expected a docker context change to restart the agent, got %d restarts", restarts)
+	}
+}
@@ -0,0 +1,11 @@
+package application
+
+import (
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/infra/connection_stats"
+)
+
+func NewConnectionStatsRepository(config *config.Config) repository.ConnectionStatsRepository {
+	return connection_stats.NewConnectionStatsRepository(config.GetConnectionStatsPath())
+}
@@ -0,0 +1,10 @@
+package check_for_update
+
+// CheckForUpdateQuery represents a query to compare the version of the agent with its latest release.
+// It contains no fields as the operation does not require additional input.
+type CheckForUpdateQuery struct{}
+
+// Name returns the unique name of the query so that the CQRS bus can route it.
+func (q CheckForUpdateQuery) Name() string {
+	return "CheckForUpdate"
+}
@@ -0,0 +1,164 @@
+package check_for_update
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/version"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/pkg/log"
+)
+
+// ErrRateLimited is returned when the releases are rate limited and no earlier check can be returned instead.
+var ErrRateLimited = errors.New("release lookup is rate limited")
+
+const (
+	// requestTimeout bounds the lookup of the latest release.
+	requestTimeout = 15 * time.Second
+	// defaultRateLimitBackoff is how long the releases are not looked up after a rate limited lookup that
+	// did not say when to retry.
+	defaultRateLimitBackoff = time.Minute
+)
+
+// CheckForUpdateQueryHandler handles the CheckForUpdateQuery.
+type CheckForUpdateQueryHandler struct {
+	// releasesURL is the download URL of the releases, e.g. https://github.com/<owner>/<repo>/releases/download.
+	releasesURL string
+	client      *http.Client
+	// agentVersion, numericVersion and now are replaced in tests.
+	agentVersion   func() string
+	numericVersion func() int
+	now            func() time.Time
+
+	mu sync.Mutex
+	// last is the result of the last successful check.
+	last *dto.CheckForUpdateResult
+	// retryAt is when the releases may be looked up again after a rate limited lookup.
+	retryAt time.Time
+}
+
+// Handle executes the CheckForUpdateQuery. While GitHub rate limits the lookup, the result of the last check is
+// returned marked as cached, or ErrRateLimited when there was none.
+func (h *CheckForUpdateQueryHandler) Handle(query CheckForUpdateQuery) (*dto.CheckForUpdateResult, error) {
+	log.Info("Processing check for update query")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	if now.Before(h.retryAt) {
+		return h.rateLimited(h.retryAt.Sub(now))
+	}
+
+	latest, retryAfter, err := h.latestRelease()
+	if err != nil {
+		return nil, err
+	}
+	if retryAfter > 0 {
+		h.retryAt = now.Add(retryAfter)
+		log.Warn("Release lookup is rate limited", "retry_after", retryAfter)
+		return h.rateLimited(retryAfter)
+	}
+
+	numericLatest := version.ParseNumericVersion(latest)
+	if numericLatest == 0 {
+		return nil, fmt.Errorf("latest release %q is not a version", latest)
+	}
+	h.last = &dto.CheckForUpdateResult{
+		CurrentVersion:  h.agentVersion(),
+		LatestVersion:   latest,
+		UpdateAvailable: numericLatest > h.numericVersion(),
+		CheckedAt:       now,
+	}
+	log.Info("Checked for agent update", "current_version", h.last.CurrentVersion, "latest_version", latest, "update_available", h.last.UpdateAvailable)
+
+	result := *h.last
+	return &result, nil
+}
+
+// rateLimited returns the result of the last check marked as cached, or ErrRateLimited when there was none.
+func (h *CheckForUpdateQueryHandler) rateLimited(remaining time.Duration) (*dto.CheckForUpdateResult, error) {
+	if h.last == nil {
+		return nil, fmt.Errorf("%w: retry in %s", ErrRateLimited, remaining.Round(time.Second))
+	}
+	result := *h.last
+	result.Cached = true
+	return &result, nil
+}
+
+// latestRelease returns the tag of the latest release, which GitHub redirects <releases>/latest to. A positive
+// retryAfter reports that the lookup was rate limited.
+func (h *CheckForUpdateQueryHandler) latestRelease() (tag string, retryAfter time.Duration, err error) {
+	latestURL := strings.TrimSuffix(strings.TrimSuffix(h.releasesURL, "/"), "/download") + "/latest"
+	resp, err := h.client.Get(latestURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if wait, limited := rateLimit(resp, h.now()); limited {
+		return "", wait, nil
+	}
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		return "", 0, fmt.Errorf("failed to look up the latest release: unexpected status %s", resp.Status)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	dir, tag := path.Split(location.Path)
+	if tag == "" || path.Base(dir) != "tag" {
+		return "", 0, fmt.Errorf("failed to look up the latest release: unexpected redirect to %s", location.Redacted())
+	}
+	if tag, err = url.PathUnescape(tag); err != nil {
+		return "", 0, fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	return tag, 0, nil
+}
+
+// rateLimit reports whether resp rejected the lookup because of rate limiting and how long to wait: GitHub
+// answers 429, or 403 with no requests remaining, and names the wait in Retry-After or the time the limit is
+// reset in X-RateLimit-Reset.
+func rateLimit(resp *http.Response, now time.Time) (time.Duration, bool) {
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0")
+	if !limited {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := time.Unix(reset, 0).Sub(now); wait > 0 {
+			return wait, true
+		}
+	}
+	return defaultRateLimitBackoff, true
+}
+
+// NewCheckForUpdateQueryHandler creates a new CheckForUpdateQueryHandler looking up the latest release next to
+// the releases agent updates are downloaded from.
+func NewCheckForUpdateQueryHandler(cfg *config.Config) *CheckForUpdateQueryHandler {
+	return &CheckForUpdateQueryHandler{
+		releasesURL: cfg.GetGitHubReleasesURL(),
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// The redirect of the latest release names its tag.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		agentVersion:   version.GetVersion,
+		numericVersion: version.GetNumericVersion,
+		now:            time.Now,
+	}
+}
@@ -0,0 +1,145 @@
+package check_for_update
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// fakeReleases serves the latest release redirect of GitHub until it is told to rate limit.
+type fakeReleases struct {
+	latest  string
+	limited func(w http.ResponseWriter)
+	hits    int
+}
+
+func (f *fakeReleases) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.hits++
+	if r.URL.Path != "/flowmitry/winterflow-agent/releases/latest" {
+		http.NotFound(w, r)
+		return
+	}
+	if f.limited != nil {
+		f.limited(w)
+		return
+	}
+	http.Redirect(w, r, "/flowmitry/winterflow-agent/releases/tag/"+f.latest, http.StatusFound)
+}
+
+// newHandler returns a handler of agent version 1.2.3 looking up the releases of server, with a clock that is
+// advanced through the returned pointer.
+func newHandler(t *testing.T, releases *fakeReleases) (*CheckForUpdateQueryHandler, *time.Time) {
+	t.Helper()
+	server := httptest.NewServer(releases)
+	t.Cleanup(server.Close)
+
+	h := NewCheckForUpdateQueryHandler(&config.Config{})
+	h.releasesURL = server.URL + "/flowmitry/winterflow-agent/releases/download"
+	h.agentVersion = func() string { return "1.2.3" }
+	h.numericVersion = func() int { return 1002003 }
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	return h, &now
+}
+
+func TestCheckForUpdateReportsANewerRelease(t *testing.T) {
+	h, now := newHandler(t, &fakeReleases{latest: "v1.3.0"})
+
+	result, err := h.Handle(CheckForUpdateQuery{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.UpdateAvailable || result.LatestVersion != "v1.3.0" || result.CurrentVersion != "1.2.3" {
+		t.Errorf("Expected an update to v1.3.0, got %+v", result)
+	}
+	if !result.CheckedAt.Equal(*now) || result.Cached {
+		t.Errorf("Expected a fresh check, got %+v", result)
+	}
+}
+
+func TestCheckForUpdateReportsNoUpdateForTheSameOrAnOlderRelease(t *testing.T) {
+	for _, latest := range []string{"1.2.3", "v1.2.0"} {
+		h, _ := newHandler(t, &fakeReleases{latest: latest})
+		result, err := h.Handle(CheckForUpdateQuery{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.UpdateAvailable {
+			t.Errorf("Expected no update for release %s, got %+v", latest, result)
+		}
+	}
+}
+
+func TestCheckForUpdateRejectsTagsThatAreNoVersion(t *testing.T) {
+	h, _ := newHandler(t, &fakeReleases{latest: "nightly"})
+	if _, err := h.Handle(CheckForUpdateQuery{}); err == nil || !strings.Contains(err.Error(), `"nightly" is not a version`) {
+		t.Fatalf("Expected the tag to be rejected, got %v", err)
+	}
+}
+
+func TestCheckForUpdateReturnsTheLastCheckWhileRateLimited(t *testing.T) {
+	releases := &fakeReleases{latest: "v1.3.0"}
+	h, now := newHandler(t, releases)
+	if _, err := h.Handle(CheckForUpdateQuery{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkedAt := *now
+
+	releases.limited = func(w http.ResponseWriter) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}
+	*now = now.Add(time.Hour)
+	result, err := h.Handle(CheckForUpdateQuery{})
+	if err != nil {
+		t.Fatalf("Expected the last check while rate limited, got %v", err)
+	}
+	if !result.Cached || result.LatestVersion != "v1.3.0" || !result.CheckedAt.Equal(checkedAt) {
+		t.Errorf("Expected the cached check of v1.3.0, got %+v", result)
+	}
+
+	// The releases are not looked up again until the limit is reset.
+	hits := releases.hits
+	*now = now.Add(5 * time.Minute)
+	if result, err := h.Handle(CheckForUpdateQuery{}); err != nil || !result.Cached {
+		t.Fatalf("Expected the cached check, got %+v, %v", result, err)
+	}
+	if releases.hits != hits {
+		t.Errorf("Expected no lookup before the reset, got %d", releases.hits-hits)
+	}
+
+	releases.limited = nil
+	releases.latest = "v1.4.0"
+	*now = now.Add(5 * time.Minute)
+	if result, err := h.Handle(CheckForUpdateQuery{}); err != nil || result.Cached || result.LatestVersion != "v1.4.0" {
+		t.Fatalf("Expected a fresh check after the reset, got %+v, %v", result, err)
+	}
+}
+
+func TestCheckForUpdateFailsWhenRateLimitedWithoutAnEarlierCheck(t *testing.T) {
+	h, _ := newHandler(t, &fakeReleases{limited: func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "90")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}})
+
+	_, err := h.Handle(CheckForUpdateQuery{})
+	if !errors.Is(err, ErrRateLimited) || !strings.Contains(err.Error(), "retry in 1m30s") {
+		t.Fatalf("Expected the lookup to be rate limited, got %v", err)
+	}
+}
+
+func TestCheckForUpdateFailsOnUnexpectedResponses(t *testing.T) {
+	h, _ := newHandler(t, &fakeReleases{limited: func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusForbidden)
+	}})
+	if _, err := h.Handle(CheckForUpdateQuery{}); err == nil || errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected a forbidden lookup with requests remaining to fail, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package export_app
+
+// ExportAppQuery represents a query to export the template tree of an application as a tarball
+type ExportAppQuery struct {
+	AppID string
+	// AllRevisions exports every stored revision instead of only the latest one.
+	AllRevisions bool
+}
+
+// Name returns the name of the query
+func (q ExportAppQuery) Name() string {
+	return "ExportApp"
+}
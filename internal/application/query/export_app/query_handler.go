@@ -0,0 +1,124 @@
+package export_app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/internal/domain/service/util"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+)
+
+// encryptedPlaceholder replaces secret values in the exported bundle. ImportApp fills it in with the
+// current value of the app the bundle is imported over and rejects the bundle when there is none.
+const encryptedPlaceholder = "<encrypted>"
+
+// ExportAppQueryHandler handles the ExportAppQuery
+type ExportAppQueryHandler struct {
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the ExportAppQuery and returns the result
+func (h *ExportAppQueryHandler) Handle(query ExportAppQuery) (*dto.ExportAppResult, error) {
+	log.Info("Processing export app request", "app_id", query.AppID, "all_revisions", query.AllRevisions)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	revisions, err := h.VersionService.GetAppRevisions(query.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions for app %s: %w", query.AppID, err)
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no revisions found for app %s", query.AppID)
+	}
+	if !query.AllRevisions {
+		revisions = revisions[len(revisions)-1:]
+	}
+
+	// Collect the configuration of every exported revision so the filter knows which values are secret.
+	configs := make(map[string]*model.AppConfig, len(revisions))
+	for _, revision := range revisions {
+		revisionDir := h.VersionService.GetRevisionDir(query.AppID, revision)
+		appConfig, _, err := orchestrator.ReadAppConfig(revisionDir)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config of revision %d: %w", revision, err)
+		}
+		configs[strconv.FormatUint(uint64(revision), 10)] = appConfig
+	}
+
+	appDir := filepath.Dir(h.VersionService.GetRevisionDir(query.AppID, revisions[0]))
+	var archive bytes.Buffer
+	if err := util.WriteTarGz(&archive, appDir, exportFilter(configs)); err != nil {
+		return nil, fmt.Errorf("error exporting app %s: %w", query.AppID, err)
+	}
+
+	return &dto.ExportAppResult{
+		AppID:     query.AppID,
+		Revisions: revisions,
+		Archive:   archive.Bytes(),
+	}, nil
+}
+
+// exportFilter limits the archive to the revisions in configs and redacts secrets: encrypted variables
+// and files are replaced with placeholders and host secret files under vars/secrets are left out. The
+// bundle therefore holds no secrets of its own; they are only restored when it is imported over an app
+// that still has them.
+func exportFilter(configs map[string]*model.AppConfig) util.ArchiveFilter {
+	return func(relPath string, content []byte) ([]byte, bool) {
+		revision, rest, found := strings.Cut(relPath, "/")
+		appConfig, ok := configs[revision]
+		if !found || !ok {
+			return nil, false
+		}
+
+		switch {
+		case rest == "vars/values.json":
+			redacted, err := redactVariables(appConfig, content)
+			if err != nil {
+				log.Warn("Excluding unreadable variables from export", "path", relPath, "error", err)
+				return nil, false
+			}
+			return redacted, true
+		case strings.HasPrefix(rest, "vars/secrets/"):
+			return nil, false
+		case strings.HasPrefix(rest, "files/"):
+			name := strings.TrimPrefix(rest, "files/")
+			for _, f := range appConfig.Files {
+				if f.IsEncrypted && strings.TrimLeft(path.Clean(filepath.ToSlash(f.Name)), "/") == name {
+					return []byte(encryptedPlaceholder), true
+				}
+			}
+		}
+		return content, true
+	}
+}
+
+// redactVariables replaces the values of encrypted variables in a values.json document.
+func redactVariables(appConfig *model.AppConfig, content []byte) ([]byte, error) {
+	var values map[string]interface{}
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+	for _, v := range appConfig.Variables {
+		if _, exists := values[v.Name]; exists && v.IsEncrypted {
+			values[v.Name] = encryptedPlaceholder
+		}
+	}
+	return json.MarshalIndent(values, "", "  ")
+}
+
+// NewExportAppQueryHandler creates a new ExportAppQueryHandler
+func NewExportAppQueryHandler(versionService app.RevisionServiceInterface) *ExportAppQueryHandler {
+	return &ExportAppQueryHandler{
+		VersionService: versionService,
+	}
+}
@@ -0,0 +1,10 @@
+package get_agent_config
+
+// GetAgentConfigQuery represents a query to retrieve the effective configuration of the agent.
+// It contains no fields as the operation does not require additional input.
+type GetAgentConfigQuery struct{}
+
+// Name returns the unique name of the query so that the CQRS bus can route it.
+func (q GetAgentConfigQuery) Name() string {
+	return "GetAgentConfig"
+}
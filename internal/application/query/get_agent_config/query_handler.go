@@ -0,0 +1,28 @@
+package get_agent_config
+
+import (
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/pkg/log"
+)
+
+// GetAgentConfigQueryHandler handles the GetAgentConfigQuery.
+type GetAgentConfigQueryHandler struct {
+	config *config.Config
+}
+
+// Handle executes the GetAgentConfigQuery. The settings are redacted, see config.Config.EffectiveSettings.
+func (h *GetAgentConfigQueryHandler) Handle(query GetAgentConfigQuery) (*dto.GetAgentConfigResult, error) {
+	log.Info("Processing get agent config query")
+
+	return &dto.GetAgentConfigResult{
+		Settings:          h.config.EffectiveSettings(),
+		BuildOverrides:    config.BuildOverrides(),
+		GRPCServerAddress: h.config.GetGRPCServerAddress(),
+	}, nil
+}
+
+// NewGetAgentConfigQueryHandler creates a new GetAgentConfigQueryHandler.
+func NewGetAgentConfigQueryHandler(cfg *config.Config) *GetAgentConfigQueryHandler {
+	return &GetAgentConfigQueryHandler{config: cfg}
+}
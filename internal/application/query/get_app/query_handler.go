@@ -55,14 +55,9 @@ func (h *GetAppQueryHandler) Handle(query GetAppQuery) (*model.AppDetails, error
 	}
 
 	// 2. Load and parse config
-	configPath := filepath.Join(templatesDir, "config.json")
-	configBytes, err := os.ReadFile(configPath)
+	appConfig, err := readAppConfig(templatesDir)
 	if err != nil {
-		return nil, fmt.Errorf("error reading config file: %w", err)
-	}
-	appConfig, err := model.ParseAppConfig(configBytes)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing config: %w", err)
+		return nil, err
 	}
 
 	// 3. Build variables map
@@ -77,25 +72,49 @@ func (h *GetAppQueryHandler) Handle(query GetAppQuery) (*model.AppDetails, error
 		return nil, err
 	}
 
-	// 5. Get all versions for the app
+	// 5. Load the server supplied compose override, if any
+	composeOverride := h.loadComposeOverride(appConfig, filesDir)
+
+	// 6. Get all versions for the app
 	versions, err := h.VersionService.GetAppRevisions(appID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 6. Return App model
+	// 7. Return App model
 	return &model.AppDetails{
 		App: &model.App{
-			ID:        appID,
-			Config:    appConfig,
-			Variables: varsMap,
-			Files:     filesMap,
+			ID:              appID,
+			Config:          appConfig,
+			Variables:       varsMap,
+			Files:           filesMap,
+			ComposeOverride: composeOverride,
 		},
 		Revision:  targetVersion,
 		Revisions: versions,
 	}, nil
 }
 
+// readAppConfig reads the app configuration of a revision, which is JSON unless it was hand-edited as
+// YAML.
+func readAppConfig(templatesDir string) (*model.AppConfig, error) {
+	for _, name := range model.AppConfigFileNames {
+		configBytes, err := os.ReadFile(filepath.Join(templatesDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+		appConfig, err := model.ParseAppConfigFile(name, configBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing config: %w", err)
+		}
+		return appConfig, nil
+	}
+	return nil, fmt.Errorf("error reading config file: %w", os.ErrNotExist)
+}
+
 // loadVariables builds the final VariableMap taking into account encryption flags.
 func (h *GetAppQueryHandler) loadVariables(appConfig *model.AppConfig, varsDir string) (model.VariableMap, error) {
 	varsFilePath := filepath.Join(varsDir, "values.json")
@@ -161,6 +180,21 @@ func (h *GetAppQueryHandler) loadFiles(appConfig *model.AppConfig, filesDir stri
 	return files, nil
 }
 
+// loadComposeOverride returns the compose.override.yml stored next to the templates
+// unless it is one of the configured template files (already part of Files).
+func (h *GetAppQueryHandler) loadComposeOverride(appConfig *model.AppConfig, filesDir string) []byte {
+	for _, f := range appConfig.Files {
+		if filepath.Clean(f.Name) == model.ComposeOverrideFileName {
+			return nil
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(filesDir, model.ComposeOverrideFileName))
+	if err != nil {
+		return nil
+	}
+	return content
+}
+
 // NewGetAppQueryHandler creates a new GetAppQueryHandler
 func NewGetAppQueryHandler(versionService app.RevisionServiceInterface) *GetAppQueryHandler {
 	return &GetAppQueryHandler{
@@ -0,0 +1,14 @@
+package get_app_diff
+
+// GetAppDiffQuery represents a query to compare two revisions of an application
+type GetAppDiffQuery struct {
+	AppID        string
+	FromRevision uint32
+	// ToRevision is the revision compared against FromRevision. Zero selects the latest revision.
+	ToRevision uint32
+}
+
+// Name returns the name of the query
+func (q GetAppDiffQuery) Name() string {
+	return "GetAppDiff"
+}
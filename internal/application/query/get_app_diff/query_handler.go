@@ -0,0 +1,227 @@
+package get_app_diff
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+)
+
+// digests maps an entry name to the SHA-256 hash of its value.
+type digests map[string][sha256.Size]byte
+
+// GetAppDiffQueryHandler handles the GetAppDiffQuery
+type GetAppDiffQueryHandler struct {
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the GetAppDiffQuery and returns the result
+func (h *GetAppDiffQueryHandler) Handle(query GetAppDiffQuery) (*dto.GetAppDiffResult, error) {
+	log.Info("Processing get app diff request", "app_id", query.AppID, "from_revision", query.FromRevision, "to_revision", query.ToRevision)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if query.FromRevision == 0 {
+		return nil, fmt.Errorf("from revision is required")
+	}
+
+	toRevision := query.ToRevision
+	if toRevision == 0 {
+		latest, err := h.VersionService.GetLatestAppRevision(query.AppID)
+		if err != nil {
+			return nil, fmt.Errorf("error determining latest revision for app %s: %w", query.AppID, err)
+		}
+		if latest == 0 {
+			return nil, fmt.Errorf("no revisions found for app %s", query.AppID)
+		}
+		toRevision = latest
+	}
+
+	for _, revision := range []uint32{query.FromRevision, toRevision} {
+		exists, err := h.VersionService.ValidateAppRevision(query.AppID, revision)
+		if err != nil {
+			return nil, fmt.Errorf("error validating app revision: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("revision %d not found for app %s", revision, query.AppID)
+		}
+	}
+
+	result := &dto.GetAppDiffResult{
+		AppID:        query.AppID,
+		FromRevision: query.FromRevision,
+		ToRevision:   toRevision,
+	}
+
+	from, err := h.loadDigests(query.AppID, query.FromRevision)
+	if err != nil {
+		return nil, err
+	}
+	to, err := h.loadDigests(query.AppID, toRevision)
+	if err != nil {
+		return nil, err
+	}
+	result.Config = diffDigests(from.config, to.config)
+	result.Variables = diffDigests(from.variables, to.variables)
+	result.Files = diffDigests(from.files, to.files)
+	return result, nil
+}
+
+// revisionDigests holds the hashed content of a single revision.
+type revisionDigests struct {
+	config    digests
+	variables digests
+	files     digests
+}
+
+// loadDigests hashes the config fields, variables and files of a revision.
+func (h *GetAppDiffQueryHandler) loadDigests(appID string, revision uint32) (*revisionDigests, error) {
+	revisionDir := h.VersionService.GetRevisionDir(appID, revision)
+
+	appConfig, _, err := orchestrator.ReadAppConfig(revisionDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config of revision %d: %w", revision, err)
+	}
+	config, err := configDigests(appConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config of revision %d: %w", revision, err)
+	}
+	variables, err := variableDigests(h.VersionService.GetVarsDir(appID, revision))
+	if err != nil {
+		return nil, fmt.Errorf("error reading variables of revision %d: %w", revision, err)
+	}
+	files, err := fileDigests(h.VersionService.GetFilesDir(appID, revision))
+	if err != nil {
+		return nil, fmt.Errorf("error reading files of revision %d: %w", revision, err)
+	}
+	return &revisionDigests{config: config, variables: variables, files: files}, nil
+}
+
+// configDigests hashes every top-level field of the configuration in its canonical JSON encoding, so
+// formatting changes and switching between JSON and YAML are not reported.
+func configDigests(appConfig *model.AppConfig) (digests, error) {
+	data, err := json.Marshal(appConfig)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	result := make(digests, len(fields))
+	for name, value := range fields {
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = sha256.Sum256(canonical)
+	}
+	return result, nil
+}
+
+// variableDigests hashes the effective value of every variable, applying the same precedence as
+// rendering: values.json, then overrides.json, then one file per variable in secrets/.
+func variableDigests(varsDir string) (digests, error) {
+	result := make(digests)
+	for _, name := range []string{"values.json", "overrides.json"} {
+		data, err := os.ReadFile(filepath.Join(varsDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		for key, value := range values {
+			result[key] = sha256.Sum256([]byte(fmt.Sprintf("%v", value)))
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(varsDir, "secrets"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(varsDir, "secrets", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result[entry.Name()] = sha256.Sum256(data)
+	}
+	return result, nil
+}
+
+// fileDigests hashes every file below filesDir, keyed by its slash separated relative path.
+func fileDigests(filesDir string) (digests, error) {
+	result := make(digests)
+	err := filepath.WalkDir(filesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == filesDir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filesDir, path)
+		if err != nil {
+			return err
+		}
+		result[filepath.ToSlash(rel)] = sha256.Sum256(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diffDigests compares the entries of two revisions by name and hash.
+func diffDigests(from, to digests) dto.DiffEntries {
+	var diff dto.DiffEntries
+	for name, sum := range to {
+		previous, ok := from[name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+		case previous != sum:
+			diff.Modified = append(diff.Modified, name)
+		}
+	}
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+	return diff
+}
+
+// NewGetAppDiffQueryHandler creates a new GetAppDiffQueryHandler
+func NewGetAppDiffQueryHandler(versionService app.RevisionServiceInterface) *GetAppDiffQueryHandler {
+	return &GetAppDiffQueryHandler{
+		VersionService: versionService,
+	}
+}
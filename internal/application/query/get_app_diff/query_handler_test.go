@@ -0,0 +1,117 @@
+package get_app_diff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+const testAppID = "3f8b1c2d-9a4e-4c6b-8d2f-1e7a5b9c0d4e"
+
+func writeRevision(t *testing.T, revisionDir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(revisionDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func newHandler(t *testing.T, revisions ...map[string]string) *GetAppDiffQueryHandler {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	for i, files := range revisions {
+		writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), testAppID, string(rune('1'+i))), files)
+	}
+	return NewGetAppDiffQueryHandler(app.NewRevisionService(cfg))
+}
+
+func TestGetAppDiffDetectsChanges(t *testing.T) {
+	handler := newHandler(t,
+		map[string]string{
+			"config.json":            `{"id":"` + testAppID + `","name":"demo","variables":[]}`,
+			"vars/values.json":       `{"DB_USER":"admin","DB_PASSWORD":"old-secret","REMOVED":"x"}`,
+			"vars/secrets/API_TOKEN": "token",
+			"files/compose.yml":      "services: {}\n",
+			"files/old.conf":         "old\n",
+			"files/same.txt":         "same\n",
+		},
+		map[string]string{
+			"config.json":            `{ "id": "` + testAppID + `", "name": "renamed", "variables": [], "restart_policy_override": "always" }`,
+			"vars/values.json":       `{"DB_USER":"admin","DB_PASSWORD":"new-secret","ADDED":"y"}`,
+			"vars/secrets/API_TOKEN": "rotated",
+			"files/compose.yml":      "services:\n  web: {}\n",
+			"files/conf/new.conf":    "new\n",
+			"files/same.txt":         "same\n",
+		},
+	)
+
+	result, err := handler.Handle(GetAppDiffQuery{AppID: testAppID, FromRevision: 1})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.ToRevision != 2 {
+		t.Errorf("Expected the latest revision to be selected, got %d", result.ToRevision)
+	}
+
+	expected := &dto.GetAppDiffResult{
+		AppID:        testAppID,
+		FromRevision: 1,
+		ToRevision:   2,
+		Config: dto.DiffEntries{
+			Added:    []string{"restart_policy_override"},
+			Modified: []string{"name"},
+		},
+		Variables: dto.DiffEntries{
+			Added:    []string{"ADDED"},
+			Removed:  []string{"REMOVED"},
+			Modified: []string{"API_TOKEN", "DB_PASSWORD"},
+		},
+		Files: dto.DiffEntries{
+			Added:    []string{"conf/new.conf"},
+			Removed:  []string{"old.conf"},
+			Modified: []string{"compose.yml"},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Unexpected diff:\n got  %+v\n want %+v", result, expected)
+	}
+}
+
+func TestGetAppDiffOfIdenticalRevisionsIsEmpty(t *testing.T) {
+	files := map[string]string{
+		"config.json":      `{"id":"` + testAppID + `","name":"demo"}`,
+		"vars/values.json": `{"DB_PASSWORD":"s3cret"}`,
+		"files/a.txt":      "a\n",
+	}
+	handler := newHandler(t, files, files)
+
+	result, err := handler.Handle(GetAppDiffQuery{AppID: testAppID, FromRevision: 1, ToRevision: 2})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	for name, entries := range map[string]dto.DiffEntries{"config": result.Config, "variables": result.Variables, "files": result.Files} {
+		if len(entries.Added)+len(entries.Removed)+len(entries.Modified) != 0 {
+			t.Errorf("Expected no %s changes, got %+v", name, entries)
+		}
+	}
+}
+
+func TestGetAppDiffRejectsUnknownRevision(t *testing.T) {
+	handler := newHandler(t, map[string]string{"config.json": `{}`})
+
+	_, err := handler.Handle(GetAppDiffQuery{AppID: testAppID, FromRevision: 1, ToRevision: 5})
+	if err == nil || !strings.Contains(err.Error(), "revision 5 not found") {
+		t.Fatalf("Expected unknown revision error, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package get_app_logs
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// Limits of grep patterns. RE2 matches in linear time, so bounding the size of the pattern and of its
+// compiled program bounds the cost of filtering every log line.
+const (
+	maxGrepPatternLength = 1024
+	maxGrepProgramSize   = 10000
+)
+
+// newLogFilter validates the level and compiles the grep pattern of a logs query.
+func newLogFilter(level model.LogLevel, pattern string) (model.LogFilter, error) {
+	if level < model.LogLevelUnknown || level > model.LogLevelFatal {
+		return model.LogFilter{}, fmt.Errorf("unknown log level %d", level)
+	}
+	filter := model.LogFilter{MinLevel: level}
+	if pattern == "" {
+		return filter, nil
+	}
+
+	if len(pattern) > maxGrepPatternLength {
+		return model.LogFilter{}, fmt.Errorf("grep pattern is longer than %d bytes", maxGrepPatternLength)
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return model.LogFilter{}, fmt.Errorf("invalid grep pattern: %w", err)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return model.LogFilter{}, fmt.Errorf("invalid grep pattern: %w", err)
+	}
+	if len(prog.Inst) > maxGrepProgramSize {
+		return model.LogFilter{}, fmt.Errorf("grep pattern is too complex")
+	}
+
+	filter.Pattern, err = regexp.Compile(pattern)
+	if err != nil {
+		return model.LogFilter{}, fmt.Errorf("invalid grep pattern: %w", err)
+	}
+	return filter, nil
+}
@@ -0,0 +1,61 @@
+package get_app_logs
+
+import (
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+func TestNewLogFilter(t *testing.T) {
+	filter, err := newLogFilter(model.LogLevelError, `timeout|refused`)
+	if err != nil {
+		t.Fatalf("newLogFilter returned error: %v", err)
+	}
+
+	testCases := []struct {
+		entry    model.LogEntry
+		expected bool
+	}{
+		{model.LogEntry{Level: model.LogLevelError, Message: "connection refused"}, true},
+		{model.LogEntry{Level: model.LogLevelFatal, Message: "read timeout"}, true},
+		{model.LogEntry{Level: model.LogLevelError, Message: "disk full"}, false},
+		{model.LogEntry{Level: model.LogLevelWarn, Message: "connection refused"}, false},
+		{model.LogEntry{Level: model.LogLevelUnknown, Message: "connection refused"}, false},
+	}
+	for _, tc := range testCases {
+		if got := filter.Matches(tc.entry); got != tc.expected {
+			t.Errorf("Matches(%+v) = %v, expected %v", tc.entry, got, tc.expected)
+		}
+	}
+}
+
+func TestNewLogFilterKeepsEverythingByDefault(t *testing.T) {
+	filter, err := newLogFilter(model.LogLevelUnknown, "")
+	if err != nil {
+		t.Fatalf("newLogFilter returned error: %v", err)
+	}
+	if !filter.Matches(model.LogEntry{Message: "anything"}) {
+		t.Error("Expected an empty filter to keep every entry")
+	}
+}
+
+func TestNewLogFilterRejectsInvalidFilters(t *testing.T) {
+	testCases := map[string]struct {
+		level   model.LogLevel
+		pattern string
+	}{
+		"Unknown level":     {level: 7},
+		"Invalid pattern":   {pattern: `(unclosed`},
+		"Backreference":     {pattern: `(a)\1`},
+		"Too long pattern":  {pattern: strings.Repeat("a", maxGrepPatternLength+1)},
+		"Too large program": {pattern: `((a{100}){100}){100}`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newLogFilter(tc.level, tc.pattern); err == nil {
+				t.Error("Expected the filter to be rejected")
+			}
+		})
+	}
+}
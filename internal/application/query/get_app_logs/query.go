@@ -1,5 +1,7 @@
 package get_app_logs
 
+import "winterflow-agent/internal/domain/model"
+
 // GetAppLogsQuery represents a query to retrieve logs for an application in a given time range.
 // When Since or Until is zero, the boundary is ignored (i.e. retrieve from the beginning or up to now).
 // All timestamps are Unix seconds.
@@ -7,8 +9,14 @@ type GetAppLogsQuery struct {
 	AppID string
 	Since int64
 	Until int64
-	// Tail limits the number of log lines returned. A value <= 0 returns all available logs.
+	// Tail limits the number of log lines read per container and stream. A value <= 0 reads all available logs.
 	Tail int32
+	// LevelFilter drops lines below the level, including lines of unknown level. LogLevelUnknown keeps all lines.
+	LevelFilter model.LogLevel
+	// GrepPattern is a regular expression (RE2 syntax) the message of a line must match; empty keeps all lines.
+	GrepPattern string
+	// ContainerID limits the logs to one container of the app, by ID, short ID or name; empty reads every container.
+	ContainerID string
 }
 
 // Name returns the name of the query.
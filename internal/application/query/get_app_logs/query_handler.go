@@ -1,6 +1,7 @@
 package get_app_logs
 
 import (
+	"context"
 	"fmt"
 	"winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/model"
@@ -14,8 +15,8 @@ type GetAppLogsQueryHandler struct {
 	config        *config.Config
 }
 
-// Handle executes the GetAppLogsQuery and returns the logs.
-func (h *GetAppLogsQueryHandler) Handle(query GetAppLogsQuery) (*model.Logs, error) {
+// Handle executes the GetAppLogsQuery and returns the logs. Reading the logs is stopped when ctx is done.
+func (h *GetAppLogsQueryHandler) Handle(ctx context.Context, query GetAppLogsQuery) (*model.Logs, error) {
 	if h.appRepository == nil {
 		return nil, fmt.Errorf("appRepository is not configured")
 	}
@@ -25,9 +26,15 @@ func (h *GetAppLogsQueryHandler) Handle(query GetAppLogsQuery) (*model.Logs, err
 		return nil, log.Errorf("logs operations are disabled by configuration")
 	}
 
-	log.Info("Processing get app logs request", "app_id", query.AppID, "tail", query.Tail)
+	log.Info("Processing get app logs request", "app_id", query.AppID, "container_id", query.ContainerID, "tail", query.Tail, "level_filter", query.LevelFilter, "grep_pattern", query.GrepPattern)
 
-	logs, err := h.appRepository.GetLogs(query.AppID, query.Since, query.Until, query.Tail)
+	filter, err := newLogFilter(query.LevelFilter, query.GrepPattern)
+	if err != nil {
+		return nil, log.Errorf("invalid log filter: %v", err)
+	}
+	filter.ContainerID = query.ContainerID
+
+	logs, err := h.appRepository.GetLogs(ctx, query.AppID, query.Since, query.Until, query.Tail, filter)
 	if err != nil {
 		log.Error("Error getting app logs", "error", err)
 		return nil, fmt.Errorf("failed to get app logs: %w", err)
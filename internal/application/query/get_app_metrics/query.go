@@ -0,0 +1,12 @@
+package get_app_metrics
+
+// GetAppMetricsQuery represents a query to retrieve the success and failure counts and the durations of the
+// lifecycle operations of an application, or of every application when AppID is empty.
+type GetAppMetricsQuery struct {
+	AppID string
+}
+
+// Name returns the name of the query.
+func (q GetAppMetricsQuery) Name() string {
+	return "GetAppMetrics"
+}
@@ -0,0 +1,29 @@
+package get_app_metrics
+
+import (
+	"fmt"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// GetAppMetricsQueryHandler handles the GetAppMetricsQuery.
+type GetAppMetricsQueryHandler struct {
+	source repository.OperationMetricsSource
+}
+
+// Handle executes the GetAppMetricsQuery and returns the metrics of the operations of the app.
+func (h *GetAppMetricsQueryHandler) Handle(query GetAppMetricsQuery) (*dto.GetAppMetricsResult, error) {
+	if h.source == nil {
+		return nil, fmt.Errorf("operation metrics are not available")
+	}
+
+	log.Info("Processing get app metrics request", "app_id", query.AppID)
+
+	return &dto.GetAppMetricsResult{Operations: h.source.AppOperationMetrics(query.AppID)}, nil
+}
+
+// NewGetAppMetricsQueryHandler creates a new GetAppMetricsQueryHandler.
+func NewGetAppMetricsQueryHandler(source repository.OperationMetricsSource) *GetAppMetricsQueryHandler {
+	return &GetAppMetricsQueryHandler{source: source}
+}
@@ -0,0 +1,11 @@
+package get_app_resources
+
+// GetAppResourcesQuery represents a query to retrieve the resource limits and usage of the containers of an application.
+type GetAppResourcesQuery struct {
+	AppID string
+}
+
+// Name returns the name of the query.
+func (q GetAppResourcesQuery) Name() string {
+	return "GetAppResources"
+}
@@ -0,0 +1,39 @@
+package get_app_resources
+
+import (
+	"fmt"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// GetAppResourcesQueryHandler handles the GetAppResourcesQuery.
+type GetAppResourcesQueryHandler struct {
+	appRepository repository.AppRepository
+}
+
+// Handle executes the GetAppResourcesQuery and returns the resources of the app containers.
+func (h *GetAppResourcesQueryHandler) Handle(query GetAppResourcesQuery) (*model.AppResources, error) {
+	if h.appRepository == nil {
+		return nil, fmt.Errorf("appRepository is not configured")
+	}
+
+	log.Info("Processing get app resources request", "app_id", query.AppID)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	resources, err := h.appRepository.GetAppResources(query.AppID)
+	if err != nil {
+		log.Error("Error getting app resources", "app_id", query.AppID, "error", err)
+		return nil, fmt.Errorf("failed to get app resources: %w", err)
+	}
+
+	return &resources, nil
+}
+
+// NewGetAppResourcesQueryHandler creates a new GetAppResourcesQueryHandler.
+func NewGetAppResourcesQueryHandler(appRepo repository.AppRepository) *GetAppResourcesQueryHandler {
+	return &GetAppResourcesQueryHandler{appRepository: appRepo}
+}
@@ -0,0 +1,11 @@
+package get_app_revisions
+
+// GetAppRevisionsQuery represents a query to list the stored revisions of an application
+type GetAppRevisionsQuery struct {
+	AppID string
+}
+
+// Name returns the name of the query
+func (q GetAppRevisionsQuery) Name() string {
+	return "GetAppRevisions"
+}
@@ -0,0 +1,87 @@
+package get_app_revisions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// GetAppRevisionsQueryHandler handles the GetAppRevisionsQuery
+type GetAppRevisionsQueryHandler struct {
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the GetAppRevisionsQuery and returns the result
+func (h *GetAppRevisionsQueryHandler) Handle(query GetAppRevisionsQuery) (*dto.GetAppRevisionsResult, error) {
+	log.Info("Processing get app revisions request", "app_id", query.AppID)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	revisions, err := h.VersionService.GetAppRevisions(query.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions for app %s: %w", query.AppID, err)
+	}
+
+	result := &dto.GetAppRevisionsResult{
+		AppID:     query.AppID,
+		Revisions: make([]model.AppRevision, 0, len(revisions)),
+	}
+
+	for _, revision := range revisions {
+		result.Revisions = append(result.Revisions, h.describeRevision(query.AppID, revision))
+	}
+
+	return result, nil
+}
+
+// describeRevision collects the metadata of a single revision. Missing or
+// unreadable metadata is logged and left empty so that one damaged revision
+// does not hide the rest of the history.
+func (h *GetAppRevisionsQueryHandler) describeRevision(appID string, revision uint32) model.AppRevision {
+	revisionDir := h.VersionService.GetRevisionDir(appID, revision)
+	appRevision := model.AppRevision{Revision: revision}
+
+	if info, err := os.Stat(revisionDir); err == nil {
+		appRevision.CreatedAt = info.ModTime()
+	} else {
+		log.Warn("Failed to stat revision directory", "app_id", appID, "revision", revision, "error", err)
+	}
+
+	appConfig, err := readAppConfig(revisionDir)
+	if err != nil {
+		log.Warn("Failed to read revision config", "app_id", appID, "revision", revision, "error", err)
+		return appRevision
+	}
+
+	appRevision.Name = appConfig.Name
+	appRevision.Version = appConfig.Version
+	return appRevision
+}
+
+// readAppConfig reads the app configuration of a revision in any of the accepted formats.
+func readAppConfig(revisionDir string) (*model.AppConfig, error) {
+	for _, name := range model.AppConfigFileNames {
+		configBytes, err := os.ReadFile(filepath.Join(revisionDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return model.ParseAppConfigFile(name, configBytes)
+	}
+	return nil, os.ErrNotExist
+}
+
+// NewGetAppRevisionsQueryHandler creates a new GetAppRevisionsQueryHandler
+func NewGetAppRevisionsQueryHandler(versionService app.RevisionServiceInterface) *GetAppRevisionsQueryHandler {
+	return &GetAppRevisionsQueryHandler{
+		VersionService: versionService,
+	}
+}
@@ -0,0 +1,110 @@
+package get_app_revisions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+const testAppID = "3f8b1c2d-9a4e-4c6b-8d2f-1e7a5b9c0d4e"
+
+func writeRevision(t *testing.T, revisionDir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(revisionDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// newHandler returns a handler over the given revisions of testAppID, keyed by revision number.
+func newHandler(t *testing.T, revisions map[uint32]map[string]string) (*GetAppRevisionsQueryHandler, *config.Config) {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	for revision, files := range revisions {
+		writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), testAppID, strconv.FormatUint(uint64(revision), 10)), files)
+	}
+	return NewGetAppRevisionsQueryHandler(app.NewRevisionService(cfg)), cfg
+}
+
+func revisionNumbers(t *testing.T, handler *GetAppRevisionsQueryHandler) []uint32 {
+	t.Helper()
+	result, err := handler.Handle(GetAppRevisionsQuery{AppID: testAppID})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.AppID != testAppID {
+		t.Errorf("Expected app ID %s, got %s", testAppID, result.AppID)
+	}
+	if result.Revisions == nil {
+		t.Fatal("Expected a non-nil list of revisions")
+	}
+	numbers := make([]uint32, 0, len(result.Revisions))
+	for _, revision := range result.Revisions {
+		numbers = append(numbers, revision.Revision)
+	}
+	return numbers
+}
+
+func TestGetAppRevisionsListsRevisionsInOrder(t *testing.T) {
+	handler, _ := newHandler(t, map[uint32]map[string]string{
+		10: {"config.json": `{"id":"` + testAppID + `","name":"demo","version":"1.10"}`},
+		2:  {"config.yaml": "id: " + testAppID + "\nname: demo\nversion: \"1.2\"\n"},
+		1:  {"config.json": `{"id":"` + testAppID + `","name":"old-name","version":"1.0"}`},
+	})
+
+	result, err := handler.Handle(GetAppRevisionsQuery{AppID: testAppID})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	var got []string
+	for _, revision := range result.Revisions {
+		got = append(got, strconv.FormatUint(uint64(revision.Revision), 10)+" "+revision.Name+" "+revision.Version)
+		if revision.CreatedAt.IsZero() {
+			t.Errorf("Expected revision %d to have a creation time", revision.Revision)
+		}
+	}
+	expected := []string{"1 old-name 1.0", "2 demo 1.2", "10 demo 1.10"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected revisions %v, got %v", expected, got)
+	}
+}
+
+func TestGetAppRevisionsOfAppWithoutRevisions(t *testing.T) {
+	handler, cfg := newHandler(t, nil)
+	// Leftovers of an interrupted save are not revisions.
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), testAppID), map[string]string{
+		"1/files/compose.yml": "services: {}\n",
+		"tmp/config.json":     `{"id":"` + testAppID + `"}`,
+	})
+
+	if numbers := revisionNumbers(t, handler); len(numbers) != 0 {
+		t.Errorf("Expected no revisions, got %v", numbers)
+	}
+}
+
+func TestGetAppRevisionsOfMissingApp(t *testing.T) {
+	handler, _ := newHandler(t, nil)
+
+	if numbers := revisionNumbers(t, handler); len(numbers) != 0 {
+		t.Errorf("Expected no revisions, got %v", numbers)
+	}
+}
+
+func TestGetAppRevisionsRequiresAppID(t *testing.T) {
+	handler, _ := newHandler(t, nil)
+
+	if _, err := handler.Handle(GetAppRevisionsQuery{}); err == nil {
+		t.Fatal("Expected an error for an empty app ID")
+	}
+}
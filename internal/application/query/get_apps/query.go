@@ -0,0 +1,17 @@
+package get_apps
+
+// GetAppsQuery represents a query to retrieve the latest revision of several applications at once
+type GetAppsQuery struct {
+	// AppIDs selects the applications to return; empty selects all applications.
+	AppIDs []string
+	// PageToken continues a listing with the NextPageToken of a previous page.
+	PageToken string
+	// MaxPayloadBytes bounds the estimated size of a page (default 3 MiB). An application larger than
+	// the limit is returned on a page of its own.
+	MaxPayloadBytes int
+}
+
+// Name returns the name of the query
+func (q GetAppsQuery) Name() string {
+	return "GetApps"
+}
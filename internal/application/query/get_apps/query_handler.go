@@ -0,0 +1,115 @@
+package get_apps
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"winterflow-agent/internal/application/query/get_app"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// defaultMaxPayloadBytes keeps a page well below the 4 MiB default message size limit of gRPC servers.
+const defaultMaxPayloadBytes = 3 << 20
+
+// GetAppsQueryHandler handles the GetAppsQuery
+type GetAppsQueryHandler struct {
+	VersionService app.RevisionServiceInterface
+	appHandler     *get_app.GetAppQueryHandler
+}
+
+// Handle executes the GetAppsQuery and returns one page of applications. Applications are listed in the
+// order of their IDs; selected applications that do not exist (any more) are skipped.
+func (h *GetAppsQueryHandler) Handle(query GetAppsQuery) (*model.AppsPage, error) {
+	log.Info("Processing get apps request", "app_ids", len(query.AppIDs), "page_token", query.PageToken)
+
+	appIDs, err := h.selectAppIDs(query.AppIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPayload := query.MaxPayloadBytes
+	if maxPayload <= 0 {
+		maxPayload = defaultMaxPayloadBytes
+	}
+
+	// The page token is the ID of the first application of the page, so that a listing continues at the
+	// right place even when applications are added or removed between pages.
+	start := 0
+	if query.PageToken != "" {
+		start = sort.SearchStrings(appIDs, query.PageToken)
+	}
+
+	page := &model.AppsPage{Apps: []*model.AppDetails{}}
+	payload := 0
+	for _, appID := range appIDs[start:] {
+		details, err := h.appHandler.Handle(get_app.GetAppQuery{AppID: appID})
+		if err != nil {
+			log.Warn("Skipping app in get apps response", "app_id", appID, "error", err)
+			continue
+		}
+
+		size := appDetailsSize(details)
+		if len(page.Apps) > 0 && payload+size > maxPayload {
+			page.NextPageToken = appID
+			break
+		}
+		page.Apps = append(page.Apps, details)
+		payload += size
+	}
+
+	log.Info("Retrieved apps", "apps_count", len(page.Apps), "payload_bytes", payload, "next_page_token", page.NextPageToken)
+	return page, nil
+}
+
+// selectAppIDs returns the sorted, de-duplicated IDs of the requested applications or of every
+// application when none were requested.
+func (h *GetAppsQueryHandler) selectAppIDs(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		appIDs, err := h.VersionService.GetAppIDs()
+		if err != nil {
+			return nil, fmt.Errorf("error listing apps: %w", err)
+		}
+		return appIDs, nil
+	}
+
+	seen := make(map[string]struct{}, len(requested))
+	appIDs := make([]string, 0, len(requested))
+	for _, appID := range requested {
+		if _, ok := seen[appID]; ok || appID == "" {
+			continue
+		}
+		seen[appID] = struct{}{}
+		appIDs = append(appIDs, appID)
+	}
+	sort.Strings(appIDs)
+	return appIDs, nil
+}
+
+// appDetailsSize estimates the encoded size of an application in a response.
+func appDetailsSize(details *model.AppDetails) int {
+	size := 4 * (len(details.Revisions) + 1)
+	if details.App == nil {
+		return size
+	}
+	size += len(details.App.ID) + len(details.App.ComposeOverride)
+	if configBytes, err := json.Marshal(details.App.Config); err == nil {
+		size += len(configBytes)
+	}
+	for id, value := range details.App.Variables {
+		size += len(id) + len(value)
+	}
+	for id, content := range details.App.Files {
+		size += len(id) + len(content)
+	}
+	return size
+}
+
+// NewGetAppsQueryHandler creates a new GetAppsQueryHandler
+func NewGetAppsQueryHandler(versionService app.RevisionServiceInterface) *GetAppsQueryHandler {
+	return &GetAppsQueryHandler{
+		VersionService: versionService,
+		appHandler:     get_app.NewGetAppQueryHandler(versionService),
+	}
+}
@@ -0,0 +1,126 @@
+package get_apps
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+// writeApp stores revisions 1..revisions of appID with a compose file of composeSize bytes.
+func writeApp(t *testing.T, cfg *config.Config, appID string, revisions int, composeSize int) {
+	t.Helper()
+	for revision := 1; revision <= revisions; revision++ {
+		revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), appID, string(rune('0'+revision)))
+		files := map[string]string{
+			"config.json":       `{"id":"` + appID + `","name":"` + appID + `","files":[{"id":"f1","name":"compose.yml","type":"template"}],"variables":[]}`,
+			"vars/values.json":  `{}`,
+			"files/compose.yml": strings.Repeat("x", composeSize),
+		}
+		for name, content := range files {
+			path := filepath.Join(revisionDir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatalf("Failed to create directory for %s: %v", name, err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("Failed to write %s: %v", name, err)
+			}
+		}
+	}
+}
+
+func newHandler(t *testing.T, composeSize int, appIDs ...string) *GetAppsQueryHandler {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	for i, appID := range appIDs {
+		writeApp(t, cfg, appID, i+1, composeSize)
+	}
+	// Directories without a revision are not apps.
+	if err := os.MkdirAll(filepath.Join(cfg.GetAppsTemplatesPath(), "empty"), 0o755); err != nil {
+		t.Fatalf("Failed to create empty app directory: %v", err)
+	}
+	return NewGetAppsQueryHandler(app.NewRevisionService(cfg))
+}
+
+func pageAppIDs(page *model.AppsPage) []string {
+	var appIDs []string
+	for _, details := range page.Apps {
+		appIDs = append(appIDs, details.App.ID)
+	}
+	return appIDs
+}
+
+func TestGetAppsReturnsAllApps(t *testing.T) {
+	handler := newHandler(t, 16, "app-c", "app-a", "app-b")
+
+	page, err := handler.Handle(GetAppsQuery{})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if got := pageAppIDs(page); !reflect.DeepEqual(got, []string{"app-a", "app-b", "app-c"}) {
+		t.Errorf("Expected every app in ID order, got %v", got)
+	}
+	if page.NextPageToken != "" {
+		t.Errorf("Expected a single page, got next page token %q", page.NextPageToken)
+	}
+
+	// app-b was written with three revisions; the latest one is returned with the full list.
+	details := page.Apps[1]
+	if details.Revision != 3 || !reflect.DeepEqual(details.Revisions, []uint32{1, 2, 3}) {
+		t.Errorf("Expected the latest revision and all revisions, got %d %v", details.Revision, details.Revisions)
+	}
+	if string(details.App.Files["f1"]) != strings.Repeat("x", 16) {
+		t.Errorf("Expected the file contents, got %q", details.App.Files["f1"])
+	}
+}
+
+func TestGetAppsReturnsSubset(t *testing.T) {
+	handler := newHandler(t, 16, "app-a", "app-b", "app-c")
+
+	page, err := handler.Handle(GetAppsQuery{AppIDs: []string{"app-c", "missing", "app-a", "app-c"}})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if got := pageAppIDs(page); !reflect.DeepEqual(got, []string{"app-a", "app-c"}) {
+		t.Errorf("Expected the existing requested apps once each, got %v", got)
+	}
+}
+
+func TestGetAppsPaginatesByPayloadSize(t *testing.T) {
+	handler := newHandler(t, 1000, "app-a", "app-b", "app-c")
+
+	var pages [][]string
+	token := ""
+	for {
+		page, err := handler.Handle(GetAppsQuery{PageToken: token, MaxPayloadBytes: 2500})
+		if err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+		pages = append(pages, pageAppIDs(page))
+		if page.NextPageToken == "" {
+			break
+		}
+		token = page.NextPageToken
+		if len(pages) > 3 {
+			t.Fatal("Expected the listing to end")
+		}
+	}
+	expected := [][]string{{"app-a", "app-b"}, {"app-c"}}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+
+	// An app larger than the limit is still returned, alone on its page.
+	page, err := handler.Handle(GetAppsQuery{MaxPayloadBytes: 10})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if got := pageAppIDs(page); !reflect.DeepEqual(got, []string{"app-a"}) || page.NextPageToken != "app-b" {
+		t.Errorf("Expected app-a alone followed by app-b, got %v (next %q)", got, page.NextPageToken)
+	}
+}
@@ -0,0 +1,10 @@
+package get_connection_stats
+
+// GetConnectionStatsQuery represents a query to retrieve the statistics of the connection to the server.
+// It contains no fields as the operation does not require additional input.
+type GetConnectionStatsQuery struct{}
+
+// Name returns the unique name of the query so that the CQRS bus can route it.
+func (q GetConnectionStatsQuery) Name() string {
+	return "GetConnectionStats"
+}
@@ -0,0 +1,41 @@
+package get_connection_stats
+
+import (
+	"fmt"
+	"time"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// GetConnectionStatsQueryHandler handles the GetConnectionStatsQuery.
+type GetConnectionStatsQueryHandler struct {
+	repository repository.ConnectionStatsRepository
+	now        func() time.Time
+}
+
+// Handle executes the GetConnectionStatsQuery. The statistics include the connections of previous runs
+// of the agent.
+func (h *GetConnectionStatsQueryHandler) Handle(query GetConnectionStatsQuery) (*dto.GetConnectionStatsResult, error) {
+	log.Info("Processing get connection stats query")
+
+	stats, err := h.repository.Load()
+	if err != nil {
+		return nil, fmt.Errorf("error loading connection stats: %w", err)
+	}
+
+	current := stats.CurrentUptime(h.now())
+	return &dto.GetConnectionStatsResult{
+		ConnectionStats: stats,
+		CurrentUptime:   current,
+		TotalUptime:     time.Duration(stats.TotalUptimeSeconds)*time.Second + current,
+	}, nil
+}
+
+// NewGetConnectionStatsQueryHandler creates a new GetConnectionStatsQueryHandler.
+func NewGetConnectionStatsQueryHandler(repository repository.ConnectionStatsRepository) *GetConnectionStatsQueryHandler {
+	return &GetConnectionStatsQueryHandler{
+		repository: repository,
+		now:        time.Now,
+	}
+}
@@ -0,0 +1,13 @@
+package get_rendered_compose
+
+// GetRenderedComposeQuery represents a query to preview the compose configuration of an application revision
+type GetRenderedComposeQuery struct {
+	AppID string
+	// Revision is the revision to render. Zero selects the latest revision.
+	Revision uint32
+}
+
+// Name returns the name of the query
+func (q GetRenderedComposeQuery) Name() string {
+	return "GetRenderedCompose"
+}
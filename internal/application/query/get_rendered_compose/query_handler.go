@@ -0,0 +1,68 @@
+package get_rendered_compose
+
+import (
+	"fmt"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// GetRenderedComposeQueryHandler handles the GetRenderedComposeQuery
+type GetRenderedComposeQueryHandler struct {
+	appRepository  repository.AppRepository
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the GetRenderedComposeQuery and returns the result
+func (h *GetRenderedComposeQueryHandler) Handle(query GetRenderedComposeQuery) (*dto.GetRenderedComposeResult, error) {
+	if h.appRepository == nil {
+		return nil, fmt.Errorf("appRepository is not configured")
+	}
+
+	log.Info("Processing get rendered compose request", "app_id", query.AppID, "revision", query.Revision)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	revision := query.Revision
+	if revision > 0 {
+		exists, err := h.VersionService.ValidateAppRevision(query.AppID, revision)
+		if err != nil {
+			return nil, fmt.Errorf("error validating app revision: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("revision %d not found for app %s", revision, query.AppID)
+		}
+	} else {
+		latest, err := h.VersionService.GetLatestAppRevision(query.AppID)
+		if err != nil {
+			return nil, fmt.Errorf("error determining latest revision for app %s: %w", query.AppID, err)
+		}
+		if latest == 0 {
+			return nil, fmt.Errorf("no revisions found for app %s", query.AppID)
+		}
+		revision = latest
+	}
+
+	compose, err := h.appRepository.RenderCompose(query.AppID, revision)
+	if err != nil {
+		log.Error("Error rendering app compose configuration", "app_id", query.AppID, "revision", revision, "error", err)
+		return nil, fmt.Errorf("failed to render compose configuration: %w", err)
+	}
+
+	return &dto.GetRenderedComposeResult{
+		AppID:    query.AppID,
+		Revision: revision,
+		Compose:  compose,
+	}, nil
+}
+
+// NewGetRenderedComposeQueryHandler creates a new GetRenderedComposeQueryHandler
+func NewGetRenderedComposeQueryHandler(appRepo repository.AppRepository, versionService app.RevisionServiceInterface) *GetRenderedComposeQueryHandler {
+	return &GetRenderedComposeQueryHandler{
+		appRepository:  appRepo,
+		VersionService: versionService,
+	}
+}
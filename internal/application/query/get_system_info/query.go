@@ -0,0 +1,10 @@
+package get_system_info
+
+// GetSystemInfoQuery represents a query to retrieve diagnostic details about the host.
+// It contains no fields as the operation does not require additional input.
+type GetSystemInfoQuery struct{}
+
+// Name returns the unique name of the query so that the CQRS bus can route it.
+func (q GetSystemInfoQuery) Name() string {
+	return "GetSystemInfo"
+}
@@ -0,0 +1,86 @@
+package get_system_info
+
+import (
+	"time"
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// Names of the data sources reported in GetSystemInfoResult.Unavailable.
+const (
+	SourceOS     = "os"
+	SourceKernel = "kernel"
+	SourceMemory = "memory"
+	SourceCPU    = "cpu"
+	SourceDisk   = "disk"
+	SourceDocker = "docker"
+)
+
+// GetSystemInfoQueryHandler handles the GetSystemInfoQuery.
+type GetSystemInfoQueryHandler struct {
+	repository repository.SystemInfoRepository
+	config     *config.Config
+	startTime  time.Time
+}
+
+// Handle executes the GetSystemInfoQuery. Failing data sources are logged and reported as unavailable
+// instead of failing the whole query.
+func (h *GetSystemInfoQueryHandler) Handle(query GetSystemInfoQuery) (*dto.GetSystemInfoResult, error) {
+	log.Info("Processing get system info query")
+
+	result := &dto.GetSystemInfoResult{AgentUptime: time.Since(h.startTime)}
+	unavailable := func(source string, err error) {
+		log.Warn("System info source unavailable", "source", source, "error", err)
+		result.Unavailable = append(result.Unavailable, source)
+	}
+
+	if osName, err := h.repository.GetOSName(); err != nil {
+		unavailable(SourceOS, err)
+	} else {
+		result.OS = osName
+	}
+
+	if kernel, err := h.repository.GetKernelVersion(); err != nil {
+		unavailable(SourceKernel, err)
+	} else {
+		result.KernelVersion = kernel
+	}
+
+	if memory, err := h.repository.GetMemoryTotal(); err != nil {
+		unavailable(SourceMemory, err)
+	} else {
+		result.MemoryTotalBytes = memory
+	}
+
+	if cpus, err := h.repository.GetCPUCount(); err != nil {
+		unavailable(SourceCPU, err)
+	} else {
+		result.CPUCount = cpus
+	}
+
+	if disk, err := h.repository.GetDiskUsage(h.config.BasePath); err != nil {
+		unavailable(SourceDisk, err)
+	} else {
+		result.DiskTotalBytes = disk.TotalBytes
+		result.DiskAvailableBytes = disk.AvailableBytes
+	}
+
+	if engine, err := h.repository.GetDockerEngine(); err != nil {
+		unavailable(SourceDocker, err)
+	} else {
+		result.DockerVersion = engine.Version
+		result.DockerAPIVersion = engine.APIVersion
+		if result.OS == "" {
+			result.OS = engine.OperatingSystem
+		}
+	}
+
+	return result, nil
+}
+
+// NewGetSystemInfoQueryHandler creates a new GetSystemInfoQueryHandler. The agent uptime is measured from startTime.
+func NewGetSystemInfoQueryHandler(repo repository.SystemInfoRepository, cfg *config.Config, startTime time.Time) *GetSystemInfoQueryHandler {
+	return &GetSystemInfoQueryHandler{repository: repo, config: cfg, startTime: startTime}
+}
@@ -0,0 +1,111 @@
+package get_system_info
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+)
+
+// fakeSystemInfoRepository returns fixed values and fails the sources listed in failing.
+type fakeSystemInfoRepository struct {
+	failing  map[string]bool
+	diskPath string
+}
+
+func (f *fakeSystemInfoRepository) fail(source string) error {
+	if f.failing[source] {
+		return errors.New(source + " unavailable")
+	}
+	return nil
+}
+
+func (f *fakeSystemInfoRepository) GetOSName() (string, error) {
+	return "Debian GNU/Linux 12 (bookworm)", f.fail(SourceOS)
+}
+
+func (f *fakeSystemInfoRepository) GetKernelVersion() (string, error) {
+	return "6.1.0-18-amd64", f.fail(SourceKernel)
+}
+
+func (f *fakeSystemInfoRepository) GetMemoryTotal() (uint64, error) {
+	return 8 << 30, f.fail(SourceMemory)
+}
+
+func (f *fakeSystemInfoRepository) GetCPUCount() (int, error) {
+	return 4, f.fail(SourceCPU)
+}
+
+func (f *fakeSystemInfoRepository) GetDiskUsage(path string) (model.DiskUsage, error) {
+	f.diskPath = path
+	return model.DiskUsage{TotalBytes: 100 << 30, AvailableBytes: 40 << 30}, f.fail(SourceDisk)
+}
+
+func (f *fakeSystemInfoRepository) GetDockerEngine() (model.DockerEngineInfo, error) {
+	return model.DockerEngineInfo{Version: "28.3.3", APIVersion: "1.51", OperatingSystem: "Docker Desktop"}, f.fail(SourceDocker)
+}
+
+func TestGetSystemInfoCollectsAllSources(t *testing.T) {
+	repo := &fakeSystemInfoRepository{}
+	handler := NewGetSystemInfoQueryHandler(repo, &config.Config{BasePath: "/opt/winterflow"}, time.Now().Add(-time.Minute))
+
+	result, err := handler.Handle(GetSystemInfoQuery{})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if result.OS != "Debian GNU/Linux 12 (bookworm)" || result.KernelVersion != "6.1.0-18-amd64" {
+		t.Errorf("Unexpected host details: %+v", result)
+	}
+	if result.DockerVersion != "28.3.3" || result.DockerAPIVersion != "1.51" {
+		t.Errorf("Unexpected docker details: %+v", result)
+	}
+	if result.MemoryTotalBytes != 8<<30 || result.CPUCount != 4 || result.DiskAvailableBytes != 40<<30 {
+		t.Errorf("Unexpected resources: %+v", result)
+	}
+	if repo.diskPath != "/opt/winterflow" {
+		t.Errorf("Expected disk usage of the base path, got %q", repo.diskPath)
+	}
+	if result.AgentUptime < time.Minute {
+		t.Errorf("Expected uptime of at least a minute, got %v", result.AgentUptime)
+	}
+	if len(result.Unavailable) != 0 {
+		t.Errorf("Expected all sources to be available, got %v", result.Unavailable)
+	}
+}
+
+func TestGetSystemInfoToleratesFailingSources(t *testing.T) {
+	repo := &fakeSystemInfoRepository{failing: map[string]bool{SourceDocker: true, SourceMemory: true}}
+	handler := NewGetSystemInfoQueryHandler(repo, &config.Config{}, time.Now())
+
+	result, err := handler.Handle(GetSystemInfoQuery{})
+	if err != nil {
+		t.Fatalf("Expected partial result, got error: %v", err)
+	}
+
+	if result.DockerVersion != "" || result.MemoryTotalBytes != 0 {
+		t.Errorf("Expected failing sources to be left empty, got %+v", result)
+	}
+	if result.KernelVersion == "" || result.CPUCount == 0 {
+		t.Errorf("Expected available sources to be filled, got %+v", result)
+	}
+	if expected := []string{SourceMemory, SourceDocker}; !reflect.DeepEqual(result.Unavailable, expected) {
+		t.Errorf("Expected unavailable %v, got %v", expected, result.Unavailable)
+	}
+}
+
+func TestGetSystemInfoFallsBackToDockerOS(t *testing.T) {
+	repo := &fakeSystemInfoRepository{failing: map[string]bool{SourceOS: true}}
+	handler := NewGetSystemInfoQueryHandler(repo, &config.Config{}, time.Now())
+
+	result, err := handler.Handle(GetSystemInfoQuery{})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.OS != "Docker Desktop" {
+		t.Errorf("Expected OS reported by Docker, got %q", result.OS)
+	}
+}
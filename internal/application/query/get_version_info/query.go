@@ -0,0 +1,10 @@
+package get_version_info
+
+// GetVersionInfoQuery represents a query to retrieve the versions of the agent and of its orchestrator.
+// It contains no fields as the operation does not require additional input.
+type GetVersionInfoQuery struct{}
+
+// Name returns the unique name of the query so that the CQRS bus can route it.
+func (q GetVersionInfoQuery) Name() string {
+	return "GetVersionInfo"
+}
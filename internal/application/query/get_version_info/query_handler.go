@@ -0,0 +1,88 @@
+package get_version_info
+
+import (
+	"errors"
+	"runtime"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/version"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// Names of the data sources reported in GetVersionInfoResult.Unavailable.
+const (
+	SourceOrchestrator = "orchestrator"
+	SourceDocker       = "docker"
+	SourceOS           = "os"
+	SourceKernel       = "kernel"
+)
+
+// GetVersionInfoQueryHandler handles the GetVersionInfoQuery.
+type GetVersionInfoQueryHandler struct {
+	systemInfo repository.SystemInfoRepository
+	apps       repository.AppRepository
+	config     *config.Config
+	// agentVersion and numericVersion return the version of the agent; they are replaced in tests.
+	agentVersion   func() string
+	numericVersion func() int
+}
+
+// Handle executes the GetVersionInfoQuery. Failing data sources are logged and reported as unavailable
+// instead of failing the whole query.
+func (h *GetVersionInfoQueryHandler) Handle(query GetVersionInfoQuery) (*dto.GetVersionInfoResult, error) {
+	log.Info("Processing get version info query")
+
+	result := &dto.GetVersionInfoResult{
+		AgentVersion:        h.agentVersion(),
+		AgentNumericVersion: h.numericVersion(),
+		Orchestrator:        h.config.GetOrchestrator(),
+		Arch:                runtime.GOARCH,
+	}
+	unavailable := func(source string, err error) {
+		log.Warn("Version info source unavailable", "source", source, "error", err)
+		result.Unavailable = append(result.Unavailable, source)
+	}
+
+	if versioner, ok := h.apps.(repository.OrchestratorVersioner); !ok {
+		unavailable(SourceOrchestrator, errors.New("the orchestrator does not report its version"))
+	} else if orchestratorVersion, err := versioner.GetOrchestratorVersion(); err != nil {
+		unavailable(SourceOrchestrator, err)
+	} else {
+		result.OrchestratorVersion = orchestratorVersion
+	}
+
+	if engine, err := h.systemInfo.GetDockerEngine(); err != nil {
+		unavailable(SourceDocker, err)
+	} else {
+		result.DockerVersion = engine.Version
+		result.DockerAPIVersion = engine.APIVersion
+	}
+
+	if osName, err := h.systemInfo.GetOSName(); err != nil {
+		unavailable(SourceOS, err)
+	} else {
+		result.OS = osName
+	}
+
+	if kernel, err := h.systemInfo.GetKernelVersion(); err != nil {
+		unavailable(SourceKernel, err)
+	} else {
+		result.KernelVersion = kernel
+	}
+
+	return result, nil
+}
+
+// NewGetVersionInfoQueryHandler creates a new GetVersionInfoQueryHandler. The orchestrator version is read
+// from apps when it implements repository.OrchestratorVersioner.
+func NewGetVersionInfoQueryHandler(systemInfo repository.SystemInfoRepository, apps repository.AppRepository, cfg *config.Config) *GetVersionInfoQueryHandler {
+	return &GetVersionInfoQueryHandler{
+		systemInfo:     systemInfo,
+		apps:           apps,
+		config:         cfg,
+		agentVersion:   version.GetVersion,
+		numericVersion: version.GetNumericVersion,
+	}
+}
@@ -0,0 +1,115 @@
+package get_version_info
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
+// fakeSystemInfoRepository returns fixed values and fails the sources listed in failing.
+type fakeSystemInfoRepository struct {
+	repository.SystemInfoRepository
+	failing map[string]bool
+}
+
+func (f *fakeSystemInfoRepository) fail(source string) error {
+	if f.failing[source] {
+		return errors.New(source + " unavailable")
+	}
+	return nil
+}
+
+func (f *fakeSystemInfoRepository) GetOSName() (string, error) {
+	return "Debian GNU/Linux 12 (bookworm)", f.fail(SourceOS)
+}
+
+func (f *fakeSystemInfoRepository) GetKernelVersion() (string, error) {
+	return "6.1.0-18-amd64", f.fail(SourceKernel)
+}
+
+func (f *fakeSystemInfoRepository) GetDockerEngine() (model.DockerEngineInfo, error) {
+	return model.DockerEngineInfo{Version: "28.3.3", APIVersion: "1.51"}, f.fail(SourceDocker)
+}
+
+// fakeAppRepository reports a fixed orchestrator version.
+type fakeAppRepository struct {
+	repository.AppRepository
+	version string
+	err     error
+}
+
+func (f *fakeAppRepository) GetOrchestratorVersion() (string, error) {
+	return f.version, f.err
+}
+
+// newHandler returns a handler reporting agent version 1.2.3.
+func newHandler(systemInfo repository.SystemInfoRepository, apps repository.AppRepository) *GetVersionInfoQueryHandler {
+	handler := NewGetVersionInfoQueryHandler(systemInfo, apps, &config.Config{Orchestrator: config.OrchestratorTypeDockerCompose})
+	handler.agentVersion = func() string { return "1.2.3" }
+	handler.numericVersion = func() int { return 1002003 }
+	return handler
+}
+
+func TestGetVersionInfoCollectsAllSources(t *testing.T) {
+	handler := newHandler(&fakeSystemInfoRepository{}, &fakeAppRepository{version: "2.39.1"})
+
+	result, err := handler.Handle(GetVersionInfoQuery{})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if result.AgentVersion != "1.2.3" || result.AgentNumericVersion != 1002003 {
+		t.Errorf("Unexpected agent version: %+v", result)
+	}
+	if result.Orchestrator != "docker_compose" || result.OrchestratorVersion != "2.39.1" {
+		t.Errorf("Unexpected orchestrator version: %+v", result)
+	}
+	if result.DockerVersion != "28.3.3" || result.DockerAPIVersion != "1.51" {
+		t.Errorf("Unexpected docker version: %+v", result)
+	}
+	if result.OS != "Debian GNU/Linux 12 (bookworm)" || result.KernelVersion != "6.1.0-18-amd64" || result.Arch != runtime.GOARCH {
+		t.Errorf("Unexpected OS details: %+v", result)
+	}
+	if len(result.Unavailable) != 0 {
+		t.Errorf("Expected all sources to be available, got %v", result.Unavailable)
+	}
+}
+
+func TestGetVersionInfoToleratesFailingSources(t *testing.T) {
+	systemInfo := &fakeSystemInfoRepository{failing: map[string]bool{SourceDocker: true}}
+	handler := newHandler(systemInfo, &fakeAppRepository{err: errors.New("compose not found")})
+
+	result, err := handler.Handle(GetVersionInfoQuery{})
+	if err != nil {
+		t.Fatalf("Expected partial result, got error: %v", err)
+	}
+
+	if result.AgentVersion != "1.2.3" || result.OS == "" {
+		t.Errorf("Expected available sources to be filled, got %+v", result)
+	}
+	if result.OrchestratorVersion != "" || result.DockerVersion != "" {
+		t.Errorf("Expected failing sources to be left empty, got %+v", result)
+	}
+	if expected := []string{SourceOrchestrator, SourceDocker}; !reflect.DeepEqual(result.Unavailable, expected) {
+		t.Errorf("Expected unavailable %v, got %v", expected, result.Unavailable)
+	}
+}
+
+func TestGetVersionInfoWithoutOrchestratorVersion(t *testing.T) {
+	// The embedded repository does not implement repository.OrchestratorVersioner.
+	apps := struct{ repository.AppRepository }{}
+	handler := newHandler(&fakeSystemInfoRepository{}, apps)
+
+	result, err := handler.Handle(GetVersionInfoQuery{})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if expected := []string{SourceOrchestrator}; !reflect.DeepEqual(result.Unavailable, expected) {
+		t.Errorf("Expected unavailable %v, got %v", expected, result.Unavailable)
+	}
+}
@@ -1,41 +1,127 @@
 package query
 
 import (
+	"time"
 	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/query/check_for_update"
+	"winterflow-agent/internal/application/query/export_app"
+	"winterflow-agent/internal/application/query/get_agent_config"
 	"winterflow-agent/internal/application/query/get_app"
+	"winterflow-agent/internal/application/query/get_app_diff"
 	"winterflow-agent/internal/application/query/get_app_logs"
+	"winterflow-agent/internal/application/query/get_app_metrics"
+	"winterflow-agent/internal/application/query/get_app_resources"
+	"winterflow-agent/internal/application/query/get_app_revisions"
+	"winterflow-agent/internal/application/query/get_apps"
 	"winterflow-agent/internal/application/query/get_apps_status"
+	"winterflow-agent/internal/application/query/get_connection_stats"
 	"winterflow-agent/internal/application/query/get_networks"
 	"winterflow-agent/internal/application/query/get_registries"
+	"winterflow-agent/internal/application/query/get_rendered_compose"
+	"winterflow-agent/internal/application/query/get_system_info"
+	"winterflow-agent/internal/application/query/get_version_info"
+	"winterflow-agent/internal/application/query/stream_docker_events"
+	"winterflow-agent/internal/application/query/validate_app"
 	"winterflow-agent/internal/domain/repository"
 	appservice "winterflow-agent/internal/domain/service/app"
 	"winterflow-agent/pkg/cqrs"
 	"winterflow-agent/pkg/log"
 )
 
-func RegisterQueryHandlers(b cqrs.QueryBus, config *config.Config, appRepository repository.AppRepository, registryRepository repository.DockerRegistryRepository, networkRepository repository.DockerNetworkRepository) error {
+func RegisterQueryHandlers(b cqrs.QueryBus, config *config.Config, appRepository repository.AppRepository, registryRepository repository.DockerRegistryRepository, networkRepository repository.DockerNetworkRepository, systemInfoRepository repository.SystemInfoRepository, connectionStatsRepository repository.ConnectionStatsRepository, startTime time.Time) error {
 	// Initialise the service responsible for application versions.
 	versionService := appservice.NewRevisionService(config)
 
 	if err := b.Register(get_app.NewGetAppQueryHandler(versionService)); err != nil {
-		return log.Errorf("failed to register get app query handler", "error", err)
+		return log.Errorf("failed to register get app query handler: %v", err)
+	}
+
+	if err := b.Register(get_apps.NewGetAppsQueryHandler(versionService)); err != nil {
+		return log.Errorf("failed to register get apps query handler: %v", err)
 	}
 
 	if err := b.Register(get_apps_status.NewGetAppsStatusQueryHandler(appRepository)); err != nil {
-		return log.Errorf("failed to register get apps status query handler", "error", err)
+		return log.Errorf("failed to register get apps status query handler: %v", err)
 	}
 
 	if err := b.Register(get_registries.NewGetRegistriesQueryHandler(registryRepository, config)); err != nil {
-		return log.Errorf("failed to register get registries query handler", "error", err)
+		return log.Errorf("failed to register get registries query handler: %v", err)
 	}
 
 	if err := b.Register(get_networks.NewGetNetworksQueryHandler(networkRepository, config)); err != nil {
-		return log.Errorf("failed to register get networks query handler", "error", err)
+		return log.Errorf("failed to register get networks query handler: %v", err)
 	}
 
 	if err := b.Register(get_app_logs.NewGetAppLogsQueryHandler(appRepository, config)); err != nil {
-		return log.Errorf("failed to register get app logs query handler", "error", err)
+		return log.Errorf("failed to register get app logs query handler: %v", err)
+	}
+
+	if err := b.Register(get_app_resources.NewGetAppResourcesQueryHandler(appRepository)); err != nil {
+		return log.Errorf("failed to register get app resources query handler: %v", err)
+	}
+
+	if source, ok := appRepository.(repository.OperationMetricsSource); ok {
+		if err := b.Register(get_app_metrics.NewGetAppMetricsQueryHandler(source)); err != nil {
+			return log.Errorf("failed to register get app metrics query handler: %v", err)
+		}
+	}
+
+	if err := b.Register(get_app_revisions.NewGetAppRevisionsQueryHandler(versionService)); err != nil {
+		return log.Errorf("failed to register get app revisions query handler: %v", err)
+	}
+
+	if err := b.Register(get_app_diff.NewGetAppDiffQueryHandler(versionService)); err != nil {
+		return log.Errorf("failed to register get app diff query handler: %v", err)
+	}
+
+	if err := b.Register(validate_app.NewValidateAppQueryHandler(versionService)); err != nil {
+		return log.Errorf("failed to register validate app query handler: %v", err)
+	}
+
+	if err := b.Register(get_rendered_compose.NewGetRenderedComposeQueryHandler(appRepository, versionService)); err != nil {
+		return log.Errorf("failed to register get rendered compose query handler: %v", err)
+	}
+
+	if err := b.Register(export_app.NewExportAppQueryHandler(versionService)); err != nil {
+		return log.Errorf("failed to register export app query handler: %v", err)
+	}
+
+	if err := b.Register(get_system_info.NewGetSystemInfoQueryHandler(systemInfoRepository, config, startTime)); err != nil {
+		return log.Errorf("failed to register get system info query handler: %v", err)
+	}
+
+	if err := b.Register(get_connection_stats.NewGetConnectionStatsQueryHandler(connectionStatsRepository)); err != nil {
+		return log.Errorf("failed to register get connection stats query handler: %v", err)
+	}
+
+	if err := b.Register(get_agent_config.NewGetAgentConfigQueryHandler(config)); err != nil {
+		return log.Errorf("failed to register get agent config query handler: %v", err)
+	}
+
+	if err := b.Register(get_version_info.NewGetVersionInfoQueryHandler(systemInfoRepository, appRepository, config)); err != nil {
+		return log.Errorf("failed to register get version info query handler: %v", err)
+	}
+
+	if err := b.Register(check_for_update.NewCheckForUpdateQueryHandler(config)); err != nil {
+		return log.Errorf("failed to register check for update query handler: %v", err)
+	}
+
+	if source, ok := appRepository.(repository.ContainerEventSource); ok {
+		if err := b.Register(stream_docker_events.NewStreamDockerEventsQueryHandler(source)); err != nil {
+			return log.Errorf("failed to register stream docker events query handler: %v", err)
+		}
 	}
 
 	return nil
 }
+
+// Timeouts returns the timeouts of the queries by their name as configured. Subscriptions such as
+// StreamDockerEvents run until they are canceled and are not limited.
+func Timeouts(config *config.Config) func(queryName string) time.Duration {
+	return func(queryName string) time.Duration {
+		if queryName == (stream_docker_events.StreamDockerEventsQuery{}).Name() {
+			return 0
+		}
+		return config.GetQueryTimeout(queryName)
+	}
+}
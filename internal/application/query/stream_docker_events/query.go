@@ -0,0 +1,15 @@
+package stream_docker_events
+
+import "winterflow-agent/internal/domain/model"
+
+// StreamDockerEventsQuery subscribes to the lifecycle events of the managed containers. Unlike other
+// queries it runs until its context is done, forwarding the events in coalesced batches to Send.
+type StreamDockerEventsQuery struct {
+	// Send forwards a batch of events; the subscription ends when it fails.
+	Send func([]model.ContainerEvent) error
+}
+
+// Name returns the name of the query.
+func (q StreamDockerEventsQuery) Name() string {
+	return "StreamDockerEvents"
+}
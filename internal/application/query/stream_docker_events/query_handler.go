@@ -0,0 +1,135 @@
+package stream_docker_events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// defaultCoalesceWindow is how long events are buffered before they are forwarded, so that bursts,
+	// e.g. a container in a restart loop, are sent as one batch.
+	defaultCoalesceWindow = time.Second
+	// defaultMaxBufferedEvents forwards the buffered events early once this many distinct events are pending.
+	defaultMaxBufferedEvents = 100
+)
+
+// StreamDockerEventsQueryHandler handles the StreamDockerEventsQuery.
+type StreamDockerEventsQueryHandler struct {
+	source            repository.ContainerEventSource
+	coalesceWindow    time.Duration
+	maxBufferedEvents int
+}
+
+// Handle forwards the container events until ctx is done and returns the number of
+// events received. Repeated events of the same container and action within the coalesce window are sent
+// once, with the number of occurrences and the time of the latest one.
+func (h *StreamDockerEventsQueryHandler) Handle(ctx context.Context, query StreamDockerEventsQuery) (int, error) {
+	if h.source == nil {
+		return 0, fmt.Errorf("container event source is not configured")
+	}
+	if query.Send == nil {
+		return 0, fmt.Errorf("send is required")
+	}
+
+	log.Info("Processing stream docker events request")
+	events, errs := h.source.ContainerEvents(ctx)
+
+	received := 0
+	var buffer eventBuffer
+	var flush <-chan time.Time
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if err := h.send(query, &buffer); err != nil {
+					return received, err
+				}
+				select {
+				case err := <-errs:
+					return received, fmt.Errorf("docker events subscription failed: %w", err)
+				default:
+				}
+				log.Info("Docker events subscription ended", "events", received)
+				return received, nil
+			}
+			received++
+			buffer.add(event)
+			if buffer.len() >= h.maxBufferedEvents {
+				if err := h.send(query, &buffer); err != nil {
+					return received, err
+				}
+				flush = nil
+			} else if flush == nil {
+				flush = time.After(h.coalesceWindow)
+			}
+
+		case <-ctx.Done():
+			log.Info("Docker events subscription canceled", "events", received)
+			return received, nil
+
+		case <-flush:
+			flush = nil
+			if err := h.send(query, &buffer); err != nil {
+				return received, err
+			}
+		}
+	}
+}
+
+// send forwards and clears the buffered events, if any.
+func (h *StreamDockerEventsQueryHandler) send(query StreamDockerEventsQuery, buffer *eventBuffer) error {
+	batch := buffer.take()
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := query.Send(batch); err != nil {
+		return fmt.Errorf("failed to send docker events: %w", err)
+	}
+	return nil
+}
+
+// eventBuffer coalesces the events of the same container and action, in the order first received.
+type eventBuffer struct {
+	events []model.ContainerEvent
+	index  map[string]int
+}
+
+func (b *eventBuffer) add(event model.ContainerEvent) {
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+	key := event.ContainerID + "/" + string(event.Action)
+	if i, ok := b.index[key]; ok {
+		count := b.events[i].Count + max(event.Count, 1)
+		b.events[i] = event
+		b.events[i].Count = count
+		return
+	}
+	event.Count = max(event.Count, 1)
+	b.index[key] = len(b.events)
+	b.events = append(b.events, event)
+}
+
+func (b *eventBuffer) len() int {
+	return len(b.events)
+}
+
+func (b *eventBuffer) take() []model.ContainerEvent {
+	events := b.events
+	b.events, b.index = nil, nil
+	return events
+}
+
+// NewStreamDockerEventsQueryHandler creates a new StreamDockerEventsQueryHandler.
+func NewStreamDockerEventsQueryHandler(source repository.ContainerEventSource) *StreamDockerEventsQueryHandler {
+	return &StreamDockerEventsQueryHandler{
+		source:            source,
+		coalesceWindow:    defaultCoalesceWindow,
+		maxBufferedEvents: defaultMaxBufferedEvents,
+	}
+}
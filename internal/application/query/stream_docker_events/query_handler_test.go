@@ -0,0 +1,118 @@
+package stream_docker_events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// fakeEventSource replays events sent on its channel as the Docker events API would.
+type fakeEventSource struct {
+	events chan model.ContainerEvent
+	errs   chan error
+}
+
+func newFakeEventSource() *fakeEventSource {
+	return &fakeEventSource{events: make(chan model.ContainerEvent), errs: make(chan error, 1)}
+}
+
+func (s *fakeEventSource) ContainerEvents(context.Context) (<-chan model.ContainerEvent, <-chan error) {
+	return s.events, s.errs
+}
+
+// runHandler runs the handler in the background and returns the channel of sent batches and of its result.
+func runHandler(t *testing.T, h *StreamDockerEventsQueryHandler, ctx context.Context) (<-chan []model.ContainerEvent, <-chan error) {
+	t.Helper()
+	batches := make(chan []model.ContainerEvent, 10)
+	done := make(chan error, 1)
+	go func() {
+		_, err := h.Handle(ctx, StreamDockerEventsQuery{Send: func(events []model.ContainerEvent) error {
+			batches <- events
+			return nil
+		}})
+		done <- err
+	}()
+	return batches, done
+}
+
+func receiveBatch(t *testing.T, batches <-chan []model.ContainerEvent) []model.ContainerEvent {
+	t.Helper()
+	select {
+	case batch := <-batches:
+		return batch
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a batch of events")
+		return nil
+	}
+}
+
+func TestStreamDockerEventsCoalescesBursts(t *testing.T) {
+	source := newFakeEventSource()
+	h := NewStreamDockerEventsQueryHandler(source)
+	h.coalesceWindow = 50 * time.Millisecond
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	batches, done := runHandler(t, h, ctx)
+
+	base := time.Unix(1700000000, 0)
+	for i := range 3 {
+		source.events <- model.ContainerEvent{ContainerID: "web", Action: model.ContainerEventDie, ExitCode: i, Time: base.Add(time.Duration(i) * time.Second)}
+		source.events <- model.ContainerEvent{ContainerID: "web", Action: model.ContainerEventStart, Time: base.Add(time.Duration(i) * time.Second)}
+	}
+	source.events <- model.ContainerEvent{ContainerID: "db", Action: model.ContainerEventOOM, Time: base}
+
+	batch := receiveBatch(t, batches)
+	if len(batch) != 3 {
+		t.Fatalf("Expected 3 coalesced events, got %+v", batch)
+	}
+	die := batch[0]
+	if die.Action != model.ContainerEventDie || die.Count != 3 || die.ExitCode != 2 || !die.Time.Equal(base.Add(2*time.Second)) {
+		t.Errorf("Expected the die events to be coalesced into the latest one, got %+v", die)
+	}
+	if batch[1].Action != model.ContainerEventStart || batch[1].Count != 3 {
+		t.Errorf("Expected the start events to be coalesced, got %+v", batch[1])
+	}
+	if batch[2].ContainerID != "db" || batch[2].Count != 1 {
+		t.Errorf("Expected the OOM event of db, got %+v", batch[2])
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected a canceled subscription to end without error, got %v", err)
+	}
+}
+
+func TestStreamDockerEventsFlushesFullBuffer(t *testing.T) {
+	source := newFakeEventSource()
+	h := NewStreamDockerEventsQueryHandler(source)
+	h.coalesceWindow = time.Hour
+	h.maxBufferedEvents = 2
+	batches, _ := runHandler(t, h, t.Context())
+
+	source.events <- model.ContainerEvent{ContainerID: "a", Action: model.ContainerEventStart}
+	source.events <- model.ContainerEvent{ContainerID: "b", Action: model.ContainerEventStart}
+	if batch := receiveBatch(t, batches); len(batch) != 2 {
+		t.Errorf("Expected the full buffer to be sent right away, got %+v", batch)
+	}
+}
+
+func TestStreamDockerEventsReportsSourceErrors(t *testing.T) {
+	source := newFakeEventSource()
+	h := NewStreamDockerEventsQueryHandler(source)
+	h.coalesceWindow = time.Hour
+	batches, done := runHandler(t, h, t.Context())
+
+	source.events <- model.ContainerEvent{ContainerID: "a", Action: model.ContainerEventStop}
+	source.errs <- errors.New("daemon went away")
+	close(source.events)
+
+	if batch := receiveBatch(t, batches); len(batch) != 1 {
+		t.Errorf("Expected the buffered event to be sent before the subscription ends, got %+v", batch)
+	}
+	if err := <-done; err == nil {
+		t.Error("Expected the source error to be returned")
+	}
+}
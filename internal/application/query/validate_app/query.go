@@ -0,0 +1,11 @@
+package validate_app
+
+// ValidateAppQuery represents a query to check that the required variables of an application have values
+type ValidateAppQuery struct {
+	AppID string
+}
+
+// Name returns the name of the query
+func (q ValidateAppQuery) Name() string {
+	return "ValidateApp"
+}
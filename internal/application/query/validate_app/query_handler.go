@@ -0,0 +1,136 @@
+package validate_app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/service/app"
+	"winterflow-agent/pkg/log"
+)
+
+// ValidateAppQueryHandler handles the ValidateAppQuery
+type ValidateAppQueryHandler struct {
+	VersionService app.RevisionServiceInterface
+}
+
+// Handle executes the ValidateAppQuery and returns the required variables of the latest revision that
+// have no value.
+func (h *ValidateAppQueryHandler) Handle(query ValidateAppQuery) (*dto.ValidateAppResult, error) {
+	log.Info("Processing validate app request", "app_id", query.AppID)
+
+	if query.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	revision, err := h.VersionService.GetLatestAppRevision(query.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("error determining latest revision for app %s: %w", query.AppID, err)
+	}
+	if revision == 0 {
+		return nil, fmt.Errorf("no revisions found for app %s", query.AppID)
+	}
+
+	appConfig, err := readAppConfig(h.VersionService.GetRevisionDir(query.AppID, revision))
+	if err != nil {
+		return nil, err
+	}
+	values, err := loadValues(h.VersionService.GetVarsDir(query.AppID, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []dto.MissingVariable{}
+	for _, variable := range appConfig.Variables {
+		if !variable.Required {
+			continue
+		}
+		// values.json is keyed by variable name; older revisions may use the variable ID.
+		value, ok := values[variable.Name]
+		if !ok {
+			value = values[variable.ID]
+		}
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, dto.MissingVariable{ID: variable.ID, Name: variable.Name})
+		}
+	}
+
+	log.Info("Validated app", "app_id", query.AppID, "revision", revision, "missing_variables", len(missing))
+	return &dto.ValidateAppResult{
+		AppID:            query.AppID,
+		Revision:         revision,
+		MissingVariables: missing,
+	}, nil
+}
+
+// readAppConfig reads the app configuration of a revision in any of the accepted formats.
+func readAppConfig(revisionDir string) (*model.AppConfig, error) {
+	for _, name := range model.AppConfigFileNames {
+		configBytes, err := os.ReadFile(filepath.Join(revisionDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+		appConfig, err := model.ParseAppConfigFile(name, configBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing config: %w", err)
+		}
+		return appConfig, nil
+	}
+	return nil, fmt.Errorf("error reading config file: %w", os.ErrNotExist)
+}
+
+// loadValues returns the effective variable values with the same precedence as rendering: values.json,
+// then overrides.json, then one file per variable in secrets/. Null values count as unset.
+func loadValues(varsDir string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, name := range []string{"values.json", "overrides.json"} {
+		data, err := os.ReadFile(filepath.Join(varsDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		for key, value := range raw {
+			if value == nil {
+				values[key] = ""
+				continue
+			}
+			values[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	secretsDir := filepath.Join(varsDir, "secrets")
+	entries, err := os.ReadDir(secretsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading secrets directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(secretsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret %s: %w", entry.Name(), err)
+		}
+		values[entry.Name()] = string(data)
+	}
+	return values, nil
+}
+
+// NewValidateAppQueryHandler creates a new ValidateAppQueryHandler
+func NewValidateAppQueryHandler(versionService app.RevisionServiceInterface) *ValidateAppQueryHandler {
+	return &ValidateAppQueryHandler{
+		VersionService: versionService,
+	}
+}
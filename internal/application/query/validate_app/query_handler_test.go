@@ -0,0 +1,88 @@
+package validate_app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/service/app"
+)
+
+const testAppID = "3f8b1c2d-9a4e-4c6b-8d2f-1e7a5b9c0d4e"
+
+const testConfig = `{"id":"` + testAppID + `","name":"demo","variables":[
+	{"id":"v1","name":"DB_USER","type":"template","required":true},
+	{"id":"v2","name":"DB_PASSWORD","is_encrypted":true,"type":"template","required":true},
+	{"id":"v3","name":"API_TOKEN","type":"template","required":true},
+	{"id":"v4","name":"PORT","type":"template","required":true},
+	{"id":"v5","name":"OPTIONAL","type":"template"}
+]}`
+
+func newHandler(t *testing.T, files map[string]string) *ValidateAppQueryHandler {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), testAppID, "1")
+	for name, content := range files {
+		path := filepath.Join(revisionDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return NewValidateAppQueryHandler(app.NewRevisionService(cfg))
+}
+
+func TestValidateAppAcceptsPopulatedVariables(t *testing.T) {
+	handler := newHandler(t, map[string]string{
+		"config.json":            testConfig,
+		"vars/values.json":       `{"DB_USER":"admin","DB_PASSWORD":"","PORT":8080,"OPTIONAL":""}`,
+		"vars/overrides.json":    `{"DB_PASSWORD":"s3cret"}`,
+		"vars/secrets/API_TOKEN": "token\n",
+	})
+
+	result, err := handler.Handle(ValidateAppQuery{AppID: testAppID})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.AppID != testAppID || result.Revision != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(result.MissingVariables) != 0 {
+		t.Errorf("Expected no missing variables, got %v", result.MissingVariables)
+	}
+}
+
+func TestValidateAppReportsMissingVariables(t *testing.T) {
+	handler := newHandler(t, map[string]string{
+		"config.json":            testConfig,
+		"vars/values.json":       `{"DB_USER":"  ","DB_PASSWORD":null,"PORT":8080}`,
+		"vars/secrets/API_TOKEN": "\n",
+	})
+
+	result, err := handler.Handle(ValidateAppQuery{AppID: testAppID})
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	expected := []dto.MissingVariable{
+		{ID: "v1", Name: "DB_USER"},
+		{ID: "v2", Name: "DB_PASSWORD"},
+		{ID: "v3", Name: "API_TOKEN"},
+	}
+	if !reflect.DeepEqual(result.MissingVariables, expected) {
+		t.Errorf("Expected missing variables %v, got %v", expected, result.MissingVariables)
+	}
+}
+
+func TestValidateAppRequiresExistingApp(t *testing.T) {
+	handler := newHandler(t, nil)
+	for _, appID := range []string{"", testAppID} {
+		if _, err := handler.Handle(ValidateAppQuery{AppID: appID}); err == nil {
+			t.Errorf("Expected an error for app %q", appID)
+		}
+	}
+}
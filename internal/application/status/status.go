@@ -0,0 +1,92 @@
+// Package status prints the state of the locally deployed apps for on-host debugging. It only talks to
+// the local Docker daemon and does not need a connection to the WinterFlow server.
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"winterflow-agent/internal/application"
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/repository"
+	appsvc "winterflow-agent/internal/domain/service/app"
+)
+
+// AppRow is a single line of the status table.
+type AppRow struct {
+	ID         string
+	Name       string
+	Revision   uint32
+	Status     string
+	Containers int
+}
+
+// CollectRows returns one row per app known to appRepository, sorted by name. The revision is the deployed
+// one reported by the containers; otherwise latestRevision resolves the latest revision of the app. Apps
+// whose revision cannot be determined are reported with revision 0.
+func CollectRows(appRepository repository.AppRepository, latestRevision func(appID string) (uint32, error)) ([]AppRow, error) {
+	result, err := appRepository.GetAppsStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]AppRow, 0, len(result.Apps))
+	for _, app := range result.Apps {
+		revision := app.Revision
+		if revision == 0 {
+			if latest, err := latestRevision(app.ID); err == nil {
+				revision = latest
+			}
+		}
+		rows = append(rows, AppRow{
+			ID:         app.ID,
+			Name:       app.Name,
+			Revision:   revision,
+			Status:     app.StatusCode.String(),
+			Containers: len(app.Containers),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		return rows[i].ID < rows[j].ID
+	})
+	return rows, nil
+}
+
+// WriteTable writes rows to w as an aligned table with a header line.
+func WriteTable(w io.Writer, rows []AppRow) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tREVISION\tSTATUS\tCONTAINERS\tID")
+	for _, row := range rows {
+		revision := "-"
+		if row.Revision > 0 {
+			revision = fmt.Sprintf("%d", row.Revision)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", row.Name, revision, row.Status, row.Containers, row.ID)
+	}
+	return tw.Flush()
+}
+
+// PrintLocalStatus loads the configuration at configPath and writes the status table of the apps deployed on
+// this host to w.
+func PrintLocalStatus(configPath string, w io.Writer) error {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	revisionService := appsvc.NewRevisionService(cfg)
+	rows, err := CollectRows(application.NewAppRepository(cfg), revisionService.GetLatestAppRevision)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "No apps deployed")
+		return err
+	}
+	return WriteTable(w, rows)
+}
@@ -0,0 +1,64 @@
+package status
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
+// fakeAppRepository returns a fixed GetAppsStatus result; other methods are not used.
+type fakeAppRepository struct {
+	repository.AppRepository
+	apps []*model.ContainerApp
+}
+
+func (f *fakeAppRepository) GetAppsStatus() (model.GetAppsStatusResult, error) {
+	return model.GetAppsStatusResult{Apps: f.apps}, nil
+}
+
+func TestCollectRowsAndWriteTable(t *testing.T) {
+	repo := &fakeAppRepository{apps: []*model.ContainerApp{
+		{ID: "id-web", Name: "web", StatusCode: model.ContainerStatusActive, Containers: []model.Container{{}, {}}, Revision: 11},
+		{ID: "id-db", Name: "db", StatusCode: model.ContainerStatusStopped},
+		{ID: "id-new", Name: "cache", StatusCode: model.ContainerStatusProblematic, Containers: []model.Container{{}}},
+	}}
+	revisions := map[string]uint32{"id-web": 12, "id-db": 3}
+	latestRevision := func(appID string) (uint32, error) {
+		if revision, ok := revisions[appID]; ok {
+			return revision, nil
+		}
+		return 0, errors.New("no revisions")
+	}
+
+	rows, err := CollectRows(repo, latestRevision)
+	if err != nil {
+		t.Fatalf("CollectRows returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteTable(&out, rows); err != nil {
+		t.Fatalf("WriteTable returned error: %v", err)
+	}
+
+	expected := "" +
+		"NAME   REVISION  STATUS       CONTAINERS  ID\n" +
+		"cache  -         problematic  1           id-new\n" +
+		"db     3         stopped      0           id-db\n" +
+		"web    11        active       2           id-web\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected table:\n%s\nwant:\n%s", out.String(), expected)
+	}
+}
+
+func TestWriteTableWithoutApps(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteTable(&out, nil); err != nil {
+		t.Fatalf("WriteTable returned error: %v", err)
+	}
+	if out.String() != "NAME  REVISION  STATUS  CONTAINERS  ID\n" {
+		t.Errorf("Expected only the header, got %q", out.String())
+	}
+}
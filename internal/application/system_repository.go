@@ -0,0 +1,17 @@
+package application
+
+import (
+	"github.com/docker/docker/client"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/infra/system"
+	"winterflow-agent/pkg/log"
+)
+
+func NewSystemInfoRepository() repository.SystemInfoRepository {
+	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		log.Warn("Failed to create Docker client, Docker engine details will be unavailable", "error", err)
+		return system.NewSystemInfoRepository(nil)
+	}
+	return system.NewSystemInfoRepository(dockerClient)
+}
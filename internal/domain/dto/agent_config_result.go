@@ -0,0 +1,11 @@
+package dto
+
+// GetAgentConfigResult holds the effective configuration of the agent.
+type GetAgentConfigResult struct {
+	// Settings are the effective settings by their JSON name, with defaults applied and sensitive values redacted.
+	Settings map[string]any
+	// BuildOverrides are the settings fixed when the agent was built, by name.
+	BuildOverrides map[string]string
+	// GRPCServerAddress is the server endpoint the agent connects to.
+	GRPCServerAddress string
+}
@@ -0,0 +1,23 @@
+package dto
+
+// DiffEntries lists the names that were added, removed or modified between two revisions, each sorted
+// alphabetically.
+type DiffEntries struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// GetAppDiffResult describes what changed between two revisions of an application. Only names are
+// reported; values are compared by hash so secrets never leave the host.
+type GetAppDiffResult struct {
+	AppID        string
+	FromRevision uint32
+	ToRevision   uint32
+	// Config lists the top-level config.json fields that changed.
+	Config DiffEntries
+	// Variables lists variable names, taking values.json, overrides.json and host secret files into account.
+	Variables DiffEntries
+	// Files lists file paths relative to the files directory.
+	Files DiffEntries
+}
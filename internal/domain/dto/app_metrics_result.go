@@ -0,0 +1,8 @@
+package dto
+
+import "winterflow-agent/internal/domain/model"
+
+// GetAppMetricsResult holds the metrics of the lifecycle operations run on the requested apps.
+type GetAppMetricsResult struct {
+	Operations []model.AppOperationMetrics
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "winterflow-agent/internal/domain/model"
+
+// GetAppRevisionsResult represents the deploy history of an application as stored on the host.
+// Revisions are sorted in ascending order; an application without revisions yields an empty slice.
+type GetAppRevisionsResult struct {
+	AppID     string
+	Revisions []model.AppRevision
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// CheckForUpdateResult reports whether a newer release of the agent than the running one is available.
+type CheckForUpdateResult struct {
+	CurrentVersion  string
+	LatestVersion   string
+	UpdateAvailable bool
+	// CheckedAt is when the latest release was looked up.
+	CheckedAt time.Time
+	// Cached reports that the releases could not be looked up because of rate limiting and the result of an
+	// earlier check is returned.
+	Cached bool
+}
@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+	"winterflow-agent/internal/domain/model"
+)
+
+// GetConnectionStatsResult holds the persisted statistics of the connection to the server.
+type GetConnectionStatsResult struct {
+	model.ConnectionStats
+	// CurrentUptime is how long the current connection has lasted; zero while disconnected.
+	CurrentUptime time.Duration
+	// TotalUptime is the time spent connected, including the current connection.
+	TotalUptime time.Duration
+}
@@ -0,0 +1,10 @@
+package dto
+
+// ExportAppResult holds a gzip compressed tarball of an application's template tree. Entry names are
+// relative to the application's template directory, i.e. "<revision>/config.json". Secret values are
+// replaced with placeholders and host secret files are left out.
+type ExportAppResult struct {
+	AppID     string
+	Revisions []uint32
+	Archive   []byte
+}
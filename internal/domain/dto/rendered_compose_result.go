@@ -0,0 +1,9 @@
+package dto
+
+// GetRenderedComposeResult holds the merged `docker compose config` output for a single revision of an
+// application. Values of encrypted variables are redacted.
+type GetRenderedComposeResult struct {
+	AppID    string
+	Revision uint32
+	Compose  string
+}
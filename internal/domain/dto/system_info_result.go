@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// GetSystemInfoResult holds host diagnostics. Fields whose data source could not be read are left empty
+// and the name of the source is listed in Unavailable.
+type GetSystemInfoResult struct {
+	OS                 string
+	KernelVersion      string
+	DockerVersion      string
+	DockerAPIVersion   string
+	DiskTotalBytes     uint64
+	DiskAvailableBytes uint64
+	MemoryTotalBytes   uint64
+	CPUCount           int
+	AgentUptime        time.Duration
+	Unavailable        []string
+}
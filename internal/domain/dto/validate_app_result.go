@@ -0,0 +1,15 @@
+package dto
+
+// MissingVariable identifies a required variable without a value.
+type MissingVariable struct {
+	ID   string
+	Name string
+}
+
+// ValidateAppResult lists the required variables of the latest revision of an application that have no
+// value. The application is ready to be deployed when MissingVariables is empty.
+type ValidateAppResult struct {
+	AppID            string
+	Revision         uint32
+	MissingVariables []MissingVariable
+}
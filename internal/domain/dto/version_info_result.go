@@ -0,0 +1,16 @@
+package dto
+
+// GetVersionInfoResult holds the versions of the agent and of the software it deploys apps with. Fields whose
+// data source could not be read are left empty and the name of the source is listed in Unavailable.
+type GetVersionInfoResult struct {
+	AgentVersion        string
+	AgentNumericVersion int
+	Orchestrator        string
+	OrchestratorVersion string
+	DockerVersion       string
+	DockerAPIVersion    string
+	OS                  string
+	Arch                string
+	KernelVersion       string
+	Unavailable         []string
+}
@@ -1,11 +1,15 @@
 package model
 
+import "time"
+
 // App represents an application with its configuration, variables, and files
 type App struct {
 	ID        string
 	Config    *AppConfig
 	Variables VariableMap
 	Files     FilesMap
+	// ComposeOverride holds optional compose.override.yml content supplied by the server.
+	ComposeOverride []byte
 }
 
 // VariableMap represents a map of variable UUIDs to values
@@ -21,3 +25,20 @@ type AppDetails struct {
 	Revision  uint32
 	Revisions []uint32
 }
+
+// AppsPage is one page of the applications returned by the bulk GetApps query.
+type AppsPage struct {
+	Apps []*AppDetails
+	// NextPageToken continues the listing with the next page; it is empty on the last page.
+	NextPageToken string
+}
+
+// AppRevision describes a single stored revision of an application.
+// CreatedAt is derived from the revision directory modification time, Name
+// and Version come from the config.json stored at that revision.
+type AppRevision struct {
+	Revision  uint32
+	CreatedAt time.Time
+	Name      string
+	Version   string
+}
@@ -1,10 +1,24 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
 )
 
+// AppConfigFileNames lists the file names an app configuration may be stored under, in order of
+// precedence. The agent itself always writes canonical JSON to the first one; the YAML variants are
+// accepted for hand-edited configurations.
+var AppConfigFileNames = []string{"config.json", "config.yaml", "config.yml"}
+
+// ComposeOverrideFileName is the file name of the server supplied compose override, stored next to the
+// templates of a revision and applied on top of the compose files of the app.
+const ComposeOverrideFileName = "compose.override.yml"
+
 type ExtensionValue struct {
 	Extension      string `json:"extension"`
 	ExtensionAppID string `json:"extension_app_id"`
@@ -21,6 +35,57 @@ type AppConfig struct {
 	Files           []AppFile        `json:"files"`
 	Variables       []AppVariable    `json:"variables"`
 	ExtensionValues []ExtensionValue `json:"extension_values"`
+	// Scale optionally maps service names to the number of containers started at deploy time.
+	Scale map[string]int `json:"scale,omitempty"`
+	// RestartPolicyOverride optionally forces the restart policy of every service, e.g. "unless-stopped".
+	// It takes precedence over the agent wide override.
+	RestartPolicyOverride string `json:"restart_policy_override,omitempty"`
+	// GitSource optionally deploys the compose files of a git repository instead of the pushed templates.
+	GitSource *GitSource `json:"git_source,omitempty"`
+	// WaitForHealthy makes deployments wait until every service with a healthcheck reports healthy and
+	// fail when one does not within the agent's health wait timeout.
+	WaitForHealthy bool `json:"wait_for_healthy,omitempty"`
+	// StoragePath optionally deploys the app below this directory instead of the agent's apps directory,
+	// e.g. on another disk. It must be within the agent's allowed storage paths.
+	StoragePath string `json:"storage_path,omitempty"`
+	// DeployStrategy selects how the containers of the app are replaced on deployments and updates; empty
+	// selects DeployStrategyRecreate.
+	DeployStrategy DeployStrategy `json:"deploy_strategy,omitempty"`
+	// ReadinessProbe optionally makes deployments fail unless the app accepts connections on this address once
+	// its containers are up: tcp://host:port or unix:///path/to/socket.
+	ReadinessProbe string `json:"readiness_probe,omitempty"`
+	// ReadinessProbeTimeout is the number of seconds a connection attempt of the probe may take (default 5).
+	ReadinessProbeTimeout int `json:"readiness_probe_timeout,omitempty"`
+	// ReadinessProbeRetries is the number of attempts after a failed one (default 10, negative disables retries).
+	ReadinessProbeRetries int `json:"readiness_probe_retries,omitempty"`
+	// BuildNoCache builds the images of services with a build section without using the build cache.
+	BuildNoCache bool `json:"build_no_cache,omitempty"`
+	// BuildArgs names the variables of the app that are passed to the image builds as build arguments.
+	BuildArgs []string `json:"build_args,omitempty"`
+	// DependsOnApps lists the IDs of apps that must be running and healthy before this app is started in a
+	// batch start.
+	DependsOnApps []string `json:"depends_on_apps,omitempty"`
+}
+
+// DeployStrategy is the way the containers of an app are replaced by the ones of a new deployment.
+type DeployStrategy string
+
+const (
+	// DeployStrategyRecreate stops the containers of the app before starting the new ones.
+	DeployStrategyRecreate DeployStrategy = "recreate"
+	// DeployStrategyRolling starts the new containers of a service next to the old ones and only removes the
+	// old ones once the new ones are up.
+	DeployStrategyRolling DeployStrategy = "rolling"
+)
+
+// GitSource references the compose files of an app in a git repository.
+type GitSource struct {
+	// URL is the clone URL of the repository.
+	URL string `json:"url"`
+	// Ref is the branch, tag or commit to deploy; empty selects the default branch.
+	Ref string `json:"ref,omitempty"`
+	// Path is the directory within the repository holding the compose files; empty selects the root.
+	Path string `json:"path,omitempty"`
 }
 
 // AppFile represents a file in the app configuration
@@ -37,6 +102,8 @@ type AppVariable struct {
 	Name        string      `json:"name"`
 	IsEncrypted bool        `json:"is_encrypted"`
 	Type        ContentType `json:"type"`
+	// Required variables must have a non-empty value for the app to be deployed.
+	Required bool `json:"required,omitempty"`
 }
 
 type ContentType string
@@ -62,8 +129,30 @@ func (ct ContentType) Value() string {
 	return string(ct)
 }
 
-// ParseAppConfig parses the app configuration from JSON bytes
+// ParseAppConfig parses the app configuration from JSON or YAML bytes. Documents starting with "{"
+// (and empty ones) are parsed as JSON, anything else as YAML.
 func ParseAppConfig(configBytes []byte) (*AppConfig, error) {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(configBytes, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] == '{' {
+		return parseJSONAppConfig(configBytes)
+	}
+	return parseYAMLAppConfig(configBytes)
+}
+
+// ParseAppConfigFile parses the app configuration read from the file name. The format is selected by
+// the extension of name and detected from the content for other extensions.
+func ParseAppConfigFile(name string, configBytes []byte) (*AppConfig, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return parseJSONAppConfig(configBytes)
+	case ".yaml", ".yml":
+		return parseYAMLAppConfig(configBytes)
+	default:
+		return ParseAppConfig(configBytes)
+	}
+}
+
+func parseJSONAppConfig(configBytes []byte) (*AppConfig, error) {
 	var config AppConfig
 	err := json.Unmarshal(configBytes, &config)
 	if err != nil {
@@ -71,3 +160,22 @@ func ParseAppConfig(configBytes []byte) (*AppConfig, error) {
 	}
 	return &config, nil
 }
+
+// parseYAMLAppConfig decodes a YAML app configuration through its JSON equivalent, so that both formats
+// share the field names and validation of the JSON decoder. Scalars keep their YAML type: values such as
+// `version: 1.0` must be quoted to be read as strings.
+func parseYAMLAppConfig(configBytes []byte) (*AppConfig, error) {
+	doc, err := yaml.UnmarshalMap(configBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML app config: %w", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML app config: %w", err)
+	}
+	config, err := parseJSONAppConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML app config: %w", err)
+	}
+	return config, nil
+}
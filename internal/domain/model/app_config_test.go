@@ -0,0 +1,96 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+const jsonAppConfig = `{
+  "id": "app-1",
+  "name": "demo",
+  "version": "1.0",
+  "files": [{"id": "f1", "name": "compose.yml", "is_encrypted": false, "type": "template"}],
+  "variables": [
+    {"id": "v1", "name": "PASSWORD", "is_encrypted": true, "type": "user"},
+    {"id": "v2", "name": "PORT", "is_encrypted": false, "type": "expose"}
+  ],
+  "extension_values": [],
+  "scale": {"web": 2},
+  "restart_policy_override": "unless-stopped",
+  "git_source": {"url": "https://git.example.com/app.git", "ref": "v1"}
+}`
+
+const yamlAppConfig = `# hand-edited local override
+id: app-1
+name: demo
+version: "1.0"
+files:
+  - id: f1
+    name: compose.yml
+    is_encrypted: false
+    type: template
+variables:
+  - {id: v1, name: PASSWORD, is_encrypted: true, type: user}
+  - id: v2
+    name: PORT
+    is_encrypted: false
+    type: expose
+extension_values: []
+scale:
+  web: 2
+restart_policy_override: unless-stopped
+git_source:
+  url: https://git.example.com/app.git
+  ref: v1
+`
+
+func TestParseAppConfigAcceptsEquivalentJSONAndYAML(t *testing.T) {
+	fromJSON, err := ParseAppConfig([]byte(jsonAppConfig))
+	if err != nil {
+		t.Fatalf("ParseAppConfig(JSON) returned error: %v", err)
+	}
+	fromYAML, err := ParseAppConfig([]byte(yamlAppConfig))
+	if err != nil {
+		t.Fatalf("ParseAppConfig(YAML) returned error: %v", err)
+	}
+	if !reflect.DeepEqual(fromJSON, fromYAML) {
+		t.Errorf("Expected equal configurations:\nJSON: %+v\nYAML: %+v", fromJSON, fromYAML)
+	}
+	if fromYAML.Scale["web"] != 2 || fromYAML.GitSource == nil || !fromYAML.Variables[0].IsEncrypted {
+		t.Errorf("Unexpected YAML configuration: %+v", fromYAML)
+	}
+}
+
+func TestParseAppConfigFileSelectsFormatByExtension(t *testing.T) {
+	testCases := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr bool
+	}{
+		{name: "json", file: "config.json", data: jsonAppConfig},
+		{name: "yaml", file: "config.yaml", data: yamlAppConfig},
+		{name: "yml", file: "config.yml", data: yamlAppConfig},
+		{name: "unknown extension", file: "config", data: yamlAppConfig},
+		{name: "yaml in json file", file: "config.json", data: yamlAppConfig, wantErr: true},
+		{name: "invalid yaml", file: "config.yaml", data: "name: [unterminated\n", wantErr: true},
+		{name: "mistyped yaml", file: "config.yaml", data: "name: demo\nversion: 1.0\n", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ParseAppConfigFile(tc.file, []byte(tc.data))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAppConfigFile returned error: %v", err)
+			}
+			if cfg.ID != "app-1" || cfg.Name != "demo" || cfg.Version != "1.0" {
+				t.Errorf("Unexpected configuration: %+v", cfg)
+			}
+		})
+	}
+}
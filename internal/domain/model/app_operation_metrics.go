@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// AppOperation names a lifecycle operation of an application in its metrics.
+type AppOperation string
+
+const (
+	AppOperationDeploy           AppOperation = "deploy"
+	AppOperationStart            AppOperation = "start"
+	AppOperationStop             AppOperation = "stop"
+	AppOperationRestart          AppOperation = "restart"
+	AppOperationUpdate           AppOperation = "update"
+	AppOperationRecreate         AppOperation = "recreate"
+	AppOperationDelete           AppOperation = "delete"
+	AppOperationRename           AppOperation = "rename"
+	AppOperationReconcile        AppOperation = "reconcile"
+	AppOperationRestartServices  AppOperation = "restart_services"
+	AppOperationRecreateServices AppOperation = "recreate_services"
+	AppOperationBackupVolumes    AppOperation = "backup_volumes"
+)
+
+// AppOperationMetrics holds the outcome counters and the duration histogram of one operation of an
+// application since the agent started.
+type AppOperationMetrics struct {
+	AppID     string       `json:"app_id"`
+	Operation AppOperation `json:"operation"`
+	Successes uint64       `json:"successes"`
+	Failures  uint64       `json:"failures"`
+	// DurationBuckets counts the runs that took at most the upper bound of each bucket, cumulatively and in
+	// ascending order of the bounds, like a Prometheus histogram. Runs taking longer than the last bound are
+	// only counted in Successes and Failures.
+	DurationBuckets []DurationBucket `json:"duration_buckets"`
+	// DurationSum is the total run time of all runs.
+	DurationSum time.Duration `json:"duration_sum"`
+}
+
+// DurationBucket is a bucket of a duration histogram.
+type DurationBucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      uint64        `json:"count"`
+}
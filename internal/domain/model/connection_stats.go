@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// MaxRecentDisconnects is the number of disconnects kept in ConnectionStats.RecentDisconnects.
+const MaxRecentDisconnects = 10
+
+// ConnectionStats describes the connection of the agent to the server. The statistics are persisted so
+// that they survive restarts of the agent.
+type ConnectionStats struct {
+	// ConnectedSince is the start of the current connection; zero while disconnected.
+	ConnectedSince time.Time `json:"connected_since"`
+	// TotalUptimeSeconds is the time spent connected by all previous connections.
+	TotalUptimeSeconds int64     `json:"total_uptime_seconds"`
+	DisconnectCount    uint64    `json:"disconnect_count"`
+	LastDisconnectAt   time.Time `json:"last_disconnect_at"`
+	// LastError is the most recent error that broke the connection to the server.
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at"`
+	// RecentDisconnects lists the latest disconnects, oldest first, up to MaxRecentDisconnects.
+	RecentDisconnects []ConnectionDisconnect `json:"recent_disconnects,omitempty"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// ConnectionDisconnect describes a single loss of the connection to the server.
+type ConnectionDisconnect struct {
+	At time.Time `json:"at"`
+	// UptimeSeconds is how long the connection lasted.
+	UptimeSeconds int64 `json:"uptime_seconds"`
+	// Error is the error that broke the connection, if one was observed.
+	Error string `json:"error,omitempty"`
+}
+
+// CurrentUptime returns how long the current connection has lasted at now, or zero while disconnected.
+func (s ConnectionStats) CurrentUptime(now time.Time) time.Duration {
+	if s.ConnectedSince.IsZero() || now.Before(s.ConnectedSince) {
+		return 0
+	}
+	return now.Sub(s.ConnectedSince)
+}
@@ -11,11 +11,31 @@ const (
 	ContainerStatusStopped     ContainerStatusCode = 5
 )
 
+// String returns the lower-case name of the status code.
+func (c ContainerStatusCode) String() string {
+	switch c {
+	case ContainerStatusActive:
+		return "active"
+	case ContainerStatusIdle:
+		return "idle"
+	case ContainerStatusRestarting:
+		return "restarting"
+	case ContainerStatusProblematic:
+		return "problematic"
+	case ContainerStatusStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
 type ContainerApp struct {
 	ID         string              `json:"id"`
 	Name       string              `json:"name"`
 	StatusCode ContainerStatusCode `json:"status_code"`
 	Containers []Container         `json:"containers"`
+	// Revision is the deployed revision read from the container labels; zero when unknown.
+	Revision uint32 `json:"revision,omitempty"`
 }
 
 type Container struct {
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// ContainerEventAction names a lifecycle event of a container reported by Docker.
+type ContainerEventAction string
+
+const (
+	ContainerEventStart ContainerEventAction = "start"
+	ContainerEventStop  ContainerEventAction = "stop"
+	ContainerEventDie   ContainerEventAction = "die"
+	ContainerEventOOM   ContainerEventAction = "oom"
+)
+
+// ContainerEvent is a lifecycle event of a container of an app.
+type ContainerEvent struct {
+	AppID         string               `json:"app_id"`
+	ContainerID   string               `json:"container_id"`
+	ContainerName string               `json:"container_name"`
+	ServiceName   string               `json:"service_name"`
+	Action        ContainerEventAction `json:"action"`
+	// Time is the time of the latest occurrence.
+	Time time.Time `json:"time"`
+	// ExitCode is the exit code of the container of a die event.
+	ExitCode int `json:"exit_code,omitempty"`
+	// Count is the number of occurrences coalesced into the event.
+	Count int `json:"count"`
+}
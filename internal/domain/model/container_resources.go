@@ -0,0 +1,25 @@
+package model
+
+// AppResources holds the resource limits and the current usage of the containers of an application.
+type AppResources struct {
+	AppID      string               `json:"app_id"`
+	Containers []ContainerResources `json:"containers"`
+}
+
+// ContainerResources describes the configured limits and the current usage of a single container.
+// Zero limits mean the container is not limited.
+type ContainerResources struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// CPULimit is the number of CPUs the container may use, e.g. 0.5 for half a CPU.
+	CPULimit float64 `json:"cpu_limit"`
+	// MemoryLimitBytes is the hard memory limit.
+	MemoryLimitBytes uint64 `json:"memory_limit_bytes"`
+	// CPUPercent is the CPU usage since the previous stats sample, where 100 is one full CPU.
+	CPUPercent float64 `json:"cpu_percent"`
+	// MemoryUsageBytes is the memory in use excluding the page cache.
+	MemoryUsageBytes uint64 `json:"memory_usage_bytes"`
+	// OOMKilled reports whether the last exit of the container was caused by the OOM killer.
+	OOMKilled    bool `json:"oom_killed"`
+	RestartCount int  `json:"restart_count"`
+}
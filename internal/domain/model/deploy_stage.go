@@ -0,0 +1,21 @@
+package model
+
+// DeployStage is a stage an app operation, such as a deployment, reaches while it runs.
+type DeployStage string
+
+const (
+	// DeployStageRendering renders and validates the app files.
+	DeployStageRendering DeployStage = "rendering"
+	// DeployStagePulling pulls the images of the app.
+	DeployStagePulling DeployStage = "pulling"
+	// DeployStageBuilding builds the images of the services of the app that have a build section.
+	DeployStageBuilding DeployStage = "building"
+	// DeployStageStarting creates and starts the containers of the app.
+	DeployStageStarting DeployStage = "starting"
+	// DeployStageWaitingHealthy waits for the services of the app to become healthy and ready.
+	DeployStageWaitingHealthy DeployStage = "waiting_healthy"
+	// DeployStageDone is reported once the operation succeeded.
+	DeployStageDone DeployStage = "done"
+	// DeployStageFailed is reported once the operation failed.
+	DeployStageFailed DeployStage = "failed"
+)
@@ -1,5 +1,13 @@
 package model
 
+import (
+	"errors"
+	"regexp"
+)
+
+// ErrContainerNotInApp is returned when the logs of a container are requested that does not belong to the app.
+var ErrContainerNotInApp = errors.New("container does not belong to the app")
+
 type LogLevel int8
 
 const (
@@ -33,3 +41,23 @@ type LogEntry struct {
 	Data        map[string]interface{} `json:"data,omitempty"`
 	ContainerID string                 `json:"container_id,omitempty"`
 }
+
+// LogFilter selects the log entries returned by a logs query.
+type LogFilter struct {
+	// MinLevel drops entries below the level, including entries of unknown level. LogLevelUnknown keeps
+	// entries of every level.
+	MinLevel LogLevel
+	// Pattern keeps only entries whose message matches; nil keeps every message.
+	Pattern *regexp.Regexp
+	// ContainerID restricts the logs to the container of the app with the ID, short ID or name; empty reads the
+	// logs of every container of the app.
+	ContainerID string
+}
+
+// Matches reports whether the entry is selected by the filter.
+func (f LogFilter) Matches(entry LogEntry) bool {
+	if f.MinLevel != LogLevelUnknown && entry.Level < f.MinLevel {
+		return false
+	}
+	return f.Pattern == nil || f.Pattern.MatchString(entry.Message)
+}
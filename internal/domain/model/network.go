@@ -1,5 +1,78 @@
 package model
 
+import (
+	"fmt"
+	"net/netip"
+)
+
 type Network struct {
 	Name string
+	// Driver is the network driver, e.g. bridge or overlay.
+	Driver string
+	// Scope is the level at which the network exists: local, global or swarm.
+	Scope string
+	// Subnets lists the IPAM subnets of the network in CIDR notation.
+	Subnets []string
+	// Gateways lists the gateway addresses of the subnets; each belongs to the subnet containing it.
+	Gateways []string
+	// Internal restricts external access to the network.
+	Internal bool
+	// Attachable allows standalone containers to attach to swarm scoped networks.
+	Attachable bool
+	// ContainerCount is the number of containers attached to the network.
+	ContainerCount int
+}
+
+// SubnetGateways validates the subnets and gateways of the network and returns the gateway of every subnet in
+// the order of Subnets, or an empty string for subnets without one. Subnets must be IPv4 or IPv6 networks in
+// CIDR notation, such as 172.20.0.0/16 or fd00:20::/64, and every gateway must be an address within one of them.
+func (n Network) SubnetGateways() ([]string, error) {
+	prefixes := make([]netip.Prefix, 0, len(n.Subnets))
+	for _, subnet := range n.Subnets {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subnet %q: %w", subnet, err)
+		}
+		if prefix.Masked() != prefix {
+			return nil, fmt.Errorf("invalid subnet %q: host bits are set, expected %s", subnet, prefix.Masked())
+		}
+		for _, other := range prefixes {
+			if other.Overlaps(prefix) {
+				return nil, fmt.Errorf("subnet %q overlaps %q", subnet, other)
+			}
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	gateways := make([]string, len(prefixes))
+	for _, gateway := range n.Gateways {
+		addr, err := netip.ParseAddr(gateway)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway %q: %w", gateway, err)
+		}
+		index := -1
+		for i, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				index = i
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("gateway %q is not within any subnet", gateway)
+		}
+		if gateways[index] != "" {
+			return nil, fmt.Errorf("subnet %q has more than one gateway", n.Subnets[index])
+		}
+		gateways[index] = addr.String()
+	}
+	return gateways, nil
+}
+
+// HasIPv6Subnet reports whether one of the subnets of the network is an IPv6 network.
+func (n Network) HasIPv6Subnet() bool {
+	for _, subnet := range n.Subnets {
+		if prefix, err := netip.ParsePrefix(subnet); err == nil && prefix.Addr().Is6() && !prefix.Addr().Is4In6() {
+			return true
+		}
+	}
+	return false
 }
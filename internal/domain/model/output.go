@@ -0,0 +1,7 @@
+package model
+
+// OutputLine is a line written by a command run for an app operation, such as `docker compose up`.
+type OutputLine struct {
+	Channel LogChannel
+	Text    string
+}
@@ -0,0 +1,14 @@
+package model
+
+// DockerEngineInfo describes the Docker engine the agent talks to.
+type DockerEngineInfo struct {
+	Version         string
+	APIVersion      string
+	OperatingSystem string
+}
+
+// DiskUsage describes the capacity of the filesystem holding a path, in bytes.
+type DiskUsage struct {
+	TotalBytes     uint64
+	AvailableBytes uint64
+}
@@ -0,0 +1,9 @@
+package model
+
+// VolumeBackup describes the archive a named volume of an application was backed up to.
+type VolumeBackup struct {
+	// Volume is the name of the Docker volume, e.g. demo_data.
+	Volume string `json:"volume"`
+	// Archive is the path of the gzip compressed tar archive holding the contents of the volume.
+	Archive string `json:"archive"`
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"winterflow-agent/internal/domain/model"
 )
 
@@ -30,8 +33,12 @@ type AppRepository interface {
 	// UpdateApp updates the specified application by its app ID and version.
 	UpdateApp(appID string) error
 
+	// RecreateApp recreates the containers of the specified application from the current images without pulling.
+	RecreateApp(appID string) error
+
 	// DeleteApp removes an application identified by the provided appID.
-	DeleteApp(appID string) error
+	// If purge is true, the named volumes of the application are removed as well.
+	DeleteApp(appID string, purge bool) error
 
 	// RenameApp renames an existing app identified by appID to the new name provided in newName. Returns an error on failure.
 	RenameApp(appID, newName string) error
@@ -39,6 +46,119 @@ type AppRepository interface {
 	// GetLogs retrieves logs for a specific application identified by appID.
 	// The time range is defined by unix timestamps (seconds) in the `since` and `until` parameters.
 	// A zero value disables the respective boundary (i.e. retrieve from the beginning or up to now).
-	// The `tail` parameter limits the number of log lines returned. A value <= 0 returns all available logs.
-	GetLogs(appID string, since int64, until int64, tail int32) (model.Logs, error)
+	// The `tail` parameter limits the number of log lines read per container and channel. A value <= 0 reads all
+	// available logs. Only the lines selected by `filter` are returned, and model.ErrContainerNotInApp when the
+	// container of the filter is not one of the app. Reading the logs is stopped when ctx is done.
+	GetLogs(ctx context.Context, appID string, since int64, until int64, tail int32, filter model.LogFilter) (model.Logs, error)
+
+	// RenderCompose renders the specified revision of an application without deploying it and returns the
+	// merged compose configuration. Values of encrypted variables are redacted.
+	RenderCompose(appID string, revision uint32) (string, error)
+
+	// GetAppResources returns the configured CPU and memory limits and the current usage of the containers
+	// of the specified application.
+	GetAppResources(appID string) (model.AppResources, error)
+}
+
+// DeployQueue is implemented by app repositories that limit the number of concurrent deployments.
+type DeployQueue interface {
+	// DeployQueueDepth returns the number of deployment operations waiting for a free slot.
+	DeployQueueDepth() int
+}
+
+// DockerPinger is implemented by app repositories that talk to a Docker daemon.
+type DockerPinger interface {
+	// PingDocker checks that the Docker daemon responds.
+	PingDocker(ctx context.Context) error
+}
+
+// OrchestratorVersioner is implemented by app repositories that can report the version of their orchestrator.
+type OrchestratorVersioner interface {
+	// GetOrchestratorVersion returns the version of the orchestrator the apps are deployed with, e.g. of
+	// Docker Compose.
+	GetOrchestratorVersion() (string, error)
+}
+
+// OperationCanceler is implemented by app repositories whose lifecycle operations can be canceled.
+type OperationCanceler interface {
+	// CancelOperation cancels the operation running on the app and reports whether one was running.
+	CancelOperation(appID string) bool
+}
+
+// AppLocker is implemented by app repositories that can keep lifecycle operations off an app, e.g. while
+// its templates are replaced.
+type AppLocker interface {
+	// LockApp waits until no lifecycle operation runs on the app and keeps new ones from starting until the
+	// returned function is called.
+	LockApp(appID string) func()
+}
+
+// ServiceController is implemented by app repositories that can restart or recreate a subset of the services
+// of an app.
+type ServiceController interface {
+	// RestartServices restarts the containers of the named services of the app. It fails when the rendered app
+	// does not define one of them.
+	RestartServices(appID string, services []string) error
+	// RecreateServices recreates the containers of the named services of the app from the current images
+	// without pulling or touching their dependencies. It fails when the rendered app does not define one of them.
+	RecreateServices(appID string, services []string) error
+}
+
+// OutputStreamer is implemented by app repositories that can report the output of the commands they run for
+// the operations of an app while the commands run.
+type OutputStreamer interface {
+	// StreamOutput sends the output lines of the commands run for the app to out until the returned function
+	// is called. out must not block.
+	StreamOutput(appID string, out func(model.OutputLine)) func()
+}
+
+// ProgressReporter receives the stages the operations of an app reach.
+type ProgressReporter interface {
+	// ReportStage is called from the operation when it reaches stage, so it should return quickly.
+	ReportStage(stage model.DeployStage)
+}
+
+// ProgressTracker is implemented by app repositories that report the stages of the operations of an app.
+type ProgressTracker interface {
+	// TrackProgress reports the stages of the operations of the app to reporter until the returned function
+	// is called.
+	TrackProgress(appID string, reporter ProgressReporter) func()
+}
+
+// AppReconciler is implemented by app repositories that can reset the deployment of an app to its templates.
+type AppReconciler interface {
+	// ReconcileApp deletes the rendered directory of the app, renders the latest revision from scratch and
+	// deploys it. It reports whether the previous directory differed from the rendered one.
+	ReconcileApp(appID string) (bool, error)
+}
+
+// OperationMetricsSource is implemented by app repositories that measure the lifecycle operations of the apps.
+type OperationMetricsSource interface {
+	// AppOperationMetrics returns the metrics of every operation run on the app since the agent started, or
+	// of every app when appID is empty, ordered by app ID and operation.
+	AppOperationMetrics(appID string) []model.AppOperationMetrics
+}
+
+// VolumeBackupper is implemented by app repositories that can back up the named volumes of an app.
+type VolumeBackupper interface {
+	// BackupVolumes archives the contents of every named volume of the app into a new backup directory and
+	// returns the archives written.
+	BackupVolumes(appID string) ([]model.VolumeBackup, error)
+}
+
+// ContainerEventSource is implemented by app repositories that can report the lifecycle events of the
+// containers of the apps.
+type ContainerEventSource interface {
+	// ContainerEvents subscribes to the start, stop, die and OOM events of the managed containers until ctx is
+	// done. The events channel is closed when the subscription ends; an error that ended it is sent on the
+	// error channel first.
+	ContainerEvents(ctx context.Context) (<-chan model.ContainerEvent, <-chan error)
+}
+
+// ContainerEventHistory is implemented by app repositories that can report the lifecycle events the
+// containers of the apps had in the past.
+type ContainerEventHistory interface {
+	// RecentContainerEvents returns the start, stop, die and OOM events of the managed containers since the
+	// given time, oldest first.
+	RecentContainerEvents(ctx context.Context, since time.Time) ([]model.ContainerEvent, error)
 }
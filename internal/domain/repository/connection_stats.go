@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"winterflow-agent/internal/domain/model"
+)
+
+// ConnectionStatsRepository persists the statistics of the connection to the server.
+type ConnectionStatsRepository interface {
+	// Load returns the persisted statistics, or zero statistics when none have been saved yet.
+	Load() (model.ConnectionStats, error)
+
+	// Save replaces the persisted statistics. Readers never observe a partially written state.
+	Save(stats model.ConnectionStats) error
+}
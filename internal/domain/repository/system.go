@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"winterflow-agent/internal/domain/model"
+)
+
+// SystemInfoRepository gathers diagnostic details about the host the agent runs on.
+// Each method reads a single data source so that callers can tolerate individual failures.
+type SystemInfoRepository interface {
+	// GetOSName returns a human-readable name of the operating system distribution.
+	GetOSName() (string, error)
+
+	// GetKernelVersion returns the version of the running kernel.
+	GetKernelVersion() (string, error)
+
+	// GetMemoryTotal returns the total physical memory in bytes.
+	GetMemoryTotal() (uint64, error)
+
+	// GetCPUCount returns the number of logical CPUs.
+	GetCPUCount() (int, error)
+
+	// GetDiskUsage returns the capacity of the filesystem holding path.
+	GetDiskUsage(path string) (model.DiskUsage, error)
+
+	// GetDockerEngine returns information about the Docker engine.
+	GetDockerEngine() (model.DockerEngineInfo, error)
+}
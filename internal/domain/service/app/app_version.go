@@ -8,10 +8,13 @@ import (
 	"sort"
 	"strconv"
 	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/domain/service/util"
 )
 
 type RevisionServiceInterface interface {
+	GetAppIDs() ([]string, error)
+
 	GetAppRevisions(appID string) ([]uint32, error)
 
 	ValidateAppRevision(appID string, revision uint32) (bool, error)
@@ -45,6 +48,34 @@ func NewRevisionService(config *config.Config) *RevisionService {
 	}
 }
 
+// GetAppIDs returns the sorted IDs of all apps with at least one stored revision.
+func (s *RevisionService) GetAppIDs() ([]string, error) {
+	templatesDir := s.config.GetAppsTemplatesPath()
+	entries, err := os.ReadDir(templatesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read apps templates directory %s: %w", templatesDir, err)
+	}
+
+	appIDs := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		revisions, err := s.GetAppRevisions(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) > 0 {
+			appIDs = append(appIDs, entry.Name())
+		}
+	}
+	sort.Strings(appIDs)
+	return appIDs, nil
+}
+
 func (s *RevisionService) GetAppRevisions(appID string) ([]uint32, error) {
 	appDir := filepath.Join(s.config.GetAppsTemplatesPath(), appID)
 
@@ -74,10 +105,8 @@ func (s *RevisionService) GetAppRevisions(appID string) ([]uint32, error) {
 			continue
 		}
 
-		// Check if the revision directory contains a config.json file
-		configPath := filepath.Join(appDir, revisionStr, "config.json")
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			// Skip revision directories without config.json
+		// Skip revision directories without an app configuration
+		if !hasAppConfig(filepath.Join(appDir, revisionStr)) {
 			continue
 		}
 
@@ -92,6 +121,16 @@ func (s *RevisionService) GetAppRevisions(appID string) ([]uint32, error) {
 	return revisions, nil
 }
 
+// hasAppConfig reports whether revisionDir holds an app configuration in any of the accepted formats.
+func hasAppConfig(revisionDir string) bool {
+	for _, name := range model.AppConfigFileNames {
+		if _, err := os.Stat(filepath.Join(revisionDir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *RevisionService) ValidateAppRevision(appID string, revision uint32) (bool, error) {
 	revisions, err := s.GetAppRevisions(appID)
 	if err != nil {
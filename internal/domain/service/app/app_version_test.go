@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// createRevisions lays out count revision directories (1..count) for appID,
+// each containing the config.json that GetAppRevisions requires.
+func createRevisions(t *testing.T, cfg *config.Config, appID string, count int) {
+	t.Helper()
+	for i := 1; i <= count; i++ {
+		revisionDir := filepath.Join(cfg.GetAppsTemplatesPath(), appID, fmt.Sprintf("%d", i))
+		if err := os.MkdirAll(revisionDir, 0o755); err != nil {
+			t.Fatalf("Failed to create revision dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(revisionDir, "config.json"), []byte(`{}`), 0o644); err != nil {
+			t.Fatalf("Failed to write config.json: %v", err)
+		}
+	}
+}
+
+func TestDeleteOldRevisionsRespectsRetention(t *testing.T) {
+	testCases := []struct {
+		name      string
+		retention int
+		expected  []uint32
+	}{
+		{
+			name:      "Retention of one",
+			retention: 1,
+			expected:  []uint32{5},
+		},
+		{
+			name:      "Retention of three",
+			retention: 3,
+			expected:  []uint32{3, 4, 5},
+		},
+		{
+			name:      "Retention larger than revision count",
+			retention: 10,
+			expected:  []uint32{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{BasePath: t.TempDir(), RevisionRetention: tc.retention}
+			appID := "test-app"
+			createRevisions(t, cfg, appID, 5)
+
+			service := NewRevisionService(cfg)
+			if err := service.DeleteOldRevisions(appID); err != nil {
+				t.Fatalf("DeleteOldRevisions returned error: %v", err)
+			}
+
+			revisions, err := service.GetAppRevisions(appID)
+			if err != nil {
+				t.Fatalf("GetAppRevisions returned error: %v", err)
+			}
+			if !reflect.DeepEqual(revisions, tc.expected) {
+				t.Errorf("Expected revisions %v, got %v", tc.expected, revisions)
+			}
+		})
+	}
+}
+
+func TestGetKeepAppRevisionsEnforcesMinimum(t *testing.T) {
+	cfg := &config.Config{RevisionRetention: -2}
+	if got := cfg.GetKeepAppRevisions(); got != 1 {
+		t.Errorf("Expected retention to be clamped to 1, got %d", got)
+	}
+
+	cfg = &config.Config{}
+	if got := cfg.GetKeepAppRevisions(); got != 3 {
+		t.Errorf("Expected default retention of 3, got %d", got)
+	}
+}
@@ -0,0 +1,183 @@
+package util
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveFilter decides whether a file becomes part of an archive. It receives the slash separated path
+// of the file relative to the archived directory together with its content and returns the content to
+// store, or false to leave the file out.
+type ArchiveFilter func(relPath string, content []byte) ([]byte, bool)
+
+// WriteTarGz writes a gzip compressed tarball of all regular files below srcDir to w. Entry names are
+// relative to srcDir. A nil filter includes every file unchanged.
+func WriteTarGz(w io.Writer, srcDir string, filter ArchiveFilter) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	walkFn := func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to calculate relative path: %w", err)
+		}
+		rel = filepath.ToSlash(rel)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if filter != nil {
+			var include bool
+			if content, include = filter(rel, content); !include {
+				return nil
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		header := &tar.Header{
+			Name:    rel,
+			Mode:    int64(info.Mode().Perm()),
+			Size:    int64(len(content)),
+			ModTime: info.ModTime(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write tar header for %s: %w", rel, err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("failed to write %s to tarball: %w", rel, err)
+		}
+		return nil
+	}
+
+	if err := filepath.WalkDir(srcDir, walkFn); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", srcDir, err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize tarball: %w", err)
+	}
+	return gz.Close()
+}
+
+// ArchiveLimits bounds the content of a tarball. Zero fields do not limit.
+type ArchiveLimits struct {
+	// MaxEntries is the maximum number of entries of any type.
+	MaxEntries int
+	// MaxSize is the maximum total size of the regular files once decompressed.
+	MaxSize int64
+}
+
+// CheckTarGz reads the entry headers of a gzip compressed tarball and returns an error when it holds more
+// entries or more decompressed file content than limits allow. Nothing is written, and the archive is
+// rejected before content beyond the limits is decompressed, so callers can check an untrusted archive
+// before extracting it.
+func CheckTarGz(r io.Reader, limits ArchiveLimits) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	var entries int
+	var size int64
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+
+		entries++
+		if limits.MaxEntries > 0 && entries > limits.MaxEntries {
+			return fmt.Errorf("archive holds more than %d entries", limits.MaxEntries)
+		}
+		if header.Typeflag == tar.TypeReg {
+			size += header.Size
+			if limits.MaxSize > 0 && size > limits.MaxSize {
+				return fmt.Errorf("archive content exceeds %d bytes", limits.MaxSize)
+			}
+		}
+	}
+}
+
+// ExtractTarGz unpacks the regular files of a gzip compressed tarball into dstDir. The whole archive is
+// rejected as soon as an entry name could escape dstDir; directories, links and other special entries
+// are skipped. Callers should extract into a staging directory so a rejected archive leaves no trace.
+func ExtractTarGz(r io.Reader, dstDir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel, err := sanitizeEntryName(header.Name)
+		if err != nil {
+			return fmt.Errorf("invalid archive entry %q: %w", header.Name, err)
+		}
+
+		target := filepath.Join(dstDir, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", target, err)
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			return fmt.Errorf("failed to extract %s: %w", rel, err)
+		}
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to close %s: %w", target, err)
+		}
+	}
+}
+
+// sanitizeEntryName ensures that an archive entry name cannot escape the extraction directory. The
+// implementation mirrors the logic used for template file names in the save app command
+// (sanitizeTemplateFilename): the name is cleaned and made relative, and anything containing
+// traversal ("..") segments is rejected.
+func sanitizeEntryName(name string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	rel = strings.TrimLeft(rel, string(os.PathSeparator))
+
+	if rel == "" || rel == "." {
+		return "", fmt.Errorf("invalid empty filename")
+	}
+	if filepath.IsAbs(rel) || strings.Contains(rel, "..") {
+		return "", fmt.Errorf("invalid filename: potential path traversal detected")
+	}
+	return rel, nil
+}
@@ -15,7 +15,7 @@ func CopyDirectory(src, dst string) error {
 	}
 
 	// Create destination directory with same permissions
-	err = os.MkdirAll(dst, srcInfo.Mode())
+	err = os.MkdirAll(dst, srcInfo.Mode().Perm())
 	if err != nil {
 		return fmt.Errorf("failed to create destination directory %s: %w", dst, err)
 	}
@@ -46,6 +46,15 @@ func CopyDirectory(src, dst string) error {
 		}
 	}
 
+	// Keep the permissions and modification time of the source directory; copying the entries above
+	// updated the modification time of dst.
+	if err := os.Chmod(dst, srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions of %s: %w", dst, err)
+	}
+	if err := os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime()); err != nil {
+		return fmt.Errorf("failed to set modification time of %s: %w", dst, err)
+	}
+
 	return nil
 }
 
@@ -77,5 +86,15 @@ func CopyFile(src, dst string) error {
 		return fmt.Errorf("failed to copy content from %s to %s: %w", src, dst, err)
 	}
 
+	// Keep the permissions and modification time of the source file, so that copies of unchanged files
+	// (e.g. into a new revision) are not mistaken for changes. OpenFile applies the umask and leaves the
+	// mode of an existing file untouched.
+	if err := dstFile.Chmod(srcInfo.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions of %s: %w", dst, err)
+	}
+	if err := os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime()); err != nil {
+		return fmt.Errorf("failed to set modification time of %s: %w", dst, err)
+	}
+
 	return nil
 }
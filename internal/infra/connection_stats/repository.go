@@ -0,0 +1,78 @@
+package connection_stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
+// connectionStatsRepository stores the connection statistics as a JSON file.
+type connectionStatsRepository struct {
+	path string
+}
+
+// Compile-time assertion that *connectionStatsRepository implements the interface.
+var _ repository.ConnectionStatsRepository = (*connectionStatsRepository)(nil)
+
+// NewConnectionStatsRepository creates a ConnectionStatsRepository storing the statistics in path.
+func NewConnectionStatsRepository(path string) repository.ConnectionStatsRepository {
+	return &connectionStatsRepository{path: path}
+}
+
+// Load implements repository.ConnectionStatsRepository.
+func (r *connectionStatsRepository) Load() (model.ConnectionStats, error) {
+	var stats model.ConnectionStats
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stats, nil
+		}
+		return stats, fmt.Errorf("failed to read connection stats: %w", err)
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return model.ConnectionStats{}, fmt.Errorf("failed to parse connection stats %s: %w", r.path, err)
+	}
+	return stats, nil
+}
+
+// Save implements repository.ConnectionStatsRepository. The statistics are written to a temporary file
+// that replaces the previous one, so that a crash never leaves a truncated file behind.
+func (r *connectionStatsRepository) Save(stats model.ConnectionStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal connection stats: %w", err)
+	}
+
+	dir := filepath.Dir(r.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(r.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary connection stats file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write connection stats: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync connection stats: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close connection stats: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to set permissions of connection stats: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("failed to replace connection stats: %w", err)
+	}
+	return nil
+}
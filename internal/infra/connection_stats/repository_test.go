@@ -0,0 +1,80 @@
+package connection_stats
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+func TestLoadWithoutStatsFile(t *testing.T) {
+	repo := NewConnectionStatsRepository(filepath.Join(t.TempDir(), "logs", "connection_stats.json"))
+
+	stats, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(stats, model.ConnectionStats{}) {
+		t.Errorf("Expected zero stats, got %+v", stats)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "connection_stats.json")
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stats := model.ConnectionStats{
+		ConnectedSince:     base.Add(time.Hour),
+		TotalUptimeSeconds: 3542,
+		DisconnectCount:    2,
+		LastDisconnectAt:   base.Add(30 * time.Minute),
+		LastError:          "rpc error: code = Unavailable desc = connection reset",
+		LastErrorAt:        base.Add(30 * time.Minute),
+		RecentDisconnects: []model.ConnectionDisconnect{
+			{At: base, UptimeSeconds: 1742},
+			{At: base.Add(30 * time.Minute), UptimeSeconds: 1800, Error: "rpc error: code = Unavailable desc = connection reset"},
+		},
+		UpdatedAt: base.Add(61 * time.Minute),
+	}
+
+	if err := NewConnectionStatsRepository(path).Save(stats); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	// A new repository, as after a restart, reads the same statistics.
+	loaded, err := NewConnectionStatsRepository(path).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, stats) {
+		t.Errorf("Expected %+v, got %+v", stats, loaded)
+	}
+
+	stats.DisconnectCount = 3
+	if err := NewConnectionStatsRepository(path).Save(stats); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "connection_stats.json" {
+		t.Errorf("Expected only the stats file to be left behind, got %v", entries)
+	}
+	if loaded, _ := NewConnectionStatsRepository(path).Load(); loaded.DisconnectCount != 3 {
+		t.Errorf("Expected the replaced stats to be loaded, got %+v", loaded)
+	}
+}
+
+func TestLoadRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "connection_stats.json")
+	if err := os.WriteFile(path, []byte(`{"disconnect_count":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewConnectionStatsRepository(path).Load(); err == nil {
+		t.Fatal("Expected an error for a truncated file")
+	}
+}
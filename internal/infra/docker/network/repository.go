@@ -13,9 +13,17 @@ import (
 	"winterflow-agent/pkg/log"
 )
 
+// networkAPI is the part of the Docker client used to manage networks.
+type networkAPI interface {
+	NetworkList(ctx context.Context, options networktypes.ListOptions) ([]networktypes.Summary, error)
+	NetworkInspect(ctx context.Context, networkID string, options networktypes.InspectOptions) (networktypes.Inspect, error)
+	NetworkCreate(ctx context.Context, name string, options networktypes.CreateOptions) (networktypes.CreateResponse, error)
+	NetworkRemove(ctx context.Context, networkID string) error
+}
+
 // dockerNetworkRepository provides thread-safe methods for managing Docker networks using a Docker client.
 type dockerNetworkRepository struct {
-	client *client.Client
+	client networkAPI
 	mu     sync.RWMutex
 }
 
@@ -42,20 +50,48 @@ func (r *dockerNetworkRepository) GetNetworks() ([]model.Network, error) {
 		return nil, fmt.Errorf("list networks: %w", err)
 	}
 
+	// Listing does not report attached containers, so every network is inspected. A network that
+	// cannot be inspected, e.g. because it was removed meanwhile, is reported with the listed details.
 	networks := make([]model.Network, 0, len(dockerNetworks))
 	for _, dn := range dockerNetworks {
-		networks = append(networks, model.Network{Name: dn.Name})
+		inspect, err := r.client.NetworkInspect(ctx, dn.ID, networktypes.InspectOptions{})
+		if err != nil {
+			log.Warn("[Network] failed to inspect network", "network_name", dn.Name, "error", err)
+			inspect = dn
+		}
+		networks = append(networks, networkFromInspect(inspect))
 	}
 
 	return networks, nil
 }
 
+// networkFromInspect converts the Docker network details into the domain model.
+func networkFromInspect(inspect networktypes.Inspect) model.Network {
+	network := model.Network{
+		Name:           inspect.Name,
+		Driver:         inspect.Driver,
+		Scope:          inspect.Scope,
+		ContainerCount: len(inspect.Containers),
+	}
+	for _, cfg := range inspect.IPAM.Config {
+		if cfg.Subnet != "" {
+			network.Subnets = append(network.Subnets, cfg.Subnet)
+		}
+	}
+	return network
+}
+
 func (r *dockerNetworkRepository) CreateNetwork(network model.Network) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	options, err := createOptions(network)
+	if err != nil {
+		return fmt.Errorf("create network: %w", err)
+	}
+
 	ctx := context.Background()
-	_, err := r.client.NetworkCreate(ctx, network.Name, networktypes.CreateOptions{})
+	_, err = r.client.NetworkCreate(ctx, network.Name, options)
 	if err != nil {
 		log.Error("[Network] failed to create network", "network_name", network.Name, "error", err)
 		return fmt.Errorf("create network: %w", err)
@@ -65,6 +101,32 @@ func (r *dockerNetworkRepository) CreateNetwork(network model.Network) error {
 	return nil
 }
 
+// createOptions returns the options of `docker network create` for the network. IPv6 is enabled when one of
+// its subnets is an IPv6 network.
+func createOptions(network model.Network) (networktypes.CreateOptions, error) {
+	options := networktypes.CreateOptions{
+		Driver:     network.Driver,
+		Internal:   network.Internal,
+		Attachable: network.Attachable,
+	}
+
+	gateways, err := network.SubnetGateways()
+	if err != nil {
+		return options, err
+	}
+	if len(network.Subnets) > 0 {
+		options.IPAM = &networktypes.IPAM{}
+		for i, subnet := range network.Subnets {
+			options.IPAM.Config = append(options.IPAM.Config, networktypes.IPAMConfig{Subnet: subnet, Gateway: gateways[i]})
+		}
+	}
+	if network.HasIPv6Subnet() {
+		enableIPv6 := true
+		options.EnableIPv6 = &enableIPv6
+	}
+	return options, nil
+}
+
 func (r *dockerNetworkRepository) DeleteNetwork(name string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
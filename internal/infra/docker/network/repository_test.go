@@ -0,0 +1,174 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	networktypes "github.com/docker/docker/api/types/network"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// fakeNetworkAPI serves the list and inspect fixtures of a Docker daemon.
+type fakeNetworkAPI struct {
+	networkAPI
+	list    []networktypes.Summary
+	inspect map[string]networktypes.Inspect
+}
+
+func (f *fakeNetworkAPI) NetworkList(context.Context, networktypes.ListOptions) ([]networktypes.Summary, error) {
+	return f.list, nil
+}
+
+func (f *fakeNetworkAPI) NetworkInspect(_ context.Context, networkID string, _ networktypes.InspectOptions) (networktypes.Inspect, error) {
+	inspect, ok := f.inspect[networkID]
+	if !ok {
+		return networktypes.Inspect{}, errors.New("network " + networkID + " not found")
+	}
+	return inspect, nil
+}
+
+func TestGetNetworksInspectsDetails(t *testing.T) {
+	api := &fakeNetworkAPI{
+		list: []networktypes.Summary{
+			{ID: "n1", Name: "bridge", Driver: "bridge", Scope: "local"},
+			{ID: "n2", Name: "proxy", Driver: "bridge", Scope: "local"},
+			{ID: "n3", Name: "gone", Driver: "overlay", Scope: "swarm"},
+		},
+		inspect: map[string]networktypes.Inspect{
+			"n1": {
+				ID: "n1", Name: "bridge", Driver: "bridge", Scope: "local",
+				IPAM: networktypes.IPAM{Config: []networktypes.IPAMConfig{{Subnet: "172.17.0.0/16", Gateway: "172.17.0.1"}}},
+			},
+			"n2": {
+				ID: "n2", Name: "proxy", Driver: "bridge", Scope: "local",
+				IPAM: networktypes.IPAM{Config: []networktypes.IPAMConfig{
+					{Subnet: "172.20.0.0/16"},
+					{Subnet: "fd00:20::/64"},
+				}},
+				Containers: map[string]networktypes.EndpointResource{
+					"c1": {Name: "traefik"},
+					"c2": {Name: "web"},
+				},
+			},
+		},
+	}
+	r := &dockerNetworkRepository{client: api}
+
+	networks, err := r.GetNetworks()
+	if err != nil {
+		t.Fatalf("GetNetworks returned error: %v", err)
+	}
+
+	expected := []model.Network{
+		{Name: "bridge", Driver: "bridge", Scope: "local", Subnets: []string{"172.17.0.0/16"}},
+		{Name: "proxy", Driver: "bridge", Scope: "local", Subnets: []string{"172.20.0.0/16", "fd00:20::/64"}, ContainerCount: 2},
+		{Name: "gone", Driver: "overlay", Scope: "swarm"},
+	}
+	if !reflect.DeepEqual(networks, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, networks)
+	}
+}
+
+// recordingNetworkAPI records the networks created through it.
+type recordingNetworkAPI struct {
+	networkAPI
+	name    string
+	options networktypes.CreateOptions
+}
+
+func (f *recordingNetworkAPI) NetworkCreate(_ context.Context, name string, options networktypes.CreateOptions) (networktypes.CreateResponse, error) {
+	f.name = name
+	f.options = options
+	return networktypes.CreateResponse{ID: "n1"}, nil
+}
+
+func TestCreateNetworkOptions(t *testing.T) {
+	enabled := true
+	tests := []struct {
+		name    string
+		network model.Network
+		want    networktypes.CreateOptions
+	}{
+		{
+			name:    "name only",
+			network: model.Network{Name: "proxy"},
+			want:    networktypes.CreateOptions{},
+		},
+		{
+			name:    "IPv4 subnet with gateway",
+			network: model.Network{Name: "proxy", Subnets: []string{"172.20.0.0/16"}, Gateways: []string{"172.20.0.1"}},
+			want: networktypes.CreateOptions{
+				IPAM: &networktypes.IPAM{Config: []networktypes.IPAMConfig{{Subnet: "172.20.0.0/16", Gateway: "172.20.0.1"}}},
+			},
+		},
+		{
+			name: "dual stack with IPv6 gateway",
+			network: model.Network{
+				Name:     "proxy",
+				Driver:   "bridge",
+				Subnets:  []string{"172.20.0.0/16", "fd00:20::/64"},
+				Gateways: []string{"fd00:20::1"},
+			},
+			want: networktypes.CreateOptions{
+				Driver:     "bridge",
+				EnableIPv6: &enabled,
+				IPAM: &networktypes.IPAM{Config: []networktypes.IPAMConfig{
+					{Subnet: "172.20.0.0/16"},
+					{Subnet: "fd00:20::/64", Gateway: "fd00:20::1"},
+				}},
+			},
+		},
+		{
+			name:    "internal attachable overlay",
+			network: model.Network{Name: "backend", Driver: "overlay", Internal: true, Attachable: true},
+			want:    networktypes.CreateOptions{Driver: "overlay", Internal: true, Attachable: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &recordingNetworkAPI{}
+			r := &dockerNetworkRepository{client: api}
+
+			if err := r.CreateNetwork(tt.network); err != nil {
+				t.Fatalf("CreateNetwork returned error: %v", err)
+			}
+			if api.name != tt.network.Name {
+				t.Errorf("Expected network %q to be created, got %q", tt.network.Name, api.name)
+			}
+			if !reflect.DeepEqual(api.options, tt.want) {
+				t.Errorf("Unexpected create options:\n got: %+v\nwant: %+v", api.options, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateNetworkRejectsInvalidAddressing(t *testing.T) {
+	tests := map[string]model.Network{
+		"invalid subnet":         {Subnets: []string{"172.20.0.0"}},
+		"host bits set":          {Subnets: []string{"172.20.0.1/16"}},
+		"overlapping subnets":    {Subnets: []string{"172.20.0.0/16", "172.20.1.0/24"}},
+		"invalid gateway":        {Subnets: []string{"172.20.0.0/16"}, Gateways: []string{"172.20.0"}},
+		"gateway outside subnet": {Subnets: []string{"172.20.0.0/16"}, Gateways: []string{"10.0.0.1"}},
+		"gateway without subnet": {Gateways: []string{"172.20.0.1"}},
+		"two gateways in subnet": {Subnets: []string{"fd00:20::/64"}, Gateways: []string{"fd00:20::1", "fd00:20::2"}},
+	}
+
+	for name, network := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &recordingNetworkAPI{}
+			r := &dockerNetworkRepository{client: api}
+
+			network.Name = "proxy"
+			if err := r.CreateNetwork(network); err == nil {
+				t.Fatal("Expected CreateNetwork to fail")
+			}
+			if api.name != "" {
+				t.Errorf("Expected no network to be created, got %q", api.name)
+			}
+		})
+	}
+}
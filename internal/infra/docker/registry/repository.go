@@ -43,7 +43,7 @@ func (r *dockerRegistryRepository) GetRegistries() ([]model.Registry, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	cfgPath, err := getDockerConfigPath()
+	cfgPath, err := GetDockerConfigPath()
 	if err != nil {
 		return nil, err
 	}
@@ -116,10 +116,10 @@ func (r *dockerRegistryRepository) DeleteRegistry(address string) error {
 	return nil
 }
 
-// getDockerConfigPath resolves the path to the Docker configuration file
+// GetDockerConfigPath resolves the path to the Docker configuration file
 // (~/.docker/config.json) while taking the DOCKER_CONFIG environment variable
 // into account.
-func getDockerConfigPath() (string, error) {
+func GetDockerConfigPath() (string, error) {
 	dockerCfgDir := os.Getenv("DOCKER_CONFIG")
 	if dockerCfgDir == "" {
 		home, err := os.UserHomeDir()
@@ -0,0 +1,117 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+	"winterflow-agent/pkg/yaml"
+)
+
+// buildableServices returns the sorted names of the services of the rendered compose files in appDir that
+// have a build section.
+func (r *composeRepository) buildableServices(appDir string) ([]string, error) {
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return nil, err
+	}
+
+	buildable := make(map[string]struct{})
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name, raw := range definitions {
+			definition, _ := raw.(map[string]interface{})
+			if build, ok := definition["build"]; ok && build != nil {
+				buildable[name] = struct{}{}
+			}
+		}
+	}
+
+	services := make([]string, 0, len(buildable))
+	for name := range buildable {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// composeBuild runs `docker compose build` for the services of the app rendered in appDir that have a build
+// section, so that their images are rebuilt before the containers are started. It does nothing when no
+// service has one. The variables named by the build args of the app are passed by name only; compose reads
+// their values from the env file and the secret environment, which keeps them out of the command line.
+func (r *composeRepository) composeBuild(appID, appDir string) error {
+	services, err := r.buildableServices(appDir)
+	if err != nil || len(services) == 0 {
+		return err
+	}
+	cfg, err := orchestrator.GetCurrentConfig(appDir)
+	if err != nil {
+		cfg = &model.AppConfig{}
+	}
+	buildArgs, err := buildArgsOf(cfg)
+	if err != nil {
+		return err
+	}
+
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+	args := make([]string, 0)
+	if fileExists(filepath.Join(appDir, ".winterflow.env")) {
+		args = append(args, "--env-file", ".winterflow.env")
+	}
+	args = append(args, r.buildComposeFileArgs(files)...)
+	args = append(args, "build")
+	if cfg.BuildNoCache {
+		args = append(args, "--no-cache")
+	}
+	args = append(args, buildArgs...)
+	args = append(args, services...)
+
+	// Base images of the builds may come from private registries.
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	release, err := r.deploys.acquire(r.operations.context(appDir))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	r.reportStage(appID, model.DeployStageBuilding)
+	log.Info("[Deploy] building images", "app_id", appID, "services", services, "no_cache", cfg.BuildNoCache)
+	return r.runDockerComposeWithRetry(appDir, env, args...)
+}
+
+// buildArgsOf converts the build args of cfg into `--build-arg NAME` CLI arguments. Every build arg must name a
+// variable of the app.
+func buildArgsOf(cfg *model.AppConfig) ([]string, error) {
+	variables := make(map[string]bool, len(cfg.Variables))
+	for _, v := range cfg.Variables {
+		variables[v.Name] = true
+	}
+	args := make([]string, 0, 2*len(cfg.BuildArgs))
+	for _, name := range cfg.BuildArgs {
+		if !variables[name] {
+			return nil, fmt.Errorf("invalid build arg %q: the app has no such variable", name)
+		}
+		args = append(args, "--build-arg", name)
+	}
+	return args, nil
+}
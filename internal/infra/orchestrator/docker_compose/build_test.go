@@ -0,0 +1,85 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildComposeFile defines a service built from the app directory next to one using a published image.
+const buildComposeFile = `services:
+  web:
+    build: ./web
+  db:
+    image: postgres
+  worker:
+    build:
+      context: ./worker
+`
+
+// writeBuildComposeFile replaces the compose file of the app rendered by newRollingRepository with
+// buildComposeFile.
+func writeBuildComposeFile(t *testing.T, r *composeRepository) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(r.getAppDir("app"), "compose.yml"), []byte(buildComposeFile), 0o644); err != nil {
+		t.Fatalf("Failed to write compose file: %v", err)
+	}
+}
+
+func TestUpdateAppBuildsServicesWithABuildSectionBeforeUp(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo"}`)
+	writeBuildComposeFile(t, r)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := []string{"pull", "build web worker", "up -d"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
+
+func TestUpdateAppDoesNotBuildWithoutABuildSection(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo","build_no_cache":true}`)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	for _, args := range composeSubcommands(runner.Commands()) {
+		if strings.Contains(args, "build") {
+			t.Errorf("Expected no build without a build section, got %q", args)
+		}
+	}
+}
+
+func TestUpdateAppBuildsWithoutCacheAndWithBuildArgs(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo","build_no_cache":true,"build_args":["VERSION","TOKEN"],"variables":[{"name":"VERSION"},{"name":"TOKEN"}]}`)
+	writeBuildComposeFile(t, r)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := "build --no-cache --build-arg VERSION --build-arg TOKEN web worker"
+	if len(got) < 2 || got[1] != want {
+		t.Errorf("Expected %q as the second command, got %q", want, got)
+	}
+}
+
+func TestUpdateAppRejectsBuildArgsThatAreNotVariables(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo","build_args":["MISSING"]}`)
+	writeBuildComposeFile(t, r)
+
+	err := r.UpdateApp("app")
+	if err == nil || !strings.Contains(err.Error(), `invalid build arg "MISSING"`) {
+		t.Fatalf("Expected an invalid build arg error, got %v", err)
+	}
+	if got := composeSubcommands(runner.Commands()); len(got) != 1 {
+		t.Errorf("Expected only the pull to run, got %q", got)
+	}
+}
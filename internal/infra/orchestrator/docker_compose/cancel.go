@@ -0,0 +1,140 @@
+package docker_compose
+
+import (
+	"context"
+	"sync"
+
+	"winterflow-agent/pkg/log"
+)
+
+// appOperations tracks the lifecycle operations running per app directory so that they can be canceled,
+// and keeps operations off app directories that are locked. The zero value is ready to use.
+type appOperations struct {
+	mu      sync.Mutex
+	running map[string]*appOperation
+	// active counts the operations per app directory, including canceled ones that have not ended yet.
+	active map[string]int
+	locked map[string]bool
+	// ended is signaled when an operation ends or a lock is released; created on first use.
+	ended *sync.Cond
+}
+
+// appOperation is the cancelable context shared by the operations running on one app directory.
+type appOperation struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	refs   int
+}
+
+// wait blocks until done reports true. It must be called with mu held.
+func (o *appOperations) wait(done func() bool) {
+	if o.ended == nil {
+		o.ended = sync.NewCond(&o.mu)
+	}
+	for !done() {
+		o.ended.Wait()
+	}
+}
+
+// begin registers an operation on appDir and returns the function ending it. Concurrent operations on
+// the same directory share one context. It waits while appDir is locked.
+func (o *appOperations) begin(appDir string) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.wait(func() bool { return !o.locked[appDir] })
+	if o.running == nil {
+		o.running = make(map[string]*appOperation)
+		o.active = make(map[string]int)
+	}
+	op, ok := o.running[appDir]
+	if !ok || op.ctx.Err() != nil {
+		// Operations started after a cancellation are not affected by it.
+		ctx, cancel := context.WithCancel(context.Background())
+		op = &appOperation{ctx: ctx, cancel: cancel}
+		o.running[appDir] = op
+	}
+	op.refs++
+	o.active[appDir]++
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		op.refs--
+		if op.refs == 0 {
+			op.cancel()
+			if o.running[appDir] == op {
+				delete(o.running, appDir)
+			}
+		}
+		if o.active[appDir]--; o.active[appDir] == 0 {
+			delete(o.active, appDir)
+			if o.ended != nil {
+				o.ended.Broadcast()
+			}
+		}
+	}
+}
+
+// lock waits until no operation runs on appDir and keeps new operations from beginning until the returned
+// function is called.
+func (o *appOperations) lock(appDir string) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.wait(func() bool { return !o.locked[appDir] && o.active[appDir] == 0 })
+	if o.locked == nil {
+		o.locked = make(map[string]bool)
+	}
+	o.locked[appDir] = true
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.locked, appDir)
+		o.ended.Broadcast()
+	}
+}
+
+// context returns the context of the operation running on appDir, or nil when there is none.
+func (o *appOperations) context(appDir string) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if op, ok := o.running[appDir]; ok {
+		return op.ctx
+	}
+	return nil
+}
+
+// cancel cancels the operation running on appDir and reports whether there was one that was not
+// canceled yet. The remaining steps of a canceled operation fail right away.
+func (o *appOperations) cancel(appDir string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	op, ok := o.running[appDir]
+	if !ok || op.ctx.Err() != nil {
+		return false
+	}
+	op.cancel()
+	return true
+}
+
+// beginAppOperation registers a cancelable lifecycle operation on the app and returns the function ending it.
+func (r *composeRepository) beginAppOperation(appID string) func() {
+	return r.operations.begin(r.getAppDir(appID))
+}
+
+// LockApp waits until no lifecycle operation runs on the app and keeps new ones from starting until the
+// returned function is called.
+func (r *composeRepository) LockApp(appID string) func() {
+	return r.operations.lock(r.getAppDir(appID))
+}
+
+// CancelOperation cancels the lifecycle operation running on the app, interrupting its `docker compose` and
+// hook processes, and reports whether one was running. Containers a canceled `up` already created are left
+// as they are; the next deploy, start or update of the app reconciles them.
+func (r *composeRepository) CancelOperation(appID string) bool {
+	canceled := r.operations.cancel(r.getAppDir(appID))
+	if canceled {
+		log.Info("Canceled running app operation", "app_id", appID)
+	}
+	return canceled
+}
@@ -0,0 +1,154 @@
+package docker_compose
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// cancelableRunner is a command runner whose first invocation runs until its context is canceled.
+type cancelableRunner struct {
+	command.FakeRunner
+	started chan struct{}
+}
+
+func (c *cancelableRunner) CombinedOutput(cmd command.Cmd) ([]byte, error) {
+	if len(c.Commands()) > 0 {
+		return c.FakeRunner.CombinedOutput(cmd)
+	}
+	c.FakeRunner.CombinedOutput(cmd)
+	close(c.started)
+	if cmd.Context == nil {
+		return nil, errors.New("command cannot be canceled")
+	}
+	<-cmd.Context.Done()
+	return []byte("Container demo-web-1  Creating\n"), cmd.Context.Err()
+}
+
+func TestCancelOperationInterruptsComposeUp(t *testing.T) {
+	runner := &cancelableRunner{started: make(chan struct{})}
+	r := newTestRepository(t, &config.Config{}, runner)
+	r.deploys = newDeployLimiter(1)
+	appDir := writeTestApp(t, r, map[string]string{"compose.yml": "services: {}\n"})
+
+	if r.CancelOperation("app") {
+		t.Fatal("Expected nothing to cancel before the app is started")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- r.StartApp("app") }()
+	select {
+	case <-runner.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected docker compose up to be started")
+	}
+
+	if r.CancelOperation("other") {
+		t.Error("Expected the operation of another app not to be canceled")
+	}
+	if !r.CancelOperation("app") {
+		t.Fatal("Expected the running operation to be canceled")
+	}
+	if r.CancelOperation("app") {
+		t.Error("Expected a second cancellation to report nothing to cancel")
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected StartApp to fail with context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected StartApp to return after the cancellation")
+	}
+	if r.CancelOperation("app") {
+		t.Error("Expected no operation once StartApp returned")
+	}
+
+	// The canceled operation released its deploy slot and does not affect the next one.
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("Expected the app to start after the cancellation, got %v", err)
+	}
+	if commands := runner.Commands(); len(commands) != 2 {
+		t.Errorf("Expected 2 compose invocations, got %d", len(commands))
+	}
+	if !dirExists(appDir) {
+		t.Error("Expected the rendered app to be kept")
+	}
+}
+
+func TestCanceledOperationGivesUpWaitingForDeploySlot(t *testing.T) {
+	r, _, appDir := newFakeComposeRepository(t)
+	runner := &blockingRunner{started: make(chan struct{}, 1), release: make(chan struct{})}
+	r.runner = runner
+	r.deploys = newDeployLimiter(1)
+
+	otherDir := t.TempDir()
+	writeFiles(t, otherDir, "compose.yml")
+	go func() { _ = r.composeUp(otherDir) }()
+	<-runner.started
+	defer close(runner.release)
+
+	end := r.operations.begin(appDir)
+	defer end()
+	done := make(chan error, 1)
+	go func() { done <- r.composeUp(appDir) }()
+	waitForQueueDepth(t, r, 1)
+
+	r.operations.cancel(appDir)
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected composeUp to fail with context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the queued composeUp to give up after the cancellation")
+	}
+	waitForQueueDepth(t, r, 0)
+}
+
+func TestLockWaitsForRunningOperationsAndHoldsOffNewOnes(t *testing.T) {
+	var ops appOperations
+	end := ops.begin("app")
+	// A canceled operation keeps the lock off until it has ended as well.
+	ops.cancel("app")
+	endCanceled := ops.begin("app")
+	end()
+
+	locked := make(chan func(), 1)
+	go func() { locked <- ops.lock("app") }()
+	select {
+	case <-locked:
+		t.Fatal("Expected lock to wait for the running operation")
+	case <-time.After(100 * time.Millisecond):
+	}
+	endCanceled()
+	var unlock func()
+	select {
+	case unlock = <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected lock to succeed once the operations ended")
+	}
+
+	begun := make(chan func(), 1)
+	go func() { begun <- ops.begin("app") }()
+	select {
+	case <-begun:
+		t.Fatal("Expected begin to wait while the app is locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	// Other apps are not affected.
+	ops.begin("other")()
+
+	unlock()
+	select {
+	case end := <-begun:
+		end()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected begin to proceed once the app was unlocked")
+	}
+}
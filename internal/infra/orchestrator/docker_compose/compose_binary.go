@@ -0,0 +1,111 @@
+package docker_compose
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"winterflow-agent/pkg/command"
+)
+
+const (
+	// ComposePluginCommand selects the `docker compose` CLI plugin in the ComposeCommand setting.
+	ComposePluginCommand = "docker compose"
+	// composeStandaloneBinary is the standalone binary tried when the plugin is not installed.
+	composeStandaloneBinary = "docker-compose"
+	// composeVersionTimeout bounds the availability check of a compose command.
+	composeVersionTimeout = 30 * time.Second
+)
+
+// ComposeCommand describes how Docker Compose is invoked on the host: through the `docker compose` CLI
+// plugin or through a standalone docker-compose binary. The zero value uses the plugin.
+type ComposeCommand struct {
+	// Standalone is the name or path of the standalone binary; empty selects the plugin.
+	Standalone string
+}
+
+// Mode returns "plugin" or "standalone".
+func (c ComposeCommand) Mode() string {
+	if c.Standalone != "" {
+		return "standalone"
+	}
+	return "plugin"
+}
+
+// String returns the command line prefix used to run Docker Compose.
+func (c ComposeCommand) String() string {
+	if c.Standalone != "" {
+		return c.Standalone
+	}
+	return ComposePluginCommand
+}
+
+// cmd builds the invocation of Docker Compose with args in dir. The plugin selects dockerContext with the
+// global `--context` flag of the docker binary; the standalone binary has no such flag and receives it
+// through DOCKER_CONTEXT instead.
+func (c ComposeCommand) cmd(dockerContext, dir string, env []string, args ...string) command.Cmd {
+	if c.Standalone != "" {
+		if dockerContext != "" {
+			env = append(append([]string(nil), env...), "DOCKER_CONTEXT="+dockerContext)
+		}
+		return command.Cmd{Name: c.Standalone, Args: args, Dir: dir, Env: env}
+	}
+
+	fullArgs := make([]string, 0, len(args)+3)
+	if dockerContext != "" {
+		fullArgs = append(fullArgs, "--context", dockerContext)
+	}
+	fullArgs = append(fullArgs, "compose")
+	return command.Cmd{Name: "docker", Args: append(fullArgs, args...), Dir: dir, Env: env}
+}
+
+// DetectComposeCommand resolves the configured compose command and verifies it is available by running
+// its `version` subcommand. An empty setting prefers the plugin and falls back to the standalone
+// docker-compose binary when the plugin is absent; "docker compose" requires the plugin and any other
+// value is taken as the name or path of a standalone binary.
+func DetectComposeCommand(runner command.Runner, configured string) (ComposeCommand, error) {
+	configured = strings.TrimSpace(configured)
+	switch configured {
+	case "":
+		plugin := ComposeCommand{}
+		if err := checkComposeCommand(runner, plugin); err == nil {
+			return plugin, nil
+		}
+		standalone := ComposeCommand{Standalone: composeStandaloneBinary}
+		if err := checkComposeCommand(runner, standalone); err != nil {
+			return plugin, fmt.Errorf("neither the docker compose plugin nor the %s binary is available", composeStandaloneBinary)
+		}
+		return standalone, nil
+	case ComposePluginCommand:
+		plugin := ComposeCommand{}
+		return plugin, checkComposeCommand(runner, plugin)
+	default:
+		standalone := ComposeCommand{Standalone: configured}
+		return standalone, checkComposeCommand(runner, standalone)
+	}
+}
+
+// checkComposeCommand runs `version` through compose and reports whether it succeeded.
+func checkComposeCommand(runner command.Runner, compose ComposeCommand) error {
+	cmd := compose.cmd("", "", nil, "version")
+	cmd.Timeout = composeVersionTimeout
+	if _, stderr, err := runner.Output(cmd); err != nil {
+		return fmt.Errorf("%s is not available: %w: %s", compose, err, strings.TrimSpace(string(stderr)))
+	}
+	return nil
+}
+
+// GetOrchestratorVersion returns the version of Docker Compose as reported by `version --short`.
+func (r *composeRepository) GetOrchestratorVersion() (string, error) {
+	dockerContext := ""
+	if r.config != nil {
+		dockerContext = r.config.GetDockerContext()
+	}
+	cmd := r.compose.cmd(dockerContext, "", nil, "version", "--short")
+	cmd.Timeout = composeVersionTimeout
+	stdout, stderr, err := r.commandRunner().Output(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the version of %s: %w: %s", r.compose, err, strings.TrimSpace(string(stderr)))
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(stdout)), "v"), nil
+}
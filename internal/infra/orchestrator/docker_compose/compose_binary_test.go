@@ -0,0 +1,122 @@
+package docker_compose
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/pkg/command"
+)
+
+// commandLines renders each command as its name followed by its arguments.
+func commandLines(commands []command.Cmd) []string {
+	lines := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		lines = append(lines, strings.Join(append([]string{cmd.Name}, cmd.Args...), " "))
+	}
+	return lines
+}
+
+func TestDetectComposeCommand(t *testing.T) {
+	notFound := command.FakeResult{Err: errors.New("not found")}
+
+	tests := []struct {
+		name       string
+		configured string
+		results    []command.FakeResult
+		expected   ComposeCommand
+		checked    []string
+		wantErr    bool
+	}{
+		{
+			name:     "plugin detected",
+			expected: ComposeCommand{},
+			checked:  []string{"docker compose version"},
+		},
+		{
+			name:     "falls back to standalone binary",
+			results:  []command.FakeResult{notFound},
+			expected: ComposeCommand{Standalone: "docker-compose"},
+			checked:  []string{"docker compose version", "docker-compose version"},
+		},
+		{
+			name:     "neither available",
+			results:  []command.FakeResult{notFound, notFound},
+			expected: ComposeCommand{},
+			checked:  []string{"docker compose version", "docker-compose version"},
+			wantErr:  true,
+		},
+		{
+			name:       "configured plugin missing",
+			configured: "docker compose",
+			results:    []command.FakeResult{notFound},
+			expected:   ComposeCommand{},
+			checked:    []string{"docker compose version"},
+			wantErr:    true,
+		},
+		{
+			name:       "configured standalone path",
+			configured: "/opt/bin/docker-compose",
+			expected:   ComposeCommand{Standalone: "/opt/bin/docker-compose"},
+			checked:    []string{"/opt/bin/docker-compose version"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := &command.FakeRunner{Results: tc.results}
+			compose, err := DetectComposeCommand(runner, tc.configured)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+			}
+			if compose != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, compose)
+			}
+			if got := commandLines(runner.Commands()); !reflect.DeepEqual(got, tc.checked) {
+				t.Errorf("Expected checks %v, got %v", tc.checked, got)
+			}
+		})
+	}
+}
+
+func TestStandaloneComposeRunsDeploy(t *testing.T) {
+	r, runner, appDir := newFakeComposeRepository(t)
+	r.compose = ComposeCommand{Standalone: "docker-compose"}
+
+	if err := r.composeUp(appDir); err != nil {
+		t.Fatalf("composeUp returned error: %v", err)
+	}
+	commands := runner.Commands()
+	if len(commands) != 1 {
+		t.Fatalf("Expected one command, got %d", len(commands))
+	}
+	if commands[0].Name != "docker-compose" || len(commands[0].Args) == 0 || commands[0].Args[0] == "compose" {
+		t.Errorf("Expected standalone invocation, got %s %v", commands[0].Name, commands[0].Args)
+	}
+}
+
+func TestGetOrchestratorVersion(t *testing.T) {
+	runner := &command.FakeRunner{Results: []command.FakeResult{{Stdout: []byte("v2.39.1\n")}}}
+	r := &composeRepository{runner: runner, compose: ComposeCommand{Standalone: "docker-compose"}}
+
+	got, err := r.GetOrchestratorVersion()
+	if err != nil {
+		t.Fatalf("GetOrchestratorVersion returned error: %v", err)
+	}
+	if got != "2.39.1" {
+		t.Errorf("Expected version 2.39.1, got %q", got)
+	}
+	if lines := commandLines(runner.Commands()); !reflect.DeepEqual(lines, []string{"docker-compose version --short"}) {
+		t.Errorf("Unexpected commands %q", lines)
+	}
+}
+
+func TestGetOrchestratorVersionFailsWhenComposeIsMissing(t *testing.T) {
+	runner := &command.FakeRunner{Results: []command.FakeResult{{Stderr: []byte("unknown command"), Err: errors.New("exit status 1")}}}
+	r := &composeRepository{runner: runner}
+
+	if _, err := r.GetOrchestratorVersion(); err == nil || !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("Expected the failure to be reported, got %v", err)
+	}
+}
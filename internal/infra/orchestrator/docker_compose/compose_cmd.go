@@ -2,9 +2,14 @@ package docker_compose
 
 import (
 	"fmt"
-	"os/exec"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"winterflow-agent/internal/domain/model"
+
+	"winterflow-agent/pkg/command"
 	"winterflow-agent/pkg/log"
 )
 
@@ -16,8 +21,15 @@ var composeExtensionFiles = []string{
 	"expose", // compose.expose.yml
 }
 
-// composeUp performs `docker compose up -d` in the provided directory.
-func (r *composeRepository) composeUp(appDir string) error {
+// composeUp performs `docker compose up -d` in the provided directory. upFlags are appended to
+// the `up` subcommand, e.g. --force-recreate.
+func (r *composeRepository) composeUp(appDir string, upFlags ...string) error {
+	return r.composeUpServices(appDir, nil, upFlags...)
+}
+
+// composeUpServices performs `docker compose up -d` for services, or all services when there are none, in
+// the provided directory. upFlags are appended to the `up` subcommand.
+func (r *composeRepository) composeUpServices(appDir string, services []string, upFlags ...string) error {
 	files, err := r.detectComposeFiles(appDir)
 	if err != nil {
 		return err
@@ -29,11 +41,31 @@ func (r *composeRepository) composeUp(appDir string) error {
 	}
 	args = append(args, r.buildComposeFileArgs(files)...)
 	args = append(args, "up", "-d")
+	args = append(args, upFlags...)
 
-	return r.runDockerCompose(appDir, args...)
+	scaleArgs, err := r.buildScaleArgs(appDir, services)
+	if err != nil {
+		return err
+	}
+	args = append(args, scaleArgs...)
+	args = append(args, services...)
+
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	release, err := r.deploys.acquire(r.operations.context(appDir))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.runDockerComposeWithRetry(appDir, env, args...)
 }
 
-func (r *composeRepository) composeDown(appDir string) error {
+// composeDown stops and removes the containers of the app in appDir, passing downFlags to `down`.
+func (r *composeRepository) composeDown(appDir string, downFlags ...string) error {
 	files, err := r.detectComposeFiles(appDir)
 	if err != nil {
 		return err
@@ -45,11 +77,18 @@ func (r *composeRepository) composeDown(appDir string) error {
 	}
 	args = append(args, r.buildComposeFileArgs(files)...)
 	args = append(args, "down", "--remove-orphans")
+	args = append(args, downFlags...)
 
 	return r.runDockerCompose(appDir, args...)
 }
 
 func (r *composeRepository) composeRestart(appDir string) error {
+	return r.composeRestartServices(appDir, nil)
+}
+
+// composeRestartServices restarts the containers of services, or of all services when there are none, of
+// the app in appDir.
+func (r *composeRepository) composeRestartServices(appDir string, services []string) error {
 	files, err := r.detectComposeFiles(appDir)
 	if err != nil {
 		return err
@@ -61,6 +100,7 @@ func (r *composeRepository) composeRestart(appDir string) error {
 	}
 	args = append(args, r.buildComposeFileArgs(files)...)
 	args = append(args, "restart")
+	args = append(args, services...)
 
 	return r.runDockerCompose(appDir, args...)
 }
@@ -70,8 +110,48 @@ func (r *composeRepository) composePull(appDir string) error {
 	if err != nil {
 		return err
 	}
-	args := append(r.buildComposeFileArgs(files), "pull")
-	return r.runDockerCompose(appDir, args...)
+	fileArgs := r.buildComposeFileArgs(files)
+
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	release, err := r.deploys.acquire(r.operations.context(appDir))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if r.config != nil && r.config.PullConcurrency > 1 {
+		services, err := r.composeServices(appDir)
+		if err != nil {
+			return err
+		}
+		if len(services) > 1 {
+			return r.pullServicesInParallel(appDir, env, fileArgs, services, r.config.PullConcurrency)
+		}
+	}
+	return r.runDockerComposeWithRetry(appDir, env, append(fileArgs, "pull")...)
+}
+
+// composeConfig returns the output of `docker compose config`, i.e. the fully merged and interpolated
+// project definition, for the application rendered in appDir.
+func (r *composeRepository) composeConfig(appDir string) (string, error) {
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		return "", err
+	}
+
+	args := make([]string, 0)
+	if fileExists(filepath.Join(appDir, ".winterflow.env")) {
+		args = append(args, "--env-file", ".winterflow.env")
+	}
+	args = append(args, r.buildComposeFileArgs(files)...)
+	args = append(args, "config")
+
+	return r.runDockerComposeOutput(appDir, args...)
 }
 
 // detectComposeFiles mimics the original playbook logic to decide which compose files to use.
@@ -97,11 +177,18 @@ func (r *composeRepository) detectComposeFiles(appDir string) ([]string, error)
 	}
 
 	// Optional override file should be last so it can supersede previous ones
-	override := filepath.Join(appDir, "compose.override.yml")
+	override := filepath.Join(appDir, model.ComposeOverrideFileName)
 	if fileExists(override) {
 		extraFiles = append(extraFiles, override)
 	}
 
+	// The generated labels, registry mirror, service hosts and restart policy overrides supersede every other file.
+	for _, generated := range []string{labelsOverrideFile, mirrorOverrideFile, hostsOverrideFile, restartOverrideFile} {
+		if path := filepath.Join(appDir, generated); fileExists(path) {
+			extraFiles = append(extraFiles, path)
+		}
+	}
+
 	// If no additional files are found, rely on Docker's implicit file detection.
 	if len(extraFiles) == 0 {
 		return nil, nil
@@ -131,16 +218,128 @@ func (r *composeRepository) buildComposeFileArgs(files []string) []string {
 	return args
 }
 
+// buildScaleArgs converts the per-service scale of the configuration deployed in appDir into
+// `--scale service=N` CLI arguments. Services without a count or with a count of zero keep the
+// scale declared in the compose file. Apps without a deployed configuration are not scaled. When services
+// are given only their scale is applied, as compose rejects the scale of services it does not start.
+func (r *composeRepository) buildScaleArgs(appDir string, services []string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(appDir, ".winterflow.config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read deployed configuration: %w", err)
+	}
+	appConfig, err := model.ParseAppConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployed configuration: %w", err)
+	}
+	if len(services) == 0 {
+		return scaleArgs(appConfig.Scale)
+	}
+	selected := make(map[string]int)
+	for _, service := range services {
+		if count, ok := appConfig.Scale[service]; ok {
+			selected[service] = count
+		}
+	}
+	return scaleArgs(selected)
+}
+
+// scaleArgs returns the `--scale` arguments for scale in a stable order.
+func scaleArgs(scale map[string]int) ([]string, error) {
+	services := make([]string, 0, len(scale))
+	for service, count := range scale {
+		if strings.TrimSpace(service) == "" {
+			return nil, fmt.Errorf("scale service name cannot be empty")
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("invalid scale %d for service %s: must be a positive integer", count, service)
+		}
+		if count > 0 {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+
+	var args []string
+	for _, service := range services {
+		args = append(args, "--scale", fmt.Sprintf("%s=%d", service, scale[service]))
+	}
+	return args, nil
+}
+
+// composeCmd builds the Docker Compose invocation with args in dir for the detected compose command,
+// selecting the configured Docker context when one is set. The configured compose environment is set before
+// env, so that the variables of the invocation take precedence.
+func (r *composeRepository) composeCmd(dir string, env []string, args ...string) command.Cmd {
+	dockerContext := ""
+	if r.config != nil {
+		dockerContext = r.config.GetDockerContext()
+		if composeEnv := r.config.GetComposeEnv(); len(composeEnv) > 0 {
+			env = append(composeEnv, env...)
+		}
+	}
+	cmd := r.compose.cmd(dockerContext, dir, env, args...)
+	cmd.Context = r.operations.context(dir)
+	return cmd
+}
+
 // runDockerCompose executes `docker compose` with given args in dir.
 func (r *composeRepository) runDockerCompose(dir string, args ...string) error {
-	fullCmd := append([]string{"compose"}, args...)
-	cmd := exec.Command("docker", fullCmd...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
+	return r.runDockerComposeWithEnv(dir, nil, args...)
+}
+
+// runDockerComposeWithEnv executes `docker compose` with given args in dir,
+// appending env and the app's resolved secrets to the agent's own environment.
+// The extra environment is not logged as it may hold credentials.
+func (r *composeRepository) runDockerComposeWithEnv(dir string, env []string, args ...string) error {
+	_, err := r.execDockerCompose(dir, env, args...)
+	return err
+}
+
+// execDockerCompose runs `docker compose` with given args in dir and returns the combined output,
+// which is also returned when the command fails so that callers can inspect the failure.
+func (r *composeRepository) execDockerCompose(dir string, env []string, args ...string) (string, error) {
+	secretEnv, err := r.secretEnv(dir)
+	if err != nil {
+		return "", err
+	}
+	cmd := r.composeCmd(dir, append(env, secretEnv...), args...)
+	stdout, stderr, flush := r.outputWriters(dir)
+	if stdout != nil {
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+	}
+	output, err := r.commandRunner().CombinedOutput(cmd)
+	flush()
+	if err != nil {
+		log.Error("docker compose command failed", "dir", dir, "command", cmd.Name, "args", cmd.Args, "output", string(output), "error", err)
+		return string(output), fmt.Errorf("docker compose %v failed: %w", args, err)
+	}
+	log.Debug("docker compose executed", "dir", dir, "command", cmd.Name, "args", cmd.Args, "output", string(output))
+	return string(output), nil
+}
+
+// commandRunner returns the runner used for docker invocations.
+func (r *composeRepository) commandRunner() command.Runner {
+	if r.runner != nil {
+		return r.runner
+	}
+	return command.NewExecRunner()
+}
+
+// runDockerComposeOutput executes `docker compose` with given args in dir and returns its standard output.
+// Standard error is only included in the log and the returned error.
+func (r *composeRepository) runDockerComposeOutput(dir string, args ...string) (string, error) {
+	secretEnv, err := r.secretEnv(dir)
+	if err != nil {
+		return "", err
+	}
+	cmd := r.composeCmd(dir, secretEnv, args...)
+	output, stderr, err := r.commandRunner().Output(cmd)
 	if err != nil {
-		log.Error("docker compose command failed", "dir", dir, "args", fullCmd, "output", string(output), "error", err)
-		return fmt.Errorf("docker compose %v failed: %w", args, err)
+		log.Error("docker compose command failed", "dir", dir, "command", cmd.Name, "args", cmd.Args, "output", string(stderr), "error", err)
+		return "", fmt.Errorf("docker compose %v failed: %w: %s", args, err, strings.TrimSpace(string(stderr)))
 	}
-	log.Debug("docker compose executed", "dir", dir, "args", fullCmd, "output", string(output))
-	return nil
+	return string(output), nil
 }
@@ -0,0 +1,282 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// writeFiles creates empty files with the given names inside dir.
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("services: {}\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestDetectComposeFilesOverrideIsLast(t *testing.T) {
+	appDir := t.TempDir()
+	writeFiles(t, appDir, "compose.yml", "compose.override.yml", "compose.expose.yml")
+
+	r := &composeRepository{}
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+
+	expected := []string{"-f", "compose.yml", "-f", "compose.expose.yml", "-f", "compose.override.yml"}
+	if got := r.buildComposeFileArgs(files); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDetectComposeFilesOverrideWithDockerCompose(t *testing.T) {
+	appDir := t.TempDir()
+	writeFiles(t, appDir, "docker-compose.yml", "compose.override.yml")
+
+	r := &composeRepository{}
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+
+	expected := []string{"-f", "docker-compose.yml", "-f", "compose.override.yml"}
+	if got := r.buildComposeFileArgs(files); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDetectComposeFilesWithoutOverride(t *testing.T) {
+	appDir := t.TempDir()
+	writeFiles(t, appDir, "compose.yml")
+
+	r := &composeRepository{}
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected implicit file detection, got %v", files)
+	}
+}
+
+func TestComposeCmdWithContext(t *testing.T) {
+	r := &composeRepository{config: &config.Config{DockerContext: "remote"}}
+
+	cmd := r.composeCmd("/app", nil, "-f", "compose.yml", "up", "-d")
+	expected := []string{"--context", "remote", "compose", "-f", "compose.yml", "up", "-d"}
+	if cmd.Name != "docker" || !reflect.DeepEqual(cmd.Args, expected) || cmd.Dir != "/app" {
+		t.Errorf("Expected docker %v in /app, got %s %v in %s", expected, cmd.Name, cmd.Args, cmd.Dir)
+	}
+}
+
+func TestComposeCmdWithoutContext(t *testing.T) {
+	r := &composeRepository{config: &config.Config{}}
+
+	cmd := r.composeCmd("/app", nil, "pull")
+	expected := []string{"compose", "pull"}
+	if cmd.Name != "docker" || !reflect.DeepEqual(cmd.Args, expected) {
+		t.Errorf("Expected docker %v, got %s %v", expected, cmd.Name, cmd.Args)
+	}
+}
+
+func TestComposeCmdStandalone(t *testing.T) {
+	r := &composeRepository{
+		config:  &config.Config{DockerContext: "remote"},
+		compose: ComposeCommand{Standalone: "/usr/local/bin/docker-compose"},
+	}
+
+	cmd := r.composeCmd("/app", []string{"FOO=bar"}, "-f", "compose.yml", "up", "-d")
+	expectedArgs := []string{"-f", "compose.yml", "up", "-d"}
+	expectedEnv := []string{"FOO=bar", "DOCKER_CONTEXT=remote"}
+	if cmd.Name != "/usr/local/bin/docker-compose" || !reflect.DeepEqual(cmd.Args, expectedArgs) {
+		t.Errorf("Expected /usr/local/bin/docker-compose %v, got %s %v", expectedArgs, cmd.Name, cmd.Args)
+	}
+	if !reflect.DeepEqual(cmd.Env, expectedEnv) {
+		t.Errorf("Expected env %v, got %v", expectedEnv, cmd.Env)
+	}
+}
+
+func TestComposeCmdSetsComposeEnvBeforeTheInvocationEnv(t *testing.T) {
+	r := &composeRepository{
+		config:  &config.Config{DockerContext: "remote", ComposeEnv: map[string]string{"DOCKER_BUILDKIT": "1", "COMPOSE_DOCKER_CLI_BUILD": "1"}},
+		compose: ComposeCommand{Standalone: "docker-compose"},
+	}
+
+	cmd := r.composeCmd("/app", []string{"FOO=bar"}, "build")
+	expectedEnv := []string{"COMPOSE_DOCKER_CLI_BUILD=1", "DOCKER_BUILDKIT=1", "FOO=bar", "DOCKER_CONTEXT=remote"}
+	if !reflect.DeepEqual(cmd.Env, expectedEnv) {
+		t.Errorf("Expected env %v, got %v", expectedEnv, cmd.Env)
+	}
+}
+
+func TestDeployAppAppliesComposeEnvToEveryInvocation(t *testing.T) {
+	r, runner := newSecretRepository(t, &config.Config{ComposeEnv: map[string]string{"DOCKER_BUILDKIT": "1"}}, "hunter2")
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	commands := runner.Commands()
+	if len(commands) == 0 {
+		t.Fatal("Expected docker compose to be invoked")
+	}
+	for _, cmd := range commands {
+		if len(cmd.Env) == 0 || cmd.Env[0] != "DOCKER_BUILDKIT=1" {
+			t.Errorf("Expected the compose environment for %v, got %v", cmd.Args, cmd.Env)
+		}
+	}
+	up := commands[len(commands)-1]
+	if up.Env[len(up.Env)-1] != "DB_PASSWORD=hunter2" {
+		t.Errorf("Expected the resolved secret after the compose environment, got %v", up.Env)
+	}
+}
+
+// newFakeComposeRepository returns a repository issuing commands to a fake runner, with an app
+// directory containing compose.yml plus the given files and no registry credentials configured.
+func newFakeComposeRepository(t *testing.T, names ...string) (*composeRepository, *command.FakeRunner, string) {
+	t.Helper()
+	runner := &command.FakeRunner{}
+	r := newTestRepository(t, &config.Config{}, runner)
+	appDir := writeTestApp(t, r, nil)
+	writeFiles(t, appDir, append([]string{"compose.yml"}, names...)...)
+	return r, runner, appDir
+}
+
+func TestComposeOperationsIssueExpectedCommands(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    []string
+		run      func(r *composeRepository, appDir string) error
+		expected []string
+	}{
+		{
+			name:     "Up with env file",
+			files:    []string{".winterflow.env"},
+			run:      func(r *composeRepository, appDir string) error { return r.composeUp(appDir) },
+			expected: []string{"compose", "--env-file", ".winterflow.env", "up", "-d"},
+		},
+		{
+			name:     "Down with override",
+			files:    []string{"compose.override.yml"},
+			run:      func(r *composeRepository, appDir string) error { return r.composeDown(appDir) },
+			expected: []string{"compose", "-f", "compose.yml", "-f", "compose.override.yml", "down", "--remove-orphans"},
+		},
+		{
+			name:     "Restart",
+			run:      (*composeRepository).composeRestart,
+			expected: []string{"compose", "restart"},
+		},
+		{
+			name:     "Pull ignores env file",
+			files:    []string{".winterflow.env"},
+			run:      (*composeRepository).composePull,
+			expected: []string{"compose", "pull"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, runner, appDir := newFakeComposeRepository(t, tc.files...)
+			if err := tc.run(r, appDir); err != nil {
+				t.Fatalf("Operation returned error: %v", err)
+			}
+
+			commands := runner.Commands()
+			if len(commands) != 1 {
+				t.Fatalf("Expected a single command, got %v", commands)
+			}
+			if commands[0].Name != "docker" || commands[0].Dir != appDir {
+				t.Errorf("Expected docker to run in %s, got %s in %s", appDir, commands[0].Name, commands[0].Dir)
+			}
+			if !reflect.DeepEqual(commands[0].Args, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, commands[0].Args)
+			}
+		})
+	}
+}
+
+func TestRecreateAppForcesRecreateWithoutPull(t *testing.T) {
+	r, runner, _ := newFakeComposeRepository(t, ".winterflow.env")
+
+	if err := r.RecreateApp("app"); err != nil {
+		t.Fatalf("RecreateApp returned error: %v", err)
+	}
+
+	commands := runner.Commands()
+	if len(commands) != 1 {
+		t.Fatalf("Expected a single command without pull, got %v", commands)
+	}
+	expected := []string{"compose", "--env-file", ".winterflow.env", "up", "-d", "--force-recreate"}
+	if !reflect.DeepEqual(commands[0].Args, expected) {
+		t.Errorf("Expected %v, got %v", expected, commands[0].Args)
+	}
+}
+
+func TestComposeConfigReturnsStdout(t *testing.T) {
+	r, runner, appDir := newFakeComposeRepository(t)
+	runner.Results = []command.FakeResult{{Stdout: []byte("services: {}\n"), Stderr: []byte("warning")}}
+
+	output, err := r.composeConfig(appDir)
+	if err != nil {
+		t.Fatalf("composeConfig returned error: %v", err)
+	}
+	if output != "services: {}\n" {
+		t.Errorf("Expected stdout only, got %q", output)
+	}
+	if args := runner.Commands()[0].Args; !reflect.DeepEqual(args, []string{"compose", "config"}) {
+		t.Errorf("Expected compose config, got %v", args)
+	}
+}
+
+func TestScaleArgs(t *testing.T) {
+	got, err := scaleArgs(map[string]int{"worker": 3, "web": 2, "cron": 0})
+	if err != nil {
+		t.Fatalf("scaleArgs returned error: %v", err)
+	}
+
+	expected := []string{"--scale", "web=2", "--scale", "worker=3"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got, err := scaleArgs(nil); err != nil || len(got) != 0 {
+		t.Errorf("Expected no arguments for missing scale, got %v (%v)", got, err)
+	}
+}
+
+func TestScaleArgsRejectsInvalidCounts(t *testing.T) {
+	for name, scale := range map[string]map[string]int{
+		"Negative count": {"web": -1},
+		"Empty service":  {" ": 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := scaleArgs(scale); err == nil {
+				t.Errorf("Expected %v to be rejected", scale)
+			}
+		})
+	}
+}
+
+func TestComposeUpAppliesDeployedScale(t *testing.T) {
+	r, runner, appDir := newFakeComposeRepository(t)
+	cfg := `{"id":"app","name":"app","scale":{"worker":2}}`
+	if err := os.WriteFile(filepath.Join(appDir, ".winterflow.config.json"), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("Failed to write deployed config: %v", err)
+	}
+
+	if err := r.composeUp(appDir); err != nil {
+		t.Fatalf("composeUp returned error: %v", err)
+	}
+
+	expected := []string{"compose", "up", "-d", "--scale", "worker=2"}
+	if args := runner.Commands()[0].Args; !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+}
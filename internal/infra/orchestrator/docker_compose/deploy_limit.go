@@ -0,0 +1,61 @@
+package docker_compose
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"winterflow-agent/pkg/log"
+)
+
+// deployLimiter caps the number of `docker compose up` and `pull` operations running at the same time across
+// all apps. Operations beyond the cap wait for a free slot instead of failing.
+type deployLimiter struct {
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+// newDeployLimiter returns a limiter allowing max concurrent operations, or nil (no limit) when max is not positive.
+func newDeployLimiter(max int) *deployLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &deployLimiter{slots: make(chan struct{}, max)}
+}
+
+// acquire blocks until a slot is free and returns the function releasing it. Waiting is given up with
+// the error of ctx once it is canceled; a nil ctx waits indefinitely.
+func (l *deployLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		l.waiting.Add(1)
+		defer l.waiting.Add(-1)
+		log.Info("Maximum concurrent deploys reached, waiting for a free slot", "max_concurrent_deploys", cap(l.slots))
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for a free deploy slot: %w", ctx.Err())
+		}
+	}
+	return func() { <-l.slots }, nil
+}
+
+// queueDepth returns the number of operations waiting for a slot.
+func (l *deployLimiter) queueDepth() int {
+	if l == nil {
+		return 0
+	}
+	return int(l.waiting.Load())
+}
+
+// DeployQueueDepth returns the number of compose up/pull operations waiting for a free deploy slot.
+func (r *composeRepository) DeployQueueDepth() int {
+	return r.deploys.queueDepth()
+}
@@ -0,0 +1,83 @@
+package docker_compose
+
+import (
+	"testing"
+	"time"
+
+	"winterflow-agent/pkg/command"
+)
+
+// blockingRunner is a command runner whose invocations block until released.
+type blockingRunner struct {
+	command.FakeRunner
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingRunner) CombinedOutput(cmd command.Cmd) ([]byte, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return b.FakeRunner.CombinedOutput(cmd)
+}
+
+// waitForQueueDepth polls until r reports depth waiting operations or fails the test.
+func waitForQueueDepth(t *testing.T, r *composeRepository, depth int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for r.DeployQueueDepth() != depth {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected deploy queue depth %d, got %d", depth, r.DeployQueueDepth())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeployLimiterQueuesBeyondCap(t *testing.T) {
+	r, _, appDir := newFakeComposeRepository(t)
+	runner := &blockingRunner{started: make(chan struct{}, 3), release: make(chan struct{})}
+	r.runner = runner
+	r.deploys = newDeployLimiter(2)
+
+	done := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { done <- r.composeUp(appDir) }()
+	}
+
+	<-runner.started
+	<-runner.started
+	waitForQueueDepth(t, r, 1)
+	select {
+	case <-runner.started:
+		t.Fatal("Expected the third deploy to wait for a free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	runner.release <- struct{}{}
+	<-runner.started
+	waitForQueueDepth(t, r, 0)
+
+	close(runner.release)
+	for i := 0; i < 3; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("composeUp returned error: %v", err)
+		}
+	}
+	if commands := runner.Commands(); len(commands) != 3 {
+		t.Errorf("Expected 3 compose invocations, got %d", len(commands))
+	}
+}
+
+func TestDeployLimiterDisabled(t *testing.T) {
+	if newDeployLimiter(0) != nil {
+		t.Fatal("Expected no limiter without a configured cap")
+	}
+	var limiter *deployLimiter
+	release, err := limiter.acquire(nil)
+	if err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+	release()
+	if depth := limiter.queueDepth(); depth != 0 {
+		t.Errorf("Expected an empty queue, got %d", depth)
+	}
+}
@@ -0,0 +1,264 @@
+package docker_compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+	"winterflow-agent/pkg/yaml"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// composeOneOffLabel is the label Docker Compose sets on containers started by `docker compose run`.
+const composeOneOffLabel = "com.docker.compose.oneoff"
+
+// deployStrategy returns the deploy strategy of the app described by cfg, defaulting to recreate.
+func deployStrategy(cfg *model.AppConfig) (model.DeployStrategy, error) {
+	if cfg == nil || cfg.DeployStrategy == "" {
+		return model.DeployStrategyRecreate, nil
+	}
+	switch cfg.DeployStrategy {
+	case model.DeployStrategyRecreate, model.DeployStrategyRolling:
+		return cfg.DeployStrategy, nil
+	}
+	return "", fmt.Errorf("invalid deploy strategy %q: expected %s or %s", cfg.DeployStrategy, model.DeployStrategyRecreate, model.DeployStrategyRolling)
+}
+
+// keepsContainersRunning reports whether deploying the revision in templateDir over the app deployed in appDir
+// replaces the running containers service by service, so that they must not be stopped before rendering. This
+// is only the case for rolling deployments that keep the compose project of the app.
+func keepsContainersRunning(templateDir, appDir string) (bool, error) {
+	newCfg, _, err := orchestrator.ReadAppConfig(templateDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to load new configuration: %w", err)
+	}
+	strategy, err := deployStrategy(newCfg)
+	if err != nil || strategy != model.DeployStrategyRolling {
+		return false, err
+	}
+	currentCfg, err := orchestrator.GetCurrentConfig(appDir)
+	return err == nil && currentCfg.Name == newCfg.Name, nil
+}
+
+// composeUpWithStrategy starts the containers of the app rendered in appDir according to its deploy strategy.
+// With the rolling strategy the running services that can be scaled are replaced one at a time first, the
+// final `up` then recreates the remaining services and starts new ones.
+func (r *composeRepository) composeUpWithStrategy(appID, appDir string) error {
+	cfg, err := orchestrator.GetCurrentConfig(appDir)
+	if err != nil {
+		cfg = nil
+	}
+	strategy, err := deployStrategy(cfg)
+	if err != nil {
+		return err
+	}
+	if strategy == model.DeployStrategyRolling {
+		if err := r.rollServices(appID, appDir, cfg); err != nil {
+			return err
+		}
+	}
+	return r.composeUp(appDir)
+}
+
+// rollServices replaces the running containers of every rollable service of the app in appDir. For each
+// service the new containers are started next to the old ones, waited for when the app waits for healthy
+// services, and the old containers are removed afterwards. New containers that do not become healthy are
+// removed again so that the old ones keep serving.
+func (r *composeRepository) rollServices(appID, appDir string, cfg *model.AppConfig) error {
+	services, err := r.rollableServices(appDir)
+	if err != nil {
+		return err
+	}
+	ctx := r.operations.context(appDir)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for _, service := range services {
+		old, err := r.serviceContainers(ctx, cfg.Name, service)
+		if err != nil {
+			return err
+		}
+		if len(old) == 0 {
+			// Nothing to replace, the final `up` starts the service.
+			continue
+		}
+		desired := len(old)
+		if cfg.Scale[service] > 0 {
+			desired = cfg.Scale[service]
+		}
+
+		log.Info("[Deploy] rolling update of service", "app_id", appID, "service", service, "containers", desired)
+		if err := r.composeScaleService(appDir, service, len(old)+desired); err != nil {
+			return fmt.Errorf("failed to start new containers of service %s: %w", service, err)
+		}
+		if err := r.waitForHealthy(appID, appDir); err != nil {
+			started, listErr := r.serviceContainers(ctx, cfg.Name, service)
+			if listErr == nil {
+				listErr = r.removeContainers(ctx, withoutContainers(started, old))
+			}
+			if listErr != nil {
+				log.Warn("[Deploy] failed to remove new containers of service", "app_id", appID, "service", service, "error", listErr)
+			}
+			return fmt.Errorf("rolling update of service %s failed: %w", service, err)
+		}
+		if err := r.removeContainers(ctx, old); err != nil {
+			return fmt.Errorf("failed to remove old containers of service %s: %w", service, err)
+		}
+	}
+	return nil
+}
+
+// composeScaleService starts service of the app in appDir with count containers, leaving the existing
+// containers and the other services untouched.
+func (r *composeRepository) composeScaleService(appDir, service string, count int) error {
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+
+	args := make([]string, 0)
+	if fileExists(filepath.Join(appDir, ".winterflow.env")) {
+		args = append(args, "--env-file", ".winterflow.env")
+	}
+	args = append(args, r.buildComposeFileArgs(files)...)
+	args = append(args, "up", "-d", "--no-deps", "--no-recreate", "--scale", fmt.Sprintf("%s=%d", service, count), service)
+
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	release, err := r.deploys.acquire(r.operations.context(appDir))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return r.runDockerComposeWithRetry(appDir, env, args...)
+}
+
+// rollableServices returns the services of the app rendered in appDir that can run an old and a new
+// container side by side, sorted by name. Services with a fixed container name, a published host port or
+// host networking would conflict with themselves and are recreated instead.
+func (r *composeRepository) rollableServices(appDir string) ([]string, error) {
+	names, err := r.composeServices(appDir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name, raw := range definitions {
+			definition, _ := raw.(map[string]interface{})
+			if reason := unscalableReason(definition); reason != "" {
+				pinned[name] = reason
+			}
+		}
+	}
+
+	var services []string
+	for _, name := range names {
+		if reason, ok := pinned[name]; ok {
+			log.Info("[Deploy] service cannot be rolled, recreating it", "app_dir", appDir, "service", name, "reason", reason)
+			continue
+		}
+		services = append(services, name)
+	}
+	return services, nil
+}
+
+// unscalableReason returns why a service definition cannot run more than one container on a host, or an
+// empty string when it can.
+func unscalableReason(definition map[string]interface{}) string {
+	if name, _ := definition["container_name"].(string); name != "" {
+		return "container_name is set"
+	}
+	if mode, _ := definition["network_mode"].(string); mode == "host" {
+		return "host networking is used"
+	}
+	ports, _ := definition["ports"].([]interface{})
+	for _, port := range ports {
+		switch port := port.(type) {
+		case string:
+			// Short syntax: a host port is separated from the container port by a colon.
+			if strings.Contains(port, ":") {
+				return "a host port is published"
+			}
+		case map[string]interface{}:
+			if published, ok := port["published"]; ok && fmt.Sprint(published) != "" {
+				return "a host port is published"
+			}
+		}
+	}
+	return ""
+}
+
+// serviceContainers returns the IDs of the containers of service in the compose project appName.
+func (r *composeRepository) serviceContainers(ctx context.Context, appName, service string) ([]string, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("com.docker.compose.project=%s", appName))
+	filterArgs.Add("label", fmt.Sprintf("%s=%s", composeServiceLabel, service))
+	filterArgs.Add("label", managedLabel+"=true")
+
+	containers, err := r.listContainers(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers of service %s: %w", service, err)
+	}
+	var ids []string
+	for _, c := range containers {
+		if strings.EqualFold(c.Labels[composeOneOffLabel], "true") {
+			continue
+		}
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+// removeContainers stops and removes the containers with ids, within their stop grace period.
+func (r *composeRepository) removeContainers(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := r.GetClient().ContainerStop(ctx, id, container.StopOptions{}); err != nil {
+			return fmt.Errorf("failed to stop container %s: %w", id, err)
+		}
+		if err := r.GetClient().ContainerRemove(ctx, id, container.RemoveOptions{}); err != nil {
+			return fmt.Errorf("failed to remove container %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// withoutContainers returns the ids that are not in exclude.
+func withoutContainers(ids, exclude []string) []string {
+	excluded := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+	var result []string
+	for _, id := range ids {
+		if !excluded[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
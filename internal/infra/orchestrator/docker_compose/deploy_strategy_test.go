@@ -0,0 +1,301 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/command"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// rollingComposeFile defines a scalable service next to services that cannot run twice on a host.
+const rollingComposeFile = `services:
+  web:
+    image: nginx
+    ports:
+      - "80"
+  db:
+    image: postgres
+    container_name: demo-db
+  proxy:
+    image: traefik
+    ports:
+      - "8080:80"
+`
+
+// rollingFixture simulates the containers of the "demo" project. Scaling a service up through compose adds
+// containers reporting the health in newHealth.
+type rollingFixture struct {
+	mu         sync.Mutex
+	containers []container.Summary
+	newHealth  container.HealthStatus
+	created    int
+	removed    []string
+}
+
+func newRollingFixture(services ...string) *rollingFixture {
+	f := &rollingFixture{}
+	for _, service := range services {
+		f.add(service, service+"-old")
+	}
+	return f
+}
+
+func (f *rollingFixture) add(service, id string) {
+	f.containers = append(f.containers, container.Summary{
+		ID:     id,
+		Names:  []string{"/demo-" + id},
+		State:  "running",
+		Labels: map[string]string{"com.docker.compose.project": "demo", managedLabel: "true", composeServiceLabel: service},
+	})
+}
+
+// scale applies a compose `up --scale service=N service` invocation to the simulated containers.
+func (f *rollingFixture) scale(args []string) {
+	for i, arg := range args {
+		if arg != "--scale" || i+1 >= len(args) {
+			continue
+		}
+		service, count, _ := strings.Cut(args[i+1], "=")
+		want, _ := strconv.Atoi(count)
+		f.mu.Lock()
+		for f.count(service) < want {
+			f.created++
+			f.add(service, fmt.Sprintf("%s-new-%d", service, f.created))
+		}
+		f.mu.Unlock()
+	}
+}
+
+func (f *rollingFixture) count(service string) int {
+	n := 0
+	for _, c := range f.containers {
+		if c.Labels[composeServiceLabel] == service {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *rollingFixture) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		path := req.URL.Path
+		if strings.HasSuffix(path, "/containers/json") {
+			serveContainerList(w, req, f.containers)
+			return
+		}
+		id := path[strings.LastIndex(path, "/containers/")+len("/containers/"):]
+		switch {
+		case req.Method == http.MethodPost && strings.HasSuffix(id, "/stop"):
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodDelete:
+			f.removed = append(f.removed, id)
+			kept := f.containers[:0]
+			for _, c := range f.containers {
+				if c.ID != id {
+					kept = append(kept, c)
+				}
+			}
+			f.containers = kept
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			id = strings.TrimSuffix(id, "/json")
+			state := &container.State{Status: "running", Running: true}
+			if strings.Contains(id, "-new-") && f.newHealth != "" {
+				state.Health = &container.Health{Status: f.newHealth}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{ID: id, Name: "/demo-" + id, State: state},
+			})
+		}
+	}
+}
+
+func (f *rollingFixture) removedContainers() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.removed...)
+}
+
+// scalingRunner records commands like FakeRunner and applies compose scale invocations to a fixture.
+type scalingRunner struct {
+	*command.FakeRunner
+	fixture *rollingFixture
+}
+
+func (r scalingRunner) CombinedOutput(cmd command.Cmd) ([]byte, error) {
+	r.fixture.scale(cmd.Args)
+	return r.FakeRunner.CombinedOutput(cmd)
+}
+
+// newRollingRepository returns a repository with a rendered "app" whose deployed config is appConfig and
+// whose containers are simulated by fixture.
+func newRollingRepository(t *testing.T, fixture *rollingFixture, appConfig string) (*composeRepository, *command.FakeRunner) {
+	t.Helper()
+	runner := &command.FakeRunner{}
+	r := newTestRepository(t, &config.Config{HealthWaitTimeout: 1}, scalingRunner{FakeRunner: runner, fixture: fixture})
+	r.client = newFakeDockerClientWithHandler(t, fixture.handler())
+	r.healthPollInterval = 10 * time.Millisecond
+	writeTestApp(t, r, map[string]string{"compose.yml": rollingComposeFile, ".winterflow.config.json": appConfig})
+	return r, runner
+}
+
+// composeSubcommands returns the arguments of the issued compose commands.
+func composeSubcommands(commands []command.Cmd) []string {
+	args := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		args = append(args, strings.TrimPrefix(strings.Join(cmd.Args, " "), "compose "))
+	}
+	return args
+}
+
+func TestUpdateAppRecreatesByDefault(t *testing.T) {
+	fixture := newRollingFixture("web", "db", "proxy")
+	r, runner := newRollingRepository(t, fixture, `{"id":"app","name":"demo"}`)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := []string{"pull", "up -d"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+	if removed := fixture.removedContainers(); len(removed) != 0 {
+		t.Errorf("Expected compose to replace the containers, got removed %v", removed)
+	}
+}
+
+func TestUpdateAppRollsScalableServices(t *testing.T) {
+	fixture := newRollingFixture("web", "db", "proxy")
+	r, runner := newRollingRepository(t, fixture, `{"id":"app","name":"demo","deploy_strategy":"rolling"}`)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := []string{"pull", "up -d --no-deps --no-recreate --scale web=2 web", "up -d"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+	if removed := fixture.removedContainers(); len(removed) != 1 || removed[0] != "web-old" {
+		t.Errorf("Expected only the old web container to be removed, got %v", removed)
+	}
+}
+
+func TestUpdateAppRollsToConfiguredScale(t *testing.T) {
+	fixture := newRollingFixture("web")
+	r, runner := newRollingRepository(t, fixture, `{"id":"app","name":"demo","deploy_strategy":"rolling","scale":{"web":3}}`)
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := []string{"pull", "up -d --no-deps --no-recreate --scale web=4 web", "up -d --scale web=3"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
+
+func TestUpdateAppKeepsOldContainersWhenNewOnesAreUnhealthy(t *testing.T) {
+	fixture := newRollingFixture("web")
+	fixture.newHealth = container.Unhealthy
+	r, runner := newRollingRepository(t, fixture, `{"id":"app","name":"demo","deploy_strategy":"rolling","wait_for_healthy":true}`)
+
+	err := r.UpdateApp("app")
+	if err == nil || !strings.Contains(err.Error(), "rolling update of service web failed") {
+		t.Fatalf("Expected the rolling update to fail, got %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	want := []string{"pull", "up -d --no-deps --no-recreate --scale web=2 web"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected no final up after the failure, got %q", got)
+	}
+	if removed := fixture.removedContainers(); len(removed) != 1 || removed[0] != "web-new-1" {
+		t.Errorf("Expected only the new web container to be removed, got %v", removed)
+	}
+}
+
+func TestUpdateAppRejectsUnknownDeployStrategy(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture("web"), `{"id":"app","name":"demo","deploy_strategy":"blue-green"}`)
+
+	err := r.UpdateApp("app")
+	if err == nil || !strings.Contains(err.Error(), `invalid deploy strategy "blue-green"`) {
+		t.Fatalf("Expected an invalid deploy strategy error, got %v", err)
+	}
+	if got := composeSubcommands(runner.Commands()); len(got) != 1 {
+		t.Errorf("Expected only the pull to run, got %q", got)
+	}
+}
+
+func TestUnscalableReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition map[string]interface{}
+		scalable   bool
+	}{
+		{"plain", map[string]interface{}{"image": "nginx"}, true},
+		{"container port", map[string]interface{}{"ports": []interface{}{"80", 443}}, true},
+		{"container name", map[string]interface{}{"container_name": "web"}, false},
+		{"host network", map[string]interface{}{"network_mode": "host"}, false},
+		{"host port", map[string]interface{}{"ports": []interface{}{"127.0.0.1:8080:80"}}, false},
+		{"long syntax host port", map[string]interface{}{"ports": []interface{}{map[string]interface{}{"target": 80, "published": "8080"}}}, false},
+		{"long syntax container port", map[string]interface{}{"ports": []interface{}{map[string]interface{}{"target": 80}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := unscalableReason(tt.definition); (reason == "") != tt.scalable {
+				t.Errorf("Expected scalable=%v, got reason %q", tt.scalable, reason)
+			}
+		})
+	}
+}
+
+func TestKeepsContainersRunningOnlyForRollingDeploymentsOfTheSameProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		newCfg   string
+		expected bool
+	}{
+		{"recreate", `{"id":"app","name":"demo"}`, false},
+		{"rolling", `{"id":"app","name":"demo","deploy_strategy":"rolling"}`, true},
+		{"renamed", `{"id":"app","name":"renamed","deploy_strategy":"rolling"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateDir, appDir := t.TempDir(), t.TempDir()
+			if err := os.WriteFile(filepath.Join(templateDir, model.AppConfigFileNames[0]), []byte(tt.newCfg), 0o644); err != nil {
+				t.Fatalf("Failed to write new config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(appDir, ".winterflow.config.json"), []byte(`{"id":"app","name":"demo"}`), 0o644); err != nil {
+				t.Fatalf("Failed to write deployed config: %v", err)
+			}
+
+			got, err := keepsContainersRunning(templateDir, appDir)
+			if err != nil {
+				t.Fatalf("keepsContainersRunning returned error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
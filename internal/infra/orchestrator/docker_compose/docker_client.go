@@ -0,0 +1,123 @@
+package docker_compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"time"
+
+	"winterflow-agent/pkg/backoff"
+	"winterflow-agent/pkg/log"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// DockerClientFactory creates a client of the Docker daemon apps are deployed to. It is called again to
+// replace the client when the daemon could not be reached, e.g. after it restarted.
+type DockerClientFactory func() (*client.Client, error)
+
+const (
+	// dockerReconnectAttempts caps how often a Docker API call is retried with a recreated client.
+	dockerReconnectAttempts = 5
+	// defaultDockerReconnectDelay is the initial delay before the Docker client is recreated.
+	defaultDockerReconnectDelay = 500 * time.Millisecond
+	// maxDockerReconnectDelay caps the exponential backoff between reconnection attempts.
+	maxDockerReconnectDelay = 8 * time.Second
+)
+
+// isDaemonConnectionError reports whether err means that the Docker daemon could not be reached or dropped the
+// connection, as it does while it restarts, rather than that it answered the request with an error.
+func isDaemonConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return client.IsErrConnectionFailed(err) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// withDockerClient runs call with the Docker client. When the daemon cannot be reached, the client is recreated
+// through the client factory and call is retried with backoff until it reaches the daemon, the attempts are used
+// up or ctx is done. Errors the daemon answered with are returned right away.
+func (r *composeRepository) withDockerClient(ctx context.Context, call func(*client.Client) error) error {
+	dockerClient := r.GetClient()
+	err := call(dockerClient)
+	if r.newClient == nil || !isDaemonConnectionError(err) {
+		return err
+	}
+
+	delay := r.dockerReconnectDelay
+	if delay <= 0 {
+		delay = defaultDockerReconnectDelay
+	}
+	reconnectBackoff := backoff.New(delay, maxDockerReconnectDelay)
+	for attempt := 1; attempt <= dockerReconnectAttempts; attempt++ {
+		wait := reconnectBackoff.Next()
+		log.Warn("Docker daemon is unreachable, reconnecting", "attempt", attempt, "max_attempts", dockerReconnectAttempts, "wait", wait, "error", err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("docker daemon is unreachable: %w", err)
+		}
+
+		reconnected, reconnectErr := r.reconnectDockerClient(dockerClient)
+		if reconnectErr != nil {
+			err = reconnectErr
+			continue
+		}
+		dockerClient = reconnected
+		if err = call(dockerClient); !isDaemonConnectionError(err) {
+			log.Info("Reconnected to the Docker daemon", "attempts", attempt)
+			return err
+		}
+	}
+	return fmt.Errorf("docker daemon is unreachable after %d reconnection attempts: %w", dockerReconnectAttempts, err)
+}
+
+// reconnectDockerClient replaces stale, a client that failed to reach the daemon, with a new one from the client
+// factory. When another call replaced it in the meantime, the current client is returned instead.
+func (r *composeRepository) reconnectDockerClient(stale *client.Client) (*client.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != stale {
+		return r.client, nil
+	}
+
+	fresh, err := r.newClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to recreate docker client: %w", err)
+	}
+	if stale != nil {
+		_ = stale.Close()
+	}
+	r.client = fresh
+	return fresh, nil
+}
+
+// listContainers lists containers like client.ContainerList, reconnecting to a restarted daemon.
+func (r *composeRepository) listContainers(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
+	var containers []container.Summary
+	err := r.withDockerClient(ctx, func(dockerClient *client.Client) error {
+		var err error
+		containers, err = dockerClient.ContainerList(ctx, options)
+		return err
+	})
+	return containers, err
+}
+
+// inspectContainer inspects a container like client.ContainerInspect, reconnecting to a restarted daemon.
+func (r *composeRepository) inspectContainer(ctx context.Context, id string) (container.InspectResponse, error) {
+	var inspect container.InspectResponse
+	err := r.withDockerClient(ctx, func(dockerClient *client.Client) error {
+		var err error
+		inspect, err = dockerClient.ContainerInspect(ctx, id)
+		return err
+	})
+	return inspect, err
+}
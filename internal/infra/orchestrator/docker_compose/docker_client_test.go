@@ -0,0 +1,120 @@
+package docker_compose
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// stoppedDaemonClient returns a Docker client of a daemon that refuses connections, like one that restarts.
+func stoppedDaemonClient(t *testing.T) *client.Client {
+	t.Helper()
+	dockerClient, err := client.NewClientWithOpts(client.WithHost("http://"+refusedAddress(t)), client.WithVersion("1.45"))
+	if err != nil {
+		t.Fatalf("Failed to create docker client: %v", err)
+	}
+	return dockerClient
+}
+
+// restartingDaemon is a client factory whose clients reach the daemon only from the given call on, as if the
+// daemon came back after a restart.
+type restartingDaemon struct {
+	t       *testing.T
+	upAt    int
+	calls   int
+	running *client.Client
+}
+
+func (d *restartingDaemon) newClient() (*client.Client, error) {
+	d.calls++
+	if d.upAt > 0 && d.calls >= d.upAt {
+		return d.running, nil
+	}
+	return stoppedDaemonClient(d.t), nil
+}
+
+func newRestartingRepository(t *testing.T, upAt int, containers []container.Summary) (*composeRepository, *restartingDaemon) {
+	t.Helper()
+	daemon := &restartingDaemon{t: t, upAt: upAt, running: newFakeDockerClient(t, containers)}
+	r := &composeRepository{
+		client:               stoppedDaemonClient(t),
+		newClient:            daemon.newClient,
+		dockerReconnectDelay: time.Millisecond,
+	}
+	return r, daemon
+}
+
+func TestListContainersReconnectsAfterTheDaemonRestarted(t *testing.T) {
+	r, daemon := newRestartingRepository(t, 2, []container.Summary{{ID: "web"}})
+	stale := r.GetClient()
+
+	containers, err := r.listContainers(context.Background(), container.ListOptions{All: true})
+	if err != nil {
+		t.Fatalf("Expected the containers to be listed once the daemon is back, got %v", err)
+	}
+	if len(containers) != 1 || containers[0].ID != "web" {
+		t.Errorf("Expected the web container, got %+v", containers)
+	}
+	if daemon.calls != 2 {
+		t.Errorf("Expected the client to be recreated twice, got %d", daemon.calls)
+	}
+	if r.GetClient() == stale || r.GetClient() != daemon.running {
+		t.Error("Expected the recreated client to replace the stale one")
+	}
+}
+
+func TestListContainersFailsOnceTheReconnectionAttemptsAreUsedUp(t *testing.T) {
+	r, daemon := newRestartingRepository(t, 0, nil)
+
+	_, err := r.listContainers(context.Background(), container.ListOptions{All: true})
+	if err == nil || !strings.Contains(err.Error(), "docker daemon is unreachable after 5 reconnection attempts") {
+		t.Fatalf("Expected the daemon to be reported unreachable, got %v", err)
+	}
+	if !client.IsErrConnectionFailed(err) {
+		t.Errorf("Expected the connection error to be wrapped, got %v", err)
+	}
+	if daemon.calls != dockerReconnectAttempts {
+		t.Errorf("Expected %d reconnection attempts, got %d", dockerReconnectAttempts, daemon.calls)
+	}
+}
+
+func TestListContainersStopsReconnectingWhenCanceled(t *testing.T) {
+	r, _ := newRestartingRepository(t, 0, nil)
+	r.dockerReconnectDelay = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := r.listContainers(ctx, container.ListOptions{All: true}); err == nil || !strings.Contains(err.Error(), "docker daemon is unreachable") {
+		t.Fatalf("Expected the daemon to be reported unreachable, got %v", err)
+	}
+}
+
+func TestInspectContainerDoesNotReconnectOnDaemonErrors(t *testing.T) {
+	r, daemon := newRestartingRepository(t, 1, nil)
+	r.client = newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "no such container", http.StatusNotFound)
+	})
+
+	if _, err := r.inspectContainer(context.Background(), "missing"); err == nil {
+		t.Fatal("Expected the daemon error to be returned")
+	}
+	if daemon.calls != 0 {
+		t.Errorf("Expected no reconnection for an error of the daemon, got %d", daemon.calls)
+	}
+}
+
+func TestIsDaemonConnectionError(t *testing.T) {
+	if isDaemonConnectionError(nil) || isDaemonConnectionError(errors.New("no such container")) {
+		t.Error("Expected errors of the daemon not to be connection errors")
+	}
+	_, err := stoppedDaemonClient(t).Ping(context.Background())
+	if !isDaemonConnectionError(err) {
+		t.Errorf("Expected %v to be a connection error", err)
+	}
+}
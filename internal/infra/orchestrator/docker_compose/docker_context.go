@@ -0,0 +1,24 @@
+package docker_compose
+
+import (
+	"fmt"
+	"strings"
+
+	"winterflow-agent/pkg/command"
+)
+
+// ResolveDockerContextHost verifies through runner that the named Docker context exists and returns the Docker
+// endpoint it points to, so that API clients can talk to the same daemon as `docker compose`.
+func ResolveDockerContextHost(runner command.Runner, name string) (string, error) {
+	cmd := command.Cmd{Name: "docker", Args: []string{"context", "inspect", "--format", "{{.Endpoints.docker.Host}}", name}}
+	output, stderr, err := runner.Output(cmd)
+	if err != nil {
+		return "", fmt.Errorf("docker context %q is not available: %w: %s", name, err, strings.TrimSpace(string(stderr)))
+	}
+
+	host := strings.TrimSpace(string(output))
+	if host == "" {
+		return "", fmt.Errorf("docker context %q has no docker endpoint", name)
+	}
+	return host, nil
+}
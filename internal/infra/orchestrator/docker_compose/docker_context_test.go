@@ -0,0 +1,56 @@
+package docker_compose
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/pkg/command"
+)
+
+func TestResolveDockerContextHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   command.FakeResult
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "context endpoint",
+			result:   command.FakeResult{Stdout: []byte("ssh://deploy@remote\n")},
+			expected: "ssh://deploy@remote",
+		},
+		{
+			name:    "missing context",
+			result:  command.FakeResult{Stderr: []byte("context \"remote\" does not exist\n"), Err: errors.New("exit status 1")},
+			wantErr: `context "remote" does not exist`,
+		},
+		{
+			name:    "context without docker endpoint",
+			result:  command.FakeResult{Stdout: []byte("\n")},
+			wantErr: "has no docker endpoint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &command.FakeRunner{Results: []command.FakeResult{tt.result}}
+			host, err := ResolveDockerContextHost(runner, "remote")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("ResolveDockerContextHost returned error: %v", err)
+			}
+			if host != tt.expected {
+				t.Errorf("Expected host %q, got %q", tt.expected, host)
+			}
+
+			expected := []string{"docker context inspect --format {{.Endpoints.docker.Host}} remote"}
+			if got := commandLines(runner.Commands()); !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected commands %v, got %v", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package docker_compose
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// envFileReference is an env_file entry of a compose service.
+type envFileReference struct {
+	service  string
+	path     string
+	required bool
+}
+
+// checkEnvFiles verifies that every env_file referenced by the rendered compose files in appDir resolves to a
+// file within appDir, so that `docker compose up` neither reads files of the host nor fails on a file the
+// templates did not provide. Relative paths are resolved against the directory of the referencing compose
+// file. Missing files are only accepted when the reference sets `required: false`; paths interpolated by
+// compose are left to it.
+func (r *composeRepository) checkEnvFiles(appDir string) error {
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(appDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve app directory: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+
+		for _, ref := range envFileReferences(doc) {
+			if strings.Contains(ref.path, "$") {
+				continue
+			}
+			exists, err := checkAppFilePath(appDir, root, file, ref.path)
+			if err != nil {
+				return fmt.Errorf("env_file %q of service %s %w", ref.path, ref.service, err)
+			}
+			if !exists && ref.required {
+				return fmt.Errorf("env_file %q of service %s does not exist", ref.path, ref.service)
+			}
+		}
+	}
+	return nil
+}
+
+// checkAppFilePath verifies that path, as referenced by composeFile, is a file within appDir, whose resolved
+// form is root, also once symlinks are followed. Relative paths are resolved against the directory of
+// composeFile. It reports whether the file exists; errors complete a sentence naming the reference.
+func checkAppFilePath(appDir, root, composeFile, path string) (bool, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(composeFile), path)
+	}
+	if !withinDir(appDir, path) {
+		return false, errors.New("is outside the app directory")
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot be resolved: %w", err)
+	}
+	if !withinDir(root, resolved) {
+		return false, errors.New("links outside the app directory")
+	}
+	return true, nil
+}
+
+// envFileReferences returns the env_file entries of the services of a parsed compose document, sorted by
+// service. Entries are either paths or mappings with a path and an optional required flag (default true).
+func envFileReferences(doc map[string]interface{}) []envFileReference {
+	services, _ := doc["services"].(map[string]interface{})
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var refs []envFileReference
+	for _, name := range names {
+		definition, _ := services[name].(map[string]interface{})
+		var entries []interface{}
+		switch envFile := definition["env_file"].(type) {
+		case string:
+			entries = []interface{}{envFile}
+		case []interface{}:
+			entries = envFile
+		}
+
+		for _, entry := range entries {
+			switch entry := entry.(type) {
+			case string:
+				refs = append(refs, envFileReference{service: name, path: entry, required: true})
+			case map[string]interface{}:
+				path, _ := entry["path"].(string)
+				if path == "" {
+					continue
+				}
+				required, ok := entry["required"].(bool)
+				refs = append(refs, envFileReference{service: name, path: path, required: required || !ok})
+			}
+		}
+	}
+	return refs
+}
+
+// withinDir reports whether path is dir or lexically below it.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
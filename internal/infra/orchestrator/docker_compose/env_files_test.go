@@ -0,0 +1,95 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// writeEnvFileTemplate replaces the compose file of the app revision with compose and adds extra files.
+func writeEnvFileTemplate(t *testing.T, r *composeRepository, compose string, files map[string]string) {
+	t.Helper()
+	filesDir := filepath.Join(r.config.GetAppsTemplatesPath(), "app", "1", "files")
+	files["compose.yml"] = compose
+	for name, content := range files {
+		path := filepath.Join(filesDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestDeployAppRendersReferencedEnvFiles(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	writeEnvFileTemplate(t, r, "services:\n  db:\n    image: postgres\n    env_file:\n      - ./config.env\n      - path: env/optional.env\n        required: false\n",
+		map[string]string{"config.env": "POSTGRES_USER=${DB_USER}\n"})
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	if got := readAppFile(t, r, "config.env"); got != "POSTGRES_USER=admin\n" {
+		t.Errorf("Expected the env file to be rendered, got %q", got)
+	}
+	if len(runner.Commands()) == 0 {
+		t.Error("Expected docker compose to be invoked")
+	}
+}
+
+func TestDeployAppRejectsEnvFilesOutsideAppDir(t *testing.T) {
+	testCases := map[string]string{
+		"parent traversal": "services:\n  db:\n    env_file: ../../../etc/passwd\n",
+		"absolute path":    "services:\n  db:\n    env_file:\n      - /etc/passwd\n",
+		"nested traversal": "services:\n  db:\n    env_file:\n      - path: conf/../../secrets.env\n",
+	}
+	for name, compose := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, runner := newHookRepository(t, &config.Config{})
+			writeEnvFileTemplate(t, r, compose, map[string]string{})
+
+			err := r.DeployApp("app")
+			if err == nil || !strings.Contains(err.Error(), "outside the app directory") {
+				t.Fatalf("Expected the env_file to be rejected, got %v", err)
+			}
+			if len(runner.Commands()) != 0 {
+				t.Errorf("Expected docker compose not to be invoked, got %v", commandNames(runner.Commands()))
+			}
+		})
+	}
+}
+
+func TestDeployAppRejectsMissingEnvFiles(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	writeEnvFileTemplate(t, r, "services:\n  db:\n    env_file: config.env\n", map[string]string{})
+
+	if err := r.DeployApp("app"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("Expected the missing env_file to be rejected, got %v", err)
+	}
+	if len(runner.Commands()) != 0 {
+		t.Errorf("Expected docker compose not to be invoked, got %v", commandNames(runner.Commands()))
+	}
+}
+
+func TestCheckEnvFilesRejectsSymlinksOutsideAppDir(t *testing.T) {
+	appDir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "host.env")
+	if err := os.WriteFile(outside, []byte("KEY=value\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(appDir, "config.env")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte("services:\n  db:\n    env_file: config.env\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+
+	r := &composeRepository{config: &config.Config{}}
+	if err := r.checkEnvFiles(appDir); err == nil || !strings.Contains(err.Error(), "links outside") {
+		t.Errorf("Expected the symlinked env_file to be rejected, got %v", err)
+	}
+}
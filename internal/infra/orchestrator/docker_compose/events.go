@@ -0,0 +1,113 @@
+package docker_compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// containerEventActions are the Docker container events reported by ContainerEvents.
+var containerEventActions = []model.ContainerEventAction{
+	model.ContainerEventStart,
+	model.ContainerEventStop,
+	model.ContainerEventDie,
+	model.ContainerEventOOM,
+}
+
+// ContainerEvents subscribes to the Docker events API for the start, stop, die and OOM events of the
+// containers managed by the agent until ctx is done.
+func (r *composeRepository) ContainerEvents(ctx context.Context) (<-chan model.ContainerEvent, <-chan error) {
+	messages, errs := r.GetClient().Events(ctx, events.ListOptions{Filters: containerEventFilters()})
+	out := make(chan model.ContainerEvent)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				// The client reports the cancellation of ctx as an error as well.
+				if err != nil && ctx.Err() == nil {
+					outErrs <- err
+				}
+				return
+			case msg := <-messages:
+				select {
+				case out <- containerEventFromMessage(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, outErrs
+}
+
+// RecentContainerEvents returns the start, stop, die and OOM events the Docker engine recorded for the
+// containers managed by the agent since the given time, oldest first.
+func (r *composeRepository) RecentContainerEvents(ctx context.Context, since time.Time) ([]model.ContainerEvent, error) {
+	messages, errs := r.GetClient().Events(ctx, events.ListOptions{
+		Filters: containerEventFilters(),
+		Since:   strconv.FormatInt(since.Unix(), 10),
+		Until:   strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	var recent []model.ContainerEvent
+	for {
+		select {
+		case msg := <-messages:
+			recent = append(recent, containerEventFromMessage(msg))
+		case err := <-errs:
+			// The engine ends the stream once it reported the events up to the until time.
+			if errors.Is(err, io.EOF) {
+				return recent, nil
+			}
+			return recent, fmt.Errorf("failed to read container events: %w", err)
+		}
+	}
+}
+
+// containerEventFilters selects the events of containerEventActions of the managed containers.
+func containerEventFilters() filters.Args {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("type", string(events.ContainerEventType))
+	filterArgs.Add("label", managedLabel+"=true")
+	for _, action := range containerEventActions {
+		filterArgs.Add("event", string(action))
+	}
+	return filterArgs
+}
+
+// containerEventFromMessage converts a Docker container event. The labels of the container are
+// reported as attributes of the event.
+func containerEventFromMessage(msg events.Message) model.ContainerEvent {
+	attributes := msg.Actor.Attributes
+	event := model.ContainerEvent{
+		AppID:         attributes[appIDLabel],
+		ContainerID:   msg.Actor.ID,
+		ContainerName: attributes["name"],
+		ServiceName:   attributes[composeServiceLabel],
+		Action:        model.ContainerEventAction(msg.Action),
+		Count:         1,
+	}
+	switch {
+	case msg.TimeNano != 0:
+		event.Time = time.Unix(0, msg.TimeNano)
+	case msg.Time != 0:
+		event.Time = time.Unix(msg.Time, 0)
+	default:
+		event.Time = time.Now()
+	}
+	if code, err := strconv.Atoi(attributes["exitCode"]); err == nil {
+		event.ExitCode = code
+	}
+	return event
+}
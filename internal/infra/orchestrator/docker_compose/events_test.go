@@ -0,0 +1,118 @@
+package docker_compose
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestContainerEventsForwardsManagedContainerEvents(t *testing.T) {
+	stamp := time.Unix(1700000000, 500)
+	var gotFilters filters.Args
+	dockerClient := newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/events") {
+			http.NotFound(w, req)
+			return
+		}
+		args, err := filters.FromJSON(req.URL.Query().Get("filters"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gotFilters = args
+		encoder := json.NewEncoder(w)
+		_ = encoder.Encode(events.Message{
+			Type:   events.ContainerEventType,
+			Action: events.ActionDie,
+			Actor: events.Actor{ID: "abc", Attributes: map[string]string{
+				"name":              "app-web-1",
+				"exitCode":          "137",
+				appIDLabel:          "app",
+				composeServiceLabel: "web",
+			}},
+			TimeNano: stamp.UnixNano(),
+		})
+		w.(http.Flusher).Flush()
+		<-req.Context().Done()
+	})
+	r := &composeRepository{config: &config.Config{}, client: dockerClient}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	received, errs := r.ContainerEvents(ctx)
+	var event model.ContainerEvent
+	select {
+	case event = <-received:
+	case err := <-errs:
+		t.Fatalf("Unexpected error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for an event")
+	}
+
+	expected := model.ContainerEvent{AppID: "app", ContainerID: "abc", ContainerName: "app-web-1", ServiceName: "web",
+		Action: model.ContainerEventDie, Time: stamp, ExitCode: 137, Count: 1}
+	if event.AppID != expected.AppID || event.ContainerID != expected.ContainerID || event.ContainerName != expected.ContainerName ||
+		event.ServiceName != expected.ServiceName || event.Action != expected.Action || !event.Time.Equal(expected.Time) ||
+		event.ExitCode != expected.ExitCode || event.Count != expected.Count {
+		t.Errorf("Expected %+v, got %+v", expected, event)
+	}
+	if !gotFilters.ExactMatch("label", managedLabel+"=true") || !gotFilters.ExactMatch("type", "container") {
+		t.Errorf("Expected the events to be filtered to managed containers, got %v", gotFilters)
+	}
+	for _, action := range []string{"start", "stop", "die", "oom"} {
+		if !gotFilters.ExactMatch("event", action) {
+			t.Errorf("Expected the %s events to be requested, got %v", action, gotFilters)
+		}
+	}
+
+	cancel()
+	for range received {
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("Expected no error after the subscription was canceled, got %v", err)
+	default:
+	}
+}
+
+func TestRecentContainerEventsReadsTheEventsUntilNow(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	var query map[string]string
+	dockerClient := newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/events") {
+			http.NotFound(w, req)
+			return
+		}
+		query = map[string]string{"since": req.URL.Query().Get("since"), "until": req.URL.Query().Get("until")}
+		encoder := json.NewEncoder(w)
+		for _, action := range []events.Action{events.ActionStart, events.ActionDie} {
+			_ = encoder.Encode(events.Message{
+				Type:     events.ContainerEventType,
+				Action:   action,
+				Actor:    events.Actor{ID: "abc", Attributes: map[string]string{appIDLabel: "app", composeServiceLabel: "web"}},
+				TimeNano: time.Now().UnixNano(),
+			})
+		}
+	})
+	r := &composeRepository{config: &config.Config{}, client: dockerClient}
+
+	recent, err := r.RecentContainerEvents(t.Context(), since)
+	if err != nil {
+		t.Fatalf("RecentContainerEvents returned error: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Action != model.ContainerEventStart || recent[1].Action != model.ContainerEventDie {
+		t.Fatalf("Expected the start and die events, got %+v", recent)
+	}
+	if query["since"] != strconv.FormatInt(since.Unix(), 10) || query["until"] == "" {
+		t.Errorf("Expected the events since %d until now, got %v", since.Unix(), query)
+	}
+}
@@ -0,0 +1,84 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// fileSourceKinds are the top-level compose elements whose definitions may be backed by a file.
+var fileSourceKinds = []string{"configs", "secrets"}
+
+// fileSource is a top-level config or secret of a compose file that is backed by a file.
+type fileSource struct {
+	kind string
+	name string
+	path string
+}
+
+// checkFileSources verifies that every config and secret defined with `file:` in the rendered compose files in
+// appDir is an existing file within appDir, i.e. one rendered from the app templates. Compose mounts them into
+// the containers as they are, so a file of the host must not be reachable through them. Relative paths are
+// resolved against the directory of the compose file; paths interpolated by compose are left to it.
+// Definitions backed by an environment variable, inline content or an external object are not checked.
+func (r *composeRepository) checkFileSources(appDir string) error {
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.EvalSymlinks(appDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve app directory: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+
+		for _, source := range fileSources(doc) {
+			if strings.Contains(source.path, "$") {
+				continue
+			}
+			exists, err := checkAppFilePath(appDir, root, file, source.path)
+			if err != nil {
+				return fmt.Errorf("file %q of %s %s %w", source.path, strings.TrimSuffix(source.kind, "s"), source.name, err)
+			}
+			if !exists {
+				return fmt.Errorf("file %q of %s %s does not exist", source.path, strings.TrimSuffix(source.kind, "s"), source.name)
+			}
+		}
+	}
+	return nil
+}
+
+// fileSources returns the configs and secrets of a parsed compose document that are backed by a file, sorted by
+// kind and name.
+func fileSources(doc map[string]interface{}) []fileSource {
+	var sources []fileSource
+	for _, kind := range fileSourceKinds {
+		definitions, _ := doc[kind].(map[string]interface{})
+		names := make([]string, 0, len(definitions))
+		for name := range definitions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			definition, _ := definitions[name].(map[string]interface{})
+			if path, _ := definition["file"].(string); path != "" {
+				sources = append(sources, fileSource{kind: kind, name: name, path: path})
+			}
+		}
+	}
+	return sources
+}
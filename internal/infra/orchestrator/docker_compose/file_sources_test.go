@@ -0,0 +1,122 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// fileSourcesCompose defines a config and a secret of every kind next to a service using them.
+const fileSourcesCompose = `services:
+  web:
+    image: nginx
+    configs:
+      - source: nginx
+        target: /etc/nginx/nginx.conf
+    secrets:
+      - db_password
+configs:
+  nginx:
+    file: ./conf/nginx.conf
+  generated:
+    content: "listen 80;"
+secrets:
+  db_password:
+    file: secrets/db_password.txt
+  token:
+    environment: TOKEN
+  external_key:
+    external: true
+`
+
+func TestDeployAppRendersTheFilesOfConfigsAndSecrets(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	writeEnvFileTemplate(t, r, fileSourcesCompose, map[string]string{
+		"conf/nginx.conf":         "user ${DB_USER};\n",
+		"secrets/db_password.txt": "hunter2\n",
+	})
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	if got := readAppFile(t, r, filepath.Join("conf", "nginx.conf")); got != "user admin;\n" {
+		t.Errorf("Expected the config file to be rendered, got %q", got)
+	}
+	if got := readAppFile(t, r, filepath.Join("secrets", "db_password.txt")); got != "hunter2\n" {
+		t.Errorf("Expected the secret file to be rendered, got %q", got)
+	}
+	if len(runner.Commands()) == 0 {
+		t.Error("Expected docker compose to be invoked")
+	}
+}
+
+func TestDeployAppRejectsInvalidConfigAndSecretFiles(t *testing.T) {
+	testCases := map[string]struct {
+		compose string
+		reason  string
+	}{
+		"config outside":  {"services:\n  web:\n    image: nginx\nconfigs:\n  passwd:\n    file: ../../../etc/passwd\n", "outside the app directory"},
+		"secret absolute": {"services:\n  web:\n    image: nginx\nsecrets:\n  key:\n    file: /etc/ssl/private/host.key\n", "outside the app directory"},
+		"missing config":  {"services:\n  web:\n    image: nginx\nconfigs:\n  nginx:\n    file: nginx.conf\n", "does not exist"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, runner := newHookRepository(t, &config.Config{})
+			writeEnvFileTemplate(t, r, tc.compose, map[string]string{})
+
+			err := r.DeployApp("app")
+			if err == nil || !strings.Contains(err.Error(), tc.reason) {
+				t.Fatalf("Expected the %s to be rejected, got %v", name, err)
+			}
+			if len(runner.Commands()) != 0 {
+				t.Errorf("Expected docker compose not to be invoked, got %v", commandNames(runner.Commands()))
+			}
+		})
+	}
+}
+
+func TestCheckFileSourcesRejectsSymlinksOutsideAppDir(t *testing.T) {
+	appDir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "host.key")
+	if err := os.WriteFile(outside, []byte("key"), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(appDir, "app.key")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte("secrets:\n  key:\n    file: app.key\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+
+	r := &composeRepository{config: &config.Config{}}
+	if err := r.checkFileSources(appDir); err == nil || !strings.Contains(err.Error(), "secret key links outside") {
+		t.Errorf("Expected the symlinked secret file to be rejected, got %v", err)
+	}
+}
+
+func TestFileSources(t *testing.T) {
+	doc := map[string]interface{}{
+		"configs": map[string]interface{}{
+			"nginx":     map[string]interface{}{"file": "./nginx.conf"},
+			"generated": map[string]interface{}{"content": "listen 80;"},
+		},
+		"secrets": map[string]interface{}{
+			"b": map[string]interface{}{"file": "b.txt"},
+			"a": map[string]interface{}{"file": "a.txt"},
+			"c": map[string]interface{}{"external": true},
+		},
+	}
+
+	want := []fileSource{
+		{kind: "configs", name: "nginx", path: "./nginx.conf"},
+		{kind: "secrets", name: "a", path: "a.txt"},
+		{kind: "secrets", name: "b", path: "b.txt"},
+	}
+	if got := fileSources(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
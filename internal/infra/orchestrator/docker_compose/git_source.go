@@ -0,0 +1,224 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/docker/registry"
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// gitFilesManifest lists the files rendered into an app from its git source, so that files removed from
+	// the repository are removed from the app on the next deployment.
+	gitFilesManifest = ".winterflow.git-files.json"
+	// gitTimeout bounds every git invocation of a checkout.
+	gitTimeout = 5 * time.Minute
+)
+
+// renderGitSource checks out the git source of cfg and renders the files below its path into destDir the
+// same way templates are rendered. It returns the slash separated paths of the rendered files; apps
+// without a git source render none.
+func (r *composeRepository) renderGitSource(cfg *model.AppConfig, destDir string, vars map[string]string) ([]string, error) {
+	if cfg == nil || cfg.GitSource == nil {
+		return nil, nil
+	}
+	source := *cfg.GitSource
+	if source.URL == "" {
+		return nil, fmt.Errorf("git source URL is required")
+	}
+	subPath := "."
+	if source.Path != "" {
+		clean, err := sanitizeFileRelPath(source.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid git source path %q: %w", source.Path, err)
+		}
+		subPath = clean
+	}
+
+	env, err := gitAuthEnv(source.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	checkoutDir, err := os.MkdirTemp("", "winterflow-git-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git checkout directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(checkoutDir); err != nil {
+			log.Warn("Failed to remove git checkout", "dir", checkoutDir, "error", err)
+		}
+	}()
+
+	if err := gitCheckout(r.commandRunner(), source, checkoutDir, env); err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", source.URL, err)
+	}
+	root := filepath.Join(checkoutDir, subPath)
+	if !dirExists(root) {
+		return nil, fmt.Errorf("path %s does not exist in %s", source.Path, source.URL)
+	}
+
+	files, err := renderTree(root, destDir, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render git source: %w", err)
+	}
+	log.Debug("Rendered git source", "url", source.URL, "ref", source.Ref, "path", source.Path, "files", len(files))
+	return files, nil
+}
+
+// gitCheckout checks out ref of the repository into the empty directory dir. It fetches the ref alone
+// with a shallow fetch; servers that do not serve unadvertised commits that way get a full fetch instead,
+// after which a branch ref is checked out from its remote-tracking branch and any other ref as is.
+func gitCheckout(runner command.Runner, source model.GitSource, dir string, env []string) error {
+	// Never wait for credentials on a terminal that nobody watches.
+	env = append(append([]string(nil), env...), "GIT_TERMINAL_PROMPT=0")
+	run := func(args ...string) error {
+		cmd := command.Cmd{Name: "git", Args: args, Dir: dir, Env: env, Timeout: gitTimeout}
+		if output, err := runner.CombinedOutput(cmd); err != nil {
+			return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	ref := source.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if err := run("init", "--quiet"); err != nil {
+		return err
+	}
+	if err := run("remote", "add", "origin", source.URL); err != nil {
+		return err
+	}
+	if err := run("fetch", "--quiet", "--depth", "1", "origin", ref); err != nil {
+		log.Debug("Shallow git fetch failed, fetching the full history", "url", source.URL, "ref", ref, "error", err)
+		if err := run("fetch", "--quiet", "--tags", "origin"); err != nil {
+			return err
+		}
+		// The full fetch creates remote-tracking branches only, so a branch name resolves under origin.
+		if remoteRef := "refs/remotes/origin/" + ref; run("rev-parse", "--verify", "--quiet", remoteRef+"^{commit}") == nil {
+			return run("checkout", "--quiet", "--detach", remoteRef)
+		}
+		return run("checkout", "--quiet", "--detach", ref)
+	}
+	return run("checkout", "--quiet", "--detach", "FETCH_HEAD")
+}
+
+// gitAuthEnv returns the git environment that authenticates requests to the host of an HTTP(S) clone URL
+// with the credentials stored for that host by the registry commands. URLs with their own credentials,
+// other transports and hosts without stored credentials yield no environment. The credentials are passed
+// as an HTTP header through the environment so they appear neither in process arguments nor on disk.
+func gitAuthEnv(rawURL string) ([]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return nil, nil
+	}
+
+	auth, err := storedRegistryAuth(u.Host)
+	if err != nil || auth == "" {
+		return nil, err
+	}
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + auth,
+	}, nil
+}
+
+// storedRegistryAuth returns the base64 encoded `user:password` stored inline in the Docker configuration
+// for host. Credentials kept by credential helpers are not available.
+func storedRegistryAuth(host string) (string, error) {
+	cfgPath, err := registry.GetDockerConfigPath()
+	if err != nil {
+		return "", err
+	}
+	raw, err := os.ReadFile(cfgPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read docker config: %w", err)
+	}
+
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return "", fmt.Errorf("failed to parse docker config: %w", err)
+	}
+	for address, entry := range cfg.Auths {
+		// Addresses are stored as host names or as URLs, e.g. https://index.docker.io/v1/.
+		address = strings.TrimPrefix(strings.TrimPrefix(address, "https://"), "http://")
+		if i := strings.Index(address, "/"); i >= 0 {
+			address = address[:i]
+		}
+		if strings.EqualFold(address, host) {
+			return entry.Auth, nil
+		}
+	}
+	return "", nil
+}
+
+// syncGitFiles removes the files rendered from the git source by the previous deployment that the current
+// one did not render, pruning directories left empty, and records files as the rendered set.
+func syncGitFiles(destDir string, files []string) error {
+	manifestPath := filepath.Join(destDir, gitFilesManifest)
+
+	var previous []string
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &previous); err != nil {
+			log.Warn("Ignoring unreadable git files manifest", "path", manifestPath, "error", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", gitFilesManifest, err)
+	}
+
+	current := make(map[string]struct{}, len(files))
+	for _, name := range files {
+		current[name] = struct{}{}
+	}
+	for _, name := range previous {
+		if _, keep := current[name]; keep {
+			continue
+		}
+		rel, err := sanitizeFileRelPath(name)
+		if err != nil {
+			log.Warn("[Cleanup] skipping invalid filename", "filename", name, "error", err)
+			continue
+		}
+		absPath := filepath.Join(destDir, rel)
+		if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove file %s: %w", absPath, err)
+		}
+		for dir := filepath.Dir(absPath); dir != destDir; dir = filepath.Dir(dir) {
+			if entries, _ := os.ReadDir(dir); len(entries) > 0 || os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		if err := os.Remove(manifestPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", gitFilesManifest, err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal git files: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", gitFilesManifest, err)
+	}
+	return nil
+}
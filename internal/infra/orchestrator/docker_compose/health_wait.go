@@ -0,0 +1,105 @@
+package docker_compose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// defaultHealthPollInterval is how often the health of the services is checked while waiting for them.
+const defaultHealthPollInterval = 2 * time.Second
+
+// composeServiceLabel is the label Docker Compose sets to the service name of a container.
+const composeServiceLabel = "com.docker.compose.service"
+
+// waitForHealthy waits after `docker compose up` until every service of the app that defines a healthcheck
+// reports healthy, when the app opts in with wait_for_healthy. Services without a healthcheck are not
+// waited for. It fails with the services that are still not healthy once the health wait timeout elapses
+// or the operation is canceled.
+func (r *composeRepository) waitForHealthy(appID, appDir string) error {
+	appConfig, err := orchestrator.GetCurrentConfig(appDir)
+	if err != nil || !appConfig.WaitForHealthy {
+		return nil
+	}
+
+	parent := r.operations.context(appDir)
+	if parent == nil {
+		parent = context.Background()
+	}
+	timeout := r.config.GetHealthWaitTimeout()
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	interval := r.healthPollInterval
+	if interval <= 0 {
+		interval = defaultHealthPollInterval
+	}
+
+	log.Info("Waiting for services to become healthy", "app_id", appID, "timeout", timeout)
+	var pending []string
+	for {
+		current, err := r.unhealthyServices(ctx, appConfig.Name)
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("failed to check service health: %w", err)
+		}
+		if err == nil {
+			if len(current) == 0 {
+				log.Info("All services are healthy", "app_id", appID)
+				return nil
+			}
+			pending = current
+		}
+
+		select {
+		case <-ctx.Done():
+			// The check interrupted by the deadline, if any, is reported with the result of the previous one.
+			if parent.Err() != nil {
+				return fmt.Errorf("waiting for healthy services: %w", parent.Err())
+			}
+			return fmt.Errorf("services did not become healthy within %s: %s", timeout, strings.Join(pending, ", "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// unhealthyServices returns the services of the project that define a healthcheck and have a container that
+// is not healthy, together with its health status, e.g. "db (starting)".
+func (r *composeRepository) unhealthyServices(ctx context.Context, appName string) ([]string, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("com.docker.compose.project=%s", appName))
+	filterArgs.Add("label", managedLabel+"=true")
+
+	containers, err := r.listContainers(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var pending []string
+	for _, c := range containers {
+		inspect, err := r.inspectContainer(ctx, c.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect container %s: %w", c.ID, err)
+		}
+		if inspect.State == nil || inspect.State.Health == nil {
+			// The service does not define a healthcheck.
+			continue
+		}
+		if status := inspect.State.Health.Status; status != container.Healthy {
+			service := c.Labels[composeServiceLabel]
+			if service == "" {
+				service = strings.TrimPrefix(inspect.Name, "/")
+			}
+			pending = append(pending, fmt.Sprintf("%s (%s)", service, status))
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
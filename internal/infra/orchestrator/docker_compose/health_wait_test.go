@@ -0,0 +1,123 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// healthFixture simulates the containers of the "demo" project whose health changes over successive
+// inspections.
+type healthFixture struct {
+	mu sync.Mutex
+	// health lists the health statuses reported by successive inspections of a container; the last one
+	// is repeated. Containers without an entry have no healthcheck.
+	health   map[string][]container.HealthStatus
+	inspects int
+}
+
+func (f *healthFixture) handler() http.HandlerFunc {
+	project := "com.docker.compose.project"
+	containers := []container.Summary{
+		{ID: "db", Names: []string{"/demo-db-1"}, State: "running", Labels: map[string]string{project: "demo", managedLabel: "true", composeServiceLabel: "db"}},
+		{ID: "cache", Names: []string{"/demo-cache-1"}, State: "running", Labels: map[string]string{project: "demo", managedLabel: "true", composeServiceLabel: "cache"}},
+		{ID: "web", Names: []string{"/demo-web-1"}, State: "running", Labels: map[string]string{project: "demo", managedLabel: "true", composeServiceLabel: "web"}},
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/containers/json") {
+			serveContainerList(w, req, containers)
+			return
+		}
+		id := strings.TrimSuffix(req.URL.Path[strings.LastIndex(req.URL.Path, "/containers/")+len("/containers/"):], "/json")
+
+		f.mu.Lock()
+		f.inspects++
+		state := &container.State{Status: "running", Running: true}
+		if statuses := f.health[id]; len(statuses) > 0 {
+			state.Health = &container.Health{Status: statuses[0]}
+			if len(statuses) > 1 {
+				f.health[id] = statuses[1:]
+			}
+		}
+		f.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: id, Name: "/demo-" + id + "-1", State: state},
+		})
+	}
+}
+
+func (f *healthFixture) inspections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.inspects
+}
+
+// newHealthWaitRepository returns a repository with a rendered "app" whose deployed config is appConfig
+// and whose containers are simulated by fixture.
+func newHealthWaitRepository(t *testing.T, fixture *healthFixture, appConfig string) *composeRepository {
+	t.Helper()
+	r := newTestRepository(t, &config.Config{HealthWaitTimeout: 1}, &command.FakeRunner{})
+	r.client = newFakeDockerClientWithHandler(t, fixture.handler())
+	r.healthPollInterval = 10 * time.Millisecond
+	writeTestApp(t, r, map[string]string{"compose.yml": "services: {}\n", ".winterflow.config.json": appConfig})
+	return r
+}
+
+func TestStartAppWaitsForServicesToBecomeHealthy(t *testing.T) {
+	fixture := &healthFixture{health: map[string][]container.HealthStatus{
+		"db":    {container.Starting, container.Starting, container.Healthy},
+		"cache": {container.Healthy},
+	}}
+	r := newHealthWaitRepository(t, fixture, `{"id":"app","name":"demo","wait_for_healthy":true}`)
+
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("Expected the app to start once its services are healthy, got %v", err)
+	}
+	// Three polls of the two services with a healthcheck and the one without.
+	if got := fixture.inspections(); got != 9 {
+		t.Errorf("Expected 9 inspections, got %d", got)
+	}
+}
+
+func TestStartAppReportsServicesThatDoNotBecomeHealthy(t *testing.T) {
+	fixture := &healthFixture{health: map[string][]container.HealthStatus{
+		"db":    {container.Starting, container.Unhealthy},
+		"cache": {container.Starting},
+	}}
+	r := newHealthWaitRepository(t, fixture, `{"id":"app","name":"demo","wait_for_healthy":true}`)
+
+	err := r.StartApp("app")
+	if err == nil {
+		t.Fatal("Expected StartApp to fail when services do not become healthy")
+	}
+	for _, expected := range []string{"did not become healthy within 1s", "cache (starting), db (unhealthy)"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got %v", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "web") {
+		t.Errorf("Expected the service without a healthcheck not to be reported, got %v", err)
+	}
+}
+
+func TestStartAppDoesNotWaitWithoutOptIn(t *testing.T) {
+	fixture := &healthFixture{health: map[string][]container.HealthStatus{"db": {container.Unhealthy}}}
+	r := newHealthWaitRepository(t, fixture, `{"id":"app","name":"demo"}`)
+
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("StartApp returned error: %v", err)
+	}
+	if got := fixture.inspections(); got != 0 {
+		t.Errorf("Expected no health checks, got %d inspections", got)
+	}
+}
@@ -0,0 +1,49 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// newTestRepository returns a repository with cfg issuing docker commands to runner, isolated from the docker
+// configuration of the host. A cfg without a base path gets a temporary one.
+func newTestRepository(t *testing.T, cfg *config.Config, runner command.Runner) *composeRepository {
+	t.Helper()
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	if cfg.BasePath == "" {
+		cfg.BasePath = t.TempDir()
+	}
+	return &composeRepository{config: cfg, runner: runner}
+}
+
+// writeTestRevision writes revision 1 of app "app" of r, see writeRevision, and returns its directory.
+func writeTestRevision(t *testing.T, r *composeRepository) string {
+	t.Helper()
+	templateDir := filepath.Join(r.config.GetAppsTemplatesPath(), "app", "1")
+	writeRevision(t, templateDir)
+	return templateDir
+}
+
+// writeTestApp writes the rendered app "app" of r with files, mapping file names to their contents, and
+// returns its directory.
+func writeTestApp(t *testing.T, r *composeRepository, files map[string]string) string {
+	t.Helper()
+	appDir := r.getAppDir("app")
+	if err := os.MkdirAll(appDir, 0o755); err != nil {
+		t.Fatalf("Failed to create app directory: %v", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(appDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return appDir
+}
@@ -0,0 +1,68 @@
+package docker_compose
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// hooksDir is the directory of an app revision holding optional deployment hooks.
+	hooksDir = "hooks"
+	// preDeployHook runs after rendering and before `docker compose up`; a failure aborts the deployment.
+	preDeployHook = "pre_deploy.sh"
+	// postDeployHook runs after `docker compose up`; a failure is logged unless configured otherwise.
+	postDeployHook = "post_deploy.sh"
+)
+
+// runDeployHook executes the hook script name from the hooks directory of templateDir, if present, with the
+// rendered appDir as working directory and the app variables, with secret references resolved, as environment. The run time is limited by the
+// configured hook timeout.
+func (r *composeRepository) runDeployHook(templateDir, appDir, name string) error {
+	hookPath := filepath.Join(templateDir, hooksDir, name)
+	if !fileExists(hookPath) {
+		return nil
+	}
+
+	vars, err := r.loadTemplateVariables(templateDir)
+	if err != nil {
+		return fmt.Errorf("failed to load variables for hook %s: %w", name, err)
+	}
+	secretValues, err := r.resolveSecrets(secretReferences(vars))
+	if err != nil {
+		return fmt.Errorf("failed to load variables for hook %s: %w", name, err)
+	}
+	for key, value := range secretValues {
+		vars[key] = value
+	}
+
+	timeout := r.config.GetDeployHookTimeout()
+	log.Info("[Deploy] running hook", "hook", name, "dir", appDir, "timeout", timeout)
+	output, err := r.commandRunner().CombinedOutput(command.Cmd{
+		Name:    "sh",
+		Args:    []string{hookPath},
+		Dir:     appDir,
+		Env:     hookEnv(vars),
+		Timeout: timeout,
+		Context: r.operations.context(appDir),
+	})
+	if err != nil {
+		log.Error("[Deploy] hook failed", "hook", name, "output", string(output), "error", err)
+		return fmt.Errorf("hook %s failed: %w", name, err)
+	}
+	log.Debug("[Deploy] hook completed", "hook", name, "output", string(output))
+	return nil
+}
+
+// hookEnv converts vars to KEY=VALUE environment entries in a stable order.
+func hookEnv(vars map[string]string) []string {
+	env := make([]string, 0, len(vars))
+	for key, value := range vars {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
@@ -0,0 +1,114 @@
+package docker_compose
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// newHookRepository prepares revision 1 of app "app" with the given hook scripts and returns a
+// repository deploying it through a fake runner.
+func newHookRepository(t *testing.T, cfg *config.Config, hooks ...string) (*composeRepository, *command.FakeRunner) {
+	t.Helper()
+	runner := &command.FakeRunner{}
+	r := newTestRepository(t, cfg, runner)
+
+	templateDir := writeTestRevision(t, r)
+	if err := os.MkdirAll(filepath.Join(templateDir, hooksDir), 0o755); err != nil {
+		t.Fatalf("Failed to create hooks directory: %v", err)
+	}
+	for _, hook := range hooks {
+		if err := os.WriteFile(filepath.Join(templateDir, hooksDir, hook), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatalf("Failed to write hook %s: %v", hook, err)
+		}
+	}
+	return r, runner
+}
+
+// commandNames returns the program names of the issued commands.
+func commandNames(commands []command.Cmd) []string {
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.Name)
+	}
+	return names
+}
+
+func TestDeployAppRunsHooksAroundComposeUp(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{}, preDeployHook, postDeployHook)
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	commands := runner.Commands()
+	if len(commands) != 3 {
+		t.Fatalf("Expected pre hook, compose up and post hook, got %v", commandNames(commands))
+	}
+	appDir := r.getAppDir("app")
+	pre, up, post := commands[0], commands[1], commands[2]
+	if pre.Name != "sh" || filepath.Base(pre.Args[0]) != preDeployHook || pre.Dir != appDir {
+		t.Errorf("Unexpected pre-deploy invocation: %+v", pre)
+	}
+	if up.Name != "docker" {
+		t.Errorf("Expected compose up between hooks, got %+v", up)
+	}
+	if post.Name != "sh" || filepath.Base(post.Args[0]) != postDeployHook {
+		t.Errorf("Unexpected post-deploy invocation: %+v", post)
+	}
+	if pre.Timeout != 5*time.Minute {
+		t.Errorf("Expected default hook timeout, got %v", pre.Timeout)
+	}
+
+	found := false
+	for _, entry := range pre.Env {
+		found = found || entry == "DB_USER=admin"
+	}
+	if !found {
+		t.Errorf("Expected app variables in hook environment, got %v", pre.Env)
+	}
+}
+
+func TestDeployAppFailsOnPreDeployHookError(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{}, preDeployHook)
+	runner.Results = []command.FakeResult{{Err: errors.New("exit status 1")}}
+
+	if err := r.DeployApp("app"); err == nil {
+		t.Fatal("Expected pre-deploy hook failure to fail the deployment")
+	}
+	if names := commandNames(runner.Commands()); len(names) != 1 {
+		t.Errorf("Expected compose up to be skipped, got %v", names)
+	}
+}
+
+func TestDeployAppPostDeployHookFailure(t *testing.T) {
+	failPost := []command.FakeResult{{}, {Err: errors.New("exit status 1")}}
+
+	r, runner := newHookRepository(t, &config.Config{}, postDeployHook)
+	runner.Results = failPost
+	if err := r.DeployApp("app"); err != nil {
+		t.Errorf("Expected post-deploy hook failure to be tolerated, got %v", err)
+	}
+
+	r, runner = newHookRepository(t, &config.Config{FailOnPostDeployHookError: true}, postDeployHook)
+	runner.Results = failPost
+	if err := r.DeployApp("app"); err == nil {
+		t.Error("Expected post-deploy hook failure to fail the deployment when configured")
+	}
+}
+
+func TestDeployAppWithoutHooks(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	if names := commandNames(runner.Commands()); len(names) != 1 || names[0] != "docker" {
+		t.Errorf("Expected only compose up, got %v", names)
+	}
+}
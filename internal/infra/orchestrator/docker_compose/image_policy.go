@@ -0,0 +1,95 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// dockerHubRegistry is the name of Docker Hub in the allowed registries.
+const dockerHubRegistry = "docker.io"
+
+// imageRegistry returns the registry host of an image reference: its domain, or docker.io for Docker Hub
+// images such as "nginx" or "org/app:1.0".
+func imageRegistry(image string) string {
+	if domain, _, found := strings.Cut(image, "/"); found && isRegistryDomain(domain) {
+		if dockerHubDomains[strings.ToLower(domain)] {
+			return dockerHubRegistry
+		}
+		return strings.ToLower(domain)
+	}
+	return dockerHubRegistry
+}
+
+// normalizeRegistry returns the registry host of an allowed_registries entry, accepting a URL scheme, a
+// trailing slash and the alternative domains of Docker Hub.
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://"), "/")
+	registry = strings.ToLower(registry)
+	if dockerHubDomains[registry] {
+		return dockerHubRegistry
+	}
+	return registry
+}
+
+// validateImageRegistries checks that every service image of the rendered app in appDir is pulled from a
+// registry of the configured allow-list, and returns an error naming the first offending image otherwise.
+// Images holding variables interpolated by compose cannot be checked and are rejected. The images of the
+// registry mirror override are not checked, as they mirror the checked Docker Hub images.
+func (r *composeRepository) validateImageRegistries(appDir string) error {
+	if r.config == nil || len(r.config.AllowedRegistries) == 0 {
+		return nil
+	}
+	allowed := make(map[string]struct{}, len(r.config.AllowedRegistries))
+	for _, registry := range r.config.AllowedRegistries {
+		allowed[normalizeRegistry(registry)] = struct{}{}
+	}
+
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+	images := make(map[string]string)
+	for _, file := range files {
+		if filepath.Base(file) == mirrorOverrideFile {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name, raw := range definitions {
+			definition, _ := raw.(map[string]interface{})
+			if image, ok := definition["image"].(string); ok && image != "" {
+				images[name] = image
+			}
+		}
+	}
+
+	services := make([]string, 0, len(images))
+	for service := range images {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		image := images[service]
+		if strings.Contains(image, "$") {
+			return fmt.Errorf("image %q of service %s holds a variable, its registry cannot be checked against the allowed registries", image, service)
+		}
+		registry := imageRegistry(image)
+		if _, ok := allowed[registry]; !ok {
+			return fmt.Errorf("image %q of service %s is pulled from registry %s, which is not in the allowed registries", image, service, registry)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// newImagePolicyDir returns an app directory whose compose.yml runs image in the service web.
+func newImagePolicyDir(t *testing.T, image string) string {
+	t.Helper()
+	appDir := t.TempDir()
+	compose := "services:\n  web:\n    image: " + image + "\n  worker:\n    build: .\n"
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(compose), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+	return appDir
+}
+
+func TestImageRegistry(t *testing.T) {
+	testCases := map[string]string{
+		"nginx":                               "docker.io",
+		"nginx:1.27":                          "docker.io",
+		"library/nginx@sha256:abc":            "docker.io",
+		"org/app:1.0":                         "docker.io",
+		"docker.io/org/app":                   "docker.io",
+		"index.docker.io/library/nginx":       "docker.io",
+		"ghcr.io/org/app:1.0":                 "ghcr.io",
+		"registry.example.com:5000/team/app":  "registry.example.com:5000",
+		"localhost/app":                       "localhost",
+		"Registry.Example.com/team/app:1.0.0": "registry.example.com",
+	}
+	for image, expected := range testCases {
+		if got := imageRegistry(image); got != expected {
+			t.Errorf("imageRegistry(%q) = %q, expected %q", image, got, expected)
+		}
+	}
+}
+
+func TestValidateImageRegistriesAllowsListedRegistries(t *testing.T) {
+	testCases := map[string]struct {
+		allowed []string
+		image   string
+	}{
+		"Any registry without allow-list": {image: "quay.io/org/app"},
+		"Library image":                   {allowed: []string{"docker.io"}, image: "nginx:1.27"},
+		"Docker Hub alias":                {allowed: []string{"index.docker.io"}, image: "org/app"},
+		"Registry with port":              {allowed: []string{"registry.example.com:5000"}, image: "registry.example.com:5000/team/app:1.0"},
+		"Registry URL":                    {allowed: []string{"https://ghcr.io/"}, image: "ghcr.io/org/app"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &composeRepository{config: &config.Config{AllowedRegistries: tc.allowed}}
+			if err := r.validateImageRegistries(newImagePolicyDir(t, tc.image)); err != nil {
+				t.Errorf("Expected the image to be allowed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateImageRegistriesRejectsOtherRegistries(t *testing.T) {
+	testCases := map[string]struct {
+		allowed []string
+		image   string
+	}{
+		"Library image":         {allowed: []string{"ghcr.io"}, image: "nginx"},
+		"Other registry":        {allowed: []string{"docker.io", "ghcr.io"}, image: "quay.io/org/app:1.0"},
+		"Other port":            {allowed: []string{"registry.example.com:5000"}, image: "registry.example.com/team/app"},
+		"Unresolved variable":   {allowed: []string{"ghcr.io"}, image: "${REGISTRY}/org/app"},
+		"Namespace not a host":  {allowed: []string{"org"}, image: "org/app"},
+		"Subdomain not allowed": {allowed: []string{"example.com"}, image: "registry.example.com/app"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &composeRepository{config: &config.Config{AllowedRegistries: tc.allowed}}
+			err := r.validateImageRegistries(newImagePolicyDir(t, tc.image))
+			if err == nil || !strings.Contains(err.Error(), tc.image) {
+				t.Errorf("Expected an error naming %q, got %v", tc.image, err)
+			}
+		})
+	}
+}
+
+func TestValidateImageRegistriesIgnoresMirrorOverride(t *testing.T) {
+	appDir := newImagePolicyDir(t, "nginx")
+	r := &composeRepository{config: &config.Config{RegistryMirror: "mirror.example.com", AllowedRegistries: []string{"docker.io"}}}
+	if err := r.writeRegistryMirrorOverride(appDir); err != nil {
+		t.Fatalf("writeRegistryMirrorOverride returned error: %v", err)
+	}
+
+	if err := r.validateImageRegistries(appDir); err != nil {
+		t.Errorf("Expected mirrored Docker Hub images to be allowed, got %v", err)
+	}
+}
+
+func TestDeployAppRejectsDisallowedImages(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{AllowedRegistries: []string{"ghcr.io"}})
+	writeEnvFileTemplate(t, r, "services:\n  db:\n    image: postgres:16\n", map[string]string{})
+
+	err := r.DeployApp("app")
+	if err == nil || !strings.Contains(err.Error(), `"postgres:16"`) {
+		t.Fatalf("Expected the deployment to be rejected, got %v", err)
+	}
+	for _, cmd := range runner.Commands() {
+		if slices.Contains(cmd.Args, "up") {
+			t.Errorf("Expected the containers not to be started, got %s %v", cmd.Name, cmd.Args)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// labelsOverrideFile is the compose file generated into a rendered app to attach the agent's labels to
+	// every service.
+	labelsOverrideFile = "compose.winterflow-labels.yml"
+	// managedLabel marks containers of apps deployed by the agent, so that status queries ignore compose
+	// projects that are not managed by it.
+	managedLabel = "io.winterflow.managed"
+	// appIDLabel and revisionLabel correlate containers with the app and revision they were deployed from.
+	appIDLabel    = "io.winterflow.app_id"
+	revisionLabel = "io.winterflow.revision"
+)
+
+// appLabels returns the labels attached to every service of the app appID deployed from revision. The
+// revision label is left out when the revision is unknown.
+func appLabels(appID string, revision uint32) map[string]string {
+	labels := map[string]string{managedLabel: "true"}
+	if appID != "" {
+		labels[appIDLabel] = appID
+	}
+	if revision > 0 {
+		labels[revisionLabel] = strconv.FormatUint(uint64(revision), 10)
+	}
+	return labels
+}
+
+// templateRevision returns the revision of the revision directory templateDir, or 0 when its name is not
+// a revision number.
+func templateRevision(templateDir string) uint32 {
+	revision, err := strconv.ParseUint(filepath.Base(templateDir), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(revision)
+}
+
+// containerRevision returns the revision recorded in the labels of a deployed container, or 0 when the
+// container carries no valid revision label.
+func containerRevision(labels map[string]string) uint32 {
+	revision, err := strconv.ParseUint(labels[revisionLabel], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(revision)
+}
+
+// writeLabelsOverride generates labelsOverrideFile in destDir, adding labels to every service defined by
+// the rendered compose files. Compose merges labels, so labels declared by the app itself are preserved.
+func (r *composeRepository) writeLabelsOverride(destDir string, labels map[string]string) error {
+	overridePath := filepath.Join(destDir, labelsOverrideFile)
+	if err := os.Remove(overridePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", labelsOverrideFile, err)
+	}
+
+	services, err := r.composeServices(destDir)
+	if err != nil || len(services) == 0 {
+		return err
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("# Generated by the WinterFlow agent to label the containers of every service.\nservices:\n")
+	for _, name := range services {
+		fmt.Fprintf(&b, "  %s:\n    labels:\n", strconv.Quote(name))
+		for _, key := range keys {
+			fmt.Fprintf(&b, "      %s: %s\n", strconv.Quote(key), strconv.Quote(labels[key]))
+		}
+	}
+	if err := os.WriteFile(overridePath, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", labelsOverrideFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/yaml"
+)
+
+// readServiceLabels returns the labels per service of the generated labels override file.
+func readServiceLabels(t *testing.T, appDir string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(appDir, labelsOverrideFile))
+	if err != nil {
+		t.Fatalf("Failed to read labels override: %v", err)
+	}
+	doc, err := yaml.UnmarshalMap(data)
+	if err != nil {
+		t.Fatalf("Generated override is not valid YAML: %v", err)
+	}
+	labels := make(map[string]interface{})
+	for name, definition := range doc["services"].(map[string]interface{}) {
+		labels[name] = definition.(map[string]interface{})["labels"]
+	}
+	return labels
+}
+
+func TestRenderRedactedAppLabelsEveryService(t *testing.T) {
+	templateDir := filepath.Join(t.TempDir(), "app", "3")
+	destDir := t.TempDir()
+	writeRevision(t, templateDir)
+
+	r := &composeRepository{}
+	if err := r.renderRedactedApp(templateDir, destDir); err != nil {
+		t.Fatalf("renderRedactedApp returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{"db": map[string]interface{}{
+		managedLabel:  "true",
+		appIDLabel:    "app",
+		revisionLabel: "3",
+	}}
+	if got := readServiceLabels(t, destDir); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	files, err := r.detectComposeFiles(destDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+	if len(files) != 2 || filepath.Base(files[1]) != labelsOverrideFile {
+		t.Errorf("Expected the labels override after compose.yml, got %v", files)
+	}
+}
+
+// newFakeDockerClient returns a Docker client served by a fake daemon listing containers, which applies
+// the label filters of the request like the Docker daemon does.
+func newFakeDockerClient(t *testing.T, containers []container.Summary) *client.Client {
+	t.Helper()
+	return newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/containers/json") {
+			http.NotFound(w, req)
+			return
+		}
+		serveContainerList(w, req, containers)
+	})
+}
+
+// serveContainerList answers a container list request with the containers matching its label filters.
+func serveContainerList(w http.ResponseWriter, req *http.Request, containers []container.Summary) {
+	args, err := filters.FromJSON(req.URL.Query().Get("filters"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	matched := []container.Summary{}
+	for _, c := range containers {
+		if args.MatchKVList("label", c.Labels) {
+			matched = append(matched, c)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(matched)
+}
+
+// newFakeDockerClientWithHandler returns a Docker client whose daemon requests are answered by handler.
+func newFakeDockerClientWithHandler(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	dockerClient, err := client.NewClientWithOpts(client.WithHost(server.URL), client.WithVersion("1.45"))
+	if err != nil {
+		t.Fatalf("Failed to create docker client: %v", err)
+	}
+	return dockerClient
+}
+
+func TestGetAppStatusIgnoresUnmanagedContainers(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), "app", "1"))
+
+	project := "com.docker.compose.project"
+	dockerClient := newFakeDockerClient(t, []container.Summary{
+		{ID: "managed", Names: []string{"/demo-db-1"}, State: "running", Labels: map[string]string{project: "demo", managedLabel: "true", revisionLabel: "1"}},
+		{ID: "unmanaged", Names: []string{"/demo-web-1"}, State: "exited", Labels: map[string]string{project: "demo"}},
+		{ID: "other", Names: []string{"/other-db-1"}, State: "running", Labels: map[string]string{project: "other", managedLabel: "true"}},
+	})
+	r := &composeRepository{client: dockerClient, config: cfg}
+
+	result, err := r.GetAppStatus("app")
+	if err != nil {
+		t.Fatalf("GetAppStatus returned error: %v", err)
+	}
+
+	var ids []string
+	for _, c := range result.App.Containers {
+		ids = append(ids, c.ID)
+	}
+	sort.Strings(ids)
+	if !reflect.DeepEqual(ids, []string{"managed"}) {
+		t.Errorf("Expected only the managed container, got %v", ids)
+	}
+	if result.App.Revision != 1 {
+		t.Errorf("Expected revision 1 from the container labels, got %d", result.App.Revision)
+	}
+	if result.App.StatusCode != model.ContainerStatusActive {
+		t.Errorf("Expected active status, got %v", result.App.StatusCode)
+	}
+}
+
+func TestGetAppStatusReportsNewestDeployedRevision(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), "app", "1"))
+
+	labels := func(revision string) map[string]string {
+		return map[string]string{"com.docker.compose.project": "demo", managedLabel: "true", appIDLabel: "app", revisionLabel: revision}
+	}
+	dockerClient := newFakeDockerClient(t, []container.Summary{
+		{ID: "old", Names: []string{"/demo-db-1"}, State: "running", Labels: labels("4")},
+		{ID: "new", Names: []string{"/demo-web-1"}, State: "running", Labels: labels("5")},
+	})
+	r := &composeRepository{client: dockerClient, config: cfg}
+
+	result, err := r.GetAppStatus("app")
+	if err != nil {
+		t.Fatalf("GetAppStatus returned error: %v", err)
+	}
+	if result.App.Revision != 5 {
+		t.Errorf("Expected revision 5, got %d", result.App.Revision)
+	}
+}
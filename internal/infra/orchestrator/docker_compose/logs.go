@@ -18,6 +18,10 @@ import (
 	"github.com/docker/docker/api/types/filters"
 )
 
+// shortContainerIDLength is the length of the short container IDs shown by Docker; shorter ID prefixes are not
+// accepted to select a container.
+const shortContainerIDLength = 12
+
 // Precompiled regexp that matches ANSI escape sequences (e.g. \x1b[31m).
 var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
 
@@ -52,7 +56,7 @@ func stripDockerHeader(s string) string {
 	return s
 }
 
-func (r *composeRepository) GetLogs(appID string, since int64, until int64, tail int32) (model.Logs, error) {
+func (r *composeRepository) GetLogs(ctx context.Context, appID string, since int64, until int64, tail int32, filter model.LogFilter) (model.Logs, error) {
 	// Prepare the result struct so we can populate it incrementally.
 	res := model.Logs{
 		Logs:       make([]model.LogEntry, 0),
@@ -65,16 +69,21 @@ func (r *composeRepository) GetLogs(appID string, since int64, until int64, tail
 		return res, fmt.Errorf("cannot get logs: %w", err)
 	}
 
-	ctx := context.Background()
-
 	// Locate containers that belong to the compose project by label.
 	filterArgs := filters.NewArgs()
 	filterArgs.Add("label", fmt.Sprintf("com.docker.compose.project=%s", appName))
 
-	containers, err := r.client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	containers, err := r.listContainers(ctx, container.ListOptions{All: true, Filters: filterArgs})
 	if err != nil {
 		return res, fmt.Errorf("failed to list containers for app %s: %w", appID, err)
 	}
+	if filter.ContainerID != "" {
+		selected, ok := findContainer(containers, filter.ContainerID)
+		if !ok {
+			return res, fmt.Errorf("%w: %s is not a container of app %s", model.ErrContainerNotInApp, filter.ContainerID, appID)
+		}
+		containers = []container.Summary{selected}
+	}
 
 	// Convert unix timestamps (in seconds) to strings understood by the Docker API.
 	sinceStr := ""
@@ -110,7 +119,7 @@ func (r *composeRepository) GetLogs(appID string, since int64, until int64, tail
 			{stdout: true, stderr: false, channel: model.LogChannelStdout},
 			{stdout: false, stderr: true, channel: model.LogChannelStderr},
 		} {
-			logsReader, err := r.client.ContainerLogs(ctx, c.ID, container.LogsOptions{
+			logsReader, err := r.GetClient().ContainerLogs(ctx, c.ID, container.LogsOptions{
 				ShowStdout: ch.stdout,
 				ShowStderr: ch.stderr,
 				Timestamps: true,
@@ -187,6 +196,9 @@ func (r *composeRepository) GetLogs(appID string, since int64, until int64, tail
 					Data:        dataMap,
 					ContainerID: c.ID,
 				}
+				if !filter.Matches(entry) {
+					continue
+				}
 				res.Logs = append(res.Logs, entry)
 			}
 			// Intentionally ignore scanner error – in most cases incomplete logs are acceptable.
@@ -197,6 +209,21 @@ func (r *composeRepository) GetLogs(appID string, since int64, until int64, tail
 	return res, nil
 }
 
+// findContainer returns the container of containers with the ID, short ID or name ref.
+func findContainer(containers []container.Summary, ref string) (container.Summary, bool) {
+	for _, c := range containers {
+		if c.ID == ref || (len(ref) >= shortContainerIDLength && strings.HasPrefix(c.ID, ref)) {
+			return c, true
+		}
+		for _, name := range c.Names {
+			if strings.TrimPrefix(name, "/") == ref {
+				return c, true
+			}
+		}
+	}
+	return container.Summary{}, false
+}
+
 // detectLogLevel performs a best-effort detection of the log level based on
 // common textual prefixes. If no known prefix is found it returns
 // LogLevelUnknown.
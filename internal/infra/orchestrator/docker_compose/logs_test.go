@@ -0,0 +1,172 @@
+package docker_compose
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// logsFixture is the stdout of the container served by newLogsRepository.
+var logsFixture = []string{
+	"INFO server started",
+	"DEBUG connection accepted from 10.0.0.2",
+	`{"level":"error","message":"connection refused by db"}`,
+	"WARN slow query on orders",
+	"plain line without level",
+}
+
+// newLogsRepository returns a repository of the app "app" whose only container writes logsFixture to stdout.
+func newLogsRepository(t *testing.T) *composeRepository {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), "app", "1"))
+
+	containers := []container.Summary{{ID: "c1", Names: []string{"/demo-db-1"}, State: "running", Labels: map[string]string{"com.docker.compose.project": "demo"}}}
+	dockerClient := newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/containers/json"):
+			serveContainerList(w, req, containers)
+		case strings.HasSuffix(req.URL.Path, "/containers/c1/logs"):
+			if req.URL.Query().Get("stdout") != "1" {
+				return
+			}
+			for i, line := range logsFixture {
+				fmt.Fprintf(w, "2025-01-01T00:00:0%dZ %s\n", i, line)
+			}
+		default:
+			http.NotFound(w, req)
+		}
+	})
+	return &composeRepository{client: dockerClient, config: cfg}
+}
+
+// logMessages returns the messages of the log entries.
+func logMessages(logs model.Logs) []string {
+	messages := make([]string, 0, len(logs.Logs))
+	for _, entry := range logs.Logs {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+func TestGetLogsFilters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   model.LogFilter
+		expected []string
+	}{
+		{
+			name:     "No filter",
+			expected: []string{"INFO server started", "DEBUG connection accepted from 10.0.0.2", "connection refused by db", "WARN slow query on orders", "plain line without level"},
+		},
+		{
+			name:     "Minimum level",
+			filter:   model.LogFilter{MinLevel: model.LogLevelWarn},
+			expected: []string{"connection refused by db", "WARN slow query on orders"},
+		},
+		{
+			name:     "Pattern",
+			filter:   model.LogFilter{Pattern: regexp.MustCompile(`connection (accepted|refused)`)},
+			expected: []string{"DEBUG connection accepted from 10.0.0.2", "connection refused by db"},
+		},
+		{
+			name:     "Level and pattern",
+			filter:   model.LogFilter{MinLevel: model.LogLevelInfo, Pattern: regexp.MustCompile(`^connection`)},
+			expected: []string{"connection refused by db"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newLogsRepository(t)
+
+			logs, err := r.GetLogs(t.Context(), "app", 0, 0, 0, tc.filter)
+			if err != nil {
+				t.Fatalf("GetLogs returned error: %v", err)
+			}
+			if got := logMessages(logs); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+			if len(logs.Containers) != 1 {
+				t.Errorf("Expected the container to be reported, got %v", logs.Containers)
+			}
+		})
+	}
+}
+
+// newMultiContainerLogsRepository returns a repository of the app "app" with a db and a web container, each
+// writing its name to stdout, next to a container of another compose project.
+func newMultiContainerLogsRepository(t *testing.T) *composeRepository {
+	t.Helper()
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), "app", "1"))
+
+	dbID := strings.Repeat("a", 64)
+	webID := strings.Repeat("b", 64)
+	otherID := strings.Repeat("c", 64)
+	containers := []container.Summary{
+		{ID: dbID, Names: []string{"/demo-db-1"}, State: "running", Labels: map[string]string{"com.docker.compose.project": "demo"}},
+		{ID: webID, Names: []string{"/demo-web-1"}, State: "running", Labels: map[string]string{"com.docker.compose.project": "demo"}},
+		{ID: otherID, Names: []string{"/other-web-1"}, State: "running", Labels: map[string]string{"com.docker.compose.project": "other"}},
+	}
+	dockerClient := newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/containers/json") {
+			serveContainerList(w, req, containers)
+			return
+		}
+		for _, c := range containers {
+			if strings.HasSuffix(req.URL.Path, "/containers/"+c.ID+"/logs") {
+				if req.URL.Query().Get("stdout") == "1" {
+					fmt.Fprintf(w, "2025-01-01T00:00:00Z %s\n", strings.TrimPrefix(c.Names[0], "/"))
+				}
+				return
+			}
+		}
+		http.NotFound(w, req)
+	})
+	return &composeRepository{client: dockerClient, config: cfg}
+}
+
+func TestGetLogsOfSingleContainer(t *testing.T) {
+	for _, ref := range []string{strings.Repeat("b", 64), strings.Repeat("b", 12), "demo-web-1"} {
+		t.Run(ref, func(t *testing.T) {
+			r := newMultiContainerLogsRepository(t)
+
+			logs, err := r.GetLogs(t.Context(), "app", 0, 0, 0, model.LogFilter{ContainerID: ref})
+			if err != nil {
+				t.Fatalf("GetLogs returned error: %v", err)
+			}
+			if got := logMessages(logs); !reflect.DeepEqual(got, []string{"demo-web-1"}) {
+				t.Errorf("Expected only the logs of the web container, got %q", got)
+			}
+			if len(logs.Containers) != 1 || logs.Containers[0].Name != "demo-web-1" {
+				t.Errorf("Expected only the web container to be reported, got %v", logs.Containers)
+			}
+		})
+	}
+}
+
+func TestGetLogsRejectsContainerOfAnotherApp(t *testing.T) {
+	for _, ref := range []string{strings.Repeat("c", 64), "other-web-1", strings.Repeat("b", 4), "missing"} {
+		t.Run(ref, func(t *testing.T) {
+			r := newMultiContainerLogsRepository(t)
+
+			logs, err := r.GetLogs(t.Context(), "app", 0, 0, 0, model.LogFilter{ContainerID: ref})
+			if !errors.Is(err, model.ErrContainerNotInApp) {
+				t.Fatalf("Expected ErrContainerNotInApp, got %v", err)
+			}
+			if len(logs.Logs) != 0 {
+				t.Errorf("Expected no logs to be read, got %q", logMessages(logs))
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// renderedComposeFiles returns the compose files of the rendered app in appDir in the order compose
+// applies them.
+func (r *composeRepository) renderedComposeFiles(appDir string) ([]string, error) {
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		return files, nil
+	}
+	// Without extension files compose picks the base file itself, preferring compose.yml.
+	for _, name := range []string{"compose.yml", "docker-compose.yml"} {
+		if path := filepath.Join(appDir, name); fileExists(path) {
+			return []string{path}, nil
+		}
+	}
+	return nil, fmt.Errorf("neither docker-compose.yml nor compose.yml found in %s", appDir)
+}
+
+// externalNetworks returns the names of the external networks declared in the top-level networks section
+// of a parsed compose document. Networks managed by the project itself are not returned as compose
+// creates them on `up`.
+func externalNetworks(doc map[string]interface{}) []string {
+	networks, _ := doc["networks"].(map[string]interface{})
+	var names []string
+	for key, raw := range networks {
+		definition, _ := raw.(map[string]interface{})
+		name := key
+		switch external := definition["external"].(type) {
+		case bool:
+			if !external {
+				continue
+			}
+		case map[string]interface{}:
+			// Legacy syntax: external: {name: actual-name}
+			if legacyName, ok := external["name"].(string); ok && legacyName != "" {
+				name = legacyName
+			}
+		default:
+			continue
+		}
+		if explicitName, ok := definition["name"].(string); ok && explicitName != "" {
+			name = explicitName
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// validateNetworks checks that the rendered app in appDir only references external networks that are
+// allowed by the configured allow-list and exist on the host. It returns an error naming the offending
+// network otherwise.
+func (r *composeRepository) validateNetworks(appDir string) error {
+	files, err := r.renderedComposeFiles(appDir)
+	if err != nil {
+		return err
+	}
+
+	referenced := make(map[string]struct{})
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		for _, name := range externalNetworks(doc) {
+			referenced[name] = struct{}{}
+		}
+	}
+	if len(referenced) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(referenced))
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if allowed := r.config.AllowedNetworks; len(allowed) > 0 {
+		allowSet := make(map[string]struct{}, len(allowed))
+		for _, name := range allowed {
+			allowSet[name] = struct{}{}
+		}
+		for _, name := range names {
+			if _, ok := allowSet[name]; !ok {
+				return fmt.Errorf("external network %q is not in the allowed networks", name)
+			}
+		}
+	}
+
+	if r.networks == nil {
+		return nil
+	}
+	existing, err := r.networks.GetNetworks()
+	if err != nil {
+		return fmt.Errorf("failed to list docker networks: %w", err)
+	}
+	existingSet := make(map[string]struct{}, len(existing))
+	for _, network := range existing {
+		existingSet[network.Name] = struct{}{}
+	}
+	for _, name := range names {
+		if _, ok := existingSet[name]; !ok {
+			return fmt.Errorf("external network %q does not exist", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+)
+
+// fakeNetworkRepository reports a fixed set of existing docker networks.
+type fakeNetworkRepository struct {
+	names []string
+}
+
+func (f *fakeNetworkRepository) GetNetworks() ([]model.Network, error) {
+	networks := make([]model.Network, 0, len(f.names))
+	for _, name := range f.names {
+		networks = append(networks, model.Network{Name: name})
+	}
+	return networks, nil
+}
+
+func (f *fakeNetworkRepository) CreateNetwork(model.Network) error { return nil }
+
+func (f *fakeNetworkRepository) DeleteNetwork(string) error { return nil }
+
+const networkPolicyCompose = `services:
+  web:
+    image: nginx
+    networks: [frontend, proxy, legacy]
+networks:
+  frontend: {}
+  proxy:
+    external: true
+    name: traefik
+  legacy:
+    external:
+      name: shared
+`
+
+// newNetworkPolicyRepository returns a repository with the given allow-list and existing networks and an
+// app directory holding networkPolicyCompose.
+func newNetworkPolicyRepository(t *testing.T, allowed []string, existing ...string) (*composeRepository, string) {
+	t.Helper()
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(networkPolicyCompose), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+	r := &composeRepository{
+		config:   &config.Config{AllowedNetworks: allowed},
+		networks: &fakeNetworkRepository{names: existing},
+	}
+	return r, appDir
+}
+
+func TestValidateNetworksAllowsExistingExternalNetworks(t *testing.T) {
+	r, appDir := newNetworkPolicyRepository(t, []string{"traefik", "shared"}, "traefik", "shared", "bridge")
+	if err := r.validateNetworks(appDir); err != nil {
+		t.Errorf("Expected networks to be allowed, got %v", err)
+	}
+}
+
+func TestValidateNetworksRejectsMissingNetwork(t *testing.T) {
+	r, appDir := newNetworkPolicyRepository(t, nil, "traefik")
+	err := r.validateNetworks(appDir)
+	if err == nil || !strings.Contains(err.Error(), `"shared" does not exist`) {
+		t.Errorf("Expected missing network error, got %v", err)
+	}
+}
+
+func TestValidateNetworksRejectsForbiddenNetwork(t *testing.T) {
+	r, appDir := newNetworkPolicyRepository(t, []string{"traefik"}, "traefik", "shared")
+	err := r.validateNetworks(appDir)
+	if err == nil || !strings.Contains(err.Error(), `"shared" is not in the allowed networks`) {
+		t.Errorf("Expected forbidden network error, got %v", err)
+	}
+}
+
+func TestValidateNetworksIgnoresProjectNetworks(t *testing.T) {
+	appDir := t.TempDir()
+	compose := "services:\n  web:\n    image: nginx\nnetworks:\n  backend:\n    driver: bridge\n    name: custom\n"
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(compose), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+	r := &composeRepository{
+		config:   &config.Config{AllowedNetworks: []string{"traefik"}},
+		networks: &fakeNetworkRepository{},
+	}
+	if err := r.validateNetworks(appDir); err != nil {
+		t.Errorf("Expected project networks to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package docker_compose
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// operationDurationBuckets are the upper bounds of the buckets of the operation duration histograms.
+var operationDurationBuckets = []time.Duration{
+	time.Second, 5 * time.Second, 10 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute,
+}
+
+// operationKey identifies the metrics of an operation of an app.
+type operationKey struct {
+	appID     string
+	operation model.AppOperation
+}
+
+// operationStats accumulates the runs of an operation of an app.
+type operationStats struct {
+	successes uint64
+	failures  uint64
+	// buckets counts the runs per bucket of operationDurationBuckets, not cumulatively.
+	buckets []uint64
+	sum     time.Duration
+}
+
+// operationMetrics holds the metrics of the lifecycle operations run per app. The zero value is ready to use.
+type operationMetrics struct {
+	mu    sync.Mutex
+	stats map[operationKey]*operationStats
+}
+
+// observe records a run of operation on the app that started at start and ended with *err. It is deferred
+// by the operations with a pointer to their named error result, so that the outcome is read on return.
+func (m *operationMetrics) observe(appID string, operation model.AppOperation, start time.Time, err *error) {
+	duration := time.Since(start)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stats == nil {
+		m.stats = make(map[operationKey]*operationStats)
+	}
+	key := operationKey{appID: appID, operation: operation}
+	stats, ok := m.stats[key]
+	if !ok {
+		stats = &operationStats{buckets: make([]uint64, len(operationDurationBuckets))}
+		m.stats[key] = stats
+	}
+
+	if *err != nil {
+		stats.failures++
+	} else {
+		stats.successes++
+	}
+	stats.sum += duration
+	if i := sort.Search(len(operationDurationBuckets), func(i int) bool { return duration <= operationDurationBuckets[i] }); i < len(stats.buckets) {
+		stats.buckets[i]++
+	}
+}
+
+// snapshot returns the metrics recorded for the app, or for every app when appID is empty, ordered by app ID
+// and operation.
+func (m *operationMetrics) snapshot(appID string) []model.AppOperationMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := make([]model.AppOperationMetrics, 0, len(m.stats))
+	for key, stats := range m.stats {
+		if appID != "" && key.appID != appID {
+			continue
+		}
+		buckets := make([]model.DurationBucket, len(operationDurationBuckets))
+		var cumulative uint64
+		for i, bound := range operationDurationBuckets {
+			cumulative += stats.buckets[i]
+			buckets[i] = model.DurationBucket{UpperBound: bound, Count: cumulative}
+		}
+		metrics = append(metrics, model.AppOperationMetrics{
+			AppID:           key.appID,
+			Operation:       key.operation,
+			Successes:       stats.successes,
+			Failures:        stats.failures,
+			DurationBuckets: buckets,
+			DurationSum:     stats.sum,
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].AppID != metrics[j].AppID {
+			return metrics[i].AppID < metrics[j].AppID
+		}
+		return metrics[i].Operation < metrics[j].Operation
+	})
+	return metrics
+}
+
+// AppOperationMetrics returns the success and failure counts and the duration histograms of the lifecycle
+// operations run on the app, or on every app when appID is empty, since the agent started.
+func (r *composeRepository) AppOperationMetrics(appID string) []model.AppOperationMetrics {
+	return r.metrics.snapshot(appID)
+}
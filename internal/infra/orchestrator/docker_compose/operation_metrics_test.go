@@ -0,0 +1,90 @@
+package docker_compose
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/command"
+)
+
+func TestDeployAppRecordsSuccessAndDuration(t *testing.T) {
+	r, _ := newHookRepository(t, &config.Config{})
+
+	start := time.Now()
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	metrics := r.AppOperationMetrics("app")
+	if len(metrics) != 1 {
+		t.Fatalf("Expected the metrics of a single operation, got %+v", metrics)
+	}
+	deploy := metrics[0]
+	if deploy.AppID != "app" || deploy.Operation != model.AppOperationDeploy || deploy.Successes != 1 || deploy.Failures != 0 {
+		t.Errorf("Expected a successful deploy of app, got %+v", deploy)
+	}
+	if deploy.DurationSum <= 0 || deploy.DurationSum > elapsed {
+		t.Errorf("Expected a duration of at most %s, got %s", elapsed, deploy.DurationSum)
+	}
+	if len(deploy.DurationBuckets) != len(operationDurationBuckets) {
+		t.Fatalf("Expected a bucket per bound, got %+v", deploy.DurationBuckets)
+	}
+	for _, bucket := range deploy.DurationBuckets {
+		if bucket.Count != 1 {
+			t.Errorf("Expected the deploy in bucket %s, got %d", bucket.UpperBound, bucket.Count)
+		}
+	}
+}
+
+func TestDeployAppRecordsFailure(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	runner.Results = []command.FakeResult{{Err: errors.New("exit status 1")}}
+
+	if err := r.DeployApp("app"); err == nil {
+		t.Fatal("Expected DeployApp to fail")
+	}
+
+	metrics := r.AppOperationMetrics("app")
+	if len(metrics) != 1 || metrics[0].Successes != 0 || metrics[0].Failures != 1 {
+		t.Errorf("Expected a failed deploy, got %+v", metrics)
+	}
+}
+
+func TestOperationMetricsAreKeyedByAppAndOperation(t *testing.T) {
+	var m operationMetrics
+	var ok, failed error = nil, errors.New("failed")
+	m.observe("web", model.AppOperationUpdate, time.Now().Add(-2*time.Second), &ok)
+	m.observe("web", model.AppOperationUpdate, time.Now().Add(-time.Hour), &failed)
+	m.observe("db", model.AppOperationStop, time.Now(), &ok)
+	m.observe("web", model.AppOperationDeploy, time.Now(), &ok)
+
+	all := m.snapshot("")
+	var keys []string
+	for _, metrics := range all {
+		keys = append(keys, metrics.AppID+"/"+string(metrics.Operation))
+	}
+	if want := "db/stop web/deploy web/update"; strings.Join(keys, " ") != want {
+		t.Fatalf("Expected metrics %s, got %s", want, strings.Join(keys, " "))
+	}
+
+	update := all[2]
+	if update.Successes != 1 || update.Failures != 1 || update.DurationSum < time.Hour {
+		t.Errorf("Unexpected update metrics %+v", update)
+	}
+	counts := map[time.Duration]uint64{}
+	for _, bucket := range update.DurationBuckets {
+		counts[bucket.UpperBound] = bucket.Count
+	}
+	if counts[time.Second] != 0 || counts[5*time.Second] != 1 || counts[30*time.Minute] != 1 {
+		t.Errorf("Expected the hour long update beyond the last bucket, got %v", counts)
+	}
+
+	if web := m.snapshot("web"); len(web) != 2 {
+		t.Errorf("Expected the metrics of web only, got %+v", web)
+	}
+}
@@ -3,13 +3,22 @@ package docker_compose
 import (
 	"fmt"
 	"os"
+	"time"
 	"winterflow-agent/internal/domain/model"
 	appsvc "winterflow-agent/internal/domain/service/app"
 	"winterflow-agent/pkg/log"
 )
 
 // DeployApp renders templates for the given revision of an application and starts the containers.
-func (r *composeRepository) DeployApp(appID string) error {
+func (r *composeRepository) DeployApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationDeploy, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+	return r.deployApp(appID)
+}
+
+// deployApp implements DeployApp within an operation that has already begun.
+func (r *composeRepository) deployApp(appID string) error {
 	// Ensure the base applications directory exists before proceeding.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -22,12 +31,19 @@ func (r *composeRepository) DeployApp(appID string) error {
 	}
 
 	templateDir := versionService.GetRevisionDir(appID, latest)
-	outputDir := r.getAppDir(appID)
 
 	if _, err := os.Stat(templateDir); err != nil {
 		return fmt.Errorf("role directory %s does not exist: %w", templateDir, err)
 	}
 
+	outputDir, err := r.relocateApp(appID, templateDir)
+	if err != nil {
+		return err
+	}
+	// Operations on a moved app are canceled through its new directory.
+	defer r.operations.begin(outputDir)()
+	r.reportStage(appID, model.DeployStageRendering)
+
 	// If the application is already deployed, check if it's running and stop containers before we re-render.
 	if dirExists(outputDir) {
 		// Check if the service is running before attempting to stop it
@@ -40,8 +56,12 @@ func (r *composeRepository) DeployApp(appID string) error {
 			log.Warn("Unable to determine app status before deployment", "app_id", appID, "error", statusErr)
 		}
 
-		// Only stop containers if they are running
-		if containersAreRunning {
+		// Only stop containers if they are running and are not replaced one service at a time.
+		keepRunning, err := keepsContainersRunning(templateDir, outputDir)
+		if err != nil {
+			return err
+		}
+		if containersAreRunning && !keepRunning {
 			if err := r.composeDown(outputDir); err != nil {
 				return fmt.Errorf("failed to stop running containers before deployment: %w", err)
 			}
@@ -53,17 +73,55 @@ func (r *composeRepository) DeployApp(appID string) error {
 		return err
 	}
 
-	// Start containers using the freshly rendered project definition.
-	if err := r.composeUp(outputDir); err != nil {
+	if err := r.validateNetworks(outputDir); err != nil {
+		return fmt.Errorf("network policy check failed: %w", err)
+	}
+	if err := r.validateImageRegistries(outputDir); err != nil {
+		return fmt.Errorf("image registry check failed: %w", err)
+	}
+	if err := validateReadinessProbe(outputDir); err != nil {
+		return err
+	}
+
+	if err := r.runDeployHook(templateDir, outputDir, preDeployHook); err != nil {
+		return fmt.Errorf("pre-deploy hook failed: %w", err)
+	}
+	if err := r.composeBuild(appID, outputDir); err != nil {
+		return fmt.Errorf("docker compose build failed: %w", err)
+	}
+
+	// Start containers using the freshly rendered project definition. Missing images are pulled by compose
+	// up, so there is no separate pulling stage.
+	r.reportStage(appID, model.DeployStageStarting)
+	if err := r.composeUpWithStrategy(appID, outputDir); err != nil {
 		return fmt.Errorf("docker compose up failed: %w", err)
 	}
 
+	r.reportStage(appID, model.DeployStageWaitingHealthy)
+	if err := r.waitForHealthy(appID, outputDir); err != nil {
+		return err
+	}
+	if err := r.waitForReady(appID, outputDir); err != nil {
+		return err
+	}
+
+	if err := r.runDeployHook(templateDir, outputDir, postDeployHook); err != nil {
+		if r.config.FailOnPostDeployHookError {
+			return fmt.Errorf("post-deploy hook failed: %w", err)
+		}
+		log.Warn("[Deploy] post-deploy hook failed, keeping deployment", "app_id", appID, "error", err)
+	}
+
 	log.Info("[Deploy] successfully deployed app", "app_id", appID, "version", latest)
 	return nil
 }
 
 // StartApp starts an application with the specified ID (deploys latest version)
-func (r *composeRepository) StartApp(appID string) error {
+func (r *composeRepository) StartApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationStart, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
 	// Ensure the base applications directory exists before proceeding.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -74,20 +132,31 @@ func (r *composeRepository) StartApp(appID string) error {
 
 	// If the app hasn't been rendered yet, perform a full deploy (render + start).
 	if !dirExists(outputDir) {
-		return r.DeployApp(appID)
+		return r.deployApp(appID)
 	}
 
 	// Start (or resume) the containers for the already rendered project.
+	r.reportStage(appID, model.DeployStageStarting)
 	if err := r.composeUp(outputDir); err != nil {
 		return fmt.Errorf("docker compose up failed: %w", err)
 	}
+	r.reportStage(appID, model.DeployStageWaitingHealthy)
+	if err := r.waitForHealthy(appID, outputDir); err != nil {
+		return err
+	}
+	if err := r.waitForReady(appID, outputDir); err != nil {
+		return err
+	}
 
 	log.Info("[Start] successfully started app", "app_id", appID)
 	return nil
 }
 
 // StopApp stops all containers belonging to the specified application.
-func (r *composeRepository) StopApp(appID string) error {
+func (r *composeRepository) StopApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationStop, time.Now(), &err)
+
 	// Ensure the base applications directory exists.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -111,7 +180,11 @@ func (r *composeRepository) StopApp(appID string) error {
 }
 
 // RestartApp restarts containers of the given application.
-func (r *composeRepository) RestartApp(appID string) error {
+func (r *composeRepository) RestartApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationRestart, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
 	// Ensure the base applications directory exists.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -121,10 +194,11 @@ func (r *composeRepository) RestartApp(appID string) error {
 
 	// If the application directory does not exist, fall back to a full deploy (render + start).
 	if !dirExists(appDir) {
-		return r.DeployApp(appID)
+		return r.deployApp(appID)
 	}
 
 	// Perform an in-place container restart.
+	r.reportStage(appID, model.DeployStageStarting)
 	if err := r.composeRestart(appDir); err != nil {
 		return fmt.Errorf("docker compose restart failed: %w", err)
 	}
@@ -133,8 +207,13 @@ func (r *composeRepository) RestartApp(appID string) error {
 	return nil
 }
 
-// UpdateApp pulls the latest images for the project and recreates containers.
-func (r *composeRepository) UpdateApp(appID string) error {
+// UpdateApp pulls the latest images for the project and recreates containers. When configured the named
+// volumes of the app are backed up first.
+func (r *composeRepository) UpdateApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationUpdate, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
 	}
@@ -146,19 +225,75 @@ func (r *composeRepository) UpdateApp(appID string) error {
 		return fmt.Errorf("failed to stat app directory: %w", err)
 	}
 
+	if r.config.BackupVolumesBeforeUpdate {
+		if _, err := r.backupVolumes(appID, appDir); err != nil {
+			return fmt.Errorf("volume backup before update failed: %w", err)
+		}
+	}
+
+	r.reportStage(appID, model.DeployStagePulling)
 	if err := r.composePull(appDir); err != nil {
 		return fmt.Errorf("docker compose pull failed: %w", err)
 	}
-	if err := r.composeUp(appDir); err != nil {
+	if err := r.composeBuild(appID, appDir); err != nil {
+		return fmt.Errorf("docker compose build failed: %w", err)
+	}
+	r.reportStage(appID, model.DeployStageStarting)
+	if err := r.composeUpWithStrategy(appID, appDir); err != nil {
 		return fmt.Errorf("docker compose up (after pull) failed: %w", err)
 	}
+	r.reportStage(appID, model.DeployStageWaitingHealthy)
+	if err := r.waitForHealthy(appID, appDir); err != nil {
+		return err
+	}
+	if err := r.waitForReady(appID, appDir); err != nil {
+		return err
+	}
 
 	log.Info("[Update] successfully updated app", "app_id", appID)
 	return nil
 }
 
-// DeleteApp stops containers and removes the application directory.
-func (r *composeRepository) DeleteApp(appID string) error {
+// RecreateApp recreates the containers of the project from the images already present on the host, so that
+// configuration-only changes take effect without pulling.
+func (r *composeRepository) RecreateApp(appID string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationRecreate, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
+	if err := ensureDir(r.config.GetAppsPath()); err != nil {
+		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
+	}
+	appDir := r.getAppDir(appID)
+	if _, err := os.Stat(appDir); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("app directory %s does not exist", appDir)
+		}
+		return fmt.Errorf("failed to stat app directory: %w", err)
+	}
+
+	r.reportStage(appID, model.DeployStageStarting)
+	if err := r.composeUp(appDir, "--force-recreate"); err != nil {
+		return fmt.Errorf("docker compose up --force-recreate failed: %w", err)
+	}
+	r.reportStage(appID, model.DeployStageWaitingHealthy)
+	if err := r.waitForHealthy(appID, appDir); err != nil {
+		return err
+	}
+	if err := r.waitForReady(appID, appDir); err != nil {
+		return err
+	}
+
+	log.Info("[Recreate] successfully recreated app", "app_id", appID)
+	return nil
+}
+
+// DeleteApp stops containers and removes the application directory. With purge the named volumes of the
+// application are removed too, after they were backed up when configured; a failure to do either keeps the
+// directory so that the deletion can be retried.
+func (r *composeRepository) DeleteApp(appID string, purge bool) (err error) {
+	defer r.metrics.observe(appID, model.AppOperationDelete, time.Now(), &err)
+
 	// Ensure the base applications directory exists.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -170,23 +305,35 @@ func (r *composeRepository) DeleteApp(appID string) error {
 	// Check if the app directory exists
 	if !dirExists(appDir) {
 		log.Warn("[Delete] app directory does not exist, skipping", "app_id", appID, "app_dir", appDir)
-		return nil
+		return r.setAppLocation(appID, r.config.GetAppsPath())
 	}
 
-	// Check if containers are running before attempting to stop them
-	statusResult, statusErr := r.GetAppStatus(appID)
-	containersAreRunning := false
-	if statusErr == nil && statusResult.App != nil {
-		code := statusResult.App.StatusCode
-		containersAreRunning = code != model.ContainerStatusStopped && code != model.ContainerStatusUnknown
-	} else if statusErr != nil {
-		log.Warn("Unable to determine app status before deletion", "app_id", appID, "error", statusErr)
-	}
+	if purge {
+		if r.config.BackupVolumesBeforePurge {
+			if _, err := r.backupVolumes(appID, appDir); err != nil {
+				return fmt.Errorf("volume backup before purge failed: %w", err)
+			}
+		}
+		// Volumes outlive stopped containers, so they are removed regardless of the app status.
+		if err := r.composeDown(appDir, "--volumes"); err != nil {
+			return fmt.Errorf("failed to remove containers and volumes of app %s: %w", appID, err)
+		}
+	} else {
+		// Check if containers are running before attempting to stop them
+		statusResult, statusErr := r.GetAppStatus(appID)
+		containersAreRunning := false
+		if statusErr == nil && statusResult.App != nil {
+			code := statusResult.App.StatusCode
+			containersAreRunning = code != model.ContainerStatusStopped && code != model.ContainerStatusUnknown
+		} else if statusErr != nil {
+			log.Warn("Unable to determine app status before deletion", "app_id", appID, "error", statusErr)
+		}
 
-	// Only attempt to stop containers if they are running
-	if containersAreRunning {
-		if err := r.StopApp(appID); err != nil {
-			log.Warn("Failed to stop app before deletion, continuing with removal", "app_id", appID, "error", err)
+		// Only attempt to stop containers if they are running
+		if containersAreRunning {
+			if err := r.StopApp(appID); err != nil {
+				log.Warn("Failed to stop app before deletion, continuing with removal", "app_id", appID, "error", err)
+			}
 		}
 	}
 
@@ -194,12 +341,17 @@ func (r *composeRepository) DeleteApp(appID string) error {
 	if err := os.RemoveAll(appDir); err != nil {
 		return fmt.Errorf("failed to delete app directory for app ID %s: %w", appID, err)
 	}
+	if err := r.setAppLocation(appID, r.config.GetAppsPath()); err != nil {
+		return err
+	}
 
 	log.Info("[Delete] successfully deleted app", "app_id", appID)
 	return nil
 }
 
-func (r *composeRepository) RenameApp(appID, newName string) error {
+func (r *composeRepository) RenameApp(appID, newName string) (err error) {
+	defer r.metrics.observe(appID, model.AppOperationRename, time.Now(), &err)
+
 	// Ensure the base applications directory exists before proceeding.
 	if err := ensureDir(r.config.GetAppsPath()); err != nil {
 		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
@@ -239,8 +391,12 @@ func (r *composeRepository) RenameApp(appID, newName string) error {
 			log.Warn("Unable to determine app status before deployment", "app_id", appID, "error", statusErr)
 		}
 
-		// Only stop containers if they are running
-		if containersAreRunning {
+		// Only stop containers if they are running and are not replaced one service at a time.
+		keepRunning, err := keepsContainersRunning(templateDir, outputDir)
+		if err != nil {
+			return err
+		}
+		if containersAreRunning && !keepRunning {
 			if err := r.composeDown(outputDir); err != nil {
 				return fmt.Errorf("failed to stop running containers before deployment: %w", err)
 			}
@@ -261,3 +417,30 @@ func (r *composeRepository) RenameApp(appID, newName string) error {
 	log.Info("[Deploy] successfully renamed app", "app_id", appID, "version", latest, "template_dir", templateDir, "output_dir", outputDir, "wasRunning", wasRunning)
 	return nil
 }
+
+// RenderCompose renders the given revision of an application into a scratch directory and returns the
+// merged compose project definition. The deployed application directory is never touched.
+func (r *composeRepository) RenderCompose(appID string, revision uint32) (string, error) {
+	versionService := appsvc.NewRevisionService(r.config)
+	templateDir := versionService.GetRevisionDir(appID, revision)
+	if _, err := os.Stat(templateDir); err != nil {
+		return "", fmt.Errorf("revision %d of app %s does not exist: %w", revision, appID, err)
+	}
+
+	renderDir, err := os.MkdirTemp("", "winterflow-render-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create render directory: %w", err)
+	}
+	defer os.RemoveAll(renderDir)
+
+	if err := r.renderRedactedApp(templateDir, renderDir); err != nil {
+		return "", err
+	}
+
+	output, err := r.composeConfig(renderDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve compose configuration: %w", err)
+	}
+
+	return output, nil
+}
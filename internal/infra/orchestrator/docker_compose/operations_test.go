@@ -0,0 +1,78 @@
+package docker_compose
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+func TestDeleteApp(t *testing.T) {
+	running := []container.Summary{{
+		ID:     "db",
+		Names:  []string{"/demo-db-1"},
+		State:  "running",
+		Labels: map[string]string{"com.docker.compose.project": "demo", managedLabel: "true"},
+	}}
+
+	testCases := []struct {
+		name       string
+		purge      bool
+		containers []container.Summary
+		results    []command.FakeResult
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name:       "running app is stopped and its volumes are kept",
+			containers: running,
+			expected:   []string{"docker compose down --remove-orphans"},
+		},
+		{
+			name:     "stopped app is removed without compose commands",
+			expected: []string{},
+		},
+		{
+			name:     "purge removes the volumes of a stopped app",
+			purge:    true,
+			expected: []string{"docker compose down --remove-orphans --volumes"},
+		},
+		{
+			name:       "purge removes the volumes of a running app",
+			purge:      true,
+			containers: running,
+			expected:   []string{"docker compose down --remove-orphans --volumes"},
+		},
+		{
+			name:     "failed purge keeps the app directory",
+			purge:    true,
+			results:  []command.FakeResult{{Err: errors.New("volume is in use")}},
+			expected: []string{"docker compose down --remove-orphans --volumes"},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := &command.FakeRunner{Results: tc.results}
+			r := newTestRepository(t, &config.Config{}, runner)
+			r.client = newFakeDockerClient(t, tc.containers)
+			writeTestRevision(t, r)
+			appDir := writeTestApp(t, r, map[string]string{"compose.yml": "services: {}\n"})
+
+			err := r.DeleteApp("app", tc.purge)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("DeleteApp returned error %v, want error %v", err, tc.wantErr)
+			}
+			if got := commandLines(runner.Commands()); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected commands %v, got %v", tc.expected, got)
+			}
+			if dirExists(appDir) != tc.wantErr {
+				t.Errorf("Expected app directory to exist: %v", tc.wantErr)
+			}
+		})
+	}
+}
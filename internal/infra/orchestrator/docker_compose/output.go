@@ -0,0 +1,123 @@
+package docker_compose
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// maxOutputLineLength splits output lines longer than it, e.g. of a progress bar without newlines.
+const maxOutputLineLength = 16 * 1024
+
+// appOutputs holds the receivers of the output of the compose commands run per app ID. The zero value is
+// ready to use.
+type appOutputs struct {
+	mu    sync.Mutex
+	sinks map[string]*outputSink
+}
+
+// outputSink is a receiver of the output of an app.
+type outputSink struct {
+	out func(model.OutputLine)
+}
+
+// set registers out as the receiver of the output of the app, replacing the previous one, and returns the
+// function removing it.
+func (o *appOutputs) set(appID string, out func(model.OutputLine)) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.sinks == nil {
+		o.sinks = make(map[string]*outputSink)
+	}
+	sink := &outputSink{out: out}
+	o.sinks[appID] = sink
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if o.sinks[appID] == sink {
+			delete(o.sinks, appID)
+		}
+	}
+}
+
+// get returns the receiver of the output of the app, or nil when there is none.
+func (o *appOutputs) get(appID string) func(model.OutputLine) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if sink, ok := o.sinks[appID]; ok {
+		return sink.out
+	}
+	return nil
+}
+
+// StreamOutput sends the output lines of the compose commands run for the app, such as `docker compose up`,
+// to out until the returned function is called.
+func (r *composeRepository) StreamOutput(appID string, out func(model.OutputLine)) func() {
+	return r.outputs.set(appID, out)
+}
+
+// outputWriters returns the writers receiving the standard output and standard error of a compose command
+// run in appDir and the function flushing their last lines, or nil writers when no output is streamed.
+func (r *composeRepository) outputWriters(appDir string) (*outputLineWriter, *outputLineWriter, func()) {
+	out := r.outputs.get(filepath.Base(appDir))
+	if out == nil {
+		return nil, nil, func() {}
+	}
+	stdout := &outputLineWriter{channel: model.LogChannelStdout, out: out}
+	stderr := &outputLineWriter{channel: model.LogChannelStderr, out: out}
+	return stdout, stderr, func() {
+		stdout.Flush()
+		stderr.Flush()
+	}
+}
+
+// outputLineWriter splits the output written to it into lines sent to out. A last line without a newline is
+// sent by Flush.
+type outputLineWriter struct {
+	channel model.LogChannel
+	out     func(model.OutputLine)
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *outputLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			if len(w.buf) < maxOutputLineLength {
+				return len(p), nil
+			}
+			i = maxOutputLineLength
+		}
+		w.send(w.buf[:i])
+		if i < len(w.buf) && w.buf[i] == '\n' {
+			i++
+		}
+		w.buf = w.buf[i:]
+	}
+}
+
+// Flush sends the buffered last line, if any.
+func (w *outputLineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.send(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *outputLineWriter) send(line []byte) {
+	text := sanitizeMessage(string(bytes.TrimSuffix(line, []byte("\r"))))
+	if text == "" {
+		return
+	}
+	w.out(model.OutputLine{Channel: w.channel, Text: text})
+}
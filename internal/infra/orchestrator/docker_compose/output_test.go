@@ -0,0 +1,120 @@
+package docker_compose
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/command"
+)
+
+// outputRecorder collects the output lines sent to it.
+type outputRecorder struct {
+	mu    sync.Mutex
+	lines []model.OutputLine
+}
+
+func (o *outputRecorder) Write(line model.OutputLine) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.lines = append(o.lines, line)
+}
+
+func (o *outputRecorder) Lines() []model.OutputLine {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]model.OutputLine(nil), o.lines...)
+}
+
+func TestOutputLineWriterSplitsLines(t *testing.T) {
+	recorder := &outputRecorder{}
+	w := &outputLineWriter{channel: model.LogChannelStderr, out: recorder.Write}
+
+	for _, chunk := range []string{" Container demo-db-1  Creat", "ing\r\n Container demo-db-1  Created\n", "\n\x1b[32mStarted\x1b[0m"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+	w.Flush()
+
+	expected := []model.OutputLine{
+		{Channel: model.LogChannelStderr, Text: " Container demo-db-1  Creating"},
+		{Channel: model.LogChannelStderr, Text: " Container demo-db-1  Created"},
+		{Channel: model.LogChannelStderr, Text: "Started"},
+	}
+	if got := recorder.Lines(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestOutputLineWriterSplitsLongLines(t *testing.T) {
+	recorder := &outputRecorder{}
+	w := &outputLineWriter{channel: model.LogChannelStdout, out: recorder.Write}
+
+	long := make([]byte, maxOutputLineLength+10)
+	for i := range long {
+		long[i] = 'a'
+	}
+	if _, err := w.Write(long); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	w.Flush()
+
+	lines := recorder.Lines()
+	if len(lines) != 2 || len(lines[0].Text) != maxOutputLineLength || len(lines[1].Text) != 10 {
+		t.Errorf("Expected the line to be split at %d bytes, got %d lines", maxOutputLineLength, len(lines))
+	}
+}
+
+func TestStreamOutputForwardsComposeOutput(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	for range 20 {
+		runner.Results = append(runner.Results, command.FakeResult{Stdout: []byte("Container demo-db-1  Started\n"), Stderr: []byte("Image postgres Pulled\n")})
+	}
+
+	recorder := &outputRecorder{}
+	stop := r.StreamOutput("app", recorder.Write)
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	stop()
+
+	lines := recorder.Lines()
+	if len(lines) == 0 {
+		t.Fatal("Expected the compose output to be streamed")
+	}
+	channels := map[model.LogChannel]string{}
+	for _, line := range lines {
+		channels[line.Channel] = line.Text
+	}
+	expected := map[model.LogChannel]string{model.LogChannelStdout: "Container demo-db-1  Started", model.LogChannelStderr: "Image postgres Pulled"}
+	if !reflect.DeepEqual(channels, expected) {
+		t.Errorf("Expected lines %q, got %q", expected, channels)
+	}
+
+	if err := r.composeUp(r.getAppDir("app")); err != nil {
+		t.Fatalf("composeUp returned error: %v", err)
+	}
+	if got := len(recorder.Lines()); got != len(lines) {
+		t.Errorf("Expected no output once the stream was stopped, got %d more lines", got-len(lines))
+	}
+}
+
+func TestStreamOutputKeepsNewerReceiver(t *testing.T) {
+	r := &composeRepository{}
+	first, second := &outputRecorder{}, &outputRecorder{}
+
+	stopFirst := r.StreamOutput("app", first.Write)
+	stopSecond := r.StreamOutput("app", second.Write)
+	stopFirst()
+
+	if out := r.outputs.get("app"); out == nil {
+		t.Fatal("Expected the newer receiver to stay registered")
+	}
+	stopSecond()
+	if out := r.outputs.get("app"); out != nil {
+		t.Error("Expected no receiver once both streams were stopped")
+	}
+}
@@ -0,0 +1,75 @@
+package docker_compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/log"
+)
+
+// pullServicesInParallel pulls the images of services of the app in appDir with one `docker compose pull`
+// per service, running at most concurrency of them at a time. The number of services pulled so far is
+// logged and sent to the output stream of the app. A failed pull does not stop the others; the failures of
+// all services are returned together.
+func (r *composeRepository) pullServicesInParallel(appDir string, env, fileArgs, services []string, concurrency int) error {
+	ctx := r.operations.context(appDir)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	out := r.outputs.get(filepath.Base(appDir))
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures = make(map[string]error)
+		finished int
+	)
+	slots := make(chan struct{}, concurrency)
+	log.Info("[Pull] pulling images in parallel", "app_dir", appDir, "services", len(services), "concurrency", concurrency)
+
+launch:
+	for _, service := range services {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			args := append(append([]string(nil), fileArgs...), "pull", service)
+			err := r.runDockerComposeWithRetry(appDir, env, args...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			finished++
+			if err != nil {
+				failures[service] = err
+			}
+			log.Info("[Pull] pulled service images", "app_dir", appDir, "service", service, "error", err, "finished", finished, "total", len(services), "failed", len(failures))
+			if out != nil {
+				out(model.OutputLine{Channel: model.LogChannelUnknown, Text: fmt.Sprintf("[pulled %d of %d services, %d failed]", finished, len(services), len(failures))})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil && finished < len(services) {
+		return fmt.Errorf("pulling images canceled after %d of %d services: %w", finished, len(services), err)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(failures))
+	for _, service := range services {
+		if err, ok := failures[service]; ok {
+			errs = append(errs, fmt.Errorf("service %s: %w", service, err))
+		}
+	}
+	return fmt.Errorf("failed to pull the images of %d of %d services: %w", len(failures), len(services), errors.Join(errs...))
+}
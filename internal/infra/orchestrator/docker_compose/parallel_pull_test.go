@@ -0,0 +1,113 @@
+package docker_compose
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// pullRunner records the pulled services and the largest number of pulls running at the same time. Pulls of
+// the services in fail fail.
+type pullRunner struct {
+	*command.FakeRunner
+	fail map[string]bool
+
+	mu          sync.Mutex
+	running     int
+	maxRunning  int
+	pulledNames []string
+}
+
+func (r *pullRunner) CombinedOutput(cmd command.Cmd) ([]byte, error) {
+	r.mu.Lock()
+	r.running++
+	r.maxRunning = max(r.maxRunning, r.running)
+	r.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	service := cmd.Args[len(cmd.Args)-1]
+
+	r.mu.Lock()
+	r.running--
+	r.pulledNames = append(r.pulledNames, service)
+	r.mu.Unlock()
+
+	_, _ = r.FakeRunner.CombinedOutput(cmd)
+	if r.fail[service] {
+		return []byte("manifest for " + service + " not found"), errors.New("exit status 1")
+	}
+	return nil, nil
+}
+
+func (r *pullRunner) pulled() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := append([]string(nil), r.pulledNames...)
+	sort.Strings(names)
+	return names
+}
+
+// newPullRepository returns a repository pulling the images of an app with five services, at most
+// concurrency at a time.
+func newPullRepository(t *testing.T, concurrency int, fail ...string) (*composeRepository, *pullRunner, string) {
+	t.Helper()
+	runner := &pullRunner{FakeRunner: &command.FakeRunner{}, fail: make(map[string]bool)}
+	for _, service := range fail {
+		runner.fail[service] = true
+	}
+	r := newTestRepository(t, &config.Config{PullConcurrency: concurrency, ComposeRetryAttempts: -1}, runner)
+	compose := "services:\n  a:\n    image: a\n  b:\n    image: b\n  c:\n    image: c\n  d:\n    image: d\n  e:\n    image: e\n"
+	appDir := writeTestApp(t, r, map[string]string{"compose.yml": compose})
+	return r, runner, appDir
+}
+
+func TestComposePullPullsServicesInParallelUpToTheLimit(t *testing.T) {
+	r, runner, appDir := newPullRepository(t, 2)
+
+	if err := r.composePull(appDir); err != nil {
+		t.Fatalf("composePull returned error: %v", err)
+	}
+
+	if got := runner.pulled(); strings.Join(got, ",") != "a,b,c,d,e" {
+		t.Errorf("Expected every service to be pulled once, got %v", got)
+	}
+	if runner.maxRunning != 2 {
+		t.Errorf("Expected 2 pulls to run at the same time, got %d", runner.maxRunning)
+	}
+}
+
+func TestComposePullCollectsTheFailuresOfAllServices(t *testing.T) {
+	r, runner, appDir := newPullRepository(t, 3, "b", "d")
+
+	err := r.composePull(appDir)
+	if err == nil {
+		t.Fatal("Expected composePull to fail")
+	}
+	for _, want := range []string{"failed to pull the images of 2 of 5 services", "service b:", "service d:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to contain %q, got %v", want, err)
+		}
+	}
+	if got := runner.pulled(); len(got) != 5 {
+		t.Errorf("Expected the other services to be pulled despite the failures, got %v", got)
+	}
+}
+
+func TestComposePullPullsTheProjectWithoutConcurrency(t *testing.T) {
+	r, runner, appDir := newPullRepository(t, 0)
+
+	if err := r.composePull(appDir); err != nil {
+		t.Fatalf("composePull returned error: %v", err)
+	}
+
+	commands := runner.Commands()
+	if len(commands) != 1 || strings.Join(commands[0].Args, " ") != "compose pull" {
+		t.Errorf("Expected a single compose pull, got %v", commandLines(commands))
+	}
+}
@@ -0,0 +1,72 @@
+package docker_compose
+
+import (
+	"sync"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
+// appProgress holds the reporters of the stages of the operations run per app ID. The zero value is ready to
+// use.
+type appProgress struct {
+	mu    sync.Mutex
+	sinks map[string]*progressSink
+}
+
+// progressSink is a reporter of the stages of an app.
+type progressSink struct {
+	reporter repository.ProgressReporter
+}
+
+// set registers reporter as the reporter of the stages of the app, replacing the previous one, and returns
+// the function removing it.
+func (p *appProgress) set(appID string, reporter repository.ProgressReporter) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.sinks == nil {
+		p.sinks = make(map[string]*progressSink)
+	}
+	sink := &progressSink{reporter: reporter}
+	p.sinks[appID] = sink
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.sinks[appID] == sink {
+			delete(p.sinks, appID)
+		}
+	}
+}
+
+// get returns the reporter of the stages of the app, or nil when there is none.
+func (p *appProgress) get(appID string) repository.ProgressReporter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if sink, ok := p.sinks[appID]; ok {
+		return sink.reporter
+	}
+	return nil
+}
+
+// TrackProgress reports the stages the deploy, start, restart, update and recreate operations of the app reach
+// to reporter until the returned function is called.
+func (r *composeRepository) TrackProgress(appID string, reporter repository.ProgressReporter) func() {
+	return r.progress.set(appID, reporter)
+}
+
+// reportStage reports that the operation running on the app reached stage.
+func (r *composeRepository) reportStage(appID string, stage model.DeployStage) {
+	if reporter := r.progress.get(appID); reporter != nil {
+		reporter.ReportStage(stage)
+	}
+}
+
+// reportOutcome reports the done or failed stage of an operation on the app that ended with err.
+func (r *composeRepository) reportOutcome(appID string, err error) {
+	if err != nil {
+		r.reportStage(appID, model.DeployStageFailed)
+		return
+	}
+	r.reportStage(appID, model.DeployStageDone)
+}
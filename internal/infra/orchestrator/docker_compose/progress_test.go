@@ -0,0 +1,92 @@
+package docker_compose
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/command"
+)
+
+// stageRecorder collects the stages reported to it.
+type stageRecorder struct {
+	mu     sync.Mutex
+	stages []model.DeployStage
+}
+
+func (s *stageRecorder) ReportStage(stage model.DeployStage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stages = append(s.stages, stage)
+}
+
+func (s *stageRecorder) Stages() []model.DeployStage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]model.DeployStage(nil), s.stages...)
+}
+
+func TestDeployAppReportsItsStages(t *testing.T) {
+	r, _ := newHookRepository(t, &config.Config{})
+	recorder := &stageRecorder{}
+	defer r.TrackProgress("app", recorder)()
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	expected := []model.DeployStage{model.DeployStageRendering, model.DeployStageStarting, model.DeployStageWaitingHealthy, model.DeployStageDone}
+	if got := recorder.Stages(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected stages %v, got %v", expected, got)
+	}
+}
+
+func TestUpdateAppReportsThePullingStage(t *testing.T) {
+	r, _ := newHookRepository(t, &config.Config{})
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	recorder := &stageRecorder{}
+	defer r.TrackProgress("app", recorder)()
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	expected := []model.DeployStage{model.DeployStagePulling, model.DeployStageStarting, model.DeployStageWaitingHealthy, model.DeployStageDone}
+	if got := recorder.Stages(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected stages %v, got %v", expected, got)
+	}
+}
+
+func TestDeployAppReportsTheFailedStage(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	runner.Results = []command.FakeResult{{Err: errors.New("no such image")}}
+	recorder := &stageRecorder{}
+	defer r.TrackProgress("app", recorder)()
+
+	if err := r.DeployApp("app"); err == nil {
+		t.Fatal("Expected DeployApp to fail")
+	}
+
+	expected := []model.DeployStage{model.DeployStageRendering, model.DeployStageStarting, model.DeployStageFailed}
+	if got := recorder.Stages(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected stages %v, got %v", expected, got)
+	}
+}
+
+func TestTrackProgressStopsReporting(t *testing.T) {
+	r, _ := newHookRepository(t, &config.Config{})
+	recorder := &stageRecorder{}
+	r.TrackProgress("app", recorder)()
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	if got := recorder.Stages(); len(got) != 0 {
+		t.Errorf("Expected no stages once tracking stopped, got %v", got)
+	}
+}
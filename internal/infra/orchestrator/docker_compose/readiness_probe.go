@@ -0,0 +1,120 @@
+package docker_compose
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+)
+
+// Defaults of the readiness probe of an app.
+const (
+	defaultReadinessProbeTimeout = 5 * time.Second
+	defaultReadinessProbeRetries = 10
+)
+
+// readinessProbe is the parsed readiness probe of an app.
+type readinessProbe struct {
+	network string
+	address string
+	timeout time.Duration
+	retries int
+}
+
+// readinessProbeOf returns the readiness probe of the app described by cfg, or nil when it has none.
+func readinessProbeOf(cfg *model.AppConfig) (*readinessProbe, error) {
+	if cfg == nil || cfg.ReadinessProbe == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(cfg.ReadinessProbe)
+	if err != nil {
+		return nil, fmt.Errorf("invalid readiness probe %q: %w", cfg.ReadinessProbe, err)
+	}
+
+	probe := &readinessProbe{network: u.Scheme, timeout: defaultReadinessProbeTimeout, retries: defaultReadinessProbeRetries}
+	switch u.Scheme {
+	case "tcp":
+		if _, port, err := net.SplitHostPort(u.Host); err != nil || port == "" || (u.Path != "" && u.Path != "/") {
+			return nil, fmt.Errorf("invalid readiness probe %q: expected tcp://host:port", cfg.ReadinessProbe)
+		}
+		probe.address = u.Host
+	case "unix":
+		if u.Host != "" || !filepath.IsAbs(u.Path) {
+			return nil, fmt.Errorf("invalid readiness probe %q: expected unix:///absolute/path", cfg.ReadinessProbe)
+		}
+		probe.address = u.Path
+	default:
+		return nil, fmt.Errorf("invalid readiness probe %q: expected a tcp:// or unix:// address", cfg.ReadinessProbe)
+	}
+
+	if cfg.ReadinessProbeTimeout > 0 {
+		probe.timeout = time.Duration(cfg.ReadinessProbeTimeout) * time.Second
+	}
+	if cfg.ReadinessProbeRetries < 0 {
+		probe.retries = 0
+	} else if cfg.ReadinessProbeRetries > 0 {
+		probe.retries = cfg.ReadinessProbeRetries
+	}
+	return probe, nil
+}
+
+// validateReadinessProbe checks the readiness probe of the app rendered in appDir before its containers are
+// started, so that a malformed probe does not fail the deployment only after it replaced the containers.
+func validateReadinessProbe(appDir string) error {
+	appConfig, err := orchestrator.GetCurrentConfig(appDir)
+	if err != nil {
+		return nil
+	}
+	_, err = readinessProbeOf(appConfig)
+	return err
+}
+
+// waitForReady connects to the readiness probe address of the app rendered in appDir, if it has one, and
+// retries failed connections at the health poll interval. It fails once the retries are used up or the
+// operation is canceled. The connection is closed right away; only accepting it is checked.
+func (r *composeRepository) waitForReady(appID, appDir string) error {
+	appConfig, err := orchestrator.GetCurrentConfig(appDir)
+	if err != nil {
+		return nil
+	}
+	probe, err := readinessProbeOf(appConfig)
+	if err != nil || probe == nil {
+		return err
+	}
+
+	ctx := r.operations.context(appDir)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	interval := r.healthPollInterval
+	if interval <= 0 {
+		interval = defaultHealthPollInterval
+	}
+
+	log.Info("Probing app readiness", "app_id", appID, "probe", appConfig.ReadinessProbe)
+	dialer := net.Dialer{Timeout: probe.timeout}
+	for attempt := 1; ; attempt++ {
+		conn, err := dialer.DialContext(ctx, probe.network, probe.address)
+		if err == nil {
+			_ = conn.Close()
+			log.Info("App is accepting connections", "app_id", appID, "probe", appConfig.ReadinessProbe, "attempts", attempt)
+			return nil
+		}
+		if attempt > probe.retries {
+			return fmt.Errorf("readiness probe %s failed after %d attempts: %w", appConfig.ReadinessProbe, attempt, err)
+		}
+
+		log.Debug("App is not accepting connections yet", "app_id", appID, "probe", appConfig.ReadinessProbe, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("readiness probe %s: %w", appConfig.ReadinessProbe, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package docker_compose
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+// acceptConnections accepts and closes connections on listener until it is closed.
+func acceptConnections(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_ = conn.Close()
+	}
+}
+
+// refusedAddress returns a local TCP address nothing listens on.
+func refusedAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+	return address
+}
+
+func TestStartAppSucceedsWhenTheReadinessProbeConnects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go acceptConnections(listener)
+
+	r := newHealthWaitRepository(t, &healthFixture{}, fmt.Sprintf(`{"id":"app","name":"demo","readiness_probe":"tcp://%s"}`, listener.Addr()))
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+}
+
+func TestStartAppSucceedsWhenTheUnixReadinessProbeConnects(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, which the test temp directories may exceed.
+	dir, err := os.MkdirTemp("", "probe")
+	if err != nil {
+		t.Fatalf("Failed to create socket directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "app.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go acceptConnections(listener)
+
+	r := newHealthWaitRepository(t, &healthFixture{}, fmt.Sprintf(`{"id":"app","name":"demo","readiness_probe":"unix://%s"}`, socket))
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+}
+
+func TestStartAppFailsWhenTheReadinessProbeIsRefused(t *testing.T) {
+	address := refusedAddress(t)
+	r := newHealthWaitRepository(t, &healthFixture{}, fmt.Sprintf(`{"id":"app","name":"demo","readiness_probe":"tcp://%s","readiness_probe_retries":2}`, address))
+
+	err := r.StartApp("app")
+	if err == nil {
+		t.Fatal("Expected StartApp to fail when the probe is refused")
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("readiness probe tcp://%s failed after 3 attempts", address)) {
+		t.Errorf("Expected the failed attempts to be reported, got %v", err)
+	}
+}
+
+func TestStartAppRetriesTheReadinessProbeUntilTheAppListens(t *testing.T) {
+	address := refusedAddress(t)
+	r := newHealthWaitRepository(t, &healthFixture{}, fmt.Sprintf(`{"id":"app","name":"demo","readiness_probe":"tcp://%s","readiness_probe_retries":100}`, address))
+
+	// The app starts listening after the first attempts were refused.
+	listening := make(chan net.Listener, 1)
+	time.AfterFunc(50*time.Millisecond, func() {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			close(listening)
+			return
+		}
+		listening <- listener
+		acceptConnections(listener)
+	})
+
+	if err := r.StartApp("app"); err != nil {
+		t.Fatalf("Expected the probe to succeed once the app listens, got %v", err)
+	}
+	if listener, ok := <-listening; ok {
+		_ = listener.Close()
+	}
+}
+
+func TestReadinessProbeOf(t *testing.T) {
+	tests := []struct {
+		probe   string
+		network string
+		address string
+		valid   bool
+	}{
+		{"tcp://127.0.0.1:8080", "tcp", "127.0.0.1:8080", true},
+		{"tcp://localhost:80/", "tcp", "localhost:80", true},
+		{"unix:///run/app.sock", "unix", "/run/app.sock", true},
+		{"tcp://localhost", "", "", false},
+		{"tcp://localhost:80/health", "", "", false},
+		{"unix://run/app.sock", "", "", false},
+		{"http://localhost:80", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.probe, func(t *testing.T) {
+			probe, err := readinessProbeOf(&model.AppConfig{ReadinessProbe: tt.probe})
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("Expected %q to be rejected", tt.probe)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if probe.network != tt.network || probe.address != tt.address {
+				t.Errorf("Expected %s %s, got %s %s", tt.network, tt.address, probe.network, probe.address)
+			}
+			if probe.timeout != defaultReadinessProbeTimeout || probe.retries != defaultReadinessProbeRetries {
+				t.Errorf("Expected the default timeout and retries, got %v and %d", probe.timeout, probe.retries)
+			}
+		})
+	}
+}
+
+func TestReadinessProbeOfAppliesTimeoutAndRetries(t *testing.T) {
+	probe, err := readinessProbeOf(&model.AppConfig{ReadinessProbe: "tcp://localhost:80", ReadinessProbeTimeout: 2, ReadinessProbeRetries: -1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if probe.timeout.Seconds() != 2 || probe.retries != 0 {
+		t.Errorf("Expected a 2s timeout without retries, got %v and %d", probe.timeout, probe.retries)
+	}
+}
@@ -0,0 +1,94 @@
+package docker_compose
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/log"
+)
+
+// ReconcileApp resets the app to a clean state: its containers are removed, the app directory is deleted and
+// the latest revision is rendered from scratch and deployed. Unlike UpdateApp and DeployApp nothing of the
+// previous directory is kept, including data of bind mounts below it. It reports whether the directory
+// differed from the freshly rendered one.
+func (r *composeRepository) ReconcileApp(appID string) (drifted bool, err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationReconcile, time.Now(), &err)
+
+	appDir := r.getAppDir(appID)
+	before, err := dirDigests(appDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to read app directory: %w", err)
+	}
+
+	if dirExists(appDir) {
+		if err := r.composeDown(appDir); err != nil {
+			return false, fmt.Errorf("failed to remove containers of app %s: %w", appID, err)
+		}
+		if err := os.RemoveAll(appDir); err != nil {
+			return false, fmt.Errorf("failed to remove app directory %s: %w", appDir, err)
+		}
+		log.Info("[Reconcile] removed app directory", "app_id", appID, "app_dir", appDir)
+	}
+
+	if err := r.deployApp(appID); err != nil {
+		return false, err
+	}
+
+	after, err := dirDigests(r.getAppDir(appID))
+	if err != nil {
+		return false, fmt.Errorf("failed to read rendered app directory: %w", err)
+	}
+	drifted = !maps.Equal(before, after)
+	log.Info("[Reconcile] successfully reconciled app", "app_id", appID, "drifted", drifted)
+	return drifted, nil
+}
+
+// dirDigests maps the path of every file and symlink below dir, relative to dir, to the SHA-256 hash of its
+// content or link target. A missing directory has no entries.
+func dirDigests(dir string) (map[string][sha256.Size]byte, error) {
+	result := make(map[string][sha256.Size]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		var data []byte
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			data = []byte("symlink:" + target)
+		} else if d.Type().IsRegular() {
+			if data, err = os.ReadFile(path); err != nil {
+				return err
+			}
+		} else {
+			data = []byte("special:" + d.Type().String())
+		}
+		result[rel] = sha256.Sum256(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
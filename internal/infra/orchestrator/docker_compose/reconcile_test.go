@@ -0,0 +1,81 @@
+package docker_compose
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+)
+
+func TestReconcileAppRestoresDriftedDirectory(t *testing.T) {
+	r, runner := newHookRepository(t, &config.Config{})
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	appDir := r.getAppDir("app")
+	rendered, err := dirDigests(appDir)
+	if err != nil {
+		t.Fatalf("Failed to read rendered directory: %v", err)
+	}
+
+	// Drift: a hand edited compose file and a stray file.
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte("services: {}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to edit compose.yml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "stray.txt"), []byte("left behind"), 0o644); err != nil {
+		t.Fatalf("Failed to write stray file: %v", err)
+	}
+	deployed := len(runner.Commands())
+
+	drifted, err := r.ReconcileApp("app")
+	if err != nil {
+		t.Fatalf("ReconcileApp returned error: %v", err)
+	}
+	if !drifted {
+		t.Error("Expected the drift to be reported")
+	}
+	reconciled, err := dirDigests(appDir)
+	if err != nil {
+		t.Fatalf("Failed to read reconciled directory: %v", err)
+	}
+	if !maps.Equal(rendered, reconciled) {
+		t.Errorf("Expected the directory to match a fresh render, got %v want %v", slices.Sorted(maps.Keys(reconciled)), slices.Sorted(maps.Keys(rendered)))
+	}
+
+	var down, up bool
+	for _, cmd := range runner.Commands()[deployed:] {
+		args := strings.Join(cmd.Args, " ")
+		down = down || strings.Contains(args, " down")
+		up = up || (down && strings.Contains(args, " up"))
+	}
+	if !down || !up {
+		t.Errorf("Expected the containers to be removed and the app redeployed, got %v", commandNames(runner.Commands()[deployed:]))
+	}
+
+	drifted, err = r.ReconcileApp("app")
+	if err != nil {
+		t.Fatalf("ReconcileApp returned error: %v", err)
+	}
+	if drifted {
+		t.Error("Expected no drift right after a reconciliation")
+	}
+}
+
+func TestReconcileAppDeploysMissingDirectory(t *testing.T) {
+	r, _ := newHookRepository(t, &config.Config{})
+
+	drifted, err := r.ReconcileApp("app")
+	if err != nil {
+		t.Fatalf("ReconcileApp returned error: %v", err)
+	}
+	if !drifted {
+		t.Error("Expected a missing app directory to be reported as drift")
+	}
+	if _, err := os.Stat(filepath.Join(r.getAppDir("app"), "compose.yml")); err != nil {
+		t.Errorf("Expected the app to be rendered: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package docker_compose
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"winterflow-agent/internal/infra/docker/registry"
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/log"
+)
+
+// registryLoginTimeout bounds a single `docker login` run before a compose command.
+const registryLoginTimeout = 30 * time.Second
+
+// registryAuthConfigKeys lists the Docker configuration sections that carry
+// registry credentials (inline auths or references to credential helpers).
+// A temporary configuration is only prepared when one of them is present.
+var registryAuthConfigKeys = []string{"auths", "credsStore", "credHelpers"}
+
+// linkedDockerConfigDirs lists the directories next to config.json that are
+// linked into the temporary configuration: the Docker contexts, so that the
+// configured --context still resolves, and the per-user CLI plugins, so that
+// `docker compose` is still found when it is installed in ~/.docker/cli-plugins.
+var linkedDockerConfigDirs = []string{"contexts", "cli-plugins"}
+
+// prepareRegistryAuth logs in to the registries stored by the registry
+// commands (`docker login`) in a temporary Docker configuration and returns
+// the environment variables that point `docker compose` at it.
+//
+// Every registry with stored credentials is logged in to again with
+// `docker login --password-stdin`, so that expired or rejected credentials
+// surface in the log before the pull. Settings such as proxies and credential
+// helpers are kept, as are registries without inline credentials, and the
+// directories of linkedDockerConfigDirs are linked. The returned cleanup
+// function removes the temporary configuration and must always be called.
+// When no registry has been configured the environment is empty and cleanup
+// is a no-op. Credentials are never written to the log.
+func (r *composeRepository) prepareRegistryAuth() ([]string, func(), error) {
+	noop := func() {}
+
+	cfgPath, err := registry.GetDockerConfigPath()
+	if err != nil {
+		return nil, noop, err
+	}
+	cfgDir := filepath.Dir(cfgPath)
+
+	raw, err := os.ReadFile(cfgPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, noop, nil
+		}
+		return nil, noop, fmt.Errorf("failed to read docker config: %w", err)
+	}
+
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return nil, noop, fmt.Errorf("failed to parse docker config: %w", err)
+	}
+
+	hasAuth := false
+	for _, key := range registryAuthConfigKeys {
+		if _, ok := source[key]; ok {
+			hasAuth = true
+		}
+	}
+	if !hasAuth {
+		return nil, noop, nil
+	}
+
+	// Registries with inline credentials are logged in to, the others are
+	// resolved through the credential helpers and copied as they are.
+	var auths map[string]json.RawMessage
+	if value, ok := source["auths"]; ok {
+		_ = json.Unmarshal(value, &auths)
+	}
+	logins := make(map[string]registryCredentials)
+	kept := make(map[string]json.RawMessage)
+	for address, entry := range auths {
+		if creds, ok := parseRegistryCredentials(entry); ok {
+			logins[address] = creds
+		} else {
+			kept[address] = entry
+		}
+	}
+	keptAuths, err := json.Marshal(kept)
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to marshal docker config: %w", err)
+	}
+	source["auths"] = keptAuths
+
+	tmpDir, err := os.MkdirTemp("", "winterflow-docker-config-")
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to create temporary docker config dir: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			log.Warn("Failed to remove temporary docker config", "dir", tmpDir, "error", err)
+		}
+	}
+
+	data, err := json.Marshal(source)
+	if err != nil {
+		cleanup()
+		return nil, noop, fmt.Errorf("failed to marshal docker config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0o600); err != nil {
+		cleanup()
+		return nil, noop, fmt.Errorf("failed to write temporary docker config: %w", err)
+	}
+
+	for _, name := range linkedDockerConfigDirs {
+		if dir := filepath.Join(cfgDir, name); dirExists(dir) {
+			if err := os.Symlink(dir, filepath.Join(tmpDir, name)); err != nil {
+				cleanup()
+				return nil, noop, fmt.Errorf("failed to link docker %s: %w", name, err)
+			}
+		}
+	}
+
+	env := []string{"DOCKER_CONFIG=" + tmpDir}
+	addresses := make([]string, 0, len(logins))
+	for address := range logins {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		r.registryLogin(env, address, logins[address])
+	}
+	log.Debug("Prepared registry authentication for docker compose", "registries", addresses)
+
+	return env, cleanup, nil
+}
+
+// registryCredentials are the username and password stored for a registry.
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// parseRegistryCredentials returns the credentials of an auths entry of the
+// Docker configuration, which stores them base64 encoded as "user:password".
+func parseRegistryCredentials(entry json.RawMessage) (registryCredentials, bool) {
+	var auth struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.Unmarshal(entry, &auth); err != nil {
+		return registryCredentials{}, false
+	}
+	if auth.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return registryCredentials{}, false
+		}
+		auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+	}
+	if auth.Username == "" || auth.Password == "" {
+		return registryCredentials{}, false
+	}
+	return registryCredentials{username: auth.Username, password: auth.Password}, true
+}
+
+// registryLogin runs `docker login` for address with the configuration of env.
+// The password is passed on standard input so that it never shows up in the
+// process list. A failed login is only logged, as the registry may not be used
+// by the app; its pull then fails with the error of the registry.
+func (r *composeRepository) registryLogin(env []string, address string, creds registryCredentials) {
+	cmd := command.Cmd{
+		Name:    "docker",
+		Args:    []string{"login", "--username", creds.username, "--password-stdin", address},
+		Env:     env,
+		Stdin:   strings.NewReader(creds.password),
+		Timeout: registryLoginTimeout,
+	}
+	if output, err := r.commandRunner().CombinedOutput(cmd); err != nil {
+		log.Warn("docker login failed before compose command", "address", address, "error", err, "output", strings.TrimSpace(string(output)))
+	}
+}
@@ -0,0 +1,193 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/pkg/command"
+)
+
+func TestPrepareRegistryAuthLogsInToStoredRegistries(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", cfgDir)
+
+	source := `{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNz"},"helper.example.com":{}},` +
+		`"credHelpers":{"helper.example.com":"ecr-login"},"currentContext":"default","proxies":{"default":{"httpProxy":"http://proxy:3128"}}}`
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(source), 0o600); err != nil {
+		t.Fatalf("Failed to write docker config: %v", err)
+	}
+
+	runner := &command.FakeRunner{}
+	r := &composeRepository{runner: runner}
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		t.Fatalf("prepareRegistryAuth returned error: %v", err)
+	}
+
+	if len(env) != 1 || !strings.HasPrefix(env[0], "DOCKER_CONFIG=") {
+		cleanup()
+		t.Fatalf("Expected a single DOCKER_CONFIG entry, got %v", env)
+	}
+	tmpDir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+
+	cmds := runner.Commands()
+	if len(cmds) != 1 {
+		cleanup()
+		t.Fatalf("Expected a single docker login, got %v", commandLines(cmds))
+	}
+	login := cmds[0]
+	if got, expected := commandLines(cmds)[0], "docker login --username user --password-stdin registry.example.com"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if !reflect.DeepEqual(login.Env, env) {
+		t.Errorf("Expected the login to use the temporary docker config %v, got %v", env, login.Env)
+	}
+	if login.Stdin == nil {
+		t.Error("Expected the password to be passed on standard input")
+	} else if password, _ := io.ReadAll(login.Stdin); string(password) != "pass" {
+		t.Errorf("Expected the password on standard input, got %q", password)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tmpDir, "config.json"))
+	if err != nil {
+		cleanup()
+		t.Fatalf("Failed to read temporary docker config: %v", err)
+	}
+
+	var cfg struct {
+		Auths          map[string]json.RawMessage `json:"auths"`
+		CredHelpers    map[string]string          `json:"credHelpers"`
+		Proxies        map[string]json.RawMessage `json:"proxies"`
+		CurrentContext *string                    `json:"currentContext"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		cleanup()
+		t.Fatalf("Failed to parse temporary docker config: %v", err)
+	}
+	if _, ok := cfg.Auths["registry.example.com"]; ok {
+		t.Errorf("Expected the credentials of registry.example.com to come from docker login, got %s", string(raw))
+	}
+	if _, ok := cfg.Auths["helper.example.com"]; !ok || cfg.CredHelpers["helper.example.com"] != "ecr-login" {
+		t.Errorf("Expected the credential helper of helper.example.com to be kept, got %s", string(raw))
+	}
+	if _, ok := cfg.Proxies["default"]; !ok {
+		t.Errorf("Expected the proxies to be kept, got %s", string(raw))
+	}
+	if cfg.CurrentContext == nil {
+		t.Errorf("Expected the other settings to be kept, got %s", string(raw))
+	}
+
+	cleanup()
+	if _, err := os.Stat(tmpDir); !os.IsNotExist(err) {
+		t.Errorf("Expected temporary docker config %s to be removed", tmpDir)
+	}
+}
+
+func TestPrepareRegistryAuthIgnoresFailedLogin(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", cfgDir)
+
+	source := `{"auths":{"a.example.com":{"auth":"dXNlcjpwYXNz"},"b.example.com":{"username":"robot","password":"token"}}}`
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(source), 0o600); err != nil {
+		t.Fatalf("Failed to write docker config: %v", err)
+	}
+
+	runner := &command.FakeRunner{Results: []command.FakeResult{{Stderr: []byte("unauthorized"), Err: errors.New("exit status 1")}}}
+	r := &composeRepository{runner: runner}
+	env, cleanup, err := r.prepareRegistryAuth()
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("Expected a failed login to be ignored, got %v", err)
+	}
+	if len(env) != 1 {
+		t.Errorf("Expected a single DOCKER_CONFIG entry, got %v", env)
+	}
+
+	expected := []string{
+		"docker login --username user --password-stdin a.example.com",
+		"docker login --username robot --password-stdin b.example.com",
+	}
+	if got := commandLines(runner.Commands()); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected logins %v, got %v", expected, got)
+	}
+}
+
+func TestPrepareRegistryAuthWithoutDockerConfig(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	r := &composeRepository{runner: &command.FakeRunner{}}
+	env, cleanup, err := r.prepareRegistryAuth()
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("prepareRegistryAuth returned error: %v", err)
+	}
+	if len(env) != 0 {
+		t.Errorf("Expected no environment overrides, got %v", env)
+	}
+}
+
+func TestPrepareRegistryAuthKeepsDockerContexts(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", cfgDir)
+
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(`{"auths":{"registry.example.com":{}}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write docker config: %v", err)
+	}
+	metaDir := filepath.Join(cfgDir, "contexts", "meta", "abc")
+	if err := os.MkdirAll(metaDir, 0o755); err != nil {
+		t.Fatalf("Failed to create contexts directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(`{"Name":"remote"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write context metadata: %v", err)
+	}
+
+	r := &composeRepository{runner: &command.FakeRunner{}}
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		t.Fatalf("prepareRegistryAuth returned error: %v", err)
+	}
+	defer cleanup()
+
+	tmpDir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+	if _, err := os.Stat(filepath.Join(tmpDir, "contexts", "meta", "abc", "meta.json")); err != nil {
+		t.Errorf("Expected docker contexts to be available in the temporary config: %v", err)
+	}
+}
+
+func TestPrepareRegistryAuthKeepsCLIPlugins(t *testing.T) {
+	cfgDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", cfgDir)
+
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(`{"auths":{"registry.example.com":{}}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write docker config: %v", err)
+	}
+	pluginsDir := filepath.Join(cfgDir, "cli-plugins")
+	if err := os.MkdirAll(pluginsDir, 0o755); err != nil {
+		t.Fatalf("Failed to create cli-plugins directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginsDir, "docker-compose"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("Failed to write compose plugin: %v", err)
+	}
+
+	r := &composeRepository{runner: &command.FakeRunner{}}
+	env, cleanup, err := r.prepareRegistryAuth()
+	if err != nil {
+		t.Fatalf("prepareRegistryAuth returned error: %v", err)
+	}
+	defer cleanup()
+
+	tmpDir := strings.TrimPrefix(env[0], "DOCKER_CONFIG=")
+	info, err := os.Stat(filepath.Join(tmpDir, "cli-plugins", "docker-compose"))
+	if err != nil {
+		t.Fatalf("Expected the compose plugin to resolve through the temporary config: %v", err)
+	}
+	if info.Mode()&0o111 == 0 {
+		t.Errorf("Expected the compose plugin to stay executable, got %v", info.Mode())
+	}
+}
@@ -0,0 +1,134 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// mirrorOverrideFile is the compose file generated into a rendered app to pull the Docker Hub images of
+// its services through the configured registry mirror.
+const mirrorOverrideFile = "compose.winterflow-mirror.yml"
+
+// dockerHubDomains are the registry domains that denote Docker Hub in image references.
+var dockerHubDomains = map[string]bool{
+	"docker.io":            true,
+	"index.docker.io":      true,
+	"registry-1.docker.io": true,
+}
+
+// mirrorImageReference rewrites image to be pulled through mirror when it is hosted on Docker Hub:
+// "nginx:1.27" becomes "<mirror>/library/nginx:1.27" and "docker.io/org/app@sha256:…" becomes
+// "<mirror>/org/app@sha256:…". Images of other registries, references holding unresolved variables and
+// an empty mirror leave image unchanged.
+func mirrorImageReference(mirror, image string) string {
+	mirror = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(mirror, "https://"), "http://"), "/")
+	if mirror == "" || image == "" || strings.Contains(image, "$") {
+		return image
+	}
+
+	path := image
+	if domain, rest, found := strings.Cut(image, "/"); found && isRegistryDomain(domain) {
+		if !dockerHubDomains[domain] {
+			return image
+		}
+		path = rest
+	}
+	if !strings.Contains(repositoryName(path), "/") {
+		path = "library/" + path
+	}
+	return mirror + "/" + path
+}
+
+// isRegistryDomain reports whether the first component of an image reference names a registry rather
+// than a Docker Hub namespace, following the rules of the Docker reference parser.
+func isRegistryDomain(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost" || strings.ToLower(component) != component
+}
+
+// repositoryName strips the tag and digest from an image path.
+func repositoryName(path string) string {
+	if name, _, found := strings.Cut(path, "@"); found {
+		path = name
+	}
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		path = path[:i]
+	}
+	return path
+}
+
+// writeRegistryMirrorOverride generates mirrorOverrideFile in destDir, replacing the Docker Hub images of
+// the rendered services by their mirrored reference. Without a configured mirror, or when no image needs
+// rewriting, any previously generated file is removed.
+func (r *composeRepository) writeRegistryMirrorOverride(destDir string) error {
+	overridePath := filepath.Join(destDir, mirrorOverrideFile)
+	if err := os.Remove(overridePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", mirrorOverrideFile, err)
+	}
+	if r.config == nil || r.config.RegistryMirror == "" {
+		return nil
+	}
+
+	images, err := r.composeServiceImages(destDir)
+	if err != nil {
+		return err
+	}
+	mirrored := make(map[string]string)
+	for service, image := range images {
+		if rewritten := mirrorImageReference(r.config.RegistryMirror, image); rewritten != image {
+			mirrored[service] = rewritten
+		}
+	}
+	if len(mirrored) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(mirrored))
+	for name := range mirrored {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("# Generated by the WinterFlow agent to pull images through the registry mirror.\nservices:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %s:\n    image: %s\n", strconv.Quote(name), strconv.Quote(mirrored[name]))
+	}
+	if err := os.WriteFile(overridePath, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", mirrorOverrideFile, err)
+	}
+	return nil
+}
+
+// composeServiceImages returns the image of every service of the rendered compose files in destDir that
+// sets one. Later files override the images of earlier ones, as with `docker compose`.
+func (r *composeRepository) composeServiceImages(destDir string) (map[string]string, error) {
+	files, err := r.renderedComposeFiles(destDir)
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name, raw := range definitions {
+			definition, _ := raw.(map[string]interface{})
+			if image, ok := definition["image"].(string); ok && image != "" {
+				images[name] = image
+			}
+		}
+	}
+	return images, nil
+}
@@ -0,0 +1,94 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/yaml"
+)
+
+func TestMirrorImageReference(t *testing.T) {
+	const mirror = "mirror.local:5000"
+	digest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{"nginx", mirror + "/library/nginx"},
+		{"nginx:1.27", mirror + "/library/nginx:1.27"},
+		{"nginx@" + digest, mirror + "/library/nginx@" + digest},
+		{"nginx:1.27@" + digest, mirror + "/library/nginx:1.27@" + digest},
+		{"bitnami/redis:7.2", mirror + "/bitnami/redis:7.2"},
+		{"docker.io/library/postgres:16", mirror + "/library/postgres:16"},
+		{"docker.io/postgres:16", mirror + "/library/postgres:16"},
+		{"index.docker.io/org/app@" + digest, mirror + "/org/app@" + digest},
+		{"ghcr.io/org/app:latest", "ghcr.io/org/app:latest"},
+		{"registry.local:5000/app", "registry.local:5000/app"},
+		{"localhost/app:dev", "localhost/app:dev"},
+		{"${IMAGE}:latest", "${IMAGE}:latest"},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			if got := mirrorImageReference(mirror, tc.image); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMirrorImageReferenceNormalisesMirror(t *testing.T) {
+	if got := mirrorImageReference("https://mirror.local/", "nginx"); got != "mirror.local/library/nginx" {
+		t.Errorf("Expected scheme and trailing slash to be dropped, got %q", got)
+	}
+	if got := mirrorImageReference("", "nginx"); got != "nginx" {
+		t.Errorf("Expected no rewrite without a mirror, got %q", got)
+	}
+}
+
+func TestRegistryMirrorOverrideRewritesDockerHubServices(t *testing.T) {
+	appDir := t.TempDir()
+	compose := "services:\n  web:\n    image: nginx:1.27\n  api:\n    image: ghcr.io/org/api:1\n  worker:\n    build: .\n"
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(compose), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	override := "services:\n  api:\n    image: org/api:2\n"
+	if err := os.WriteFile(filepath.Join(appDir, "compose.override.yml"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &composeRepository{config: &config.Config{RegistryMirror: "mirror.local"}}
+	if err := r.writeRegistryMirrorOverride(appDir); err != nil {
+		t.Fatalf("writeRegistryMirrorOverride returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(appDir, mirrorOverrideFile))
+	if err != nil {
+		t.Fatalf("Failed to read mirror override: %v", err)
+	}
+	doc, err := yaml.UnmarshalMap(data)
+	if err != nil {
+		t.Fatalf("Generated override is not valid YAML: %v", err)
+	}
+	expected := map[string]interface{}{
+		"web": map[string]interface{}{"image": "mirror.local/library/nginx:1.27"},
+		"api": map[string]interface{}{"image": "mirror.local/org/api:2"},
+	}
+	if !reflect.DeepEqual(doc["services"], expected) {
+		t.Errorf("Expected %v, got %v", expected, doc["services"])
+	}
+
+	// Without a mirror the generated file is removed again.
+	r.config.RegistryMirror = ""
+	if err := r.writeRegistryMirrorOverride(appDir); err != nil {
+		t.Fatalf("writeRegistryMirrorOverride returned error: %v", err)
+	}
+	if fileExists(filepath.Join(appDir, mirrorOverrideFile)) {
+		t.Errorf("Expected the mirror override to be removed")
+	}
+}
@@ -1,10 +1,16 @@
 package docker_compose
 
 import (
+	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/internal/infra/docker/network"
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/secrets"
 
 	"github.com/docker/docker/client"
 )
@@ -14,8 +20,35 @@ import (
 // The implementation is intentionally split across several files in this package:
 //  - repository.go       – struct definition, constructor, simple accessors
 //  - status.go           – application status related logic
+//  - resources.go        – resource limits and usage of the containers of an app
 //  - operations.go       – high-level lifecycle operations (deploy, stop, restart, etc.)
 //  - compose_cmd.go      – helpers that wrap `docker compose` CLI invocations
+//  - compose_binary.go   – detection of the compose plugin or standalone binary
+//  - docker_client.go    – reconnecting the Docker client after the daemon restarted
+//  - retry.go            – retries of transient `docker compose` failures
+//  - hooks.go            – optional pre/post deployment hook scripts
+//  - deploy_limit.go     – global cap on concurrent compose up/pull operations
+//  - parallel_pull.go    – pulling the images of the services of an app in parallel
+//  - build.go            – building the images of the services of an app that have a build section
+//  - cancel.go           – cancellation of running lifecycle operations
+//  - output.go           – streaming of the output of compose commands while they run
+//  - progress.go         – reporting of the stages lifecycle operations reach
+//  - service_filter.go   – restarting and recreating a subset of the services of an app
+//  - reconcile.go        – re-rendering an app from scratch
+//  - events.go           – lifecycle events of the managed containers
+//  - health_wait.go      – waiting for the services of an app to become healthy after a deploy
+//  - readiness_probe.go  – checking that an app accepts connections after a deploy
+//  - deploy_strategy.go  – recreating or rolling the containers of an app on deploys and updates
+//  - storage_path.go     – app directories placed below per-app storage paths
+//  - secrets.go          – resolution of secret:// variable references
+//  - restart_policy.go   – forced restart policy of all services
+//  - labels.go           – labels attached to the containers of every service
+//  - registry_mirror.go  – pulling Docker Hub images through a registry mirror
+//  - network_policy.go   – validation of the external networks referenced by an app
+//  - image_policy.go     – validation of the registries of the images of an app
+//  - env_files.go        – validation of the env_file references of an app
+//  - file_sources.go     – validation of the files backing the configs and secrets of an app
+//  - git_source.go       – compose files checked out from a git repository
 //  - template_utils.go   – helper functions for rendering template files
 //  - utils.go            – small utility helpers shared by the other files
 //
@@ -30,14 +63,61 @@ type composeRepository struct {
 	client *client.Client
 	mu     sync.RWMutex
 	config *config.Config
+	// newClient recreates client when the Docker daemon cannot be reached; nil keeps the client.
+	newClient DockerClientFactory
+	// dockerReconnectDelay is the initial backoff before client is recreated; zero uses the default.
+	dockerReconnectDelay time.Duration
+
+	// runner executes docker commands; nil uses the docker binary.
+	runner command.Runner
+	// compose selects the compose plugin or a standalone binary; the zero value uses the plugin.
+	compose ComposeCommand
+	// secrets resolves secret:// variable references; nil reads them from the configured secrets directory.
+	secrets secrets.Resolver
+	// networks lists the docker networks that external network references are checked against; nil skips the check.
+	networks repository.DockerNetworkRepository
+	// deploys caps concurrent compose up/pull operations across all apps; nil means no limit.
+	deploys *deployLimiter
+	// operations tracks the running lifecycle operations of every app so that they can be canceled.
+	operations appOperations
+	// outputs holds the receivers of the output of the compose commands run for an app.
+	outputs appOutputs
+	// progress holds the reporters of the stages of the operations run for an app.
+	progress appProgress
+	// metrics measures the lifecycle operations run for an app.
+	metrics operationMetrics
+	// retryDelay is the initial backoff between retries of transient compose failures; zero uses the default.
+	retryDelay time.Duration
+	// healthPollInterval is how often service health is checked while waiting for it; zero uses the default.
+	healthPollInterval time.Duration
 }
 
-// NewComposeRepository creates a new Docker Compose-backed AppRepository implementation.
-func NewComposeRepository(cfg *config.Config, dockerClient *client.Client) repository.AppRepository {
+// NewComposeRepository creates a new Docker Compose-backed AppRepository implementation invoking
+// Docker Compose through compose, see DetectComposeCommand. The Docker client is created with newClient,
+// which is called again to reconnect when the daemon cannot be reached, e.g. after it restarted.
+func NewComposeRepository(cfg *config.Config, newClient DockerClientFactory, compose ComposeCommand) (repository.AppRepository, error) {
+	dockerClient, err := newClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
 	return &composeRepository{
-		client: dockerClient,
-		config: cfg,
+		client:    dockerClient,
+		config:    cfg,
+		newClient: newClient,
+		runner:    command.NewExecRunner(),
+		compose:   compose,
+		secrets:   secrets.NewFileResolver(cfg.GetSecretsDir()),
+		networks:  network.NewDockerNetworkRepository(dockerClient),
+		deploys:   newDeployLimiter(cfg.MaxConcurrentDeploys),
+	}, nil
+}
+
+// PingDocker checks that the Docker daemon responds.
+func (r *composeRepository) PingDocker(ctx context.Context) error {
+	if _, err := r.GetClient().Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping docker daemon: %w", err)
 	}
+	return nil
 }
 
 // GetClient returns the underlying Docker client instance.
@@ -0,0 +1,129 @@
+package docker_compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/log"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// GetAppResources returns the configured limits and the current usage of the containers of appID. Stats
+// are only collected from running containers; stopped containers report their limits and OOM state only.
+func (r *composeRepository) GetAppResources(appID string) (model.AppResources, error) {
+	appName, err := r.getAppNameById(appID)
+	if err != nil {
+		return model.AppResources{}, fmt.Errorf("cannot get app resources: %w", err)
+	}
+	log.Debug("Getting Docker Compose app resources", "app_id", appID, "app_name", appName)
+
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("com.docker.compose.project=%s", appName))
+	filterArgs.Add("label", managedLabel+"=true")
+
+	ctx := context.TODO()
+	dockerContainers, err := r.listContainers(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return model.AppResources{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	result := model.AppResources{
+		AppID:      appID,
+		Containers: make([]model.ContainerResources, 0, len(dockerContainers)),
+	}
+	for _, dockerContainer := range dockerContainers {
+		inspect, err := r.inspectContainer(ctx, dockerContainer.ID)
+		if err != nil {
+			return model.AppResources{}, fmt.Errorf("failed to inspect container %s: %w", dockerContainer.ID, err)
+		}
+
+		resources := containerLimits(inspect)
+		resources.Name = strings.TrimPrefix(dockerContainer.Names[0], "/")
+		if inspect.State != nil && inspect.State.Running {
+			if err := r.containerUsage(ctx, dockerContainer.ID, &resources); err != nil {
+				log.Warn("Failed to get container stats", "app_id", appID, "container_id", dockerContainer.ID, "error", err)
+			}
+		}
+		result.Containers = append(result.Containers, resources)
+	}
+
+	log.Debug("Docker Compose app resources retrieved", "app_id", appID, "containers", len(result.Containers))
+	return result, nil
+}
+
+// containerLimits returns the CPU and memory limits and the OOM state of an inspected container.
+func containerLimits(inspect container.InspectResponse) model.ContainerResources {
+	resources := model.ContainerResources{ID: inspect.ID, RestartCount: inspect.RestartCount}
+	if inspect.HostConfig != nil {
+		limits := inspect.HostConfig.Resources
+		switch {
+		case limits.NanoCPUs > 0:
+			resources.CPULimit = float64(limits.NanoCPUs) / 1e9
+		case limits.CPUQuota > 0:
+			// The kernel applies a period of 100ms when none is configured.
+			period := limits.CPUPeriod
+			if period == 0 {
+				period = 100000
+			}
+			resources.CPULimit = float64(limits.CPUQuota) / float64(period)
+		}
+		if limits.Memory > 0 {
+			resources.MemoryLimitBytes = uint64(limits.Memory)
+		}
+	}
+	if inspect.State != nil {
+		resources.OOMKilled = inspect.State.OOMKilled
+	}
+	return resources
+}
+
+// containerUsage fills the CPU and memory usage of the running container id from a stats sample.
+func (r *composeRepository) containerUsage(ctx context.Context, id string, resources *model.ContainerResources) error {
+	// Without streaming the daemon waits for a second sample, so the previous CPU usage is populated.
+	reader, err := r.GetClient().ContainerStats(ctx, id, false)
+	if err != nil {
+		return err
+	}
+	defer reader.Body.Close()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(reader.Body).Decode(&stats); err != nil {
+		return fmt.Errorf("failed to decode stats: %w", err)
+	}
+	resources.CPUPercent = cpuPercent(stats)
+	resources.MemoryUsageBytes = memoryUsage(stats.MemoryStats)
+	return nil
+}
+
+// cpuPercent computes the CPU usage between the two samples of stats the way `docker stats` does, where
+// 100 is one full CPU.
+func cpuPercent(stats container.StatsResponse) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// memoryUsage returns the memory in use excluding the page cache, like `docker stats`. cgroup v2 reports
+// the cache as inactive_file, cgroup v1 as total_inactive_file.
+func memoryUsage(stats container.MemoryStats) uint64 {
+	cache, ok := stats.Stats["inactive_file"]
+	if !ok {
+		cache = stats.Stats["total_inactive_file"]
+	}
+	if cache > stats.Usage {
+		return 0
+	}
+	return stats.Usage - cache
+}
@@ -0,0 +1,125 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+)
+
+// newFakeResourcesDockerClient returns a Docker client served by a fake daemon that lists containers and
+// answers inspect and stats requests from the given fixtures, keyed by container ID.
+func newFakeResourcesDockerClient(t *testing.T, containers []container.Summary, inspects map[string]container.InspectResponse, stats map[string]container.StatsResponse) *client.Client {
+	t.Helper()
+	return newFakeDockerClientWithHandler(t, func(w http.ResponseWriter, req *http.Request) {
+		path := req.URL.Path[strings.Index(req.URL.Path, "/containers/"):]
+		parts := strings.Split(strings.TrimPrefix(path, "/containers/"), "/")
+		var body interface{}
+		var ok bool
+		switch {
+		case len(parts) == 1 && parts[0] == "json":
+			serveContainerList(w, req, containers)
+			return
+		case len(parts) == 2 && parts[1] == "json":
+			body, ok = inspects[parts[0]]
+		case len(parts) == 2 && parts[1] == "stats":
+			if req.URL.Query().Get("stream") != "0" {
+				http.Error(w, "expected a single stats sample", http.StatusBadRequest)
+				return
+			}
+			body, ok = stats[parts[0]]
+		}
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+func TestGetAppResources(t *testing.T) {
+	cfg := &config.Config{BasePath: t.TempDir()}
+	writeRevision(t, filepath.Join(cfg.GetAppsTemplatesPath(), "app", "1"))
+
+	labels := map[string]string{"com.docker.compose.project": "demo", managedLabel: "true"}
+	containers := []container.Summary{
+		{ID: "limited", Names: []string{"/demo-db-1"}, Labels: labels},
+		{ID: "unlimited", Names: []string{"/demo-web-1"}, Labels: labels},
+		{ID: "stopped", Names: []string{"/demo-worker-1"}, Labels: labels},
+	}
+	inspects := map[string]container.InspectResponse{
+		"limited": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:           "limited",
+				RestartCount: 2,
+				State:        &container.State{Running: true},
+				HostConfig:   &container.HostConfig{Resources: container.Resources{NanoCPUs: 1500000000, Memory: 256 << 20}},
+			},
+		},
+		"unlimited": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:         "unlimited",
+				State:      &container.State{Running: true},
+				HostConfig: &container.HostConfig{},
+			},
+		},
+		"stopped": {
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:         "stopped",
+				State:      &container.State{OOMKilled: true, ExitCode: 137},
+				HostConfig: &container.HostConfig{Resources: container.Resources{CPUQuota: 50000, Memory: 64 << 20}},
+			},
+		},
+	}
+	sample := func(cpu, system, memory uint64) container.StatsResponse {
+		return container.StatsResponse{
+			CPUStats:    container.CPUStats{CPUUsage: container.CPUUsage{TotalUsage: cpu}, SystemUsage: system, OnlineCPUs: 4},
+			PreCPUStats: container.CPUStats{CPUUsage: container.CPUUsage{TotalUsage: 1000}, SystemUsage: 10000},
+			MemoryStats: container.MemoryStats{Usage: memory, Stats: map[string]uint64{"inactive_file": 1 << 20}},
+		}
+	}
+	stats := map[string]container.StatsResponse{
+		"limited":   sample(1500, 20000, 101<<20),
+		"unlimited": sample(1000, 20000, 33<<20),
+	}
+	r := &composeRepository{client: newFakeResourcesDockerClient(t, containers, inspects, stats), config: cfg}
+
+	result, err := r.GetAppResources("app")
+	if err != nil {
+		t.Fatalf("GetAppResources returned error: %v", err)
+	}
+	if result.AppID != "app" || len(result.Containers) != 3 {
+		t.Fatalf("Expected three containers of app, got %+v", result)
+	}
+
+	expected := []model.ContainerResources{
+		{ID: "limited", Name: "demo-db-1", CPULimit: 1.5, MemoryLimitBytes: 256 << 20, CPUPercent: 20, MemoryUsageBytes: 100 << 20, RestartCount: 2},
+		{ID: "unlimited", Name: "demo-web-1", MemoryUsageBytes: 32 << 20},
+		{ID: "stopped", Name: "demo-worker-1", CPULimit: 0.5, MemoryLimitBytes: 64 << 20, OOMKilled: true},
+	}
+	for i, want := range expected {
+		got := result.Containers[i]
+		if math.Abs(got.CPUPercent-want.CPUPercent) < 1e-9 {
+			got.CPUPercent = want.CPUPercent
+		}
+		if got != want {
+			t.Errorf("Container %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestMemoryUsageFallsBackToCgroupV1Cache(t *testing.T) {
+	stats := container.MemoryStats{Usage: 10 << 20, Stats: map[string]uint64{"total_inactive_file": 4 << 20}}
+	if got := memoryUsage(stats); got != 6<<20 {
+		t.Errorf("Expected usage without the page cache, got %d", got)
+	}
+}
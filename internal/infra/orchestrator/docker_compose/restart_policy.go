@@ -0,0 +1,97 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/yaml"
+)
+
+// restartOverrideFile is the compose file generated into a rendered app to force the restart policy of
+// every service. It is applied after all other compose files so that it wins over them.
+const restartOverrideFile = "compose.winterflow-restart.yml"
+
+// restartPolicyPattern matches the restart policies supported by Docker Compose.
+var restartPolicyPattern = regexp.MustCompile(`^(no|always|unless-stopped|on-failure(:[1-9][0-9]*)?)$`)
+
+// restartPolicyOverride returns the restart policy forced for the app described by cfg: the app level
+// override wins over the agent wide one. An empty result means the compose files are used as is.
+func (r *composeRepository) restartPolicyOverride(cfg *model.AppConfig) (string, error) {
+	policy := ""
+	if r.config != nil {
+		policy = r.config.RestartPolicyOverride
+	}
+	if cfg != nil && cfg.RestartPolicyOverride != "" {
+		policy = cfg.RestartPolicyOverride
+	}
+	if policy != "" && !restartPolicyPattern.MatchString(policy) {
+		return "", fmt.Errorf("invalid restart policy override %q: expected no, always, unless-stopped or on-failure[:max-retries]", policy)
+	}
+	return policy, nil
+}
+
+// writeRestartPolicyOverride generates restartOverrideFile in destDir, setting the restart policy of every
+// service defined by the rendered compose files. Without an override any previously generated file is
+// removed and the rendered files are left untouched.
+func (r *composeRepository) writeRestartPolicyOverride(destDir string, cfg *model.AppConfig) error {
+	overridePath := filepath.Join(destDir, restartOverrideFile)
+	if err := os.Remove(overridePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", restartOverrideFile, err)
+	}
+
+	policy, err := r.restartPolicyOverride(cfg)
+	if err != nil || policy == "" {
+		return err
+	}
+
+	names, err := r.composeServices(destDir)
+	if err != nil || len(names) == 0 {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by the WinterFlow agent to force the restart policy of every service.\nservices:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %s:\n    restart: %s\n", strconv.Quote(name), strconv.Quote(policy))
+	}
+	if err := os.WriteFile(overridePath, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", restartOverrideFile, err)
+	}
+	return nil
+}
+
+// composeServices returns the sorted names of the services defined by the rendered compose files in destDir.
+func (r *composeRepository) composeServices(destDir string) ([]string, error) {
+	files, err := r.renderedComposeFiles(destDir)
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]struct{})
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name := range definitions {
+			services[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
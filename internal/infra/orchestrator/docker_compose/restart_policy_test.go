@@ -0,0 +1,106 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/yaml"
+)
+
+const restartPolicyCompose = "services:\n  web:\n    image: nginx\n    restart: always\n  db:\n    image: postgres\n"
+
+// newRestartPolicyDir returns an app directory holding restartPolicyCompose.
+func newRestartPolicyDir(t *testing.T) string {
+	t.Helper()
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(restartPolicyCompose), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+	return appDir
+}
+
+// readRestartPolicies returns the restart policy per service of the generated override file.
+func readRestartPolicies(t *testing.T, appDir string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(appDir, restartOverrideFile))
+	if err != nil {
+		t.Fatalf("Failed to read restart override: %v", err)
+	}
+	doc, err := yaml.UnmarshalMap(data)
+	if err != nil {
+		t.Fatalf("Generated override is not valid YAML: %v", err)
+	}
+	policies := make(map[string]interface{})
+	for name, definition := range doc["services"].(map[string]interface{}) {
+		policies[name] = definition.(map[string]interface{})["restart"]
+	}
+	return policies
+}
+
+func TestRestartPolicyOverrideAppliesToEveryService(t *testing.T) {
+	appDir := newRestartPolicyDir(t)
+	r := &composeRepository{config: &config.Config{RestartPolicyOverride: "unless-stopped"}}
+
+	if err := r.writeRestartPolicyOverride(appDir, &model.AppConfig{}); err != nil {
+		t.Fatalf("writeRestartPolicyOverride returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{"web": "unless-stopped", "db": "unless-stopped"}
+	if got := readRestartPolicies(t, appDir); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+	if len(files) == 0 || filepath.Base(files[len(files)-1]) != restartOverrideFile {
+		t.Errorf("Expected the restart override to be applied last, got %v", files)
+	}
+}
+
+func TestRestartPolicyOverrideAppLevelWins(t *testing.T) {
+	appDir := newRestartPolicyDir(t)
+	r := &composeRepository{config: &config.Config{RestartPolicyOverride: "always"}}
+
+	if err := r.writeRestartPolicyOverride(appDir, &model.AppConfig{RestartPolicyOverride: "on-failure:3"}); err != nil {
+		t.Fatalf("writeRestartPolicyOverride returned error: %v", err)
+	}
+	if got := readRestartPolicies(t, appDir)["db"]; got != "on-failure:3" {
+		t.Errorf("Expected the app level policy, got %v", got)
+	}
+}
+
+func TestEmptyRestartPolicyOverrideLeavesFilesUntouched(t *testing.T) {
+	appDir := newRestartPolicyDir(t)
+	stale := filepath.Join(appDir, restartOverrideFile)
+	if err := os.WriteFile(stale, []byte("services: {}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write stale override: %v", err)
+	}
+	r := &composeRepository{config: &config.Config{}}
+
+	if err := r.writeRestartPolicyOverride(appDir, &model.AppConfig{}); err != nil {
+		t.Fatalf("writeRestartPolicyOverride returned error: %v", err)
+	}
+	if fileExists(stale) {
+		t.Error("Expected the previously generated override to be removed")
+	}
+	data, err := os.ReadFile(filepath.Join(appDir, "compose.yml"))
+	if err != nil || string(data) != restartPolicyCompose {
+		t.Errorf("Expected compose.yml to be untouched, got %q (%v)", data, err)
+	}
+}
+
+func TestRestartPolicyOverrideRejectsInvalidPolicy(t *testing.T) {
+	appDir := newRestartPolicyDir(t)
+	for _, policy := range []string{"sometimes", "on-failure:0", "on-failure:x"} {
+		r := &composeRepository{config: &config.Config{RestartPolicyOverride: policy}}
+		if err := r.writeRestartPolicyOverride(appDir, &model.AppConfig{}); err == nil {
+			t.Errorf("Expected policy %q to be rejected", policy)
+		}
+	}
+}
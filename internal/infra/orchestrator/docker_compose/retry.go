@@ -0,0 +1,86 @@
+package docker_compose
+
+import (
+	"regexp"
+	"time"
+
+	"winterflow-agent/pkg/backoff"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// defaultRetryDelay is the initial delay between retries of a transient compose failure.
+	defaultRetryDelay = 2 * time.Second
+	// maxRetryDelay caps the exponential backoff between retries.
+	maxRetryDelay = 30 * time.Second
+)
+
+// runDockerComposeWithRetry runs `docker compose` like runDockerComposeWithEnv but retries failures
+// whose output matches one of the configured transient error patterns, such as registry timeouts
+// during pull. Other failures and the final failure after the last retry are returned as is.
+func (r *composeRepository) runDockerComposeWithRetry(dir string, env []string, args ...string) error {
+	matchers := r.transientErrorMatchers()
+	attempts := 0
+	if r.config != nil {
+		attempts = r.config.GetComposeRetryAttempts()
+	}
+
+	delay := r.retryDelay
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	retryBackoff := backoff.New(delay, maxRetryDelay)
+
+	var done <-chan struct{}
+	if ctx := r.operations.context(dir); ctx != nil {
+		done = ctx.Done()
+	}
+
+	for retry := 0; ; retry++ {
+		output, err := r.execDockerCompose(dir, env, args...)
+		if err == nil {
+			return nil
+		}
+		if retry >= attempts || !isTransientFailure(output, matchers) {
+			return err
+		}
+
+		wait := retryBackoff.Next()
+		log.Warn("Transient docker compose failure, retrying", "dir", dir, "args", args, "retry", retry+1, "max_retries", attempts, "wait", wait)
+		select {
+		case <-time.After(wait):
+		case <-done:
+			// The operation was canceled during the backoff.
+			return err
+		}
+	}
+}
+
+// transientErrorMatchers compiles the configured transient error patterns. Invalid patterns are
+// logged and skipped so that a typo does not disable retries altogether.
+func (r *composeRepository) transientErrorMatchers() []*regexp.Regexp {
+	if r.config == nil {
+		return nil
+	}
+	patterns := r.config.GetComposeRetryPatterns()
+	matchers := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warn("Ignoring invalid compose retry pattern", "pattern", pattern, "error", err)
+			continue
+		}
+		matchers = append(matchers, re)
+	}
+	return matchers
+}
+
+// isTransientFailure reports whether output matches any of the matchers.
+func isTransientFailure(output string, matchers []*regexp.Regexp) bool {
+	for _, re := range matchers {
+		if re.MatchString(output) {
+			return true
+		}
+	}
+	return false
+}
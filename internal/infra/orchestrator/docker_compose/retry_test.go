@@ -0,0 +1,81 @@
+package docker_compose
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// failingRunner returns a fake runner whose first invocations fail with the given outputs.
+func failingRunner(outputs ...string) *command.FakeRunner {
+	runner := &command.FakeRunner{}
+	for _, output := range outputs {
+		runner.Results = append(runner.Results, command.FakeResult{Stderr: []byte(output), Err: errors.New("exit status 1")})
+	}
+	return runner
+}
+
+func newRetryRepository(t *testing.T, cfg *config.Config, runner *command.FakeRunner) *composeRepository {
+	t.Helper()
+	r := newTestRepository(t, cfg, runner)
+	r.retryDelay = time.Millisecond
+	return r
+}
+
+func TestComposeRetrySucceedsAfterTransientFailures(t *testing.T) {
+	runner := failingRunner(
+		"Error response from daemon: Get \"https://registry-1.docker.io/v2/\": net/http: TLS handshake timeout",
+		"read tcp 10.0.0.2:443: connection reset by peer",
+	)
+	r := newRetryRepository(t, &config.Config{}, runner)
+
+	if err := r.runDockerComposeWithRetry(t.TempDir(), nil, "pull"); err != nil {
+		t.Fatalf("Expected pull to succeed after retries, got %v", err)
+	}
+	if len(runner.Commands()) != 3 {
+		t.Errorf("Expected 3 calls, got %d", len(runner.Commands()))
+	}
+}
+
+func TestComposeRetrySkipsPermanentFailures(t *testing.T) {
+	runner := failingRunner("manifest for nginx:missing not found")
+	r := newRetryRepository(t, &config.Config{}, runner)
+
+	if err := r.runDockerComposeWithRetry(t.TempDir(), nil, "pull"); err == nil {
+		t.Fatal("Expected permanent failure to be returned")
+	}
+	if len(runner.Commands()) != 1 {
+		t.Errorf("Expected a single call, got %d", len(runner.Commands()))
+	}
+}
+
+func TestComposeRetryIsCapped(t *testing.T) {
+	transient := "toomanyrequests: You have reached your pull rate limit"
+	runner := failingRunner(transient, transient, transient, transient)
+	r := newRetryRepository(t, &config.Config{ComposeRetryAttempts: 2}, runner)
+
+	err := r.runDockerComposeWithRetry(t.TempDir(), nil, "up", "-d")
+	if err == nil || !strings.Contains(err.Error(), "up -d") {
+		t.Fatalf("Expected final compose error to be surfaced, got %v", err)
+	}
+	if len(runner.Commands()) != 3 {
+		t.Errorf("Expected 3 calls, got %d", len(runner.Commands()))
+	}
+}
+
+func TestComposeRetryUsesConfiguredPatterns(t *testing.T) {
+	runner := failingRunner("registry mirror unavailable")
+	cfg := &config.Config{ComposeRetryPatterns: []string{"(", "mirror unavailable"}}
+	r := newRetryRepository(t, cfg, runner)
+
+	if err := r.runDockerComposeWithRetry(t.TempDir(), nil, "pull"); err != nil {
+		t.Fatalf("Expected configured pattern to trigger a retry, got %v", err)
+	}
+	if len(runner.Commands()) != 2 {
+		t.Errorf("Expected 2 calls, got %d", len(runner.Commands()))
+	}
+}
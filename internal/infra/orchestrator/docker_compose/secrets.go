@@ -0,0 +1,119 @@
+package docker_compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"winterflow-agent/pkg/secrets"
+)
+
+// secretReferencesFile lists the variables of a rendered app that reference host secrets, mapping each
+// variable name to its secret path. It holds references only, never the resolved values.
+const secretReferencesFile = ".winterflow.secrets.json"
+
+// secretResolver returns the resolver of secret:// variable references.
+func (r *composeRepository) secretResolver() secrets.Resolver {
+	if r.secrets != nil {
+		return r.secrets
+	}
+	return secrets.NewFileResolver(r.config.GetSecretsDir())
+}
+
+// secretReferences returns the variables of vars that are secret:// references, mapped to their secret path.
+func secretReferences(vars map[string]string) map[string]string {
+	refs := make(map[string]string)
+	for name, value := range vars {
+		if path, ok := secrets.ParseReference(value); ok {
+			refs[name] = path
+		}
+	}
+	return refs
+}
+
+// resolveSecrets resolves every reference of refs, failing on the first secret that cannot be resolved.
+func (r *composeRepository) resolveSecrets(refs map[string]string) (map[string]string, error) {
+	resolver := r.secretResolver()
+	values := make(map[string]string, len(refs))
+	for name, path := range refs {
+		value, err := resolver.Resolve(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secret for variable %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// applySecretVariables resolves the secret:// references of vars right before rendering into destDir.
+//
+// When secrets may be written to disk the references are replaced by their values. Otherwise every
+// referencing variable is rendered as ${NAME}, is left out of the env file and its reference is recorded
+// in destDir, so that `docker compose` receives the value through its process environment only. The
+// returned names are the variables to drop from the env file.
+func (r *composeRepository) applySecretVariables(destDir string, vars map[string]string) ([]string, error) {
+	refs := secretReferences(vars)
+	refsPath := filepath.Join(destDir, secretReferencesFile)
+	if len(refs) == 0 {
+		if err := os.Remove(refsPath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove %s: %w", secretReferencesFile, err)
+		}
+		return nil, nil
+	}
+
+	values, err := r.resolveSecrets(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config.AllowSecretsOnDisk {
+		for name, value := range values {
+			vars[name] = value
+		}
+		if err := os.Remove(refsPath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove %s: %w", secretReferencesFile, err)
+		}
+		return nil, nil
+	}
+
+	data, err := json.MarshalIndent(refs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret references: %w", err)
+	}
+	if err := os.WriteFile(refsPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", secretReferencesFile, err)
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		vars[name] = "${" + name + "}"
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// secretEnv resolves the secret references recorded in appDir into KEY=VALUE environment entries for
+// `docker compose`. Apps without secret references yield no entries.
+func (r *composeRepository) secretEnv(appDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(appDir, secretReferencesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", secretReferencesFile, err)
+	}
+
+	var refs map[string]string
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", secretReferencesFile, err)
+	}
+
+	values, err := r.resolveSecrets(refs)
+	if err != nil {
+		return nil, err
+	}
+	return hookEnv(values), nil
+}
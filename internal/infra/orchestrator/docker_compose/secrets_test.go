@@ -0,0 +1,105 @@
+package docker_compose
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/secrets"
+)
+
+// newSecretRepository prepares revision 1 of app "app" whose DB_PASSWORD references the host secret
+// db/password and returns a repository deploying it through a fake runner.
+func newSecretRepository(t *testing.T, cfg *config.Config, secretValue string) (*composeRepository, *command.FakeRunner) {
+	t.Helper()
+	runner := &command.FakeRunner{}
+	r := newTestRepository(t, cfg, runner)
+
+	templateDir := writeTestRevision(t, r)
+	overrides := `{"DB_PASSWORD":"secret://db/password"}`
+	if err := os.WriteFile(filepath.Join(templateDir, "vars", "overrides.json"), []byte(overrides), 0o644); err != nil {
+		t.Fatalf("Failed to write overrides: %v", err)
+	}
+
+	secretsDir := t.TempDir()
+	if secretValue != "" {
+		if err := os.MkdirAll(filepath.Join(secretsDir, "db"), 0o700); err != nil {
+			t.Fatalf("Failed to create secrets directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(secretsDir, "db", "password"), []byte(secretValue+"\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write secret: %v", err)
+		}
+	}
+	r.secrets = secrets.NewFileResolver(secretsDir)
+	return r, runner
+}
+
+// readAppFile returns the content of a rendered app file.
+func readAppFile(t *testing.T, r *composeRepository, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(r.getAppDir("app"), name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestDeployAppPassesSecretsThroughEnvironment(t *testing.T) {
+	r, runner := newSecretRepository(t, &config.Config{}, "hunter2")
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	compose := readAppFile(t, r, "compose.yml")
+	if strings.Contains(compose, "hunter2") || !strings.Contains(compose, "PASSWORD: ${DB_PASSWORD}") {
+		t.Errorf("Expected the secret to stay a compose variable, got:\n%s", compose)
+	}
+	if envFile := readAppFile(t, r, ".winterflow.env"); strings.Contains(envFile, "DB_PASSWORD") {
+		t.Errorf("Expected the secret to be left out of the env file, got:\n%s", envFile)
+	}
+
+	commands := runner.Commands()
+	if len(commands) == 0 {
+		t.Fatal("Expected docker compose to be invoked")
+	}
+	up := commands[len(commands)-1]
+	found := false
+	for _, entry := range up.Env {
+		found = found || entry == "DB_PASSWORD=hunter2"
+	}
+	if !found {
+		t.Errorf("Expected the resolved secret in the compose environment, got %v", up.Env)
+	}
+}
+
+func TestDeployAppRendersSecretsWhenAllowed(t *testing.T) {
+	r, _ := newSecretRepository(t, &config.Config{AllowSecretsOnDisk: true}, "hunter2")
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	if compose := readAppFile(t, r, "compose.yml"); !strings.Contains(compose, "PASSWORD: hunter2") {
+		t.Errorf("Expected the resolved secret in the rendered file, got:\n%s", compose)
+	}
+	if fileExists(filepath.Join(r.getAppDir("app"), secretReferencesFile)) {
+		t.Errorf("Expected no secret references file when secrets are rendered")
+	}
+}
+
+func TestDeployAppFailsOnMissingSecret(t *testing.T) {
+	r, runner := newSecretRepository(t, &config.Config{}, "")
+
+	err := r.DeployApp("app")
+	if !errors.Is(err, secrets.ErrNotFound) {
+		t.Fatalf("Expected a missing secret error, got %v", err)
+	}
+	if commands := runner.Commands(); len(commands) != 0 {
+		t.Errorf("Expected no docker compose invocation, got %v", commandNames(commands))
+	}
+}
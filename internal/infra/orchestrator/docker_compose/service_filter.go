@@ -0,0 +1,102 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/log"
+)
+
+// RestartServices restarts the containers of the named services of the app in place.
+func (r *composeRepository) RestartServices(appID string, services []string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationRestartServices, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
+	appDir, err := r.renderedAppDir(appID)
+	if err != nil {
+		return err
+	}
+	if err := r.checkServices(appDir, services); err != nil {
+		return err
+	}
+
+	r.reportStage(appID, model.DeployStageStarting)
+	if err := r.composeRestartServices(appDir, services); err != nil {
+		return fmt.Errorf("docker compose restart failed: %w", err)
+	}
+
+	log.Info("[Restart] successfully restarted services", "app_id", appID, "services", services)
+	return nil
+}
+
+// RecreateServices recreates the containers of the named services of the app from the images already
+// present on the host. Their dependencies are left as they are.
+func (r *composeRepository) RecreateServices(appID string, services []string) (err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationRecreateServices, time.Now(), &err)
+	defer func() { r.reportOutcome(appID, err) }()
+
+	appDir, err := r.renderedAppDir(appID)
+	if err != nil {
+		return err
+	}
+	if err := r.checkServices(appDir, services); err != nil {
+		return err
+	}
+
+	r.reportStage(appID, model.DeployStageStarting)
+	if err := r.composeUpServices(appDir, services, "--force-recreate", "--no-deps"); err != nil {
+		return fmt.Errorf("docker compose up --force-recreate failed: %w", err)
+	}
+	r.reportStage(appID, model.DeployStageWaitingHealthy)
+	if err := r.waitForHealthy(appID, appDir); err != nil {
+		return err
+	}
+	if err := r.waitForReady(appID, appDir); err != nil {
+		return err
+	}
+
+	log.Info("[Recreate] successfully recreated services", "app_id", appID, "services", services)
+	return nil
+}
+
+// renderedAppDir returns the directory of the rendered app, failing when the app has not been rendered.
+func (r *composeRepository) renderedAppDir(appID string) (string, error) {
+	if err := ensureDir(r.config.GetAppsPath()); err != nil {
+		return "", fmt.Errorf("failed to ensure apps base directory exists: %w", err)
+	}
+	appDir := r.getAppDir(appID)
+	if _, err := os.Stat(appDir); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("app directory %s does not exist", appDir)
+		}
+		return "", fmt.Errorf("failed to stat app directory: %w", err)
+	}
+	return appDir, nil
+}
+
+// checkServices verifies that services is not empty and that the compose files rendered in appDir define
+// each of them.
+func (r *composeRepository) checkServices(appDir string, services []string) error {
+	if len(services) == 0 {
+		return fmt.Errorf("no services given")
+	}
+	defined, err := r.composeServices(appDir)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(defined))
+	for _, name := range defined {
+		known[name] = true
+	}
+	for _, service := range services {
+		if !known[service] {
+			return fmt.Errorf("unknown service %q: the app defines %s", service, strings.Join(defined, ", "))
+		}
+	}
+	return nil
+}
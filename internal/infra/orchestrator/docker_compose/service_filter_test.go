@@ -0,0 +1,57 @@
+package docker_compose
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRestartServicesRestartsASingleService(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture("web", "db"), `{"id":"app","name":"demo"}`)
+
+	if err := r.RestartServices("app", []string{"web"}); err != nil {
+		t.Fatalf("RestartServices returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	if want := []string{"restart web"}; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
+
+func TestRestartServicesRestartsSeveralServices(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture("web", "db", "proxy"), `{"id":"app","name":"demo"}`)
+
+	if err := r.RestartServices("app", []string{"web", "proxy"}); err != nil {
+		t.Fatalf("RestartServices returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	if want := []string{"restart web proxy"}; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
+
+func TestRestartServicesRejectsUnknownService(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture("web"), `{"id":"app","name":"demo"}`)
+
+	err := r.RestartServices("app", []string{"web", "cache"})
+	if err == nil || !strings.Contains(err.Error(), `unknown service "cache"`) {
+		t.Fatalf("Expected an unknown service error, got %v", err)
+	}
+	if got := runner.Commands(); len(got) != 0 {
+		t.Errorf("Expected docker compose not to be invoked, got %q", composeSubcommands(got))
+	}
+}
+
+func TestRecreateServicesRecreatesOnlyTheNamedServices(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture("web", "db"), `{"id":"app","name":"demo","scale":{"web":2,"db":1}}`)
+
+	if err := r.RecreateServices("app", []string{"web"}); err != nil {
+		t.Fatalf("RecreateServices returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	if want := []string{"up -d --force-recreate --no-deps --scale web=2 web"}; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected commands %q, got %q", want, got)
+	}
+}
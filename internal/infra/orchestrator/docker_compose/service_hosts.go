@@ -0,0 +1,154 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"winterflow-agent/pkg/yaml"
+)
+
+// hostsOverrideFile is the compose file generated into a rendered app to add the configured DNS servers and
+// extra hosts to its services.
+const hostsOverrideFile = "compose.winterflow-hosts.yml"
+
+// serviceHosts holds the DNS servers and the host names of the extra hosts a service sets.
+type serviceHosts struct {
+	dns   map[string]bool
+	hosts map[string]bool
+}
+
+// writeServiceHostsOverride generates hostsOverrideFile in destDir, adding the configured service DNS servers
+// and extra hosts to every service of the rendered compose files. Compose appends the entries of the override
+// to those of the service, so only servers the service does not list yet and host names it does not map yet
+// are added; the settings of the service win. Without configured entries, or when every service already sets
+// them, any previously generated file is removed.
+func (r *composeRepository) writeServiceHostsOverride(destDir string) error {
+	overridePath := filepath.Join(destDir, hostsOverrideFile)
+	if err := os.Remove(overridePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", hostsOverrideFile, err)
+	}
+	if r.config == nil || (len(r.config.ServiceDNS) == 0 && len(r.config.ServiceExtraHosts) == 0) {
+		return nil
+	}
+
+	services, err := r.composeServiceHosts(destDir)
+	if err != nil || len(services) == 0 {
+		return err
+	}
+
+	hostNames := make([]string, 0, len(r.config.ServiceExtraHosts))
+	for host := range r.config.ServiceExtraHosts {
+		hostNames = append(hostNames, host)
+	}
+	sort.Strings(hostNames)
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		existing := services[name]
+		var dns, extraHosts []string
+		for _, server := range r.config.ServiceDNS {
+			if !existing.dns[server] {
+				existing.dns[server] = true
+				dns = append(dns, server)
+			}
+		}
+		for _, host := range hostNames {
+			if !existing.hosts[host] {
+				extraHosts = append(extraHosts, host+":"+r.config.ServiceExtraHosts[host])
+			}
+		}
+		if len(dns) == 0 && len(extraHosts) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s:\n", strconv.Quote(name))
+		if len(dns) > 0 {
+			b.WriteString("    dns:\n")
+			for _, server := range dns {
+				fmt.Fprintf(&b, "      - %s\n", strconv.Quote(server))
+			}
+		}
+		if len(extraHosts) > 0 {
+			b.WriteString("    extra_hosts:\n")
+			for _, entry := range extraHosts {
+				fmt.Fprintf(&b, "      - %s\n", strconv.Quote(entry))
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+
+	content := "# Generated by the WinterFlow agent to add the configured DNS servers and extra hosts.\nservices:\n" + b.String()
+	if err := os.WriteFile(overridePath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", hostsOverrideFile, err)
+	}
+	return nil
+}
+
+// composeServiceHosts returns the DNS servers and extra host names every service of the rendered compose
+// files in destDir sets, across all files as compose merges them.
+func (r *composeRepository) composeServiceHosts(destDir string) (map[string]serviceHosts, error) {
+	files, err := r.renderedComposeFiles(destDir)
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]serviceHosts)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+		}
+		doc, err := yaml.UnmarshalMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(file), err)
+		}
+		definitions, _ := doc["services"].(map[string]interface{})
+		for name, raw := range definitions {
+			existing, ok := services[name]
+			if !ok {
+				existing = serviceHosts{dns: make(map[string]bool), hosts: make(map[string]bool)}
+				services[name] = existing
+			}
+			definition, _ := raw.(map[string]interface{})
+			switch dns := definition["dns"].(type) {
+			case string:
+				existing.dns[dns] = true
+			case []interface{}:
+				for _, server := range dns {
+					existing.dns[fmt.Sprint(server)] = true
+				}
+			}
+			switch extraHosts := definition["extra_hosts"].(type) {
+			case map[string]interface{}:
+				for host := range extraHosts {
+					existing.hosts[host] = true
+				}
+			case []interface{}:
+				for _, entry := range extraHosts {
+					existing.hosts[extraHostName(fmt.Sprint(entry))] = true
+				}
+			}
+		}
+	}
+	return services, nil
+}
+
+// extraHostName returns the host name of an extra_hosts entry in the "host:ip" or "host=ip" form.
+func extraHostName(entry string) string {
+	if host, _, found := strings.Cut(entry, "="); found {
+		return host
+	}
+	host, _, _ := strings.Cut(entry, ":")
+	return host
+}
@@ -0,0 +1,142 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/yaml"
+)
+
+// newServiceHostsDir returns an app directory holding compose.
+func newServiceHostsDir(t *testing.T, compose string) string {
+	t.Helper()
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "compose.yml"), []byte(compose), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.yml: %v", err)
+	}
+	return appDir
+}
+
+// readServiceHosts returns the services of the generated hosts override file.
+func readServiceHosts(t *testing.T, appDir string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(appDir, hostsOverrideFile))
+	if err != nil {
+		t.Fatalf("Failed to read hosts override: %v", err)
+	}
+	doc, err := yaml.UnmarshalMap(data)
+	if err != nil {
+		t.Fatalf("Generated override is not valid YAML: %v", err)
+	}
+	services, _ := doc["services"].(map[string]interface{})
+	return services
+}
+
+func newServiceHostsRepository() *composeRepository {
+	return &composeRepository{config: &config.Config{
+		ServiceDNS:        []string{"10.0.0.53", "1.1.1.1"},
+		ServiceExtraHosts: map[string]string{"db.internal": "10.0.0.5", "host.docker.internal": "host-gateway"},
+	}}
+}
+
+func TestServiceHostsOverrideAppliesToEveryService(t *testing.T) {
+	appDir := newServiceHostsDir(t, "services:\n  web:\n    image: nginx\n  worker:\n    image: busybox\n")
+	r := newServiceHostsRepository()
+
+	if err := r.writeServiceHostsOverride(appDir); err != nil {
+		t.Fatalf("writeServiceHostsOverride returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"dns":         []interface{}{"10.0.0.53", "1.1.1.1"},
+		"extra_hosts": []interface{}{"db.internal:10.0.0.5", "host.docker.internal:host-gateway"},
+	}
+	services := readServiceHosts(t, appDir)
+	for _, name := range []string{"web", "worker"} {
+		if !reflect.DeepEqual(services[name], expected) {
+			t.Errorf("Expected %s to get %v, got %v", name, expected, services[name])
+		}
+	}
+
+	files, err := r.detectComposeFiles(appDir)
+	if err != nil {
+		t.Fatalf("detectComposeFiles returned error: %v", err)
+	}
+	if len(files) != 2 || filepath.Base(files[1]) != hostsOverrideFile {
+		t.Errorf("Expected the hosts override to be applied after compose.yml, got %v", files)
+	}
+}
+
+func TestServiceHostsOverrideMergesWithServiceSettings(t *testing.T) {
+	compose := `services:
+  list:
+    image: nginx
+    dns: 1.1.1.1
+    extra_hosts:
+      - "db.internal:192.168.1.5"
+  mapping:
+    image: nginx
+    dns:
+      - 10.0.0.53
+      - 1.1.1.1
+    extra_hosts:
+      db.internal: 192.168.1.5
+      host.docker.internal: 192.168.1.1
+`
+	appDir := newServiceHostsDir(t, compose)
+	r := newServiceHostsRepository()
+
+	if err := r.writeServiceHostsOverride(appDir); err != nil {
+		t.Fatalf("writeServiceHostsOverride returned error: %v", err)
+	}
+
+	services := readServiceHosts(t, appDir)
+	expected := map[string]interface{}{
+		"dns":         []interface{}{"10.0.0.53"},
+		"extra_hosts": []interface{}{"host.docker.internal:host-gateway"},
+	}
+	if !reflect.DeepEqual(services["list"], expected) {
+		t.Errorf("Expected only the missing entries to be added, got %v", services["list"])
+	}
+	if _, ok := services["mapping"]; ok {
+		t.Errorf("Expected a service that sets every entry to be left out, got %v", services["mapping"])
+	}
+}
+
+func TestServiceHostsOverrideEntriesSetInOverrideFiles(t *testing.T) {
+	appDir := newServiceHostsDir(t, "services:\n  web:\n    image: nginx\n")
+	override := "services:\n  web:\n    dns: [10.0.0.53, 1.1.1.1]\n    extra_hosts: [\"db.internal=192.168.1.5\", \"host.docker.internal:192.168.1.1\"]\n"
+	if err := os.WriteFile(filepath.Join(appDir, "compose.override.yml"), []byte(override), 0o644); err != nil {
+		t.Fatalf("Failed to write compose.override.yml: %v", err)
+	}
+	stale := filepath.Join(appDir, hostsOverrideFile)
+	if err := os.WriteFile(stale, []byte("services: {}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write stale override: %v", err)
+	}
+
+	if err := newServiceHostsRepository().writeServiceHostsOverride(appDir); err != nil {
+		t.Fatalf("writeServiceHostsOverride returned error: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected no override when the service sets every entry, got %v", err)
+	}
+}
+
+func TestServiceHostsOverrideWithoutConfiguredEntries(t *testing.T) {
+	appDir := newServiceHostsDir(t, "services:\n  web:\n    image: nginx\n")
+	stale := filepath.Join(appDir, hostsOverrideFile)
+	if err := os.WriteFile(stale, []byte("services: {}\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write stale override: %v", err)
+	}
+
+	r := &composeRepository{config: &config.Config{}}
+	if err := r.writeServiceHostsOverride(appDir); err != nil {
+		t.Fatalf("writeServiceHostsOverride returned error: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale override to be removed, got %v", err)
+	}
+}
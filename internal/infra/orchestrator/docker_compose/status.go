@@ -29,12 +29,14 @@ func (r *composeRepository) GetAppStatus(appID string) (model.GetAppStatusResult
 	// a stopped application (directory exists but no containers) and an unknown one.
 	appDirExists := dirExists(appDir)
 
-	// List containers that belong to the compose project.
+	// List containers that belong to the compose project and were deployed by the agent, ignoring
+	// unmanaged stacks that happen to use the same project name.
 	filterArgs := filters.NewArgs()
 	filterArgs.Add("label", fmt.Sprintf("com.docker.compose.project=%s", appName))
+	filterArgs.Add("label", managedLabel+"=true")
 
 	ctx := context.TODO()
-	dockerContainers, err := r.client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	dockerContainers, err := r.listContainers(ctx, container.ListOptions{All: true, Filters: filterArgs})
 	if err != nil {
 		log.Error("Failed to list containers for app", "app_id", appID, "error", err)
 		return model.GetAppStatusResult{}, fmt.Errorf("failed to list containers: %w", err)
@@ -56,6 +58,10 @@ func (r *composeRepository) GetAppStatus(appID string) (model.GetAppStatusResult
 			c.Error = fmt.Sprintf("Container in problematic state: %s", dockerContainer.Status)
 		}
 		containerApp.Containers = append(containerApp.Containers, c)
+
+		// Containers of a revision that is still being rolled out may carry different revisions;
+		// report the newest one.
+		containerApp.Revision = max(containerApp.Revision, containerRevision(dockerContainer.Labels))
 	}
 
 	// Derive overall status.
@@ -69,7 +75,7 @@ func (r *composeRepository) GetAppStatus(appID string) (model.GetAppStatusResult
 		containerApp.StatusCode = determineContainerAppStatus(containerApp.Containers)
 	}
 
-	log.Debug("Docker Compose app status retrieved", "app_id", appID, "containers", len(containerApp.Containers), "status_code", containerApp.StatusCode)
+	log.Debug("Docker Compose app status retrieved", "app_id", appID, "containers", len(containerApp.Containers), "status_code", containerApp.StatusCode, "revision", containerApp.Revision)
 	return model.GetAppStatusResult{App: containerApp}, nil
 }
 
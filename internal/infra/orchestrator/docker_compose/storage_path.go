@@ -0,0 +1,110 @@
+package docker_compose
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/orchestrator"
+	"winterflow-agent/pkg/log"
+)
+
+// appLocationSuffix names the file <apps path>/<app_id>.location recording the storage path an app is
+// deployed to when it is not the apps directory.
+const appLocationSuffix = ".location"
+
+// resolveStoragePath returns the base directory of apps whose configuration sets storagePath: the apps
+// directory when it is empty, or the cleaned storagePath when it is an absolute path at or below one of
+// the allowed storage paths. The check is lexical; symlinks below an allowed path are trusted.
+func resolveStoragePath(cfg *config.Config, storagePath string) (string, error) {
+	if storagePath == "" {
+		return cfg.GetAppsPath(), nil
+	}
+	if !filepath.IsAbs(storagePath) {
+		return "", fmt.Errorf("storage path %q must be absolute", storagePath)
+	}
+	path := filepath.Clean(storagePath)
+	for _, allowed := range cfg.AllowedStoragePaths {
+		if !filepath.IsAbs(allowed) {
+			continue
+		}
+		rel, err := filepath.Rel(filepath.Clean(allowed), path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("storage path %q is not within the allowed storage paths", storagePath)
+}
+
+// appLocationFile returns the path of the file recording the storage path of the app.
+func (r *composeRepository) appLocationFile(appID string) string {
+	return filepath.Join(r.config.GetAppsPath(), appID+appLocationSuffix)
+}
+
+// getAppDir returns the directory the app is deployed to: below the storage path recorded when it was
+// deployed, or below the apps directory.
+func (r *composeRepository) getAppDir(appID string) string {
+	base := r.config.GetAppsPath()
+	data, err := os.ReadFile(r.appLocationFile(appID))
+	if err == nil {
+		// The recorded path was validated when the app was deployed, but the allow-list may have changed.
+		if path, err := resolveStoragePath(r.config, strings.TrimSpace(string(data))); err == nil {
+			base = path
+		} else {
+			log.Warn("Ignoring storage path of app", "app_id", appID, "error", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Failed to read storage path of app", "app_id", appID, "error", err)
+	}
+	return filepath.Join(base, appID)
+}
+
+// setAppLocation records base as the storage path the app is deployed to, or removes the record when it
+// is the apps directory.
+func (r *composeRepository) setAppLocation(appID, base string) error {
+	path := r.appLocationFile(appID)
+	if base == r.config.GetAppsPath() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove storage path of app %s: %w", appID, err)
+		}
+		return nil
+	}
+	if err := ensureDir(r.config.GetAppsPath()); err != nil {
+		return fmt.Errorf("failed to ensure apps base directory exists: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(base+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to record storage path of app %s: %w", appID, err)
+	}
+	return nil
+}
+
+// relocateApp moves the deployment of the app to the storage path of the revision in templateDir and
+// returns the app directory to render it to. The containers of a deployment in another directory are
+// removed; its files are kept as they may hold data of bind mounts.
+func (r *composeRepository) relocateApp(appID, templateDir string) (string, error) {
+	appConfig, _, err := orchestrator.ReadAppConfig(templateDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to load new configuration: %w", err)
+	}
+	base, err := resolveStoragePath(r.config, appConfig.StoragePath)
+	if err != nil {
+		return "", err
+	}
+
+	current := r.getAppDir(appID)
+	target := filepath.Join(base, appID)
+	if current != target && dirExists(current) {
+		log.Info("[Deploy] moving app to another storage path", "app_id", appID, "from", current, "to", target)
+		if err := r.composeDown(current); err != nil {
+			return "", fmt.Errorf("failed to remove containers of the previous storage path: %w", err)
+		}
+		log.Warn("[Deploy] the previous app directory is kept, remove it once its data is no longer needed", "app_id", appID, "app_dir", current)
+	}
+	if err := r.setAppLocation(appID, base); err != nil {
+		return "", err
+	}
+	return target, nil
+}
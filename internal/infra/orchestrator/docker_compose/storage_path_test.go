@@ -0,0 +1,197 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+func TestResolveStoragePath(t *testing.T) {
+	cfg := &config.Config{BasePath: "/opt/winterflow", AllowedStoragePaths: []string{"/mnt/disk1", "/srv/apps/", "relative"}}
+
+	testCases := []struct {
+		name        string
+		storagePath string
+		expected    string
+		wantErr     bool
+	}{
+		{name: "Default apps directory", storagePath: "", expected: cfg.GetAppsPath()},
+		{name: "Allowed path", storagePath: "/mnt/disk1", expected: "/mnt/disk1"},
+		{name: "Below an allowed path", storagePath: "/srv/apps/fast/", expected: "/srv/apps/fast"},
+		{name: "Traversal within an allowed path", storagePath: "/mnt/disk1/a/../b", expected: "/mnt/disk1/b"},
+		{name: "Traversal out of an allowed path", storagePath: "/mnt/disk1/../../etc", wantErr: true},
+		{name: "Sibling sharing a prefix", storagePath: "/mnt/disk10", wantErr: true},
+		{name: "Not allowed", storagePath: "/etc", wantErr: true},
+		{name: "Relative path", storagePath: "relative", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveStoragePath(cfg, tc.storagePath)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("resolveStoragePath(%q) returned error %v, want error %v", tc.storagePath, err, tc.wantErr)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveStoragePathWithoutAllowList(t *testing.T) {
+	if _, err := resolveStoragePath(&config.Config{}, "/mnt/disk1"); err == nil {
+		t.Error("Expected storage paths to be rejected without allowed storage paths")
+	}
+}
+
+// newStorageRepository prepares revision 1 of app "app" with the given storage path and returns a repository
+// deploying it through a fake runner, with the storage path allowed.
+func newStorageRepository(t *testing.T, storagePath string) (*composeRepository, *command.FakeRunner) {
+	t.Helper()
+	runner := &command.FakeRunner{}
+	r := newTestRepository(t, &config.Config{AllowedStoragePaths: []string{filepath.Dir(storagePath)}}, runner)
+	r.client = newFakeDockerClient(t, nil)
+	writeStorageRevision(t, r.config, "1", storagePath)
+	return r, runner
+}
+
+// writeStorageRevision writes the given revision of app "app" deployed below storagePath.
+func writeStorageRevision(t *testing.T, cfg *config.Config, revision, storagePath string) {
+	t.Helper()
+	templateDir := filepath.Join(cfg.GetAppsTemplatesPath(), "app", revision)
+	writeRevision(t, templateDir)
+	appConfig := `{"id":"app","name":"demo","storage_path":"` + storagePath + `"}`
+	if err := os.WriteFile(filepath.Join(templateDir, "config.json"), []byte(appConfig), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestDeployAppToStoragePath(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "disk2")
+	r, runner := newStorageRepository(t, storagePath)
+
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	appDir := filepath.Join(storagePath, "app")
+	if got := r.getAppDir("app"); got != appDir {
+		t.Fatalf("Expected the app directory %s, got %s", appDir, got)
+	}
+	if !fileExists(filepath.Join(appDir, "compose.yml")) || dirExists(filepath.Join(r.config.GetAppsPath(), "app")) {
+		t.Error("Expected the app to be rendered below the storage path only")
+	}
+	if up := runner.Commands()[0]; up.Dir != appDir {
+		t.Errorf("Expected compose up in %s, got %s", appDir, up.Dir)
+	}
+
+	// Status, stop and delete use the storage path too.
+	result, err := r.GetAppStatus("app")
+	if err != nil {
+		t.Fatalf("GetAppStatus returned error: %v", err)
+	}
+	if result.App.Name != "demo" {
+		t.Errorf("Expected the name of the deployed config, got %q", result.App.Name)
+	}
+	if err := r.StopApp("app"); err != nil {
+		t.Fatalf("StopApp returned error: %v", err)
+	}
+	if down := runner.Commands()[1]; down.Dir != appDir {
+		t.Errorf("Expected compose down in %s, got %s", appDir, down.Dir)
+	}
+	if err := r.DeleteApp("app", false); err != nil {
+		t.Fatalf("DeleteApp returned error: %v", err)
+	}
+	if dirExists(appDir) || fileExists(r.appLocationFile("app")) {
+		t.Error("Expected the app directory and its storage path record to be removed")
+	}
+}
+
+func TestDeployAppRejectsStoragePathOutsideAllowList(t *testing.T) {
+	r, runner := newStorageRepository(t, filepath.Join(t.TempDir(), "disk2"))
+	r.config.AllowedStoragePaths = []string{filepath.Join(t.TempDir(), "other")}
+
+	err := r.DeployApp("app")
+	if err == nil || !strings.Contains(err.Error(), "not within the allowed storage paths") {
+		t.Fatalf("Expected the storage path to be rejected, got %v", err)
+	}
+	if len(runner.Commands()) != 0 {
+		t.Errorf("Expected no compose commands, got %v", commandLines(runner.Commands()))
+	}
+	if fileExists(r.appLocationFile("app")) {
+		t.Error("Expected no storage path to be recorded")
+	}
+}
+
+func TestDeployAppMovesAppToAnotherStoragePath(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "disk2")
+	r, runner := newStorageRepository(t, storagePath)
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	// The next revision moves the app back to the apps directory.
+	writeStorageRevision(t, r.config, "2", "")
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	oldDir, newDir := filepath.Join(storagePath, "app"), filepath.Join(r.config.GetAppsPath(), "app")
+	if got := r.getAppDir("app"); got != newDir {
+		t.Fatalf("Expected the app directory %s, got %s", newDir, got)
+	}
+	commands := runner.Commands()
+	suffixes := []string{"up -d", "down --remove-orphans", "up -d"}
+	lines := commandLines(commands)
+	if len(lines) != len(suffixes) {
+		t.Fatalf("Expected up, down and up, got %v", lines)
+	}
+	for i, suffix := range suffixes {
+		if !strings.HasSuffix(lines[i], suffix) {
+			t.Errorf("Expected command %d to end with %q, got %q", i, suffix, lines[i])
+		}
+	}
+	if commands[1].Dir != oldDir || commands[2].Dir != newDir {
+		t.Errorf("Expected the previous deployment to be removed and the app started in %s, got %+v", newDir, commands)
+	}
+	if !dirExists(oldDir) {
+		t.Error("Expected the files of the previous storage path to be kept")
+	}
+	if fileExists(r.appLocationFile("app")) {
+		t.Error("Expected no storage path to be recorded for the apps directory")
+	}
+}
+
+func TestDeleteAppForgetsStoragePath(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "disk2")
+	r, runner := newStorageRepository(t, storagePath)
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+	if err := r.DeleteApp("app", false); err != nil {
+		t.Fatalf("DeleteApp returned error: %v", err)
+	}
+
+	// The app is created again with the same ID, this time in the apps directory.
+	if err := os.RemoveAll(filepath.Join(r.config.GetAppsTemplatesPath(), "app")); err != nil {
+		t.Fatalf("Failed to remove templates: %v", err)
+	}
+	writeStorageRevision(t, r.config, "1", "")
+	if err := r.DeployApp("app"); err != nil {
+		t.Fatalf("DeployApp returned error: %v", err)
+	}
+
+	appDir := filepath.Join(r.config.GetAppsPath(), "app")
+	if got := r.getAppDir("app"); got != appDir {
+		t.Fatalf("Expected the app directory %s, got %s", appDir, got)
+	}
+	commands := runner.Commands()
+	if last := commands[len(commands)-1]; last.Dir != appDir || !strings.HasSuffix(commandLines(commands)[len(commands)-1], "up -d") {
+		t.Errorf("Expected compose up in %s, got %v", appDir, commandLines(commands))
+	}
+	if dirExists(filepath.Join(storagePath, "app")) {
+		t.Error("Expected the deleted app to stay out of its previous storage path")
+	}
+}
@@ -1,11 +1,13 @@
 package docker_compose
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/infra/orchestrator"
@@ -14,27 +16,80 @@ import (
 	"winterflow-agent/pkg/template"
 )
 
-// loadTemplateVariables merges default and variable files into a single map used for template substitution.
+// loadTemplateVariables merges the variable sources of a revision into a single map used for template
+// substitution. Sources are applied in increasing order of precedence, so a later source wins when the
+// same variable is defined more than once:
+//
+//  1. vars/values.json    – values pushed by the server with the app configuration
+//  2. vars/overrides.json – host specific values maintained locally on the agent host
+//  3. vars/secrets/<name> – one file per variable, the file name is the variable name and the file
+//     content (without a single trailing newline) is its value
+//
+// Overrides and secret files are never written by the agent itself. They are carried over to new
+// revisions together with the rest of the vars directory, which lets host-specific secrets live outside
+// the server-pushed configuration.
 func (r *composeRepository) loadTemplateVariables(templateDir string) (map[string]string, error) {
 	vars := make(map[string]string)
+	varsDir := filepath.Join(templateDir, "vars")
 
-	varsPath := filepath.Join(templateDir, "vars", "values.json")
-	data, err := os.ReadFile(varsPath)
+	for _, name := range []string{"values.json", "overrides.json"} {
+		if err := mergeJSONVariables(vars, filepath.Join(varsDir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mergeSecretVariables(vars, filepath.Join(varsDir, "secrets")); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// mergeJSONVariables adds the variables from a flat JSON object at path into vars. A missing file is
+// not an error.
+func mergeJSONVariables(vars map[string]string, path string) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return vars, nil // No vars file – that's fine.
+			return nil // No vars file – that's fine.
 		}
-		return nil, err
+		return err
 	}
 
 	var raw map[string]interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
-		return nil, fmt.Errorf("failed to parse variables JSON: %w", err)
+		return fmt.Errorf("failed to parse variables JSON %s: %w", filepath.Base(path), err)
 	}
 	for k, v := range raw {
 		vars[k] = fmt.Sprintf("%v", v)
 	}
-	return vars, nil
+	return nil
+}
+
+// mergeSecretVariables adds one variable per regular file in secretsDir into vars. A missing directory
+// is not an error; nested directories and hidden files are ignored.
+func mergeSecretVariables(vars map[string]string, secretsDir string) error {
+	entries, err := os.ReadDir(secretsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read secrets directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(secretsDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read secret %s: %w", name, err)
+		}
+		value := strings.TrimSuffix(string(content), "\n")
+		vars[name] = strings.TrimSuffix(value, "\r")
+	}
+	return nil
 }
 
 // renderTemplates processes template files from templateDir/files into destDir performing Docker-Compose-style
@@ -42,8 +97,16 @@ func (r *composeRepository) loadTemplateVariables(templateDir string) (map[strin
 // "template" root are subject to variable substitution; files from the "expose" and "user" roots are copied
 // verbatim.
 func (r *composeRepository) renderTemplates(templateDir, destDir string, vars map[string]string) error {
-	filesRoot := filepath.Join(templateDir, "files")
+	if _, err := renderTree(filepath.Join(templateDir, "files"), destDir, vars); err != nil {
+		return fmt.Errorf("failed to process templates: %w", err)
+	}
+	return nil
+}
 
+// renderTree renders every file below filesRoot into the same relative path below destDir, substituting
+// variables, and returns the slash separated relative paths of the rendered files. Git metadata is skipped.
+func renderTree(filesRoot, destDir string, vars map[string]string) ([]string, error) {
+	var rendered []string
 	walkFn := func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
@@ -57,6 +120,12 @@ func (r *composeRepository) renderTemplates(templateDir, destDir string, vars ma
 		if relPath == "." {
 			return nil // Skip root
 		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
 		destPath := filepath.Join(destDir, relPath)
 
@@ -73,21 +142,48 @@ func (r *composeRepository) renderTemplates(templateDir, destDir string, vars ma
 			return fmt.Errorf("failed to read source file %s: %w", path, err)
 		}
 
-		rendered, err := template.Substitute(string(contentBytes), vars)
+		content, err := template.Substitute(string(contentBytes), vars)
 		if err != nil {
 			return fmt.Errorf("failed to render template %s: %w", path, err)
 		}
 
-		if err := os.WriteFile(destPath, []byte(rendered), 0o644); err != nil {
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat source file %s: %w", path, err)
+		}
+		if err := writeRenderedFile(destPath, []byte(content), bytes.Equal(contentBytes, []byte(content)), info); err != nil {
 			return fmt.Errorf("failed to write file to %s: %w", destPath, err)
 		}
+		rendered = append(rendered, filepath.ToSlash(relPath))
 		return nil
 	}
 
 	if err := filepath.WalkDir(filesRoot, walkFn); err != nil {
-		return fmt.Errorf("failed to process templates: %w", err)
+		return nil, err
 	}
+	return rendered, nil
+}
 
+// writeRenderedFile writes the rendered content of the source file src to destPath with the permissions of
+// src. A file that already holds the content is not rewritten, so that redeploying unchanged files keeps their
+// modification time, and a file copied verbatim takes the modification time of src.
+func writeRenderedFile(destPath string, content []byte, verbatim bool, src fs.FileInfo) error {
+	existing, err := os.ReadFile(destPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err != nil || !bytes.Equal(existing, content) {
+		if err := os.WriteFile(destPath, content, src.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	// WriteFile applies the umask and keeps the mode of an existing file.
+	if err := os.Chmod(destPath, src.Mode().Perm()); err != nil {
+		return err
+	}
+	if verbatim {
+		return os.Chtimes(destPath, src.ModTime(), src.ModTime())
+	}
 	return nil
 }
 
@@ -99,19 +195,13 @@ func (r *composeRepository) renderTemplates(templateDir, destDir string, vars ma
 func (r *composeRepository) renderApp(appID, templateDir, destDir string) error {
 	// Load configuration of the version to be rendered so we can compare it with the currently
 	// deployed version (if any) and subsequently save a copy for external tools.
-	cfgPath := filepath.Join(templateDir, "config.json")
-	data, err := os.ReadFile(cfgPath)
+	newCfg, _, err := orchestrator.ReadAppConfig(templateDir)
 	if err != nil {
-		return fmt.Errorf("failed to read configuration %s: %w", cfgPath, err)
-	}
-
-	newCfg, err := model.ParseAppConfig(data)
-	if err != nil {
-		return fmt.Errorf("failed to parse new configuration: %w", err)
+		return fmt.Errorf("failed to load new configuration: %w", err)
 	}
 
 	// Remove files that belonged to the previously deployed version but are absent in the new one.
-	if currentCfg, errCfg := orchestrator.GetCurrentConfig(r.config, appID); errCfg == nil {
+	if currentCfg, errCfg := orchestrator.GetCurrentConfig(destDir); errCfg == nil {
 		if err := r.removeDeployedFiles(destDir, currentCfg, newCfg); err != nil {
 			return fmt.Errorf("failed to remove previously deployed files: %w", err)
 		}
@@ -131,21 +221,55 @@ func (r *composeRepository) renderApp(appID, templateDir, destDir string) error
 	if err != nil {
 		return fmt.Errorf("failed to load template variables: %w", err)
 	}
+	secretNames, err := r.applySecretVariables(destDir, vars)
+	if err != nil {
+		return err
+	}
 
+	// Files checked out from a git source are rendered first so that pushed templates can override them.
+	gitFiles, err := r.renderGitSource(newCfg, destDir, vars)
+	if err != nil {
+		return err
+	}
+	if err := syncGitFiles(destDir, gitFiles); err != nil {
+		return fmt.Errorf("failed to clean up git source files: %w", err)
+	}
 	if err := r.renderTemplates(templateDir, destDir, vars); err != nil {
 		return fmt.Errorf("failed to render templates: %w", err)
 	}
+	if err := r.writeRestartPolicyOverride(destDir, newCfg); err != nil {
+		return fmt.Errorf("failed to apply restart policy override: %w", err)
+	}
+	if err := r.writeLabelsOverride(destDir, appLabels(appID, templateRevision(templateDir))); err != nil {
+		return fmt.Errorf("failed to apply labels: %w", err)
+	}
+	if err := r.writeRegistryMirrorOverride(destDir); err != nil {
+		return fmt.Errorf("failed to apply registry mirror: %w", err)
+	}
+	if err := r.writeServiceHostsOverride(destDir); err != nil {
+		return fmt.Errorf("failed to apply service hosts: %w", err)
+	}
 
-	// Generate .winterflow.env file so that compose commands can load variable values.
+	// Generate .winterflow.env file so that compose commands can load variable values. Secret values
+	// are supplied to compose through its environment instead (see secretEnv).
+	for _, name := range secretNames {
+		delete(vars, name)
+	}
 	vars["COMPOSE_PROJECT_NAME"] = newCfg.Name
 	vars["_APP_NAME"] = newCfg.Name
 	if err := writeEnvFile(destDir, vars); err != nil {
 		return fmt.Errorf("failed to write .winterflow.env: %w", err)
 	}
+	if err := r.checkEnvFiles(destDir); err != nil {
+		return fmt.Errorf("invalid env_file reference: %w", err)
+	}
+	if err := r.checkFileSources(destDir); err != nil {
+		return fmt.Errorf("invalid config or secret: %w", err)
+	}
 
 	// Persist a copy of the configuration that has just been rendered so that other components can
 	// quickly inspect the active version without having to resolve templateDir themselves.
-	if err := orchestrator.SaveCurrentConfigCopy(r.config, appID, templateDir); err != nil {
+	if err := orchestrator.SaveCurrentConfigCopy(destDir, templateDir); err != nil {
 		return err
 	}
 
@@ -153,25 +277,25 @@ func (r *composeRepository) renderApp(appID, templateDir, destDir string) error
 }
 
 func (r *composeRepository) changeTemplateAppName(newName, templateDir string) error {
-	configPath := filepath.Join(templateDir, "config.json")
-	cfgBytes, err := os.ReadFile(configPath)
-	if err != nil {
-		return log.Errorf("failed to read app config", "error", err)
-	}
-
-	cfg, err := model.ParseAppConfig(cfgBytes)
+	cfg, srcPath, err := orchestrator.ReadAppConfig(templateDir)
 	if err != nil {
-		return log.Errorf("failed to parse app config", "error", err)
+		return log.Errorf("failed to load app config: %v", err)
 	}
 
-	// Update the name in the config
+	// Update the name in the config. Hand-edited YAML configurations are written back as canonical JSON.
 	cfg.Name = newName
 	data, err := json.Marshal(cfg)
 	if err != nil {
-		return log.Errorf("failed to marshal updated app config", "error", err)
+		return log.Errorf("failed to marshal updated app config: %v", err)
 	}
+	configPath := filepath.Join(templateDir, model.AppConfigFileNames[0])
 	if err := os.WriteFile(configPath, data, 0o644); err != nil {
-		return log.Errorf("failed to write updated app config", "error", err)
+		return log.Errorf("failed to write updated app config: %v", err)
+	}
+	if srcPath != configPath {
+		if err := os.Remove(srcPath); err != nil {
+			return log.Errorf("failed to remove replaced app config: %v", err)
+		}
 	}
 	log.Debug("Updated config.json with new application name", "path", configPath)
 
@@ -183,13 +307,13 @@ func (r *composeRepository) changeTemplateAppName(newName, templateDir string) e
 		// Read the values.json file
 		valuesData, err := os.ReadFile(valuesPath)
 		if err != nil {
-			return log.Errorf("failed to read values.json", "error", err)
+			return log.Errorf("failed to read values.json: %v", err)
 		}
 
 		// Parse the JSON
 		var values map[string]interface{}
 		if err := json.Unmarshal(valuesData, &values); err != nil {
-			return log.Errorf("failed to parse values.json", "error", err)
+			return log.Errorf("failed to parse values.json: %v", err)
 		}
 
 		// Update the _APP_NAME value
@@ -198,12 +322,12 @@ func (r *composeRepository) changeTemplateAppName(newName, templateDir string) e
 		// Marshal back to JSON
 		updatedValuesData, err := json.MarshalIndent(values, "", "  ")
 		if err != nil {
-			return log.Errorf("failed to marshal updated values.json", "error", err)
+			return log.Errorf("failed to marshal updated values.json: %v", err)
 		}
 
 		// Write the updated values.json file
 		if err := os.WriteFile(valuesPath, updatedValuesData, 0o644); err != nil {
-			return log.Errorf("failed to write updated values.json", "error", err)
+			return log.Errorf("failed to write updated values.json: %v", err)
 		}
 
 		log.Debug("Updated _APP_NAME in values.json", "path", valuesPath)
@@ -227,3 +351,70 @@ func writeEnvFile(dir string, vars map[string]string) error {
 	envPath := filepath.Join(dir, ".winterflow.env")
 	return env.Save(envPath, vars)
 }
+
+// redactedVariableValue replaces the values of encrypted variables when an application is rendered for
+// inspection rather than deployment.
+const redactedVariableValue = "<encrypted>"
+
+// redactEncryptedVariables replaces the value of every variable marked as encrypted in cfg with a placeholder.
+func redactEncryptedVariables(cfg *model.AppConfig, vars map[string]string) {
+	for _, v := range cfg.Variables {
+		if !v.IsEncrypted {
+			continue
+		}
+		if _, ok := vars[v.Name]; ok {
+			vars[v.Name] = redactedVariableValue
+		}
+	}
+}
+
+// renderRedactedApp renders templates from templateDir into destDir the same way renderApp does, but with
+// encrypted variable values redacted. It neither cleans up previously deployed files nor stores a copy of
+// the active configuration, so it is safe to use for previewing any revision.
+func (r *composeRepository) renderRedactedApp(templateDir, destDir string) error {
+	cfg, _, err := orchestrator.ReadAppConfig(templateDir)
+	if err != nil {
+		return err
+	}
+
+	vars, err := r.loadTemplateVariables(templateDir)
+	if err != nil {
+		return fmt.Errorf("failed to load template variables: %w", err)
+	}
+	redactEncryptedVariables(cfg, vars)
+	// Variables supplied as host secret files are secrets by definition.
+	if entries, err := os.ReadDir(filepath.Join(templateDir, "vars", "secrets")); err == nil {
+		for _, entry := range entries {
+			if _, ok := vars[entry.Name()]; ok {
+				vars[entry.Name()] = redactedVariableValue
+			}
+		}
+	}
+
+	if _, err := r.renderGitSource(cfg, destDir, vars); err != nil {
+		return err
+	}
+	if err := r.renderTemplates(templateDir, destDir, vars); err != nil {
+		return fmt.Errorf("failed to render templates: %w", err)
+	}
+	if err := r.writeRestartPolicyOverride(destDir, cfg); err != nil {
+		return fmt.Errorf("failed to apply restart policy override: %w", err)
+	}
+	if err := r.writeLabelsOverride(destDir, appLabels(cfg.ID, templateRevision(templateDir))); err != nil {
+		return fmt.Errorf("failed to apply labels: %w", err)
+	}
+	if err := r.writeRegistryMirrorOverride(destDir); err != nil {
+		return fmt.Errorf("failed to apply registry mirror: %w", err)
+	}
+	if err := r.writeServiceHostsOverride(destDir); err != nil {
+		return fmt.Errorf("failed to apply service hosts: %w", err)
+	}
+
+	vars["COMPOSE_PROJECT_NAME"] = cfg.Name
+	vars["_APP_NAME"] = cfg.Name
+	if err := writeEnvFile(destDir, vars); err != nil {
+		return fmt.Errorf("failed to write .winterflow.env: %w", err)
+	}
+
+	return nil
+}
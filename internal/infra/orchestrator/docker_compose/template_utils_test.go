@@ -0,0 +1,202 @@
+package docker_compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+)
+
+// writeRevision creates a minimal revision directory with a compose template, its variables and config.
+func writeRevision(t *testing.T, templateDir string) {
+	t.Helper()
+	files := map[string]string{
+		"config.json": `{"id":"app","name":"demo","variables":[` +
+			`{"id":"v1","name":"DB_PASSWORD","is_encrypted":true,"type":"template"},` +
+			`{"id":"v2","name":"DB_USER","is_encrypted":false,"type":"template"}]}`,
+		filepath.Join("vars", "values.json"):  `{"DB_PASSWORD":"s3cret","DB_USER":"admin"}`,
+		filepath.Join("files", "compose.yml"): "services:\n  db:\n    environment:\n      USER: ${DB_USER}\n      PASSWORD: ${DB_PASSWORD}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(templateDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestRenderRedactedAppHidesEncryptedVariables(t *testing.T) {
+	templateDir := t.TempDir()
+	destDir := t.TempDir()
+	writeRevision(t, templateDir)
+
+	r := &composeRepository{}
+	if err := r.renderRedactedApp(templateDir, destDir); err != nil {
+		t.Fatalf("renderRedactedApp returned error: %v", err)
+	}
+
+	for _, name := range []string{"compose.yml", ".winterflow.env"} {
+		content, err := os.ReadFile(filepath.Join(destDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read rendered %s: %v", name, err)
+		}
+		if strings.Contains(string(content), "s3cret") {
+			t.Errorf("Expected encrypted value to be redacted from %s, got:\n%s", name, content)
+		}
+		if !strings.Contains(string(content), redactedVariableValue) {
+			t.Errorf("Expected redaction placeholder in %s, got:\n%s", name, content)
+		}
+		if !strings.Contains(string(content), "admin") {
+			t.Errorf("Expected plain variable to be rendered in %s, got:\n%s", name, content)
+		}
+	}
+}
+
+func TestRenderComposeMissingRevision(t *testing.T) {
+	r := &composeRepository{config: &config.Config{BasePath: t.TempDir()}}
+	_, err := r.RenderCompose("missing-app", 7)
+	if err == nil || !strings.Contains(err.Error(), "revision 7") {
+		t.Errorf("Expected missing revision error, got %v", err)
+	}
+}
+
+func TestLoadTemplateVariablesPrecedence(t *testing.T) {
+	templateDir := t.TempDir()
+	varsDir := filepath.Join(templateDir, "vars")
+	if err := os.MkdirAll(filepath.Join(varsDir, "secrets", "nested"), 0o755); err != nil {
+		t.Fatalf("Failed to create secrets directory: %v", err)
+	}
+
+	files := map[string]string{
+		"values.json":                                 `{"ONLY_VALUES":"v","IN_OVERRIDES":"v","IN_ALL":"v","PORT":8080}`,
+		"overrides.json":                              `{"IN_OVERRIDES":"o","IN_ALL":"o","ONLY_OVERRIDES":"o"}`,
+		filepath.Join("secrets", "IN_ALL"):            "s\n",
+		filepath.Join("secrets", "ONLY_SECRETS"):      "line1\nline2",
+		filepath.Join("secrets", ".hidden"):           "ignored",
+		filepath.Join("secrets", "nested", "IGNORED"): "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(varsDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	r := &composeRepository{}
+	vars, err := r.loadTemplateVariables(templateDir)
+	if err != nil {
+		t.Fatalf("loadTemplateVariables returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"ONLY_VALUES":    "v",
+		"PORT":           "8080",
+		"IN_OVERRIDES":   "o",
+		"ONLY_OVERRIDES": "o",
+		"IN_ALL":         "s",
+		"ONLY_SECRETS":   "line1\nline2",
+	}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("Expected %v, got %v", expected, vars)
+	}
+}
+
+func TestLoadTemplateVariablesInvalidOverrides(t *testing.T) {
+	templateDir := t.TempDir()
+	varsDir := filepath.Join(templateDir, "vars")
+	if err := os.MkdirAll(varsDir, 0o755); err != nil {
+		t.Fatalf("Failed to create vars directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(varsDir, "overrides.json"), []byte("{"), 0o600); err != nil {
+		t.Fatalf("Failed to write overrides.json: %v", err)
+	}
+
+	r := &composeRepository{}
+	if _, err := r.loadTemplateVariables(templateDir); err == nil {
+		t.Error("Expected error for malformed overrides.json")
+	}
+}
+
+func TestChangeTemplateAppNameRewritesYAMLConfigAsJSON(t *testing.T) {
+	templateDir := t.TempDir()
+	writeRevision(t, templateDir)
+	if err := os.Remove(filepath.Join(templateDir, "config.json")); err != nil {
+		t.Fatalf("Failed to remove config.json: %v", err)
+	}
+	yamlConfig := "id: app\nname: demo\nvariables:\n  - {id: v1, name: DB_USER, is_encrypted: false, type: template}\n"
+	if err := os.WriteFile(filepath.Join(templateDir, "config.yaml"), []byte(yamlConfig), 0o644); err != nil {
+		t.Fatalf("Failed to write config.yaml: %v", err)
+	}
+
+	r := &composeRepository{}
+	if err := r.changeTemplateAppName("renamed", templateDir); err != nil {
+		t.Fatalf("changeTemplateAppName returned error: %v", err)
+	}
+
+	if fileExists(filepath.Join(templateDir, "config.yaml")) {
+		t.Error("Expected the YAML config to be replaced")
+	}
+	data, err := os.ReadFile(filepath.Join(templateDir, "config.json"))
+	if err != nil {
+		t.Fatalf("Failed to read config.json: %v", err)
+	}
+	if !strings.HasPrefix(string(data), `{"id":"app","name":"renamed",`) || !strings.Contains(string(data), `"DB_USER"`) {
+		t.Errorf("Expected canonical JSON with the new name, got %s", data)
+	}
+}
+
+func TestRenderTemplatesKeepsModesAndModificationTimes(t *testing.T) {
+	templateDir := t.TempDir()
+	destDir := t.TempDir()
+	filesDir := filepath.Join(templateDir, "files")
+	if err := os.MkdirAll(filesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sources := map[string]os.FileMode{"entrypoint.sh": 0o755, "compose.yml": 0o644}
+	contents := map[string]string{"entrypoint.sh": "#!/bin/sh\nexec \"$@\"\n", "compose.yml": "services:\n  db:\n    user: ${DB_USER}\n"}
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for name, mode := range sources {
+		path := filepath.Join(filesDir, name)
+		if err := os.WriteFile(path, []byte(contents[name]), mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &composeRepository{}
+	vars := map[string]string{"DB_USER": "admin"}
+	if err := r.renderTemplates(templateDir, destDir, vars); err != nil {
+		t.Fatalf("renderTemplates returned error: %v", err)
+	}
+	rendered := filepath.Join(destDir, "compose.yml")
+	if err := os.Chtimes(rendered, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.renderTemplates(templateDir, destDir, vars); err != nil {
+		t.Fatalf("renderTemplates returned error: %v", err)
+	}
+
+	for name, mode := range sources {
+		info, err := os.Stat(filepath.Join(destDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("Expected mode %o for %s, got %o", mode, name, info.Mode().Perm())
+		}
+		if !info.ModTime().Equal(past) {
+			t.Errorf("Expected %s to keep mtime %v, got %v", name, past, info.ModTime())
+		}
+	}
+}
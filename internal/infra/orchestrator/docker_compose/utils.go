@@ -40,12 +40,6 @@ func ensureDir(path string) error {
 	return os.MkdirAll(path, 0o755) // Create with typical rwxr-xr-x permissions
 }
 
-// getAppDir returns the directory path for an app using its ID directly
-// This is used to store apps by their ID instead of name
-func (r *composeRepository) getAppDir(appID string) string {
-	return filepath.Join(r.config.GetAppsPath(), appID)
-}
-
 // getAppName determines the human-readable application name by inspecting the
 // config.json stored in the latest revision directory.
 //
@@ -53,7 +47,7 @@ func (r *composeRepository) getAppDir(appID string) string {
 // name (e.g. missing config.json or empty name field). This preserves previous
 // behaviour where the app ID served as a safe default.
 func (r *composeRepository) getAppNameById(appID string) (string, error) {
-	appConfig, err := orchestrator.GetCurrentConfig(r.config, appID)
+	appConfig, err := orchestrator.GetCurrentConfig(r.getAppDir(appID))
 	if err == nil {
 		return appConfig.Name, nil
 	}
@@ -88,17 +82,9 @@ func getAppName(appPath string) (string, error) {
 		return "", fmt.Errorf("app path cannot be empty")
 	}
 
-	// Construct path to config.json relative to the provided directory.
-	configPath := filepath.Join(appPath, "config.json")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read app config: %w", err)
-	}
-
-	appConfig, err := model.ParseAppConfig(data)
+	appConfig, configPath, err := orchestrator.ReadAppConfig(appPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse app config: %w", err)
+		return "", fmt.Errorf("failed to load app config: %w", err)
 	}
 
 	name := strings.TrimSpace(appConfig.Name)
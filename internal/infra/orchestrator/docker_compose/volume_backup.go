@@ -0,0 +1,133 @@
+package docker_compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/command"
+	"winterflow-agent/pkg/log"
+	"winterflow-agent/pkg/yaml"
+)
+
+const (
+	// volumeBackupTimeFormat names the directory of a backup after the time it was taken.
+	volumeBackupTimeFormat = "20060102T150405Z"
+	// volumeBackupDataDir and volumeBackupTargetDir are the mount points of the volume and of the backup
+	// directory in the backup container.
+	volumeBackupDataDir   = "/data"
+	volumeBackupTargetDir = "/backup"
+)
+
+// BackupVolumes archives the named volumes of the app into a new directory below the volume backup directory.
+func (r *composeRepository) BackupVolumes(appID string) (backups []model.VolumeBackup, err error) {
+	defer r.beginAppOperation(appID)()
+	defer r.metrics.observe(appID, model.AppOperationBackupVolumes, time.Now(), &err)
+
+	appDir := r.getAppDir(appID)
+	if !dirExists(appDir) {
+		return nil, fmt.Errorf("app directory %s does not exist", appDir)
+	}
+	return r.backupVolumes(appID, appDir)
+}
+
+// backupVolumes archives every named volume of the app rendered in appDir with a throwaway container running
+// tar, one gzip compressed archive per volume. The volumes are mounted read-only and archived while the
+// containers of the app keep running. The archives written before a failure are returned with the error.
+func (r *composeRepository) backupVolumes(appID, appDir string) ([]model.VolumeBackup, error) {
+	volumes, err := r.composeVolumes(appDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the volumes of app %s: %w", appID, err)
+	}
+	if len(volumes) == 0 {
+		log.Info("[Backup] app has no named volumes, nothing to back up", "app_id", appID)
+		return nil, nil
+	}
+
+	if err := r.checkLocalDockerContext(); err != nil {
+		return nil, err
+	}
+
+	backupDir := filepath.Join(r.config.GetVolumeBackupDir(), appID, time.Now().UTC().Format(volumeBackupTimeFormat))
+	if err := os.MkdirAll(backupDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	backups := make([]model.VolumeBackup, 0, len(volumes))
+	for _, volume := range volumes {
+		archive := volume + ".tar.gz"
+		log.Info("[Backup] backing up volume", "app_id", appID, "volume", volume, "dir", backupDir)
+		output, err := r.commandRunner().CombinedOutput(r.volumeBackupCmd(appDir, volume, backupDir, archive))
+		if err != nil {
+			log.Error("[Backup] volume backup failed", "app_id", appID, "volume", volume, "output", string(output), "error", err)
+			return backups, fmt.Errorf("failed to back up volume %s: %w: %s", volume, err, strings.TrimSpace(string(output)))
+		}
+		backups = append(backups, model.VolumeBackup{Volume: volume, Archive: filepath.Join(backupDir, archive)})
+	}
+
+	log.Info("[Backup] successfully backed up volumes", "app_id", appID, "volumes", volumes, "dir", backupDir)
+	return backups, nil
+}
+
+// volumeBackupCmd builds the `docker run` invocation archiving volume into archive in backupDir, on the
+// configured Docker context.
+func (r *composeRepository) volumeBackupCmd(appDir, volume, backupDir, archive string) command.Cmd {
+	var args []string
+	if dockerContext := r.config.GetDockerContext(); dockerContext != "" {
+		args = append(args, "--context", dockerContext)
+	}
+	args = append(args, "run", "--rm",
+		"-v", volume+":"+volumeBackupDataDir+":ro",
+		"-v", backupDir+":"+volumeBackupTargetDir,
+		r.config.GetVolumeBackupImage(),
+		"tar", "czf", volumeBackupTargetDir+"/"+archive, "-C", volumeBackupDataDir, ".")
+	return command.Cmd{Name: "docker", Args: args, Context: r.operations.context(appDir)}
+}
+
+// checkLocalDockerContext fails unless the configured Docker context, if any, points to a daemon on this
+// host. The backup directory is bind-mounted into the backup container, which on a remote daemon would
+// write the archives to a directory of the remote host.
+func (r *composeRepository) checkLocalDockerContext() error {
+	dockerContext := r.config.GetDockerContext()
+	if dockerContext == "" {
+		return nil
+	}
+	host, err := ResolveDockerContextHost(r.commandRunner(), dockerContext)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(host, "unix://") && !strings.HasPrefix(host, "npipe://") {
+		return fmt.Errorf("volume backups are not supported on docker context %q as its daemon %s is not local", dockerContext, host)
+	}
+	return nil
+}
+
+// composeVolumes returns the sorted Docker names of the named volumes of the app rendered in appDir, as
+// resolved by `docker compose config`.
+func (r *composeRepository) composeVolumes(appDir string) ([]string, error) {
+	resolved, err := r.composeConfig(appDir)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := yaml.UnmarshalMap([]byte(resolved))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse compose configuration: %w", err)
+	}
+
+	definitions, _ := doc["volumes"].(map[string]interface{})
+	names := make([]string, 0, len(definitions))
+	for key, raw := range definitions {
+		definition, _ := raw.(map[string]interface{})
+		name, _ := definition["name"].(string)
+		if name == "" {
+			name = key
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,207 @@
+package docker_compose
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// volumesConfig is `docker compose config` output declaring two named volumes and an external one.
+const volumesConfig = `name: demo
+services:
+  db:
+    image: postgres
+volumes:
+  db:
+    name: demo_db
+  cache:
+    name: demo_cache
+  shared:
+    name: shared-data
+    external: true
+`
+
+// newVolumeBackupRepository returns a repository with a rendered "app" whose `docker compose config` prints
+// composeConfig, followed by the given results.
+func newVolumeBackupRepository(t *testing.T, cfg *config.Config, composeConfig string, results ...command.FakeResult) (*composeRepository, *command.FakeRunner) {
+	t.Helper()
+	runner := &command.FakeRunner{Results: append([]command.FakeResult{{Stdout: []byte(composeConfig)}}, results...)}
+	r := newTestRepository(t, cfg, runner)
+	writeTestApp(t, r, map[string]string{"compose.yml": "services: {}\n"})
+	return r, runner
+}
+
+// backupCommand returns the expected `docker run` invocation archiving volume into backupDir.
+func backupCommand(image, volume, backupDir string) []string {
+	return []string{"run", "--rm", "-v", volume + ":/data:ro", "-v", backupDir + ":/backup", image,
+		"tar", "czf", "/backup/" + volume + ".tar.gz", "-C", "/data", "."}
+}
+
+func TestBackupVolumesArchivesEachNamedVolume(t *testing.T) {
+	r, runner := newVolumeBackupRepository(t, &config.Config{}, volumesConfig)
+
+	backups, err := r.BackupVolumes("app")
+	if err != nil {
+		t.Fatalf("BackupVolumes returned error: %v", err)
+	}
+
+	volumes := []string{"demo_cache", "demo_db", "shared-data"}
+	if len(backups) != len(volumes) {
+		t.Fatalf("Expected a backup per volume, got %+v", backups)
+	}
+	backupDir := filepath.Dir(backups[0].Archive)
+	if filepath.Dir(filepath.Dir(backupDir)) != r.config.GetVolumeBackupDir() || filepath.Base(filepath.Dir(backupDir)) != "app" {
+		t.Errorf("Expected the backup below %s/app, got %s", r.config.GetVolumeBackupDir(), backupDir)
+	}
+	if !dirExists(backupDir) {
+		t.Errorf("Expected the backup directory %s to be created", backupDir)
+	}
+
+	commands := runner.Commands()
+	if len(commands) != 1+len(volumes) {
+		t.Fatalf("Expected compose config and a command per volume, got %v", commandLines(commands))
+	}
+	for i, volume := range volumes {
+		cmd := commands[1+i]
+		if want := backupCommand("busybox", volume, backupDir); cmd.Name != "docker" || !reflect.DeepEqual(cmd.Args, want) {
+			t.Errorf("Expected docker %v, got %s %v", want, cmd.Name, cmd.Args)
+		}
+		if backups[i].Volume != volume || backups[i].Archive != filepath.Join(backupDir, volume+".tar.gz") {
+			t.Errorf("Unexpected backup %+v of volume %s", backups[i], volume)
+		}
+	}
+}
+
+func TestBackupVolumesUsesConfiguredImageAndDockerContext(t *testing.T) {
+	r, runner := newVolumeBackupRepository(t, &config.Config{DockerContext: "rootless", VolumeBackupImage: "alpine:3"}, "volumes:\n  data:\n    name: demo_data\n",
+		command.FakeResult{Stdout: []byte("unix:///run/user/1000/docker.sock\n")})
+
+	backups, err := r.BackupVolumes("app")
+	if err != nil {
+		t.Fatalf("BackupVolumes returned error: %v", err)
+	}
+
+	commands := runner.Commands()
+	want := append([]string{"--context", "rootless"}, backupCommand("alpine:3", "demo_data", filepath.Dir(backups[0].Archive))...)
+	if len(commands) != 3 || !reflect.DeepEqual(commands[2].Args, want) {
+		t.Errorf("Expected docker %v, got %v", want, commandLines(commands))
+	}
+}
+
+func TestBackupVolumesRejectsRemoteDockerContext(t *testing.T) {
+	r, runner := newVolumeBackupRepository(t, &config.Config{DockerContext: "remote"}, "volumes:\n  data:\n    name: demo_data\n",
+		command.FakeResult{Stdout: []byte("ssh://deploy@db.example.com\n")})
+
+	_, err := r.BackupVolumes("app")
+	if err == nil || !strings.Contains(err.Error(), "not local") {
+		t.Fatalf("Expected the backup on a remote daemon to be rejected, got %v", err)
+	}
+	if lines := commandLines(runner.Commands()); len(lines) != 2 || !strings.HasPrefix(lines[1], "docker context inspect") {
+		t.Errorf("Expected no backup container to be run, got %v", lines)
+	}
+	if dirExists(filepath.Join(r.config.GetVolumeBackupDir(), "app")) {
+		t.Error("Expected no backup directory to be created")
+	}
+}
+
+func TestBackupVolumesWithoutNamedVolumes(t *testing.T) {
+	r, runner := newVolumeBackupRepository(t, &config.Config{}, "services:\n  web:\n    image: nginx\n")
+
+	backups, err := r.BackupVolumes("app")
+	if err != nil || len(backups) != 0 {
+		t.Fatalf("Expected no backups, got %+v, %v", backups, err)
+	}
+	if commands := runner.Commands(); len(commands) != 1 {
+		t.Errorf("Expected only compose config, got %v", commandLines(commands))
+	}
+	if dirExists(r.config.GetVolumeBackupDir()) {
+		t.Error("Expected no backup directory without volumes")
+	}
+}
+
+func TestBackupVolumesStopsAtFailedVolume(t *testing.T) {
+	r, runner := newVolumeBackupRepository(t, &config.Config{}, volumesConfig,
+		command.FakeResult{}, command.FakeResult{Err: errors.New("exit status 1"), Stderr: []byte("no space left on device")})
+
+	backups, err := r.BackupVolumes("app")
+	if err == nil || !strings.Contains(err.Error(), "demo_db") || !strings.Contains(err.Error(), "no space left on device") {
+		t.Fatalf("Expected the failed volume in the error, got %v", err)
+	}
+	if len(backups) != 1 || backups[0].Volume != "demo_cache" {
+		t.Errorf("Expected the backup written before the failure, got %+v", backups)
+	}
+	if commands := runner.Commands(); len(commands) != 3 {
+		t.Errorf("Expected no backup after the failure, got %v", commandLines(commands))
+	}
+}
+
+func TestBackupVolumesOfMissingApp(t *testing.T) {
+	r := newTestRepository(t, &config.Config{}, &command.FakeRunner{})
+
+	if _, err := r.BackupVolumes("missing"); err == nil {
+		t.Fatal("Expected an error for an app that is not deployed")
+	}
+}
+
+func TestUpdateAppBacksUpVolumesFirstWhenConfigured(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo"}`)
+	r.config.BackupVolumesBeforeUpdate = true
+	runner.Results = []command.FakeResult{{Stdout: []byte("volumes:\n  data:\n    name: demo_data\n")}}
+
+	if err := r.UpdateApp("app"); err != nil {
+		t.Fatalf("UpdateApp returned error: %v", err)
+	}
+
+	got := composeSubcommands(runner.Commands())
+	if len(got) != 4 || got[0] != "config" || !strings.HasPrefix(got[1], "run --rm -v demo_data:/data:ro") || got[2] != "pull" || got[3] != "up -d" {
+		t.Errorf("Expected the volume backup before pull and up, got %q", got)
+	}
+}
+
+func TestUpdateAppFailsWhenVolumeBackupFails(t *testing.T) {
+	r, runner := newRollingRepository(t, newRollingFixture(), `{"id":"app","name":"demo"}`)
+	r.config.BackupVolumesBeforeUpdate = true
+	runner.Results = []command.FakeResult{{Stdout: []byte("volumes:\n  data:\n    name: demo_data\n")}, {Err: errors.New("exit status 1")}}
+
+	err := r.UpdateApp("app")
+	if err == nil || !strings.Contains(err.Error(), "volume backup before update failed") {
+		t.Fatalf("Expected the backup failure, got %v", err)
+	}
+	if got := composeSubcommands(runner.Commands()); len(got) != 2 {
+		t.Errorf("Expected no pull after the failed backup, got %q", got)
+	}
+}
+
+func TestDeleteAppPurgeBacksUpVolumesFirstWhenConfigured(t *testing.T) {
+	for _, backupFails := range []bool{false, true} {
+		results := []command.FakeResult{{}}
+		if backupFails {
+			results = []command.FakeResult{{Err: errors.New("exit status 1")}}
+		}
+		r, runner := newVolumeBackupRepository(t, &config.Config{BackupVolumesBeforePurge: true}, "volumes:\n  data:\n    name: demo_data\n", results...)
+		r.client = newFakeDockerClient(t, nil)
+		writeRevision(t, filepath.Join(r.config.GetAppsTemplatesPath(), "app", "1"))
+
+		err := r.DeleteApp("app", true)
+		if (err != nil) != backupFails {
+			t.Fatalf("DeleteApp returned error %v, want error %v", err, backupFails)
+		}
+
+		lines := commandLines(runner.Commands())
+		want := 3
+		if backupFails {
+			want = 2
+		}
+		if len(lines) != want || !strings.HasPrefix(lines[1], "docker run --rm -v demo_data:/data:ro") {
+			t.Errorf("Expected the volume backup before the purge, got %v", lines)
+		}
+		if backupFails && !dirExists(r.getAppDir("app")) {
+			t.Error("Expected the app directory to be kept after a failed backup")
+		}
+	}
+}
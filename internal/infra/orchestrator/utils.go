@@ -1,11 +1,11 @@
 package orchestrator
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"winterflow-agent/internal/application/config"
 	"winterflow-agent/internal/domain/model"
 )
 
@@ -27,26 +27,57 @@ func MapDockerStateToContainerStatus(state string) model.ContainerStatusCode {
 	}
 }
 
+// ReadAppConfig loads the app configuration stored in dir under the first of model.AppConfigFileNames
+// that exists and returns it together with the path it was read from. The error wraps os.ErrNotExist
+// when dir holds no configuration.
+func ReadAppConfig(dir string) (*model.AppConfig, string, error) {
+	for _, name := range model.AppConfigFileNames {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, path, fmt.Errorf("failed to read configuration %s: %w", path, err)
+		}
+		appConfig, err := model.ParseAppConfigFile(name, data)
+		if err != nil {
+			return nil, path, fmt.Errorf("failed to parse configuration %s: %w", path, err)
+		}
+		return appConfig, path, nil
+	}
+	path := filepath.Join(dir, model.AppConfigFileNames[0])
+	return nil, path, fmt.Errorf("failed to read configuration %s: %w", path, os.ErrNotExist)
+}
+
 // SaveCurrentConfigCopy creates/updates a lightweight copy of the configuration that is currently
 // being deployed. It copies <templateDir>/config.json into
 //
-//	apps_templates/{app_id}/current.config.json
+//	<appDir>/.winterflow.config.json
 //
 // so that other system components can quickly inspect the active configuration without having to
-// resolve versions.
+// resolve versions. Hand-edited YAML configurations are stored as canonical JSON.
 //
 // The function is orchestration-agnostic – it operates purely on the file system and therefore sits
 // at the generic orchestrator layer rather than inside a concrete implementation such as
 // docker_compose.
-func SaveCurrentConfigCopy(cfg *config.Config, appID, templateDir string) error {
-	srcConfigPath := filepath.Join(templateDir, "config.json")
-
-	data, err := os.ReadFile(srcConfigPath)
+func SaveCurrentConfigCopy(appDir, templateDir string) error {
+	appConfig, srcConfigPath, err := ReadAppConfig(templateDir)
 	if err != nil {
-		return fmt.Errorf("failed to read source configuration %s: %w", srcConfigPath, err)
+		return err
+	}
+
+	var data []byte
+	if filepath.Ext(srcConfigPath) == ".json" {
+		// Copy JSON verbatim so that fields unknown to this agent version are kept.
+		if data, err = os.ReadFile(srcConfigPath); err != nil {
+			return fmt.Errorf("failed to read source configuration %s: %w", srcConfigPath, err)
+		}
+	} else if data, err = json.Marshal(appConfig); err != nil {
+		return fmt.Errorf("failed to marshal source configuration %s: %w", srcConfigPath, err)
 	}
 
-	dstConfigPath := filepath.Join(cfg.GetAppsPath(), appID, ".winterflow.config.json")
+	dstConfigPath := filepath.Join(appDir, ".winterflow.config.json")
 
 	// Ensure destination directory exists.
 	if err := os.MkdirAll(filepath.Dir(dstConfigPath), 0o755); err != nil {
@@ -60,14 +91,14 @@ func SaveCurrentConfigCopy(cfg *config.Config, appID, templateDir string) error
 	return nil
 }
 
-// GetCurrentConfig loads and parses the current.config.json for an application.
+// GetCurrentConfig loads and parses the .winterflow.config.json of the application deployed to appDir.
 // It returns a parsed *model.AppConfig representing the configuration that was
 // last deployed (i.e. written by SaveCurrentConfigCopy).
 //
 // The helper centralises path resolution and JSON parsing so that callers do
 // not need to duplicate this logic across the codebase.
-func GetCurrentConfig(cfg *config.Config, appID string) (*model.AppConfig, error) {
-	currentCfgPath := filepath.Join(cfg.GetAppsPath(), appID, ".winterflow.config.json")
+func GetCurrentConfig(appDir string) (*model.AppConfig, error) {
+	currentCfgPath := filepath.Join(appDir, ".winterflow.config.json")
 
 	data, err := os.ReadFile(currentCfgPath)
 	if err != nil {
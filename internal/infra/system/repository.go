@@ -0,0 +1,142 @@
+package system
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/client"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+)
+
+// dockerTimeout bounds the Docker API calls so that an unresponsive engine does not block diagnostics.
+const dockerTimeout = 10 * time.Second
+
+// systemInfoRepository reads host details from /proc, /etc/os-release and the Docker engine.
+type systemInfoRepository struct {
+	client        *client.Client
+	procPath      string
+	osReleasePath string
+}
+
+// Compile-time assertion that *systemInfoRepository implements the interface.
+var _ repository.SystemInfoRepository = (*systemInfoRepository)(nil)
+
+// NewSystemInfoRepository creates a new SystemInfoRepository. A nil Docker client makes
+// GetDockerEngine fail while the host details remain available.
+func NewSystemInfoRepository(dockerClient *client.Client) repository.SystemInfoRepository {
+	return &systemInfoRepository{
+		client:        dockerClient,
+		procPath:      "/proc",
+		osReleasePath: "/etc/os-release",
+	}
+}
+
+func (r *systemInfoRepository) GetOSName() (string, error) {
+	file, err := os.Open(r.osReleasePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read os release: %w", err)
+	}
+	defer file.Close()
+
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read os release: %w", err)
+	}
+
+	for _, key := range []string{"PRETTY_NAME", "NAME"} {
+		if name := fields[key]; name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("os name not found in %s", r.osReleasePath)
+}
+
+func (r *systemInfoRepository) GetKernelVersion() (string, error) {
+	data, err := os.ReadFile(filepath.Join(r.procPath, "sys", "kernel", "osrelease"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read kernel version: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (r *systemInfoRepository) GetMemoryTotal() (uint64, error) {
+	file, err := os.Open(filepath.Join(r.procPath, "meminfo"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read meminfo: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemTotal value %q: %w", fields[1], err)
+		}
+		return kb * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read meminfo: %w", err)
+	}
+	return 0, fmt.Errorf("MemTotal not found in meminfo")
+}
+
+func (r *systemInfoRepository) GetCPUCount() (int, error) {
+	return runtime.NumCPU(), nil
+}
+
+func (r *systemInfoRepository) GetDiskUsage(path string) (model.DiskUsage, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return model.DiskUsage{}, fmt.Errorf("failed to stat filesystem of %s: %w", path, err)
+	}
+	return model.DiskUsage{
+		TotalBytes:     fs.Blocks * uint64(fs.Bsize),
+		AvailableBytes: fs.Bavail * uint64(fs.Bsize),
+	}, nil
+}
+
+func (r *systemInfoRepository) GetDockerEngine() (model.DockerEngineInfo, error) {
+	if r.client == nil {
+		return model.DockerEngineInfo{}, fmt.Errorf("docker client is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerTimeout)
+	defer cancel()
+
+	version, err := r.client.ServerVersion(ctx)
+	if err != nil {
+		return model.DockerEngineInfo{}, fmt.Errorf("failed to get docker server version: %w", err)
+	}
+	info, err := r.client.Info(ctx)
+	if err != nil {
+		return model.DockerEngineInfo{}, fmt.Errorf("failed to get docker info: %w", err)
+	}
+
+	return model.DockerEngineInfo{
+		Version:         version.Version,
+		APIVersion:      version.APIVersion,
+		OperatingSystem: info.OperatingSystem,
+	}, nil
+}
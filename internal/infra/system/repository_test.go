@@ -0,0 +1,61 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepository returns a repository reading from a fake /proc and os-release in a temporary directory.
+func newTestRepository(t *testing.T, files map[string]string) *systemInfoRepository {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return &systemInfoRepository{
+		procPath:      filepath.Join(root, "proc"),
+		osReleasePath: filepath.Join(root, "os-release"),
+	}
+}
+
+func TestSystemInfoRepositoryReadsHostFiles(t *testing.T) {
+	r := newTestRepository(t, map[string]string{
+		"os-release":                "NAME=\"Ubuntu\"\nPRETTY_NAME=\"Ubuntu 24.04 LTS\"\n",
+		"proc/sys/kernel/osrelease": "6.8.0-31-generic\n",
+		"proc/meminfo":              "MemTotal:        2048 kB\nMemFree:          512 kB\n",
+	})
+
+	if name, err := r.GetOSName(); err != nil || name != "Ubuntu 24.04 LTS" {
+		t.Errorf("Expected pretty OS name, got %q (%v)", name, err)
+	}
+	if kernel, err := r.GetKernelVersion(); err != nil || kernel != "6.8.0-31-generic" {
+		t.Errorf("Expected kernel version, got %q (%v)", kernel, err)
+	}
+	if memory, err := r.GetMemoryTotal(); err != nil || memory != 2048*1024 {
+		t.Errorf("Expected total memory in bytes, got %d (%v)", memory, err)
+	}
+}
+
+func TestSystemInfoRepositoryReportsMissingSources(t *testing.T) {
+	r := newTestRepository(t, map[string]string{"proc/meminfo": "MemFree: 512 kB\n"})
+
+	if _, err := r.GetOSName(); err == nil {
+		t.Error("Expected missing os-release to fail")
+	}
+	if _, err := r.GetKernelVersion(); err == nil {
+		t.Error("Expected missing kernel version to fail")
+	}
+	if _, err := r.GetMemoryTotal(); err == nil {
+		t.Error("Expected meminfo without MemTotal to fail")
+	}
+	if _, err := r.GetDockerEngine(); err == nil {
+		t.Error("Expected docker engine details to fail without a client")
+	}
+}
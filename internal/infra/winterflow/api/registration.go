@@ -9,6 +9,7 @@ import (
 
 	"winterflow-agent/internal/application/config"
 	"winterflow-agent/pkg/certs"
+	"winterflow-agent/pkg/device"
 )
 
 // RegistrationError represents a structured error response from the server
@@ -37,10 +38,21 @@ func RegisterAgent(configPath string, orchestrator string) error {
 		}
 	}
 
+	deviceID, err := device.GetDeviceID()
+	if err != nil {
+		fmt.Printf("Warning: Failed to determine the device ID, cloned agents will not be detected: %v\n", err)
+	}
+
 	// Check if agent is already registered
 	if cfg.AgentStatus == config.AgentStatusRegistered {
-		fmt.Println("\n=== Agent Already Registered ===")
-		return nil
+		if cfg.DeviceID == "" || deviceID == "" || cfg.DeviceID == deviceID {
+			fmt.Println("\n=== Agent Already Registered ===")
+			return nil
+		}
+		// The configuration was copied from another host; register this host as a new agent.
+		fmt.Println("\nThe agent was registered on another device, registering this device as a new agent...")
+		cfg.AgentID = ""
+		cfg.AgentStatus = config.AgentStatusUnknown
 	}
 
 	client := NewClient(cfg.GetAPIBaseURL())
@@ -159,6 +171,7 @@ func RegisterAgent(configPath string, orchestrator string) error {
 		case "registered":
 			// Update agent status to registered
 			cfg.AgentStatus = config.AgentStatusRegistered
+			cfg.DeviceID = deviceID
 			if err := config.SaveConfig(cfg, configPath); err != nil {
 				fmt.Printf("Failed to update agent status to registered: %v", err)
 			} else {
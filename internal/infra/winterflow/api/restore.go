@@ -66,19 +66,98 @@ func RestoreAgentData(configPath string) error {
 	}
 
 	// ---------------------------------------------------------------------
-	// 2. Create backup of apps_templates if it doesn't exist
+	// 2-3. Back up apps_templates and rewrite its structure
+	// ---------------------------------------------------------------------
+	apps, err := restoreTemplates(cfg.GetAppsTemplatesPath(), restoreBackupPath(cfg))
+	if err != nil {
+		return err
+	}
+
+	// No apps found – nothing to send.
+	if len(apps) == 0 {
+		log.Info("No application templates found - restore finished")
+		return nil
+	}
+
+	// ---------------------------------------------------------------------
+	// 4. Create signed secret (agent_id + timestamp + apps)
 	// ---------------------------------------------------------------------
-	templatesRoot := cfg.GetAppsTemplatesPath()
-	backupRoot := filepath.Join(cfg.BasePath, "apps_templates.bak")
+	// Create deterministic representation of apps slice by sorting by app_id.
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+
+	appsJSON, err := json.Marshal(apps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal apps for signing: %w", err)
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	message := []byte(cfg.AgentID + timestamp + string(appsJSON))
+
+	secret, err := certs.SignWithPrivateKey(cfg.GetPrivateKeyPath(), message)
+	if err != nil {
+		return fmt.Errorf("failed to sign secret: %w", err)
+	}
+
+	// ---------------------------------------------------------------------
+	// 5. Send request to backend
+	// ---------------------------------------------------------------------
+	payload := restoreDataRequest{
+		AgentID:   cfg.AgentID,
+		Timestamp: timestamp,
+		Secret:    secret,
+		Apps:      apps,
+	}
+
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/data/restore", cfg.GetAPIBaseURL())
+	log.Info("Sending restore request", "url", url)
+
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server responded with %d: %s", resp.StatusCode, string(body))
+	}
+
+	log.Info("Restore completed successfully")
+	return nil
+}
 
+// restoreBackupPath returns the directory the templates are backed up to before they are restored.
+func restoreBackupPath(cfg *config.Config) string {
+	return filepath.Join(cfg.BasePath, "apps_templates.bak")
+}
+
+// restoreTemplates backs templatesRoot up to backupRoot, then regenerates the app IDs and keeps only the
+// latest revision of every app. It returns the restored apps to report to the backend.
+func restoreTemplates(templatesRoot, backupRoot string) ([]AppInfo, error) {
+	// ---------------------------------------------------------------------
+	// 2. Create backup of apps_templates if it doesn't exist
+	// ---------------------------------------------------------------------
 	if _, err := os.Stat(backupRoot); err == nil {
 		// directory exists
-		return fmt.Errorf("backup directory already exists: %s – aborting to prevent overwrite", backupRoot)
+		return nil, fmt.Errorf("backup directory already exists: %s – aborting to prevent overwrite", backupRoot)
 	}
 
 	log.Info("Creating backup of application templates", "source", templatesRoot, "destination", backupRoot)
 	if err := copyDirectoryRecursive(templatesRoot, backupRoot); err != nil {
-		return fmt.Errorf("failed to create backup: %w", err)
+		return nil, fmt.Errorf("failed to create backup: %w", err)
 	}
 	log.Info("Backup created successfully", "path", backupRoot)
 
@@ -87,7 +166,7 @@ func RestoreAgentData(configPath string) error {
 	// ---------------------------------------------------------------------
 	entries, err := os.ReadDir(templatesRoot)
 	if err != nil {
-		return fmt.Errorf("cannot read apps_templates directory %s: %w", templatesRoot, err)
+		return nil, fmt.Errorf("cannot read apps_templates directory %s: %w", templatesRoot, err)
 	}
 
 	var apps []AppInfo
@@ -307,70 +386,7 @@ func RestoreAgentData(configPath string) error {
 		}
 	}
 
-	// No apps found – nothing to send.
-	if len(apps) == 0 {
-		log.Info("No application templates found - restore finished")
-		return nil
-	}
-
-	// ---------------------------------------------------------------------
-	// 4. Create signed secret (agent_id + timestamp + apps)
-	// ---------------------------------------------------------------------
-	// Create deterministic representation of apps slice by sorting by app_id.
-	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
-
-	appsJSON, err := json.Marshal(apps)
-	if err != nil {
-		return fmt.Errorf("failed to marshal apps for signing: %w", err)
-	}
-
-	timestamp := time.Now().UTC().Format(time.RFC3339)
-	message := []byte(cfg.AgentID + timestamp + string(appsJSON))
-
-	secret, err := certs.SignWithPrivateKey(cfg.GetPrivateKeyPath(), message)
-	if err != nil {
-		return fmt.Errorf("failed to sign secret: %w", err)
-	}
-
-	// ---------------------------------------------------------------------
-	// 5. Send request to backend
-	// ---------------------------------------------------------------------
-	payload := restoreDataRequest{
-		AgentID:   cfg.AgentID,
-		Timestamp: timestamp,
-		Secret:    secret,
-		Apps:      apps,
-	}
-
-	jsonBody, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	url := fmt.Sprintf("%s/api/v1/data/restore", cfg.GetAPIBaseURL())
-	log.Info("Sending restore request", "url", url)
-
-	httpClient := &http.Client{Timeout: 15 * time.Second}
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("server responded with %d: %s", resp.StatusCode, string(body))
-	}
-
-	log.Info("Restore completed successfully")
-	return nil
+	return apps, nil
 }
 
 // copyDirectoryRecursive duplicates the entire src directory tree under dst.
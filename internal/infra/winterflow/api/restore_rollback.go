@@ -0,0 +1,156 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"winterflow-agent/internal/application/config"
+	domain "winterflow-agent/internal/domain/model"
+	"winterflow-agent/pkg/files"
+	"winterflow-agent/pkg/log"
+)
+
+// RollbackRestore reverses `--restore` by replacing apps_templates with the apps_templates.bak backup it
+// created. The backup is removed on success, so a later `--restore` can create a new one.
+//
+// It is intended to be executed via `winterflow-agent --restore-rollback` while the agent is stopped: it
+// refuses to run while an agent holds the lock of the configuration.
+func RollbackRestore(configPath string) error {
+	log.Info("Starting restore rollback")
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	unlock, err := files.TryLock(config.GetLockPath(configPath))
+	if errors.Is(err, files.ErrLocked) {
+		return fmt.Errorf("the agent is running – stop it before running --restore-rollback")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock the agent: %w", err)
+	}
+	defer unlock()
+
+	if err := rollbackTemplates(cfg.GetAppsTemplatesPath(), restoreBackupPath(cfg)); err != nil {
+		return err
+	}
+
+	log.Warn("Restore rolled back; the apps created on the server by the restore keep their new IDs and should be removed there")
+	return nil
+}
+
+// rollbackTemplates replaces templatesRoot with backupRoot once both are verified to be the state before
+// and after a restore. The current templates are only removed after the backup took their place.
+func rollbackTemplates(templatesRoot, backupRoot string) error {
+	info, err := os.Stat(backupRoot)
+	if err != nil {
+		return fmt.Errorf("no restore backup found at %s: %w", backupRoot, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("restore backup %s is not a directory", backupRoot)
+	}
+	if err := validateRestoreBackup(backupRoot); err != nil {
+		return err
+	}
+	if err := validateRestoredTemplates(templatesRoot); err != nil {
+		return err
+	}
+
+	previousRoot := templatesRoot + ".rollback"
+	if _, err := os.Stat(previousRoot); err == nil {
+		return fmt.Errorf("directory %s already exists – aborting to prevent overwrite", previousRoot)
+	}
+
+	log.Info("Restoring application templates from backup", "source", backupRoot, "destination", templatesRoot)
+	if err := os.Rename(templatesRoot, previousRoot); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to move restored templates aside: %w", err)
+	}
+	if err := os.Rename(backupRoot, templatesRoot); err != nil {
+		if restoreErr := os.Rename(previousRoot, templatesRoot); restoreErr != nil && !os.IsNotExist(restoreErr) {
+			log.Error("Failed to put the restored templates back", "path", previousRoot, "error", restoreErr)
+		}
+		return fmt.Errorf("failed to move backup into place: %w", err)
+	}
+	if err := os.RemoveAll(previousRoot); err != nil {
+		log.Warn("Failed to remove the restored templates", "path", previousRoot, "error", err)
+	}
+
+	log.Info("Application templates restored from backup", "path", templatesRoot)
+	return nil
+}
+
+// validateRestoreBackup verifies that every app of the backup has a revision with a readable config.json.
+func validateRestoreBackup(backupRoot string) error {
+	entries, err := os.ReadDir(backupRoot)
+	if err != nil {
+		return fmt.Errorf("cannot read restore backup %s: %w", backupRoot, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		revision, ok, err := latestRevision(filepath.Join(backupRoot, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("restore backup of app %s is not readable: %w", entry.Name(), err)
+		}
+		if !ok {
+			continue
+		}
+		cfgPath := filepath.Join(backupRoot, entry.Name(), revision, "config.json")
+		data, err := os.ReadFile(cfgPath)
+		if err != nil {
+			return fmt.Errorf("restore backup of app %s is incomplete: %w", entry.Name(), err)
+		}
+		if _, err := domain.ParseAppConfig(data); err != nil {
+			return fmt.Errorf("restore backup of app %s has an invalid config.json: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// validateRestoredTemplates verifies that the templates were not changed since the restore, which
+// leaves revision 1 as the only revision of every app. Later revisions would be lost by the rollback.
+func validateRestoredTemplates(templatesRoot string) error {
+	entries, err := os.ReadDir(templatesRoot)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read apps_templates directory %s: %w", templatesRoot, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		revision, ok, err := latestRevision(filepath.Join(templatesRoot, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("cannot read templates of app %s: %w", entry.Name(), err)
+		}
+		if ok && revision != "1" {
+			return fmt.Errorf("app %s has revision %s saved after the restore – aborting to prevent losing it", entry.Name(), revision)
+		}
+	}
+	return nil
+}
+
+// latestRevision returns the name of the highest numeric revision directory of the app.
+func latestRevision(appPath string) (string, bool, error) {
+	revisions, err := os.ReadDir(appPath)
+	if err != nil {
+		return "", false, err
+	}
+	latest, name := -1, ""
+	for _, r := range revisions {
+		if !r.IsDir() {
+			continue
+		}
+		if n, err := strconv.Atoi(r.Name()); err == nil && n > latest {
+			latest, name = n, r.Name()
+		}
+	}
+	return name, latest >= 0, nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/files"
+)
+
+// writeTemplateRevision writes a minimal revision of the app below templatesRoot.
+func writeTemplateRevision(t *testing.T, templatesRoot, appID, revision string) {
+	t.Helper()
+	dir := filepath.Join(templatesRoot, appID, revision)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `{"id":"` + appID + `","name":"demo","version":"` + revision + `"}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreRollbackRoundTrip(t *testing.T) {
+	base := t.TempDir()
+	templatesRoot := filepath.Join(base, "apps_templates")
+	backupRoot := filepath.Join(base, "apps_templates.bak")
+	writeTemplateRevision(t, templatesRoot, "old-app", "1")
+	writeTemplateRevision(t, templatesRoot, "old-app", "2")
+
+	apps, err := restoreTemplates(templatesRoot, backupRoot)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if len(apps) != 1 || apps[0].ID == "old-app" {
+		t.Fatalf("Expected the app to be restored with a new ID, got %+v", apps)
+	}
+	if _, err := os.Stat(filepath.Join(templatesRoot, apps[0].ID, "1", "config.json")); err != nil {
+		t.Fatalf("Expected the restored revision, got %v", err)
+	}
+
+	if err := rollbackTemplates(templatesRoot, backupRoot); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	for _, revision := range []string{"1", "2"} {
+		if _, err := os.Stat(filepath.Join(templatesRoot, "old-app", revision, "config.json")); err != nil {
+			t.Errorf("Expected revision %s of the original app to be back, got %v", revision, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(templatesRoot, apps[0].ID)); !os.IsNotExist(err) {
+		t.Errorf("Expected the restored app to be removed, got %v", err)
+	}
+	for _, path := range []string{backupRoot, templatesRoot + ".rollback"} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", path, err)
+		}
+	}
+
+	// The backup is consumed, so the restore can be run again.
+	if _, err := restoreTemplates(templatesRoot, backupRoot); err != nil {
+		t.Errorf("Expected a new restore to succeed, got %v", err)
+	}
+}
+
+func TestRollbackTemplatesRequiresBackup(t *testing.T) {
+	base := t.TempDir()
+	templatesRoot := filepath.Join(base, "apps_templates")
+	writeTemplateRevision(t, templatesRoot, "app", "1")
+
+	if err := rollbackTemplates(templatesRoot, filepath.Join(base, "apps_templates.bak")); err == nil {
+		t.Fatal("Expected the rollback to fail without a backup")
+	}
+	if _, err := os.Stat(filepath.Join(templatesRoot, "app", "1")); err != nil {
+		t.Errorf("Expected the templates to be kept, got %v", err)
+	}
+}
+
+func TestRollbackTemplatesRejectsInconsistentState(t *testing.T) {
+	t.Run("incomplete backup", func(t *testing.T) {
+		base := t.TempDir()
+		templatesRoot := filepath.Join(base, "apps_templates")
+		backupRoot := filepath.Join(base, "apps_templates.bak")
+		writeTemplateRevision(t, templatesRoot, "new-app", "1")
+		if err := os.MkdirAll(filepath.Join(backupRoot, "old-app", "3"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		err := rollbackTemplates(templatesRoot, backupRoot)
+		if err == nil || !strings.Contains(err.Error(), "incomplete") {
+			t.Fatalf("Expected the incomplete backup to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("revision saved after the restore", func(t *testing.T) {
+		base := t.TempDir()
+		templatesRoot := filepath.Join(base, "apps_templates")
+		backupRoot := filepath.Join(base, "apps_templates.bak")
+		writeTemplateRevision(t, templatesRoot, "old-app", "1")
+		if _, err := restoreTemplates(templatesRoot, backupRoot); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := os.ReadDir(templatesRoot)
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("Expected one restored app, got %v %v", entries, err)
+		}
+		writeTemplateRevision(t, templatesRoot, entries[0].Name(), "2")
+
+		err = rollbackTemplates(templatesRoot, backupRoot)
+		if err == nil || !strings.Contains(err.Error(), "saved after the restore") {
+			t.Fatalf("Expected the new revision to block the rollback, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(backupRoot, "old-app", "1")); err != nil {
+			t.Errorf("Expected the backup to be kept, got %v", err)
+		}
+	})
+}
+
+func TestRollbackRestoreRefusesWhileAgentRuns(t *testing.T) {
+	base := t.TempDir()
+	configPath := filepath.Join(base, "agent.config.json")
+	if err := os.WriteFile(configPath, []byte(`{"base_path":"`+base+`"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeTemplateRevision(t, filepath.Join(base, "apps_templates.bak"), "old-app", "1")
+
+	unlock, err := files.TryLock(config.GetLockPath(configPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = RollbackRestore(configPath)
+	if err == nil || !strings.Contains(err.Error(), "agent is running") {
+		t.Fatalf("Expected the rollback to be refused, got %v", err)
+	}
+
+	unlock()
+	if err := RollbackRestore(configPath); err != nil {
+		t.Fatalf("Expected the rollback to succeed once the agent stopped, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(base, "apps_templates", "old-app", "1")); err != nil {
+		t.Errorf("Expected the backup to be restored, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package certs
 import (
 	"embed"
 	"io/fs"
+	"net/http"
 
 	"winterflow-agent/pkg/log"
 
@@ -15,6 +16,8 @@ var certsFS embed.FS
 
 type Manager struct {
 	embeddedManager *embedded.Manager
+	config          *config.Config
+	httpClient      *http.Client
 }
 
 func NewManager(configPath string) *Manager {
@@ -32,11 +35,24 @@ func NewManager(configPath string) *Manager {
 
 	return &Manager{
 		embeddedManager: embedded.NewManager(subFS, cfg.GetCertificatesDefaultFolder()),
+		config:          cfg,
+		httpClient:      &http.Client{Timeout: caCertificateFetchTimeout},
 	}
 }
 
-// SyncFiles synchronizes the certificate files using the embeddedManager's SyncFiles method.
+// SyncFiles synchronizes the certificate files using the embeddedManager's SyncFiles method. When a CA
+// certificate URL is configured, the CA certificate is then replaced by the fetched one if it matches the
+// pinned fingerprint; the embedded CA certificate is kept when it cannot be fetched or verified.
 func (m *Manager) SyncFiles() error {
 	log.Debug("Syncing certificates")
-	return m.embeddedManager.SyncFiles()
+	if err := m.embeddedManager.SyncFiles(); err != nil {
+		return err
+	}
+	if m.config.CACertificateURL == "" {
+		return nil
+	}
+	if err := m.syncRemoteCACertificate(); err != nil {
+		log.Warn("Failed to fetch the CA certificate, using the embedded one", "url", m.config.CACertificateURL, "error", err)
+	}
+	return nil
 }
@@ -0,0 +1,102 @@
+package certs
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"winterflow-agent/pkg/certs"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// caCertificateFetchTimeout limits fetching the CA certificate from the configured URL.
+	caCertificateFetchTimeout = 30 * time.Second
+	// maxCACertificateSize bounds the response read from the CA certificate URL.
+	maxCACertificateSize = 1 << 20
+)
+
+// syncRemoteCACertificate fetches the CA certificate from the configured URL and writes it to the CA
+// certificate path if its fingerprint matches the pinned one.
+func (m *Manager) syncRemoteCACertificate() error {
+	fingerprint := m.config.CACertificateFingerprint
+	if fingerprint == "" {
+		return fmt.Errorf("ca_certificate_fingerprint is required with ca_certificate_url")
+	}
+
+	data, err := fetchCACertificate(m.httpClient, m.config.CACertificateURL)
+	if err != nil {
+		return err
+	}
+	cert, err := certs.VerifyPinnedCertificate(data, fingerprint)
+	if err != nil {
+		return err
+	}
+
+	path := m.config.GetCACertificatePath()
+	if err := writeFileAtomic(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})); err != nil {
+		return fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+	log.Info("Updated the CA certificate from the configured URL", "path", path, "subject", cert.Subject.String(), "not_after", cert.NotAfter)
+	return nil
+}
+
+// fetchCACertificate downloads the CA certificate from rawURL, which must be an HTTPS URL.
+func fetchCACertificate(client *http.Client, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("CA certificate URL must use https, got %q", u.Scheme)
+	}
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch CA certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch CA certificate: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCACertificateSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	if len(data) > maxCACertificateSize {
+		return nil, fmt.Errorf("CA certificate exceeds %d bytes", maxCACertificateSize)
+	}
+	return data, nil
+}
+
+// writeFileAtomic replaces the file at path with data, so that a partially written CA certificate is
+// never used.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
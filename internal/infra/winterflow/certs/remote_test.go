@@ -0,0 +1,123 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/certs"
+	"winterflow-agent/pkg/embedded"
+)
+
+// newTestCertificate returns a self-signed CA certificate and its PEM encoding.
+func newTestCertificate(t *testing.T, name string) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// newRemoteCAManager returns a manager with an embedded CA certificate that fetches the CA certificate from
+// a TLS server answering with handler.
+func newRemoteCAManager(t *testing.T, embeddedCA []byte, fingerprint string, handler http.HandlerFunc) *Manager {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{BasePath: t.TempDir(), CACertificateURL: server.URL + "/ca.crt", CACertificateFingerprint: fingerprint}
+	return &Manager{
+		embeddedManager: embedded.NewManager(fstest.MapFS{cfg.GetCACertificateFile(): {Data: embeddedCA}}, cfg.GetCertificatesPath()),
+		config:          cfg,
+		httpClient:      server.Client(),
+	}
+}
+
+func readCA(t *testing.T, m *Manager) string {
+	t.Helper()
+	data, err := os.ReadFile(m.config.GetCACertificatePath())
+	if err != nil {
+		t.Fatalf("Failed to read CA certificate: %v", err)
+	}
+	return string(data)
+}
+
+func TestSyncFilesWritesFetchedCAWhenFingerprintMatches(t *testing.T) {
+	_, embeddedCA := newTestCertificate(t, "embedded")
+	rotated, rotatedPEM := newTestCertificate(t, "rotated")
+	m := newRemoteCAManager(t, embeddedCA, certs.Fingerprint(rotated), func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(rotatedPEM)
+	})
+
+	if err := m.SyncFiles(); err != nil {
+		t.Fatalf("SyncFiles failed: %v", err)
+	}
+	if got := readCA(t, m); got != string(rotatedPEM) {
+		t.Errorf("Expected the fetched CA certificate, got %q", got)
+	}
+}
+
+func TestSyncFilesKeepsEmbeddedCAWhenFingerprintMismatches(t *testing.T) {
+	embeddedCert, embeddedCA := newTestCertificate(t, "embedded")
+	_, rotatedPEM := newTestCertificate(t, "rotated")
+	m := newRemoteCAManager(t, embeddedCA, certs.Fingerprint(embeddedCert), func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(rotatedPEM)
+	})
+
+	if err := m.SyncFiles(); err != nil {
+		t.Fatalf("SyncFiles failed: %v", err)
+	}
+	if got := readCA(t, m); got != string(embeddedCA) {
+		t.Errorf("Expected the embedded CA certificate to be kept, got %q", got)
+	}
+}
+
+func TestSyncFilesKeepsEmbeddedCAWhenFetchFails(t *testing.T) {
+	_, embeddedCA := newTestCertificate(t, "embedded")
+	rotated, _ := newTestCertificate(t, "rotated")
+	m := newRemoteCAManager(t, embeddedCA, certs.Fingerprint(rotated), func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	if err := m.SyncFiles(); err != nil {
+		t.Fatalf("SyncFiles failed: %v", err)
+	}
+	if got := readCA(t, m); got != string(embeddedCA) {
+		t.Errorf("Expected the embedded CA certificate to be kept, got %q", got)
+	}
+}
+
+func TestFetchCACertificateRequiresHTTPS(t *testing.T) {
+	if _, err := fetchCACertificate(http.DefaultClient, "http://example.com/ca.crt"); err == nil {
+		t.Error("Expected a plain HTTP URL to be rejected")
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// appOutputBufferSize caps the output lines of an app action waiting to be sent. Further lines are
+	// dropped until the server caught up, and replaced by a marker line.
+	appOutputBufferSize = 512
+	// appOutputBatchSize caps the output lines sent in one AppOutputV1 message.
+	appOutputBatchSize = 64
+)
+
+// appOutputStream sends the output lines of an app action to the server as AppOutputV1 messages
+// answering the request the action was started by.
+type appOutputStream struct {
+	messageID string
+	agentID   string
+	appID     string
+	send      func(*pb.AgentMessage) error
+
+	lines chan model.OutputLine
+	done  chan struct{}
+
+	mu      sync.Mutex
+	dropped int
+	closed  bool
+}
+
+// newAppOutputStream starts sending the output lines written to the stream with send.
+func newAppOutputStream(send func(*pb.AgentMessage) error, messageID, agentID, appID string) *appOutputStream {
+	s := &appOutputStream{
+		messageID: messageID,
+		agentID:   agentID,
+		appID:     appID,
+		send:      send,
+		lines:     make(chan model.OutputLine, appOutputBufferSize),
+		done:      make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues the line to be sent without blocking. When the buffer is full the line is dropped; the
+// number of dropped lines is reported by a marker line once there is room again.
+func (s *appOutputStream) Write(line model.OutputLine) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.dropped > 0 {
+		select {
+		case s.lines <- droppedLinesMarker(s.dropped):
+			s.dropped = 0
+		default:
+			s.dropped++
+			return
+		}
+	}
+	select {
+	case s.lines <- line:
+	default:
+		s.dropped++
+	}
+}
+
+// Close sends the queued lines and the final message carrying the outcome of the action, then returns.
+func (s *appOutputStream) Close(code pb.ResponseCode, message string) {
+	s.mu.Lock()
+	s.closed = true
+	dropped := s.dropped
+	close(s.lines)
+	s.mu.Unlock()
+	<-s.done
+
+	var lines []*pb.AppOutputLineV1
+	if dropped > 0 {
+		lines = OutputLinesToProtoAppOutputLinesV1([]model.OutputLine{droppedLinesMarker(dropped)})
+	}
+	if err := s.send(s.message(code, message, lines, true)); err != nil {
+		log.Warn("Failed to send the end of the app output", "app_id", s.appID, "error", err)
+	}
+}
+
+// run sends the queued lines in batches until the stream is closed. After a failed send the remaining
+// lines are discarded.
+func (s *appOutputStream) run() {
+	defer close(s.done)
+	failed := false
+	for line := range s.lines {
+		batch := []model.OutputLine{line}
+	collect:
+		for len(batch) < appOutputBatchSize {
+			select {
+			case next, ok := <-s.lines:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, next)
+			default:
+				break collect
+			}
+		}
+		if failed {
+			continue
+		}
+		msg := s.message(pb.ResponseCode_RESPONSE_CODE_SUCCESS, "App output", OutputLinesToProtoAppOutputLinesV1(batch), false)
+		if err := s.send(msg); err != nil {
+			log.Warn("Failed to send app output, discarding the remaining output", "app_id", s.appID, "error", err)
+			failed = true
+		}
+	}
+}
+
+// message creates an AppOutputV1 message of the stream.
+func (s *appOutputStream) message(code pb.ResponseCode, message string, lines []*pb.AppOutputLineV1, ended bool) *pb.AgentMessage {
+	baseResp := createBaseResponse(s.messageID, s.agentID, code, message)
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_AppOutputV1{
+			AppOutputV1: &pb.AppOutputV1{
+				Base:  &baseResp,
+				AppId: s.appID,
+				Lines: lines,
+				Ended: ended,
+			},
+		},
+	}
+}
+
+// droppedLinesMarker returns the line reporting that count output lines were dropped.
+func droppedLinesMarker(count int) model.OutputLine {
+	return model.OutputLine{Channel: model.LogChannelUnknown, Text: fmt.Sprintf("[%d lines of output dropped]", count)}
+}
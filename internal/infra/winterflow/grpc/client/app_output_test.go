@@ -0,0 +1,93 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+// outputSender records the AppOutputV1 messages sent to it. Sends block while gate is not nil and open.
+type outputSender struct {
+	mu       sync.Mutex
+	messages []*pb.AppOutputV1
+	gate     chan struct{}
+}
+
+func (s *outputSender) Send(msg *pb.AgentMessage) error {
+	if s.gate != nil {
+		<-s.gate
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg.GetAppOutputV1())
+	return nil
+}
+
+func TestAppOutputStreamSendsLinesBeforeTheEnd(t *testing.T) {
+	sender := &outputSender{}
+	stream := newAppOutputStream(sender.Send, "msg-1", "agent", "app")
+	stream.Write(model.OutputLine{Channel: model.LogChannelStderr, Text: "Image postgres Pulled"})
+	stream.Write(model.OutputLine{Channel: model.LogChannelStdout, Text: "Container demo-db-1 Started"})
+	stream.Close(pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR, "Error controlling app: boom")
+
+	var texts []string
+	for i, msg := range sender.messages {
+		if msg.GetBase().GetMessageId() != "msg-1" || msg.GetAppId() != "app" {
+			t.Errorf("Expected message %d to answer msg-1 of app, got %q of %q", i, msg.GetBase().GetMessageId(), msg.GetAppId())
+		}
+		if ended := i == len(sender.messages)-1; msg.GetEnded() != ended {
+			t.Errorf("Expected only the last message to be ended, message %d ended=%v", i, msg.GetEnded())
+		}
+		for _, line := range msg.GetLines() {
+			texts = append(texts, line.GetText())
+		}
+	}
+	if len(texts) != 2 || texts[0] != "Image postgres Pulled" || texts[1] != "Container demo-db-1 Started" {
+		t.Errorf("Unexpected output lines %q", texts)
+	}
+	last := sender.messages[len(sender.messages)-1]
+	if last.GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR || last.GetBase().GetMessage() != "Error controlling app: boom" {
+		t.Errorf("Expected the last message to carry the outcome, got %v", last.GetBase())
+	}
+}
+
+func TestAppOutputStreamDropsLinesOfLaggingConsumer(t *testing.T) {
+	sender := &outputSender{gate: make(chan struct{})}
+	stream := newAppOutputStream(sender.Send, "msg-1", "agent", "app")
+
+	const written = 3 * appOutputBufferSize
+	for i := range written {
+		stream.Write(model.OutputLine{Channel: model.LogChannelStdout, Text: fmt.Sprintf("line %d", i)})
+	}
+	close(sender.gate)
+	stream.Write(model.OutputLine{Channel: model.LogChannelStdout, Text: "after catching up"})
+	stream.Close(pb.ResponseCode_RESPONSE_CODE_SUCCESS, "done")
+
+	marker := regexp.MustCompile(`^\[(\d+) lines of output dropped\]$`)
+	received, dropped, markers := 0, 0, 0
+	for _, msg := range sender.messages {
+		for _, line := range msg.GetLines() {
+			if m := marker.FindStringSubmatch(line.GetText()); m != nil {
+				count, _ := strconv.Atoi(m[1])
+				dropped += count
+				markers++
+				continue
+			}
+			received++
+		}
+	}
+	if markers == 0 {
+		t.Fatal("Expected a marker for the dropped lines")
+	}
+	if received+dropped != written+1 {
+		t.Errorf("Expected %d lines to be received or reported as dropped, got %d received and %d dropped", written+1, received, dropped)
+	}
+	if received > appOutputBufferSize+appOutputBatchSize+1 {
+		t.Errorf("Expected the buffered output to be capped, received %d lines", received)
+	}
+}
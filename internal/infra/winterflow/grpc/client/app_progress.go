@@ -0,0 +1,50 @@
+package client
+
+import (
+	"sync"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/log"
+)
+
+// appProgressReporter sends the stages an app action reaches to the server as AppProgressV1 messages
+// answering the request the action was started by.
+type appProgressReporter struct {
+	messageID string
+	agentID   string
+	appID     string
+	send      func(*pb.AgentMessage) error
+
+	mu     sync.Mutex
+	failed bool
+}
+
+// newAppProgressReporter returns a reporter sending the stages of the action with send.
+func newAppProgressReporter(send func(*pb.AgentMessage) error, messageID, agentID, appID string) *appProgressReporter {
+	return &appProgressReporter{messageID: messageID, agentID: agentID, appID: appID, send: send}
+}
+
+// ReportStage sends the stage. After a failed send further stages are discarded.
+func (p *appProgressReporter) ReportStage(stage model.DeployStage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failed {
+		return
+	}
+
+	baseResp := createBaseResponse(p.messageID, p.agentID, pb.ResponseCode_RESPONSE_CODE_SUCCESS, "App progress")
+	msg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_AppProgressV1{
+			AppProgressV1: &pb.AppProgressV1{
+				Base:  &baseResp,
+				AppId: p.appID,
+				Stage: DeployStageToProtoDeployStage(stage),
+			},
+		},
+	}
+	if err := p.send(msg); err != nil {
+		log.Warn("Failed to send app progress, discarding the remaining stages", "app_id", p.appID, "stage", stage, "error", err)
+		p.failed = true
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+func TestAppProgressReporterSendsStages(t *testing.T) {
+	var messages []*pb.AppProgressV1
+	reporter := newAppProgressReporter(func(msg *pb.AgentMessage) error {
+		messages = append(messages, msg.GetAppProgressV1())
+		return nil
+	}, "msg-1", "agent", "app")
+
+	reporter.ReportStage(model.DeployStageRendering)
+	reporter.ReportStage(model.DeployStageDone)
+
+	expected := []pb.DeployStage{pb.DeployStage_DEPLOY_STAGE_RENDERING, pb.DeployStage_DEPLOY_STAGE_DONE}
+	if len(messages) != len(expected) {
+		t.Fatalf("Expected %d progress messages, got %d", len(expected), len(messages))
+	}
+	for i, msg := range messages {
+		if msg.GetBase().GetMessageId() != "msg-1" || msg.GetAppId() != "app" {
+			t.Errorf("Message %d: expected to answer msg-1 for app, got %q for %q", i, msg.GetBase().GetMessageId(), msg.GetAppId())
+		}
+		if msg.GetStage() != expected[i] {
+			t.Errorf("Message %d: expected stage %v, got %v", i, expected[i], msg.GetStage())
+		}
+	}
+}
+
+func TestAppProgressReporterDiscardsStagesAfterAFailedSend(t *testing.T) {
+	sends := 0
+	reporter := newAppProgressReporter(func(*pb.AgentMessage) error {
+		sends++
+		return errors.New("stream closed")
+	}, "msg-1", "agent", "app")
+
+	reporter.ReportStage(model.DeployStageStarting)
+	reporter.ReportStage(model.DeployStageFailed)
+
+	if sends != 1 {
+		t.Errorf("Expected a single send attempt, got %d", sends)
+	}
+}
@@ -2,12 +2,15 @@ package client
 
 import (
 	"encoding/json"
+	"sort"
 	"time"
 	"winterflow-agent/internal/application/command/control_app"
 	"winterflow-agent/internal/application/command/create_registry"
 	"winterflow-agent/internal/application/command/delete_app"
 	"winterflow-agent/internal/application/command/delete_registry"
+	"winterflow-agent/internal/application/command/import_app"
 	"winterflow-agent/internal/application/command/rename_app"
+	"winterflow-agent/internal/domain/dto"
 	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/infra/winterflow/grpc/pb"
 	"winterflow-agent/pkg/log"
@@ -23,21 +26,20 @@ func AppToProtoAppV1(app *model.App) *pb.AppV1 {
 		return nil
 	}
 
-	// Convert variables
+	// Convert variables and files in the order of their IDs so that the same app always encodes to the same bytes
 	var variables []*pb.AppVarV1
-	for id, content := range app.Variables {
+	for _, id := range sortedKeys(app.Variables) {
 		variables = append(variables, &pb.AppVarV1{
 			Id:      id,
-			Content: []byte(content),
+			Content: []byte(app.Variables[id]),
 		})
 	}
 
-	// Convert files
 	var files []*pb.AppFileV1
-	for id, content := range app.Files {
+	for _, id := range sortedKeys(app.Files) {
 		files = append(files, &pb.AppFileV1{
 			Id:      id,
-			Content: []byte(content),
+			Content: app.Files[id],
 		})
 	}
 
@@ -49,13 +51,54 @@ func AppToProtoAppV1(app *model.App) *pb.AppV1 {
 	}
 
 	return &pb.AppV1{
-		AppId:     app.ID,
-		Config:    configBytes,
-		Variables: variables,
-		Files:     files,
+		AppId:           app.ID,
+		Config:          configBytes,
+		Variables:       variables,
+		Files:           files,
+		ComposeOverride: app.ComposeOverride,
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// AppDetailsToProtoAppDetailsV1 converts a domain app with its revisions to a protobuf app details model
+func AppDetailsToProtoAppDetailsV1(details *model.AppDetails) *pb.AppDetailsV1 {
+	if details == nil {
+		return nil
+	}
+	return &pb.AppDetailsV1{
+		App:                AppToProtoAppV1(details.App),
+		AppRevision:        details.Revision,
+		AvailableRevisions: details.Revisions,
 	}
 }
 
+// AppRevisionsToProtoAppRevisionsV1 converts domain app revisions to protobuf app revisions
+func AppRevisionsToProtoAppRevisionsV1(revisions []model.AppRevision) []*pb.AppRevisionV1 {
+	result := make([]*pb.AppRevisionV1, 0, len(revisions))
+	for _, r := range revisions {
+		var createdAt *timestamppb.Timestamp
+		if !r.CreatedAt.IsZero() {
+			createdAt = timestamppb.New(r.CreatedAt)
+		}
+		result = append(result, &pb.AppRevisionV1{
+			Revision:  r.Revision,
+			CreatedAt: createdAt,
+			Name:      r.Name,
+			Version:   r.Version,
+		})
+	}
+	return result
+}
+
 // ContainerAppsToProtoAppStatusesV1 converts domain container apps to protobuf app statuses
 func ContainerAppsToProtoAppStatusesV1(apps []*model.ContainerApp) []*pb.AppStatusV1 {
 	var appStatuses []*pb.AppStatusV1
@@ -69,6 +112,7 @@ func ContainerAppsToProtoAppStatusesV1(apps []*model.ContainerApp) []*pb.AppStat
 			AppId:      app.ID,
 			StatusCode: ContainerStatusCodeToProtoContainerStatusCode(app.StatusCode),
 			Containers: ContainersToProtoContainerStatusesV1(app.Containers),
+			Revision:   app.Revision,
 		}
 
 		appStatuses = append(appStatuses, appStatus)
@@ -147,10 +191,11 @@ func ProtoAppV1ToApp(app *pb.AppV1) *model.App {
 	}
 
 	return &model.App{
-		ID:        app.AppId,
-		Config:    appConfig,
-		Variables: variables,
-		Files:     files,
+		ID:              app.AppId,
+		Config:          appConfig,
+		Variables:       variables,
+		Files:           files,
+		ComposeOverride: app.ComposeOverride,
 	}
 }
 
@@ -166,6 +211,7 @@ func ProtoAppStatusesV1ToContainerApps(appStatuses []*pb.AppStatusV1) []*model.C
 		app := &model.ContainerApp{
 			ID:         appStatus.AppId,
 			Containers: ProtoContainerStatusesV1ToContainers(appStatus.Containers),
+			Revision:   appStatus.Revision,
 		}
 
 		apps = append(apps, app)
@@ -225,6 +271,7 @@ func ProtoDeleteAppRequestV1ToDeleteAppCommand(request *pb.DeleteAppRequestV1) d
 
 	return delete_app.DeleteAppCommand{
 		AppID: request.AppId,
+		Purge: request.Purge,
 	}
 }
 
@@ -247,13 +294,17 @@ func ProtoControlAppRequestV1ToControlAppCommand(request *pb.ControlAppRequestV1
 		action = control_app.AppActionUpdate
 	case pb.AppAction_REDEPLOY:
 		action = control_app.AppActionRedeploy
+	case pb.AppAction_RECREATE:
+		action = control_app.AppActionRecreate
 	default:
 		action = control_app.AppActionStop
 	}
 
 	return control_app.ControlAppCommand{
-		AppID:  request.AppId,
-		Action: action,
+		AppID:    request.AppId,
+		Action:   action,
+		Services: request.Services,
+		Force:    request.Force,
 	}
 }
 
@@ -268,6 +319,18 @@ func ProtoRenameAppRequestV1ToRenameAppCommand(request *pb.RenameAppRequestV1) r
 	}
 }
 
+// ProtoImportAppRequestV1ToImportAppCommand converts a protobuf ImportAppRequestV1 to a domain ImportAppCommand
+func ProtoImportAppRequestV1ToImportAppCommand(request *pb.ImportAppRequestV1) import_app.ImportAppCommand {
+	if request == nil {
+		return import_app.ImportAppCommand{}
+	}
+	return import_app.ImportAppCommand{
+		AppID:   request.AppId,
+		Archive: request.Archive,
+		Deploy:  request.Deploy,
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Registry helpers
 // ---------------------------------------------------------------------------
@@ -316,6 +379,123 @@ func NetworksToProtoNames(networks []model.Network) []string {
 	return names
 }
 
+// NetworksToProtoNetworksV1 converts a slice of domain networks to protobuf NetworkV1 messages.
+func NetworksToProtoNetworksV1(networks []model.Network) []*pb.NetworkV1 {
+	result := make([]*pb.NetworkV1, 0, len(networks))
+	for _, n := range networks {
+		result = append(result, &pb.NetworkV1{
+			Name:           n.Name,
+			Driver:         n.Driver,
+			Scope:          n.Scope,
+			Subnets:        n.Subnets,
+			ContainerCount: uint32(n.ContainerCount),
+		})
+	}
+	return result
+}
+
+// SystemInfoToProtoSystemInfoV1 converts the system info query result to a protobuf SystemInfoV1 message.
+func SystemInfoToProtoSystemInfoV1(info *dto.GetSystemInfoResult) *pb.SystemInfoV1 {
+	if info == nil {
+		return nil
+	}
+
+	return &pb.SystemInfoV1{
+		Os:                 info.OS,
+		KernelVersion:      info.KernelVersion,
+		DockerVersion:      info.DockerVersion,
+		DockerApiVersion:   info.DockerAPIVersion,
+		DiskTotalBytes:     info.DiskTotalBytes,
+		DiskAvailableBytes: info.DiskAvailableBytes,
+		MemoryTotalBytes:   info.MemoryTotalBytes,
+		CpuCount:           uint32(info.CPUCount),
+		AgentUptimeSeconds: uint64(info.AgentUptime.Seconds()),
+		Unavailable:        info.Unavailable,
+	}
+}
+
+// ConnectionStatsToProtoConnectionStatsV1 converts the connection statistics to a protobuf message.
+// Zero times are left unset.
+func ConnectionStatsToProtoConnectionStatsV1(stats *dto.GetConnectionStatsResult) *pb.ConnectionStatsV1 {
+	if stats == nil {
+		return nil
+	}
+
+	timestamp := func(t time.Time) *timestamppb.Timestamp {
+		if t.IsZero() {
+			return nil
+		}
+		return timestamppb.New(t)
+	}
+
+	disconnects := make([]*pb.ConnectionDisconnectV1, 0, len(stats.RecentDisconnects))
+	for _, d := range stats.RecentDisconnects {
+		disconnects = append(disconnects, &pb.ConnectionDisconnectV1{
+			At:            timestamp(d.At),
+			UptimeSeconds: uint64(d.UptimeSeconds),
+			Error:         d.Error,
+		})
+	}
+
+	return &pb.ConnectionStatsV1{
+		ConnectedSince:       timestamp(stats.ConnectedSince),
+		CurrentUptimeSeconds: uint64(stats.CurrentUptime.Seconds()),
+		TotalUptimeSeconds:   uint64(stats.TotalUptime.Seconds()),
+		DisconnectCount:      stats.DisconnectCount,
+		LastDisconnectAt:     timestamp(stats.LastDisconnectAt),
+		LastError:            stats.LastError,
+		LastErrorAt:          timestamp(stats.LastErrorAt),
+		RecentDisconnects:    disconnects,
+	}
+}
+
+// ContainerResourcesToProtoContainerResourcesV1 converts the resources of app containers to protobuf messages.
+func ContainerResourcesToProtoContainerResourcesV1(containers []model.ContainerResources) []*pb.ContainerResourcesV1 {
+	result := make([]*pb.ContainerResourcesV1, 0, len(containers))
+	for _, c := range containers {
+		result = append(result, &pb.ContainerResourcesV1{
+			ContainerId:      c.ID,
+			Name:             c.Name,
+			CpuLimit:         c.CPULimit,
+			MemoryLimitBytes: c.MemoryLimitBytes,
+			CpuPercent:       c.CPUPercent,
+			MemoryUsageBytes: c.MemoryUsageBytes,
+			OomKilled:        c.OOMKilled,
+			RestartCount:     uint32(c.RestartCount),
+		})
+	}
+	return result
+}
+
+// AppOperationMetricsToProtoAppOperationMetricsV1 converts the metrics of app operations to protobuf messages.
+func AppOperationMetricsToProtoAppOperationMetricsV1(operations []model.AppOperationMetrics) []*pb.AppOperationMetricsV1 {
+	result := make([]*pb.AppOperationMetricsV1, 0, len(operations))
+	for _, o := range operations {
+		buckets := make([]*pb.DurationBucketV1, 0, len(o.DurationBuckets))
+		for _, b := range o.DurationBuckets {
+			buckets = append(buckets, &pb.DurationBucketV1{UpperBoundSeconds: b.UpperBound.Seconds(), Count: b.Count})
+		}
+		result = append(result, &pb.AppOperationMetricsV1{
+			AppId:              o.AppID,
+			Operation:          string(o.Operation),
+			Successes:          o.Successes,
+			Failures:           o.Failures,
+			DurationBuckets:    buckets,
+			DurationSumSeconds: o.DurationSum.Seconds(),
+		})
+	}
+	return result
+}
+
+// MissingVariablesToProtoMissingVariablesV1 converts the missing required variables of an app to protobuf messages.
+func MissingVariablesToProtoMissingVariablesV1(variables []dto.MissingVariable) []*pb.MissingVariableV1 {
+	result := make([]*pb.MissingVariableV1, 0, len(variables))
+	for _, v := range variables {
+		result = append(result, &pb.MissingVariableV1{Id: v.ID, Name: v.Name})
+	}
+	return result
+}
+
 // LogsToProtoAppLogsV1 converts domain logs model to a protobuf AppLogsV1 message.
 func LogsToProtoAppLogsV1(l *model.Logs) *pb.AppLogsV1 {
 	if l == nil {
@@ -374,6 +554,40 @@ func LogChannelToProtoLogChannel(ch model.LogChannel) pb.LogChannel {
 	}
 }
 
+// OutputLinesToProtoAppOutputLinesV1 converts domain output lines to protobuf AppOutputLineV1 messages.
+func OutputLinesToProtoAppOutputLinesV1(lines []model.OutputLine) []*pb.AppOutputLineV1 {
+	result := make([]*pb.AppOutputLineV1, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, &pb.AppOutputLineV1{
+			Channel: LogChannelToProtoLogChannel(line.Channel),
+			Text:    line.Text,
+		})
+	}
+	return result
+}
+
+// DeployStageToProtoDeployStage converts a domain DeployStage to a protobuf DeployStage.
+func DeployStageToProtoDeployStage(stage model.DeployStage) pb.DeployStage {
+	switch stage {
+	case model.DeployStageRendering:
+		return pb.DeployStage_DEPLOY_STAGE_RENDERING
+	case model.DeployStagePulling:
+		return pb.DeployStage_DEPLOY_STAGE_PULLING
+	case model.DeployStageBuilding:
+		return pb.DeployStage_DEPLOY_STAGE_BUILDING
+	case model.DeployStageStarting:
+		return pb.DeployStage_DEPLOY_STAGE_STARTING
+	case model.DeployStageWaitingHealthy:
+		return pb.DeployStage_DEPLOY_STAGE_WAITING_HEALTHY
+	case model.DeployStageDone:
+		return pb.DeployStage_DEPLOY_STAGE_DONE
+	case model.DeployStageFailed:
+		return pb.DeployStage_DEPLOY_STAGE_FAILED
+	default:
+		return pb.DeployStage_DEPLOY_STAGE_UNKNOWN
+	}
+}
+
 // LogLevelToProtoLogLevel converts domain LogLevel to protobuf LogLevel.
 func LogLevelToProtoLogLevel(lvl model.LogLevel) pb.LogLevel {
 	switch lvl {
@@ -393,3 +607,37 @@ func LogLevelToProtoLogLevel(lvl model.LogLevel) pb.LogLevel {
 		return pb.LogLevel_LOG_LEVEL_UNKNOWN
 	}
 }
+
+// ContainerEventsToProtoDockerEventsV1 converts container events to their protobuf representation.
+func ContainerEventsToProtoDockerEventsV1(events []model.ContainerEvent) []*pb.DockerEventV1 {
+	result := make([]*pb.DockerEventV1, 0, len(events))
+	for _, e := range events {
+		result = append(result, &pb.DockerEventV1{
+			AppId:         e.AppID,
+			ContainerId:   e.ContainerID,
+			ContainerName: e.ContainerName,
+			ServiceName:   e.ServiceName,
+			Action:        ContainerEventActionToProtoDockerEventAction(e.Action),
+			Time:          timestamppb.New(e.Time),
+			ExitCode:      int32(e.ExitCode),
+			Count:         uint32(e.Count),
+		})
+	}
+	return result
+}
+
+// ContainerEventActionToProtoDockerEventAction converts a container event action to its protobuf representation.
+func ContainerEventActionToProtoDockerEventAction(action model.ContainerEventAction) pb.DockerEventAction {
+	switch action {
+	case model.ContainerEventStart:
+		return pb.DockerEventAction_DOCKER_EVENT_ACTION_START
+	case model.ContainerEventStop:
+		return pb.DockerEventAction_DOCKER_EVENT_ACTION_STOP
+	case model.ContainerEventDie:
+		return pb.DockerEventAction_DOCKER_EVENT_ACTION_DIE
+	case model.ContainerEventOOM:
+		return pb.DockerEventAction_DOCKER_EVENT_ACTION_OOM
+	default:
+		return pb.DockerEventAction_DOCKER_EVENT_ACTION_UNKNOWN
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"winterflow-agent/internal/domain/model"
+)
+
+func TestAppToProtoAppV1IsDeterministic(t *testing.T) {
+	app := &model.App{
+		ID:        "app-1",
+		Config:    &model.AppConfig{ID: "app-1", Name: "demo"},
+		Variables: model.VariableMap{},
+		Files:     model.FilesMap{},
+	}
+	for i := 0; i < 20; i++ {
+		app.Variables[fmt.Sprintf("var-%02d", i)] = fmt.Sprintf("value-%d", i)
+		app.Files[fmt.Sprintf("file-%02d", i)] = []byte(fmt.Sprintf("content-%d", i))
+	}
+
+	first, err := proto.Marshal(AppToProtoAppV1(app))
+	if err != nil {
+		t.Fatalf("Failed to marshal app: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := proto.Marshal(AppToProtoAppV1(app))
+		if err != nil {
+			t.Fatalf("Failed to marshal app: %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatal("Expected conversions of the same app to encode identically")
+		}
+	}
+
+	converted := AppToProtoAppV1(app)
+	for i, variable := range converted.Variables {
+		if expected := fmt.Sprintf("var-%02d", i); variable.Id != expected {
+			t.Errorf("Expected variable %d to be %s, got %s", i, expected, variable.Id)
+		}
+	}
+	for i, file := range converted.Files {
+		if expected := fmt.Sprintf("file-%02d", i); file.Id != expected {
+			t.Errorf("Expected file %d to be %s, got %s", i, expected, file.Id)
+		}
+	}
+}
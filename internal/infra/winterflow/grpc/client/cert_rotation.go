@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"winterflow-agent/pkg/files"
+	"winterflow-agent/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// certificateWatchers are the watchers of the client certificate and key of a Client.
+type certificateWatchers []*files.FileWatcher
+
+// stop stops every watcher.
+func (w certificateWatchers) stop() {
+	for _, watcher := range w {
+		watcher.Stop()
+	}
+}
+
+// watchCertificate watches the client certificate and key files, checking them every interval (the file
+// watcher default when zero), until ctx is done or the client is closed. Once a changed pair loads, the agent
+// stream is asked to cycle the connection through c.certRenewed.
+func (c *Client) watchCertificate(ctx context.Context, interval time.Duration) error {
+	for _, path := range []string{c.certPath, c.keyPath} {
+		watcher := files.NewFileWatcher(path, c.handleCertificateChange)
+		if interval > 0 {
+			watcher.SetInterval(interval)
+		}
+		if err := watcher.Start(ctx); err != nil {
+			c.certWatchers.stop()
+			return err
+		}
+		c.certWatchers = append(c.certWatchers, watcher)
+	}
+	return nil
+}
+
+// handleCertificateChange loads the client certificate and key after one of them changed on disk. A pair
+// that does not load yet, e.g. because only the certificate has been replaced so far, is skipped; the
+// change of the other file is handled once it is written.
+func (c *Client) handleCertificateChange(path string) {
+	pair, err := tls.LoadX509KeyPair(c.certPath, c.keyPath)
+	if err != nil {
+		log.Warn("Changed client certificate does not load, keeping the current one", "file_path", path, "error", err)
+		return
+	}
+	if leaf, err := x509.ParseCertificate(pair.Certificate[0]); err == nil {
+		log.Info("Client certificate renewed", "file_path", path, "serial", leaf.SerialNumber.String(), "not_after", leaf.NotAfter)
+	}
+
+	select {
+	case c.certRenewed <- struct{}{}:
+	default:
+		// A renewal is already pending and will load the latest files.
+	}
+}
+
+// cycleConnection connects with the client certificate and key on disk next to the current connection. It
+// returns the previous connection, which the caller closes once its stream ended. When the new connection
+// does not become ready within the connection timeout, it is closed and the current one is kept.
+func (c *Client) cycleConnection(ctx context.Context) (*grpc.ClientConn, error) {
+	c.reconnectMu.Lock()
+	defer c.reconnectMu.Unlock()
+
+	previous, previousClient := c.conn, c.client
+	restore := func() {
+		c.conn, c.client = previous, previousClient
+	}
+	if err := c.setupConnection(); err != nil {
+		restore()
+		return nil, err
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, c.connectionTimeout.get())
+	defer cancel()
+	c.conn.Connect()
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return previous, nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			c.conn.Close()
+			restore()
+			return nil, fmt.Errorf("connection with the renewed certificate failed: %v", state)
+		}
+		if !c.conn.WaitForStateChange(attemptCtx, state) {
+			c.conn.Close()
+			restore()
+			return nil, fmt.Errorf("connection with the renewed certificate not ready: %w", attemptCtx.Err())
+		}
+	}
+}
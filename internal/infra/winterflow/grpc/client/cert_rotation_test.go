@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeClientCertificate writes a self-signed certificate with serial and, unless keyPath is empty, its key.
+func writeClientCertificate(t *testing.T, certPath, keyPath string, serial int64) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "agent"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	// The watchers compare modification times, which may not advance between quick writes.
+	modTime := time.Now().Add(time.Duration(serial) * time.Second)
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.Chtimes(certPath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set certificate time: %v", err)
+	}
+	if keyPath == "" {
+		return
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	if err := os.Chtimes(keyPath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set key time: %v", err)
+	}
+}
+
+// newCertificateClient returns a client watching a freshly written certificate and key.
+func newCertificateClient(t *testing.T) *Client {
+	t.Helper()
+	dir := t.TempDir()
+	c := &Client{
+		certPath:    filepath.Join(dir, "agent.crt"),
+		keyPath:     filepath.Join(dir, "agent.key"),
+		certRenewed: make(chan struct{}, 1),
+	}
+	writeClientCertificate(t, c.certPath, c.keyPath, 1)
+	if err := c.watchCertificate(t.Context(), 10*time.Millisecond); err != nil {
+		t.Fatalf("Failed to watch certificate: %v", err)
+	}
+	t.Cleanup(c.certWatchers.stop)
+	return c
+}
+
+func TestSwappingTheCertificateRequestsACredentialReload(t *testing.T) {
+	c := newCertificateClient(t)
+
+	writeClientCertificate(t, c.certPath, c.keyPath, 2)
+
+	select {
+	case <-c.certRenewed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the renewed certificate to request a credential reload")
+	}
+}
+
+func TestCertificateWithoutMatchingKeyIsNotReloaded(t *testing.T) {
+	c := newCertificateClient(t)
+
+	// Only the certificate is replaced, so it does not match the key on disk.
+	writeClientCertificate(t, c.certPath, "", 2)
+
+	select {
+	case <-c.certRenewed:
+		t.Fatal("Expected a certificate that does not match the key to be skipped")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
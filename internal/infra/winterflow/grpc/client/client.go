@@ -2,24 +2,26 @@ package client
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"io"
-	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"winterflow-agent/internal/application/config"
 	"winterflow-agent/pkg/log"
 
+	"winterflow-agent/internal/infra/connection_stats"
 	"winterflow-agent/internal/infra/winterflow/grpc/pb"
 	"winterflow-agent/pkg/backoff"
 	"winterflow-agent/pkg/certs"
 	"winterflow-agent/pkg/cqrs"
 
 	"google.golang.org/grpc"
+	grpcbackoff "google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 )
 
@@ -35,7 +37,12 @@ type Client struct {
 
 	// Reconnection and timeouts
 	serverAddress     string
-	connectionTimeout time.Duration
+	connectionTimeout connectionTimeout
+
+	// Configured endpoints in failover order; serverAddress is
+	// serverAddresses[serverIndex].
+	serverAddresses []string
+	serverIndex     int
 
 	// Exponential back-off helper for reconnection attempts to keep the code
 	// DRY and easier to maintain.
@@ -47,6 +54,8 @@ type Client struct {
 	// Registration state
 	isRegistered bool
 	regMutex     sync.RWMutex
+	// Limits of the retries of RegisterAgent
+	registrationBudget registrationBudget
 
 	// Command and Query buses for CQRS
 	commandBus cqrs.CommandBus
@@ -61,6 +70,42 @@ type Client struct {
 
 	// Reconnect mutex
 	reconnectMu sync.Mutex
+
+	// Serializes sends on the agent stream, see syncStream
+	sendMu sync.Mutex
+
+	// Connection state transitions, shared across reconnects
+	connObserver connectionObserver
+
+	// Pauses app commands while set, see SetMaintenanceMode
+	maintenance atomic.Bool
+
+	// Round-trip times of heartbeats, shared across reconnects
+	heartbeatRTT heartbeatRTTTracker
+
+	// Running app commands and the maintenance mode to restore after a drain, see Drain
+	drainMu             sync.Mutex
+	appCommandsInFlight int
+	drainRestore        bool
+
+	// Set while an agent update runs, see startAgentUpdate
+	updating atomic.Bool
+
+	// Responses to recently processed app commands, replayed when the server delivers a command again
+	processedCommands processedCommands
+
+	// Pinned server key that mutating commands must be signed with; nil disables the verification
+	signingKey *ecdsa.PublicKey
+
+	// Persisted statistics of the stream to the server, shared across reconnects
+	connStats *connectionStatsRecorder
+
+	// Docker events subscription requested by the server on the current stream
+	dockerEvents dockerEventsSubscription
+
+	// Watchers of the certificate files and the renewals of the certificate the connection is cycled for
+	certWatchers certificateWatchers
+	certRenewed  chan struct{}
 }
 
 // setupConnection creates a new gRPC connection and client
@@ -81,23 +126,21 @@ func (c *Client) setupConnection() error {
 	}
 
 	log.Info("Setting up secure gRPC connection with TLS credentials")
-	host, _, err := net.SplitHostPort(c.serverAddress)
-	if err != nil {
-		host = c.serverAddress
-	}
-	creds, err := certs.LoadTLSCredentials(c.caCertPath, c.certPath, c.keyPath, host)
+	host := serverNameFromAddress(c.serverAddress)
+	creds, err := certs.LoadTLSCredentials(c.caCertPath, c.caPoolOptions(), c.certPath, c.keyPath, host)
 	if err != nil {
 		return log.Errorf("Failed to load TLS credentials: %v", err)
 	}
 	opts = append(opts, grpc.WithTransportCredentials(creds))
 
 	// --- keep-alive settings ---
-	kap := keepalive.ClientParameters{
-		Time:                10 * time.Second, // send pings every 10s if the connection is idle
-		Timeout:             3 * time.Second,  // wait 3s for ping ack
-		PermitWithoutStream: true,             // send pings even without active RPCs
-	}
-	opts = append(opts, grpc.WithKeepaliveParams(kap))
+	opts = append(opts, grpc.WithKeepaliveParams(keepaliveParams(c.config)))
+
+	// --- per-attempt connection timeout, grown after failed attempts ---
+	opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+		Backoff:           grpcbackoff.DefaultConfig,
+		MinConnectTimeout: c.connectionTimeout.get(),
+	}))
 
 	// --- unary retry policy via service config ---
 	const retryServiceConfig = `
@@ -132,24 +175,40 @@ func (c *Client) setupConnection() error {
 
 	c.conn = clientConn
 	c.client = pb.NewAgentServiceClient(clientConn)
+
+	// Follow the state of this connection until it is closed on reconnect or shutdown.
+	go watchConnectivity(context.Background(), clientConn, c.connObserver.observe)
 	return nil
 }
 
+// caPoolOptions returns the configured sources of trusted CA certificates besides the agent CA.
+func (c *Client) caPoolOptions() certs.CAPoolOptions {
+	return certs.CAPoolOptions{
+		Dir:            c.config.GetCACertificatesDir(),
+		UseSystemRoots: c.config.UseSystemCAs,
+	}
+}
+
 // NewClient creates a new gRPC client
 func NewClient(ctx context.Context, config *config.Config, commandBus cqrs.CommandBus, queryBus cqrs.QueryBus) (*Client, error) {
-	serverAddress := config.GetGRPCServerAddress()
+	serverAddresses := parseServerAddresses(config.GetGRPCServerAddress())
+	if len(serverAddresses) == 0 {
+		return nil, log.Errorf("gRPC server address is not configured")
+	}
+	serverAddress := serverAddresses[0]
 	caCertPath := config.GetCACertificatePath()
 	certPath := config.GetCertificatePath()
 	keyPath := config.GetPrivateKeyPath()
 
-	log.Info("Creating new gRPC client", "serverAddress", serverAddress)
+	log.Info("Creating new gRPC client", "serverAddress", serverAddress, "endpoints", len(serverAddresses))
 
 	// Always use TLS and fail if certificates don't exist
 	if caCertPath == "" || certPath == "" || keyPath == "" {
 		return nil, log.Errorf("TLS is required but certificate paths are not configured")
 	}
 
-	if !certs.CertificateExists(caCertPath) {
+	// The agent CA may only be omitted when other trusted CAs are configured.
+	if !certs.CertificateExists(caCertPath) && config.GetCACertificatesDir() == "" && !config.UseSystemCAs {
 		return nil, log.Errorf("TLS is required but CA certificate does not exist at path: %s", caCertPath)
 	}
 
@@ -163,21 +222,41 @@ func NewClient(ctx context.Context, config *config.Config, commandBus cqrs.Comma
 
 	log.Info("TLS enabled", "certificate", certPath)
 
+	signingKey, err := loadServerSigningKey(config)
+	if err != nil {
+		return nil, log.Errorf("failed to configure command signatures: %v", err)
+	}
+	if signingKey != nil {
+		log.Info("Command signature verification enabled", "key", config.GetServerSigningKeyPath())
+	}
+	if err := validateCommandPolicy(config); err != nil {
+		return nil, log.Errorf("invalid command policy: %v", err)
+	}
+
 	client := &Client{
-		serverAddress:     serverAddress,
-		connectionTimeout: DefaultConnectionTimeout,
-		streamCleanup:     make(chan struct{}),
-		isRegistered:      false,
-		regMutex:          sync.RWMutex{},
-		backoffStrategy:   backoff.New(DefaultReconnectInterval, DefaultMaximumReconnectInterval),
-		commandBus:        commandBus,
-		queryBus:          queryBus,
-		caCertPath:        caCertPath,
-		certPath:          certPath,
-		keyPath:           keyPath,
-		config:            config,
+		serverAddress:   serverAddress,
+		serverAddresses: serverAddresses,
+		streamCleanup:   make(chan struct{}),
+		certRenewed:     make(chan struct{}, 1),
+		isRegistered:    false,
+		regMutex:        sync.RWMutex{},
+		backoffStrategy: backoff.New(DefaultReconnectInterval, DefaultMaximumReconnectInterval),
+		commandBus:      commandBus,
+		queryBus:        queryBus,
+		caCertPath:      caCertPath,
+		certPath:        certPath,
+		keyPath:         keyPath,
+		config:          config,
+		signingKey:      signingKey,
+		connStats:       newConnectionStatsRecorder(connection_stats.NewConnectionStatsRepository(config.GetConnectionStatsPath()), time.Now),
 	}
 
+	client.maintenance.Store(config.MaintenanceMode)
+	client.connectionTimeout.setBounds(config.GetConnectionTimeoutMin(), config.GetConnectionTimeoutMax())
+	client.registrationBudget = registrationBudget{maxAttempts: config.GetRegistrationMaxAttempts(), maxDuration: config.GetRegistrationMaxDuration()}
+
+	// Registered before the first connection is watched so that no transition goes unlogged.
+	client.OnConnectionStateChange(logConnectionState)
 	if err := client.setupConnection(); err != nil {
 		return nil, err
 	}
@@ -188,6 +267,11 @@ func NewClient(ctx context.Context, config *config.Config, commandBus cqrs.Comma
 		return nil, log.Errorf("failed to establish initial connection: %v", err)
 	}
 
+	// Renewed certificates are picked up without waiting for the connection to drop.
+	if err := client.watchCertificate(ctx, 0); err != nil {
+		log.Warn("Failed to watch the client certificate, renewals apply on the next reconnect", "error", err)
+	}
+
 	return client, nil
 }
 
@@ -196,9 +280,10 @@ func (c *Client) SetReconnectParameters(initialInterval, maxInterval time.Durati
 	c.backoffStrategy = backoff.New(initialInterval, maxInterval)
 }
 
-// SetConnectionTimeout sets the connection timeout
-func (c *Client) SetConnectionTimeout(timeout time.Duration) {
-	c.connectionTimeout = timeout
+// SetConnectionTimeout sets the initial per-attempt connection timeout and the maximum it grows to
+// after consecutive failed attempts
+func (c *Client) SetConnectionTimeout(minTimeout, maxTimeout time.Duration) {
+	c.connectionTimeout.setBounds(minTimeout, maxTimeout)
 }
 
 // Close closes the client connection and gracefully shuts down the command and query buses
@@ -211,6 +296,8 @@ func (c *Client) Close() error {
 	c.commandBus.WaitForCompletion()
 	c.queryBus.WaitForCompletion()
 
+	c.certWatchers.stop()
+
 	// Close the gRPC connection
 	return c.conn.Close()
 }
@@ -250,14 +337,31 @@ func (c *Client) waitForConnectionReady(ctx context.Context) error {
 		switch state {
 		case connectivity.Ready:
 			log.Info("Connection is ready", "attempts", attemptCount, "totalTime", time.Since(startTime))
+			c.connectionTimeout.succeeded()
 			return nil
 
 		case connectivity.Shutdown:
 			return log.Errorf("connection is shutdown after %d attempts (total time: %v)", attemptCount, time.Since(startTime))
 
 		case connectivity.TransientFailure:
+			log.Warn("Connection attempt failed with TransientFailure", "server_address", c.serverAddress, "attempt", attemptCount)
+			c.connectionTimeout.failed()
+
+			// With several endpoints configured, fail over to the next one. Backoff is
+			// only applied once every endpoint has been tried.
+			if len(c.serverAddresses) > 1 {
+				if err := c.switchServerAddress(); err != nil {
+					return err
+				}
+				if c.serverIndex != 0 {
+					attemptCount++
+					log.Debug("Initiating connection attempt on next endpoint", "server_address", c.serverAddress, "attempt", attemptCount)
+					c.conn.Connect()
+					continue
+				}
+			}
+
 			// The previous dial attempt resulted in a transient failure, apply backoff before retrying.
-			log.Warn("Connection attempt failed with TransientFailure", "attempt", attemptCount, "action", "retrying with exponential backoff")
 
 			nextInterval := c.getNextReconnectInterval()
 			log.Info("Waiting before next connection attempt", "waitTime", nextInterval, "nextAttempt", attemptCount+1)
@@ -282,9 +386,27 @@ func (c *Client) waitForConnectionReady(ctx context.Context) error {
 			// gRPC will transition either to READY, TRANSIENT_FAILURE or SHUTDOWN.
 			log.Debug("Waiting for state change", "currentState", state)
 
-			if !c.conn.WaitForStateChange(ctx, state) {
-				// Context was cancelled while waiting.
-				return log.Errorf("connection cancelled while waiting for state change: %v", ctx.Err())
+			timeout := c.connectionTimeout.get()
+			attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+			changed := c.conn.WaitForStateChange(attemptCtx, state)
+			cancel()
+			if !changed {
+				if ctx.Err() != nil {
+					// Context was cancelled while waiting.
+					return log.Errorf("connection cancelled while waiting for state change: %v", ctx.Err())
+				}
+
+				// The attempt did not complete in time. Abandon it and dial again with a longer
+				// timeout; the connection is recreated so the new timeout applies to the handshake.
+				nextTimeout := c.connectionTimeout.failed()
+				log.Warn("Connection attempt timed out", "server_address", c.serverAddress, "attempt", attemptCount, "timeout", timeout, "nextTimeout", nextTimeout)
+				c.conn.Close()
+				if err := c.setupConnection(); err != nil {
+					return err
+				}
+				attemptCount++
+				log.Debug("Initiating new connection attempt", "attempt", attemptCount)
+				c.conn.Connect()
 			}
 			// State changed, loop and evaluate again.
 			continue
@@ -346,10 +468,29 @@ func (c *Client) SetRegistered(registered bool) {
 	c.isRegistered = registered
 }
 
-// RegisterAgent registers the agent with the server
+// RegisterAgent registers the agent with the server. Failed attempts are retried until the registration
+// budget of the client is used up, which fails with ErrRegistrationBudgetExhausted.
 func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]string, features map[string]bool, agentID string) (*pb.RegisterAgentResponseV1, error) {
 	log.Info("Starting agent registration process")
 
+	maxDuration := c.registrationBudget.maxDuration
+	if maxDuration <= 0 {
+		return c.registerAgent(ctx, capabilities, features, agentID)
+	}
+	budgetCtx, cancel := context.WithTimeout(ctx, maxDuration)
+	defer cancel()
+	resp, err := c.registerAgent(budgetCtx, capabilities, features, agentID)
+	if err != nil && ctx.Err() == nil && budgetCtx.Err() != nil {
+		log.Error("Giving up registration", "max_duration", maxDuration, "error", err)
+		return nil, fmt.Errorf("%w: not registered within %s", ErrRegistrationBudgetExhausted, maxDuration)
+	}
+	return resp, err
+}
+
+// registerAgent sends the registration request until it succeeds, is rejected, ctx is done or the attempts of
+// the registration budget are used up.
+func (c *Client) registerAgent(ctx context.Context, capabilities map[string]string, features map[string]bool, agentID string) (*pb.RegisterAgentResponseV1, error) {
+
 	// Create a unique message ID
 	messageID := GenerateUUID()
 
@@ -363,7 +504,8 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 		Features:     features,
 	}
 
-	for {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
 		// Check for context cancellation first
 		select {
 		case <-ctx.Done():
@@ -372,11 +514,17 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 			// Continue with normal operation
 		}
 
+		if err := c.registrationBudget.checkAttempts(attempt, lastErr); err != nil {
+			log.Error("Giving up registration", "attempts", attempt, "error", lastErr)
+			return nil, err
+		}
+
 		// Ensure connection is ready before making the request
 		if err := c.waitForReady(ctx); err != nil {
 			log.Warn("Connection not ready before registration", "error", err)
 			if err := c.reconnect(ctx); err != nil {
 				log.Warn("Failed to reconnect, will retry", "error", err)
+				lastErr = err
 
 				// Use a timer so we can interrupt the wait
 				timer := time.NewTimer(c.getNextReconnectInterval())
@@ -396,6 +544,10 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 		log.Info("Sending RegisterAgentV1 request")
 		resp, err := c.client.RegisterAgentV1(ctx, req)
 		if err != nil {
+			if rejection := registrationRejection(err); rejection != nil {
+				log.Error("Registration rejected by the server", "code", rejection.Code.String(), "reason", rejection.Reason, "action", rejection.Action)
+				return nil, rejection
+			}
 			grpcCode := status.Code(err)
 			switch grpcCode {
 			case codes.FailedPrecondition:
@@ -404,6 +556,7 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 				return nil, ErrUnrecoverableAgentAlreadyConnected
 			case codes.Unavailable:
 				log.Warn("Connection unavailable during registration", "action", "attempting to reconnect")
+				lastErr = err
 				if err := c.reconnect(ctx); err != nil {
 					log.Warn("Failed to reconnect, will retry", "error", err)
 					timer := time.NewTimer(c.getNextReconnectInterval())
@@ -417,6 +570,7 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 				continue
 			default:
 				log.Warn("Error during registration", "error", err, "action", "will retry")
+				lastErr = err
 				timer := time.NewTimer(c.getNextReconnectInterval())
 				select {
 				case <-timer.C:
@@ -435,6 +589,7 @@ func (c *Client) RegisterAgent(ctx context.Context, capabilities map[string]stri
 				return nil, ErrUnrecoverableAgentAlreadyConnected
 			default:
 				log.Warn("Registration failed", "responseCode", resp.Base.ResponseCode, "action", "retrying")
+				lastErr = fmt.Errorf("registration failed with response code %s", resp.Base.ResponseCode)
 				timer := time.NewTimer(c.getNextReconnectInterval())
 				select {
 				case <-timer.C:
@@ -462,8 +617,12 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 	// Start goroutine to maintain the heartbeat stream
 	go func() {
 		log.Debug("Agent stream goroutine started")
+		defer c.connStats.disconnected()
 	outerLoop:
 		for {
+			// A previous stream, if any, has ended.
+			c.connStats.disconnected()
+
 			// Check if we should stop
 			select {
 			case <-c.streamCleanup:
@@ -501,7 +660,7 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 			}
 
 			log.Debug("Creating Agent stream")
-			stream, err := c.client.AgentStream(ctx)
+			rawStream, err := c.client.AgentStream(ctx)
 			if err != nil {
 				log.Error("Failed to create Agent stream", "error", err)
 				if err := c.reconnect(ctx); err != nil {
@@ -524,25 +683,11 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 			}
 
 			log.Info("Agent stream established successfully")
+			// Every goroutine below sends through this one wrapper.
+			stream := c.syncStream(rawStream)
 
 			// Send initial heartbeat
-			baseMsg := &pb.BaseMessage{
-				MessageId: GenerateUUID(),
-				Timestamp: TimestampNow(),
-				AgentId:   agentID,
-			}
-
-			heartbeat := &pb.AgentHeartbeatV1{
-				Base: baseMsg,
-			}
-
-			agentMsg := &pb.AgentMessage{
-				Message: &pb.AgentMessage_HeartbeatV1{
-					HeartbeatV1: heartbeat,
-				},
-			}
-
-			if err := stream.Send(agentMsg); err != nil {
+			if err := c.sendHeartbeat(stream, agentID); err != nil {
 				log.Error("Failed to send initial heartbeat", "error", err)
 				if status.Code(err) == codes.Unavailable || err == io.EOF {
 					log.Warn("Connection unavailable or stream closed, recreating stream")
@@ -568,12 +713,15 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 			}
 
 			log.Debug("Initial heartbeat sent successfully")
+			c.connStats.connected()
 
 			// Create channels for stream management
 			streamDone := make(chan struct{})
 			reregisterCh := make(chan struct{})
 			fatalErrorCh := make(chan error)
 			appRequestCh := make(chan *pb.GetAppRequestV1, queueChannelSize)
+			getAppsRequestCh := make(chan *pb.GetAppsRequestV1, queueChannelSize)
+			validateAppRequestCh := make(chan *pb.ValidateAppRequestV1, queueChannelSize)
 			saveAppRequestCh := make(chan *pb.SaveAppRequestV1, queueChannelSize)
 			deleteAppRequestCh := make(chan *pb.DeleteAppRequestV1, queueChannelSize)
 			controlAppRequestCh := make(chan *pb.ControlAppRequestV1, queueChannelSize)
@@ -589,6 +737,31 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 
 			// Logs operations
 			getAppLogsRequestCh := make(chan *pb.GetAppLogsRequestV1, queueChannelSize)
+			getAppResourcesRequestCh := make(chan *pb.GetAppResourcesRequestV1, queueChannelSize)
+
+			// Revisions operations
+			getAppRevisionsRequestCh := make(chan *pb.GetAppRevisionsRequestV1, queueChannelSize)
+			getRenderedComposeRequestCh := make(chan *pb.GetRenderedComposeRequestV1, queueChannelSize)
+			exportAppRequestCh := make(chan *pb.ExportAppRequestV1, queueChannelSize)
+			importAppRequestCh := make(chan *pb.ImportAppRequestV1, queueChannelSize)
+			reconcileAppRequestCh := make(chan *pb.ReconcileAppRequestV1, queueChannelSize)
+			startAppsRequestCh := make(chan *pb.StartAppsRequestV1, queueChannelSize)
+			backupVolumesRequestCh := make(chan *pb.BackupVolumesRequestV1, queueChannelSize)
+
+			// Diagnostics operations
+			getSystemInfoRequestCh := make(chan *pb.GetSystemInfoRequestV1, queueChannelSize)
+			getConnectionStatsRequestCh := make(chan *pb.GetConnectionStatsRequestV1, queueChannelSize)
+			getAgentConfigRequestCh := make(chan *pb.GetAgentConfigRequestV1, queueChannelSize)
+			getVersionInfoRequestCh := make(chan *pb.GetVersionInfoRequestV1, queueChannelSize)
+			checkForUpdateRequestCh := make(chan *pb.CheckForUpdateRequestV1, queueChannelSize)
+			getAppMetricsRequestCh := make(chan *pb.GetAppMetricsRequestV1, queueChannelSize)
+			collectDiagnosticsRequestCh := make(chan *pb.CollectDiagnosticsRequestV1, queueChannelSize)
+
+			// Events of the Docker events subscription, sent by the main loop
+			dockerEventsCh := make(chan *pb.AgentMessage, queueChannelSize)
+
+			// Responses to agent updates running in the background, sent by the main loop
+			updateAgentResponseCh := make(chan *pb.AgentMessage, queueChannelSize)
 
 			// Start goroutine to receive responses
 			go func() {
@@ -603,6 +776,7 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 						}
 						if status.Code(err) == codes.Unavailable || err == io.EOF {
 							log.Error("Connection unavailable or stream closed", "error", err)
+							c.connStats.failed(err)
 							log.Warn("Stream receiver stopping, will recreate stream")
 							return
 						}
@@ -624,10 +798,48 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 						continue
 					}
 
+					// Mutating commands must carry a valid server signature when command signatures are enabled.
+					if agentMsg := c.signatureResponse(serverCmd, agentID); agentMsg != nil {
+						if err := stream.Send(agentMsg); err != nil {
+							log.Warn("Error sending unauthorized response", "error", err)
+						}
+						continue
+					}
+
+					// Requests the configuration does not allow are rejected before they are dispatched.
+					if agentMsg := c.forbiddenResponse(serverCmd.Command, agentID); agentMsg != nil {
+						log.Info("Rejecting request not allowed by the configuration", "type", fmt.Sprintf("%T", serverCmd.Command))
+						if err := stream.Send(agentMsg); err != nil {
+							log.Warn("Error sending forbidden response", "error", err)
+						}
+						continue
+					}
+
+					// App commands are answered right away while the agent is in maintenance mode.
+					if agentMsg := c.maintenanceResponse(serverCmd.Command, agentID); agentMsg != nil {
+						log.Info("Rejecting app command in maintenance mode", "type", fmt.Sprintf("%T", serverCmd.Command))
+						if err := stream.Send(agentMsg); err != nil {
+							log.Warn("Error sending maintenance response", "error", err)
+						}
+						continue
+					}
+
+					// Mutating commands are rejected until safe mode is cleared, so that the state can be inspected first.
+					if agentMsg := c.safeModeResponse(serverCmd.Command, agentID); agentMsg != nil {
+						log.Info("Rejecting mutating command in safe mode", "type", fmt.Sprintf("%T", serverCmd.Command))
+						if err := stream.Send(agentMsg); err != nil {
+							log.Warn("Error sending safe mode response", "error", err)
+						}
+						continue
+					}
+
 					// Handle different command types
 					switch cmd := serverCmd.Command.(type) {
 					case *pb.ServerCommand_HeartbeatResponseV1:
 						response := cmd.HeartbeatResponseV1.Base
+						if rtt, ok := c.heartbeatRTT.received(response.GetMessageId()); ok {
+							log.Debug("Heartbeat round-trip time", "rtt", rtt)
+						}
 
 						// Handle response codes
 						switch response.ResponseCode {
@@ -684,10 +896,10 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 
 					case *pb.ServerCommand_UpdateAgentRequestV1:
 						log.Info("Received update agent request", "messageId", cmd.UpdateAgentRequestV1.Base.MessageId)
-						// Handle the update agent request directly since it will exit the process
-						agentMsg, err := HandleUpdateAgentRequest(c.commandBus, cmd.UpdateAgentRequestV1, agentID)
-						if err != nil {
-							log.Error("Error handling update agent request", "error", err)
+						// Handled in the background since it drains in-flight app commands and exits the process;
+						// the response is sent by the main loop.
+						agentMsg := c.startAgentUpdate(cmd.UpdateAgentRequestV1, agentID, streamDone, updateAgentResponseCh)
+						if agentMsg == nil {
 							continue
 						}
 
@@ -699,7 +911,56 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 							}
 							continue
 						}
-						log.Info("Update agent response sent successfully")
+						log.Info("Rejected update agent request while an update is running")
+
+					case *pb.ServerCommand_SetMaintenanceModeRequestV1:
+						log.Info("Received set maintenance mode request", "messageId", cmd.SetMaintenanceModeRequestV1.Base.MessageId, "enabled", cmd.SetMaintenanceModeRequestV1.Enabled)
+						agentMsg := HandleSetMaintenanceModeRequest(c, cmd.SetMaintenanceModeRequestV1, agentID)
+						if err := stream.Send(agentMsg); err != nil {
+							log.Error("Error sending set maintenance mode response", "error", err)
+							if status.Code(err) == codes.Unavailable || err == io.EOF {
+								log.Warn("Connection unavailable or stream closed, recreating stream")
+								return
+							}
+							continue
+						}
+						log.Info("Set maintenance mode response sent successfully")
+
+					case *pb.ServerCommand_ClearSafeModeRequestV1:
+						log.Info("Received clear safe mode request", "messageId", cmd.ClearSafeModeRequestV1.Base.MessageId)
+						agentMsg := HandleClearSafeModeRequest(c, cmd.ClearSafeModeRequestV1, agentID)
+						if err := stream.Send(agentMsg); err != nil {
+							log.Error("Error sending clear safe mode response", "error", err)
+							if status.Code(err) == codes.Unavailable || err == io.EOF {
+								log.Warn("Connection unavailable or stream closed, recreating stream")
+								return
+							}
+							continue
+						}
+						log.Info("Clear safe mode response sent successfully")
+
+					case *pb.ServerCommand_CancelOperationRequestV1:
+						// Handled right away: the main loop is busy with the operation that is to be canceled.
+						log.Info("Received cancel operation request", "messageId", cmd.CancelOperationRequestV1.Base.MessageId, "app_id", cmd.CancelOperationRequestV1.AppId)
+						agentMsg, err := HandleCancelOperationRequest(c.commandBus, cmd.CancelOperationRequestV1, agentID)
+						if err != nil {
+							log.Error("Error canceling operation response", "error", err)
+							continue
+						}
+						if err := stream.Send(agentMsg); err != nil {
+							log.Error("Error sending cancel operation response", "error", err)
+							if status.Code(err) == codes.Unavailable || err == io.EOF {
+								log.Warn("Connection unavailable or stream closed, recreating stream")
+								return
+							}
+							continue
+						}
+						log.Info("Cancel operation response sent successfully")
+
+					case *pb.ServerCommand_StreamDockerEventsRequestV1:
+						// Handled right away: the subscription runs until it is stopped or the stream ends.
+						log.Info("Received stream docker events request", "messageId", cmd.StreamDockerEventsRequestV1.Base.MessageId, "stop", cmd.StreamDockerEventsRequestV1.Stop)
+						HandleStreamDockerEventsRequest(ctx, c.queryBus, &c.dockerEvents, cmd.StreamDockerEventsRequestV1, agentID, streamDone, dockerEventsCh)
 
 					case *pb.ServerCommand_GetAppRequestV1:
 						log.Info("Received app request", "messageId", cmd.GetAppRequestV1.Base.MessageId)
@@ -985,76 +1246,334 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 							}
 						}
 
-					default:
-						// Log details about the unknown command type
-						log.Warn("Received unknown command type", "type", fmt.Sprintf("%T", cmd))
-					}
-				}
-			}()
+					case *pb.ServerCommand_GetAppRevisionsRequestV1:
+						log.Info("Received get app revisions request", "messageId", cmd.GetAppRevisionsRequestV1.Base.MessageId)
+						select {
+						case getAppRevisionsRequestCh <- cmd.GetAppRevisionsRequestV1:
+						default:
+							log.Warn("Get app revisions request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetAppRevisionsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetAppRevisionsResponseV1{Base: &baseResp, AppId: cmd.GetAppRevisionsRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAppRevisionsResponseV1{GetAppRevisionsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-			// Start periodic heartbeat sender
-			ticker := time.NewTicker(HeartbeatInterval)
+					case *pb.ServerCommand_GetAppsRequestV1:
+						log.Info("Received get apps request", "messageId", cmd.GetAppsRequestV1.Base.MessageId)
+						select {
+						case getAppsRequestCh <- cmd.GetAppsRequestV1:
+						default:
+							log.Warn("Get apps request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetAppsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetAppsResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAppsResponseV1{GetAppsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-			// Start periodic metrics sender
-			metricsTicker := time.NewTicker(MetricsInterval)
+					case *pb.ServerCommand_ValidateAppRequestV1:
+						log.Info("Received validate app request", "messageId", cmd.ValidateAppRequestV1.Base.MessageId)
+						select {
+						case validateAppRequestCh <- cmd.ValidateAppRequestV1:
+						default:
+							log.Warn("Validate app request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.ValidateAppRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.ValidateAppResponseV1{Base: &baseResp, AppId: cmd.ValidateAppRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_ValidateAppResponseV1{ValidateAppResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-			for {
-				select {
-				case <-ticker.C:
-					if !c.IsRegistered() {
-						log.Warn("Agent is not registered, stopping heartbeat sender")
-						return
-					}
+					case *pb.ServerCommand_GetAppResourcesRequestV1:
+						log.Info("Received get app resources request", "messageId", cmd.GetAppResourcesRequestV1.Base.MessageId)
+						select {
+						case getAppResourcesRequestCh <- cmd.GetAppResourcesRequestV1:
+						default:
+							log.Warn("Get app resources request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetAppResourcesRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetAppResourcesResponseV1{Base: &baseResp, AppId: cmd.GetAppResourcesRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAppResourcesResponseV1{GetAppResourcesResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					baseMsg := &pb.BaseMessage{
-						MessageId: GenerateUUID(),
-						Timestamp: TimestampNow(),
-						AgentId:   agentID,
-					}
+					case *pb.ServerCommand_GetRenderedComposeRequestV1:
+						log.Info("Received get rendered compose request", "messageId", cmd.GetRenderedComposeRequestV1.Base.MessageId)
+						select {
+						case getRenderedComposeRequestCh <- cmd.GetRenderedComposeRequestV1:
+						default:
+							log.Warn("Get rendered compose request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetRenderedComposeRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetRenderedComposeResponseV1{Base: &baseResp, AppId: cmd.GetRenderedComposeRequestV1.AppId, AppRevision: cmd.GetRenderedComposeRequestV1.AppRevision}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetRenderedComposeResponseV1{GetRenderedComposeResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					heartbeat := &pb.AgentHeartbeatV1{
-						Base: baseMsg,
-					}
+					case *pb.ServerCommand_ExportAppRequestV1:
+						log.Info("Received export app request", "messageId", cmd.ExportAppRequestV1.Base.MessageId)
+						select {
+						case exportAppRequestCh <- cmd.ExportAppRequestV1:
+						default:
+							log.Warn("Export app request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.ExportAppRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.ExportAppResponseV1{Base: &baseResp, AppId: cmd.ExportAppRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_ExportAppResponseV1{ExportAppResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					agentMsg := &pb.AgentMessage{
-						Message: &pb.AgentMessage_HeartbeatV1{
-							HeartbeatV1: heartbeat,
-						},
-					}
+					case *pb.ServerCommand_ImportAppRequestV1:
+						log.Info("Received import app request", "messageId", cmd.ImportAppRequestV1.Base.MessageId)
+						select {
+						case importAppRequestCh <- cmd.ImportAppRequestV1:
+						default:
+							log.Warn("Import app request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.ImportAppRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.ImportAppResponseV1{Base: &baseResp, AppId: cmd.ImportAppRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_ImportAppResponseV1{ImportAppResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					if err := stream.Send(agentMsg); err != nil {
-						log.Error("Error sending heartbeat", "error", err)
-						if status.Code(err) == codes.Unavailable || err == io.EOF {
-							log.Warn("Connection unavailable or stream closed, recreating stream")
-							ticker.Stop()
-							metricsTicker.Stop()
-							continue outerLoop
+					case *pb.ServerCommand_ReconcileAppRequestV1:
+						log.Info("Received reconcile app request", "messageId", cmd.ReconcileAppRequestV1.Base.MessageId, "app_id", cmd.ReconcileAppRequestV1.AppId)
+						select {
+						case reconcileAppRequestCh <- cmd.ReconcileAppRequestV1:
+						default:
+							log.Warn("Reconcile app request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.ReconcileAppRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.ReconcileAppResponseV1{Base: &baseResp, AppId: cmd.ReconcileAppRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_ReconcileAppResponseV1{ReconcileAppResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
 						}
-						continue
-					}
-					log.Debug("Periodic heartbeat sent successfully")
 
-				case <-metricsTicker.C:
-					if !c.IsRegistered() {
-						log.Warn("Agent is not registered, stopping metrics sender")
-						return
-					}
+					case *pb.ServerCommand_StartAppsRequestV1:
+						log.Info("Received start apps request", "messageId", cmd.StartAppsRequestV1.Base.MessageId, "app_ids", cmd.StartAppsRequestV1.AppIds)
+						select {
+						case startAppsRequestCh <- cmd.StartAppsRequestV1:
+						default:
+							log.Warn("Start apps request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.StartAppsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.StartAppsResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_StartAppsResponseV1{StartAppsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					baseMsg := &pb.BaseMessage{
-						MessageId: GenerateUUID(),
-						Timestamp: TimestampNow(),
-						AgentId:   agentID,
-					}
+					case *pb.ServerCommand_BackupVolumesRequestV1:
+						log.Info("Received backup volumes request", "messageId", cmd.BackupVolumesRequestV1.Base.MessageId, "app_id", cmd.BackupVolumesRequestV1.AppId)
+						select {
+						case backupVolumesRequestCh <- cmd.BackupVolumesRequestV1:
+						default:
+							log.Warn("Backup volumes request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.BackupVolumesRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.BackupVolumesResponseV1{Base: &baseResp, AppId: cmd.BackupVolumesRequestV1.AppId}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_BackupVolumesResponseV1{BackupVolumesResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					metrics := &pb.AgentMetricsV1{
-						Base: baseMsg,
-					}
+					case *pb.ServerCommand_GetSystemInfoRequestV1:
+						log.Info("Received get system info request", "messageId", cmd.GetSystemInfoRequestV1.Base.MessageId)
+						select {
+						case getSystemInfoRequestCh <- cmd.GetSystemInfoRequestV1:
+						default:
+							log.Warn("Get system info request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetSystemInfoRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetSystemInfoResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetSystemInfoResponseV1{GetSystemInfoResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
 
-					agentMsg := &pb.AgentMessage{
-						Message: &pb.AgentMessage_MetricsV1{
-							MetricsV1: metrics,
-						},
-					}
+					case *pb.ServerCommand_GetConnectionStatsRequestV1:
+						log.Info("Received get connection stats request", "messageId", cmd.GetConnectionStatsRequestV1.Base.MessageId)
+						select {
+						case getConnectionStatsRequestCh <- cmd.GetConnectionStatsRequestV1:
+						default:
+							log.Warn("Get connection stats request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetConnectionStatsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetConnectionStatsResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetConnectionStatsResponseV1{GetConnectionStatsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					case *pb.ServerCommand_GetAgentConfigRequestV1:
+						log.Info("Received get agent config request", "messageId", cmd.GetAgentConfigRequestV1.Base.MessageId)
+						select {
+						case getAgentConfigRequestCh <- cmd.GetAgentConfigRequestV1:
+						default:
+							log.Warn("Get agent config request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetAgentConfigRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetAgentConfigResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAgentConfigResponseV1{GetAgentConfigResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					case *pb.ServerCommand_GetVersionInfoRequestV1:
+						log.Info("Received get version info request", "messageId", cmd.GetVersionInfoRequestV1.Base.MessageId)
+						select {
+						case getVersionInfoRequestCh <- cmd.GetVersionInfoRequestV1:
+						default:
+							log.Warn("Get version info request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetVersionInfoRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetVersionInfoResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetVersionInfoResponseV1{GetVersionInfoResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					case *pb.ServerCommand_CheckForUpdateRequestV1:
+						log.Info("Received check for update request", "messageId", cmd.CheckForUpdateRequestV1.Base.MessageId)
+						select {
+						case checkForUpdateRequestCh <- cmd.CheckForUpdateRequestV1:
+						default:
+							log.Warn("Check for update request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.CheckForUpdateRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.CheckForUpdateResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_CheckForUpdateResponseV1{CheckForUpdateResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					case *pb.ServerCommand_GetAppMetricsRequestV1:
+						log.Info("Received get app metrics request", "messageId", cmd.GetAppMetricsRequestV1.Base.MessageId, "app_id", cmd.GetAppMetricsRequestV1.AppId)
+						select {
+						case getAppMetricsRequestCh <- cmd.GetAppMetricsRequestV1:
+						default:
+							log.Warn("Get app metrics request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.GetAppMetricsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.GetAppMetricsResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAppMetricsResponseV1{GetAppMetricsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					case *pb.ServerCommand_CollectDiagnosticsRequestV1:
+						log.Info("Received collect diagnostics request", "messageId", cmd.CollectDiagnosticsRequestV1.Base.MessageId)
+						select {
+						case collectDiagnosticsRequestCh <- cmd.CollectDiagnosticsRequestV1:
+						default:
+							log.Warn("Collect diagnostics request channel full, dropping request")
+							baseResp := createBaseResponse(cmd.CollectDiagnosticsRequestV1.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "Request dropped: channel full")
+							resp := &pb.CollectDiagnosticsResponseV1{Base: &baseResp}
+							agentMsg := &pb.AgentMessage{Message: &pb.AgentMessage_CollectDiagnosticsResponseV1{CollectDiagnosticsResponseV1: resp}}
+							if err := stream.Send(agentMsg); err != nil {
+								log.Warn("Error sending dropped request response", "error", err)
+							} else {
+								log.Info("Dropped request response sent successfully")
+							}
+						}
+
+					default:
+						// Log details about the unknown command type
+						log.Warn("Received unknown command type", "type", fmt.Sprintf("%T", cmd))
+					}
+				}
+			}()
+
+			// Start periodic heartbeat sender
+			ticker := time.NewTicker(HeartbeatInterval)
+
+			// Start periodic metrics sender
+			metricsTicker := time.NewTicker(MetricsInterval)
+
+			for {
+				select {
+				case <-ticker.C:
+					if !c.IsRegistered() {
+						log.Warn("Agent is not registered, stopping heartbeat sender")
+						return
+					}
+
+					if err := c.sendHeartbeat(stream, agentID); err != nil {
+						log.Error("Error sending heartbeat", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							c.connStats.failed(err)
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Debug("Periodic heartbeat sent successfully")
+					c.connStats.refresh()
+
+				case <-metricsTicker.C:
+					if !c.IsRegistered() {
+						log.Warn("Agent is not registered, stopping metrics sender")
+						return
+					}
+
+					baseMsg := &pb.BaseMessage{
+						MessageId: GenerateUUID(),
+						Timestamp: TimestampNow(),
+						AgentId:   agentID,
+					}
+
+					metrics := &pb.AgentMetricsV1{
+						Base: baseMsg,
+					}
+
+					agentMsg := &pb.AgentMessage{
+						Message: &pb.AgentMessage_MetricsV1{
+							MetricsV1: metrics,
+						},
+					}
 
 					if err := stream.Send(agentMsg); err != nil {
 						log.Error("Error sending metrics", "error", err)
@@ -1088,7 +1607,10 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					log.Info("App response sent successfully")
 
 				case saveAppRequest := <-saveAppRequestCh:
-					agentMsg, err := HandleSaveAppRequest(c.commandBus, saveAppRequest, agentID)
+					command := &pb.ServerCommand_SaveAppRequestV1{SaveAppRequestV1: saveAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleSaveAppRequest(c.commandBus, saveAppRequest, agentID)
+					})
 					if err != nil {
 						log.Error("Error saving app response", "error", err)
 						continue
@@ -1107,7 +1629,10 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					log.Info("Save app response sent successfully")
 
 				case deleteAppRequest := <-deleteAppRequestCh:
-					agentMsg, err := HandleDeleteAppRequest(c.commandBus, deleteAppRequest, agentID)
+					command := &pb.ServerCommand_DeleteAppRequestV1{DeleteAppRequestV1: deleteAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleDeleteAppRequest(c.commandBus, deleteAppRequest, agentID)
+					})
 					if err != nil {
 						log.Error("Error deleting app response", "error", err)
 						continue
@@ -1126,7 +1651,11 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					log.Info("Delete app response sent successfully")
 
 				case controlAppRequest := <-controlAppRequestCh:
-					agentMsg, err := HandleControlAppRequest(c.commandBus, controlAppRequest, agentID)
+					command := &pb.ServerCommand_ControlAppRequestV1{ControlAppRequestV1: controlAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						// The main loop is busy with the action, so its output is sent from the output stream.
+						return HandleControlAppRequest(c.commandBus, controlAppRequest, agentID, stream.Send)
+					})
 					if err != nil {
 						log.Error("Error controlling app response", "error", err)
 						continue
@@ -1164,7 +1693,10 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					log.Info("Get apps status response sent successfully")
 
 				case renameAppRequest := <-renameAppRequestCh:
-					agentMsg, err := HandleRenameAppRequest(c.commandBus, renameAppRequest, agentID)
+					command := &pb.ServerCommand_RenameAppRequestV1{RenameAppRequestV1: renameAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleRenameAppRequest(c.commandBus, renameAppRequest, agentID)
+					})
 					if err != nil {
 						log.Error("Error renaming app response", "error", err)
 						continue
@@ -1293,7 +1825,7 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					log.Info("Get networks response sent successfully")
 
 				case getAppLogsRequest := <-getAppLogsRequestCh:
-					agentMsg, err := HandleGetAppLogsQuery(c.queryBus, getAppLogsRequest, agentID)
+					agentMsg, err := HandleGetAppLogsQuery(c.queryBus, getAppLogsRequest, agentID, c.config.GetMaxLogBytes())
 					if err != nil {
 						log.Error("Error retrieving app logs response", "error", err)
 						continue
@@ -1311,12 +1843,388 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 					}
 					log.Info("Get app logs response sent successfully")
 
+				case getAppRevisionsRequest := <-getAppRevisionsRequestCh:
+					agentMsg, err := HandleGetAppRevisionsQuery(c.queryBus, getAppRevisionsRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving app revisions response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get app revisions response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get app revisions response sent successfully")
+
+				case getAppsRequest := <-getAppsRequestCh:
+					agentMsg, err := HandleGetAppsQuery(c.queryBus, getAppsRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving apps response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get apps response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get apps response sent successfully")
+
+				case validateAppRequest := <-validateAppRequestCh:
+					agentMsg, err := HandleValidateAppQuery(c.queryBus, validateAppRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving validate app response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending validate app response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Validate app response sent successfully")
+
+				case getAppResourcesRequest := <-getAppResourcesRequestCh:
+					agentMsg, err := HandleGetAppResourcesQuery(c.queryBus, getAppResourcesRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving app resources response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get app resources response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get app resources response sent successfully")
+
+				case getRenderedComposeRequest := <-getRenderedComposeRequestCh:
+					agentMsg, err := HandleGetRenderedComposeQuery(c.queryBus, getRenderedComposeRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving rendered compose response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get rendered compose response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get rendered compose response sent successfully")
+
+				case exportAppRequest := <-exportAppRequestCh:
+					agentMsgs, err := HandleExportAppQuery(c.queryBus, exportAppRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving export app response", "error", err)
+						continue
+					}
+
+					for _, agentMsg := range agentMsgs {
+						if err := stream.Send(agentMsg); err != nil {
+							log.Error("Error sending export app response", "error", err)
+							if status.Code(err) == codes.Unavailable || err == io.EOF {
+								log.Warn("Connection unavailable or stream closed, recreating stream")
+								ticker.Stop()
+								metricsTicker.Stop()
+								continue outerLoop
+							}
+							break
+						}
+					}
+					log.Info("Export app response sent", "chunks", len(agentMsgs))
+
+				case importAppRequest := <-importAppRequestCh:
+					command := &pb.ServerCommand_ImportAppRequestV1{ImportAppRequestV1: importAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleImportAppRequest(c.commandBus, importAppRequest, agentID)
+					})
+					if err != nil {
+						log.Error("Error processing import app request", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending import app response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Import app response sent successfully")
+
+				case reconcileAppRequest := <-reconcileAppRequestCh:
+					command := &pb.ServerCommand_ReconcileAppRequestV1{ReconcileAppRequestV1: reconcileAppRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleReconcileAppRequest(c.commandBus, reconcileAppRequest, agentID)
+					})
+					if err != nil {
+						log.Error("Error processing reconcile app request", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending reconcile app response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Reconcile app response sent successfully")
+
+				case startAppsRequest := <-startAppsRequestCh:
+					command := &pb.ServerCommand_StartAppsRequestV1{StartAppsRequestV1: startAppsRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleStartAppsRequest(c.commandBus, startAppsRequest, agentID)
+					})
+					if err != nil {
+						log.Error("Error processing start apps request", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending start apps response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Start apps response sent successfully")
+
+				case backupVolumesRequest := <-backupVolumesRequestCh:
+					command := &pb.ServerCommand_BackupVolumesRequestV1{BackupVolumesRequestV1: backupVolumesRequest}
+					agentMsg, err := c.runAppCommand(command, agentID, func() (*pb.AgentMessage, error) {
+						return HandleBackupVolumesRequest(c.commandBus, backupVolumesRequest, agentID)
+					})
+					if err != nil {
+						log.Error("Error processing backup volumes request", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending backup volumes response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Backup volumes response sent successfully")
+
+				case getSystemInfoRequest := <-getSystemInfoRequestCh:
+					agentMsg, err := HandleGetSystemInfoQuery(c.queryBus, getSystemInfoRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving system info response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get system info response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get system info response sent successfully")
+
+				case getConnectionStatsRequest := <-getConnectionStatsRequestCh:
+					agentMsg, err := HandleGetConnectionStatsQuery(c.queryBus, getConnectionStatsRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving connection stats response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get connection stats response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get connection stats response sent successfully")
+
+				case getAgentConfigRequest := <-getAgentConfigRequestCh:
+					agentMsg, err := HandleGetAgentConfigQuery(c.queryBus, getAgentConfigRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving agent config response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get agent config response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get agent config response sent successfully")
+
+				case getVersionInfoRequest := <-getVersionInfoRequestCh:
+					agentMsg, err := HandleGetVersionInfoQuery(c.queryBus, getVersionInfoRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving version info response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get version info response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get version info response sent successfully")
+
+				case checkForUpdateRequest := <-checkForUpdateRequestCh:
+					agentMsg, err := HandleCheckForUpdateQuery(c.queryBus, checkForUpdateRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving check for update response", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending check for update response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Check for update response sent successfully")
+
+				case getAppMetricsRequest := <-getAppMetricsRequestCh:
+					agentMsg, err := HandleGetAppMetricsQuery(c.queryBus, getAppMetricsRequest, agentID)
+					if err != nil {
+						log.Error("Error retrieving app metrics", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending get app metrics response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Get app metrics response sent successfully")
+
+				case collectDiagnosticsRequest := <-collectDiagnosticsRequestCh:
+					agentMsg, err := HandleCollectDiagnosticsRequest(c.commandBus, collectDiagnosticsRequest, agentID)
+					if err != nil {
+						log.Error("Error processing collect diagnostics request", "error", err)
+						continue
+					}
+
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending collect diagnostics response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Collect diagnostics response sent successfully")
+
+				case agentMsg := <-updateAgentResponseCh:
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending update agent response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Info("Update agent response sent successfully")
+
+				case agentMsg := <-dockerEventsCh:
+					if err := stream.Send(agentMsg); err != nil {
+						log.Error("Error sending docker events response", "error", err)
+						if status.Code(err) == codes.Unavailable || err == io.EOF {
+							log.Warn("Connection unavailable or stream closed, recreating stream")
+							ticker.Stop()
+							metricsTicker.Stop()
+							continue outerLoop
+						}
+						continue
+					}
+					log.Debug("Docker events response sent successfully")
+
 				case <-streamDone:
 					log.Warn("Stream receiver stopped, recreating stream")
 					ticker.Stop()
 					metricsTicker.Stop()
 					continue outerLoop
 
+				case <-c.certRenewed:
+					previous, err := c.cycleConnection(ctx)
+					if err != nil {
+						log.Error("Failed to connect with the renewed certificate, keeping the current connection", "error", err)
+						continue
+					}
+					log.Info("Connected with the renewed certificate, recreating stream")
+					stream.CloseSend()
+					previous.Close()
+					ticker.Stop()
+					metricsTicker.Stop()
+					continue outerLoop
+
 				case <-reregisterCh:
 					log.Warn("Re-registering agent due to agent not found")
 					stream.CloseSend()
@@ -1396,6 +2304,18 @@ func (c *Client) StartAgentStream(ctx context.Context, agentID string, metricsPr
 	return nil
 }
 
+// switchServerAddress closes the current connection and sets up a new one
+// for the next configured endpoint.
+func (c *Client) switchServerAddress() error {
+	previous := c.serverAddress
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	c.rotateServerAddress()
+	log.Warn("Switching gRPC endpoint", "from", previous, "to", c.serverAddress)
+	return c.setupConnection()
+}
+
 // reconnect attempts to reconnect to the server
 func (c *Client) reconnect(ctx context.Context) error {
 	c.reconnectMu.Lock()
@@ -1435,6 +2355,9 @@ func (c *Client) reconnect(ctx context.Context) error {
 	}
 	log.Debug("TLS certificates verified successfully")
 
+	// Rotate to the next endpoint so that a failing server is not retried first
+	c.rotateServerAddress()
+
 	// Setup new connection
 	log.Debug("Setting up new connection", "server_address", c.serverAddress)
 	setupStartTime := time.Now()
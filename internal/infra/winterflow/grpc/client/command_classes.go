@@ -0,0 +1,55 @@
+package client
+
+// commandClass describes how requests that change the agent, its host or its apps are processed.
+type commandClass struct {
+	// app requests change applications and are paused in maintenance mode.
+	app bool
+	// safeModeExempt requests are processed in safe mode although they change the agent.
+	safeModeExempt bool
+}
+
+// mutatingCommands classifies the requests that change the agent, its host or its apps by request name
+// (see commandTypeName). Every listed request must be signed when command signatures are enabled; requests
+// that are not listed, such as queries, are read-only.
+var mutatingCommands = map[string]commandClass{
+	"SaveApp":       {app: true},
+	"RenameApp":     {app: true},
+	"DeleteApp":     {app: true},
+	"ControlApp":    {app: true},
+	"ImportApp":     {app: true},
+	"ReconcileApp":  {app: true},
+	"StartApps":     {app: true},
+	"BackupVolumes": {app: true},
+
+	"UpdateAgent":    {},
+	"CreateRegistry": {},
+	"DeleteRegistry": {},
+	"CreateNetwork":  {},
+	"DeleteNetwork":  {},
+
+	// Leaving safe mode, pausing app commands and canceling a running operation do not change apps, so the
+	// state of the agent can still be managed while it is inspected.
+	"ClearSafeMode":      {safeModeExempt: true},
+	"SetMaintenanceMode": {safeModeExempt: true},
+	"CancelOperation":    {safeModeExempt: true},
+}
+
+// isAppCommand reports whether command changes applications and is therefore paused in maintenance mode.
+// Queries, heartbeats and agent management commands are always processed.
+func isAppCommand(command interface{}) bool {
+	return mutatingCommands[commandTypeName(command)].app
+}
+
+// isMutatingCommand reports whether command changes the state of the agent or its host. Only these commands
+// have to be signed when command signatures are enabled.
+func isMutatingCommand(command interface{}) bool {
+	_, ok := mutatingCommands[commandTypeName(command)]
+	return ok
+}
+
+// isSafeModeCommand reports whether command is rejected in safe mode: every mutating command except the
+// exempt ones.
+func isSafeModeCommand(command interface{}) bool {
+	class, ok := mutatingCommands[commandTypeName(command)]
+	return ok && !class.safeModeExempt
+}
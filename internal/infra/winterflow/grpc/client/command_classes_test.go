@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+func TestMutatingCommandsNameKnownRequests(t *testing.T) {
+	known := requestNames()
+	for name := range mutatingCommands {
+		if !known[name] {
+			t.Errorf("Classified request %q is not a server request", name)
+		}
+	}
+}
+
+func TestMaintenanceModeRejectsBackupVolumes(t *testing.T) {
+	c := &Client{}
+	c.SetMaintenanceMode(true)
+
+	command := &pb.ServerCommand_BackupVolumesRequestV1{BackupVolumesRequestV1: &pb.BackupVolumesRequestV1{Base: &pb.BaseMessage{MessageId: "msg-1"}, AppId: "app-1"}}
+	agentMsg := c.maintenanceResponse(command, maintenanceTestAgentID)
+	base := agentMsg.GetBackupVolumesResponseV1().GetBase()
+	if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_MAINTENANCE || base.GetMessageId() != "msg-1" {
+		t.Errorf("Expected backup volumes to be paused in maintenance mode, got %v", agentMsg)
+	}
+}
+
+func TestCommandClasses(t *testing.T) {
+	tests := []struct {
+		command  interface{}
+		app      bool
+		mutating bool
+		safeMode bool
+	}{
+		{controlAppCommand(), true, true, true},
+		{&pb.ServerCommand_BackupVolumesRequestV1{}, true, true, true},
+		{&pb.ServerCommand_DeleteNetworkRequestV1{}, false, true, true},
+		{&pb.ServerCommand_SetMaintenanceModeRequestV1{}, false, true, false},
+		{&pb.ServerCommand_CancelOperationRequestV1{}, false, true, false},
+		{&pb.ServerCommand_ClearSafeModeRequestV1{}, false, true, false},
+		{&pb.ServerCommand_GetAppsStatusRequestV1{}, false, false, false},
+		{&pb.ServerCommand_HeartbeatResponseV1{}, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := isAppCommand(tt.command); got != tt.app {
+			t.Errorf("isAppCommand(%T) = %v, want %v", tt.command, got, tt.app)
+		}
+		if got := isMutatingCommand(tt.command); got != tt.mutating {
+			t.Errorf("isMutatingCommand(%T) = %v, want %v", tt.command, got, tt.mutating)
+		}
+		if got := isSafeModeCommand(tt.command); got != tt.safeMode {
+			t.Errorf("isSafeModeCommand(%T) = %v, want %v", tt.command, got, tt.safeMode)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+// requestSuffix ends the names of the server command fields that carry a request.
+const requestSuffix = "RequestV1"
+
+// commandTypeName returns the name of the request carried by command, e.g. "SaveApp" for a SaveAppRequestV1,
+// or an empty string for server commands that are not requests, such as heartbeat responses.
+func commandTypeName(command interface{}) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", command), "*pb.ServerCommand_")
+	if !strings.HasSuffix(name, requestSuffix) {
+		return ""
+	}
+	return strings.TrimSuffix(name, requestSuffix)
+}
+
+// requestNames returns the names of the requests the server can send.
+func requestNames() map[string]bool {
+	names := make(map[string]bool)
+	fields := (&pb.ServerCommand{}).ProtoReflect().Descriptor().Oneofs().ByName("command").Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if name := string(fields.Get(i).Message().Name()); strings.HasSuffix(name, requestSuffix) {
+			names[strings.TrimSuffix(name, requestSuffix)] = true
+		}
+	}
+	return names
+}
+
+// validateCommandPolicy checks that the allowed and denied commands of cfg name known requests, so that a
+// typo does not silently allow a request that was meant to be denied.
+func validateCommandPolicy(cfg *config.Config) error {
+	known := requestNames()
+	for _, list := range []struct {
+		setting string
+		names   []string
+	}{
+		{"allowed_commands", cfg.AllowedCommands},
+		{"denied_commands", cfg.DeniedCommands},
+	} {
+		for _, name := range list.names {
+			if !known[name] {
+				return fmt.Errorf("%s: unknown request %q", list.setting, name)
+			}
+		}
+	}
+	return nil
+}
+
+// forbiddenResponse returns the RESPONSE_CODE_FORBIDDEN response for command when the configuration of the
+// agent does not allow it. It returns nil when command must be processed.
+func (c *Client) forbiddenResponse(command interface{}, agentID string) *pb.AgentMessage {
+	name := commandTypeName(command)
+	if name == "" || c.config == nil || c.config.IsCommandAllowed(name) {
+		return nil
+	}
+	base := extractBaseMessageFromCommand(command)
+	return buildErrorAgentMessage(command, base.GetMessageId(), agentID, pb.ResponseCode_RESPONSE_CODE_FORBIDDEN, fmt.Sprintf("%s requests are not allowed on this agent", name))
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+func saveAppCommand() *pb.ServerCommand_SaveAppRequestV1 {
+	return &pb.ServerCommand_SaveAppRequestV1{SaveAppRequestV1: &pb.SaveAppRequestV1{
+		Base: &pb.BaseMessage{MessageId: "msg-1", AgentId: maintenanceTestAgentID},
+	}}
+}
+
+func getAppsStatusCommand() *pb.ServerCommand_GetAppsStatusRequestV1 {
+	return &pb.ServerCommand_GetAppsStatusRequestV1{GetAppsStatusRequestV1: &pb.GetAppsStatusRequestV1{
+		Base: &pb.BaseMessage{MessageId: "msg-2", AgentId: maintenanceTestAgentID},
+	}}
+}
+
+func TestReadOnlyAgentRejectsSaveAppButServesGetAppsStatus(t *testing.T) {
+	c := &Client{config: &config.Config{AllowedCommands: []string{"GetAppsStatus", "GetAppLogs"}}}
+
+	agentMsg := c.forbiddenResponse(saveAppCommand(), maintenanceTestAgentID)
+	if agentMsg == nil {
+		t.Fatal("Expected SaveApp to be rejected")
+	}
+	resp := agentMsg.GetSaveAppResponseV1()
+	if resp == nil {
+		t.Fatalf("Expected a save app response, got %T", agentMsg.Message)
+	}
+	if resp.Base.ResponseCode != pb.ResponseCode_RESPONSE_CODE_FORBIDDEN || resp.Base.MessageId != "msg-1" {
+		t.Errorf("Unexpected response base: %+v", resp.Base)
+	}
+
+	if agentMsg := c.forbiddenResponse(getAppsStatusCommand(), maintenanceTestAgentID); agentMsg != nil {
+		t.Error("Expected GetAppsStatus to be served")
+	}
+	heartbeat := &pb.ServerCommand_HeartbeatResponseV1{HeartbeatResponseV1: &pb.AgentHeartbeatResponseV1{}}
+	if agentMsg := c.forbiddenResponse(heartbeat, maintenanceTestAgentID); agentMsg != nil {
+		t.Error("Expected heartbeat responses to be processed")
+	}
+}
+
+func TestDeniedCommandsOverrideAllowedCommands(t *testing.T) {
+	c := &Client{config: &config.Config{AllowedCommands: []string{"SaveApp", "GetAppsStatus"}, DeniedCommands: []string{"SaveApp"}}}
+
+	if c.forbiddenResponse(saveAppCommand(), maintenanceTestAgentID) == nil {
+		t.Error("Expected the denied SaveApp to be rejected")
+	}
+	if c.forbiddenResponse(getAppsStatusCommand(), maintenanceTestAgentID) != nil {
+		t.Error("Expected GetAppsStatus to be served")
+	}
+}
+
+func TestEveryCommandIsAllowedByDefault(t *testing.T) {
+	c := &Client{config: &config.Config{}}
+
+	if c.forbiddenResponse(saveAppCommand(), maintenanceTestAgentID) != nil {
+		t.Error("Expected SaveApp to be processed without a command policy")
+	}
+}
+
+func TestValidateCommandPolicyRejectsUnknownRequests(t *testing.T) {
+	if err := validateCommandPolicy(&config.Config{AllowedCommands: []string{"GetAppsStatus", "ControlApp"}}); err != nil {
+		t.Errorf("Expected known requests to be accepted, got %v", err)
+	}
+	err := validateCommandPolicy(&config.Config{DeniedCommands: []string{"DeleteApps"}})
+	if err == nil || !strings.Contains(err.Error(), `denied_commands: unknown request "DeleteApps"`) {
+		t.Errorf("Expected the unknown request to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/certs"
+	"winterflow-agent/pkg/log"
+)
+
+// loadServerSigningKey returns the pinned server key when the command signatures feature is enabled and nil
+// otherwise. An enabled feature without a loadable key is an error, so that commands are never accepted
+// unverified by mistake.
+func loadServerSigningKey(cfg *config.Config) (*ecdsa.PublicKey, error) {
+	if !cfg.IsFeatureEnabled(config.FeatureCommandSignatures) {
+		return nil, nil
+	}
+	path := cfg.GetServerSigningKeyPath()
+	if path == "" {
+		return nil, fmt.Errorf("command signatures are enabled but no server signing key is configured")
+	}
+	key, err := certs.LoadECPublicKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server signing key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// commandSigningPayload returns the bytes the server signs for serverCmd: its deterministic protobuf
+// encoding with the signature of the base message cleared.
+func commandSigningPayload(serverCmd *pb.ServerCommand) ([]byte, error) {
+	unsigned := proto.Clone(serverCmd).(*pb.ServerCommand)
+	if base := extractBaseMessageFromCommand(unsigned.Command); base != nil {
+		base.Signature = nil
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(unsigned)
+}
+
+// verifyCommandSignature checks the signature of serverCmd against key.
+func verifyCommandSignature(key *ecdsa.PublicKey, serverCmd *pb.ServerCommand) error {
+	signature := extractBaseMessageFromCommand(serverCmd.Command).GetSignature()
+	if len(signature) == 0 {
+		return fmt.Errorf("missing command signature")
+	}
+	payload, err := commandSigningPayload(serverCmd)
+	if err != nil {
+		return fmt.Errorf("failed to encode command: %w", err)
+	}
+	if !certs.VerifyWithPublicKey(key, payload, signature) {
+		return fmt.Errorf("invalid command signature")
+	}
+	return nil
+}
+
+// signatureResponse returns the RESPONSE_CODE_UNAUTHORIZED response for serverCmd when command signatures
+// are enabled and the mutating command is unsigned or mis-signed, and nil when it may be processed.
+func (c *Client) signatureResponse(serverCmd *pb.ServerCommand, agentID string) *pb.AgentMessage {
+	if c.signingKey == nil || !isMutatingCommand(serverCmd.Command) {
+		return nil
+	}
+	err := verifyCommandSignature(c.signingKey, serverCmd)
+	if err == nil {
+		return nil
+	}
+
+	log.Warn("Rejecting server command", "type", fmt.Sprintf("%T", serverCmd.Command), "error", err)
+	base := extractBaseMessageFromCommand(serverCmd.Command)
+	return buildErrorAgentMessage(serverCmd.Command, base.GetMessageId(), agentID, pb.ResponseCode_RESPONSE_CODE_UNAUTHORIZED, "Command signature verification failed: "+err.Error())
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/certs"
+)
+
+// newSigningKey generates a server private key and returns its path together with its public key.
+func newSigningKey(t *testing.T) (string, *ecdsa.PublicKey) {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "server.key")
+	if err := certs.GeneratePrivateKey(keyPath); err != nil {
+		t.Fatalf("GeneratePrivateKey returned error: %v", err)
+	}
+	pubPath := writeSigningPublicKey(t, keyPath)
+	pub, err := certs.LoadECPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadECPublicKey returned error: %v", err)
+	}
+	return keyPath, pub
+}
+
+// writeSigningPublicKey writes the PEM encoded public key of the private key at keyPath and returns its path.
+func writeSigningPublicKey(t *testing.T, keyPath string) string {
+	t.Helper()
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("Failed to read private key: %v", err)
+	}
+	block, _ := pem.Decode(keyBytes)
+	privKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	path := keyPath + ".pub"
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+	return path
+}
+
+// signCommand signs serverCmd with the private key at keyPath the way the server does.
+func signCommand(t *testing.T, keyPath string, serverCmd *pb.ServerCommand) {
+	t.Helper()
+	payload, err := commandSigningPayload(serverCmd)
+	if err != nil {
+		t.Fatalf("commandSigningPayload returned error: %v", err)
+	}
+	signature, err := certs.SignWithPrivateKey(keyPath, payload)
+	if err != nil {
+		t.Fatalf("SignWithPrivateKey returned error: %v", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+	extractBaseMessageFromCommand(serverCmd.Command).Signature = sig
+}
+
+func controlAppServerCommand() *pb.ServerCommand {
+	return &pb.ServerCommand{Command: controlAppCommand()}
+}
+
+func TestSignatureResponseAcceptsValidSignature(t *testing.T) {
+	keyPath, pub := newSigningKey(t)
+	c := &Client{signingKey: pub}
+
+	serverCmd := controlAppServerCommand()
+	signCommand(t, keyPath, serverCmd)
+	if agentMsg := c.signatureResponse(serverCmd, maintenanceTestAgentID); agentMsg != nil {
+		t.Errorf("Expected a validly signed command to be accepted, got %v", agentMsg)
+	}
+}
+
+func TestSignatureResponseRejectsMissingAndInvalidSignatures(t *testing.T) {
+	keyPath, pub := newSigningKey(t)
+	otherKeyPath, _ := newSigningKey(t)
+
+	testCases := []struct {
+		name    string
+		prepare func(serverCmd *pb.ServerCommand)
+	}{
+		{name: "missing", prepare: func(*pb.ServerCommand) {}},
+		{name: "other key", prepare: func(serverCmd *pb.ServerCommand) { signCommand(t, otherKeyPath, serverCmd) }},
+		{name: "tampered", prepare: func(serverCmd *pb.ServerCommand) {
+			signCommand(t, keyPath, serverCmd)
+			serverCmd.GetControlAppRequestV1().AppId = "app-2"
+		}},
+		{name: "garbage", prepare: func(serverCmd *pb.ServerCommand) {
+			extractBaseMessageFromCommand(serverCmd.Command).Signature = []byte("not a signature")
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{signingKey: pub}
+			serverCmd := controlAppServerCommand()
+			tc.prepare(serverCmd)
+
+			agentMsg := c.signatureResponse(serverCmd, maintenanceTestAgentID)
+			if agentMsg == nil {
+				t.Fatal("Expected the command to be rejected")
+			}
+			resp := agentMsg.GetControlAppResponseV1()
+			if resp == nil {
+				t.Fatalf("Expected a control app response, got %T", agentMsg.Message)
+			}
+			if resp.Base.ResponseCode != pb.ResponseCode_RESPONSE_CODE_UNAUTHORIZED || resp.Base.MessageId != "msg-1" {
+				t.Errorf("Unexpected response base: %+v", resp.Base)
+			}
+		})
+	}
+}
+
+func TestSignatureResponseSkipsQueriesAndDisabledVerification(t *testing.T) {
+	_, pub := newSigningKey(t)
+
+	query := &pb.ServerCommand{Command: &pb.ServerCommand_GetAppsStatusRequestV1{GetAppsStatusRequestV1: &pb.GetAppsStatusRequestV1{Base: &pb.BaseMessage{}}}}
+	if agentMsg := (&Client{signingKey: pub}).signatureResponse(query, maintenanceTestAgentID); agentMsg != nil {
+		t.Error("Expected unsigned queries to be accepted")
+	}
+	if agentMsg := (&Client{}).signatureResponse(controlAppServerCommand(), maintenanceTestAgentID); agentMsg != nil {
+		t.Error("Expected unsigned commands to be accepted without a signing key")
+	}
+}
+
+func TestLoadServerSigningKey(t *testing.T) {
+	keyPath, _ := newSigningKey(t)
+
+	disabled := &config.Config{Features: map[string]bool{}}
+	if key, err := loadServerSigningKey(disabled); err != nil || key != nil {
+		t.Errorf("Expected no key while the feature is disabled, got %v (%v)", key, err)
+	}
+
+	enabled := &config.Config{Features: map[string]bool{config.FeatureCommandSignatures: true}}
+	if _, err := loadServerSigningKey(enabled); err == nil {
+		t.Error("Expected an error when the feature is enabled without a key")
+	}
+
+	enabled.ServerSigningKeyPath = writeSigningPublicKey(t, keyPath)
+	if key, err := loadServerSigningKey(enabled); err != nil || key == nil {
+		t.Errorf("Expected the configured key to be loaded, got %v (%v)", key, err)
+	}
+}
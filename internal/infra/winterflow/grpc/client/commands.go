@@ -1,13 +1,20 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"winterflow-agent/internal/application/command/backup_volumes"
+	"winterflow-agent/internal/application/command/cancel_operation"
+	"winterflow-agent/internal/application/command/collect_diagnostics"
+	"winterflow-agent/internal/application/command/control_app"
 	"winterflow-agent/internal/application/command/create_network"
 	"winterflow-agent/internal/application/command/create_registry"
 	"winterflow-agent/internal/application/command/delete_app"
 	"winterflow-agent/internal/application/command/delete_network"
 	"winterflow-agent/internal/application/command/delete_registry"
+	"winterflow-agent/internal/application/command/reconcile_app"
 	"winterflow-agent/internal/application/command/save_app"
+	"winterflow-agent/internal/application/command/start_apps"
 	"winterflow-agent/internal/application/command/update_agent"
 	"winterflow-agent/internal/infra/winterflow/grpc/pb"
 	"winterflow-agent/pkg/cqrs"
@@ -49,11 +56,12 @@ func HandleSaveAppRequest(commandBus cqrs.CommandBus, saveAppRequest *pb.SaveApp
 
 // HandleDeleteAppRequest handles the command dispatch and creates the appropriate response message
 func HandleDeleteAppRequest(commandBus cqrs.CommandBus, deleteAppRequest *pb.DeleteAppRequestV1, agentID string) (*pb.AgentMessage, error) {
-	log.Debug("Processing delete app request", "app_id", deleteAppRequest.AppId)
+	log.Debug("Processing delete app request", "app_id", deleteAppRequest.AppId, "purge", deleteAppRequest.Purge)
 
 	// Create and dispatch the command
 	cmd := delete_app.DeleteAppCommand{
 		AppID: deleteAppRequest.AppId,
+		Purge: deleteAppRequest.Purge,
 	}
 
 	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
@@ -80,13 +88,24 @@ func HandleDeleteAppRequest(commandBus cqrs.CommandBus, deleteAppRequest *pb.Del
 	return agentMsg, nil
 }
 
-// HandleControlAppRequest handles the command dispatch and creates the appropriate response message
-func HandleControlAppRequest(commandBus cqrs.CommandBus, controlAppRequest *pb.ControlAppRequestV1, agentID string) (*pb.AgentMessage, error) {
-	log.Debug("Processing control app request", "app_id", controlAppRequest.AppId, "action", controlAppRequest.Action)
+// HandleControlAppRequest handles the command dispatch and creates the appropriate response message. When
+// the request asks for the output or the progress of the action, they are sent with send while the action
+// runs. send is called from several goroutines and must be safe for concurrent use, see syncStream.
+func HandleControlAppRequest(commandBus cqrs.CommandBus, controlAppRequest *pb.ControlAppRequestV1, agentID string, send func(*pb.AgentMessage) error) (*pb.AgentMessage, error) {
+	log.Debug("Processing control app request", "app_id", controlAppRequest.AppId, "action", controlAppRequest.Action, "stream_output", controlAppRequest.StreamOutput, "report_progress", controlAppRequest.ReportProgress, "services", controlAppRequest.Services)
 
 	// Create and dispatch the command
 	cmd := ProtoControlAppRequestV1ToControlAppCommand(controlAppRequest)
 
+	var output *appOutputStream
+	if controlAppRequest.StreamOutput && send != nil {
+		output = newAppOutputStream(send, controlAppRequest.Base.MessageId, agentID, controlAppRequest.AppId)
+		cmd.Output = output.Write
+	}
+	if controlAppRequest.ReportProgress && send != nil {
+		cmd.Progress = newAppProgressReporter(send, controlAppRequest.Base.MessageId, agentID, controlAppRequest.AppId)
+	}
+
 	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
 	var responseMessage = "App control action executed successfully"
 
@@ -94,8 +113,14 @@ func HandleControlAppRequest(commandBus cqrs.CommandBus, controlAppRequest *pb.C
 	if err := commandBus.Dispatch(cmd); err != nil {
 		log.Error("Error controlling app", "error", err)
 		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		if errors.Is(err, control_app.ErrRateLimited) {
+			responseCode = pb.ResponseCode_RESPONSE_CODE_RATE_LIMITED
+		}
 		responseMessage = fmt.Sprintf("Error controlling app: %v", err)
 	}
+	if output != nil {
+		output.Close(responseCode, responseMessage)
+	}
 
 	baseResp := createBaseResponse(controlAppRequest.Base.MessageId, agentID, responseCode, responseMessage)
 	controlAppResp := &pb.ControlAppResponseV1{
@@ -233,7 +258,14 @@ func HandleDeleteRegistryRequest(commandBus cqrs.CommandBus, deleteRegistryReque
 func HandleCreateNetworkRequest(commandBus cqrs.CommandBus, createNetworkRequest *pb.CreateNetworkRequestV1, agentID string) (*pb.AgentMessage, error) {
 	log.Debug("Processing create network request", "name", createNetworkRequest.Name)
 
-	cmd := create_network.CreateNetworkCommand{NetworkName: createNetworkRequest.Name}
+	cmd := create_network.CreateNetworkCommand{
+		NetworkName: createNetworkRequest.Name,
+		Driver:      createNetworkRequest.Driver,
+		Subnets:     createNetworkRequest.Subnets,
+		Gateways:    createNetworkRequest.Gateways,
+		Attachable:  createNetworkRequest.Attachable,
+		Internal:    createNetworkRequest.Internal,
+	}
 
 	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
 	responseMessage := "Network created successfully"
@@ -278,3 +310,249 @@ func HandleDeleteNetworkRequest(commandBus cqrs.CommandBus, deleteNetworkRequest
 
 	return agentMsg, nil
 }
+
+// HandleImportAppRequest handles the command dispatch and creates the appropriate response message
+func HandleImportAppRequest(commandBus cqrs.CommandBus, importAppRequest *pb.ImportAppRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing import app request", "app_id", importAppRequest.AppId, "deploy", importAppRequest.Deploy)
+
+	// Create and dispatch the command
+	cmd := ProtoImportAppRequestV1ToImportAppCommand(importAppRequest)
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "App imported successfully"
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		log.Error("Error importing app", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = fmt.Sprintf("Error importing app: %v", err)
+	}
+
+	baseResp := createBaseResponse(importAppRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	importAppResp := &pb.ImportAppResponseV1{
+		Base:  &baseResp,
+		AppId: importAppRequest.AppId,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_ImportAppResponseV1{
+			ImportAppResponseV1: importAppResp,
+		},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleCancelOperationRequest handles the command dispatch and creates the appropriate response message
+func HandleCancelOperationRequest(commandBus cqrs.CommandBus, cancelOperationRequest *pb.CancelOperationRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing cancel operation request", "app_id", cancelOperationRequest.AppId)
+
+	// Create and dispatch the command
+	cmd := cancel_operation.CancelOperationCommand{
+		AppID: cancelOperationRequest.AppId,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "Operation canceled successfully"
+	canceled := true
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		canceled = false
+		if errors.Is(err, cancel_operation.ErrNoOperationRunning) {
+			responseMessage = "No operation is running for the app"
+		} else {
+			log.Error("Error canceling operation", "error", err)
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = fmt.Sprintf("Error canceling operation: %v", err)
+		}
+	}
+
+	baseResp := createBaseResponse(cancelOperationRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	cancelOperationResp := &pb.CancelOperationResponseV1{
+		Base:     &baseResp,
+		AppId:    cancelOperationRequest.AppId,
+		Canceled: canceled,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_CancelOperationResponseV1{
+			CancelOperationResponseV1: cancelOperationResp,
+		},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleReconcileAppRequest handles the command dispatch and creates the appropriate response message
+func HandleReconcileAppRequest(commandBus cqrs.CommandBus, reconcileAppRequest *pb.ReconcileAppRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing reconcile app request", "app_id", reconcileAppRequest.AppId)
+
+	// Create and dispatch the command
+	result := &reconcile_app.ReconcileAppResult{}
+	cmd := reconcile_app.ReconcileAppCommand{
+		AppID:  reconcileAppRequest.AppId,
+		Result: result,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "App reconciled successfully"
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		log.Error("Error reconciling app", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = fmt.Sprintf("Error reconciling app: %v", err)
+	} else if result.Drifted {
+		responseMessage = "App reconciled successfully, the app directory had drifted from its templates"
+	}
+
+	baseResp := createBaseResponse(reconcileAppRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	reconcileAppResp := &pb.ReconcileAppResponseV1{
+		Base:    &baseResp,
+		AppId:   reconcileAppRequest.AppId,
+		Drifted: result.Drifted,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_ReconcileAppResponseV1{
+			ReconcileAppResponseV1: reconcileAppResp,
+		},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleStartAppsRequest handles the command dispatch and creates the appropriate response message
+func HandleStartAppsRequest(commandBus cqrs.CommandBus, startAppsRequest *pb.StartAppsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing start apps request", "app_ids", startAppsRequest.AppIds)
+
+	// Create and dispatch the command
+	result := &start_apps.StartAppsResult{}
+	cmd := start_apps.StartAppsCommand{
+		AppIDs: startAppsRequest.AppIds,
+		Result: result,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "Apps started successfully"
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		log.Error("Error starting apps", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		if errors.Is(err, start_apps.ErrDependencyCycle) {
+			responseCode = pb.ResponseCode_RESPONSE_CODE_INVALID_REQUEST
+		}
+		responseMessage = fmt.Sprintf("Error starting apps: %v", err)
+	}
+
+	results := make([]*pb.StartAppResultV1, 0, len(result.Apps))
+	for _, app := range result.Apps {
+		appResult := &pb.StartAppResultV1{AppId: app.AppID, Started: app.Err == nil}
+		if app.Err != nil {
+			appResult.Error = app.Err.Error()
+		}
+		results = append(results, appResult)
+	}
+
+	baseResp := createBaseResponse(startAppsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	startAppsResp := &pb.StartAppsResponseV1{
+		Base:    &baseResp,
+		Results: results,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_StartAppsResponseV1{
+			StartAppsResponseV1: startAppsResp,
+		},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleBackupVolumesRequest handles the command dispatch and creates the appropriate response message
+func HandleBackupVolumesRequest(commandBus cqrs.CommandBus, backupVolumesRequest *pb.BackupVolumesRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing backup volumes request", "app_id", backupVolumesRequest.AppId)
+
+	// Create and dispatch the command
+	result := &backup_volumes.BackupVolumesResult{}
+	cmd := backup_volumes.BackupVolumesCommand{
+		AppID:  backupVolumesRequest.AppId,
+		Result: result,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "Volumes backed up successfully"
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		log.Error("Error backing up volumes", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = fmt.Sprintf("Error backing up volumes: %v", err)
+	} else if len(result.Backups) == 0 {
+		responseMessage = "App has no named volumes to back up"
+	}
+
+	backups := make([]*pb.VolumeBackupV1, 0, len(result.Backups))
+	for _, backup := range result.Backups {
+		backups = append(backups, &pb.VolumeBackupV1{Volume: backup.Volume, Archive: backup.Archive})
+	}
+
+	baseResp := createBaseResponse(backupVolumesRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	backupVolumesResp := &pb.BackupVolumesResponseV1{
+		Base:    &baseResp,
+		AppId:   backupVolumesRequest.AppId,
+		Backups: backups,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_BackupVolumesResponseV1{
+			BackupVolumesResponseV1: backupVolumesResp,
+		},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleCollectDiagnosticsRequest handles the command dispatch and creates the appropriate response message
+func HandleCollectDiagnosticsRequest(commandBus cqrs.CommandBus, collectDiagnosticsRequest *pb.CollectDiagnosticsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing collect diagnostics request", "upload", collectDiagnosticsRequest.UploadUrl != "")
+
+	// Create and dispatch the command
+	result := &collect_diagnostics.CollectDiagnosticsResult{}
+	cmd := collect_diagnostics.CollectDiagnosticsCommand{
+		UploadURL: collectDiagnosticsRequest.UploadUrl,
+		Result:    result,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "Diagnostics collected successfully"
+
+	// Dispatch the command to the handler
+	if err := commandBus.Dispatch(cmd); err != nil {
+		log.Error("Error collecting diagnostics", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = fmt.Sprintf("Error collecting diagnostics: %v", err)
+	} else if result.Uploaded {
+		responseMessage = "Diagnostics collected and uploaded successfully"
+	}
+
+	baseResp := createBaseResponse(collectDiagnosticsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	collectDiagnosticsResp := &pb.CollectDiagnosticsResponseV1{
+		Base:        &baseResp,
+		Bundle:      result.Bundle,
+		Size:        uint64(result.Size),
+		Uploaded:    result.Uploaded,
+		Entries:     result.Entries,
+		Unavailable: result.Unavailable,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_CollectDiagnosticsResponseV1{
+			CollectDiagnosticsResponseV1: collectDiagnosticsResp,
+		},
+	}
+
+	return agentMsg, nil
+}
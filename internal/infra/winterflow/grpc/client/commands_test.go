@@ -0,0 +1,135 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"winterflow-agent/internal/application/command/backup_volumes"
+	"winterflow-agent/internal/application/command/control_app"
+	"winterflow-agent/internal/application/command/start_apps"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/cqrs"
+)
+
+// rateLimitedControlAppHandler rejects every control app command as rate limited and records the last one.
+type rateLimitedControlAppHandler struct {
+	cmd control_app.ControlAppCommand
+}
+
+func (h *rateLimitedControlAppHandler) Handle(cmd control_app.ControlAppCommand) error {
+	h.cmd = cmd
+	return fmt.Errorf("%w: retry in 30s or force the request", control_app.ErrRateLimited)
+}
+
+func TestHandleControlAppRequestReportsRateLimitedRestarts(t *testing.T) {
+	bus := cqrs.NewCommandBus(t.Context())
+	handler := &rateLimitedControlAppHandler{}
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	request := &pb.ControlAppRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app", Action: pb.AppAction_RESTART, Force: true}
+	msg, err := HandleControlAppRequest(bus, request, "agent", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base := msg.GetControlAppResponseV1().GetBase()
+	if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_RATE_LIMITED || base.GetMessageId() != "msg" {
+		t.Errorf("Expected a rate limited response, got %v: %s", base.GetResponseCode(), base.GetMessage())
+	}
+	if !handler.cmd.Force {
+		t.Error("Expected the force flag to be passed to the command")
+	}
+}
+
+// failingStartAppsHandler fails every start apps command with err after reporting results.
+type failingStartAppsHandler struct {
+	err     error
+	results []start_apps.AppStartResult
+}
+
+func (h *failingStartAppsHandler) Handle(cmd start_apps.StartAppsCommand) error {
+	if cmd.Result != nil {
+		cmd.Result.Apps = h.results
+	}
+	return h.err
+}
+
+func TestHandleStartAppsRequestReportsDependencyCyclesAsInvalid(t *testing.T) {
+	bus := cqrs.NewCommandBus(t.Context())
+	if err := bus.Register(&failingStartAppsHandler{err: fmt.Errorf("cannot order the apps: %w: web -> db -> web", start_apps.ErrDependencyCycle)}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	request := &pb.StartAppsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppIds: []string{"web", "db"}}
+	msg, err := HandleStartAppsRequest(bus, request, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base := msg.GetStartAppsResponseV1().GetBase()
+	if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_INVALID_REQUEST || base.GetMessageId() != "msg" {
+		t.Errorf("Expected an invalid request response, got %v: %s", base.GetResponseCode(), base.GetMessage())
+	}
+}
+
+func TestHandleStartAppsRequestReportsTheResultOfEveryApp(t *testing.T) {
+	bus := cqrs.NewCommandBus(t.Context())
+	handler := &failingStartAppsHandler{
+		err:     fmt.Errorf("failed to start 1 of 2 apps"),
+		results: []start_apps.AppStartResult{{AppID: "db"}, {AppID: "web", Err: fmt.Errorf("dependency db is not healthy")}},
+	}
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	msg, err := HandleStartAppsRequest(bus, &pb.StartAppsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppIds: []string{"web", "db"}}, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp := msg.GetStartAppsResponseV1()
+	if resp.GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR {
+		t.Errorf("Expected a server error, got %v", resp.GetBase().GetResponseCode())
+	}
+	results := resp.GetResults()
+	if len(results) != 2 || !results[0].GetStarted() || results[1].GetStarted() || results[1].GetError() != "dependency db is not healthy" {
+		t.Errorf("Expected the result of every app, got %v", results)
+	}
+}
+
+// failingBackupVolumesHandler fails every backup volumes command with err after reporting backups.
+type failingBackupVolumesHandler struct {
+	err     error
+	backups []model.VolumeBackup
+}
+
+func (h *failingBackupVolumesHandler) Handle(cmd backup_volumes.BackupVolumesCommand) error {
+	if cmd.Result != nil {
+		cmd.Result.Backups = h.backups
+	}
+	return h.err
+}
+
+func TestHandleBackupVolumesRequestReportsTheArchivesWritten(t *testing.T) {
+	bus := cqrs.NewCommandBus(t.Context())
+	handler := &failingBackupVolumesHandler{
+		err:     fmt.Errorf("failed to back up volume demo_db"),
+		backups: []model.VolumeBackup{{Volume: "demo_cache", Archive: "/backups/app/20260101T000000Z/demo_cache.tar.gz"}},
+	}
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	msg, err := HandleBackupVolumesRequest(bus, &pb.BackupVolumesRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app"}, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp := msg.GetBackupVolumesResponseV1()
+	if resp.GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR || resp.GetAppId() != "app" {
+		t.Errorf("Expected a server error for app, got %v for %s", resp.GetBase().GetResponseCode(), resp.GetAppId())
+	}
+	backups := resp.GetBackups()
+	if len(backups) != 1 || backups[0].GetVolume() != "demo_cache" || backups[0].GetArchive() != handler.backups[0].Archive {
+		t.Errorf("Expected the archive written before the failure, got %v", backups)
+	}
+}
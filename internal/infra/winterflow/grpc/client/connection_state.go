@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"winterflow-agent/pkg/log"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// connectivityWatcher is the subset of *grpc.ClientConn needed to follow connection state
+// transitions. It allows the watcher to be driven by a fake connection in tests.
+type connectivityWatcher interface {
+	GetState() connectivity.State
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+}
+
+// connectionObserver records connection state transitions and fans them out to the registered
+// callbacks. It outlives individual connections so the counters survive reconnects.
+type connectionObserver struct {
+	mu        sync.RWMutex
+	observed  bool
+	state     connectivity.State
+	changes   uint64
+	callbacks []func(connectivity.State)
+}
+
+// onChange registers fn to be invoked on every observed state transition.
+func (o *connectionObserver) onChange(fn func(connectivity.State)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.callbacks = append(o.callbacks, fn)
+}
+
+// observe records state and notifies the callbacks when it differs from the previous state. The
+// state of the first connection is recorded without counting it as a transition.
+func (o *connectionObserver) observe(state connectivity.State) {
+	o.mu.Lock()
+	if !o.observed {
+		o.observed = true
+		o.state = state
+		o.mu.Unlock()
+		return
+	}
+	if o.state == state {
+		o.mu.Unlock()
+		return
+	}
+	o.state = state
+	o.changes++
+	callbacks := append([]func(connectivity.State){}, o.callbacks...)
+	o.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(state)
+	}
+}
+
+// snapshot returns the last observed state and the number of transitions seen so far.
+func (o *connectionObserver) snapshot() (connectivity.State, uint64) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.state, o.changes
+}
+
+// watchConnectivity reports the current state of conn and every subsequent transition to observe
+// until the connection is shut down or ctx is cancelled.
+func watchConnectivity(ctx context.Context, conn connectivityWatcher, observe func(connectivity.State)) {
+	state := conn.GetState()
+	observe(state)
+	for state != connectivity.Shutdown {
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+		state = conn.GetState()
+		observe(state)
+	}
+}
+
+// OnConnectionStateChange registers fn to be called whenever the gRPC connection changes its
+// connectivity state, including transitions caused by reconnects. Transitions that happened before
+// fn was registered are not replayed. Callbacks run on the watcher goroutine and should return quickly.
+func (c *Client) OnConnectionStateChange(fn func(connectivity.State)) {
+	c.connObserver.onChange(fn)
+}
+
+// ConnectionState returns the most recently observed connectivity state.
+func (c *Client) ConnectionState() connectivity.State {
+	state, _ := c.connObserver.snapshot()
+	return state
+}
+
+// ConnectionStateChanges returns the number of connectivity state transitions observed since the
+// client was created. A fast growing value indicates a flapping connection.
+func (c *Client) ConnectionStateChanges() uint64 {
+	_, changes := c.connObserver.snapshot()
+	return changes
+}
+
+// logConnectionState logs a connectivity state transition of the server connection.
+func logConnectionState(state connectivity.State) {
+	log.Info("Server connection state changed", "state", state.String())
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// fakeConn replays a fixed sequence of connectivity states. Each WaitForStateChange call advances
+// to the next state; once the sequence is exhausted it blocks until the context is cancelled.
+type fakeConn struct {
+	states []connectivity.State
+	index  int
+}
+
+func (f *fakeConn) GetState() connectivity.State {
+	return f.states[f.index]
+}
+
+func (f *fakeConn) WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool {
+	if f.index+1 < len(f.states) {
+		f.index++
+		return true
+	}
+	<-ctx.Done()
+	return false
+}
+
+func TestWatchConnectivityReportsTransitions(t *testing.T) {
+	conn := &fakeConn{states: []connectivity.State{
+		connectivity.Idle,
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.TransientFailure,
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.Shutdown,
+	}}
+
+	var observer connectionObserver
+	var got []connectivity.State
+	observer.onChange(func(state connectivity.State) { got = append(got, state) })
+
+	// The watcher must stop on its own once the connection is shut down.
+	watchConnectivity(context.Background(), conn, observer.observe)
+
+	// The initial state is not a transition.
+	if expected := conn.states[1:]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected transitions %v, got %v", expected, got)
+	}
+	state, changes := observer.snapshot()
+	if state != connectivity.Shutdown || changes != uint64(len(conn.states)-1) {
+		t.Errorf("Unexpected snapshot: state %v, changes %d", state, changes)
+	}
+}
+
+func TestWatchConnectivityStopsOnCancel(t *testing.T) {
+	conn := &fakeConn{states: []connectivity.State{connectivity.Connecting, connectivity.Ready}}
+	var observer connectionObserver
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchConnectivity(ctx, conn, observer.observe)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected watcher to stop after context cancellation")
+	}
+	if state, _ := observer.snapshot(); state != connectivity.Ready {
+		t.Errorf("Expected last state %v, got %v", connectivity.Ready, state)
+	}
+}
+
+func TestConnectionObserverIgnoresRepeatedState(t *testing.T) {
+	var observer connectionObserver
+	calls := 0
+	observer.onChange(func(connectivity.State) { calls++ })
+
+	// Connections replaced on reconnect report the same state again; that is not a transition.
+	for _, state := range []connectivity.State{connectivity.Ready, connectivity.Ready, connectivity.Idle, connectivity.Idle} {
+		observer.observe(state)
+	}
+
+	if _, changes := observer.snapshot(); changes != 1 || calls != 1 {
+		t.Errorf("Expected 1 transition, got %d changes and %d callback calls", changes, calls)
+	}
+}
+
+func TestConnectionObserverRecordsInitialState(t *testing.T) {
+	var observer connectionObserver
+	calls := 0
+	observer.onChange(func(connectivity.State) { calls++ })
+
+	observer.observe(connectivity.Connecting)
+
+	state, changes := observer.snapshot()
+	if state != connectivity.Connecting || changes != 0 || calls != 0 {
+		t.Errorf("Expected state %v without transitions, got %v with %d changes and %d callback calls", connectivity.Connecting, state, changes, calls)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/domain/repository"
+	"winterflow-agent/pkg/log"
+)
+
+// connectionStatsRefreshInterval limits how often an established connection refreshes the persisted
+// statistics, which bounds the uptime lost when the agent is killed.
+const connectionStatsRefreshInterval = time.Minute
+
+// agentStoppedError is recorded for a connection that was still open when the agent last stopped.
+const agentStoppedError = "agent stopped"
+
+// connectionStatsRecorder keeps the statistics of the connection to the server and persists every change.
+// A nil recorder ignores all events.
+type connectionStatsRecorder struct {
+	mu        sync.Mutex
+	repo      repository.ConnectionStatsRepository
+	now       func() time.Time
+	stats     model.ConnectionStats
+	lastSaved time.Time
+}
+
+// newConnectionStatsRecorder continues the statistics persisted in repo. A connection that was open when the
+// agent stopped is closed at the time of its last refresh.
+func newConnectionStatsRecorder(repo repository.ConnectionStatsRepository, now func() time.Time) *connectionStatsRecorder {
+	r := &connectionStatsRecorder{repo: repo, now: now}
+	stats, err := repo.Load()
+	if err != nil {
+		log.Warn("Failed to load connection stats, starting over", "error", err)
+	}
+	r.stats = stats
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stats.ConnectedSince.IsZero() {
+		r.stats.LastError = agentStoppedError
+		r.stats.LastErrorAt = r.stats.UpdatedAt
+		r.closeConnection(r.stats.UpdatedAt)
+		r.save()
+	}
+	return r
+}
+
+// connected records that a stream to the server has been established. A connection that is still open
+// was replaced without being reported lost and is closed first.
+func (r *connectionStatsRecorder) connected() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if !r.stats.ConnectedSince.IsZero() {
+		r.closeConnection(now)
+	}
+	r.stats.ConnectedSince = now
+	r.save()
+}
+
+// disconnected records that the stream to the server has been lost. It is a no-op while disconnected.
+func (r *connectionStatsRecorder) disconnected() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stats.ConnectedSince.IsZero() {
+		return
+	}
+	r.closeConnection(r.now())
+	r.save()
+}
+
+// failed records err as the reason of the next disconnect.
+func (r *connectionStatsRecorder) failed(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.LastError = err.Error()
+	r.stats.LastErrorAt = r.now()
+	r.save()
+}
+
+// refresh persists the statistics of an established connection at most every connectionStatsRefreshInterval.
+func (r *connectionStatsRecorder) refresh() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.now().Sub(r.lastSaved) < connectionStatsRefreshInterval {
+		return
+	}
+	r.save()
+}
+
+// snapshot returns a copy of the current statistics.
+func (r *connectionStatsRecorder) snapshot() model.ConnectionStats {
+	if r == nil {
+		return model.ConnectionStats{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats
+	stats.RecentDisconnects = append([]model.ConnectionDisconnect(nil), r.stats.RecentDisconnects...)
+	return stats
+}
+
+// closeConnection ends the current connection at the given time. The caller must hold mu.
+func (r *connectionStatsRecorder) closeConnection(at time.Time) {
+	uptime := at.Sub(r.stats.ConnectedSince)
+	if uptime < 0 {
+		uptime = 0
+	}
+	disconnect := model.ConnectionDisconnect{At: at, UptimeSeconds: int64(uptime / time.Second)}
+	if !r.stats.LastErrorAt.Before(r.stats.ConnectedSince) {
+		disconnect.Error = r.stats.LastError
+	}
+
+	r.stats.TotalUptimeSeconds += disconnect.UptimeSeconds
+	r.stats.DisconnectCount++
+	r.stats.LastDisconnectAt = at
+	r.stats.ConnectedSince = time.Time{}
+	r.stats.RecentDisconnects = append(r.stats.RecentDisconnects, disconnect)
+	if excess := len(r.stats.RecentDisconnects) - model.MaxRecentDisconnects; excess > 0 {
+		r.stats.RecentDisconnects = r.stats.RecentDisconnects[excess:]
+	}
+}
+
+// save persists the statistics; failures are logged as they must not affect the connection. The caller
+// must hold mu.
+func (r *connectionStatsRecorder) save() {
+	now := r.now()
+	r.stats.UpdatedAt = now
+	r.lastSaved = now
+	if err := r.repo.Save(r.stats); err != nil {
+		log.Warn("Failed to persist connection stats", "error", err)
+	}
+}
+
+// ConnectionStats returns the statistics of the connection to the server, including the connections of
+// previous runs of the agent.
+func (c *Client) ConnectionStats() model.ConnectionStats {
+	return c.connStats.snapshot()
+}
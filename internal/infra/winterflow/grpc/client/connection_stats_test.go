@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/infra/connection_stats"
+)
+
+func TestConnectionStatsRecorderPersistsAcrossRestarts(t *testing.T) {
+	repo := connection_stats.NewConnectionStatsRepository(filepath.Join(t.TempDir(), "connection_stats.json"))
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+
+	recorder := newConnectionStatsRecorder(repo, now)
+	recorder.disconnected()
+	recorder.connected()
+	clock = clock.Add(10 * time.Minute)
+	recorder.failed(errors.New("stream closed"))
+	recorder.disconnected()
+	clock = clock.Add(time.Minute)
+	recorder.connected()
+	clock = clock.Add(5 * time.Minute)
+	recorder.refresh()
+	clock = clock.Add(30 * time.Second)
+
+	// The agent restarts while connected; the open connection ends at its last refresh.
+	restarted := newConnectionStatsRecorder(repo, now)
+	stats := restarted.snapshot()
+	if stats.DisconnectCount != 2 {
+		t.Errorf("Expected 2 disconnects, got %d", stats.DisconnectCount)
+	}
+	if stats.TotalUptimeSeconds != 15*60 {
+		t.Errorf("Expected 900s of uptime, got %d", stats.TotalUptimeSeconds)
+	}
+	if !stats.ConnectedSince.IsZero() {
+		t.Errorf("Expected no open connection after a restart, got %v", stats.ConnectedSince)
+	}
+	if len(stats.RecentDisconnects) != 2 ||
+		stats.RecentDisconnects[0].Error != "stream closed" || stats.RecentDisconnects[0].UptimeSeconds != 600 ||
+		stats.RecentDisconnects[1].Error != agentStoppedError || stats.RecentDisconnects[1].UptimeSeconds != 300 {
+		t.Errorf("Unexpected recent disconnects: %+v", stats.RecentDisconnects)
+	}
+
+	persisted, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if persisted.DisconnectCount != 2 || persisted.LastError != agentStoppedError {
+		t.Errorf("Expected the restart to be persisted, got %+v", persisted)
+	}
+}
+
+func TestConnectionStatsRecorderKeepsRecentDisconnects(t *testing.T) {
+	repo := connection_stats.NewConnectionStatsRepository(filepath.Join(t.TempDir(), "connection_stats.json"))
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newConnectionStatsRecorder(repo, func() time.Time { return clock })
+
+	for i := 0; i < 15; i++ {
+		recorder.connected()
+		clock = clock.Add(time.Duration(i+1) * time.Second)
+	}
+	recorder.disconnected()
+
+	stats := recorder.snapshot()
+	if stats.DisconnectCount != 15 || len(stats.RecentDisconnects) != 10 {
+		t.Fatalf("Expected 15 disconnects with 10 recent ones, got %d and %d", stats.DisconnectCount, len(stats.RecentDisconnects))
+	}
+	if first := stats.RecentDisconnects[0].UptimeSeconds; first != 6 {
+		t.Errorf("Expected the oldest kept disconnect to have lasted 6s, got %d", first)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMaximumConnectionTimeout bounds the connection timeout after consecutive failed attempts.
+const DefaultMaximumConnectionTimeout = 5 * time.Minute
+
+// connectionTimeout is the per-attempt timeout of connection attempts. It doubles after every failed
+// attempt up to its maximum, so that slow networks (e.g. satellite or congested mobile links) are
+// eventually given enough time to complete the handshake, and falls back to its minimum once an attempt
+// succeeds. It complements the reconnect backoff, which spaces attempts rather than bounding them. The
+// zero value uses DefaultConnectionTimeout and DefaultMaximumConnectionTimeout.
+type connectionTimeout struct {
+	mu      sync.Mutex
+	min     time.Duration
+	max     time.Duration
+	current time.Duration
+}
+
+// setBounds sets the minimum and maximum timeout and restarts from the minimum. Non-positive values
+// select the defaults and a maximum below the minimum is raised to it.
+func (t *connectionTimeout) setBounds(min, max time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.min, t.max = min, max
+	t.current = t.minimum()
+}
+
+func (t *connectionTimeout) minimum() time.Duration {
+	if t.min <= 0 {
+		return DefaultConnectionTimeout
+	}
+	return t.min
+}
+
+func (t *connectionTimeout) maximum() time.Duration {
+	max := t.max
+	if max <= 0 {
+		max = DefaultMaximumConnectionTimeout
+	}
+	if min := t.minimum(); max < min {
+		return min
+	}
+	return max
+}
+
+// get returns the timeout of the next connection attempt.
+func (t *connectionTimeout) get() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current <= 0 {
+		t.current = t.minimum()
+	}
+	return t.current
+}
+
+// failed records a failed connection attempt and returns the grown timeout.
+func (t *connectionTimeout) failed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current <= 0 {
+		t.current = t.minimum()
+	}
+	if max := t.maximum(); t.current >= max/2 {
+		t.current = max
+	} else {
+		t.current *= 2
+	}
+	return t.current
+}
+
+// succeeded records a successful connection attempt and resets the timeout to its minimum.
+func (t *connectionTimeout) succeeded() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.current = t.minimum()
+}
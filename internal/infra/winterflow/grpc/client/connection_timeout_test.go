@@ -0,0 +1,49 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConnectionTimeoutGrowsAndResets(t *testing.T) {
+	var timeout connectionTimeout
+	timeout.setBounds(10*time.Second, 50*time.Second)
+
+	if got := timeout.get(); got != 10*time.Second {
+		t.Fatalf("Expected the initial timeout to be the minimum, got %v", got)
+	}
+	for i, expected := range []time.Duration{20 * time.Second, 40 * time.Second, 50 * time.Second, 50 * time.Second} {
+		if got := timeout.failed(); got != expected {
+			t.Errorf("Failure %d: expected timeout %v, got %v", i+1, expected, got)
+		}
+	}
+	if got := timeout.get(); got != 50*time.Second {
+		t.Errorf("Expected the grown timeout to be kept, got %v", got)
+	}
+
+	timeout.succeeded()
+	if got := timeout.get(); got != 10*time.Second {
+		t.Errorf("Expected the timeout to be reset to the minimum, got %v", got)
+	}
+	if got := timeout.failed(); got != 20*time.Second {
+		t.Errorf("Expected the timeout to grow again from the minimum, got %v", got)
+	}
+}
+
+func TestConnectionTimeoutBounds(t *testing.T) {
+	var timeout connectionTimeout
+	if got := timeout.get(); got != DefaultConnectionTimeout {
+		t.Errorf("Expected the zero value to start at %v, got %v", DefaultConnectionTimeout, got)
+	}
+	for i := 0; i < 10; i++ {
+		timeout.failed()
+	}
+	if got := timeout.get(); got != DefaultMaximumConnectionTimeout {
+		t.Errorf("Expected the zero value to stop at %v, got %v", DefaultMaximumConnectionTimeout, got)
+	}
+
+	timeout.setBounds(time.Minute, time.Second)
+	if got := timeout.failed(); got != time.Minute {
+		t.Errorf("Expected a maximum below the minimum to be raised to it, got %v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"winterflow-agent/internal/application/query/stream_docker_events"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/cqrs"
+	"winterflow-agent/pkg/log"
+)
+
+// dockerEventsSubscription tracks the Docker events subscription requested by the server. At most one
+// subscription runs at a time; it ends when it is stopped, replaced or the stream it was requested on ends.
+type dockerEventsSubscription struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// replace cancels the running subscription, if any, and records cancel as the running one.
+func (s *dockerEventsSubscription) replace(cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.cancel = cancel
+}
+
+// HandleStreamDockerEventsRequest starts or stops the Docker events subscription. Batches of events are
+// sent to out as responses to the request until the subscription ends; streamDone ends it together with
+// the stream the request was received on. The last response of a subscription is marked as ended.
+func HandleStreamDockerEventsRequest(ctx context.Context, queryBus cqrs.QueryBus, subscription *dockerEventsSubscription, request *pb.StreamDockerEventsRequestV1, agentID string, streamDone <-chan struct{}, out chan<- *pb.AgentMessage) {
+	messageID := request.Base.MessageId
+	if request.Stop {
+		subscription.replace(nil)
+		out <- streamDockerEventsResponse(messageID, agentID, pb.ResponseCode_RESPONSE_CODE_SUCCESS, "Docker events subscription stopped", nil, true)
+		return
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	subscription.replace(cancel)
+	go func() {
+		select {
+		case <-streamDone:
+			cancel()
+		case <-subCtx.Done():
+		}
+	}()
+
+	go func() {
+		defer cancel()
+		query := stream_docker_events.StreamDockerEventsQuery{
+			Send: func(events []model.ContainerEvent) error {
+				msg := streamDockerEventsResponse(messageID, agentID, pb.ResponseCode_RESPONSE_CODE_SUCCESS, "Docker events", ContainerEventsToProtoDockerEventsV1(events), false)
+				select {
+				case out <- msg:
+					return nil
+				case <-subCtx.Done():
+					return subCtx.Err()
+				}
+			},
+		}
+
+		_, err := queryBus.DispatchContext(subCtx, query)
+		if err == nil || subCtx.Err() != nil {
+			// Stopped, replaced or the stream ended; nothing is left to answer.
+			return
+		}
+		log.Error("Docker events subscription failed", "error", err)
+		msg := streamDockerEventsResponse(messageID, agentID, pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR, fmt.Sprintf("Docker events subscription failed: %v", err), nil, true)
+		select {
+		case out <- msg:
+		case <-streamDone:
+		}
+	}()
+}
+
+// streamDockerEventsResponse creates a response of a Docker events subscription.
+func streamDockerEventsResponse(messageID, agentID string, code pb.ResponseCode, message string, events []*pb.DockerEventV1, ended bool) *pb.AgentMessage {
+	baseResp := createBaseResponse(messageID, agentID, code, message)
+	resp := &pb.StreamDockerEventsResponseV1{
+		Base:   &baseResp,
+		Events: events,
+		Ended:  ended,
+	}
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_StreamDockerEventsResponseV1{StreamDockerEventsResponseV1: resp},
+	}
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/log"
+)
+
+// drainPollInterval is how often Drain checks whether in-flight app commands have finished.
+const drainPollInterval = 50 * time.Millisecond
+
+// runAppCommand runs handle for the app command unless the agent is in maintenance mode, in which case
+// the maintenance response is returned instead. Commands queued before maintenance mode was enabled are
+// therefore rejected as well. Running commands are counted so that Drain can wait for them. A command
+// whose message ID was processed recently is not run again; the response of the first run is returned.
+func (c *Client) runAppCommand(command interface{}, agentID string, handle func() (*pb.AgentMessage, error)) (*pb.AgentMessage, error) {
+	messageID := extractBaseMessageFromCommand(command).GetMessageId()
+	if agentMsg, ok := c.processedCommands.response(messageID); ok {
+		log.Info("Replaying the response to an app command that was already processed", "type", fmt.Sprintf("%T", command), "messageId", messageID)
+		return agentMsg, nil
+	}
+
+	c.drainMu.Lock()
+	if agentMsg := c.maintenanceResponse(command, agentID); agentMsg != nil {
+		c.drainMu.Unlock()
+		log.Info("Rejecting queued app command in maintenance mode", "type", fmt.Sprintf("%T", command))
+		return agentMsg, nil
+	}
+	c.appCommandsInFlight++
+	c.drainMu.Unlock()
+
+	defer func() {
+		c.drainMu.Lock()
+		c.appCommandsInFlight--
+		c.drainMu.Unlock()
+	}()
+	agentMsg, err := handle()
+	if err == nil {
+		c.processedCommands.record(messageID, agentMsg)
+	}
+	return agentMsg, err
+}
+
+// inFlightAppCommands returns the number of app commands that are currently running.
+func (c *Client) inFlightAppCommands() int {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	return c.appCommandsInFlight
+}
+
+// Drain stops accepting app commands by enabling maintenance mode and waits until the running ones have
+// finished. When they do not finish within timeout the previous maintenance mode is restored and an error
+// is returned. After a successful drain, Resume restores the previous mode if the caller does not exit.
+func (c *Client) Drain(timeout time.Duration) error {
+	c.drainMu.Lock()
+	c.drainRestore = c.MaintenanceMode()
+	c.SetMaintenanceMode(true)
+	c.drainMu.Unlock()
+
+	log.Info("Draining in-flight app commands", "timeout", timeout)
+	deadline := time.Now().Add(timeout)
+	for {
+		inFlight := c.inFlightAppCommands()
+		if inFlight == 0 {
+			log.Info("Drained in-flight app commands")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			c.Resume()
+			return fmt.Errorf("%d app commands still running after %s", inFlight, timeout)
+		}
+		time.Sleep(drainPollInterval)
+	}
+}
+
+// Resume restores the maintenance mode that was active before the last Drain.
+func (c *Client) Resume() {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	c.SetMaintenanceMode(c.drainRestore)
+}
+
+// startAgentUpdate handles the update agent request in the background so that the stream receiver keeps
+// reading commands, e.g. the cancellation of an operation, while the update drains in-flight app commands.
+// The response is delivered on out unless the stream ends first. Only one update runs at a time: a
+// request received while one is running is not started and its rejection is returned instead.
+func (c *Client) startAgentUpdate(request *pb.UpdateAgentRequestV1, agentID string, streamDone <-chan struct{}, out chan<- *pb.AgentMessage) *pb.AgentMessage {
+	if !c.updating.CompareAndSwap(false, true) {
+		log.Warn("Rejecting update agent request, an update is already running", "messageId", request.Base.GetMessageId())
+		baseResp := createBaseResponse(request.Base.GetMessageId(), agentID, pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS, "An agent update is already running")
+		return &pb.AgentMessage{
+			Message: &pb.AgentMessage_UpdateAgentResponseV1{
+				UpdateAgentResponseV1: &pb.UpdateAgentResponseV1{Base: &baseResp},
+			},
+		}
+	}
+
+	go func() {
+		defer c.updating.Store(false)
+		agentMsg, err := HandleUpdateAgentRequest(c.commandBus, request, agentID)
+		if err != nil {
+			log.Error("Error handling update agent request", "error", err)
+			return
+		}
+		select {
+		case out <- agentMsg:
+		case <-streamDone:
+			log.Warn("Stream closed before the update agent response could be sent")
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/command/update_agent"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/cqrs"
+)
+
+func TestDrainWaitsForInFlightAppCommand(t *testing.T) {
+	c := &Client{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	completed := make(chan struct{})
+
+	go func() {
+		defer close(completed)
+		_, _ = c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+			close(started)
+			<-release
+			return &pb.AgentMessage{}, nil
+		})
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- c.Drain(10 * time.Second) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Expected Drain to wait for the running command, returned %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// New app commands are rejected while draining.
+	agentMsg, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+		t.Error("Expected the app command not to run while draining")
+		return nil, nil
+	})
+	if err != nil || agentMsg.GetControlAppResponseV1().GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_MAINTENANCE {
+		t.Errorf("Expected a maintenance response, got %v (%v)", agentMsg, err)
+	}
+
+	close(release)
+	<-completed
+	if err := <-drained; err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+}
+
+func TestDrainTimeoutRestoresMaintenanceMode(t *testing.T) {
+	c := &Client{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		_, _ = c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+			close(started)
+			<-release
+			return &pb.AgentMessage{}, nil
+		})
+	}()
+	<-started
+
+	if err := c.Drain(100 * time.Millisecond); err == nil {
+		t.Fatal("Expected Drain to time out")
+	}
+	if c.MaintenanceMode() {
+		t.Error("Expected app commands to be accepted again after a failed drain")
+	}
+}
+
+func TestResumeKeepsExplicitMaintenanceMode(t *testing.T) {
+	c := &Client{}
+	c.SetMaintenanceMode(true)
+
+	if err := c.Drain(time.Second); err != nil {
+		t.Fatalf("Drain returned error: %v", err)
+	}
+	c.Resume()
+	if !c.MaintenanceMode() {
+		t.Error("Expected maintenance mode enabled before the drain to stay enabled")
+	}
+}
+
+// drainingUpdateHandler drains the client like the update agent handler does before it replaces the binary.
+type drainingUpdateHandler struct {
+	client *Client
+}
+
+func (h *drainingUpdateHandler) Handle(update_agent.UpdateAgentCommand) error {
+	return h.client.Drain(10 * time.Second)
+}
+
+func TestAgentUpdateDrainsInTheBackground(t *testing.T) {
+	bus := cqrs.NewCommandBus(t.Context())
+	c := &Client{commandBus: bus}
+	if err := bus.Register(&drainingUpdateHandler{client: c}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+			close(started)
+			<-release
+			return &pb.AgentMessage{}, nil
+		})
+	}()
+	<-started
+
+	streamDone := make(chan struct{})
+	out := make(chan *pb.AgentMessage, 1)
+	request := &pb.UpdateAgentRequestV1{Base: &pb.BaseMessage{MessageId: "update-1"}}
+	if agentMsg := c.startAgentUpdate(request, maintenanceTestAgentID, streamDone, out); agentMsg != nil {
+		t.Fatalf("Expected the update to be started, got %v", agentMsg)
+	}
+	for !c.MaintenanceMode() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The receiver keeps handling commands while the update waits for the running app command.
+	second := &pb.UpdateAgentRequestV1{Base: &pb.BaseMessage{MessageId: "update-2"}}
+	agentMsg := c.startAgentUpdate(second, maintenanceTestAgentID, streamDone, out)
+	if agentMsg.GetUpdateAgentResponseV1().GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_TOO_MANY_REQUESTS {
+		t.Errorf("Expected a second update to be rejected while the first runs, got %v", agentMsg)
+	}
+	agentMsg, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+		t.Error("Expected the app command not to run while draining")
+		return nil, nil
+	})
+	if err != nil || agentMsg.GetControlAppResponseV1().GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_MAINTENANCE {
+		t.Errorf("Expected a maintenance response during the drain, got %v (%v)", agentMsg, err)
+	}
+	select {
+	case agentMsg := <-out:
+		t.Fatalf("Expected the update to wait for the running app command, got %v", agentMsg)
+	default:
+	}
+
+	close(release)
+	select {
+	case agentMsg := <-out:
+		base := agentMsg.GetUpdateAgentResponseV1().GetBase()
+		if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SUCCESS || base.GetMessageId() != "update-1" {
+			t.Errorf("Unexpected update agent response: %+v", base)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the update agent response after the drain")
+	}
+}
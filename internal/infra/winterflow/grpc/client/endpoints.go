@@ -0,0 +1,47 @@
+package client
+
+import (
+	"net"
+	"strings"
+)
+
+// parseServerAddresses splits a comma-separated list of gRPC endpoints into
+// individual addresses, in the order they should be tried. Empty entries are
+// dropped and bare IPv6 literals are wrapped in brackets so that they can be
+// combined with a port and passed to the gRPC resolver unchanged.
+func parseServerAddresses(raw string) []string {
+	var addresses []string
+	for _, part := range strings.Split(raw, ",") {
+		address := strings.TrimSpace(part)
+		if address == "" {
+			continue
+		}
+		if ip := net.ParseIP(address); ip != nil && strings.Contains(address, ":") {
+			address = "[" + address + "]"
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// serverNameFromAddress extracts the host part of an endpoint for TLS server
+// name verification. It accepts "host:port", "[ipv6]:port", "[ipv6]" and a
+// bare host without a port.
+func serverNameFromAddress(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
+
+// rotateServerAddress advances to the next configured endpoint, wrapping
+// around at the end of the list, and returns the newly selected address.
+// With a single endpoint the address never changes.
+func (c *Client) rotateServerAddress() string {
+	if len(c.serverAddresses) > 1 {
+		c.serverIndex = (c.serverIndex + 1) % len(c.serverAddresses)
+		c.serverAddress = c.serverAddresses[c.serverIndex]
+	}
+	return c.serverAddress
+}
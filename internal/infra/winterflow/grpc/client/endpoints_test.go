@@ -0,0 +1,79 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseServerAddresses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected []string
+	}{
+		{
+			name:     "Single endpoint",
+			raw:      "grpc.winterflow.io:50051",
+			expected: []string{"grpc.winterflow.io:50051"},
+		},
+		{
+			name:     "Multiple endpoints with spaces",
+			raw:      "a.example.com:50051, b.example.com:50051 ,,c.example.com:50051",
+			expected: []string{"a.example.com:50051", "b.example.com:50051", "c.example.com:50051"},
+		},
+		{
+			name:     "Bracketed IPv6 endpoint",
+			raw:      "[2001:db8::1]:50051",
+			expected: []string{"[2001:db8::1]:50051"},
+		},
+		{
+			name:     "Bare IPv6 endpoint",
+			raw:      "2001:db8::1",
+			expected: []string{"[2001:db8::1]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseServerAddresses(tc.raw)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestServerNameFromAddress(t *testing.T) {
+	testCases := map[string]string{
+		"grpc.winterflow.io:50051": "grpc.winterflow.io",
+		"grpc.winterflow.io":       "grpc.winterflow.io",
+		"[2001:db8::1]:50051":      "2001:db8::1",
+		"[2001:db8::1]":            "2001:db8::1",
+		"10.0.0.1:50051":           "10.0.0.1",
+	}
+
+	for address, expected := range testCases {
+		if got := serverNameFromAddress(address); got != expected {
+			t.Errorf("serverNameFromAddress(%q): expected %q, got %q", address, expected, got)
+		}
+	}
+}
+
+func TestRotateServerAddressFailoverOrder(t *testing.T) {
+	addresses := []string{"a.example.com:50051", "b.example.com:50051", "c.example.com:50051"}
+	c := &Client{serverAddresses: addresses, serverAddress: addresses[0]}
+
+	expected := []string{"b.example.com:50051", "c.example.com:50051", "a.example.com:50051", "b.example.com:50051"}
+	for i, want := range expected {
+		if got := c.rotateServerAddress(); got != want {
+			t.Fatalf("Rotation %d: expected %q, got %q", i+1, want, got)
+		}
+	}
+}
+
+func TestRotateServerAddressSingleEndpoint(t *testing.T) {
+	c := &Client{serverAddresses: []string{"a.example.com:50051"}, serverAddress: "a.example.com:50051"}
+	if got := c.rotateServerAddress(); got != "a.example.com:50051" {
+		t.Errorf("Expected the single endpoint to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+const (
+	// heartbeatRTTSamples is the number of recent round-trip times kept for the statistics.
+	heartbeatRTTSamples = 20
+	// heartbeatResponseTimeout drops pending heartbeats that were not answered in time; they are counted as lost.
+	heartbeatResponseTimeout = 3 * HeartbeatInterval
+)
+
+// HeartbeatRTTStats summarises the round-trip times of recently answered heartbeats.
+type HeartbeatRTTStats struct {
+	// Last is the round-trip time of the most recently answered heartbeat.
+	Last time.Duration
+	// Min, Max and Avg are computed over the last Samples answered heartbeats.
+	Min time.Duration
+	Max time.Duration
+	Avg time.Duration
+	// Samples is the number of round-trip times the statistics are based on.
+	Samples int
+	// Lost counts heartbeats that were not answered within heartbeatResponseTimeout.
+	Lost uint64
+}
+
+// heartbeatRTTTracker correlates sent heartbeats with their responses by message ID. Like the
+// connection observer it outlives individual streams, so the statistics survive reconnects.
+type heartbeatRTTTracker struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	pending map[string]time.Time
+	samples []time.Duration
+	next    int
+	last    time.Duration
+	lost    uint64
+}
+
+func (t *heartbeatRTTTracker) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+// sent records that the heartbeat with messageID is about to be sent and expires pending
+// heartbeats that were not answered in time.
+func (t *heartbeatRTTTracker) sent(messageID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock()
+	if t.pending == nil {
+		t.pending = make(map[string]time.Time)
+	}
+	for id, sentAt := range t.pending {
+		if now.Sub(sentAt) > heartbeatResponseTimeout {
+			delete(t.pending, id)
+			t.lost++
+		}
+	}
+	t.pending[messageID] = now
+}
+
+// forget drops the pending heartbeat with messageID, e.g. because it could not be sent.
+func (t *heartbeatRTTTracker) forget(messageID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, messageID)
+}
+
+// received records the response to the heartbeat with messageID and returns its round-trip time.
+// Unknown or expired message IDs are ignored.
+func (t *heartbeatRTTTracker) received(messageID string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sentAt, ok := t.pending[messageID]
+	if !ok {
+		return 0, false
+	}
+	delete(t.pending, messageID)
+
+	rtt := t.clock().Sub(sentAt)
+	if len(t.samples) < heartbeatRTTSamples {
+		t.samples = append(t.samples, rtt)
+	} else {
+		t.samples[t.next] = rtt
+		t.next = (t.next + 1) % heartbeatRTTSamples
+	}
+	t.last = rtt
+	return rtt, true
+}
+
+// stats returns the statistics over the recorded round-trip times.
+func (t *heartbeatRTTTracker) stats() HeartbeatRTTStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := HeartbeatRTTStats{Last: t.last, Samples: len(t.samples), Lost: t.lost}
+	if len(t.samples) == 0 {
+		return s
+	}
+	var total time.Duration
+	s.Min = t.samples[0]
+	for _, rtt := range t.samples {
+		total += rtt
+		s.Min = min(s.Min, rtt)
+		s.Max = max(s.Max, rtt)
+	}
+	s.Avg = total / time.Duration(len(t.samples))
+	return s
+}
+
+// heartbeatSender is the part of the agent stream used to send heartbeats.
+type heartbeatSender interface {
+	Send(*pb.AgentMessage) error
+}
+
+// sendHeartbeat sends a heartbeat on stream and starts measuring its round-trip time.
+func (c *Client) sendHeartbeat(stream heartbeatSender, agentID string) error {
+	baseMsg := &pb.BaseMessage{
+		MessageId: GenerateUUID(),
+		Timestamp: TimestampNow(),
+		AgentId:   agentID,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_HeartbeatV1{
+			HeartbeatV1: &pb.AgentHeartbeatV1{
+				Base: baseMsg,
+			},
+		},
+	}
+
+	c.heartbeatRTT.sent(baseMsg.MessageId)
+	if err := stream.Send(agentMsg); err != nil {
+		c.heartbeatRTT.forget(baseMsg.MessageId)
+		return err
+	}
+	return nil
+}
+
+// HeartbeatRTT returns the round-trip time statistics of recent heartbeats.
+func (c *Client) HeartbeatRTT() HeartbeatRTTStats {
+	return c.heartbeatRTT.stats()
+}
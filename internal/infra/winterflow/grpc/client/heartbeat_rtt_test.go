@@ -0,0 +1,102 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+// echoStream is a fake agent stream that answers every heartbeat once its delay has elapsed on a
+// fake clock shared with the tracker.
+type echoStream struct {
+	clock   *time.Time
+	delay   time.Duration
+	err     error
+	replies []*pb.AgentHeartbeatResponseV1
+}
+
+func (s *echoStream) Send(msg *pb.AgentMessage) error {
+	if s.err != nil {
+		return s.err
+	}
+	*s.clock = s.clock.Add(s.delay)
+	base := msg.GetHeartbeatV1().GetBase()
+	s.replies = append(s.replies, &pb.AgentHeartbeatResponseV1{Base: &pb.BaseResponse{MessageId: base.MessageId}})
+	return nil
+}
+
+// newRTTClient returns a client whose heartbeat tracker uses the returned fake clock.
+func newRTTClient() (*Client, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	c := &Client{}
+	c.heartbeatRTT.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestHeartbeatRTTCorrelatesResponses(t *testing.T) {
+	c, clock := newRTTClient()
+	stream := &echoStream{clock: clock}
+
+	for _, delay := range []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond} {
+		stream.delay = delay
+		if err := c.sendHeartbeat(stream, "agent-1"); err != nil {
+			t.Fatalf("sendHeartbeat returned error: %v", err)
+		}
+		reply := stream.replies[len(stream.replies)-1]
+		if rtt, ok := c.heartbeatRTT.received(reply.Base.MessageId); !ok || rtt != delay {
+			t.Fatalf("Expected response %s to match a heartbeat sent %v ago, got %v (%v)", reply.Base.MessageId, delay, rtt, ok)
+		}
+	}
+
+	stats := c.HeartbeatRTT()
+	if stats.Samples != 3 || stats.Lost != 0 {
+		t.Fatalf("Unexpected sample counts: %+v", stats)
+	}
+	if stats.Min != 10*time.Millisecond || stats.Max != 50*time.Millisecond || stats.Avg != 30*time.Millisecond || stats.Last != 50*time.Millisecond {
+		t.Errorf("Unexpected statistics: %+v", stats)
+	}
+}
+
+func TestHeartbeatRTTIgnoresUnknownResponses(t *testing.T) {
+	c, _ := newRTTClient()
+	if _, ok := c.heartbeatRTT.received("unknown"); ok {
+		t.Error("Expected an unknown message ID to be ignored")
+	}
+	if stats := c.HeartbeatRTT(); stats.Samples != 0 {
+		t.Errorf("Expected no samples, got %+v", stats)
+	}
+}
+
+func TestHeartbeatRTTExpiresUnansweredHeartbeats(t *testing.T) {
+	c, clock := newRTTClient()
+	stream := &echoStream{clock: clock}
+
+	if err := c.sendHeartbeat(stream, "agent-1"); err != nil {
+		t.Fatalf("sendHeartbeat returned error: %v", err)
+	}
+	*clock = clock.Add(heartbeatResponseTimeout + time.Second)
+	if err := c.sendHeartbeat(stream, "agent-1"); err != nil {
+		t.Fatalf("sendHeartbeat returned error: %v", err)
+	}
+
+	if _, ok := c.heartbeatRTT.received(stream.replies[0].Base.MessageId); ok {
+		t.Error("Expected the late response to be ignored")
+	}
+	if stats := c.HeartbeatRTT(); stats.Lost != 1 {
+		t.Errorf("Expected one lost heartbeat, got %+v", stats)
+	}
+}
+
+func TestHeartbeatRTTForgetsFailedSends(t *testing.T) {
+	c, clock := newRTTClient()
+	stream := &echoStream{clock: clock, err: errors.New("stream closed")}
+
+	if err := c.sendHeartbeat(stream, "agent-1"); err == nil {
+		t.Fatal("Expected the send error to be returned")
+	}
+	if pending := len(c.heartbeatRTT.pending); pending != 0 {
+		t.Errorf("Expected no pending heartbeats, got %d", pending)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+const (
+	// processedCommandsSize caps the number of responses to app commands kept to answer retries.
+	processedCommandsSize = 256
+	// processedCommandsTTL is how long the response to an app command is replayed for a retry of the command.
+	processedCommandsTTL = 15 * time.Minute
+)
+
+// processedCommand is the response to an app command and when it was sent.
+type processedCommand struct {
+	messageID string
+	response  *pb.AgentMessage
+	at        time.Time
+}
+
+// processedCommands remembers the responses to recently processed app commands by message ID, the
+// idempotency key of a command, so that a command the server delivers again, e.g. after a timeout, is
+// answered with the response of the first delivery instead of being executed twice. Like the heartbeat
+// tracker it outlives individual streams, so retries after a reconnect are recognized as well. The zero
+// value is ready to use.
+type processedCommands struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries []processedCommand
+}
+
+func (p *processedCommands) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// response returns the response recorded for messageID when the command was processed less than
+// processedCommandsTTL ago.
+func (p *processedCommands) response(messageID string) (*pb.AgentMessage, bool) {
+	if messageID == "" {
+		return nil, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expire()
+	for _, entry := range p.entries {
+		if entry.messageID == messageID {
+			return entry.response, true
+		}
+	}
+	return nil, false
+}
+
+// record remembers response as the response to the command with messageID. The oldest responses are
+// forgotten once more than processedCommandsSize are kept. Commands without a message ID are not recorded.
+func (p *processedCommands) record(messageID string, response *pb.AgentMessage) {
+	if messageID == "" || response == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.expire()
+	p.entries = append(p.entries, processedCommand{messageID: messageID, response: response, at: p.clock()})
+	if excess := len(p.entries) - processedCommandsSize; excess > 0 {
+		p.entries = append(p.entries[:0], p.entries[excess:]...)
+	}
+}
+
+// expire drops the responses recorded processedCommandsTTL or longer ago. Entries are kept in the order
+// they were recorded, so the expired ones are at the front.
+func (p *processedCommands) expire() {
+	cutoff := p.clock().Add(-processedCommandsTTL)
+	expired := 0
+	for expired < len(p.entries) && !p.entries[expired].at.After(cutoff) {
+		expired++
+	}
+	if expired > 0 {
+		p.entries = append(p.entries[:0], p.entries[expired:]...)
+	}
+}
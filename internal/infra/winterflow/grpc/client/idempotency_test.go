@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+// controlAppCommandWithID returns a control app command with the message ID messageID.
+func controlAppCommandWithID(messageID string) *pb.ServerCommand_ControlAppRequestV1 {
+	command := controlAppCommand()
+	command.ControlAppRequestV1.Base.MessageId = messageID
+	return command
+}
+
+// countingHandle returns a handler of app commands that counts its runs and answers with a new response each run.
+func countingHandle(runs *int) func() (*pb.AgentMessage, error) {
+	return func() (*pb.AgentMessage, error) {
+		*runs++
+		return &pb.AgentMessage{Message: &pb.AgentMessage_ControlAppResponseV1{ControlAppResponseV1: &pb.ControlAppResponseV1{
+			Base: &pb.BaseResponse{Message: fmt.Sprintf("run %d", *runs)},
+		}}}, nil
+	}
+}
+
+func TestDuplicateAppCommandIsNotExecutedTwice(t *testing.T) {
+	c := &Client{}
+	runs := 0
+
+	first, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, countingHandle(&runs))
+	if err != nil {
+		t.Fatalf("runAppCommand returned error: %v", err)
+	}
+	second, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, countingHandle(&runs))
+	if err != nil {
+		t.Fatalf("runAppCommand returned error: %v", err)
+	}
+
+	if runs != 1 {
+		t.Errorf("Expected the duplicate not to be executed, ran %d times", runs)
+	}
+	if second != first {
+		t.Errorf("Expected the response of the first run to be replayed, got %v", second)
+	}
+
+	if _, err := c.runAppCommand(controlAppCommandWithID("msg-2"), maintenanceTestAgentID, countingHandle(&runs)); err != nil {
+		t.Fatalf("runAppCommand returned error: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected a command with another message ID to be executed, ran %d times", runs)
+	}
+}
+
+func TestAppCommandsWithoutMessageIDAreAlwaysExecuted(t *testing.T) {
+	c := &Client{}
+	runs := 0
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.runAppCommand(controlAppCommandWithID(""), maintenanceTestAgentID, countingHandle(&runs)); err != nil {
+			t.Fatalf("runAppCommand returned error: %v", err)
+		}
+	}
+	if runs != 2 {
+		t.Errorf("Expected both commands to be executed, ran %d times", runs)
+	}
+}
+
+func TestRejectedAndFailedAppCommandsAreNotRecorded(t *testing.T) {
+	c := &Client{}
+	runs := 0
+
+	c.SetMaintenanceMode(true)
+	if _, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, countingHandle(&runs)); err != nil {
+		t.Fatalf("runAppCommand returned error: %v", err)
+	}
+	c.SetMaintenanceMode(false)
+
+	_, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, func() (*pb.AgentMessage, error) {
+		runs++
+		return nil, errors.New("stream closed")
+	})
+	if err == nil {
+		t.Fatal("Expected the error of the handler to be returned")
+	}
+
+	if _, err := c.runAppCommand(controlAppCommand(), maintenanceTestAgentID, countingHandle(&runs)); err != nil {
+		t.Fatalf("runAppCommand returned error: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected the retry to be executed after a rejection and a failure, ran %d times", runs)
+	}
+}
+
+func TestProcessedCommandsExpire(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	p := &processedCommands{now: func() time.Time { return now }}
+	p.record("msg-1", &pb.AgentMessage{})
+
+	now = now.Add(processedCommandsTTL - time.Second)
+	if _, ok := p.response("msg-1"); !ok {
+		t.Fatal("Expected the response to be kept within the TTL")
+	}
+	now = now.Add(time.Second)
+	if _, ok := p.response("msg-1"); ok {
+		t.Error("Expected the response to expire after the TTL")
+	}
+}
+
+func TestProcessedCommandsAreBounded(t *testing.T) {
+	p := &processedCommands{}
+	for i := 0; i <= processedCommandsSize; i++ {
+		p.record(fmt.Sprintf("msg-%d", i), &pb.AgentMessage{})
+	}
+
+	if len(p.entries) != processedCommandsSize {
+		t.Errorf("Expected %d responses to be kept, got %d", processedCommandsSize, len(p.entries))
+	}
+	if _, ok := p.response("msg-0"); ok {
+		t.Error("Expected the oldest response to be forgotten")
+	}
+	if _, ok := p.response(fmt.Sprintf("msg-%d", processedCommandsSize)); !ok {
+		t.Error("Expected the newest response to be kept")
+	}
+}
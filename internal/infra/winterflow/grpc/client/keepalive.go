@@ -0,0 +1,16 @@
+package client
+
+import (
+	"winterflow-agent/internal/application/config"
+
+	"google.golang.org/grpc/keepalive"
+)
+
+// keepaliveParams returns the keep-alive settings of the connection to the server configured by cfg.
+func keepaliveParams(cfg *config.Config) keepalive.ClientParameters {
+	return keepalive.ClientParameters{
+		Time:                cfg.GetKeepaliveTime(),    // send pings after this long without activity
+		Timeout:             cfg.GetKeepaliveTimeout(), // wait this long for a ping ack
+		PermitWithoutStream: cfg.GetKeepalivePermitWithoutStream(),
+	}
+}
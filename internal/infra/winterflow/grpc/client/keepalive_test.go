@@ -0,0 +1,37 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+)
+
+func TestKeepaliveParamsDefaults(t *testing.T) {
+	params := keepaliveParams(&config.Config{})
+
+	if params.Time != 10*time.Second || params.Timeout != 3*time.Second || !params.PermitWithoutStream {
+		t.Errorf("Expected pings every 10s with a 3s timeout without streams, got %+v", params)
+	}
+}
+
+func TestKeepaliveParamsReflectConfig(t *testing.T) {
+	permitWithoutStream := false
+	params := keepaliveParams(&config.Config{
+		KeepaliveTimeSeconds:         45,
+		KeepaliveTimeoutSeconds:      20,
+		KeepalivePermitWithoutStream: &permitWithoutStream,
+	})
+
+	if params.Time != 45*time.Second || params.Timeout != 20*time.Second || params.PermitWithoutStream {
+		t.Errorf("Expected the configured keep-alive settings, got %+v", params)
+	}
+}
+
+func TestKeepaliveParamsEnforceMinimums(t *testing.T) {
+	params := keepaliveParams(&config.Config{KeepaliveTimeSeconds: 1, KeepaliveTimeoutSeconds: -5})
+
+	if params.Time != 10*time.Second || params.Timeout != time.Second {
+		t.Errorf("Expected the minimum keep-alive time and timeout, got %+v", params)
+	}
+}
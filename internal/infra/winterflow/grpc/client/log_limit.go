@@ -0,0 +1,49 @@
+package client
+
+import (
+	"sort"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// limitAppLogs drops the oldest entries of logs, which msg carries, until msg serializes to at most maxBytes,
+// and records the dropped entries on logs. A maxBytes of 0 keeps every entry. The remaining entries keep
+// their order.
+func limitAppLogs(msg *pb.AgentMessage, logs *pb.AppLogsV1, maxBytes int) {
+	if logs == nil || maxBytes <= 0 || proto.Size(msg) <= maxBytes {
+		return
+	}
+
+	entries := logs.Logs
+	oldest := make([]int, len(entries))
+	for i := range oldest {
+		oldest[i] = i
+	}
+	sort.SliceStable(oldest, func(a, b int) bool {
+		return entries[oldest[a]].GetTimestamp().AsTime().Before(entries[oldest[b]].GetTimestamp().AsTime())
+	})
+
+	// The size shrinks with every dropped entry, so the fewest entries to drop are found by bisection.
+	drop := func(count int) {
+		dropped := make(map[int]bool, count)
+		for _, i := range oldest[:count] {
+			dropped[i] = true
+		}
+		kept := make([]*pb.LogEntryV1, 0, len(entries)-count)
+		for i, entry := range entries {
+			if !dropped[i] {
+				kept = append(kept, entry)
+			}
+		}
+		logs.Logs = kept
+		logs.Truncated = true
+		logs.DroppedCount = uint32(count)
+	}
+	count := sort.Search(len(entries), func(count int) bool {
+		drop(count)
+		return proto.Size(msg) <= maxBytes
+	})
+	drop(count)
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/log"
+)
+
+// SetMaintenanceMode pauses (enabled) or resumes the processing of app commands.
+func (c *Client) SetMaintenanceMode(enabled bool) {
+	if c.maintenance.Swap(enabled) != enabled {
+		log.Info("Maintenance mode changed", "enabled", enabled)
+	}
+}
+
+// MaintenanceMode reports whether app commands are currently paused.
+func (c *Client) MaintenanceMode() bool {
+	return c.maintenance.Load()
+}
+
+// maintenanceResponse returns the RESPONSE_CODE_MAINTENANCE response for command when the agent is in
+// maintenance mode and command is an app command. It returns nil when command must be processed.
+func (c *Client) maintenanceResponse(command interface{}, agentID string) *pb.AgentMessage {
+	if !c.MaintenanceMode() || !isAppCommand(command) {
+		return nil
+	}
+	base := extractBaseMessageFromCommand(command)
+	return buildErrorAgentMessage(command, base.GetMessageId(), agentID, pb.ResponseCode_RESPONSE_CODE_MAINTENANCE, "Agent is in maintenance mode")
+}
+
+// HandleSetMaintenanceModeRequest toggles the maintenance mode of the client and creates the response message
+func HandleSetMaintenanceModeRequest(c *Client, setMaintenanceModeRequest *pb.SetMaintenanceModeRequestV1, agentID string) *pb.AgentMessage {
+	c.SetMaintenanceMode(setMaintenanceModeRequest.Enabled)
+
+	responseMessage := "Maintenance mode disabled"
+	if setMaintenanceModeRequest.Enabled {
+		responseMessage = "Maintenance mode enabled"
+	}
+
+	baseResp := createBaseResponse(setMaintenanceModeRequest.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_SUCCESS, responseMessage)
+	resp := &pb.SetMaintenanceModeResponseV1{
+		Base:    &baseResp,
+		Enabled: c.MaintenanceMode(),
+	}
+
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_SetMaintenanceModeResponseV1{SetMaintenanceModeResponseV1: resp},
+	}
+}
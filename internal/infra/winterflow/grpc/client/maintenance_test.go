@@ -0,0 +1,69 @@
+package client
+
+import (
+	"testing"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+const maintenanceTestAgentID = "agent-1"
+
+func controlAppCommand() *pb.ServerCommand_ControlAppRequestV1 {
+	return &pb.ServerCommand_ControlAppRequestV1{ControlAppRequestV1: &pb.ControlAppRequestV1{
+		Base:  &pb.BaseMessage{MessageId: "msg-1", AgentId: maintenanceTestAgentID},
+		AppId: "app-1",
+	}}
+}
+
+func TestMaintenanceModeRejectsAppCommands(t *testing.T) {
+	c := &Client{}
+	c.SetMaintenanceMode(true)
+
+	agentMsg := c.maintenanceResponse(controlAppCommand(), maintenanceTestAgentID)
+	if agentMsg == nil {
+		t.Fatal("Expected app command to be rejected in maintenance mode")
+	}
+	resp := agentMsg.GetControlAppResponseV1()
+	if resp == nil {
+		t.Fatalf("Expected a control app response, got %T", agentMsg.Message)
+	}
+	if resp.Base.ResponseCode != pb.ResponseCode_RESPONSE_CODE_MAINTENANCE || resp.Base.MessageId != "msg-1" {
+		t.Errorf("Unexpected response base: %+v", resp.Base)
+	}
+}
+
+func TestMaintenanceModeKeepsQueriesAndHeartbeats(t *testing.T) {
+	c := &Client{}
+	c.SetMaintenanceMode(true)
+
+	commands := []interface{}{
+		&pb.ServerCommand_GetAppsStatusRequestV1{GetAppsStatusRequestV1: &pb.GetAppsStatusRequestV1{Base: &pb.BaseMessage{}}},
+		&pb.ServerCommand_HeartbeatResponseV1{HeartbeatResponseV1: &pb.AgentHeartbeatResponseV1{}},
+	}
+	for _, command := range commands {
+		if agentMsg := c.maintenanceResponse(command, maintenanceTestAgentID); agentMsg != nil {
+			t.Errorf("Expected %T to be processed in maintenance mode", command)
+		}
+	}
+}
+
+func TestMaintenanceModeResumeAcceptsAppCommands(t *testing.T) {
+	c := &Client{}
+
+	enable := &pb.SetMaintenanceModeRequestV1{Base: &pb.BaseMessage{MessageId: "msg-2"}, Enabled: true}
+	if resp := HandleSetMaintenanceModeRequest(c, enable, maintenanceTestAgentID).GetSetMaintenanceModeResponseV1(); !resp.Enabled {
+		t.Fatal("Expected maintenance mode to be enabled")
+	}
+	if c.maintenanceResponse(controlAppCommand(), maintenanceTestAgentID) == nil {
+		t.Fatal("Expected app command to be rejected while paused")
+	}
+
+	resume := &pb.SetMaintenanceModeRequestV1{Base: &pb.BaseMessage{MessageId: "msg-3"}, Enabled: false}
+	resp := HandleSetMaintenanceModeRequest(c, resume, maintenanceTestAgentID).GetSetMaintenanceModeResponseV1()
+	if resp.Enabled || resp.Base.ResponseCode != pb.ResponseCode_RESPONSE_CODE_SUCCESS {
+		t.Fatalf("Expected maintenance mode to be disabled, got %+v", resp)
+	}
+	if agentMsg := c.maintenanceResponse(controlAppCommand(), maintenanceTestAgentID); agentMsg != nil {
+		t.Error("Expected app command to be accepted after resume")
+	}
+}
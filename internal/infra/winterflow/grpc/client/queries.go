@@ -1,12 +1,26 @@
 package client
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"winterflow-agent/internal/application/query/check_for_update"
+	"winterflow-agent/internal/application/query/export_app"
+	"winterflow-agent/internal/application/query/get_agent_config"
 	"winterflow-agent/internal/application/query/get_app"
 	"winterflow-agent/internal/application/query/get_app_logs"
+	"winterflow-agent/internal/application/query/get_app_metrics"
+	"winterflow-agent/internal/application/query/get_app_resources"
+	"winterflow-agent/internal/application/query/get_app_revisions"
+	"winterflow-agent/internal/application/query/get_apps"
 	"winterflow-agent/internal/application/query/get_apps_status"
+	"winterflow-agent/internal/application/query/get_connection_stats"
 	"winterflow-agent/internal/application/query/get_networks"
 	"winterflow-agent/internal/application/query/get_registries"
+	"winterflow-agent/internal/application/query/get_rendered_compose"
+	"winterflow-agent/internal/application/query/get_system_info"
+	"winterflow-agent/internal/application/query/get_version_info"
+	"winterflow-agent/internal/application/query/validate_app"
 	"winterflow-agent/internal/domain/dto"
 	"winterflow-agent/internal/domain/model"
 	"winterflow-agent/internal/infra/winterflow/grpc/pb"
@@ -34,7 +48,7 @@ func HandleGetAppQuery(queryBus cqrs.QueryBus, getAppRequest *pb.GetAppRequestV1
 	result, err := queryBus.Dispatch(query)
 	if err != nil {
 		log.Error("Error retrieving app", "error", err)
-		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseCode = queryErrorResponseCode(err)
 		responseMessage = fmt.Sprintf("Error retrieving app: %v", err)
 	} else {
 		// Type assertion to get the app data along with revisions
@@ -70,6 +84,48 @@ func HandleGetAppQuery(queryBus cqrs.QueryBus, getAppRequest *pb.GetAppRequestV1
 	return agentMsg, nil
 }
 
+// HandleGetAppsQuery handles the query dispatch and creates the appropriate response message
+func HandleGetAppsQuery(queryBus cqrs.QueryBus, getAppsRequest *pb.GetAppsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get apps request", "app_ids", len(getAppsRequest.AppIds), "page_token", getAppsRequest.PageToken)
+
+	query := get_apps.GetAppsQuery{
+		AppIDs:    getAppsRequest.AppIds,
+		PageToken: getAppsRequest.PageToken,
+	}
+
+	var responseCode = pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	var responseMessage = "Apps retrieved successfully"
+	var apps []*pb.AppDetailsV1
+	var nextPageToken string
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving apps", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving apps: %v", err)
+	} else if page, ok := result.(*model.AppsPage); !ok {
+		log.Error("Error retrieving apps: unexpected result type")
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = "Error retrieving apps: unexpected result type"
+	} else {
+		for _, details := range page.Apps {
+			apps = append(apps, AppDetailsToProtoAppDetailsV1(details))
+		}
+		nextPageToken = page.NextPageToken
+	}
+
+	baseResp := createBaseResponse(getAppsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetAppsResponseV1{
+		Base:          &baseResp,
+		Apps:          apps,
+		NextPageToken: nextPageToken,
+	}
+
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetAppsResponseV1{GetAppsResponseV1: resp},
+	}, nil
+}
+
 // HandleGetAppsStatusQuery handles the query dispatch and creates the appropriate response message
 func HandleGetAppsStatusQuery(queryBus cqrs.QueryBus, getAppsStatusRequest *pb.GetAppsStatusRequestV1, agentID string) (*pb.AgentMessage, error) {
 	log.Debug("Processing get apps status request")
@@ -85,7 +141,7 @@ func HandleGetAppsStatusQuery(queryBus cqrs.QueryBus, getAppsStatusRequest *pb.G
 	result, err := queryBus.Dispatch(query)
 	if err != nil {
 		log.Error("Error retrieving apps statuses", "error", err)
-		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseCode = queryErrorResponseCode(err)
 		responseMessage = fmt.Sprintf("Error retrieving apps statuses: %v", err)
 	} else {
 		// Type assertion to get the app statuses
@@ -128,7 +184,7 @@ func HandleGetRegistriesQuery(queryBus cqrs.QueryBus, getRegistriesRequest *pb.G
 	result, err := queryBus.Dispatch(query)
 	if err != nil {
 		log.Error("Error retrieving registries", "error", err)
-		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseCode = queryErrorResponseCode(err)
 		responseMessage = fmt.Sprintf("Error retrieving registries: %v", err)
 	} else {
 		domainResult, ok := result.(*dto.GetRegistriesResult)
@@ -163,11 +219,12 @@ func HandleGetNetworksQuery(queryBus cqrs.QueryBus, getNetworksRequest *pb.GetNe
 	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
 	responseMessage := "Networks retrieved successfully"
 	var networkNames []string
+	var networks []*pb.NetworkV1
 
 	result, err := queryBus.Dispatch(query)
 	if err != nil {
 		log.Error("Error retrieving networks", "error", err)
-		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseCode = queryErrorResponseCode(err)
 		responseMessage = fmt.Sprintf("Error retrieving networks: %v", err)
 	} else {
 		domainResult, ok := result.(*dto.GetNetworksResult)
@@ -177,13 +234,15 @@ func HandleGetNetworksQuery(queryBus cqrs.QueryBus, getNetworksRequest *pb.GetNe
 			responseMessage = "Error retrieving networks: unexpected result type"
 		} else {
 			networkNames = NetworksToProtoNames(domainResult.Networks)
+			networks = NetworksToProtoNetworksV1(domainResult.Networks)
 		}
 	}
 
 	baseResp := createBaseResponse(getNetworksRequest.Base.MessageId, agentID, responseCode, responseMessage)
 	resp := &pb.GetNetworksResponseV1{
-		Base: &baseResp,
-		Name: networkNames,
+		Base:     &baseResp,
+		Name:     networkNames,
+		Networks: networks,
 	}
 
 	agentMsg := &pb.AgentMessage{
@@ -193,8 +252,9 @@ func HandleGetNetworksQuery(queryBus cqrs.QueryBus, getNetworksRequest *pb.GetNe
 	return agentMsg, nil
 }
 
-// HandleGetAppLogsQuery handles the query dispatch and creates the appropriate response message
-func HandleGetAppLogsQuery(queryBus cqrs.QueryBus, getAppLogsRequest *pb.GetAppLogsRequestV1, agentID string) (*pb.AgentMessage, error) {
+// HandleGetAppLogsQuery handles the query dispatch and creates the appropriate response message. The oldest
+// log entries are dropped when the response would exceed maxLogBytes; 0 sends every entry.
+func HandleGetAppLogsQuery(queryBus cqrs.QueryBus, getAppLogsRequest *pb.GetAppLogsRequestV1, agentID string, maxLogBytes int) (*pb.AgentMessage, error) {
 	log.Debug("Processing get app logs request", "app_id", getAppLogsRequest.AppId)
 
 	sinceUnix := int64(0)
@@ -208,10 +268,13 @@ func HandleGetAppLogsQuery(queryBus cqrs.QueryBus, getAppLogsRequest *pb.GetAppL
 
 	// Build query
 	query := get_app_logs.GetAppLogsQuery{
-		AppID: getAppLogsRequest.AppId,
-		Since: sinceUnix,
-		Until: untilUnix,
-		Tail:  getAppLogsRequest.Tail,
+		AppID:       getAppLogsRequest.AppId,
+		Since:       sinceUnix,
+		Until:       untilUnix,
+		Tail:        getAppLogsRequest.Tail,
+		LevelFilter: model.LogLevel(getAppLogsRequest.LevelFilter),
+		GrepPattern: getAppLogsRequest.GrepPattern,
+		ContainerID: getAppLogsRequest.ContainerId,
 	}
 
 	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
@@ -221,7 +284,10 @@ func HandleGetAppLogsQuery(queryBus cqrs.QueryBus, getAppLogsRequest *pb.GetAppL
 	result, err := queryBus.Dispatch(query)
 	if err != nil {
 		log.Error("Error retrieving app logs", "error", err)
-		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseCode = queryErrorResponseCode(err)
+		if errors.Is(err, model.ErrContainerNotInApp) {
+			responseCode = pb.ResponseCode_RESPONSE_CODE_INVALID_REQUEST
+		}
 		responseMessage = fmt.Sprintf("Error retrieving app logs: %v", err)
 	} else {
 		domainLogs, ok := result.(*model.Logs)
@@ -243,6 +309,507 @@ func HandleGetAppLogsQuery(queryBus cqrs.QueryBus, getAppLogsRequest *pb.GetAppL
 	agentMsg := &pb.AgentMessage{
 		Message: &pb.AgentMessage_GetAppLogsResponseV1{GetAppLogsResponseV1: resp},
 	}
+	limitAppLogs(agentMsg, appLogs, maxLogBytes)
+	if appLogs.GetTruncated() {
+		log.Warn("Dropped the oldest app log entries to fit the maximum log payload size", "app_id", getAppLogsRequest.AppId, "dropped", appLogs.GetDroppedCount(), "max_log_bytes", maxLogBytes)
+	}
+
+	return agentMsg, nil
+}
+
+// HandleValidateAppQuery handles the query dispatch and creates the appropriate response message
+func HandleValidateAppQuery(queryBus cqrs.QueryBus, validateAppRequest *pb.ValidateAppRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing validate app request", "app_id", validateAppRequest.AppId)
+
+	query := validate_app.ValidateAppQuery{
+		AppID: validateAppRequest.AppId,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "App validated successfully"
+	var revision uint32
+	var missing []*pb.MissingVariableV1
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error validating app", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error validating app: %v", err)
+	} else if validation, ok := result.(*dto.ValidateAppResult); !ok {
+		log.Error("Error validating app: unexpected result type")
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = "Error validating app: unexpected result type"
+	} else {
+		revision = validation.Revision
+		missing = MissingVariablesToProtoMissingVariablesV1(validation.MissingVariables)
+		if len(missing) > 0 {
+			responseMessage = fmt.Sprintf("App has %d required variables without a value", len(missing))
+		}
+	}
+
+	baseResp := createBaseResponse(validateAppRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.ValidateAppResponseV1{
+		Base:             &baseResp,
+		AppId:            validateAppRequest.AppId,
+		AppRevision:      revision,
+		MissingVariables: missing,
+	}
+
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_ValidateAppResponseV1{ValidateAppResponseV1: resp},
+	}, nil
+}
+
+// HandleGetAppResourcesQuery handles the query dispatch and creates the appropriate response message
+func HandleGetAppResourcesQuery(queryBus cqrs.QueryBus, getAppResourcesRequest *pb.GetAppResourcesRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get app resources request", "app_id", getAppResourcesRequest.AppId)
+
+	query := get_app_resources.GetAppResourcesQuery{
+		AppID: getAppResourcesRequest.AppId,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "App resources retrieved successfully"
+	var containers []*pb.ContainerResourcesV1
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving app resources", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving app resources: %v", err)
+	} else {
+		domainResources, ok := result.(*model.AppResources)
+		if !ok {
+			log.Error("Error retrieving app resources: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving app resources: unexpected result type"
+		} else {
+			containers = ContainerResourcesToProtoContainerResourcesV1(domainResources.Containers)
+		}
+	}
+
+	baseResp := createBaseResponse(getAppResourcesRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetAppResourcesResponseV1{
+		Base:       &baseResp,
+		AppId:      getAppResourcesRequest.AppId,
+		Containers: containers,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetAppResourcesResponseV1{GetAppResourcesResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetAppMetricsQuery handles the query dispatch and creates the appropriate response message
+func HandleGetAppMetricsQuery(queryBus cqrs.QueryBus, getAppMetricsRequest *pb.GetAppMetricsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get app metrics request", "app_id", getAppMetricsRequest.AppId)
+
+	query := get_app_metrics.GetAppMetricsQuery{
+		AppID: getAppMetricsRequest.AppId,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "App metrics retrieved successfully"
+	var operations []*pb.AppOperationMetricsV1
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving app metrics", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving app metrics: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.GetAppMetricsResult)
+		if !ok {
+			log.Error("Error retrieving app metrics: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving app metrics: unexpected result type"
+		} else {
+			operations = AppOperationMetricsToProtoAppOperationMetricsV1(domainResult.Operations)
+		}
+	}
+
+	baseResp := createBaseResponse(getAppMetricsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetAppMetricsResponseV1{
+		Base:       &baseResp,
+		Operations: operations,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetAppMetricsResponseV1{GetAppMetricsResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetAppRevisionsQuery handles the query dispatch and creates the appropriate response message
+func HandleGetAppRevisionsQuery(queryBus cqrs.QueryBus, getAppRevisionsRequest *pb.GetAppRevisionsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get app revisions request", "app_id", getAppRevisionsRequest.AppId)
+
+	query := get_app_revisions.GetAppRevisionsQuery{
+		AppID: getAppRevisionsRequest.AppId,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "App revisions retrieved successfully"
+	revisions := []*pb.AppRevisionV1{}
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving app revisions", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving app revisions: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.GetAppRevisionsResult)
+		if !ok {
+			log.Error("Error retrieving app revisions: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving app revisions: unexpected result type"
+		} else {
+			revisions = AppRevisionsToProtoAppRevisionsV1(domainResult.Revisions)
+		}
+	}
+
+	baseResp := createBaseResponse(getAppRevisionsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetAppRevisionsResponseV1{
+		Base:      &baseResp,
+		AppId:     getAppRevisionsRequest.AppId,
+		Revisions: revisions,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetAppRevisionsResponseV1{GetAppRevisionsResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetRenderedComposeQuery handles the query dispatch and creates the appropriate response message
+func HandleGetRenderedComposeQuery(queryBus cqrs.QueryBus, getRenderedComposeRequest *pb.GetRenderedComposeRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get rendered compose request", "app_id", getRenderedComposeRequest.AppId, "app_revision", getRenderedComposeRequest.AppRevision)
+
+	query := get_rendered_compose.GetRenderedComposeQuery{
+		AppID:    getRenderedComposeRequest.AppId,
+		Revision: getRenderedComposeRequest.AppRevision,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "Rendered compose retrieved successfully"
+	revision := getRenderedComposeRequest.AppRevision
+	compose := ""
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving rendered compose", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving rendered compose: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.GetRenderedComposeResult)
+		if !ok {
+			log.Error("Error retrieving rendered compose: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving rendered compose: unexpected result type"
+		} else {
+			revision = domainResult.Revision
+			compose = domainResult.Compose
+		}
+	}
+
+	baseResp := createBaseResponse(getRenderedComposeRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetRenderedComposeResponseV1{
+		Base:        &baseResp,
+		AppId:       getRenderedComposeRequest.AppId,
+		AppRevision: revision,
+		Compose:     compose,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetRenderedComposeResponseV1{GetRenderedComposeResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetSystemInfoQuery handles the query dispatch and creates the appropriate response message
+func HandleGetSystemInfoQuery(queryBus cqrs.QueryBus, getSystemInfoRequest *pb.GetSystemInfoRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get system info request")
+
+	query := get_system_info.GetSystemInfoQuery{}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "System info retrieved successfully"
+	var systemInfo *pb.SystemInfoV1
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving system info", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving system info: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.GetSystemInfoResult)
+		if !ok {
+			log.Error("Error retrieving system info: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving system info: unexpected result type"
+		} else {
+			systemInfo = SystemInfoToProtoSystemInfoV1(domainResult)
+		}
+	}
+
+	baseResp := createBaseResponse(getSystemInfoRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetSystemInfoResponseV1{
+		Base:       &baseResp,
+		SystemInfo: systemInfo,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetSystemInfoResponseV1{GetSystemInfoResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetConnectionStatsQuery handles the query dispatch and creates the appropriate response message
+func HandleGetConnectionStatsQuery(queryBus cqrs.QueryBus, getConnectionStatsRequest *pb.GetConnectionStatsRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get connection stats request")
+
+	query := get_connection_stats.GetConnectionStatsQuery{}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "Connection stats retrieved successfully"
+	var stats *pb.ConnectionStatsV1
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving connection stats", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving connection stats: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.GetConnectionStatsResult)
+		if !ok {
+			log.Error("Error retrieving connection stats: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error retrieving connection stats: unexpected result type"
+		} else {
+			stats = ConnectionStatsToProtoConnectionStatsV1(domainResult)
+		}
+	}
+
+	baseResp := createBaseResponse(getConnectionStatsRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp := &pb.GetConnectionStatsResponseV1{
+		Base:  &baseResp,
+		Stats: stats,
+	}
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetConnectionStatsResponseV1{GetConnectionStatsResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleGetAgentConfigQuery handles the query dispatch and creates the appropriate response message
+func HandleGetAgentConfigQuery(queryBus cqrs.QueryBus, getAgentConfigRequest *pb.GetAgentConfigRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get agent config request")
+
+	query := get_agent_config.GetAgentConfigQuery{}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "Agent config retrieved successfully"
+	resp := &pb.GetAgentConfigResponseV1{}
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving agent config", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving agent config: %v", err)
+	} else if domainResult, ok := result.(*dto.GetAgentConfigResult); !ok {
+		log.Error("Error retrieving agent config: unexpected result type")
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = "Error retrieving agent config: unexpected result type"
+	} else if settings, err := json.Marshal(domainResult.Settings); err != nil {
+		log.Error("Error encoding agent config", "error", err)
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = fmt.Sprintf("Error encoding agent config: %v", err)
+	} else {
+		resp.SettingsJson = string(settings)
+		resp.BuildOverrides = domainResult.BuildOverrides
+		resp.GrpcServerAddress = domainResult.GRPCServerAddress
+	}
+
+	baseResp := createBaseResponse(getAgentConfigRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp.Base = &baseResp
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetAgentConfigResponseV1{GetAgentConfigResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// exportChunkSize caps the archive bytes carried by a single ExportAppResponseV1 so that every message
+// stays well below the default gRPC message size limit.
+const exportChunkSize = 1 << 20
+
+// HandleExportAppQuery handles the query dispatch and creates the response messages. The exported
+// archive is split into chunks; on failure a single response without chunks is returned.
+func HandleExportAppQuery(queryBus cqrs.QueryBus, exportAppRequest *pb.ExportAppRequestV1, agentID string) ([]*pb.AgentMessage, error) {
+	log.Debug("Processing export app request", "app_id", exportAppRequest.AppId, "all_revisions", exportAppRequest.AllRevisions)
+
+	query := export_app.ExportAppQuery{
+		AppID:        exportAppRequest.AppId,
+		AllRevisions: exportAppRequest.AllRevisions,
+	}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "App exported successfully"
+	var archive []byte
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error exporting app", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error exporting app: %v", err)
+	} else {
+		domainResult, ok := result.(*dto.ExportAppResult)
+		if !ok {
+			log.Error("Error exporting app: unexpected result type")
+			responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+			responseMessage = "Error exporting app: unexpected result type"
+		} else {
+			archive = domainResult.Archive
+		}
+	}
+
+	chunks := splitChunks(archive, exportChunkSize)
+	// A failed export carries no archive data, which the server recognises by a zero chunk count.
+	chunkCount := uint32(0)
+	if len(archive) > 0 {
+		chunkCount = uint32(len(chunks))
+	}
+	messages := make([]*pb.AgentMessage, 0, len(chunks))
+	for i, chunk := range chunks {
+		baseResp := createBaseResponse(exportAppRequest.Base.MessageId, agentID, responseCode, responseMessage)
+		resp := &pb.ExportAppResponseV1{
+			Base:       &baseResp,
+			AppId:      exportAppRequest.AppId,
+			Chunk:      chunk,
+			ChunkIndex: uint32(i),
+			ChunkCount: chunkCount,
+		}
+		messages = append(messages, &pb.AgentMessage{
+			Message: &pb.AgentMessage_ExportAppResponseV1{ExportAppResponseV1: resp},
+		})
+	}
+
+	return messages, nil
+}
+
+// splitChunks splits data into consecutive slices of at most size bytes. Empty data yields a single
+// empty chunk so that callers always have one message to send.
+func splitChunks(data []byte, size int) [][]byte {
+	if len(data) == 0 {
+		return [][]byte{nil}
+	}
+	chunks := make([][]byte, 0, (len(data)+size-1)/size)
+	for start := 0; start < len(data); start += size {
+		end := min(start+size, len(data))
+		chunks = append(chunks, data[start:end])
+	}
+	return chunks
+}
+
+// queryErrorResponseCode returns the response code of a failed query: timeouts are reported as such, other
+// errors as server errors.
+func queryErrorResponseCode(err error) pb.ResponseCode {
+	if errors.Is(err, cqrs.ErrQueryTimeout) {
+		return pb.ResponseCode_RESPONSE_CODE_TIMEOUT
+	}
+	return pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+}
+
+// HandleGetVersionInfoQuery handles the query dispatch and creates the appropriate response message
+func HandleGetVersionInfoQuery(queryBus cqrs.QueryBus, getVersionInfoRequest *pb.GetVersionInfoRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing get version info request")
+
+	query := get_version_info.GetVersionInfoQuery{}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "Version info retrieved successfully"
+	resp := &pb.GetVersionInfoResponseV1{}
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error retrieving version info", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		responseMessage = fmt.Sprintf("Error retrieving version info: %v", err)
+	} else if domainResult, ok := result.(*dto.GetVersionInfoResult); !ok {
+		log.Error("Error retrieving version info: unexpected result type")
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = "Error retrieving version info: unexpected result type"
+	} else {
+		resp.AgentVersion = domainResult.AgentVersion
+		resp.AgentNumericVersion = int32(domainResult.AgentNumericVersion)
+		resp.Orchestrator = domainResult.Orchestrator
+		resp.OrchestratorVersion = domainResult.OrchestratorVersion
+		resp.DockerVersion = domainResult.DockerVersion
+		resp.DockerApiVersion = domainResult.DockerAPIVersion
+		resp.Os = domainResult.OS
+		resp.Arch = domainResult.Arch
+		resp.KernelVersion = domainResult.KernelVersion
+		resp.Unavailable = domainResult.Unavailable
+	}
+
+	baseResp := createBaseResponse(getVersionInfoRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp.Base = &baseResp
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_GetVersionInfoResponseV1{GetVersionInfoResponseV1: resp},
+	}
+
+	return agentMsg, nil
+}
+
+// HandleCheckForUpdateQuery handles the query dispatch and creates the appropriate response message
+func HandleCheckForUpdateQuery(queryBus cqrs.QueryBus, checkForUpdateRequest *pb.CheckForUpdateRequestV1, agentID string) (*pb.AgentMessage, error) {
+	log.Debug("Processing check for update request")
+
+	query := check_for_update.CheckForUpdateQuery{}
+
+	responseCode := pb.ResponseCode_RESPONSE_CODE_SUCCESS
+	responseMessage := "Checked for update successfully"
+	resp := &pb.CheckForUpdateResponseV1{}
+
+	result, err := queryBus.Dispatch(query)
+	if err != nil {
+		log.Error("Error checking for update", "error", err)
+		responseCode = queryErrorResponseCode(err)
+		if errors.Is(err, check_for_update.ErrRateLimited) {
+			responseCode = pb.ResponseCode_RESPONSE_CODE_RATE_LIMITED
+		}
+		responseMessage = fmt.Sprintf("Error checking for update: %v", err)
+	} else if domainResult, ok := result.(*dto.CheckForUpdateResult); !ok {
+		log.Error("Error checking for update: unexpected result type")
+		responseCode = pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR
+		responseMessage = "Error checking for update: unexpected result type"
+	} else {
+		resp.CurrentVersion = domainResult.CurrentVersion
+		resp.LatestVersion = domainResult.LatestVersion
+		resp.UpdateAvailable = domainResult.UpdateAvailable
+		resp.CheckedAt = domainResult.CheckedAt.Unix()
+		resp.Cached = domainResult.Cached
+		if domainResult.Cached {
+			responseMessage = "Release lookup is rate limited, returning the last check"
+		}
+	}
+
+	baseResp := createBaseResponse(checkForUpdateRequest.Base.MessageId, agentID, responseCode, responseMessage)
+	resp.Base = &baseResp
+
+	agentMsg := &pb.AgentMessage{
+		Message: &pb.AgentMessage_CheckForUpdateResponseV1{CheckForUpdateResponseV1: resp},
+	}
 
 	return agentMsg, nil
 }
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/application/query/check_for_update"
+	"winterflow-agent/internal/application/query/get_agent_config"
+	"winterflow-agent/internal/application/query/get_app_logs"
+	"winterflow-agent/internal/application/query/get_app_metrics"
+	"winterflow-agent/internal/domain/dto"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/cqrs"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// hungLogsHandler blocks like a stuck `docker logs` until the query is canceled.
+type hungLogsHandler struct{}
+
+func (h *hungLogsHandler) Handle(ctx context.Context, _ get_app_logs.GetAppLogsQuery) (*model.Logs, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHandleGetAppLogsQueryReportsTimeout(t *testing.T) {
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(&hungLogsHandler{}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+	bus.SetTimeout(func(string) time.Duration { return 10 * time.Millisecond })
+
+	request := &pb.GetAppLogsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app"}
+	msg, err := HandleGetAppLogsQuery(bus, request, "agent", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base := msg.GetGetAppLogsResponseV1().GetBase()
+	if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_TIMEOUT {
+		t.Errorf("Expected a timeout response, got %v: %s", base.GetResponseCode(), base.GetMessage())
+	}
+}
+
+// containerLogsHandler rejects every container but "c1" like a repository that does not find it in the app.
+type containerLogsHandler struct {
+	queries []get_app_logs.GetAppLogsQuery
+}
+
+func (h *containerLogsHandler) Handle(_ context.Context, query get_app_logs.GetAppLogsQuery) (*model.Logs, error) {
+	h.queries = append(h.queries, query)
+	if query.ContainerID != "c1" {
+		return nil, fmt.Errorf("failed to get app logs: %w", model.ErrContainerNotInApp)
+	}
+	return &model.Logs{Containers: []model.Container{{ID: "c1"}}}, nil
+}
+
+func TestHandleGetAppLogsQueryOfSingleContainer(t *testing.T) {
+	handler := &containerLogsHandler{}
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	request := &pb.GetAppLogsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app", ContainerId: "c1"}
+	msg, err := HandleGetAppLogsQuery(bus, request, "agent", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if code := msg.GetGetAppLogsResponseV1().GetBase().GetResponseCode(); code != pb.ResponseCode_RESPONSE_CODE_SUCCESS {
+		t.Errorf("Expected a successful response, got %v", code)
+	}
+	if len(handler.queries) != 1 || handler.queries[0].ContainerID != "c1" {
+		t.Errorf("Expected the container to be passed to the query, got %+v", handler.queries)
+	}
+
+	request.ContainerId = "c2"
+	msg, err = HandleGetAppLogsQuery(bus, request, "agent", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if code := msg.GetGetAppLogsResponseV1().GetBase().GetResponseCode(); code != pb.ResponseCode_RESPONSE_CODE_INVALID_REQUEST {
+		t.Errorf("Expected a container of another app to be an invalid request, got %v", code)
+	}
+}
+
+// staticLogsHandler returns the same logs for every query.
+type staticLogsHandler struct {
+	logs model.Logs
+}
+
+func (h *staticLogsHandler) Handle(context.Context, get_app_logs.GetAppLogsQuery) (*model.Logs, error) {
+	return &h.logs, nil
+}
+
+func TestHandleGetAppLogsQueryTruncatesOversizedLogs(t *testing.T) {
+	handler := &staticLogsHandler{logs: model.Logs{Containers: []model.Container{{ID: "c1", Name: "demo-web-1"}}}}
+	for i := 0; i < 100; i++ {
+		handler.logs.Logs = append(handler.logs.Logs, model.LogEntry{
+			Timestamp:   int64(1000 + i),
+			Message:     strings.Repeat("x", 100),
+			ContainerID: "c1",
+		})
+	}
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+	request := &pb.GetAppLogsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app"}
+
+	full, err := HandleGetAppLogsQuery(bus, request, "agent", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if logs := full.GetGetAppLogsResponseV1().GetLogs(); len(logs.GetLogs()) != 100 || logs.GetTruncated() {
+		t.Fatalf("Expected all entries without a limit, got %d (truncated %v)", len(logs.GetLogs()), logs.GetTruncated())
+	}
+
+	const maxBytes = 4096
+	msg, err := HandleGetAppLogsQuery(bus, request, "agent", maxBytes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if size := proto.Size(msg); size > maxBytes {
+		t.Errorf("Expected the response to fit in %d bytes, got %d", maxBytes, size)
+	}
+	logs := msg.GetGetAppLogsResponseV1().GetLogs()
+	if !logs.GetTruncated() {
+		t.Fatal("Expected the response to be marked as truncated")
+	}
+	kept := len(logs.GetLogs())
+	if kept == 0 || int(logs.GetDroppedCount())+kept != 100 {
+		t.Errorf("Expected the dropped and kept entries to add up to 100, got %d dropped and %d kept", logs.GetDroppedCount(), kept)
+	}
+	if first := logs.GetLogs()[0].GetTimestamp().GetSeconds(); first != int64(1000+logs.GetDroppedCount()) {
+		t.Errorf("Expected the oldest entries to be dropped, first kept entry is from %d", first)
+	}
+	if logs.GetContainers()["c1"] != "demo-web-1" {
+		t.Errorf("Expected the containers to be kept, got %v", logs.GetContainers())
+	}
+}
+
+func TestLimitAppLogsDropsOldestEntriesRegardlessOfOrder(t *testing.T) {
+	logs := &pb.AppLogsV1{}
+	// Entries are grouped by container, so the oldest ones are not necessarily first.
+	for _, ts := range []int64{30, 10, 40, 20} {
+		logs.Logs = append(logs.Logs, &pb.LogEntryV1{Timestamp: timestamppb.New(time.Unix(ts, 0)), Message: strings.Repeat("x", 50)})
+	}
+	msg := &pb.AgentMessage{Message: &pb.AgentMessage_GetAppLogsResponseV1{GetAppLogsResponseV1: &pb.GetAppLogsResponseV1{Logs: logs}}}
+	withTwo := proto.Size(&pb.AgentMessage{Message: &pb.AgentMessage_GetAppLogsResponseV1{GetAppLogsResponseV1: &pb.GetAppLogsResponseV1{
+		Logs: &pb.AppLogsV1{Logs: logs.Logs[:2], Truncated: true, DroppedCount: 2},
+	}}})
+
+	limitAppLogs(msg, logs, withTwo)
+
+	var kept []int64
+	for _, entry := range logs.GetLogs() {
+		kept = append(kept, entry.GetTimestamp().GetSeconds())
+	}
+	if len(kept) != 2 || kept[0] != 30 || kept[1] != 40 {
+		t.Errorf("Expected the two newest entries in their original order, got %v", kept)
+	}
+	if logs.GetDroppedCount() != 2 || !logs.GetTruncated() {
+		t.Errorf("Expected 2 dropped entries, got %d (truncated %v)", logs.GetDroppedCount(), logs.GetTruncated())
+	}
+}
+
+func TestHandleGetAgentConfigQueryRedactsSettings(t *testing.T) {
+	cfg := &config.Config{AgentID: "3f2b8c1e-7d4a-4b9e-a1c2-5e6f7a8b9c0d", BasePath: t.TempDir()}
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(get_agent_config.NewGetAgentConfigQueryHandler(cfg)); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	request := &pb.GetAgentConfigRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}}
+	msg, err := HandleGetAgentConfigQuery(bus, request, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp := msg.GetGetAgentConfigResponseV1()
+	if code := resp.GetBase().GetResponseCode(); code != pb.ResponseCode_RESPONSE_CODE_SUCCESS {
+		t.Fatalf("Expected a successful response, got %v: %s", code, resp.GetBase().GetMessage())
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal([]byte(resp.GetSettingsJson()), &settings); err != nil {
+		t.Fatalf("Expected settings JSON, got %q: %v", resp.GetSettingsJson(), err)
+	}
+	if strings.Contains(resp.GetSettingsJson(), cfg.AgentID) {
+		t.Errorf("Expected the agent ID to be redacted, got %s", resp.GetSettingsJson())
+	}
+	if settings["base_path"] != cfg.BasePath {
+		t.Errorf("Expected the base path %q, got %v", cfg.BasePath, settings["base_path"])
+	}
+	if resp.GetGrpcServerAddress() != cfg.GetGRPCServerAddress() {
+		t.Errorf("Expected the server address %q, got %q", cfg.GetGRPCServerAddress(), resp.GetGrpcServerAddress())
+	}
+}
+
+// checkForUpdateHandler returns result or fails with err.
+type checkForUpdateHandler struct {
+	result *dto.CheckForUpdateResult
+	err    error
+}
+
+func (h *checkForUpdateHandler) Handle(check_for_update.CheckForUpdateQuery) (*dto.CheckForUpdateResult, error) {
+	return h.result, h.err
+}
+
+func TestHandleCheckForUpdateQueryReportsTheLatestRelease(t *testing.T) {
+	bus := cqrs.NewQueryBus(t.Context())
+	checkedAt := time.Unix(1735732800, 0)
+	result := &dto.CheckForUpdateResult{CurrentVersion: "1.2.3", LatestVersion: "v1.3.0", UpdateAvailable: true, CheckedAt: checkedAt, Cached: true}
+	if err := bus.Register(&checkForUpdateHandler{result: result}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	msg, err := HandleCheckForUpdateQuery(bus, &pb.CheckForUpdateRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}}, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp := msg.GetCheckForUpdateResponseV1()
+	if resp.GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SUCCESS {
+		t.Errorf("Expected a successful response, got %v: %s", resp.GetBase().GetResponseCode(), resp.GetBase().GetMessage())
+	}
+	if resp.GetLatestVersion() != "v1.3.0" || !resp.GetUpdateAvailable() || resp.GetCheckedAt() != checkedAt.Unix() || !resp.GetCached() {
+		t.Errorf("Expected the cached check of v1.3.0, got %v", resp)
+	}
+}
+
+func TestHandleCheckForUpdateQueryReportsRateLimiting(t *testing.T) {
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(&checkForUpdateHandler{err: fmt.Errorf("%w: retry in 1m0s", check_for_update.ErrRateLimited)}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	msg, err := HandleCheckForUpdateQuery(bus, &pb.CheckForUpdateRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}}, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if code := msg.GetCheckForUpdateResponseV1().GetBase().GetResponseCode(); code != pb.ResponseCode_RESPONSE_CODE_RATE_LIMITED {
+		t.Errorf("Expected a rate limited response, got %v", code)
+	}
+}
+
+// staticMetricsHandler returns the same operation metrics for every query and records the last one.
+type staticMetricsHandler struct {
+	query      get_app_metrics.GetAppMetricsQuery
+	operations []model.AppOperationMetrics
+}
+
+func (h *staticMetricsHandler) Handle(query get_app_metrics.GetAppMetricsQuery) (*dto.GetAppMetricsResult, error) {
+	h.query = query
+	return &dto.GetAppMetricsResult{Operations: h.operations}, nil
+}
+
+func TestHandleGetAppMetricsQueryReportsDurationsInSeconds(t *testing.T) {
+	handler := &staticMetricsHandler{operations: []model.AppOperationMetrics{{
+		AppID:           "app",
+		Operation:       model.AppOperationDeploy,
+		Successes:       2,
+		Failures:        1,
+		DurationBuckets: []model.DurationBucket{{UpperBound: 500 * time.Millisecond, Count: 1}, {UpperBound: time.Minute, Count: 3}},
+		DurationSum:     90 * time.Second,
+	}}}
+	bus := cqrs.NewQueryBus(t.Context())
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	msg, err := HandleGetAppMetricsQuery(bus, &pb.GetAppMetricsRequestV1{Base: &pb.BaseMessage{MessageId: "msg"}, AppId: "app"}, "agent")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if handler.query.AppID != "app" {
+		t.Errorf("Expected the app ID to be passed to the query, got %q", handler.query.AppID)
+	}
+	resp := msg.GetGetAppMetricsResponseV1()
+	if resp.GetBase().GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SUCCESS || len(resp.GetOperations()) != 1 {
+		t.Fatalf("Expected the metrics of one operation, got %v", resp)
+	}
+	deploy := resp.GetOperations()[0]
+	if deploy.GetOperation() != "deploy" || deploy.GetSuccesses() != 2 || deploy.GetFailures() != 1 || deploy.GetDurationSumSeconds() != 90 {
+		t.Errorf("Unexpected deploy metrics %v", deploy)
+	}
+	buckets := deploy.GetDurationBuckets()
+	if len(buckets) != 2 || buckets[0].GetUpperBoundSeconds() != 0.5 || buckets[1].GetUpperBoundSeconds() != 60 || buckets[1].GetCount() != 3 {
+		t.Errorf("Unexpected duration buckets %v", buckets)
+	}
+}
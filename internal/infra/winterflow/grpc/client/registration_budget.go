@@ -0,0 +1,26 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRegistrationBudgetExhausted is returned by RegisterAgent when the agent did not register within the
+// configured number of attempts or time.
+var ErrRegistrationBudgetExhausted = errors.New("registration retry budget exhausted")
+
+// registrationBudget limits the retries of RegisterAgent. Zero values do not limit them.
+type registrationBudget struct {
+	maxAttempts int
+	maxDuration time.Duration
+}
+
+// checkAttempts returns an error wrapping ErrRegistrationBudgetExhausted, naming lastErr, once attempts
+// registration attempts were made without success.
+func (b registrationBudget) checkAttempts(attempts int, lastErr error) error {
+	if b.maxAttempts <= 0 || attempts < b.maxAttempts {
+		return nil
+	}
+	return fmt.Errorf("%w: not registered after %d attempts, last error: %v", ErrRegistrationBudgetExhausted, attempts, lastErr)
+}
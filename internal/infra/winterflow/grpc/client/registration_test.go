@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/backoff"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// registrationServer answers registrations with the configured errors in order, then with success.
+type registrationServer struct {
+	pb.UnimplementedAgentServiceServer
+	errs  []error
+	calls atomic.Int32
+}
+
+func (s *registrationServer) RegisterAgentV1(context.Context, *pb.RegisterAgentRequestV1) (*pb.RegisterAgentResponseV1, error) {
+	call := int(s.calls.Add(1)) - 1
+	if call < len(s.errs) {
+		return nil, s.errs[call]
+	}
+	return &pb.RegisterAgentResponseV1{Base: &pb.BaseResponse{ResponseCode: pb.ResponseCode_RESPONSE_CODE_SUCCESS}}, nil
+}
+
+// newRegistrationClient returns a client connected to server through an in-memory listener.
+func newRegistrationClient(t *testing.T, server *registrationServer) *Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterAgentServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create connection: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if !conn.WaitForStateChange(ctx, state) {
+			t.Fatalf("Connection did not become ready: %v", state)
+		}
+	}
+
+	return &Client{
+		serverAddress:   "bufnet",
+		conn:            conn,
+		client:          pb.NewAgentServiceClient(conn),
+		backoffStrategy: backoff.New(time.Millisecond, time.Millisecond),
+	}
+}
+
+func TestRegisterAgentStopsOnTerminalCodes(t *testing.T) {
+	for _, code := range []codes.Code{codes.PermissionDenied, codes.Unauthenticated, codes.InvalidArgument} {
+		t.Run(code.String(), func(t *testing.T) {
+			server := &registrationServer{errs: []error{status.Error(code, "rejected by test")}}
+			c := newRegistrationClient(t, server)
+
+			_, err := c.RegisterAgent(t.Context(), nil, nil, "agent")
+			var rejection *RegistrationError
+			if !errors.As(err, &rejection) {
+				t.Fatalf("Expected a RegistrationError, got %v", err)
+			}
+			if rejection.Code != code || rejection.Reason != "rejected by test" || rejection.Action == "" {
+				t.Errorf("Unexpected rejection: %+v", rejection)
+			}
+			if !errors.Is(err, ErrUnrecoverable) {
+				t.Errorf("Expected the rejection to match ErrUnrecoverable")
+			}
+			if calls := server.calls.Load(); calls != 1 {
+				t.Errorf("Expected the registration not to be retried, got %d calls", calls)
+			}
+			if c.IsRegistered() {
+				t.Error("Expected the agent not to be registered")
+			}
+		})
+	}
+}
+
+func TestRegisterAgentRetriesOtherCodes(t *testing.T) {
+	server := &registrationServer{errs: []error{status.Error(codes.Internal, "try again")}}
+	c := newRegistrationClient(t, server)
+
+	if _, err := c.RegisterAgent(t.Context(), nil, nil, "agent"); err != nil {
+		t.Fatalf("Expected the registration to succeed after a retry, got %v", err)
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("Expected 2 registration attempts, got %d", calls)
+	}
+}
+
+// failingErrs returns n retryable registration errors.
+func failingErrs(n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = status.Error(codes.Internal, "try again")
+	}
+	return errs
+}
+
+func TestRegisterAgentGivesUpAfterMaxAttempts(t *testing.T) {
+	server := &registrationServer{errs: failingErrs(10)}
+	c := newRegistrationClient(t, server)
+	c.registrationBudget = registrationBudget{maxAttempts: 3}
+
+	_, err := c.RegisterAgent(t.Context(), nil, nil, "agent")
+	if !errors.Is(err, ErrRegistrationBudgetExhausted) {
+		t.Fatalf("Expected ErrRegistrationBudgetExhausted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after 3 attempts") || !strings.Contains(err.Error(), "try again") {
+		t.Errorf("Expected the error to name the attempts and the last error, got %v", err)
+	}
+	if calls := server.calls.Load(); calls != 3 {
+		t.Errorf("Expected 3 registration attempts, got %d", calls)
+	}
+	if c.IsRegistered() {
+		t.Error("Expected the agent not to be registered")
+	}
+}
+
+func TestRegisterAgentGivesUpAfterMaxDuration(t *testing.T) {
+	server := &registrationServer{errs: failingErrs(1 << 20)}
+	c := newRegistrationClient(t, server)
+	c.registrationBudget = registrationBudget{maxDuration: 50 * time.Millisecond}
+
+	start := time.Now()
+	_, err := c.RegisterAgent(t.Context(), nil, nil, "agent")
+	if !errors.Is(err, ErrRegistrationBudgetExhausted) {
+		t.Fatalf("Expected ErrRegistrationBudgetExhausted, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected registration to give up after about 50ms, took %s", elapsed)
+	}
+}
+
+func TestRegisterAgentCancellationIsNotBudgetExhaustion(t *testing.T) {
+	server := &registrationServer{errs: failingErrs(1 << 20)}
+	c := newRegistrationClient(t, server)
+	c.registrationBudget = registrationBudget{maxDuration: time.Minute}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.RegisterAgent(ctx, nil, nil, "agent")
+	if err == nil || errors.Is(err, ErrRegistrationBudgetExhausted) {
+		t.Fatalf("Expected a cancellation error, got %v", err)
+	}
+}
+
+func TestRegisterAgentWithinBudgetSucceeds(t *testing.T) {
+	server := &registrationServer{errs: failingErrs(2)}
+	c := newRegistrationClient(t, server)
+	c.registrationBudget = registrationBudget{maxAttempts: 3, maxDuration: time.Minute}
+
+	if _, err := c.RegisterAgent(t.Context(), nil, nil, "agent"); err != nil {
+		t.Fatalf("Expected the registration to succeed on the last attempt, got %v", err)
+	}
+	if calls := server.calls.Load(); calls != 3 {
+		t.Errorf("Expected 3 registration attempts, got %d", calls)
+	}
+}
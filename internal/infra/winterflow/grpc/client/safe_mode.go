@@ -0,0 +1,43 @@
+package client
+
+import (
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/log"
+)
+
+// safeModeResponse returns the RESPONSE_CODE_SAFE_MODE response for command when the agent is in safe mode
+// and command is a mutating command that is not exempt, see mutatingCommands. It returns nil when command
+// must be processed.
+func (c *Client) safeModeResponse(command interface{}, agentID string) *pb.AgentMessage {
+	if c.config == nil || !isSafeModeCommand(command) || !c.config.SafeMode() {
+		return nil
+	}
+	base := extractBaseMessageFromCommand(command)
+	return buildErrorAgentMessage(command, base.GetMessageId(), agentID, pb.ResponseCode_RESPONSE_CODE_SAFE_MODE, "Agent is in safe mode")
+}
+
+// HandleClearSafeModeRequest leaves safe mode and creates the response message
+func HandleClearSafeModeRequest(c *Client, clearSafeModeRequest *pb.ClearSafeModeRequestV1, agentID string) *pb.AgentMessage {
+	wasEnabled, err := c.config.ClearSafeMode()
+	if err != nil {
+		log.Error("Failed to clear safe mode", "error", err)
+		return buildErrorAgentMessage(&pb.ServerCommand_ClearSafeModeRequestV1{ClearSafeModeRequestV1: clearSafeModeRequest},
+			clearSafeModeRequest.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_SERVER_ERROR, err.Error())
+	}
+
+	responseMessage := "Agent was not in safe mode"
+	if wasEnabled {
+		log.Info("Safe mode cleared, accepting mutating commands")
+		responseMessage = "Safe mode cleared"
+	}
+
+	baseResp := createBaseResponse(clearSafeModeRequest.Base.MessageId, agentID, pb.ResponseCode_RESPONSE_CODE_SUCCESS, responseMessage)
+	resp := &pb.ClearSafeModeResponseV1{
+		Base:       &baseResp,
+		WasEnabled: wasEnabled,
+	}
+
+	return &pb.AgentMessage{
+		Message: &pb.AgentMessage_ClearSafeModeResponseV1{ClearSafeModeResponseV1: resp},
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"os"
+	"testing"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+func newSafeModeClient(t *testing.T) *Client {
+	t.Helper()
+	c := &Client{config: &config.Config{BasePath: t.TempDir()}}
+	if err := c.config.EnableSafeMode(); err != nil {
+		t.Fatalf("EnableSafeMode: %v", err)
+	}
+	return c
+}
+
+func TestSafeModeRejectsMutatingCommands(t *testing.T) {
+	c := newSafeModeClient(t)
+
+	tests := []struct {
+		command interface{}
+		base    func(*pb.AgentMessage) *pb.BaseResponse
+	}{
+		{controlAppCommand(), func(m *pb.AgentMessage) *pb.BaseResponse { return m.GetControlAppResponseV1().GetBase() }},
+		{
+			&pb.ServerCommand_DeleteNetworkRequestV1{DeleteNetworkRequestV1: &pb.DeleteNetworkRequestV1{Base: &pb.BaseMessage{MessageId: "msg-1"}}},
+			func(m *pb.AgentMessage) *pb.BaseResponse { return m.GetDeleteNetworkResponseV1().GetBase() },
+		},
+		{
+			&pb.ServerCommand_UpdateAgentRequestV1{UpdateAgentRequestV1: &pb.UpdateAgentRequestV1{Base: &pb.BaseMessage{MessageId: "msg-1"}}},
+			func(m *pb.AgentMessage) *pb.BaseResponse { return m.GetUpdateAgentResponseV1().GetBase() },
+		},
+	}
+	for _, tt := range tests {
+		agentMsg := c.safeModeResponse(tt.command, maintenanceTestAgentID)
+		if agentMsg == nil {
+			t.Errorf("Expected %T to be rejected in safe mode", tt.command)
+			continue
+		}
+		base := tt.base(agentMsg)
+		if base.GetResponseCode() != pb.ResponseCode_RESPONSE_CODE_SAFE_MODE || base.GetMessageId() != "msg-1" {
+			t.Errorf("Unexpected response base for %T: %+v", tt.command, base)
+		}
+	}
+}
+
+func TestSafeModeKeepsQueriesHeartbeatsAndExemptCommands(t *testing.T) {
+	c := newSafeModeClient(t)
+
+	commands := []interface{}{
+		&pb.ServerCommand_GetAppsStatusRequestV1{GetAppsStatusRequestV1: &pb.GetAppsStatusRequestV1{Base: &pb.BaseMessage{}}},
+		&pb.ServerCommand_GetAppLogsRequestV1{GetAppLogsRequestV1: &pb.GetAppLogsRequestV1{Base: &pb.BaseMessage{}}},
+		&pb.ServerCommand_HeartbeatResponseV1{HeartbeatResponseV1: &pb.AgentHeartbeatResponseV1{}},
+		&pb.ServerCommand_ClearSafeModeRequestV1{ClearSafeModeRequestV1: &pb.ClearSafeModeRequestV1{Base: &pb.BaseMessage{}}},
+		&pb.ServerCommand_SetMaintenanceModeRequestV1{SetMaintenanceModeRequestV1: &pb.SetMaintenanceModeRequestV1{Base: &pb.BaseMessage{}}},
+		&pb.ServerCommand_CancelOperationRequestV1{CancelOperationRequestV1: &pb.CancelOperationRequestV1{Base: &pb.BaseMessage{}}},
+	}
+	for _, command := range commands {
+		if agentMsg := c.safeModeResponse(command, maintenanceTestAgentID); agentMsg != nil {
+			t.Errorf("Expected %T to be processed in safe mode", command)
+		}
+	}
+}
+
+func TestSafeModeClearedByCommand(t *testing.T) {
+	c := newSafeModeClient(t)
+
+	request := &pb.ClearSafeModeRequestV1{Base: &pb.BaseMessage{MessageId: "msg-2"}}
+	resp := HandleClearSafeModeRequest(c, request, maintenanceTestAgentID).GetClearSafeModeResponseV1()
+	if resp == nil || !resp.WasEnabled || resp.Base.ResponseCode != pb.ResponseCode_RESPONSE_CODE_SUCCESS {
+		t.Fatalf("Unexpected clear safe mode response: %+v", resp)
+	}
+	if c.safeModeResponse(controlAppCommand(), maintenanceTestAgentID) != nil {
+		t.Error("Expected app command to be processed after safe mode was cleared")
+	}
+
+	resp = HandleClearSafeModeRequest(c, request, maintenanceTestAgentID).GetClearSafeModeResponseV1()
+	if resp.WasEnabled {
+		t.Error("Expected a second clear to report that the agent was not in safe mode")
+	}
+}
+
+func TestSafeModeClearedByRemovingFile(t *testing.T) {
+	c := newSafeModeClient(t)
+
+	if err := os.Remove(c.config.GetSafeModePath()); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if c.safeModeResponse(controlAppCommand(), maintenanceTestAgentID) != nil {
+		t.Error("Expected app command to be processed after the safe mode file was removed")
+	}
+}
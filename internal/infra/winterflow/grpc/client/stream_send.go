@@ -0,0 +1,26 @@
+package client
+
+import (
+	"sync"
+
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+)
+
+// syncStream serializes the sends on an agent stream. A gRPC stream allows only one sender at a time, but the
+// receiver, the main loop and the output and progress of running actions all send on the stream of a connection.
+type syncStream struct {
+	pb.AgentService_AgentStreamClient
+	mu *sync.Mutex
+}
+
+// Send sends msg once no other goroutine is sending.
+func (s syncStream) Send(msg *pb.AgentMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AgentService_AgentStreamClient.Send(msg)
+}
+
+// syncStream wraps stream so that all its sends go through the send mutex of the client.
+func (c *Client) syncStream(stream pb.AgentService_AgentStreamClient) pb.AgentService_AgentStreamClient {
+	return syncStream{AgentService_AgentStreamClient: stream, mu: &c.sendMu}
+}
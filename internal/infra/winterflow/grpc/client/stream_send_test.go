@@ -0,0 +1,100 @@
+package client
+
+import (
+	"testing"
+
+	"winterflow-agent/internal/application/command/control_app"
+	"winterflow-agent/internal/domain/model"
+	"winterflow-agent/internal/infra/winterflow/grpc/pb"
+	"winterflow-agent/pkg/cqrs"
+)
+
+// recordingStream is a fake agent stream that records the sent messages without synchronization, so that
+// the race detector reports concurrent sends.
+type recordingStream struct {
+	pb.AgentService_AgentStreamClient
+	messages []*pb.AgentMessage
+}
+
+func (s *recordingStream) Send(msg *pb.AgentMessage) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// chattyControlAppHandler keeps writing output and reporting stages from the start of a control app command
+// until done is closed.
+type chattyControlAppHandler struct {
+	started chan struct{}
+	done    <-chan struct{}
+}
+
+func (h *chattyControlAppHandler) Handle(cmd control_app.ControlAppCommand) error {
+	for i := 0; ; i++ {
+		cmd.Output(model.OutputLine{Channel: model.LogChannelStdout, Text: "Container demo-web-1 Started"})
+		cmd.Progress.ReportStage(model.DeployStageStarting)
+		if i == 0 {
+			close(h.started)
+		}
+		select {
+		case <-h.done:
+			return nil
+		default:
+		}
+	}
+}
+
+func TestSyncStreamSerializesOutputAndRejections(t *testing.T) {
+	c := &Client{}
+	raw := &recordingStream{}
+	stream := c.syncStream(raw)
+
+	rejected := make(chan struct{})
+	handler := &chattyControlAppHandler{started: make(chan struct{}), done: rejected}
+	bus := cqrs.NewCommandBus(t.Context())
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	// The receiver rejects commands for another agent while the action streams its output.
+	const rejections = 200
+	go func() {
+		defer close(rejected)
+		<-handler.started
+		command := &pb.ServerCommand_ControlAppRequestV1{ControlAppRequestV1: &pb.ControlAppRequestV1{
+			Base:  &pb.BaseMessage{MessageId: "other", AgentId: "other-agent"},
+			AppId: "app",
+		}}
+		for i := 0; i < rejections; i++ {
+			ValidateAndRespondAgentID(stream, command, "agent")
+		}
+	}()
+
+	request := &pb.ControlAppRequestV1{
+		Base:           &pb.BaseMessage{MessageId: "msg"},
+		AppId:          "app",
+		Action:         pb.AppAction_START,
+		StreamOutput:   true,
+		ReportProgress: true,
+	}
+	msg, err := HandleControlAppRequest(bus, request, "agent", stream.Send)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-rejected
+	if err := stream.Send(msg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	unauthorized, ended := 0, false
+	for _, msg := range raw.messages {
+		if msg.GetControlAppResponseV1().GetBase().GetResponseCode() == pb.ResponseCode_RESPONSE_CODE_UNAUTHORIZED {
+			unauthorized++
+		}
+		if msg.GetAppOutputV1().GetEnded() {
+			ended = true
+		}
+	}
+	if unauthorized != rejections || !ended {
+		t.Errorf("Expected %d rejections and the end of the output, got %d rejections, ended=%v", rejections, unauthorized, ended)
+	}
+}
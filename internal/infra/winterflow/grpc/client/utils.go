@@ -9,6 +9,8 @@ import (
 
 	"github.com/google/uuid"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -27,6 +29,41 @@ const (
 var ErrUnrecoverable = errors.New("unrecoverable error. check your server ID and token")
 var ErrUnrecoverableAgentAlreadyConnected = errors.New("unrecoverable error: agent already connected")
 
+// RegistrationError is returned by RegisterAgent when the server rejects the registration for a reason that
+// retrying cannot fix. It matches ErrUnrecoverable.
+type RegistrationError struct {
+	// Code is the gRPC status code of the rejection.
+	Code codes.Code
+	// Reason is the message of the server.
+	Reason string
+	// Action tells the operator how to resolve the rejection.
+	Action string
+}
+
+func (e *RegistrationError) Error() string {
+	return fmt.Sprintf("registration rejected (%s): %s. %s", e.Code, e.Reason, e.Action)
+}
+
+func (e *RegistrationError) Unwrap() error {
+	return ErrUnrecoverable
+}
+
+// registrationRejection returns the RegistrationError of a registration failing with err, or nil when the
+// registration may be retried.
+func registrationRejection(err error) *RegistrationError {
+	st := status.Convert(err)
+	var action string
+	switch st.Code() {
+	case codes.PermissionDenied, codes.Unauthenticated:
+		action = "The agent token is invalid, expired or revoked; register the agent again with --register"
+	case codes.InvalidArgument:
+		action = "The server rejected the certificate or capabilities of the agent; check that the agent is up to date and register it again with --register"
+	default:
+		return nil
+	}
+	return &RegistrationError{Code: st.Code(), Reason: st.Message(), Action: action}
+}
+
 // GenerateUUID generates a random UUID v4
 func GenerateUUID() string {
 	return uuid.New().String()
@@ -79,6 +116,40 @@ func extractBaseMessageFromCommand(command interface{}) *pb.BaseMessage {
 		return cmd.DeleteNetworkRequestV1.GetBase()
 	case *pb.ServerCommand_GetAppLogsRequestV1:
 		return cmd.GetAppLogsRequestV1.GetBase()
+	case *pb.ServerCommand_GetAppResourcesRequestV1:
+		return cmd.GetAppResourcesRequestV1.GetBase()
+	case *pb.ServerCommand_GetAppsRequestV1:
+		return cmd.GetAppsRequestV1.GetBase()
+	case *pb.ServerCommand_ValidateAppRequestV1:
+		return cmd.ValidateAppRequestV1.GetBase()
+	case *pb.ServerCommand_ImportAppRequestV1:
+		return cmd.ImportAppRequestV1.GetBase()
+	case *pb.ServerCommand_SetMaintenanceModeRequestV1:
+		return cmd.SetMaintenanceModeRequestV1.GetBase()
+	case *pb.ServerCommand_ClearSafeModeRequestV1:
+		return cmd.ClearSafeModeRequestV1.GetBase()
+	case *pb.ServerCommand_CancelOperationRequestV1:
+		return cmd.CancelOperationRequestV1.GetBase()
+	case *pb.ServerCommand_GetConnectionStatsRequestV1:
+		return cmd.GetConnectionStatsRequestV1.GetBase()
+	case *pb.ServerCommand_StreamDockerEventsRequestV1:
+		return cmd.StreamDockerEventsRequestV1.GetBase()
+	case *pb.ServerCommand_ReconcileAppRequestV1:
+		return cmd.ReconcileAppRequestV1.GetBase()
+	case *pb.ServerCommand_StartAppsRequestV1:
+		return cmd.StartAppsRequestV1.GetBase()
+	case *pb.ServerCommand_BackupVolumesRequestV1:
+		return cmd.BackupVolumesRequestV1.GetBase()
+	case *pb.ServerCommand_GetAgentConfigRequestV1:
+		return cmd.GetAgentConfigRequestV1.GetBase()
+	case *pb.ServerCommand_GetVersionInfoRequestV1:
+		return cmd.GetVersionInfoRequestV1.GetBase()
+	case *pb.ServerCommand_CheckForUpdateRequestV1:
+		return cmd.CheckForUpdateRequestV1.GetBase()
+	case *pb.ServerCommand_GetAppMetricsRequestV1:
+		return cmd.GetAppMetricsRequestV1.GetBase()
+	case *pb.ServerCommand_CollectDiagnosticsRequestV1:
+		return cmd.CollectDiagnosticsRequestV1.GetBase()
 	default:
 		return nil
 	}
@@ -87,7 +158,13 @@ func extractBaseMessageFromCommand(command interface{}) *pb.BaseMessage {
 // buildUnauthorizedAgentMessage constructs an AgentMessage with RESPONSE_CODE_UNAUTHORIZED for the
 // provided command. Returns nil if the command type is not supported.
 func buildUnauthorizedAgentMessage(command interface{}, messageID, agentID string) *pb.AgentMessage {
-	baseResp := createBaseResponse(messageID, agentID, pb.ResponseCode_RESPONSE_CODE_UNAUTHORIZED, "Agent ID mismatch")
+	return buildErrorAgentMessage(command, messageID, agentID, pb.ResponseCode_RESPONSE_CODE_UNAUTHORIZED, "Agent ID mismatch")
+}
+
+// buildErrorAgentMessage constructs the response AgentMessage matching the provided command with the given
+// response code and message. Returns nil if the command type is not supported.
+func buildErrorAgentMessage(command interface{}, messageID, agentID string, code pb.ResponseCode, message string) *pb.AgentMessage {
+	baseResp := createBaseResponse(messageID, agentID, code, message)
 
 	switch cmd := command.(type) {
 	case *pb.ServerCommand_UpdateAgentRequestV1:
@@ -132,8 +209,59 @@ func buildUnauthorizedAgentMessage(command interface{}, messageID, agentID strin
 	case *pb.ServerCommand_GetAppLogsRequestV1:
 		resp := &pb.GetAppLogsResponseV1{Base: &baseResp}
 		return &pb.AgentMessage{Message: &pb.AgentMessage_GetAppLogsResponseV1{GetAppLogsResponseV1: resp}}
+	case *pb.ServerCommand_GetAppResourcesRequestV1:
+		resp := &pb.GetAppResourcesResponseV1{Base: &baseResp, AppId: cmd.GetAppResourcesRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetAppResourcesResponseV1{GetAppResourcesResponseV1: resp}}
+	case *pb.ServerCommand_GetAppsRequestV1:
+		resp := &pb.GetAppsResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetAppsResponseV1{GetAppsResponseV1: resp}}
+	case *pb.ServerCommand_ValidateAppRequestV1:
+		resp := &pb.ValidateAppResponseV1{Base: &baseResp, AppId: cmd.ValidateAppRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_ValidateAppResponseV1{ValidateAppResponseV1: resp}}
+	case *pb.ServerCommand_ImportAppRequestV1:
+		resp := &pb.ImportAppResponseV1{Base: &baseResp, AppId: cmd.ImportAppRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_ImportAppResponseV1{ImportAppResponseV1: resp}}
+	case *pb.ServerCommand_SetMaintenanceModeRequestV1:
+		resp := &pb.SetMaintenanceModeResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_SetMaintenanceModeResponseV1{SetMaintenanceModeResponseV1: resp}}
+	case *pb.ServerCommand_ClearSafeModeRequestV1:
+		resp := &pb.ClearSafeModeResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_ClearSafeModeResponseV1{ClearSafeModeResponseV1: resp}}
+	case *pb.ServerCommand_CancelOperationRequestV1:
+		resp := &pb.CancelOperationResponseV1{Base: &baseResp, AppId: cmd.CancelOperationRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_CancelOperationResponseV1{CancelOperationResponseV1: resp}}
+	case *pb.ServerCommand_GetConnectionStatsRequestV1:
+		resp := &pb.GetConnectionStatsResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetConnectionStatsResponseV1{GetConnectionStatsResponseV1: resp}}
+	case *pb.ServerCommand_StreamDockerEventsRequestV1:
+		resp := &pb.StreamDockerEventsResponseV1{Base: &baseResp, Ended: true}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_StreamDockerEventsResponseV1{StreamDockerEventsResponseV1: resp}}
+	case *pb.ServerCommand_ReconcileAppRequestV1:
+		resp := &pb.ReconcileAppResponseV1{Base: &baseResp, AppId: cmd.ReconcileAppRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_ReconcileAppResponseV1{ReconcileAppResponseV1: resp}}
+	case *pb.ServerCommand_StartAppsRequestV1:
+		resp := &pb.StartAppsResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_StartAppsResponseV1{StartAppsResponseV1: resp}}
+	case *pb.ServerCommand_BackupVolumesRequestV1:
+		resp := &pb.BackupVolumesResponseV1{Base: &baseResp, AppId: cmd.BackupVolumesRequestV1.GetAppId()}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_BackupVolumesResponseV1{BackupVolumesResponseV1: resp}}
+	case *pb.ServerCommand_GetAgentConfigRequestV1:
+		resp := &pb.GetAgentConfigResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetAgentConfigResponseV1{GetAgentConfigResponseV1: resp}}
+	case *pb.ServerCommand_GetVersionInfoRequestV1:
+		resp := &pb.GetVersionInfoResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetVersionInfoResponseV1{GetVersionInfoResponseV1: resp}}
+	case *pb.ServerCommand_CheckForUpdateRequestV1:
+		resp := &pb.CheckForUpdateResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_CheckForUpdateResponseV1{CheckForUpdateResponseV1: resp}}
+	case *pb.ServerCommand_GetAppMetricsRequestV1:
+		resp := &pb.GetAppMetricsResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_GetAppMetricsResponseV1{GetAppMetricsResponseV1: resp}}
+	case *pb.ServerCommand_CollectDiagnosticsRequestV1:
+		resp := &pb.CollectDiagnosticsResponseV1{Base: &baseResp}
+		return &pb.AgentMessage{Message: &pb.AgentMessage_CollectDiagnosticsResponseV1{CollectDiagnosticsResponseV1: resp}}
 	default:
-		log.Debug("Unsupported command type for unauthorized response", "type", fmt.Sprintf("%T", cmd))
+		log.Debug("Unsupported command type for error response", "type", fmt.Sprintf("%T", cmd), "code", code)
 		return nil
 	}
 }
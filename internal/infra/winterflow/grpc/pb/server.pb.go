@@ -34,19 +34,34 @@ const (
 	ResponseCode_RESPONSE_CODE_SERVER_ERROR            ResponseCode = 5
 	ResponseCode_RESPONSE_CODE_AGENT_NOT_FOUND         ResponseCode = 6
 	ResponseCode_RESPONSE_CODE_AGENT_ALREADY_CONNECTED ResponseCode = 7
+	// The agent is in maintenance mode and does not accept app commands
+	ResponseCode_RESPONSE_CODE_MAINTENANCE ResponseCode = 8
+	// The request did not complete within the agent's timeout and was canceled
+	ResponseCode_RESPONSE_CODE_TIMEOUT ResponseCode = 9
+	// The configuration of the agent does not allow the request
+	ResponseCode_RESPONSE_CODE_FORBIDDEN ResponseCode = 10
+	// The request repeats an action the agent limits, e.g. a restart within the restart cooldown of the app
+	ResponseCode_RESPONSE_CODE_RATE_LIMITED ResponseCode = 11
+	// The agent was started in safe mode and does not accept mutating commands until safe mode is cleared
+	ResponseCode_RESPONSE_CODE_SAFE_MODE ResponseCode = 12
 )
 
 // Enum value maps for ResponseCode.
 var (
 	ResponseCode_name = map[int32]string{
-		0: "RESPONSE_CODE_UNSPECIFIED",
-		1: "RESPONSE_CODE_SUCCESS",
-		2: "RESPONSE_CODE_INVALID_REQUEST",
-		3: "RESPONSE_CODE_TOO_MANY_REQUESTS",
-		4: "RESPONSE_CODE_UNAUTHORIZED",
-		5: "RESPONSE_CODE_SERVER_ERROR",
-		6: "RESPONSE_CODE_AGENT_NOT_FOUND",
-		7: "RESPONSE_CODE_AGENT_ALREADY_CONNECTED",
+		0:  "RESPONSE_CODE_UNSPECIFIED",
+		1:  "RESPONSE_CODE_SUCCESS",
+		2:  "RESPONSE_CODE_INVALID_REQUEST",
+		3:  "RESPONSE_CODE_TOO_MANY_REQUESTS",
+		4:  "RESPONSE_CODE_UNAUTHORIZED",
+		5:  "RESPONSE_CODE_SERVER_ERROR",
+		6:  "RESPONSE_CODE_AGENT_NOT_FOUND",
+		7:  "RESPONSE_CODE_AGENT_ALREADY_CONNECTED",
+		8:  "RESPONSE_CODE_MAINTENANCE",
+		9:  "RESPONSE_CODE_TIMEOUT",
+		10: "RESPONSE_CODE_FORBIDDEN",
+		11: "RESPONSE_CODE_RATE_LIMITED",
+		12: "RESPONSE_CODE_SAFE_MODE",
 	}
 	ResponseCode_value = map[string]int32{
 		"RESPONSE_CODE_UNSPECIFIED":             0,
@@ -57,6 +72,11 @@ var (
 		"RESPONSE_CODE_SERVER_ERROR":            5,
 		"RESPONSE_CODE_AGENT_NOT_FOUND":         6,
 		"RESPONSE_CODE_AGENT_ALREADY_CONNECTED": 7,
+		"RESPONSE_CODE_MAINTENANCE":             8,
+		"RESPONSE_CODE_TIMEOUT":                 9,
+		"RESPONSE_CODE_FORBIDDEN":               10,
+		"RESPONSE_CODE_RATE_LIMITED":            11,
+		"RESPONSE_CODE_SAFE_MODE":               12,
 	}
 )
 
@@ -153,6 +173,7 @@ const (
 	AppAction_RESTART  AppAction = 2
 	AppAction_UPDATE   AppAction = 3
 	AppAction_REDEPLOY AppAction = 4
+	AppAction_RECREATE AppAction = 5
 )
 
 // Enum value maps for AppAction.
@@ -163,6 +184,7 @@ var (
 		2: "RESTART",
 		3: "UPDATE",
 		4: "REDEPLOY",
+		5: "RECREATE",
 	}
 	AppAction_value = map[string]int32{
 		"STOP":     0,
@@ -170,6 +192,7 @@ var (
 		"RESTART":  2,
 		"UPDATE":   3,
 		"REDEPLOY": 4,
+		"RECREATE": 5,
 	}
 )
 
@@ -200,6 +223,125 @@ func (AppAction) EnumDescriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{2}
 }
 
+type DeployStage int32
+
+const (
+	DeployStage_DEPLOY_STAGE_UNKNOWN         DeployStage = 0
+	DeployStage_DEPLOY_STAGE_RENDERING       DeployStage = 1
+	DeployStage_DEPLOY_STAGE_PULLING         DeployStage = 2
+	DeployStage_DEPLOY_STAGE_STARTING        DeployStage = 3
+	DeployStage_DEPLOY_STAGE_WAITING_HEALTHY DeployStage = 4
+	DeployStage_DEPLOY_STAGE_DONE            DeployStage = 5
+	DeployStage_DEPLOY_STAGE_FAILED          DeployStage = 6
+	DeployStage_DEPLOY_STAGE_BUILDING        DeployStage = 7
+)
+
+// Enum value maps for DeployStage.
+var (
+	DeployStage_name = map[int32]string{
+		0: "DEPLOY_STAGE_UNKNOWN",
+		1: "DEPLOY_STAGE_RENDERING",
+		2: "DEPLOY_STAGE_PULLING",
+		3: "DEPLOY_STAGE_STARTING",
+		4: "DEPLOY_STAGE_WAITING_HEALTHY",
+		5: "DEPLOY_STAGE_DONE",
+		6: "DEPLOY_STAGE_FAILED",
+		7: "DEPLOY_STAGE_BUILDING",
+	}
+	DeployStage_value = map[string]int32{
+		"DEPLOY_STAGE_UNKNOWN":         0,
+		"DEPLOY_STAGE_RENDERING":       1,
+		"DEPLOY_STAGE_PULLING":         2,
+		"DEPLOY_STAGE_STARTING":        3,
+		"DEPLOY_STAGE_WAITING_HEALTHY": 4,
+		"DEPLOY_STAGE_DONE":            5,
+		"DEPLOY_STAGE_FAILED":          6,
+		"DEPLOY_STAGE_BUILDING":        7,
+	}
+)
+
+func (x DeployStage) Enum() *DeployStage {
+	p := new(DeployStage)
+	*p = x
+	return p
+}
+
+func (x DeployStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeployStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[3].Descriptor()
+}
+
+func (DeployStage) Type() protoreflect.EnumType {
+	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[3]
+}
+
+func (x DeployStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeployStage.Descriptor instead.
+func (DeployStage) EnumDescriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{3}
+}
+
+type DockerEventAction int32
+
+const (
+	DockerEventAction_DOCKER_EVENT_ACTION_UNKNOWN DockerEventAction = 0
+	DockerEventAction_DOCKER_EVENT_ACTION_START   DockerEventAction = 1
+	DockerEventAction_DOCKER_EVENT_ACTION_STOP    DockerEventAction = 2
+	DockerEventAction_DOCKER_EVENT_ACTION_DIE     DockerEventAction = 3
+	DockerEventAction_DOCKER_EVENT_ACTION_OOM     DockerEventAction = 4
+)
+
+// Enum value maps for DockerEventAction.
+var (
+	DockerEventAction_name = map[int32]string{
+		0: "DOCKER_EVENT_ACTION_UNKNOWN",
+		1: "DOCKER_EVENT_ACTION_START",
+		2: "DOCKER_EVENT_ACTION_STOP",
+		3: "DOCKER_EVENT_ACTION_DIE",
+		4: "DOCKER_EVENT_ACTION_OOM",
+	}
+	DockerEventAction_value = map[string]int32{
+		"DOCKER_EVENT_ACTION_UNKNOWN": 0,
+		"DOCKER_EVENT_ACTION_START":   1,
+		"DOCKER_EVENT_ACTION_STOP":    2,
+		"DOCKER_EVENT_ACTION_DIE":     3,
+		"DOCKER_EVENT_ACTION_OOM":     4,
+	}
+)
+
+func (x DockerEventAction) Enum() *DockerEventAction {
+	p := new(DockerEventAction)
+	*p = x
+	return p
+}
+
+func (x DockerEventAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DockerEventAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[4].Descriptor()
+}
+
+func (DockerEventAction) Type() protoreflect.EnumType {
+	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[4]
+}
+
+func (x DockerEventAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DockerEventAction.Descriptor instead.
+func (DockerEventAction) EnumDescriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{4}
+}
+
 type LogChannel int32
 
 const (
@@ -233,11 +375,11 @@ func (x LogChannel) String() string {
 }
 
 func (LogChannel) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[3].Descriptor()
+	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[5].Descriptor()
 }
 
 func (LogChannel) Type() protoreflect.EnumType {
-	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[3]
+	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[5]
 }
 
 func (x LogChannel) Number() protoreflect.EnumNumber {
@@ -246,7 +388,7 @@ func (x LogChannel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogChannel.Descriptor instead.
 func (LogChannel) EnumDescriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{3}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{5}
 }
 
 type LogLevel int32
@@ -294,11 +436,11 @@ func (x LogLevel) String() string {
 }
 
 func (LogLevel) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[4].Descriptor()
+	return file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[6].Descriptor()
 }
 
 func (LogLevel) Type() protoreflect.EnumType {
-	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[4]
+	return &file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes[6]
 }
 
 func (x LogLevel) Number() protoreflect.EnumNumber {
@@ -307,7 +449,7 @@ func (x LogLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LogLevel.Descriptor instead.
 func (LogLevel) EnumDescriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{4}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{6}
 }
 
 type BaseMessage struct {
@@ -316,7 +458,10 @@ type BaseMessage struct {
 	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// UUID
-	AgentId       string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// ASN.1 ECDSA signature over the SHA-256 of the deterministic encoding of the ServerCommand with this field
+	// cleared; required for mutating commands when the agent enables command signatures
+	Signature     []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -372,6 +517,13 @@ func (x *BaseMessage) GetAgentId() string {
 	return ""
 }
 
+func (x *BaseMessage) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type BaseResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// UUID
@@ -812,9 +964,11 @@ func (x *ContainerStatusV1) GetError() string {
 type AppStatusV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// UUID
-	AppId         string               `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	StatusCode    ContainerStatusCode  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3,enum=pb.ContainerStatusCode" json:"status_code,omitempty"`
-	Containers    []*ContainerStatusV1 `protobuf:"bytes,3,rep,name=containers,proto3" json:"containers,omitempty"`
+	AppId      string               `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	StatusCode ContainerStatusCode  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3,enum=pb.ContainerStatusCode" json:"status_code,omitempty"`
+	Containers []*ContainerStatusV1 `protobuf:"bytes,3,rep,name=containers,proto3" json:"containers,omitempty"`
+	// Deployed revision read from the container labels; 0 when unknown
+	Revision      uint32 `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -870,6 +1024,13 @@ func (x *AppStatusV1) GetContainers() []*ContainerStatusV1 {
 	return nil
 }
 
+func (x *AppStatusV1) GetRevision() uint32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type AppFileV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// uuid
@@ -983,11 +1144,13 @@ type AppV1 struct {
 	// UUID
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	// JSON
-	Config        []byte       `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	Variables     []*AppVarV1  `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
-	Files         []*AppFileV1 `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Config    []byte       `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Variables []*AppVarV1  `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	Files     []*AppFileV1 `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	// Optional compose.override.yml content supplied by the server (YAML)
+	ComposeOverride []byte `protobuf:"bytes,5,opt,name=compose_override,json=composeOverride,proto3" json:"compose_override,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *AppV1) Reset() {
@@ -1048,6 +1211,13 @@ func (x *AppV1) GetFiles() []*AppFileV1 {
 	return nil
 }
 
+func (x *AppV1) GetComposeOverride() []byte {
+	if x != nil {
+		return x.ComposeOverride
+	}
+	return nil
+}
+
 type GetAppRequestV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -1177,28 +1347,31 @@ func (x *GetAppResponseV1) GetAvailableRevisions() []uint32 {
 	return nil
 }
 
-type UpdateAgentRequestV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+type GetAppsRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUIDs of the apps to return; empty returns all apps
+	AppIds []string `protobuf:"bytes,2,rep,name=app_ids,json=appIds,proto3" json:"app_ids,omitempty"`
+	// next_page_token of the previous response; empty starts the listing
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAgentRequestV1) Reset() {
-	*x = UpdateAgentRequestV1{}
+func (x *GetAppsRequestV1) Reset() {
+	*x = GetAppsRequestV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAgentRequestV1) String() string {
+func (x *GetAppsRequestV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAgentRequestV1) ProtoMessage() {}
+func (*GetAppsRequestV1) ProtoMessage() {}
 
-func (x *UpdateAgentRequestV1) ProtoReflect() protoreflect.Message {
+func (x *GetAppsRequestV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1210,46 +1383,55 @@ func (x *UpdateAgentRequestV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAgentRequestV1.ProtoReflect.Descriptor instead.
-func (*UpdateAgentRequestV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAppsRequestV1.ProtoReflect.Descriptor instead.
+func (*GetAppsRequestV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateAgentRequestV1) GetBase() *BaseMessage {
+func (x *GetAppsRequestV1) GetBase() *BaseMessage {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-func (x *UpdateAgentRequestV1) GetVersion() string {
+func (x *GetAppsRequestV1) GetAppIds() []string {
 	if x != nil {
-		return x.Version
+		return x.AppIds
+	}
+	return nil
+}
+
+func (x *GetAppsRequestV1) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-type UpdateAgentResponseV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type AppDetailsV1 struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	App                *AppV1                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	AppRevision        uint32                 `protobuf:"varint,2,opt,name=app_revision,json=appRevision,proto3" json:"app_revision,omitempty"`
+	AvailableRevisions []uint32               `protobuf:"varint,3,rep,packed,name=available_revisions,json=availableRevisions,proto3" json:"available_revisions,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *UpdateAgentResponseV1) Reset() {
-	*x = UpdateAgentResponseV1{}
+func (x *AppDetailsV1) Reset() {
+	*x = AppDetailsV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAgentResponseV1) String() string {
+func (x *AppDetailsV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAgentResponseV1) ProtoMessage() {}
+func (*AppDetailsV1) ProtoMessage() {}
 
-func (x *UpdateAgentResponseV1) ProtoReflect() protoreflect.Message {
+func (x *AppDetailsV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1261,40 +1443,57 @@ func (x *UpdateAgentResponseV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAgentResponseV1.ProtoReflect.Descriptor instead.
-func (*UpdateAgentResponseV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppDetailsV1.ProtoReflect.Descriptor instead.
+func (*AppDetailsV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *UpdateAgentResponseV1) GetBase() *BaseResponse {
+func (x *AppDetailsV1) GetApp() *AppV1 {
 	if x != nil {
-		return x.Base
+		return x.App
 	}
 	return nil
 }
 
-type SaveAppRequestV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	App           *AppV1                 `protobuf:"bytes,2,opt,name=app,proto3" json:"app,omitempty"`
+func (x *AppDetailsV1) GetAppRevision() uint32 {
+	if x != nil {
+		return x.AppRevision
+	}
+	return 0
+}
+
+func (x *AppDetailsV1) GetAvailableRevisions() []uint32 {
+	if x != nil {
+		return x.AvailableRevisions
+	}
+	return nil
+}
+
+type GetAppsResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Latest revision of every returned app
+	Apps []*AppDetailsV1 `protobuf:"bytes,2,rep,name=apps,proto3" json:"apps,omitempty"`
+	// Set when more apps are available; pass it as page_token to fetch them
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveAppRequestV1) Reset() {
-	*x = SaveAppRequestV1{}
+func (x *GetAppsResponseV1) Reset() {
+	*x = GetAppsResponseV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveAppRequestV1) String() string {
+func (x *GetAppsResponseV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveAppRequestV1) ProtoMessage() {}
+func (*GetAppsResponseV1) ProtoMessage() {}
 
-func (x *SaveAppRequestV1) ProtoReflect() protoreflect.Message {
+func (x *GetAppsResponseV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1306,46 +1505,55 @@ func (x *SaveAppRequestV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveAppRequestV1.ProtoReflect.Descriptor instead.
-func (*SaveAppRequestV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAppsResponseV1.ProtoReflect.Descriptor instead.
+func (*GetAppsResponseV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SaveAppRequestV1) GetBase() *BaseMessage {
+func (x *GetAppsResponseV1) GetBase() *BaseResponse {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-func (x *SaveAppRequestV1) GetApp() *AppV1 {
+func (x *GetAppsResponseV1) GetApps() []*AppDetailsV1 {
 	if x != nil {
-		return x.App
+		return x.Apps
 	}
 	return nil
 }
 
-type SaveAppResponseV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+func (x *GetAppsResponseV1) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ValidateAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveAppResponseV1) Reset() {
-	*x = SaveAppResponseV1{}
+func (x *ValidateAppRequestV1) Reset() {
+	*x = ValidateAppRequestV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveAppResponseV1) String() string {
+func (x *ValidateAppRequestV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveAppResponseV1) ProtoMessage() {}
+func (*ValidateAppRequestV1) ProtoMessage() {}
 
-func (x *SaveAppResponseV1) ProtoReflect() protoreflect.Message {
+func (x *ValidateAppRequestV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1357,42 +1565,48 @@ func (x *SaveAppResponseV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveAppResponseV1.ProtoReflect.Descriptor instead.
-func (*SaveAppResponseV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateAppRequestV1.ProtoReflect.Descriptor instead.
+func (*ValidateAppRequestV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SaveAppResponseV1) GetBase() *BaseResponse {
+func (x *ValidateAppRequestV1) GetBase() *BaseMessage {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-type RenameAppRequestV1 struct {
+func (x *ValidateAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type MissingVariableV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	// UUID
-	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	AppName       string `protobuf:"bytes,3,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RenameAppRequestV1) Reset() {
-	*x = RenameAppRequestV1{}
+func (x *MissingVariableV1) Reset() {
+	*x = MissingVariableV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RenameAppRequestV1) String() string {
+func (x *MissingVariableV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenameAppRequestV1) ProtoMessage() {}
+func (*MissingVariableV1) ProtoMessage() {}
 
-func (x *RenameAppRequestV1) ProtoReflect() protoreflect.Message {
+func (x *MissingVariableV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1404,53 +1618,52 @@ func (x *RenameAppRequestV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenameAppRequestV1.ProtoReflect.Descriptor instead.
-func (*RenameAppRequestV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use MissingVariableV1.ProtoReflect.Descriptor instead.
+func (*MissingVariableV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *RenameAppRequestV1) GetBase() *BaseMessage {
-	if x != nil {
-		return x.Base
-	}
-	return nil
-}
-
-func (x *RenameAppRequestV1) GetAppId() string {
+func (x *MissingVariableV1) GetId() string {
 	if x != nil {
-		return x.AppId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *RenameAppRequestV1) GetAppName() string {
+func (x *MissingVariableV1) GetName() string {
 	if x != nil {
-		return x.AppName
+		return x.Name
 	}
 	return ""
 }
 
-type RenameAppResponseV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ValidateAppResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Latest revision that was validated
+	AppRevision uint32 `protobuf:"varint,3,opt,name=app_revision,json=appRevision,proto3" json:"app_revision,omitempty"`
+	// Required variables without a value; empty when the app can be deployed
+	MissingVariables []*MissingVariableV1 `protobuf:"bytes,4,rep,name=missing_variables,json=missingVariables,proto3" json:"missing_variables,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *RenameAppResponseV1) Reset() {
-	*x = RenameAppResponseV1{}
+func (x *ValidateAppResponseV1) Reset() {
+	*x = ValidateAppResponseV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RenameAppResponseV1) String() string {
+func (x *ValidateAppResponseV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenameAppResponseV1) ProtoMessage() {}
+func (*ValidateAppResponseV1) ProtoMessage() {}
 
-func (x *RenameAppResponseV1) ProtoReflect() protoreflect.Message {
+func (x *ValidateAppResponseV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1462,19 +1675,40 @@ func (x *RenameAppResponseV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenameAppResponseV1.ProtoReflect.Descriptor instead.
-func (*RenameAppResponseV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateAppResponseV1.ProtoReflect.Descriptor instead.
+func (*ValidateAppResponseV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *RenameAppResponseV1) GetBase() *BaseResponse {
+func (x *ValidateAppResponseV1) GetBase() *BaseResponse {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-type DeleteAppRequestV1 struct {
+func (x *ValidateAppResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ValidateAppResponseV1) GetAppRevision() uint32 {
+	if x != nil {
+		return x.AppRevision
+	}
+	return 0
+}
+
+func (x *ValidateAppResponseV1) GetMissingVariables() []*MissingVariableV1 {
+	if x != nil {
+		return x.MissingVariables
+	}
+	return nil
+}
+
+type GetAppRevisionsRequestV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	// UUID
@@ -1483,20 +1717,20 @@ type DeleteAppRequestV1 struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAppRequestV1) Reset() {
-	*x = DeleteAppRequestV1{}
+func (x *GetAppRevisionsRequestV1) Reset() {
+	*x = GetAppRevisionsRequestV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAppRequestV1) String() string {
+func (x *GetAppRevisionsRequestV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAppRequestV1) ProtoMessage() {}
+func (*GetAppRevisionsRequestV1) ProtoMessage() {}
 
-func (x *DeleteAppRequestV1) ProtoReflect() protoreflect.Message {
+func (x *GetAppRevisionsRequestV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1508,46 +1742,49 @@ func (x *DeleteAppRequestV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAppRequestV1.ProtoReflect.Descriptor instead.
-func (*DeleteAppRequestV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAppRevisionsRequestV1.ProtoReflect.Descriptor instead.
+func (*GetAppRevisionsRequestV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *DeleteAppRequestV1) GetBase() *BaseMessage {
+func (x *GetAppRevisionsRequestV1) GetBase() *BaseMessage {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-func (x *DeleteAppRequestV1) GetAppId() string {
+func (x *GetAppRevisionsRequestV1) GetAppId() string {
 	if x != nil {
 		return x.AppId
 	}
 	return ""
 }
 
-type DeleteAppResponseV1 struct {
+type AppRevisionV1 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Revision      uint32                 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAppResponseV1) Reset() {
-	*x = DeleteAppResponseV1{}
+func (x *AppRevisionV1) Reset() {
+	*x = AppRevisionV1{}
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAppResponseV1) String() string {
+func (x *AppRevisionV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAppResponseV1) ProtoMessage() {}
+func (*AppRevisionV1) ProtoMessage() {}
 
-func (x *DeleteAppResponseV1) ProtoReflect() protoreflect.Message {
+func (x *AppRevisionV1) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1559,43 +1796,3528 @@ func (x *DeleteAppResponseV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAppResponseV1.ProtoReflect.Descriptor instead.
-func (*DeleteAppResponseV1) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppRevisionV1.ProtoReflect.Descriptor instead.
+func (*AppRevisionV1) Descriptor() ([]byte, []int) {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *DeleteAppResponseV1) GetBase() *BaseResponse {
+func (x *AppRevisionV1) GetRevision() uint32 {
 	if x != nil {
-		return x.Base
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *AppRevisionV1) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type ControlAppRequestV1 struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
+func (x *AppRevisionV1) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppRevisionV1) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type GetAppRevisionsResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string           `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Revisions     []*AppRevisionV1 `protobuf:"bytes,3,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppRevisionsResponseV1) Reset() {
+	*x = GetAppRevisionsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppRevisionsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppRevisionsResponseV1) ProtoMessage() {}
+
+func (x *GetAppRevisionsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppRevisionsResponseV1.ProtoReflect.Descriptor instead.
+func (*GetAppRevisionsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetAppRevisionsResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAppRevisionsResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetAppRevisionsResponseV1) GetRevisions() []*AppRevisionV1 {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type GetRenderedComposeRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Revision to render; 0 selects the latest revision
+	AppRevision   uint32 `protobuf:"varint,3,opt,name=app_revision,json=appRevision,proto3" json:"app_revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRenderedComposeRequestV1) Reset() {
+	*x = GetRenderedComposeRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRenderedComposeRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRenderedComposeRequestV1) ProtoMessage() {}
+
+func (x *GetRenderedComposeRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRenderedComposeRequestV1.ProtoReflect.Descriptor instead.
+func (*GetRenderedComposeRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetRenderedComposeRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetRenderedComposeRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetRenderedComposeRequestV1) GetAppRevision() uint32 {
+	if x != nil {
+		return x.AppRevision
+	}
+	return 0
+}
+
+type GetRenderedComposeResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId       string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	AppRevision uint32 `protobuf:"varint,3,opt,name=app_revision,json=appRevision,proto3" json:"app_revision,omitempty"`
+	// Output of `docker compose config` with encrypted variables redacted
+	Compose       string `protobuf:"bytes,4,opt,name=compose,proto3" json:"compose,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRenderedComposeResponseV1) Reset() {
+	*x = GetRenderedComposeResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRenderedComposeResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRenderedComposeResponseV1) ProtoMessage() {}
+
+func (x *GetRenderedComposeResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRenderedComposeResponseV1.ProtoReflect.Descriptor instead.
+func (*GetRenderedComposeResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRenderedComposeResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetRenderedComposeResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetRenderedComposeResponseV1) GetAppRevision() uint32 {
+	if x != nil {
+		return x.AppRevision
+	}
+	return 0
+}
+
+func (x *GetRenderedComposeResponseV1) GetCompose() string {
+	if x != nil {
+		return x.Compose
+	}
+	return ""
+}
+
+type GetAppResourcesRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
 	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	// UUID
-	AppId         string    `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Action        AppAction `protobuf:"varint,3,opt,name=action,proto3,enum=pb.AppAction" json:"action,omitempty"`
+	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppResourcesRequestV1) Reset() {
+	*x = GetAppResourcesRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppResourcesRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppResourcesRequestV1) ProtoMessage() {}
+
+func (x *GetAppResourcesRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppResourcesRequestV1.ProtoReflect.Descriptor instead.
+func (*GetAppResourcesRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAppResourcesRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAppResourcesRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type ContainerResourcesV1 struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId string                 `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of CPUs the container may use; 0 when unlimited
+	CpuLimit float64 `protobuf:"fixed64,3,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
+	// Hard memory limit in bytes; 0 when unlimited
+	MemoryLimitBytes uint64 `protobuf:"varint,4,opt,name=memory_limit_bytes,json=memoryLimitBytes,proto3" json:"memory_limit_bytes,omitempty"`
+	// CPU usage where 100 is one full CPU; 0 for stopped containers
+	CpuPercent float64 `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// Memory in use excluding the page cache; 0 for stopped containers
+	MemoryUsageBytes uint64 `protobuf:"varint,6,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`
+	// Whether the last exit of the container was caused by the OOM killer
+	OomKilled     bool   `protobuf:"varint,7,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	RestartCount  uint32 `protobuf:"varint,8,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerResourcesV1) Reset() {
+	*x = ContainerResourcesV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerResourcesV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerResourcesV1) ProtoMessage() {}
+
+func (x *ContainerResourcesV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerResourcesV1.ProtoReflect.Descriptor instead.
+func (*ContainerResourcesV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ContainerResourcesV1) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ContainerResourcesV1) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerResourcesV1) GetCpuLimit() float64 {
+	if x != nil {
+		return x.CpuLimit
+	}
+	return 0
+}
+
+func (x *ContainerResourcesV1) GetMemoryLimitBytes() uint64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *ContainerResourcesV1) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ContainerResourcesV1) GetMemoryUsageBytes() uint64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *ContainerResourcesV1) GetOomKilled() bool {
+	if x != nil {
+		return x.OomKilled
+	}
+	return false
+}
+
+func (x *ContainerResourcesV1) GetRestartCount() uint32 {
+	if x != nil {
+		return x.RestartCount
+	}
+	return 0
+}
+
+type GetAppResourcesResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string                  `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Containers    []*ContainerResourcesV1 `protobuf:"bytes,3,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppResourcesResponseV1) Reset() {
+	*x = GetAppResourcesResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppResourcesResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppResourcesResponseV1) ProtoMessage() {}
+
+func (x *GetAppResourcesResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppResourcesResponseV1.ProtoReflect.Descriptor instead.
+func (*GetAppResourcesResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetAppResourcesResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAppResourcesResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetAppResourcesResponseV1) GetContainers() []*ContainerResourcesV1 {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+// Returns the success and failure counts and the duration histograms of the lifecycle operations (deploy,
+// start, stop, restart, update, recreate, delete, rename, reconcile, restart_services, recreate_services and
+// backup_volumes) run on an app since the agent started. An empty app_id returns the operations of every app.
+type GetAppMetricsRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppMetricsRequestV1) Reset() {
+	*x = GetAppMetricsRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppMetricsRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppMetricsRequestV1) ProtoMessage() {}
+
+func (x *GetAppMetricsRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppMetricsRequestV1.ProtoReflect.Descriptor instead.
+func (*GetAppMetricsRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetAppMetricsRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAppMetricsRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type DurationBucketV1 struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UpperBoundSeconds float64                `protobuf:"fixed64,1,opt,name=upper_bound_seconds,json=upperBoundSeconds,proto3" json:"upper_bound_seconds,omitempty"`
+	// Runs that took at most upper_bound_seconds, including those of the lower buckets
+	Count         uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DurationBucketV1) Reset() {
+	*x = DurationBucketV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DurationBucketV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationBucketV1) ProtoMessage() {}
+
+func (x *DurationBucketV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationBucketV1.ProtoReflect.Descriptor instead.
+func (*DurationBucketV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DurationBucketV1) GetUpperBoundSeconds() float64 {
+	if x != nil {
+		return x.UpperBoundSeconds
+	}
+	return 0
+}
+
+func (x *DurationBucketV1) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AppOperationMetricsV1 struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AppId     string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Operation string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Successes uint64                 `protobuf:"varint,3,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures  uint64                 `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	// Ascending buckets; runs longer than the last bound are only counted in successes and failures
+	DurationBuckets    []*DurationBucketV1 `protobuf:"bytes,5,rep,name=duration_buckets,json=durationBuckets,proto3" json:"duration_buckets,omitempty"`
+	DurationSumSeconds float64             `protobuf:"fixed64,6,opt,name=duration_sum_seconds,json=durationSumSeconds,proto3" json:"duration_sum_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AppOperationMetricsV1) Reset() {
+	*x = AppOperationMetricsV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppOperationMetricsV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppOperationMetricsV1) ProtoMessage() {}
+
+func (x *AppOperationMetricsV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppOperationMetricsV1.ProtoReflect.Descriptor instead.
+func (*AppOperationMetricsV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AppOperationMetricsV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *AppOperationMetricsV1) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AppOperationMetricsV1) GetSuccesses() uint64 {
+	if x != nil {
+		return x.Successes
+	}
+	return 0
+}
+
+func (x *AppOperationMetricsV1) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *AppOperationMetricsV1) GetDurationBuckets() []*DurationBucketV1 {
+	if x != nil {
+		return x.DurationBuckets
+	}
+	return nil
+}
+
+func (x *AppOperationMetricsV1) GetDurationSumSeconds() float64 {
+	if x != nil {
+		return x.DurationSumSeconds
+	}
+	return 0
+}
+
+type GetAppMetricsResponseV1 struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Base          *BaseResponse            `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Operations    []*AppOperationMetricsV1 `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppMetricsResponseV1) Reset() {
+	*x = GetAppMetricsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppMetricsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppMetricsResponseV1) ProtoMessage() {}
+
+func (x *GetAppMetricsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppMetricsResponseV1.ProtoReflect.Descriptor instead.
+func (*GetAppMetricsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetAppMetricsResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAppMetricsResponseV1) GetOperations() []*AppOperationMetricsV1 {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type GetSystemInfoRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoRequestV1) Reset() {
+	*x = GetSystemInfoRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoRequestV1) ProtoMessage() {}
+
+func (x *GetSystemInfoRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoRequestV1.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetSystemInfoRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type SystemInfoV1 struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Os               string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	KernelVersion    string                 `protobuf:"bytes,2,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	DockerVersion    string                 `protobuf:"bytes,3,opt,name=docker_version,json=dockerVersion,proto3" json:"docker_version,omitempty"`
+	DockerApiVersion string                 `protobuf:"bytes,4,opt,name=docker_api_version,json=dockerApiVersion,proto3" json:"docker_api_version,omitempty"`
+	// Capacity of the filesystem holding the agent base path
+	DiskTotalBytes     uint64 `protobuf:"varint,5,opt,name=disk_total_bytes,json=diskTotalBytes,proto3" json:"disk_total_bytes,omitempty"`
+	DiskAvailableBytes uint64 `protobuf:"varint,6,opt,name=disk_available_bytes,json=diskAvailableBytes,proto3" json:"disk_available_bytes,omitempty"`
+	MemoryTotalBytes   uint64 `protobuf:"varint,7,opt,name=memory_total_bytes,json=memoryTotalBytes,proto3" json:"memory_total_bytes,omitempty"`
+	CpuCount           uint32 `protobuf:"varint,8,opt,name=cpu_count,json=cpuCount,proto3" json:"cpu_count,omitempty"`
+	AgentUptimeSeconds uint64 `protobuf:"varint,9,opt,name=agent_uptime_seconds,json=agentUptimeSeconds,proto3" json:"agent_uptime_seconds,omitempty"`
+	// Data sources that could not be read; the related fields are left empty
+	Unavailable   []string `protobuf:"bytes,10,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemInfoV1) Reset() {
+	*x = SystemInfoV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemInfoV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemInfoV1) ProtoMessage() {}
+
+func (x *SystemInfoV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemInfoV1.ProtoReflect.Descriptor instead.
+func (*SystemInfoV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SystemInfoV1) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *SystemInfoV1) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *SystemInfoV1) GetDockerVersion() string {
+	if x != nil {
+		return x.DockerVersion
+	}
+	return ""
+}
+
+func (x *SystemInfoV1) GetDockerApiVersion() string {
+	if x != nil {
+		return x.DockerApiVersion
+	}
+	return ""
+}
+
+func (x *SystemInfoV1) GetDiskTotalBytes() uint64 {
+	if x != nil {
+		return x.DiskTotalBytes
+	}
+	return 0
+}
+
+func (x *SystemInfoV1) GetDiskAvailableBytes() uint64 {
+	if x != nil {
+		return x.DiskAvailableBytes
+	}
+	return 0
+}
+
+func (x *SystemInfoV1) GetMemoryTotalBytes() uint64 {
+	if x != nil {
+		return x.MemoryTotalBytes
+	}
+	return 0
+}
+
+func (x *SystemInfoV1) GetCpuCount() uint32 {
+	if x != nil {
+		return x.CpuCount
+	}
+	return 0
+}
+
+func (x *SystemInfoV1) GetAgentUptimeSeconds() uint64 {
+	if x != nil {
+		return x.AgentUptimeSeconds
+	}
+	return 0
+}
+
+func (x *SystemInfoV1) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type GetSystemInfoResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	SystemInfo    *SystemInfoV1          `protobuf:"bytes,2,opt,name=system_info,json=systemInfo,proto3" json:"system_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoResponseV1) Reset() {
+	*x = GetSystemInfoResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoResponseV1) ProtoMessage() {}
+
+func (x *GetSystemInfoResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoResponseV1.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetSystemInfoResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponseV1) GetSystemInfo() *SystemInfoV1 {
+	if x != nil {
+		return x.SystemInfo
+	}
+	return nil
+}
+
+type GetConnectionStatsRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionStatsRequestV1) Reset() {
+	*x = GetConnectionStatsRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionStatsRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionStatsRequestV1) ProtoMessage() {}
+
+func (x *GetConnectionStatsRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionStatsRequestV1.ProtoReflect.Descriptor instead.
+func (*GetConnectionStatsRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetConnectionStatsRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type ConnectionDisconnectV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	At    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	// How long the lost connection lasted
+	UptimeSeconds uint64 `protobuf:"varint,2,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// Error that broke the connection, if one was observed
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionDisconnectV1) Reset() {
+	*x = ConnectionDisconnectV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionDisconnectV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionDisconnectV1) ProtoMessage() {}
+
+func (x *ConnectionDisconnectV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionDisconnectV1.ProtoReflect.Descriptor instead.
+func (*ConnectionDisconnectV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ConnectionDisconnectV1) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *ConnectionDisconnectV1) GetUptimeSeconds() uint64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *ConnectionDisconnectV1) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Statistics of the connection to the server, persisted across restarts of the agent
+type ConnectionStatsV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset while disconnected
+	ConnectedSince       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=connected_since,json=connectedSince,proto3" json:"connected_since,omitempty"`
+	CurrentUptimeSeconds uint64                 `protobuf:"varint,2,opt,name=current_uptime_seconds,json=currentUptimeSeconds,proto3" json:"current_uptime_seconds,omitempty"`
+	// Time spent connected, including the current connection
+	TotalUptimeSeconds uint64                 `protobuf:"varint,3,opt,name=total_uptime_seconds,json=totalUptimeSeconds,proto3" json:"total_uptime_seconds,omitempty"`
+	DisconnectCount    uint64                 `protobuf:"varint,4,opt,name=disconnect_count,json=disconnectCount,proto3" json:"disconnect_count,omitempty"`
+	LastDisconnectAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_disconnect_at,json=lastDisconnectAt,proto3" json:"last_disconnect_at,omitempty"`
+	LastError          string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	// Latest disconnects, oldest first
+	RecentDisconnects []*ConnectionDisconnectV1 `protobuf:"bytes,8,rep,name=recent_disconnects,json=recentDisconnects,proto3" json:"recent_disconnects,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConnectionStatsV1) Reset() {
+	*x = ConnectionStatsV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionStatsV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionStatsV1) ProtoMessage() {}
+
+func (x *ConnectionStatsV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionStatsV1.ProtoReflect.Descriptor instead.
+func (*ConnectionStatsV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ConnectionStatsV1) GetConnectedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedSince
+	}
+	return nil
+}
+
+func (x *ConnectionStatsV1) GetCurrentUptimeSeconds() uint64 {
+	if x != nil {
+		return x.CurrentUptimeSeconds
+	}
+	return 0
+}
+
+func (x *ConnectionStatsV1) GetTotalUptimeSeconds() uint64 {
+	if x != nil {
+		return x.TotalUptimeSeconds
+	}
+	return 0
+}
+
+func (x *ConnectionStatsV1) GetDisconnectCount() uint64 {
+	if x != nil {
+		return x.DisconnectCount
+	}
+	return 0
+}
+
+func (x *ConnectionStatsV1) GetLastDisconnectAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDisconnectAt
+	}
+	return nil
+}
+
+func (x *ConnectionStatsV1) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ConnectionStatsV1) GetLastErrorAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastErrorAt
+	}
+	return nil
+}
+
+func (x *ConnectionStatsV1) GetRecentDisconnects() []*ConnectionDisconnectV1 {
+	if x != nil {
+		return x.RecentDisconnects
+	}
+	return nil
+}
+
+type GetConnectionStatsResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Stats         *ConnectionStatsV1     `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionStatsResponseV1) Reset() {
+	*x = GetConnectionStatsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionStatsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionStatsResponseV1) ProtoMessage() {}
+
+func (x *GetConnectionStatsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionStatsResponseV1.ProtoReflect.Descriptor instead.
+func (*GetConnectionStatsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetConnectionStatsResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetConnectionStatsResponseV1) GetStats() *ConnectionStatsV1 {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetAgentConfigRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigRequestV1) Reset() {
+	*x = GetAgentConfigRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigRequestV1) ProtoMessage() {}
+
+func (x *GetAgentConfigRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigRequestV1.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetAgentConfigRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type GetAgentConfigResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Effective settings of the agent as a JSON object keyed by setting name, with defaults applied and the
+	// agent ID, device ID and URL credentials redacted
+	SettingsJson string `protobuf:"bytes,2,opt,name=settings_json,json=settingsJson,proto3" json:"settings_json,omitempty"`
+	// Settings fixed when the agent was built, e.g. grpc_server_address and base_path; unset ones are omitted
+	BuildOverrides    map[string]string `protobuf:"bytes,3,rep,name=build_overrides,json=buildOverrides,proto3" json:"build_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	GrpcServerAddress string            `protobuf:"bytes,4,opt,name=grpc_server_address,json=grpcServerAddress,proto3" json:"grpc_server_address,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigResponseV1) Reset() {
+	*x = GetAgentConfigResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigResponseV1) ProtoMessage() {}
+
+func (x *GetAgentConfigResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigResponseV1.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetAgentConfigResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetAgentConfigResponseV1) GetSettingsJson() string {
+	if x != nil {
+		return x.SettingsJson
+	}
+	return ""
+}
+
+func (x *GetAgentConfigResponseV1) GetBuildOverrides() map[string]string {
+	if x != nil {
+		return x.BuildOverrides
+	}
+	return nil
+}
+
+func (x *GetAgentConfigResponseV1) GetGrpcServerAddress() string {
+	if x != nil {
+		return x.GrpcServerAddress
+	}
+	return ""
+}
+
+type CollectDiagnosticsRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Signed HTTPS URL the bundle is uploaded to with a PUT; when empty the bundle is returned in the response
+	UploadUrl     string `protobuf:"bytes,2,opt,name=upload_url,json=uploadUrl,proto3" json:"upload_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectDiagnosticsRequestV1) Reset() {
+	*x = CollectDiagnosticsRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectDiagnosticsRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectDiagnosticsRequestV1) ProtoMessage() {}
+
+func (x *CollectDiagnosticsRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectDiagnosticsRequestV1.ProtoReflect.Descriptor instead.
+func (*CollectDiagnosticsRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CollectDiagnosticsRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CollectDiagnosticsRequestV1) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+type CollectDiagnosticsResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// gzip tarball of the diagnostics; empty when it was uploaded
+	Bundle   []byte `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Size     uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Uploaded bool   `protobuf:"varint,4,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	// Names of the files in the bundle
+	Entries []string `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Data sources that could not be read and are missing from the bundle
+	Unavailable   []string `protobuf:"bytes,6,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectDiagnosticsResponseV1) Reset() {
+	*x = CollectDiagnosticsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectDiagnosticsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectDiagnosticsResponseV1) ProtoMessage() {}
+
+func (x *CollectDiagnosticsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectDiagnosticsResponseV1.ProtoReflect.Descriptor instead.
+func (*CollectDiagnosticsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CollectDiagnosticsResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CollectDiagnosticsResponseV1) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *CollectDiagnosticsResponseV1) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CollectDiagnosticsResponseV1) GetUploaded() bool {
+	if x != nil {
+		return x.Uploaded
+	}
+	return false
+}
+
+func (x *CollectDiagnosticsResponseV1) GetEntries() []string {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *CollectDiagnosticsResponseV1) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+type GetVersionInfoRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionInfoRequestV1) Reset() {
+	*x = GetVersionInfoRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionInfoRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionInfoRequestV1) ProtoMessage() {}
+
+func (x *GetVersionInfoRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionInfoRequestV1.ProtoReflect.Descriptor instead.
+func (*GetVersionInfoRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetVersionInfoRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type GetVersionInfoResponseV1 struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Base                *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AgentVersion        string                 `protobuf:"bytes,2,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	AgentNumericVersion int32                  `protobuf:"varint,3,opt,name=agent_numeric_version,json=agentNumericVersion,proto3" json:"agent_numeric_version,omitempty"`
+	// Orchestrator the apps are deployed with, e.g. docker_compose, and its version
+	Orchestrator        string `protobuf:"bytes,4,opt,name=orchestrator,proto3" json:"orchestrator,omitempty"`
+	OrchestratorVersion string `protobuf:"bytes,5,opt,name=orchestrator_version,json=orchestratorVersion,proto3" json:"orchestrator_version,omitempty"`
+	DockerVersion       string `protobuf:"bytes,6,opt,name=docker_version,json=dockerVersion,proto3" json:"docker_version,omitempty"`
+	DockerApiVersion    string `protobuf:"bytes,7,opt,name=docker_api_version,json=dockerApiVersion,proto3" json:"docker_api_version,omitempty"`
+	Os                  string `protobuf:"bytes,8,opt,name=os,proto3" json:"os,omitempty"`
+	Arch                string `protobuf:"bytes,9,opt,name=arch,proto3" json:"arch,omitempty"`
+	KernelVersion       string `protobuf:"bytes,10,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	// Data sources that could not be read; the corresponding fields are empty
+	Unavailable   []string `protobuf:"bytes,11,rep,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionInfoResponseV1) Reset() {
+	*x = GetVersionInfoResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionInfoResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionInfoResponseV1) ProtoMessage() {}
+
+func (x *GetVersionInfoResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionInfoResponseV1.ProtoReflect.Descriptor instead.
+func (*GetVersionInfoResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetVersionInfoResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetVersionInfoResponseV1) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetAgentNumericVersion() int32 {
+	if x != nil {
+		return x.AgentNumericVersion
+	}
+	return 0
+}
+
+func (x *GetVersionInfoResponseV1) GetOrchestrator() string {
+	if x != nil {
+		return x.Orchestrator
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetOrchestratorVersion() string {
+	if x != nil {
+		return x.OrchestratorVersion
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetDockerVersion() string {
+	if x != nil {
+		return x.DockerVersion
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetDockerApiVersion() string {
+	if x != nil {
+		return x.DockerApiVersion
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *GetVersionInfoResponseV1) GetUnavailable() []string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return nil
+}
+
+// Looks up the latest release of the agent, so that the server can decide to send an UpdateAgentRequestV1.
+// While the releases are rate limited the result of the last check is returned with cached set; without one the
+// request fails with RESPONSE_CODE_RATE_LIMITED.
+type CheckForUpdateRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckForUpdateRequestV1) Reset() {
+	*x = CheckForUpdateRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckForUpdateRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckForUpdateRequestV1) ProtoMessage() {}
+
+func (x *CheckForUpdateRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckForUpdateRequestV1.ProtoReflect.Descriptor instead.
+func (*CheckForUpdateRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CheckForUpdateRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type CheckForUpdateResponseV1 struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Base            *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	CurrentVersion  string                 `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	LatestVersion   string                 `protobuf:"bytes,3,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	UpdateAvailable bool                   `protobuf:"varint,4,opt,name=update_available,json=updateAvailable,proto3" json:"update_available,omitempty"`
+	// Unix timestamp (seconds) of the lookup of the latest release
+	CheckedAt int64 `protobuf:"varint,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// True when the result of an earlier check is returned because the releases are rate limited
+	Cached        bool `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckForUpdateResponseV1) Reset() {
+	*x = CheckForUpdateResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckForUpdateResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckForUpdateResponseV1) ProtoMessage() {}
+
+func (x *CheckForUpdateResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckForUpdateResponseV1.ProtoReflect.Descriptor instead.
+func (*CheckForUpdateResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CheckForUpdateResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CheckForUpdateResponseV1) GetCurrentVersion() string {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return ""
+}
+
+func (x *CheckForUpdateResponseV1) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *CheckForUpdateResponseV1) GetUpdateAvailable() bool {
+	if x != nil {
+		return x.UpdateAvailable
+	}
+	return false
+}
+
+func (x *CheckForUpdateResponseV1) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *CheckForUpdateResponseV1) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type SetMaintenanceModeRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// true pauses app commands, false resumes them
+	Enabled       bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeRequestV1) Reset() {
+	*x = SetMaintenanceModeRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeRequestV1) ProtoMessage() {}
+
+func (x *SetMaintenanceModeRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeRequestV1.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetMaintenanceModeRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *SetMaintenanceModeRequestV1) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetMaintenanceModeResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeResponseV1) Reset() {
+	*x = SetMaintenanceModeResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeResponseV1) ProtoMessage() {}
+
+func (x *SetMaintenanceModeResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeResponseV1.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetMaintenanceModeResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *SetMaintenanceModeResponseV1) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Leaves safe mode, in which the agent rejects mutating commands with RESPONSE_CODE_SAFE_MODE after it was
+// started with --safe-mode.
+type ClearSafeModeRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearSafeModeRequestV1) Reset() {
+	*x = ClearSafeModeRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearSafeModeRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearSafeModeRequestV1) ProtoMessage() {}
+
+func (x *ClearSafeModeRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearSafeModeRequestV1.ProtoReflect.Descriptor instead.
+func (*ClearSafeModeRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ClearSafeModeRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type ClearSafeModeResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// false when the agent was not in safe mode
+	WasEnabled    bool `protobuf:"varint,2,opt,name=was_enabled,json=wasEnabled,proto3" json:"was_enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearSafeModeResponseV1) Reset() {
+	*x = ClearSafeModeResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearSafeModeResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearSafeModeResponseV1) ProtoMessage() {}
+
+func (x *ClearSafeModeResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearSafeModeResponseV1.ProtoReflect.Descriptor instead.
+func (*ClearSafeModeResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ClearSafeModeResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ClearSafeModeResponseV1) GetWasEnabled() bool {
+	if x != nil {
+		return x.WasEnabled
+	}
+	return false
+}
+
+type ImportAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// gzip tarball as produced by ExportAppRequestV1
+	Archive []byte `protobuf:"bytes,3,opt,name=archive,proto3" json:"archive,omitempty"`
+	// Deploy the latest imported revision after the import
+	Deploy        bool `protobuf:"varint,4,opt,name=deploy,proto3" json:"deploy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAppRequestV1) Reset() {
+	*x = ImportAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAppRequestV1) ProtoMessage() {}
+
+func (x *ImportAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAppRequestV1.ProtoReflect.Descriptor instead.
+func (*ImportAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ImportAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ImportAppRequestV1) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *ImportAppRequestV1) GetDeploy() bool {
+	if x != nil {
+		return x.Deploy
+	}
+	return false
+}
+
+type ImportAppResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAppResponseV1) Reset() {
+	*x = ImportAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAppResponseV1) ProtoMessage() {}
+
+func (x *ImportAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAppResponseV1.ProtoReflect.Descriptor instead.
+func (*ImportAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ImportAppResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type ExportAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Export every stored revision instead of only the latest one
+	AllRevisions  bool `protobuf:"varint,3,opt,name=all_revisions,json=allRevisions,proto3" json:"all_revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAppRequestV1) Reset() {
+	*x = ExportAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAppRequestV1) ProtoMessage() {}
+
+func (x *ExportAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAppRequestV1.ProtoReflect.Descriptor instead.
+func (*ExportAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExportAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ExportAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ExportAppRequestV1) GetAllRevisions() bool {
+	if x != nil {
+		return x.AllRevisions
+	}
+	return false
+}
+
+// The exported gzip tarball is sent as a sequence of responses sharing the
+// request message_id; concatenating the chunks in chunk_index order yields the archive.
+type ExportAppResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Chunk         []byte `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	ChunkIndex    uint32 `protobuf:"varint,4,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	ChunkCount    uint32 `protobuf:"varint,5,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAppResponseV1) Reset() {
+	*x = ExportAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAppResponseV1) ProtoMessage() {}
+
+func (x *ExportAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAppResponseV1.ProtoReflect.Descriptor instead.
+func (*ExportAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExportAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ExportAppResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ExportAppResponseV1) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *ExportAppResponseV1) GetChunkIndex() uint32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+func (x *ExportAppResponseV1) GetChunkCount() uint32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+type UpdateAgentRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAgentRequestV1) Reset() {
+	*x = UpdateAgentRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAgentRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAgentRequestV1) ProtoMessage() {}
+
+func (x *UpdateAgentRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAgentRequestV1.ProtoReflect.Descriptor instead.
+func (*UpdateAgentRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UpdateAgentRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequestV1) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type UpdateAgentResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAgentResponseV1) Reset() {
+	*x = UpdateAgentResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAgentResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAgentResponseV1) ProtoMessage() {}
+
+func (x *UpdateAgentResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAgentResponseV1.ProtoReflect.Descriptor instead.
+func (*UpdateAgentResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *UpdateAgentResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type SaveAppRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	App           *AppV1                 `protobuf:"bytes,2,opt,name=app,proto3" json:"app,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveAppRequestV1) Reset() {
+	*x = SaveAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveAppRequestV1) ProtoMessage() {}
+
+func (x *SaveAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveAppRequestV1.ProtoReflect.Descriptor instead.
+func (*SaveAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SaveAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *SaveAppRequestV1) GetApp() *AppV1 {
+	if x != nil {
+		return x.App
+	}
+	return nil
+}
+
+type SaveAppResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveAppResponseV1) Reset() {
+	*x = SaveAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveAppResponseV1) ProtoMessage() {}
+
+func (x *SaveAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveAppResponseV1.ProtoReflect.Descriptor instead.
+func (*SaveAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SaveAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type RenameAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	AppName       string `protobuf:"bytes,3,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameAppRequestV1) Reset() {
+	*x = RenameAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameAppRequestV1) ProtoMessage() {}
+
+func (x *RenameAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameAppRequestV1.ProtoReflect.Descriptor instead.
+func (*RenameAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RenameAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RenameAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *RenameAppRequestV1) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+type RenameAppResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameAppResponseV1) Reset() {
+	*x = RenameAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameAppResponseV1) ProtoMessage() {}
+
+func (x *RenameAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameAppResponseV1.ProtoReflect.Descriptor instead.
+func (*RenameAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RenameAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type DeleteAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Also remove the named volumes, and thereby the data, of the app
+	Purge         bool `protobuf:"varint,3,opt,name=purge,proto3" json:"purge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAppRequestV1) Reset() {
+	*x = DeleteAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAppRequestV1) ProtoMessage() {}
+
+func (x *DeleteAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAppRequestV1.ProtoReflect.Descriptor instead.
+func (*DeleteAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DeleteAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DeleteAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *DeleteAppRequestV1) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+type DeleteAppResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAppResponseV1) Reset() {
+	*x = DeleteAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAppResponseV1) ProtoMessage() {}
+
+func (x *DeleteAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAppResponseV1.ProtoReflect.Descriptor instead.
+func (*DeleteAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type ControlAppRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId  string    `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Action AppAction `protobuf:"varint,3,opt,name=action,proto3,enum=pb.AppAction" json:"action,omitempty"`
+	// Streams the output of the docker compose commands of the action as AppOutputV1 messages while it runs
+	StreamOutput bool `protobuf:"varint,4,opt,name=stream_output,json=streamOutput,proto3" json:"stream_output,omitempty"`
+	// Reports the stages the action reaches as AppProgressV1 messages while it runs
+	ReportProgress bool `protobuf:"varint,5,opt,name=report_progress,json=reportProgress,proto3" json:"report_progress,omitempty"`
+	// Limits RESTART and RECREATE to the named services of the app; other actions reject services
+	Services []string `protobuf:"bytes,6,rep,name=services,proto3" json:"services,omitempty"`
+	// Runs a RESTART or REDEPLOY even when it falls within the restart cooldown of the app
+	Force         bool `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlAppRequestV1) Reset() {
+	*x = ControlAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlAppRequestV1) ProtoMessage() {}
+
+func (x *ControlAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlAppRequestV1.ProtoReflect.Descriptor instead.
+func (*ControlAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ControlAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ControlAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ControlAppRequestV1) GetAction() AppAction {
+	if x != nil {
+		return x.Action
+	}
+	return AppAction_STOP
+}
+
+func (x *ControlAppRequestV1) GetStreamOutput() bool {
+	if x != nil {
+		return x.StreamOutput
+	}
+	return false
+}
+
+func (x *ControlAppRequestV1) GetReportProgress() bool {
+	if x != nil {
+		return x.ReportProgress
+	}
+	return false
+}
+
+func (x *ControlAppRequestV1) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *ControlAppRequestV1) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type ControlAppResponseV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ControlAppResponseV1) Reset() {
+	*x = ControlAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ControlAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlAppResponseV1) ProtoMessage() {}
+
+func (x *ControlAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlAppResponseV1.ProtoReflect.Descriptor instead.
+func (*ControlAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ControlAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type AppOutputLineV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       LogChannel             `protobuf:"varint,1,opt,name=channel,proto3,enum=pb.LogChannel" json:"channel,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppOutputLineV1) Reset() {
+	*x = AppOutputLineV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppOutputLineV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppOutputLineV1) ProtoMessage() {}
+
+func (x *AppOutputLineV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppOutputLineV1.ProtoReflect.Descriptor instead.
+func (*AppOutputLineV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AppOutputLineV1) GetChannel() LogChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return LogChannel_LOG_CHANNEL_UNKNOWN
+}
+
+func (x *AppOutputLineV1) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Output of an app action requested with stream_output. The message ID of the base is the one of the
+// request. The last message is marked as ended and carries the outcome of the action; it is sent before
+// the ControlAppResponseV1.
+type AppOutputV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string             `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Lines         []*AppOutputLineV1 `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	Ended         bool               `protobuf:"varint,4,opt,name=ended,proto3" json:"ended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppOutputV1) Reset() {
+	*x = AppOutputV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppOutputV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppOutputV1) ProtoMessage() {}
+
+func (x *AppOutputV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppOutputV1.ProtoReflect.Descriptor instead.
+func (*AppOutputV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *AppOutputV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *AppOutputV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *AppOutputV1) GetLines() []*AppOutputLineV1 {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *AppOutputV1) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
+// A stage an app action requested with report_progress reached. The message ID of the base is the one of the
+// request. The done or failed stage is sent before the ControlAppResponseV1.
+type AppProgressV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// UUID
+	AppId         string      `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Stage         DeployStage `protobuf:"varint,3,opt,name=stage,proto3,enum=pb.DeployStage" json:"stage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppProgressV1) Reset() {
+	*x = AppProgressV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppProgressV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppProgressV1) ProtoMessage() {}
+
+func (x *AppProgressV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppProgressV1.ProtoReflect.Descriptor instead.
+func (*AppProgressV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *AppProgressV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *AppProgressV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *AppProgressV1) GetStage() DeployStage {
+	if x != nil {
+		return x.Stage
+	}
+	return DeployStage_DEPLOY_STAGE_UNKNOWN
+}
+
+// Cancels the lifecycle operation (deploy, start, update, ...) that is running on an app.
+type CancelOperationRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOperationRequestV1) Reset() {
+	*x = CancelOperationRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOperationRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOperationRequestV1) ProtoMessage() {}
+
+func (x *CancelOperationRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOperationRequestV1.ProtoReflect.Descriptor instead.
+func (*CancelOperationRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CancelOperationRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CancelOperationRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type CancelOperationResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// False when no operation was running on the app.
+	Canceled      bool `protobuf:"varint,3,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOperationResponseV1) Reset() {
+	*x = CancelOperationResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOperationResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOperationResponseV1) ProtoMessage() {}
+
+func (x *CancelOperationResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOperationResponseV1.ProtoReflect.Descriptor instead.
+func (*CancelOperationResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CancelOperationResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CancelOperationResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *CancelOperationResponseV1) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+// Starts several apps at once. Apps that list other apps in depends_on_apps of their config are started once
+// those are running and healthy; apps of the batch start after the apps of the batch they depend on. A
+// dependency cycle rejects the batch with RESPONSE_CODE_INVALID_REQUEST before any app is started.
+type StartAppsRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppIds        []string               `protobuf:"bytes,2,rep,name=app_ids,json=appIds,proto3" json:"app_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAppsRequestV1) Reset() {
+	*x = StartAppsRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAppsRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAppsRequestV1) ProtoMessage() {}
+
+func (x *StartAppsRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAppsRequestV1.ProtoReflect.Descriptor instead.
+func (*StartAppsRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *StartAppsRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *StartAppsRequestV1) GetAppIds() []string {
+	if x != nil {
+		return x.AppIds
+	}
+	return nil
+}
+
+type StartAppResultV1 struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppId   string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Started bool                   `protobuf:"varint,2,opt,name=started,proto3" json:"started,omitempty"`
+	// Why the app was not started; empty when it was.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAppResultV1) Reset() {
+	*x = StartAppResultV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAppResultV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAppResultV1) ProtoMessage() {}
+
+func (x *StartAppResultV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAppResultV1.ProtoReflect.Descriptor instead.
+func (*StartAppResultV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *StartAppResultV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *StartAppResultV1) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *StartAppResultV1) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StartAppsResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// One result per app in the order the apps were started.
+	Results       []*StartAppResultV1 `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAppsResponseV1) Reset() {
+	*x = StartAppsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAppsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAppsResponseV1) ProtoMessage() {}
+
+func (x *StartAppsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAppsResponseV1.ProtoReflect.Descriptor instead.
+func (*StartAppsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *StartAppsResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *StartAppsResponseV1) GetResults() []*StartAppResultV1 {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Resets an app to a clean state: the rendered app directory is deleted and the latest revision is rendered
+// from scratch and redeployed. Unlike an update nothing of the previous directory is kept.
+type ReconcileAppRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileAppRequestV1) Reset() {
+	*x = ReconcileAppRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileAppRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileAppRequestV1) ProtoMessage() {}
+
+func (x *ReconcileAppRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileAppRequestV1.ProtoReflect.Descriptor instead.
+func (*ReconcileAppRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ReconcileAppRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ReconcileAppRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type ReconcileAppResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// True when the rendered app directory differed from the templates of the latest revision.
+	Drifted       bool `protobuf:"varint,3,opt,name=drifted,proto3" json:"drifted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileAppResponseV1) Reset() {
+	*x = ReconcileAppResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileAppResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileAppResponseV1) ProtoMessage() {}
+
+func (x *ReconcileAppResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileAppResponseV1.ProtoReflect.Descriptor instead.
+func (*ReconcileAppResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ReconcileAppResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ReconcileAppResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *ReconcileAppResponseV1) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+// Archives the named volumes of an app, one gzip compressed tar archive per volume, into a new directory
+// below the volume backup directory of the agent.
+type BackupVolumesRequestV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupVolumesRequestV1) Reset() {
+	*x = BackupVolumesRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupVolumesRequestV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupVolumesRequestV1) ProtoMessage() {}
+
+func (x *BackupVolumesRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupVolumesRequestV1.ProtoReflect.Descriptor instead.
+func (*BackupVolumesRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *BackupVolumesRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *BackupVolumesRequestV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type VolumeBackupV1 struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Volume string                 `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	// Path of the archive on the host of the agent.
+	Archive       string `protobuf:"bytes,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeBackupV1) Reset() {
+	*x = VolumeBackupV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeBackupV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeBackupV1) ProtoMessage() {}
+
+func (x *VolumeBackupV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeBackupV1.ProtoReflect.Descriptor instead.
+func (*VolumeBackupV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *VolumeBackupV1) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *VolumeBackupV1) GetArchive() string {
+	if x != nil {
+		return x.Archive
+	}
+	return ""
+}
+
+type BackupVolumesResponseV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	AppId string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// The archives written, also those written before a volume failed.
+	Backups       []*VolumeBackupV1 `protobuf:"bytes,3,rep,name=backups,proto3" json:"backups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupVolumesResponseV1) Reset() {
+	*x = BackupVolumesResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupVolumesResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupVolumesResponseV1) ProtoMessage() {}
+
+func (x *BackupVolumesResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupVolumesResponseV1.ProtoReflect.Descriptor instead.
+func (*BackupVolumesResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *BackupVolumesResponseV1) GetBase() *BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *BackupVolumesResponseV1) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *BackupVolumesResponseV1) GetBackups() []*VolumeBackupV1 {
+	if x != nil {
+		return x.Backups
+	}
+	return nil
+}
+
+type DockerEventV1 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	ContainerId   string                 `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	ContainerName string                 `protobuf:"bytes,3,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,4,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Action        DockerEventAction      `protobuf:"varint,5,opt,name=action,proto3,enum=pb.DockerEventAction" json:"action,omitempty"`
+	// Time of the latest coalesced occurrence
+	Time *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	// Exit code of the container for DOCKER_EVENT_ACTION_DIE
+	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Number of occurrences coalesced into this event
+	Count         uint32 `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ControlAppRequestV1) Reset() {
-	*x = ControlAppRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[23]
+func (x *DockerEventV1) Reset() {
+	*x = DockerEventV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ControlAppRequestV1) String() string {
+func (x *DockerEventV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControlAppRequestV1) ProtoMessage() {}
+func (*DockerEventV1) ProtoMessage() {}
 
-func (x *ControlAppRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[23]
+func (x *DockerEventV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1606,54 +5328,93 @@ func (x *ControlAppRequestV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControlAppRequestV1.ProtoReflect.Descriptor instead.
-func (*ControlAppRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use DockerEventV1.ProtoReflect.Descriptor instead.
+func (*DockerEventV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *ControlAppRequestV1) GetBase() *BaseMessage {
+func (x *DockerEventV1) GetAppId() string {
 	if x != nil {
-		return x.Base
+		return x.AppId
 	}
-	return nil
+	return ""
 }
 
-func (x *ControlAppRequestV1) GetAppId() string {
+func (x *DockerEventV1) GetContainerId() string {
 	if x != nil {
-		return x.AppId
+		return x.ContainerId
 	}
 	return ""
 }
 
-func (x *ControlAppRequestV1) GetAction() AppAction {
+func (x *DockerEventV1) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *DockerEventV1) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DockerEventV1) GetAction() DockerEventAction {
 	if x != nil {
 		return x.Action
 	}
-	return AppAction_STOP
+	return DockerEventAction_DOCKER_EVENT_ACTION_UNKNOWN
 }
 
-type ControlAppResponseV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+func (x *DockerEventV1) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DockerEventV1) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *DockerEventV1) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Subscribes to the container events of managed containers until stopped or the stream ends.
+// Events are sent as StreamDockerEventsResponseV1 carrying the message id of the request.
+type StreamDockerEventsRequestV1 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// true ends the running subscription
+	Stop          bool `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ControlAppResponseV1) Reset() {
-	*x = ControlAppResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[24]
+func (x *StreamDockerEventsRequestV1) Reset() {
+	*x = StreamDockerEventsRequestV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ControlAppResponseV1) String() string {
+func (x *StreamDockerEventsRequestV1) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControlAppResponseV1) ProtoMessage() {}
+func (*StreamDockerEventsRequestV1) ProtoMessage() {}
 
-func (x *ControlAppResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[24]
+func (x *StreamDockerEventsRequestV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1664,18 +5425,86 @@ func (x *ControlAppResponseV1) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControlAppResponseV1.ProtoReflect.Descriptor instead.
-func (*ControlAppResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use StreamDockerEventsRequestV1.ProtoReflect.Descriptor instead.
+func (*StreamDockerEventsRequestV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *ControlAppResponseV1) GetBase() *BaseResponse {
+func (x *StreamDockerEventsRequestV1) GetBase() *BaseMessage {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *StreamDockerEventsRequestV1) GetStop() bool {
+	if x != nil {
+		return x.Stop
+	}
+	return false
+}
+
+type StreamDockerEventsResponseV1 struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Base   *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Events []*DockerEventV1       `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	// True for the last response of the subscription
+	Ended         bool `protobuf:"varint,3,opt,name=ended,proto3" json:"ended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamDockerEventsResponseV1) Reset() {
+	*x = StreamDockerEventsResponseV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDockerEventsResponseV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDockerEventsResponseV1) ProtoMessage() {}
+
+func (x *StreamDockerEventsResponseV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDockerEventsResponseV1.ProtoReflect.Descriptor instead.
+func (*StreamDockerEventsResponseV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *StreamDockerEventsResponseV1) GetBase() *BaseResponse {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
+func (x *StreamDockerEventsResponseV1) GetEvents() []*DockerEventV1 {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *StreamDockerEventsResponseV1) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
 type GetAppsStatusRequestV1 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -1685,7 +5514,7 @@ type GetAppsStatusRequestV1 struct {
 
 func (x *GetAppsStatusRequestV1) Reset() {
 	*x = GetAppsStatusRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[25]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1697,7 +5526,7 @@ func (x *GetAppsStatusRequestV1) String() string {
 func (*GetAppsStatusRequestV1) ProtoMessage() {}
 
 func (x *GetAppsStatusRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[25]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1710,7 +5539,7 @@ func (x *GetAppsStatusRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAppsStatusRequestV1.ProtoReflect.Descriptor instead.
 func (*GetAppsStatusRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{25}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *GetAppsStatusRequestV1) GetBase() *BaseMessage {
@@ -1730,7 +5559,7 @@ type GetAppsStatusResponseV1 struct {
 
 func (x *GetAppsStatusResponseV1) Reset() {
 	*x = GetAppsStatusResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[26]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +5571,7 @@ func (x *GetAppsStatusResponseV1) String() string {
 func (*GetAppsStatusResponseV1) ProtoMessage() {}
 
 func (x *GetAppsStatusResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[26]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +5584,7 @@ func (x *GetAppsStatusResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAppsStatusResponseV1.ProtoReflect.Descriptor instead.
 func (*GetAppsStatusResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{26}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{83}
 }
 
 func (x *GetAppsStatusResponseV1) GetBase() *BaseResponse {
@@ -1781,7 +5610,7 @@ type GetRegistriesRequestV1 struct {
 
 func (x *GetRegistriesRequestV1) Reset() {
 	*x = GetRegistriesRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[27]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1793,7 +5622,7 @@ func (x *GetRegistriesRequestV1) String() string {
 func (*GetRegistriesRequestV1) ProtoMessage() {}
 
 func (x *GetRegistriesRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[27]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1806,7 +5635,7 @@ func (x *GetRegistriesRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRegistriesRequestV1.ProtoReflect.Descriptor instead.
 func (*GetRegistriesRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{27}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *GetRegistriesRequestV1) GetBase() *BaseMessage {
@@ -1826,7 +5655,7 @@ type GetRegistriesResponseV1 struct {
 
 func (x *GetRegistriesResponseV1) Reset() {
 	*x = GetRegistriesResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[28]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1838,7 +5667,7 @@ func (x *GetRegistriesResponseV1) String() string {
 func (*GetRegistriesResponseV1) ProtoMessage() {}
 
 func (x *GetRegistriesResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[28]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1851,7 +5680,7 @@ func (x *GetRegistriesResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRegistriesResponseV1.ProtoReflect.Descriptor instead.
 func (*GetRegistriesResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{28}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *GetRegistriesResponseV1) GetBase() *BaseResponse {
@@ -1880,7 +5709,7 @@ type CreateRegistryRequestV1 struct {
 
 func (x *CreateRegistryRequestV1) Reset() {
 	*x = CreateRegistryRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[29]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1892,7 +5721,7 @@ func (x *CreateRegistryRequestV1) String() string {
 func (*CreateRegistryRequestV1) ProtoMessage() {}
 
 func (x *CreateRegistryRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[29]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1905,7 +5734,7 @@ func (x *CreateRegistryRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRegistryRequestV1.ProtoReflect.Descriptor instead.
 func (*CreateRegistryRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{29}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *CreateRegistryRequestV1) GetBase() *BaseMessage {
@@ -1945,7 +5774,7 @@ type CreateRegistryResponseV1 struct {
 
 func (x *CreateRegistryResponseV1) Reset() {
 	*x = CreateRegistryResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[30]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1957,7 +5786,7 @@ func (x *CreateRegistryResponseV1) String() string {
 func (*CreateRegistryResponseV1) ProtoMessage() {}
 
 func (x *CreateRegistryResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[30]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1970,7 +5799,7 @@ func (x *CreateRegistryResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRegistryResponseV1.ProtoReflect.Descriptor instead.
 func (*CreateRegistryResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{30}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *CreateRegistryResponseV1) GetBase() *BaseResponse {
@@ -1990,7 +5819,7 @@ type DeleteRegistryRequestV1 struct {
 
 func (x *DeleteRegistryRequestV1) Reset() {
 	*x = DeleteRegistryRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[31]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2002,7 +5831,7 @@ func (x *DeleteRegistryRequestV1) String() string {
 func (*DeleteRegistryRequestV1) ProtoMessage() {}
 
 func (x *DeleteRegistryRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[31]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2015,7 +5844,7 @@ func (x *DeleteRegistryRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRegistryRequestV1.ProtoReflect.Descriptor instead.
 func (*DeleteRegistryRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{31}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *DeleteRegistryRequestV1) GetBase() *BaseMessage {
@@ -2041,7 +5870,7 @@ type DeleteRegistryResponseV1 struct {
 
 func (x *DeleteRegistryResponseV1) Reset() {
 	*x = DeleteRegistryResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[32]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2053,7 +5882,7 @@ func (x *DeleteRegistryResponseV1) String() string {
 func (*DeleteRegistryResponseV1) ProtoMessage() {}
 
 func (x *DeleteRegistryResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[32]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2066,7 +5895,7 @@ func (x *DeleteRegistryResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRegistryResponseV1.ProtoReflect.Descriptor instead.
 func (*DeleteRegistryResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{32}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *DeleteRegistryResponseV1) GetBase() *BaseResponse {
@@ -2085,7 +5914,7 @@ type GetNetworksRequestV1 struct {
 
 func (x *GetNetworksRequestV1) Reset() {
 	*x = GetNetworksRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[33]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2097,7 +5926,7 @@ func (x *GetNetworksRequestV1) String() string {
 func (*GetNetworksRequestV1) ProtoMessage() {}
 
 func (x *GetNetworksRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[33]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2110,7 +5939,7 @@ func (x *GetNetworksRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworksRequestV1.ProtoReflect.Descriptor instead.
 func (*GetNetworksRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{33}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *GetNetworksRequestV1) GetBase() *BaseMessage {
@@ -2120,17 +5949,97 @@ func (x *GetNetworksRequestV1) GetBase() *BaseMessage {
 	return nil
 }
 
+type NetworkV1 struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Driver string                 `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	// local, global or swarm
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	// IPAM subnets in CIDR notation
+	Subnets        []string `protobuf:"bytes,4,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	ContainerCount uint32   `protobuf:"varint,5,opt,name=container_count,json=containerCount,proto3" json:"container_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NetworkV1) Reset() {
+	*x = NetworkV1{}
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkV1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkV1) ProtoMessage() {}
+
+func (x *NetworkV1) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkV1.ProtoReflect.Descriptor instead.
+func (*NetworkV1) Descriptor() ([]byte, []int) {
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *NetworkV1) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkV1) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *NetworkV1) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *NetworkV1) GetSubnets() []string {
+	if x != nil {
+		return x.Subnets
+	}
+	return nil
+}
+
+func (x *NetworkV1) GetContainerCount() uint32 {
+	if x != nil {
+		return x.ContainerCount
+	}
+	return 0
+}
+
 type GetNetworksResponseV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Name          []string               `protobuf:"bytes,2,rep,name=name,proto3" json:"name,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseResponse          `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// Deprecated: use networks
+	Name          []string     `protobuf:"bytes,2,rep,name=name,proto3" json:"name,omitempty"`
+	Networks      []*NetworkV1 `protobuf:"bytes,3,rep,name=networks,proto3" json:"networks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetNetworksResponseV1) Reset() {
 	*x = GetNetworksResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[34]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2142,7 +6051,7 @@ func (x *GetNetworksResponseV1) String() string {
 func (*GetNetworksResponseV1) ProtoMessage() {}
 
 func (x *GetNetworksResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[34]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2155,7 +6064,7 @@ func (x *GetNetworksResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNetworksResponseV1.ProtoReflect.Descriptor instead.
 func (*GetNetworksResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{34}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *GetNetworksResponseV1) GetBase() *BaseResponse {
@@ -2172,17 +6081,32 @@ func (x *GetNetworksResponseV1) GetName() []string {
 	return nil
 }
 
+func (x *GetNetworksResponseV1) GetNetworks() []*NetworkV1 {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
 type CreateNetworkRequestV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Network driver, e.g. bridge or overlay; the Docker default when empty
+	Driver string `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
+	// IPv4 and IPv6 subnets in CIDR notation; Docker assigns a subnet when empty
+	Subnets []string `protobuf:"bytes,4,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	// Gateway addresses, each within one of the subnets
+	Gateways      []string `protobuf:"bytes,5,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	Attachable    bool     `protobuf:"varint,6,opt,name=attachable,proto3" json:"attachable,omitempty"`
+	Internal      bool     `protobuf:"varint,7,opt,name=internal,proto3" json:"internal,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateNetworkRequestV1) Reset() {
 	*x = CreateNetworkRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[35]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2194,7 +6118,7 @@ func (x *CreateNetworkRequestV1) String() string {
 func (*CreateNetworkRequestV1) ProtoMessage() {}
 
 func (x *CreateNetworkRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[35]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2207,21 +6131,56 @@ func (x *CreateNetworkRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNetworkRequestV1.ProtoReflect.Descriptor instead.
 func (*CreateNetworkRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{35}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *CreateNetworkRequestV1) GetBase() *BaseMessage {
 	if x != nil {
 		return x.Base
 	}
-	return nil
+	return nil
+}
+
+func (x *CreateNetworkRequestV1) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequestV1) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *CreateNetworkRequestV1) GetSubnets() []string {
+	if x != nil {
+		return x.Subnets
+	}
+	return nil
+}
+
+func (x *CreateNetworkRequestV1) GetGateways() []string {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *CreateNetworkRequestV1) GetAttachable() bool {
+	if x != nil {
+		return x.Attachable
+	}
+	return false
 }
 
-func (x *CreateNetworkRequestV1) GetName() string {
+func (x *CreateNetworkRequestV1) GetInternal() bool {
 	if x != nil {
-		return x.Name
+		return x.Internal
 	}
-	return ""
+	return false
 }
 
 type CreateNetworkResponseV1 struct {
@@ -2233,7 +6192,7 @@ type CreateNetworkResponseV1 struct {
 
 func (x *CreateNetworkResponseV1) Reset() {
 	*x = CreateNetworkResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[36]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2245,7 +6204,7 @@ func (x *CreateNetworkResponseV1) String() string {
 func (*CreateNetworkResponseV1) ProtoMessage() {}
 
 func (x *CreateNetworkResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[36]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2258,7 +6217,7 @@ func (x *CreateNetworkResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateNetworkResponseV1.ProtoReflect.Descriptor instead.
 func (*CreateNetworkResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{36}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *CreateNetworkResponseV1) GetBase() *BaseResponse {
@@ -2278,7 +6237,7 @@ type DeleteNetworkRequestV1 struct {
 
 func (x *DeleteNetworkRequestV1) Reset() {
 	*x = DeleteNetworkRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[37]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2290,7 +6249,7 @@ func (x *DeleteNetworkRequestV1) String() string {
 func (*DeleteNetworkRequestV1) ProtoMessage() {}
 
 func (x *DeleteNetworkRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[37]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2303,7 +6262,7 @@ func (x *DeleteNetworkRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkRequestV1.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{37}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *DeleteNetworkRequestV1) GetBase() *BaseMessage {
@@ -2329,7 +6288,7 @@ type DeleteNetworkResponseV1 struct {
 
 func (x *DeleteNetworkResponseV1) Reset() {
 	*x = DeleteNetworkResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[38]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2341,7 +6300,7 @@ func (x *DeleteNetworkResponseV1) String() string {
 func (*DeleteNetworkResponseV1) ProtoMessage() {}
 
 func (x *DeleteNetworkResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[38]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2354,7 +6313,7 @@ func (x *DeleteNetworkResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNetworkResponseV1.ProtoReflect.Descriptor instead.
 func (*DeleteNetworkResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{38}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *DeleteNetworkResponseV1) GetBase() *BaseResponse {
@@ -2368,17 +6327,24 @@ type GetAppLogsRequestV1 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Base  *BaseMessage           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
 	// UUID
-	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
-	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
-	Tail          int32                  `protobuf:"varint,5,opt,name=tail,proto3" json:"tail,omitempty"`
+	AppId string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Since *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Until *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	Tail  int32                  `protobuf:"varint,5,opt,name=tail,proto3" json:"tail,omitempty"`
+	// Drops lines below the level, including lines of unknown level; LOG_LEVEL_UNKNOWN keeps all lines
+	LevelFilter LogLevel `protobuf:"varint,6,opt,name=level_filter,json=levelFilter,proto3,enum=pb.LogLevel" json:"level_filter,omitempty"`
+	// Regular expression (RE2 syntax) the message of a line must match; empty keeps all lines
+	GrepPattern string `protobuf:"bytes,7,opt,name=grep_pattern,json=grepPattern,proto3" json:"grep_pattern,omitempty"`
+	// ID, short ID or name of one container of the app to read the logs of; empty reads every container. A
+	// container that does not belong to the app is rejected with RESPONSE_CODE_INVALID_REQUEST.
+	ContainerId   string `protobuf:"bytes,8,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAppLogsRequestV1) Reset() {
 	*x = GetAppLogsRequestV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[39]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2390,7 +6356,7 @@ func (x *GetAppLogsRequestV1) String() string {
 func (*GetAppLogsRequestV1) ProtoMessage() {}
 
 func (x *GetAppLogsRequestV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[39]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2403,7 +6369,7 @@ func (x *GetAppLogsRequestV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAppLogsRequestV1.ProtoReflect.Descriptor instead.
 func (*GetAppLogsRequestV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{39}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *GetAppLogsRequestV1) GetBase() *BaseMessage {
@@ -2441,17 +6407,41 @@ func (x *GetAppLogsRequestV1) GetTail() int32 {
 	return 0
 }
 
+func (x *GetAppLogsRequestV1) GetLevelFilter() LogLevel {
+	if x != nil {
+		return x.LevelFilter
+	}
+	return LogLevel_LOG_LEVEL_UNKNOWN
+}
+
+func (x *GetAppLogsRequestV1) GetGrepPattern() string {
+	if x != nil {
+		return x.GrepPattern
+	}
+	return ""
+}
+
+func (x *GetAppLogsRequestV1) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
 type AppLogsV1 struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Containers    map[string]string      `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Logs          []*LogEntryV1          `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Containers map[string]string      `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Logs       []*LogEntryV1          `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+	// Set when the oldest entries were dropped to keep the response within the agent's max_log_bytes.
+	Truncated     bool   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	DroppedCount  uint32 `protobuf:"varint,4,opt,name=dropped_count,json=droppedCount,proto3" json:"dropped_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AppLogsV1) Reset() {
 	*x = AppLogsV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[40]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2463,7 +6453,7 @@ func (x *AppLogsV1) String() string {
 func (*AppLogsV1) ProtoMessage() {}
 
 func (x *AppLogsV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[40]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2476,7 +6466,7 @@ func (x *AppLogsV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppLogsV1.ProtoReflect.Descriptor instead.
 func (*AppLogsV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{40}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *AppLogsV1) GetContainers() map[string]string {
@@ -2493,6 +6483,20 @@ func (x *AppLogsV1) GetLogs() []*LogEntryV1 {
 	return nil
 }
 
+func (x *AppLogsV1) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *AppLogsV1) GetDroppedCount() uint32 {
+	if x != nil {
+		return x.DroppedCount
+	}
+	return 0
+}
+
 type LogEntryV1 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -2507,7 +6511,7 @@ type LogEntryV1 struct {
 
 func (x *LogEntryV1) Reset() {
 	*x = LogEntryV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[41]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2519,7 +6523,7 @@ func (x *LogEntryV1) String() string {
 func (*LogEntryV1) ProtoMessage() {}
 
 func (x *LogEntryV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[41]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2532,7 +6536,7 @@ func (x *LogEntryV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntryV1.ProtoReflect.Descriptor instead.
 func (*LogEntryV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{41}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *LogEntryV1) GetTimestamp() *timestamppb.Timestamp {
@@ -2587,7 +6591,7 @@ type GetAppLogsResponseV1 struct {
 
 func (x *GetAppLogsResponseV1) Reset() {
 	*x = GetAppLogsResponseV1{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[42]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2599,7 +6603,7 @@ func (x *GetAppLogsResponseV1) String() string {
 func (*GetAppLogsResponseV1) ProtoMessage() {}
 
 func (x *GetAppLogsResponseV1) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[42]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2612,7 +6616,7 @@ func (x *GetAppLogsResponseV1) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAppLogsResponseV1.ProtoReflect.Descriptor instead.
 func (*GetAppLogsResponseV1) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{42}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *GetAppLogsResponseV1) GetBase() *BaseResponse {
@@ -2650,6 +6654,27 @@ type ServerCommand struct {
 	//	*ServerCommand_CreateNetworkRequestV1
 	//	*ServerCommand_DeleteNetworkRequestV1
 	//	*ServerCommand_GetAppLogsRequestV1
+	//	*ServerCommand_GetAppRevisionsRequestV1
+	//	*ServerCommand_GetRenderedComposeRequestV1
+	//	*ServerCommand_ExportAppRequestV1
+	//	*ServerCommand_ImportAppRequestV1
+	//	*ServerCommand_GetSystemInfoRequestV1
+	//	*ServerCommand_SetMaintenanceModeRequestV1
+	//	*ServerCommand_GetAppResourcesRequestV1
+	//	*ServerCommand_GetAppsRequestV1
+	//	*ServerCommand_ValidateAppRequestV1
+	//	*ServerCommand_CancelOperationRequestV1
+	//	*ServerCommand_GetConnectionStatsRequestV1
+	//	*ServerCommand_StreamDockerEventsRequestV1
+	//	*ServerCommand_ReconcileAppRequestV1
+	//	*ServerCommand_GetAgentConfigRequestV1
+	//	*ServerCommand_GetVersionInfoRequestV1
+	//	*ServerCommand_CollectDiagnosticsRequestV1
+	//	*ServerCommand_StartAppsRequestV1
+	//	*ServerCommand_CheckForUpdateRequestV1
+	//	*ServerCommand_BackupVolumesRequestV1
+	//	*ServerCommand_GetAppMetricsRequestV1
+	//	*ServerCommand_ClearSafeModeRequestV1
 	Command       isServerCommand_Command `protobuf_oneof:"command"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -2657,7 +6682,7 @@ type ServerCommand struct {
 
 func (x *ServerCommand) Reset() {
 	*x = ServerCommand{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[43]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2669,7 +6694,7 @@ func (x *ServerCommand) String() string {
 func (*ServerCommand) ProtoMessage() {}
 
 func (x *ServerCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[43]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2682,7 +6707,7 @@ func (x *ServerCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerCommand.ProtoReflect.Descriptor instead.
 func (*ServerCommand) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{43}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *ServerCommand) GetCommand() isServerCommand_Command {
@@ -2836,6 +6861,195 @@ func (x *ServerCommand) GetGetAppLogsRequestV1() *GetAppLogsRequestV1 {
 	return nil
 }
 
+func (x *ServerCommand) GetGetAppRevisionsRequestV1() *GetAppRevisionsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetAppRevisionsRequestV1); ok {
+			return x.GetAppRevisionsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetRenderedComposeRequestV1() *GetRenderedComposeRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetRenderedComposeRequestV1); ok {
+			return x.GetRenderedComposeRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetExportAppRequestV1() *ExportAppRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_ExportAppRequestV1); ok {
+			return x.ExportAppRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetImportAppRequestV1() *ImportAppRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_ImportAppRequestV1); ok {
+			return x.ImportAppRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetSystemInfoRequestV1() *GetSystemInfoRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetSystemInfoRequestV1); ok {
+			return x.GetSystemInfoRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetSetMaintenanceModeRequestV1() *SetMaintenanceModeRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_SetMaintenanceModeRequestV1); ok {
+			return x.SetMaintenanceModeRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetAppResourcesRequestV1() *GetAppResourcesRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetAppResourcesRequestV1); ok {
+			return x.GetAppResourcesRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetAppsRequestV1() *GetAppsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetAppsRequestV1); ok {
+			return x.GetAppsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetValidateAppRequestV1() *ValidateAppRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_ValidateAppRequestV1); ok {
+			return x.ValidateAppRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetCancelOperationRequestV1() *CancelOperationRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_CancelOperationRequestV1); ok {
+			return x.CancelOperationRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetConnectionStatsRequestV1() *GetConnectionStatsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetConnectionStatsRequestV1); ok {
+			return x.GetConnectionStatsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetStreamDockerEventsRequestV1() *StreamDockerEventsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_StreamDockerEventsRequestV1); ok {
+			return x.StreamDockerEventsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetReconcileAppRequestV1() *ReconcileAppRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_ReconcileAppRequestV1); ok {
+			return x.ReconcileAppRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetAgentConfigRequestV1() *GetAgentConfigRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetAgentConfigRequestV1); ok {
+			return x.GetAgentConfigRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetVersionInfoRequestV1() *GetVersionInfoRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetVersionInfoRequestV1); ok {
+			return x.GetVersionInfoRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetCollectDiagnosticsRequestV1() *CollectDiagnosticsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_CollectDiagnosticsRequestV1); ok {
+			return x.CollectDiagnosticsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetStartAppsRequestV1() *StartAppsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_StartAppsRequestV1); ok {
+			return x.StartAppsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetCheckForUpdateRequestV1() *CheckForUpdateRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_CheckForUpdateRequestV1); ok {
+			return x.CheckForUpdateRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetBackupVolumesRequestV1() *BackupVolumesRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_BackupVolumesRequestV1); ok {
+			return x.BackupVolumesRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetGetAppMetricsRequestV1() *GetAppMetricsRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_GetAppMetricsRequestV1); ok {
+			return x.GetAppMetricsRequestV1
+		}
+	}
+	return nil
+}
+
+func (x *ServerCommand) GetClearSafeModeRequestV1() *ClearSafeModeRequestV1 {
+	if x != nil {
+		if x, ok := x.Command.(*ServerCommand_ClearSafeModeRequestV1); ok {
+			return x.ClearSafeModeRequestV1
+		}
+	}
+	return nil
+}
+
 type isServerCommand_Command interface {
 	isServerCommand_Command()
 }
@@ -2906,6 +7120,90 @@ type ServerCommand_GetAppLogsRequestV1 struct {
 	GetAppLogsRequestV1 *GetAppLogsRequestV1 `protobuf:"bytes,1014,opt,name=get_app_logs_request_v1,json=getAppLogsRequestV1,proto3,oneof"`
 }
 
+type ServerCommand_GetAppRevisionsRequestV1 struct {
+	GetAppRevisionsRequestV1 *GetAppRevisionsRequestV1 `protobuf:"bytes,1015,opt,name=get_app_revisions_request_v1,json=getAppRevisionsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetRenderedComposeRequestV1 struct {
+	GetRenderedComposeRequestV1 *GetRenderedComposeRequestV1 `protobuf:"bytes,1016,opt,name=get_rendered_compose_request_v1,json=getRenderedComposeRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_ExportAppRequestV1 struct {
+	ExportAppRequestV1 *ExportAppRequestV1 `protobuf:"bytes,1017,opt,name=export_app_request_v1,json=exportAppRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_ImportAppRequestV1 struct {
+	ImportAppRequestV1 *ImportAppRequestV1 `protobuf:"bytes,1018,opt,name=import_app_request_v1,json=importAppRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetSystemInfoRequestV1 struct {
+	GetSystemInfoRequestV1 *GetSystemInfoRequestV1 `protobuf:"bytes,1019,opt,name=get_system_info_request_v1,json=getSystemInfoRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_SetMaintenanceModeRequestV1 struct {
+	SetMaintenanceModeRequestV1 *SetMaintenanceModeRequestV1 `protobuf:"bytes,1020,opt,name=set_maintenance_mode_request_v1,json=setMaintenanceModeRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetAppResourcesRequestV1 struct {
+	GetAppResourcesRequestV1 *GetAppResourcesRequestV1 `protobuf:"bytes,1021,opt,name=get_app_resources_request_v1,json=getAppResourcesRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetAppsRequestV1 struct {
+	GetAppsRequestV1 *GetAppsRequestV1 `protobuf:"bytes,1022,opt,name=get_apps_request_v1,json=getAppsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_ValidateAppRequestV1 struct {
+	ValidateAppRequestV1 *ValidateAppRequestV1 `protobuf:"bytes,1023,opt,name=validate_app_request_v1,json=validateAppRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_CancelOperationRequestV1 struct {
+	CancelOperationRequestV1 *CancelOperationRequestV1 `protobuf:"bytes,1024,opt,name=cancel_operation_request_v1,json=cancelOperationRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetConnectionStatsRequestV1 struct {
+	GetConnectionStatsRequestV1 *GetConnectionStatsRequestV1 `protobuf:"bytes,1025,opt,name=get_connection_stats_request_v1,json=getConnectionStatsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_StreamDockerEventsRequestV1 struct {
+	StreamDockerEventsRequestV1 *StreamDockerEventsRequestV1 `protobuf:"bytes,1026,opt,name=stream_docker_events_request_v1,json=streamDockerEventsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_ReconcileAppRequestV1 struct {
+	ReconcileAppRequestV1 *ReconcileAppRequestV1 `protobuf:"bytes,1027,opt,name=reconcile_app_request_v1,json=reconcileAppRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetAgentConfigRequestV1 struct {
+	GetAgentConfigRequestV1 *GetAgentConfigRequestV1 `protobuf:"bytes,1029,opt,name=get_agent_config_request_v1,json=getAgentConfigRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetVersionInfoRequestV1 struct {
+	GetVersionInfoRequestV1 *GetVersionInfoRequestV1 `protobuf:"bytes,1030,opt,name=get_version_info_request_v1,json=getVersionInfoRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_CollectDiagnosticsRequestV1 struct {
+	CollectDiagnosticsRequestV1 *CollectDiagnosticsRequestV1 `protobuf:"bytes,1031,opt,name=collect_diagnostics_request_v1,json=collectDiagnosticsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_StartAppsRequestV1 struct {
+	StartAppsRequestV1 *StartAppsRequestV1 `protobuf:"bytes,1032,opt,name=start_apps_request_v1,json=startAppsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_CheckForUpdateRequestV1 struct {
+	CheckForUpdateRequestV1 *CheckForUpdateRequestV1 `protobuf:"bytes,1033,opt,name=check_for_update_request_v1,json=checkForUpdateRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_BackupVolumesRequestV1 struct {
+	BackupVolumesRequestV1 *BackupVolumesRequestV1 `protobuf:"bytes,1034,opt,name=backup_volumes_request_v1,json=backupVolumesRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_GetAppMetricsRequestV1 struct {
+	GetAppMetricsRequestV1 *GetAppMetricsRequestV1 `protobuf:"bytes,1035,opt,name=get_app_metrics_request_v1,json=getAppMetricsRequestV1,proto3,oneof"`
+}
+
+type ServerCommand_ClearSafeModeRequestV1 struct {
+	ClearSafeModeRequestV1 *ClearSafeModeRequestV1 `protobuf:"bytes,1036,opt,name=clear_safe_mode_request_v1,json=clearSafeModeRequestV1,proto3,oneof"`
+}
+
 func (*ServerCommand_HeartbeatResponseV1) isServerCommand_Command() {}
 
 func (*ServerCommand_MetricsResponseV1) isServerCommand_Command() {}
@@ -2914,29 +7212,71 @@ func (*ServerCommand_UpdateAgentRequestV1) isServerCommand_Command() {}
 
 func (*ServerCommand_GetAppRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_SaveAppRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_SaveAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_RenameAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_DeleteAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_ControlAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetAppsStatusRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetRegistriesRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_CreateRegistryRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_DeleteRegistryRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetNetworksRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_CreateNetworkRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_DeleteNetworkRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetAppLogsRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetAppRevisionsRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetRenderedComposeRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_ExportAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_ImportAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetSystemInfoRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_SetMaintenanceModeRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetAppResourcesRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_GetAppsRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_ValidateAppRequestV1) isServerCommand_Command() {}
+
+func (*ServerCommand_CancelOperationRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_RenameAppRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_GetConnectionStatsRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_DeleteAppRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_StreamDockerEventsRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_ControlAppRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_ReconcileAppRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_GetAppsStatusRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_GetAgentConfigRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_GetRegistriesRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_GetVersionInfoRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_CreateRegistryRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_CollectDiagnosticsRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_DeleteRegistryRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_StartAppsRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_GetNetworksRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_CheckForUpdateRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_CreateNetworkRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_BackupVolumesRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_DeleteNetworkRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_GetAppMetricsRequestV1) isServerCommand_Command() {}
 
-func (*ServerCommand_GetAppLogsRequestV1) isServerCommand_Command() {}
+func (*ServerCommand_ClearSafeModeRequestV1) isServerCommand_Command() {}
 
 type AgentMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2958,6 +7298,29 @@ type AgentMessage struct {
 	//	*AgentMessage_CreateNetworkResponseV1
 	//	*AgentMessage_DeleteNetworkResponseV1
 	//	*AgentMessage_GetAppLogsResponseV1
+	//	*AgentMessage_GetAppRevisionsResponseV1
+	//	*AgentMessage_GetRenderedComposeResponseV1
+	//	*AgentMessage_ExportAppResponseV1
+	//	*AgentMessage_ImportAppResponseV1
+	//	*AgentMessage_GetSystemInfoResponseV1
+	//	*AgentMessage_SetMaintenanceModeResponseV1
+	//	*AgentMessage_GetAppResourcesResponseV1
+	//	*AgentMessage_GetAppsResponseV1
+	//	*AgentMessage_ValidateAppResponseV1
+	//	*AgentMessage_CancelOperationResponseV1
+	//	*AgentMessage_GetConnectionStatsResponseV1
+	//	*AgentMessage_StreamDockerEventsResponseV1
+	//	*AgentMessage_ReconcileAppResponseV1
+	//	*AgentMessage_AppOutputV1
+	//	*AgentMessage_GetAgentConfigResponseV1
+	//	*AgentMessage_AppProgressV1
+	//	*AgentMessage_GetVersionInfoResponseV1
+	//	*AgentMessage_CollectDiagnosticsResponseV1
+	//	*AgentMessage_StartAppsResponseV1
+	//	*AgentMessage_CheckForUpdateResponseV1
+	//	*AgentMessage_BackupVolumesResponseV1
+	//	*AgentMessage_GetAppMetricsResponseV1
+	//	*AgentMessage_ClearSafeModeResponseV1
 	Message       isAgentMessage_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -2965,7 +7328,7 @@ type AgentMessage struct {
 
 func (x *AgentMessage) Reset() {
 	*x = AgentMessage{}
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[44]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2977,7 +7340,7 @@ func (x *AgentMessage) String() string {
 func (*AgentMessage) ProtoMessage() {}
 
 func (x *AgentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[44]
+	mi := &file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2990,7 +7353,7 @@ func (x *AgentMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentMessage.ProtoReflect.Descriptor instead.
 func (*AgentMessage) Descriptor() ([]byte, []int) {
-	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{44}
+	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *AgentMessage) GetMessage() isAgentMessage_Message {
@@ -3144,6 +7507,213 @@ func (x *AgentMessage) GetGetAppLogsResponseV1() *GetAppLogsResponseV1 {
 	return nil
 }
 
+func (x *AgentMessage) GetGetAppRevisionsResponseV1() *GetAppRevisionsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetAppRevisionsResponseV1); ok {
+			return x.GetAppRevisionsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetRenderedComposeResponseV1() *GetRenderedComposeResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetRenderedComposeResponseV1); ok {
+			return x.GetRenderedComposeResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetExportAppResponseV1() *ExportAppResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_ExportAppResponseV1); ok {
+			return x.ExportAppResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetImportAppResponseV1() *ImportAppResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_ImportAppResponseV1); ok {
+			return x.ImportAppResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetSystemInfoResponseV1() *GetSystemInfoResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetSystemInfoResponseV1); ok {
+			return x.GetSystemInfoResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetSetMaintenanceModeResponseV1() *SetMaintenanceModeResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_SetMaintenanceModeResponseV1); ok {
+			return x.SetMaintenanceModeResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetAppResourcesResponseV1() *GetAppResourcesResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetAppResourcesResponseV1); ok {
+			return x.GetAppResourcesResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetAppsResponseV1() *GetAppsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetAppsResponseV1); ok {
+			return x.GetAppsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetValidateAppResponseV1() *ValidateAppResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_ValidateAppResponseV1); ok {
+			return x.ValidateAppResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetCancelOperationResponseV1() *CancelOperationResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_CancelOperationResponseV1); ok {
+			return x.CancelOperationResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetConnectionStatsResponseV1() *GetConnectionStatsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetConnectionStatsResponseV1); ok {
+			return x.GetConnectionStatsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetStreamDockerEventsResponseV1() *StreamDockerEventsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_StreamDockerEventsResponseV1); ok {
+			return x.StreamDockerEventsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetReconcileAppResponseV1() *ReconcileAppResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_ReconcileAppResponseV1); ok {
+			return x.ReconcileAppResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetAppOutputV1() *AppOutputV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_AppOutputV1); ok {
+			return x.AppOutputV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetAgentConfigResponseV1() *GetAgentConfigResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetAgentConfigResponseV1); ok {
+			return x.GetAgentConfigResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetAppProgressV1() *AppProgressV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_AppProgressV1); ok {
+			return x.AppProgressV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetVersionInfoResponseV1() *GetVersionInfoResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetVersionInfoResponseV1); ok {
+			return x.GetVersionInfoResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetCollectDiagnosticsResponseV1() *CollectDiagnosticsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_CollectDiagnosticsResponseV1); ok {
+			return x.CollectDiagnosticsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetStartAppsResponseV1() *StartAppsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_StartAppsResponseV1); ok {
+			return x.StartAppsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetCheckForUpdateResponseV1() *CheckForUpdateResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_CheckForUpdateResponseV1); ok {
+			return x.CheckForUpdateResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetBackupVolumesResponseV1() *BackupVolumesResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_BackupVolumesResponseV1); ok {
+			return x.BackupVolumesResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetGetAppMetricsResponseV1() *GetAppMetricsResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_GetAppMetricsResponseV1); ok {
+			return x.GetAppMetricsResponseV1
+		}
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetClearSafeModeResponseV1() *ClearSafeModeResponseV1 {
+	if x != nil {
+		if x, ok := x.Message.(*AgentMessage_ClearSafeModeResponseV1); ok {
+			return x.ClearSafeModeResponseV1
+		}
+	}
+	return nil
+}
+
 type isAgentMessage_Message interface {
 	isAgentMessage_Message()
 }
@@ -3214,6 +7784,98 @@ type AgentMessage_GetAppLogsResponseV1 struct {
 	GetAppLogsResponseV1 *GetAppLogsResponseV1 `protobuf:"bytes,1014,opt,name=get_app_logs_response_v1,json=getAppLogsResponseV1,proto3,oneof"`
 }
 
+type AgentMessage_GetAppRevisionsResponseV1 struct {
+	GetAppRevisionsResponseV1 *GetAppRevisionsResponseV1 `protobuf:"bytes,1015,opt,name=get_app_revisions_response_v1,json=getAppRevisionsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetRenderedComposeResponseV1 struct {
+	GetRenderedComposeResponseV1 *GetRenderedComposeResponseV1 `protobuf:"bytes,1016,opt,name=get_rendered_compose_response_v1,json=getRenderedComposeResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_ExportAppResponseV1 struct {
+	ExportAppResponseV1 *ExportAppResponseV1 `protobuf:"bytes,1017,opt,name=export_app_response_v1,json=exportAppResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_ImportAppResponseV1 struct {
+	ImportAppResponseV1 *ImportAppResponseV1 `protobuf:"bytes,1018,opt,name=import_app_response_v1,json=importAppResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetSystemInfoResponseV1 struct {
+	GetSystemInfoResponseV1 *GetSystemInfoResponseV1 `protobuf:"bytes,1019,opt,name=get_system_info_response_v1,json=getSystemInfoResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_SetMaintenanceModeResponseV1 struct {
+	SetMaintenanceModeResponseV1 *SetMaintenanceModeResponseV1 `protobuf:"bytes,1020,opt,name=set_maintenance_mode_response_v1,json=setMaintenanceModeResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetAppResourcesResponseV1 struct {
+	GetAppResourcesResponseV1 *GetAppResourcesResponseV1 `protobuf:"bytes,1021,opt,name=get_app_resources_response_v1,json=getAppResourcesResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetAppsResponseV1 struct {
+	GetAppsResponseV1 *GetAppsResponseV1 `protobuf:"bytes,1022,opt,name=get_apps_response_v1,json=getAppsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_ValidateAppResponseV1 struct {
+	ValidateAppResponseV1 *ValidateAppResponseV1 `protobuf:"bytes,1023,opt,name=validate_app_response_v1,json=validateAppResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_CancelOperationResponseV1 struct {
+	CancelOperationResponseV1 *CancelOperationResponseV1 `protobuf:"bytes,1024,opt,name=cancel_operation_response_v1,json=cancelOperationResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetConnectionStatsResponseV1 struct {
+	GetConnectionStatsResponseV1 *GetConnectionStatsResponseV1 `protobuf:"bytes,1025,opt,name=get_connection_stats_response_v1,json=getConnectionStatsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_StreamDockerEventsResponseV1 struct {
+	StreamDockerEventsResponseV1 *StreamDockerEventsResponseV1 `protobuf:"bytes,1026,opt,name=stream_docker_events_response_v1,json=streamDockerEventsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_ReconcileAppResponseV1 struct {
+	ReconcileAppResponseV1 *ReconcileAppResponseV1 `protobuf:"bytes,1027,opt,name=reconcile_app_response_v1,json=reconcileAppResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_AppOutputV1 struct {
+	AppOutputV1 *AppOutputV1 `protobuf:"bytes,1028,opt,name=app_output_v1,json=appOutputV1,proto3,oneof"`
+}
+
+type AgentMessage_GetAgentConfigResponseV1 struct {
+	GetAgentConfigResponseV1 *GetAgentConfigResponseV1 `protobuf:"bytes,1029,opt,name=get_agent_config_response_v1,json=getAgentConfigResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_AppProgressV1 struct {
+	AppProgressV1 *AppProgressV1 `protobuf:"bytes,1030,opt,name=app_progress_v1,json=appProgressV1,proto3,oneof"`
+}
+
+type AgentMessage_GetVersionInfoResponseV1 struct {
+	GetVersionInfoResponseV1 *GetVersionInfoResponseV1 `protobuf:"bytes,1031,opt,name=get_version_info_response_v1,json=getVersionInfoResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_CollectDiagnosticsResponseV1 struct {
+	CollectDiagnosticsResponseV1 *CollectDiagnosticsResponseV1 `protobuf:"bytes,1032,opt,name=collect_diagnostics_response_v1,json=collectDiagnosticsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_StartAppsResponseV1 struct {
+	StartAppsResponseV1 *StartAppsResponseV1 `protobuf:"bytes,1033,opt,name=start_apps_response_v1,json=startAppsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_CheckForUpdateResponseV1 struct {
+	CheckForUpdateResponseV1 *CheckForUpdateResponseV1 `protobuf:"bytes,1034,opt,name=check_for_update_response_v1,json=checkForUpdateResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_BackupVolumesResponseV1 struct {
+	BackupVolumesResponseV1 *BackupVolumesResponseV1 `protobuf:"bytes,1035,opt,name=backup_volumes_response_v1,json=backupVolumesResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_GetAppMetricsResponseV1 struct {
+	GetAppMetricsResponseV1 *GetAppMetricsResponseV1 `protobuf:"bytes,1036,opt,name=get_app_metrics_response_v1,json=getAppMetricsResponseV1,proto3,oneof"`
+}
+
+type AgentMessage_ClearSafeModeResponseV1 struct {
+	ClearSafeModeResponseV1 *ClearSafeModeResponseV1 `protobuf:"bytes,1037,opt,name=clear_safe_mode_response_v1,json=clearSafeModeResponseV1,proto3,oneof"`
+}
+
 func (*AgentMessage_HeartbeatV1) isAgentMessage_Message() {}
 
 func (*AgentMessage_MetricsV1) isAgentMessage_Message() {}
@@ -3246,16 +7908,63 @@ func (*AgentMessage_DeleteNetworkResponseV1) isAgentMessage_Message() {}
 
 func (*AgentMessage_GetAppLogsResponseV1) isAgentMessage_Message() {}
 
+func (*AgentMessage_GetAppRevisionsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetRenderedComposeResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_ExportAppResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_ImportAppResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetSystemInfoResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_SetMaintenanceModeResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetAppResourcesResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetAppsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_ValidateAppResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_CancelOperationResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetConnectionStatsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_StreamDockerEventsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_ReconcileAppResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_AppOutputV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetAgentConfigResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_AppProgressV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetVersionInfoResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_CollectDiagnosticsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_StartAppsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_CheckForUpdateResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_BackupVolumesResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_GetAppMetricsResponseV1) isAgentMessage_Message() {}
+
+func (*AgentMessage_ClearSafeModeResponseV1) isAgentMessage_Message() {}
+
 var File_internal_infra_winterflow_grpc_pb_server_proto protoreflect.FileDescriptor
 
 const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\n" +
-	".internal/infra/winterflow/grpc/pb/server.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x01\n" +
+	".internal/infra/winterflow/grpc/pb/server.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x01\n" +
 	"\vBaseMessage\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x128\n" +
 	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
-	"\bagent_id\x18\x03 \x01(\tR\aagentId\"\xd3\x01\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\"\xd3\x01\n" +
 	"\fBaseResponse\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x128\n" +
@@ -3289,25 +7998,27 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\vstatus_code\x18\x03 \x01(\x0e2\x17.pb.ContainerStatusCodeR\n" +
 	"statusCode\x12\x1b\n" +
 	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\"\x95\x01\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xb1\x01\n" +
 	"\vAppStatusV1\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x128\n" +
 	"\vstatus_code\x18\x02 \x01(\x0e2\x17.pb.ContainerStatusCodeR\n" +
 	"statusCode\x125\n" +
 	"\n" +
 	"containers\x18\x03 \x03(\v2\x15.pb.ContainerStatusV1R\n" +
-	"containers\"5\n" +
+	"containers\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\rR\brevision\"5\n" +
 	"\tAppFileV1\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\fR\acontent\"4\n" +
 	"\bAppVarV1\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\fR\acontent\"\x87\x01\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"\xb2\x01\n" +
 	"\x05AppV1\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x16\n" +
 	"\x06config\x18\x02 \x01(\fR\x06config\x12*\n" +
 	"\tvariables\x18\x03 \x03(\v2\f.pb.AppVarV1R\tvariables\x12#\n" +
-	"\x05files\x18\x04 \x03(\v2\r.pb.AppFileV1R\x05files\"p\n" +
+	"\x05files\x18\x04 \x03(\v2\r.pb.AppFileV1R\x05files\x12)\n" +
+	"\x10compose_override\x18\x05 \x01(\fR\x0fcomposeOverride\"p\n" +
 	"\x0fGetAppRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
 	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12!\n" +
@@ -3316,7 +8027,206 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x1b\n" +
 	"\x03app\x18\x02 \x01(\v2\t.pb.AppV1R\x03app\x12!\n" +
 	"\fapp_revision\x18\x03 \x01(\rR\vappRevision\x12/\n" +
-	"\x13available_revisions\x18\x04 \x03(\rR\x12availableRevisions\"U\n" +
+	"\x13available_revisions\x18\x04 \x03(\rR\x12availableRevisions\"o\n" +
+	"\x10GetAppsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x17\n" +
+	"\aapp_ids\x18\x02 \x03(\tR\x06appIds\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x7f\n" +
+	"\fAppDetailsV1\x12\x1b\n" +
+	"\x03app\x18\x01 \x01(\v2\t.pb.AppV1R\x03app\x12!\n" +
+	"\fapp_revision\x18\x02 \x01(\rR\vappRevision\x12/\n" +
+	"\x13available_revisions\x18\x03 \x03(\rR\x12availableRevisions\"\x87\x01\n" +
+	"\x11GetAppsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12$\n" +
+	"\x04apps\x18\x02 \x03(\v2\x10.pb.AppDetailsV1R\x04apps\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"R\n" +
+	"\x14ValidateAppRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"7\n" +
+	"\x11MissingVariableV1\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xbb\x01\n" +
+	"\x15ValidateAppResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12!\n" +
+	"\fapp_revision\x18\x03 \x01(\rR\vappRevision\x12B\n" +
+	"\x11missing_variables\x18\x04 \x03(\v2\x15.pb.MissingVariableV1R\x10missingVariables\"V\n" +
+	"\x18GetAppRevisionsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"\x94\x01\n" +
+	"\rAppRevisionV1\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\rR\brevision\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"\x89\x01\n" +
+	"\x19GetAppRevisionsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12/\n" +
+	"\trevisions\x18\x03 \x03(\v2\x11.pb.AppRevisionV1R\trevisions\"|\n" +
+	"\x1bGetRenderedComposeRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12!\n" +
+	"\fapp_revision\x18\x03 \x01(\rR\vappRevision\"\x98\x01\n" +
+	"\x1cGetRenderedComposeResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12!\n" +
+	"\fapp_revision\x18\x03 \x01(\rR\vappRevision\x12\x18\n" +
+	"\acompose\x18\x04 \x01(\tR\acompose\"V\n" +
+	"\x18GetAppResourcesRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"\xab\x02\n" +
+	"\x14ContainerResourcesV1\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tcpu_limit\x18\x03 \x01(\x01R\bcpuLimit\x12,\n" +
+	"\x12memory_limit_bytes\x18\x04 \x01(\x04R\x10memoryLimitBytes\x12\x1f\n" +
+	"\vcpu_percent\x18\x05 \x01(\x01R\n" +
+	"cpuPercent\x12,\n" +
+	"\x12memory_usage_bytes\x18\x06 \x01(\x04R\x10memoryUsageBytes\x12\x1d\n" +
+	"\n" +
+	"oom_killed\x18\a \x01(\bR\toomKilled\x12#\n" +
+	"\rrestart_count\x18\b \x01(\rR\frestartCount\"\x92\x01\n" +
+	"\x19GetAppResourcesResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x128\n" +
+	"\n" +
+	"containers\x18\x03 \x03(\v2\x18.pb.ContainerResourcesV1R\n" +
+	"containers\"T\n" +
+	"\x16GetAppMetricsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"X\n" +
+	"\x10DurationBucketV1\x12.\n" +
+	"\x13upper_bound_seconds\x18\x01 \x01(\x01R\x11upperBoundSeconds\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xf9\x01\n" +
+	"\x15AppOperationMetricsV1\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x1c\n" +
+	"\tsuccesses\x18\x03 \x01(\x04R\tsuccesses\x12\x1a\n" +
+	"\bfailures\x18\x04 \x01(\x04R\bfailures\x12?\n" +
+	"\x10duration_buckets\x18\x05 \x03(\v2\x14.pb.DurationBucketV1R\x0fdurationBuckets\x120\n" +
+	"\x14duration_sum_seconds\x18\x06 \x01(\x01R\x12durationSumSeconds\"z\n" +
+	"\x17GetAppMetricsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x129\n" +
+	"\n" +
+	"operations\x18\x02 \x03(\v2\x19.pb.AppOperationMetricsV1R\n" +
+	"operations\"=\n" +
+	"\x16GetSystemInfoRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\x95\x03\n" +
+	"\fSystemInfoV1\x12\x0e\n" +
+	"\x02os\x18\x01 \x01(\tR\x02os\x12%\n" +
+	"\x0ekernel_version\x18\x02 \x01(\tR\rkernelVersion\x12%\n" +
+	"\x0edocker_version\x18\x03 \x01(\tR\rdockerVersion\x12,\n" +
+	"\x12docker_api_version\x18\x04 \x01(\tR\x10dockerApiVersion\x12(\n" +
+	"\x10disk_total_bytes\x18\x05 \x01(\x04R\x0ediskTotalBytes\x120\n" +
+	"\x14disk_available_bytes\x18\x06 \x01(\x04R\x12diskAvailableBytes\x12,\n" +
+	"\x12memory_total_bytes\x18\a \x01(\x04R\x10memoryTotalBytes\x12\x1b\n" +
+	"\tcpu_count\x18\b \x01(\rR\bcpuCount\x120\n" +
+	"\x14agent_uptime_seconds\x18\t \x01(\x04R\x12agentUptimeSeconds\x12 \n" +
+	"\vunavailable\x18\n" +
+	" \x03(\tR\vunavailable\"r\n" +
+	"\x17GetSystemInfoResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x121\n" +
+	"\vsystem_info\x18\x02 \x01(\v2\x10.pb.SystemInfoV1R\n" +
+	"systemInfo\"B\n" +
+	"\x1bGetConnectionStatsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\x81\x01\n" +
+	"\x16ConnectionDisconnectV1\x12*\n" +
+	"\x02at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12%\n" +
+	"\x0euptime_seconds\x18\x02 \x01(\x04R\ruptimeSeconds\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xdf\x03\n" +
+	"\x11ConnectionStatsV1\x12C\n" +
+	"\x0fconnected_since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x0econnectedSince\x124\n" +
+	"\x16current_uptime_seconds\x18\x02 \x01(\x04R\x14currentUptimeSeconds\x120\n" +
+	"\x14total_uptime_seconds\x18\x03 \x01(\x04R\x12totalUptimeSeconds\x12)\n" +
+	"\x10disconnect_count\x18\x04 \x01(\x04R\x0fdisconnectCount\x12H\n" +
+	"\x12last_disconnect_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10lastDisconnectAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12>\n" +
+	"\rlast_error_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vlastErrorAt\x12I\n" +
+	"\x12recent_disconnects\x18\b \x03(\v2\x1a.pb.ConnectionDisconnectV1R\x11recentDisconnects\"q\n" +
+	"\x1cGetConnectionStatsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12+\n" +
+	"\x05stats\x18\x02 \x01(\v2\x15.pb.ConnectionStatsV1R\x05stats\">\n" +
+	"\x17GetAgentConfigRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\xb3\x02\n" +
+	"\x18GetAgentConfigResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12#\n" +
+	"\rsettings_json\x18\x02 \x01(\tR\fsettingsJson\x12Y\n" +
+	"\x0fbuild_overrides\x18\x03 \x03(\v20.pb.GetAgentConfigResponseV1.BuildOverridesEntryR\x0ebuildOverrides\x12.\n" +
+	"\x13grpc_server_address\x18\x04 \x01(\tR\x11grpcServerAddress\x1aA\n" +
+	"\x13BuildOverridesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x1bCollectDiagnosticsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x02 \x01(\tR\tuploadUrl\"\xc8\x01\n" +
+	"\x1cCollectDiagnosticsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x16\n" +
+	"\x06bundle\x18\x02 \x01(\fR\x06bundle\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x04R\x04size\x12\x1a\n" +
+	"\buploaded\x18\x04 \x01(\bR\buploaded\x12\x18\n" +
+	"\aentries\x18\x05 \x03(\tR\aentries\x12 \n" +
+	"\vunavailable\x18\x06 \x03(\tR\vunavailable\">\n" +
+	"\x17GetVersionInfoRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\xb2\x03\n" +
+	"\x18GetVersionInfoResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12#\n" +
+	"\ragent_version\x18\x02 \x01(\tR\fagentVersion\x122\n" +
+	"\x15agent_numeric_version\x18\x03 \x01(\x05R\x13agentNumericVersion\x12\"\n" +
+	"\forchestrator\x18\x04 \x01(\tR\forchestrator\x121\n" +
+	"\x14orchestrator_version\x18\x05 \x01(\tR\x13orchestratorVersion\x12%\n" +
+	"\x0edocker_version\x18\x06 \x01(\tR\rdockerVersion\x12,\n" +
+	"\x12docker_api_version\x18\a \x01(\tR\x10dockerApiVersion\x12\x0e\n" +
+	"\x02os\x18\b \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\t \x01(\tR\x04arch\x12%\n" +
+	"\x0ekernel_version\x18\n" +
+	" \x01(\tR\rkernelVersion\x12 \n" +
+	"\vunavailable\x18\v \x03(\tR\vunavailable\">\n" +
+	"\x17CheckForUpdateRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\xf2\x01\n" +
+	"\x18CheckForUpdateResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12'\n" +
+	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12%\n" +
+	"\x0elatest_version\x18\x03 \x01(\tR\rlatestVersion\x12)\n" +
+	"\x10update_available\x18\x04 \x01(\bR\x0fupdateAvailable\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\x03R\tcheckedAt\x12\x16\n" +
+	"\x06cached\x18\x06 \x01(\bR\x06cached\"\\\n" +
+	"\x1bSetMaintenanceModeRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"^\n" +
+	"\x1cSetMaintenanceModeResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"=\n" +
+	"\x16ClearSafeModeRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"`\n" +
+	"\x17ClearSafeModeResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x1f\n" +
+	"\vwas_enabled\x18\x02 \x01(\bR\n" +
+	"wasEnabled\"\x82\x01\n" +
+	"\x12ImportAppRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x18\n" +
+	"\aarchive\x18\x03 \x01(\fR\aarchive\x12\x16\n" +
+	"\x06deploy\x18\x04 \x01(\bR\x06deploy\"R\n" +
+	"\x13ImportAppResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"u\n" +
+	"\x12ExportAppRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12#\n" +
+	"\rall_revisions\x18\x03 \x01(\bR\fallRevisions\"\xaa\x01\n" +
+	"\x13ExportAppResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\x12\x1f\n" +
+	"\vchunk_index\x18\x04 \x01(\rR\n" +
+	"chunkIndex\x12\x1f\n" +
+	"\vchunk_count\x18\x05 \x01(\rR\n" +
+	"chunkCount\"U\n" +
 	"\x14UpdateAgentRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\"=\n" +
@@ -3332,18 +8242,85 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x19\n" +
 	"\bapp_name\x18\x03 \x01(\tR\aappName\";\n" +
 	"\x13RenameAppResponseV1\x12$\n" +
-	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"P\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"f\n" +
 	"\x12DeleteAppRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
-	"\x06app_id\x18\x02 \x01(\tR\x05appId\";\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x14\n" +
+	"\x05purge\x18\x03 \x01(\bR\x05purge\";\n" +
 	"\x13DeleteAppResponseV1\x12$\n" +
-	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"x\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"\xf8\x01\n" +
 	"\x13ControlAppRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
 	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12%\n" +
-	"\x06action\x18\x03 \x01(\x0e2\r.pb.AppActionR\x06action\"<\n" +
+	"\x06action\x18\x03 \x01(\x0e2\r.pb.AppActionR\x06action\x12#\n" +
+	"\rstream_output\x18\x04 \x01(\bR\fstreamOutput\x12'\n" +
+	"\x0freport_progress\x18\x05 \x01(\bR\x0ereportProgress\x12\x1a\n" +
+	"\bservices\x18\x06 \x03(\tR\bservices\x12\x14\n" +
+	"\x05force\x18\a \x01(\bR\x05force\"<\n" +
 	"\x14ControlAppResponseV1\x12$\n" +
-	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"=\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"O\n" +
+	"\x0fAppOutputLineV1\x12(\n" +
+	"\achannel\x18\x01 \x01(\x0e2\x0e.pb.LogChannelR\achannel\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\x8b\x01\n" +
+	"\vAppOutputV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12)\n" +
+	"\x05lines\x18\x03 \x03(\v2\x13.pb.AppOutputLineV1R\x05lines\x12\x14\n" +
+	"\x05ended\x18\x04 \x01(\bR\x05ended\"s\n" +
+	"\rAppProgressV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12%\n" +
+	"\x05stage\x18\x03 \x01(\x0e2\x0f.pb.DeployStageR\x05stage\"V\n" +
+	"\x18CancelOperationRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"t\n" +
+	"\x19CancelOperationResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x1a\n" +
+	"\bcanceled\x18\x03 \x01(\bR\bcanceled\"R\n" +
+	"\x12StartAppsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x17\n" +
+	"\aapp_ids\x18\x02 \x03(\tR\x06appIds\"Y\n" +
+	"\x10StartAppResultV1\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x18\n" +
+	"\astarted\x18\x02 \x01(\bR\astarted\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"k\n" +
+	"\x13StartAppsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12.\n" +
+	"\aresults\x18\x02 \x03(\v2\x14.pb.StartAppResultV1R\aresults\"S\n" +
+	"\x15ReconcileAppRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"o\n" +
+	"\x16ReconcileAppResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x18\n" +
+	"\adrifted\x18\x03 \x01(\bR\adrifted\"T\n" +
+	"\x16BackupVolumesRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"B\n" +
+	"\x0eVolumeBackupV1\x12\x16\n" +
+	"\x06volume\x18\x01 \x01(\tR\x06volume\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\tR\aarchive\"\x84\x01\n" +
+	"\x17BackupVolumesResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12,\n" +
+	"\abackups\x18\x03 \x03(\v2\x12.pb.VolumeBackupV1R\abackups\"\xa5\x02\n" +
+	"\rDockerEventV1\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12%\n" +
+	"\x0econtainer_name\x18\x03 \x01(\tR\rcontainerName\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12-\n" +
+	"\x06action\x18\x05 \x01(\x0e2\x15.pb.DockerEventActionR\x06action\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1b\n" +
+	"\texit_code\x18\a \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05count\x18\b \x01(\rR\x05count\"V\n" +
+	"\x1bStreamDockerEventsRequestV1\x12#\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x12\n" +
+	"\x04stop\x18\x02 \x01(\bR\x04stop\"\x85\x01\n" +
+	"\x1cStreamDockerEventsResponseV1\x12$\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12)\n" +
+	"\x06events\x18\x02 \x03(\v2\x11.pb.DockerEventV1R\x06events\x12\x14\n" +
+	"\x05ended\x18\x03 \x01(\bR\x05ended\"=\n" +
 	"\x16GetAppsStatusRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"d\n" +
 	"\x17GetAppsStatusResponseV1\x12$\n" +
@@ -3367,31 +8344,50 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x18DeleteRegistryResponseV1\x12$\n" +
 	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\";\n" +
 	"\x14GetNetworksRequestV1\x12#\n" +
-	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"Q\n" +
+	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\"\x90\x01\n" +
+	"\tNetworkV1\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x02 \x01(\tR\x06driver\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\x12\x18\n" +
+	"\asubnets\x18\x04 \x03(\tR\asubnets\x12'\n" +
+	"\x0fcontainer_count\x18\x05 \x01(\rR\x0econtainerCount\"|\n" +
 	"\x15GetNetworksResponseV1\x12$\n" +
 	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12\x12\n" +
-	"\x04name\x18\x02 \x03(\tR\x04name\"Q\n" +
+	"\x04name\x18\x02 \x03(\tR\x04name\x12)\n" +
+	"\bnetworks\x18\x03 \x03(\v2\r.pb.NetworkV1R\bnetworks\"\xdb\x01\n" +
 	"\x16CreateNetworkRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"?\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06driver\x18\x03 \x01(\tR\x06driver\x12\x18\n" +
+	"\asubnets\x18\x04 \x03(\tR\asubnets\x12\x1a\n" +
+	"\bgateways\x18\x05 \x03(\tR\bgateways\x12\x1e\n" +
+	"\n" +
+	"attachable\x18\x06 \x01(\bR\n" +
+	"attachable\x12\x1a\n" +
+	"\binternal\x18\a \x01(\bR\binternal\"?\n" +
 	"\x17CreateNetworkResponseV1\x12$\n" +
 	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"Q\n" +
 	"\x16DeleteNetworkRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"?\n" +
 	"\x17DeleteNetworkResponseV1\x12$\n" +
-	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"\xc9\x01\n" +
+	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\"\xc0\x02\n" +
 	"\x13GetAppLogsRequestV1\x12#\n" +
 	"\x04base\x18\x01 \x01(\v2\x0f.pb.BaseMessageR\x04base\x12\x15\n" +
 	"\x06app_id\x18\x02 \x01(\tR\x05appId\x120\n" +
 	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
 	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x12\n" +
-	"\x04tail\x18\x05 \x01(\x05R\x04tail\"\xad\x01\n" +
+	"\x04tail\x18\x05 \x01(\x05R\x04tail\x12/\n" +
+	"\flevel_filter\x18\x06 \x01(\x0e2\f.pb.LogLevelR\vlevelFilter\x12!\n" +
+	"\fgrep_pattern\x18\a \x01(\tR\vgrepPattern\x12!\n" +
+	"\fcontainer_id\x18\b \x01(\tR\vcontainerId\"\xf0\x01\n" +
 	"\tAppLogsV1\x12=\n" +
 	"\n" +
 	"containers\x18\x01 \x03(\v2\x1d.pb.AppLogsV1.ContainersEntryR\n" +
 	"containers\x12\"\n" +
-	"\x04logs\x18\x02 \x03(\v2\x0e.pb.LogEntryV1R\x04logs\x1a=\n" +
+	"\x04logs\x18\x02 \x03(\v2\x0e.pb.LogEntryV1R\x04logs\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12#\n" +
+	"\rdropped_count\x18\x04 \x01(\rR\fdroppedCount\x1a=\n" +
 	"\x0fContainersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe5\x01\n" +
@@ -3405,8 +8401,7 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\fcontainer_id\x18\x06 \x01(\tR\vcontainerId\"_\n" +
 	"\x14GetAppLogsResponseV1\x12$\n" +
 	"\x04base\x18\x01 \x01(\v2\x10.pb.BaseResponseR\x04base\x12!\n" +
-	"\x04logs\x18\x02 \x01(\v2\r.pb.AppLogsV1R\x04logs\"\xd3\n" +
-	"\n" +
+	"\x04logs\x18\x02 \x01(\v2\r.pb.AppLogsV1R\x04logs\"\xe8\x19\n" +
 	"\rServerCommand\x12R\n" +
 	"\x15heartbeat_response_v1\x18\x01 \x01(\v2\x1c.pb.AgentHeartbeatResponseV1H\x00R\x13heartbeatResponseV1\x12L\n" +
 	"\x13metrics_response_v1\x18\x02 \x01(\v2\x1a.pb.AgentMetricsResponseV1H\x00R\x11metricsResponseV1\x12R\n" +
@@ -3423,9 +8418,29 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x17get_networks_request_v1\x18\xf3\a \x01(\v2\x18.pb.GetNetworksRequestV1H\x00R\x14getNetworksRequestV1\x12X\n" +
 	"\x19create_network_request_v1\x18\xf4\a \x01(\v2\x1a.pb.CreateNetworkRequestV1H\x00R\x16createNetworkRequestV1\x12X\n" +
 	"\x19delete_network_request_v1\x18\xf5\a \x01(\v2\x1a.pb.DeleteNetworkRequestV1H\x00R\x16deleteNetworkRequestV1\x12P\n" +
-	"\x17get_app_logs_request_v1\x18\xf6\a \x01(\v2\x17.pb.GetAppLogsRequestV1H\x00R\x13getAppLogsRequestV1B\t\n" +
-	"\acommand\"\xca\n" +
-	"\n" +
+	"\x17get_app_logs_request_v1\x18\xf6\a \x01(\v2\x17.pb.GetAppLogsRequestV1H\x00R\x13getAppLogsRequestV1\x12_\n" +
+	"\x1cget_app_revisions_request_v1\x18\xf7\a \x01(\v2\x1c.pb.GetAppRevisionsRequestV1H\x00R\x18getAppRevisionsRequestV1\x12h\n" +
+	"\x1fget_rendered_compose_request_v1\x18\xf8\a \x01(\v2\x1f.pb.GetRenderedComposeRequestV1H\x00R\x1bgetRenderedComposeRequestV1\x12L\n" +
+	"\x15export_app_request_v1\x18\xf9\a \x01(\v2\x16.pb.ExportAppRequestV1H\x00R\x12exportAppRequestV1\x12L\n" +
+	"\x15import_app_request_v1\x18\xfa\a \x01(\v2\x16.pb.ImportAppRequestV1H\x00R\x12importAppRequestV1\x12Y\n" +
+	"\x1aget_system_info_request_v1\x18\xfb\a \x01(\v2\x1a.pb.GetSystemInfoRequestV1H\x00R\x16getSystemInfoRequestV1\x12h\n" +
+	"\x1fset_maintenance_mode_request_v1\x18\xfc\a \x01(\v2\x1f.pb.SetMaintenanceModeRequestV1H\x00R\x1bsetMaintenanceModeRequestV1\x12_\n" +
+	"\x1cget_app_resources_request_v1\x18\xfd\a \x01(\v2\x1c.pb.GetAppResourcesRequestV1H\x00R\x18getAppResourcesRequestV1\x12F\n" +
+	"\x13get_apps_request_v1\x18\xfe\a \x01(\v2\x14.pb.GetAppsRequestV1H\x00R\x10getAppsRequestV1\x12R\n" +
+	"\x17validate_app_request_v1\x18\xff\a \x01(\v2\x18.pb.ValidateAppRequestV1H\x00R\x14validateAppRequestV1\x12^\n" +
+	"\x1bcancel_operation_request_v1\x18\x80\b \x01(\v2\x1c.pb.CancelOperationRequestV1H\x00R\x18cancelOperationRequestV1\x12h\n" +
+	"\x1fget_connection_stats_request_v1\x18\x81\b \x01(\v2\x1f.pb.GetConnectionStatsRequestV1H\x00R\x1bgetConnectionStatsRequestV1\x12h\n" +
+	"\x1fstream_docker_events_request_v1\x18\x82\b \x01(\v2\x1f.pb.StreamDockerEventsRequestV1H\x00R\x1bstreamDockerEventsRequestV1\x12U\n" +
+	"\x18reconcile_app_request_v1\x18\x83\b \x01(\v2\x19.pb.ReconcileAppRequestV1H\x00R\x15reconcileAppRequestV1\x12\\\n" +
+	"\x1bget_agent_config_request_v1\x18\x85\b \x01(\v2\x1b.pb.GetAgentConfigRequestV1H\x00R\x17getAgentConfigRequestV1\x12\\\n" +
+	"\x1bget_version_info_request_v1\x18\x86\b \x01(\v2\x1b.pb.GetVersionInfoRequestV1H\x00R\x17getVersionInfoRequestV1\x12g\n" +
+	"\x1ecollect_diagnostics_request_v1\x18\x87\b \x01(\v2\x1f.pb.CollectDiagnosticsRequestV1H\x00R\x1bcollectDiagnosticsRequestV1\x12L\n" +
+	"\x15start_apps_request_v1\x18\x88\b \x01(\v2\x16.pb.StartAppsRequestV1H\x00R\x12startAppsRequestV1\x12\\\n" +
+	"\x1bcheck_for_update_request_v1\x18\x89\b \x01(\v2\x1b.pb.CheckForUpdateRequestV1H\x00R\x17checkForUpdateRequestV1\x12X\n" +
+	"\x19backup_volumes_request_v1\x18\x8a\b \x01(\v2\x1a.pb.BackupVolumesRequestV1H\x00R\x16backupVolumesRequestV1\x12Y\n" +
+	"\x1aget_app_metrics_request_v1\x18\x8b\b \x01(\v2\x1a.pb.GetAppMetricsRequestV1H\x00R\x16getAppMetricsRequestV1\x12Y\n" +
+	"\x1aclear_safe_mode_request_v1\x18\x8c\b \x01(\v2\x1a.pb.ClearSafeModeRequestV1H\x00R\x16clearSafeModeRequestV1B\t\n" +
+	"\acommand\"\x94\x1b\n" +
 	"\fAgentMessage\x129\n" +
 	"\fheartbeat_v1\x18\x01 \x01(\v2\x14.pb.AgentHeartbeatV1H\x00R\vheartbeatV1\x123\n" +
 	"\n" +
@@ -3443,8 +8458,31 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x18get_networks_response_v1\x18\xf3\a \x01(\v2\x19.pb.GetNetworksResponseV1H\x00R\x15getNetworksResponseV1\x12[\n" +
 	"\x1acreate_network_response_v1\x18\xf4\a \x01(\v2\x1b.pb.CreateNetworkResponseV1H\x00R\x17createNetworkResponseV1\x12[\n" +
 	"\x1adelete_network_response_v1\x18\xf5\a \x01(\v2\x1b.pb.DeleteNetworkResponseV1H\x00R\x17deleteNetworkResponseV1\x12S\n" +
-	"\x18get_app_logs_response_v1\x18\xf6\a \x01(\v2\x18.pb.GetAppLogsResponseV1H\x00R\x14getAppLogsResponseV1B\t\n" +
-	"\amessage*\x9e\x02\n" +
+	"\x18get_app_logs_response_v1\x18\xf6\a \x01(\v2\x18.pb.GetAppLogsResponseV1H\x00R\x14getAppLogsResponseV1\x12b\n" +
+	"\x1dget_app_revisions_response_v1\x18\xf7\a \x01(\v2\x1d.pb.GetAppRevisionsResponseV1H\x00R\x19getAppRevisionsResponseV1\x12k\n" +
+	" get_rendered_compose_response_v1\x18\xf8\a \x01(\v2 .pb.GetRenderedComposeResponseV1H\x00R\x1cgetRenderedComposeResponseV1\x12O\n" +
+	"\x16export_app_response_v1\x18\xf9\a \x01(\v2\x17.pb.ExportAppResponseV1H\x00R\x13exportAppResponseV1\x12O\n" +
+	"\x16import_app_response_v1\x18\xfa\a \x01(\v2\x17.pb.ImportAppResponseV1H\x00R\x13importAppResponseV1\x12\\\n" +
+	"\x1bget_system_info_response_v1\x18\xfb\a \x01(\v2\x1b.pb.GetSystemInfoResponseV1H\x00R\x17getSystemInfoResponseV1\x12k\n" +
+	" set_maintenance_mode_response_v1\x18\xfc\a \x01(\v2 .pb.SetMaintenanceModeResponseV1H\x00R\x1csetMaintenanceModeResponseV1\x12b\n" +
+	"\x1dget_app_resources_response_v1\x18\xfd\a \x01(\v2\x1d.pb.GetAppResourcesResponseV1H\x00R\x19getAppResourcesResponseV1\x12I\n" +
+	"\x14get_apps_response_v1\x18\xfe\a \x01(\v2\x15.pb.GetAppsResponseV1H\x00R\x11getAppsResponseV1\x12U\n" +
+	"\x18validate_app_response_v1\x18\xff\a \x01(\v2\x19.pb.ValidateAppResponseV1H\x00R\x15validateAppResponseV1\x12a\n" +
+	"\x1ccancel_operation_response_v1\x18\x80\b \x01(\v2\x1d.pb.CancelOperationResponseV1H\x00R\x19cancelOperationResponseV1\x12k\n" +
+	" get_connection_stats_response_v1\x18\x81\b \x01(\v2 .pb.GetConnectionStatsResponseV1H\x00R\x1cgetConnectionStatsResponseV1\x12k\n" +
+	" stream_docker_events_response_v1\x18\x82\b \x01(\v2 .pb.StreamDockerEventsResponseV1H\x00R\x1cstreamDockerEventsResponseV1\x12X\n" +
+	"\x19reconcile_app_response_v1\x18\x83\b \x01(\v2\x1a.pb.ReconcileAppResponseV1H\x00R\x16reconcileAppResponseV1\x126\n" +
+	"\rapp_output_v1\x18\x84\b \x01(\v2\x0f.pb.AppOutputV1H\x00R\vappOutputV1\x12_\n" +
+	"\x1cget_agent_config_response_v1\x18\x85\b \x01(\v2\x1c.pb.GetAgentConfigResponseV1H\x00R\x18getAgentConfigResponseV1\x12<\n" +
+	"\x0fapp_progress_v1\x18\x86\b \x01(\v2\x11.pb.AppProgressV1H\x00R\rappProgressV1\x12_\n" +
+	"\x1cget_version_info_response_v1\x18\x87\b \x01(\v2\x1c.pb.GetVersionInfoResponseV1H\x00R\x18getVersionInfoResponseV1\x12j\n" +
+	"\x1fcollect_diagnostics_response_v1\x18\x88\b \x01(\v2 .pb.CollectDiagnosticsResponseV1H\x00R\x1ccollectDiagnosticsResponseV1\x12O\n" +
+	"\x16start_apps_response_v1\x18\x89\b \x01(\v2\x17.pb.StartAppsResponseV1H\x00R\x13startAppsResponseV1\x12_\n" +
+	"\x1ccheck_for_update_response_v1\x18\x8a\b \x01(\v2\x1c.pb.CheckForUpdateResponseV1H\x00R\x18checkForUpdateResponseV1\x12[\n" +
+	"\x1abackup_volumes_response_v1\x18\x8b\b \x01(\v2\x1b.pb.BackupVolumesResponseV1H\x00R\x17backupVolumesResponseV1\x12\\\n" +
+	"\x1bget_app_metrics_response_v1\x18\x8c\b \x01(\v2\x1b.pb.GetAppMetricsResponseV1H\x00R\x17getAppMetricsResponseV1\x12\\\n" +
+	"\x1bclear_safe_mode_response_v1\x18\x8d\b \x01(\v2\x1b.pb.ClearSafeModeResponseV1H\x00R\x17clearSafeModeResponseV1B\t\n" +
+	"\amessage*\xb2\x03\n" +
 	"\fResponseCode\x12\x1d\n" +
 	"\x19RESPONSE_CODE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15RESPONSE_CODE_SUCCESS\x10\x01\x12!\n" +
@@ -3453,21 +8491,43 @@ const file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc = "" +
 	"\x1aRESPONSE_CODE_UNAUTHORIZED\x10\x04\x12\x1e\n" +
 	"\x1aRESPONSE_CODE_SERVER_ERROR\x10\x05\x12!\n" +
 	"\x1dRESPONSE_CODE_AGENT_NOT_FOUND\x10\x06\x12)\n" +
-	"%RESPONSE_CODE_AGENT_ALREADY_CONNECTED\x10\a*\xea\x01\n" +
+	"%RESPONSE_CODE_AGENT_ALREADY_CONNECTED\x10\a\x12\x1d\n" +
+	"\x19RESPONSE_CODE_MAINTENANCE\x10\b\x12\x19\n" +
+	"\x15RESPONSE_CODE_TIMEOUT\x10\t\x12\x1b\n" +
+	"\x17RESPONSE_CODE_FORBIDDEN\x10\n" +
+	"\x12\x1e\n" +
+	"\x1aRESPONSE_CODE_RATE_LIMITED\x10\v\x12\x1b\n" +
+	"\x17RESPONSE_CODE_SAFE_MODE\x10\f*\xea\x01\n" +
 	"\x13ContainerStatusCode\x12!\n" +
 	"\x1dCONTAINER_STATUS_CODE_UNKNOWN\x10\x00\x12 \n" +
 	"\x1cCONTAINER_STATUS_CODE_ACTIVE\x10\x01\x12\x1e\n" +
 	"\x1aCONTAINER_STATUS_CODE_IDLE\x10\x02\x12$\n" +
 	" CONTAINER_STATUS_CODE_RESTARTING\x10\x03\x12%\n" +
 	"!CONTAINER_STATUS_CODE_PROBLEMATIC\x10\x04\x12!\n" +
-	"\x1dCONTAINER_STATUS_CODE_STOPPED\x10\x05*G\n" +
+	"\x1dCONTAINER_STATUS_CODE_STOPPED\x10\x05*U\n" +
 	"\tAppAction\x12\b\n" +
 	"\x04STOP\x10\x00\x12\t\n" +
 	"\x05START\x10\x01\x12\v\n" +
 	"\aRESTART\x10\x02\x12\n" +
 	"\n" +
 	"\x06UPDATE\x10\x03\x12\f\n" +
-	"\bREDEPLOY\x10\x04*U\n" +
+	"\bREDEPLOY\x10\x04\x12\f\n" +
+	"\bRECREATE\x10\x05*\xe5\x01\n" +
+	"\vDeployStage\x12\x18\n" +
+	"\x14DEPLOY_STAGE_UNKNOWN\x10\x00\x12\x1a\n" +
+	"\x16DEPLOY_STAGE_RENDERING\x10\x01\x12\x18\n" +
+	"\x14DEPLOY_STAGE_PULLING\x10\x02\x12\x19\n" +
+	"\x15DEPLOY_STAGE_STARTING\x10\x03\x12 \n" +
+	"\x1cDEPLOY_STAGE_WAITING_HEALTHY\x10\x04\x12\x15\n" +
+	"\x11DEPLOY_STAGE_DONE\x10\x05\x12\x17\n" +
+	"\x13DEPLOY_STAGE_FAILED\x10\x06\x12\x19\n" +
+	"\x15DEPLOY_STAGE_BUILDING\x10\a*\xab\x01\n" +
+	"\x11DockerEventAction\x12\x1f\n" +
+	"\x1bDOCKER_EVENT_ACTION_UNKNOWN\x10\x00\x12\x1d\n" +
+	"\x19DOCKER_EVENT_ACTION_START\x10\x01\x12\x1c\n" +
+	"\x18DOCKER_EVENT_ACTION_STOP\x10\x02\x12\x1b\n" +
+	"\x17DOCKER_EVENT_ACTION_DIE\x10\x03\x12\x1b\n" +
+	"\x17DOCKER_EVENT_ACTION_OOM\x10\x04*U\n" +
 	"\n" +
 	"LogChannel\x12\x17\n" +
 	"\x13LOG_CHANNEL_UNKNOWN\x10\x00\x12\x16\n" +
@@ -3497,162 +8557,337 @@ func file_internal_infra_winterflow_grpc_pb_server_proto_rawDescGZIP() []byte {
 	return file_internal_infra_winterflow_grpc_pb_server_proto_rawDescData
 }
 
-var file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_internal_infra_winterflow_grpc_pb_server_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 107)
 var file_internal_infra_winterflow_grpc_pb_server_proto_goTypes = []any{
-	(ResponseCode)(0),                // 0: pb.ResponseCode
-	(ContainerStatusCode)(0),         // 1: pb.ContainerStatusCode
-	(AppAction)(0),                   // 2: pb.AppAction
-	(LogChannel)(0),                  // 3: pb.LogChannel
-	(LogLevel)(0),                    // 4: pb.LogLevel
-	(*BaseMessage)(nil),              // 5: pb.BaseMessage
-	(*BaseResponse)(nil),             // 6: pb.BaseResponse
-	(*RegisterAgentRequestV1)(nil),   // 7: pb.RegisterAgentRequestV1
-	(*RegisterAgentResponseV1)(nil),  // 8: pb.RegisterAgentResponseV1
-	(*AgentHeartbeatV1)(nil),         // 9: pb.AgentHeartbeatV1
-	(*AgentHeartbeatResponseV1)(nil), // 10: pb.AgentHeartbeatResponseV1
-	(*AgentMetricsV1)(nil),           // 11: pb.AgentMetricsV1
-	(*AgentMetricsResponseV1)(nil),   // 12: pb.AgentMetricsResponseV1
-	(*ContainerStatusV1)(nil),        // 13: pb.ContainerStatusV1
-	(*AppStatusV1)(nil),              // 14: pb.AppStatusV1
-	(*AppFileV1)(nil),                // 15: pb.AppFileV1
-	(*AppVarV1)(nil),                 // 16: pb.AppVarV1
-	(*AppV1)(nil),                    // 17: pb.AppV1
-	(*GetAppRequestV1)(nil),          // 18: pb.GetAppRequestV1
-	(*GetAppResponseV1)(nil),         // 19: pb.GetAppResponseV1
-	(*UpdateAgentRequestV1)(nil),     // 20: pb.UpdateAgentRequestV1
-	(*UpdateAgentResponseV1)(nil),    // 21: pb.UpdateAgentResponseV1
-	(*SaveAppRequestV1)(nil),         // 22: pb.SaveAppRequestV1
-	(*SaveAppResponseV1)(nil),        // 23: pb.SaveAppResponseV1
-	(*RenameAppRequestV1)(nil),       // 24: pb.RenameAppRequestV1
-	(*RenameAppResponseV1)(nil),      // 25: pb.RenameAppResponseV1
-	(*DeleteAppRequestV1)(nil),       // 26: pb.DeleteAppRequestV1
-	(*DeleteAppResponseV1)(nil),      // 27: pb.DeleteAppResponseV1
-	(*ControlAppRequestV1)(nil),      // 28: pb.ControlAppRequestV1
-	(*ControlAppResponseV1)(nil),     // 29: pb.ControlAppResponseV1
-	(*GetAppsStatusRequestV1)(nil),   // 30: pb.GetAppsStatusRequestV1
-	(*GetAppsStatusResponseV1)(nil),  // 31: pb.GetAppsStatusResponseV1
-	(*GetRegistriesRequestV1)(nil),   // 32: pb.GetRegistriesRequestV1
-	(*GetRegistriesResponseV1)(nil),  // 33: pb.GetRegistriesResponseV1
-	(*CreateRegistryRequestV1)(nil),  // 34: pb.CreateRegistryRequestV1
-	(*CreateRegistryResponseV1)(nil), // 35: pb.CreateRegistryResponseV1
-	(*DeleteRegistryRequestV1)(nil),  // 36: pb.DeleteRegistryRequestV1
-	(*DeleteRegistryResponseV1)(nil), // 37: pb.DeleteRegistryResponseV1
-	(*GetNetworksRequestV1)(nil),     // 38: pb.GetNetworksRequestV1
-	(*GetNetworksResponseV1)(nil),    // 39: pb.GetNetworksResponseV1
-	(*CreateNetworkRequestV1)(nil),   // 40: pb.CreateNetworkRequestV1
-	(*CreateNetworkResponseV1)(nil),  // 41: pb.CreateNetworkResponseV1
-	(*DeleteNetworkRequestV1)(nil),   // 42: pb.DeleteNetworkRequestV1
-	(*DeleteNetworkResponseV1)(nil),  // 43: pb.DeleteNetworkResponseV1
-	(*GetAppLogsRequestV1)(nil),      // 44: pb.GetAppLogsRequestV1
-	(*AppLogsV1)(nil),                // 45: pb.AppLogsV1
-	(*LogEntryV1)(nil),               // 46: pb.LogEntryV1
-	(*GetAppLogsResponseV1)(nil),     // 47: pb.GetAppLogsResponseV1
-	(*ServerCommand)(nil),            // 48: pb.ServerCommand
-	(*AgentMessage)(nil),             // 49: pb.AgentMessage
-	nil,                              // 50: pb.RegisterAgentRequestV1.CapabilitiesEntry
-	nil,                              // 51: pb.RegisterAgentRequestV1.FeaturesEntry
-	nil,                              // 52: pb.AppLogsV1.ContainersEntry
-	(*timestamppb.Timestamp)(nil),    // 53: google.protobuf.Timestamp
+	(ResponseCode)(0),                    // 0: pb.ResponseCode
+	(ContainerStatusCode)(0),             // 1: pb.ContainerStatusCode
+	(AppAction)(0),                       // 2: pb.AppAction
+	(DeployStage)(0),                     // 3: pb.DeployStage
+	(DockerEventAction)(0),               // 4: pb.DockerEventAction
+	(LogChannel)(0),                      // 5: pb.LogChannel
+	(LogLevel)(0),                        // 6: pb.LogLevel
+	(*BaseMessage)(nil),                  // 7: pb.BaseMessage
+	(*BaseResponse)(nil),                 // 8: pb.BaseResponse
+	(*RegisterAgentRequestV1)(nil),       // 9: pb.RegisterAgentRequestV1
+	(*RegisterAgentResponseV1)(nil),      // 10: pb.RegisterAgentResponseV1
+	(*AgentHeartbeatV1)(nil),             // 11: pb.AgentHeartbeatV1
+	(*AgentHeartbeatResponseV1)(nil),     // 12: pb.AgentHeartbeatResponseV1
+	(*AgentMetricsV1)(nil),               // 13: pb.AgentMetricsV1
+	(*AgentMetricsResponseV1)(nil),       // 14: pb.AgentMetricsResponseV1
+	(*ContainerStatusV1)(nil),            // 15: pb.ContainerStatusV1
+	(*AppStatusV1)(nil),                  // 16: pb.AppStatusV1
+	(*AppFileV1)(nil),                    // 17: pb.AppFileV1
+	(*AppVarV1)(nil),                     // 18: pb.AppVarV1
+	(*AppV1)(nil),                        // 19: pb.AppV1
+	(*GetAppRequestV1)(nil),              // 20: pb.GetAppRequestV1
+	(*GetAppResponseV1)(nil),             // 21: pb.GetAppResponseV1
+	(*GetAppsRequestV1)(nil),             // 22: pb.GetAppsRequestV1
+	(*AppDetailsV1)(nil),                 // 23: pb.AppDetailsV1
+	(*GetAppsResponseV1)(nil),            // 24: pb.GetAppsResponseV1
+	(*ValidateAppRequestV1)(nil),         // 25: pb.ValidateAppRequestV1
+	(*MissingVariableV1)(nil),            // 26: pb.MissingVariableV1
+	(*ValidateAppResponseV1)(nil),        // 27: pb.ValidateAppResponseV1
+	(*GetAppRevisionsRequestV1)(nil),     // 28: pb.GetAppRevisionsRequestV1
+	(*AppRevisionV1)(nil),                // 29: pb.AppRevisionV1
+	(*GetAppRevisionsResponseV1)(nil),    // 30: pb.GetAppRevisionsResponseV1
+	(*GetRenderedComposeRequestV1)(nil),  // 31: pb.GetRenderedComposeRequestV1
+	(*GetRenderedComposeResponseV1)(nil), // 32: pb.GetRenderedComposeResponseV1
+	(*GetAppResourcesRequestV1)(nil),     // 33: pb.GetAppResourcesRequestV1
+	(*ContainerResourcesV1)(nil),         // 34: pb.ContainerResourcesV1
+	(*GetAppResourcesResponseV1)(nil),    // 35: pb.GetAppResourcesResponseV1
+	(*GetAppMetricsRequestV1)(nil),       // 36: pb.GetAppMetricsRequestV1
+	(*DurationBucketV1)(nil),             // 37: pb.DurationBucketV1
+	(*AppOperationMetricsV1)(nil),        // 38: pb.AppOperationMetricsV1
+	(*GetAppMetricsResponseV1)(nil),      // 39: pb.GetAppMetricsResponseV1
+	(*GetSystemInfoRequestV1)(nil),       // 40: pb.GetSystemInfoRequestV1
+	(*SystemInfoV1)(nil),                 // 41: pb.SystemInfoV1
+	(*GetSystemInfoResponseV1)(nil),      // 42: pb.GetSystemInfoResponseV1
+	(*GetConnectionStatsRequestV1)(nil),  // 43: pb.GetConnectionStatsRequestV1
+	(*ConnectionDisconnectV1)(nil),       // 44: pb.ConnectionDisconnectV1
+	(*ConnectionStatsV1)(nil),            // 45: pb.ConnectionStatsV1
+	(*GetConnectionStatsResponseV1)(nil), // 46: pb.GetConnectionStatsResponseV1
+	(*GetAgentConfigRequestV1)(nil),      // 47: pb.GetAgentConfigRequestV1
+	(*GetAgentConfigResponseV1)(nil),     // 48: pb.GetAgentConfigResponseV1
+	(*CollectDiagnosticsRequestV1)(nil),  // 49: pb.CollectDiagnosticsRequestV1
+	(*CollectDiagnosticsResponseV1)(nil), // 50: pb.CollectDiagnosticsResponseV1
+	(*GetVersionInfoRequestV1)(nil),      // 51: pb.GetVersionInfoRequestV1
+	(*GetVersionInfoResponseV1)(nil),     // 52: pb.GetVersionInfoResponseV1
+	(*CheckForUpdateRequestV1)(nil),      // 53: pb.CheckForUpdateRequestV1
+	(*CheckForUpdateResponseV1)(nil),     // 54: pb.CheckForUpdateResponseV1
+	(*SetMaintenanceModeRequestV1)(nil),  // 55: pb.SetMaintenanceModeRequestV1
+	(*SetMaintenanceModeResponseV1)(nil), // 56: pb.SetMaintenanceModeResponseV1
+	(*ClearSafeModeRequestV1)(nil),       // 57: pb.ClearSafeModeRequestV1
+	(*ClearSafeModeResponseV1)(nil),      // 58: pb.ClearSafeModeResponseV1
+	(*ImportAppRequestV1)(nil),           // 59: pb.ImportAppRequestV1
+	(*ImportAppResponseV1)(nil),          // 60: pb.ImportAppResponseV1
+	(*ExportAppRequestV1)(nil),           // 61: pb.ExportAppRequestV1
+	(*ExportAppResponseV1)(nil),          // 62: pb.ExportAppResponseV1
+	(*UpdateAgentRequestV1)(nil),         // 63: pb.UpdateAgentRequestV1
+	(*UpdateAgentResponseV1)(nil),        // 64: pb.UpdateAgentResponseV1
+	(*SaveAppRequestV1)(nil),             // 65: pb.SaveAppRequestV1
+	(*SaveAppResponseV1)(nil),            // 66: pb.SaveAppResponseV1
+	(*RenameAppRequestV1)(nil),           // 67: pb.RenameAppRequestV1
+	(*RenameAppResponseV1)(nil),          // 68: pb.RenameAppResponseV1
+	(*DeleteAppRequestV1)(nil),           // 69: pb.DeleteAppRequestV1
+	(*DeleteAppResponseV1)(nil),          // 70: pb.DeleteAppResponseV1
+	(*ControlAppRequestV1)(nil),          // 71: pb.ControlAppRequestV1
+	(*ControlAppResponseV1)(nil),         // 72: pb.ControlAppResponseV1
+	(*AppOutputLineV1)(nil),              // 73: pb.AppOutputLineV1
+	(*AppOutputV1)(nil),                  // 74: pb.AppOutputV1
+	(*AppProgressV1)(nil),                // 75: pb.AppProgressV1
+	(*CancelOperationRequestV1)(nil),     // 76: pb.CancelOperationRequestV1
+	(*CancelOperationResponseV1)(nil),    // 77: pb.CancelOperationResponseV1
+	(*StartAppsRequestV1)(nil),           // 78: pb.StartAppsRequestV1
+	(*StartAppResultV1)(nil),             // 79: pb.StartAppResultV1
+	(*StartAppsResponseV1)(nil),          // 80: pb.StartAppsResponseV1
+	(*ReconcileAppRequestV1)(nil),        // 81: pb.ReconcileAppRequestV1
+	(*ReconcileAppResponseV1)(nil),       // 82: pb.ReconcileAppResponseV1
+	(*BackupVolumesRequestV1)(nil),       // 83: pb.BackupVolumesRequestV1
+	(*VolumeBackupV1)(nil),               // 84: pb.VolumeBackupV1
+	(*BackupVolumesResponseV1)(nil),      // 85: pb.BackupVolumesResponseV1
+	(*DockerEventV1)(nil),                // 86: pb.DockerEventV1
+	(*StreamDockerEventsRequestV1)(nil),  // 87: pb.StreamDockerEventsRequestV1
+	(*StreamDockerEventsResponseV1)(nil), // 88: pb.StreamDockerEventsResponseV1
+	(*GetAppsStatusRequestV1)(nil),       // 89: pb.GetAppsStatusRequestV1
+	(*GetAppsStatusResponseV1)(nil),      // 90: pb.GetAppsStatusResponseV1
+	(*GetRegistriesRequestV1)(nil),       // 91: pb.GetRegistriesRequestV1
+	(*GetRegistriesResponseV1)(nil),      // 92: pb.GetRegistriesResponseV1
+	(*CreateRegistryRequestV1)(nil),      // 93: pb.CreateRegistryRequestV1
+	(*CreateRegistryResponseV1)(nil),     // 94: pb.CreateRegistryResponseV1
+	(*DeleteRegistryRequestV1)(nil),      // 95: pb.DeleteRegistryRequestV1
+	(*DeleteRegistryResponseV1)(nil),     // 96: pb.DeleteRegistryResponseV1
+	(*GetNetworksRequestV1)(nil),         // 97: pb.GetNetworksRequestV1
+	(*NetworkV1)(nil),                    // 98: pb.NetworkV1
+	(*GetNetworksResponseV1)(nil),        // 99: pb.GetNetworksResponseV1
+	(*CreateNetworkRequestV1)(nil),       // 100: pb.CreateNetworkRequestV1
+	(*CreateNetworkResponseV1)(nil),      // 101: pb.CreateNetworkResponseV1
+	(*DeleteNetworkRequestV1)(nil),       // 102: pb.DeleteNetworkRequestV1
+	(*DeleteNetworkResponseV1)(nil),      // 103: pb.DeleteNetworkResponseV1
+	(*GetAppLogsRequestV1)(nil),          // 104: pb.GetAppLogsRequestV1
+	(*AppLogsV1)(nil),                    // 105: pb.AppLogsV1
+	(*LogEntryV1)(nil),                   // 106: pb.LogEntryV1
+	(*GetAppLogsResponseV1)(nil),         // 107: pb.GetAppLogsResponseV1
+	(*ServerCommand)(nil),                // 108: pb.ServerCommand
+	(*AgentMessage)(nil),                 // 109: pb.AgentMessage
+	nil,                                  // 110: pb.RegisterAgentRequestV1.CapabilitiesEntry
+	nil,                                  // 111: pb.RegisterAgentRequestV1.FeaturesEntry
+	nil,                                  // 112: pb.GetAgentConfigResponseV1.BuildOverridesEntry
+	nil,                                  // 113: pb.AppLogsV1.ContainersEntry
+	(*timestamppb.Timestamp)(nil),        // 114: google.protobuf.Timestamp
 }
 var file_internal_infra_winterflow_grpc_pb_server_proto_depIdxs = []int32{
-	53, // 0: pb.BaseMessage.timestamp:type_name -> google.protobuf.Timestamp
-	53, // 1: pb.BaseResponse.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 2: pb.BaseResponse.response_code:type_name -> pb.ResponseCode
-	5,  // 3: pb.RegisterAgentRequestV1.base:type_name -> pb.BaseMessage
-	50, // 4: pb.RegisterAgentRequestV1.capabilities:type_name -> pb.RegisterAgentRequestV1.CapabilitiesEntry
-	51, // 5: pb.RegisterAgentRequestV1.features:type_name -> pb.RegisterAgentRequestV1.FeaturesEntry
-	6,  // 6: pb.RegisterAgentResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 7: pb.AgentHeartbeatV1.base:type_name -> pb.BaseMessage
-	6,  // 8: pb.AgentHeartbeatResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 9: pb.AgentMetricsV1.base:type_name -> pb.BaseMessage
-	6,  // 10: pb.AgentMetricsResponseV1.base:type_name -> pb.BaseResponse
-	1,  // 11: pb.ContainerStatusV1.status_code:type_name -> pb.ContainerStatusCode
-	1,  // 12: pb.AppStatusV1.status_code:type_name -> pb.ContainerStatusCode
-	13, // 13: pb.AppStatusV1.containers:type_name -> pb.ContainerStatusV1
-	16, // 14: pb.AppV1.variables:type_name -> pb.AppVarV1
-	15, // 15: pb.AppV1.files:type_name -> pb.AppFileV1
-	5,  // 16: pb.GetAppRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 17: pb.GetAppResponseV1.base:type_name -> pb.BaseResponse
-	17, // 18: pb.GetAppResponseV1.app:type_name -> pb.AppV1
-	5,  // 19: pb.UpdateAgentRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 20: pb.UpdateAgentResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 21: pb.SaveAppRequestV1.base:type_name -> pb.BaseMessage
-	17, // 22: pb.SaveAppRequestV1.app:type_name -> pb.AppV1
-	6,  // 23: pb.SaveAppResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 24: pb.RenameAppRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 25: pb.RenameAppResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 26: pb.DeleteAppRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 27: pb.DeleteAppResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 28: pb.ControlAppRequestV1.base:type_name -> pb.BaseMessage
-	2,  // 29: pb.ControlAppRequestV1.action:type_name -> pb.AppAction
-	6,  // 30: pb.ControlAppResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 31: pb.GetAppsStatusRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 32: pb.GetAppsStatusResponseV1.base:type_name -> pb.BaseResponse
-	14, // 33: pb.GetAppsStatusResponseV1.apps:type_name -> pb.AppStatusV1
-	5,  // 34: pb.GetRegistriesRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 35: pb.GetRegistriesResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 36: pb.CreateRegistryRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 37: pb.CreateRegistryResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 38: pb.DeleteRegistryRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 39: pb.DeleteRegistryResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 40: pb.GetNetworksRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 41: pb.GetNetworksResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 42: pb.CreateNetworkRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 43: pb.CreateNetworkResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 44: pb.DeleteNetworkRequestV1.base:type_name -> pb.BaseMessage
-	6,  // 45: pb.DeleteNetworkResponseV1.base:type_name -> pb.BaseResponse
-	5,  // 46: pb.GetAppLogsRequestV1.base:type_name -> pb.BaseMessage
-	53, // 47: pb.GetAppLogsRequestV1.since:type_name -> google.protobuf.Timestamp
-	53, // 48: pb.GetAppLogsRequestV1.until:type_name -> google.protobuf.Timestamp
-	52, // 49: pb.AppLogsV1.containers:type_name -> pb.AppLogsV1.ContainersEntry
-	46, // 50: pb.AppLogsV1.logs:type_name -> pb.LogEntryV1
-	53, // 51: pb.LogEntryV1.timestamp:type_name -> google.protobuf.Timestamp
-	3,  // 52: pb.LogEntryV1.channel:type_name -> pb.LogChannel
-	4,  // 53: pb.LogEntryV1.level:type_name -> pb.LogLevel
-	6,  // 54: pb.GetAppLogsResponseV1.base:type_name -> pb.BaseResponse
-	45, // 55: pb.GetAppLogsResponseV1.logs:type_name -> pb.AppLogsV1
-	10, // 56: pb.ServerCommand.heartbeat_response_v1:type_name -> pb.AgentHeartbeatResponseV1
-	12, // 57: pb.ServerCommand.metrics_response_v1:type_name -> pb.AgentMetricsResponseV1
-	20, // 58: pb.ServerCommand.update_agent_request_v1:type_name -> pb.UpdateAgentRequestV1
-	18, // 59: pb.ServerCommand.get_app_request_v1:type_name -> pb.GetAppRequestV1
-	22, // 60: pb.ServerCommand.save_app_request_v1:type_name -> pb.SaveAppRequestV1
-	24, // 61: pb.ServerCommand.rename_app_request_v1:type_name -> pb.RenameAppRequestV1
-	26, // 62: pb.ServerCommand.delete_app_request_v1:type_name -> pb.DeleteAppRequestV1
-	28, // 63: pb.ServerCommand.control_app_request_v1:type_name -> pb.ControlAppRequestV1
-	30, // 64: pb.ServerCommand.get_apps_status_request_v1:type_name -> pb.GetAppsStatusRequestV1
-	32, // 65: pb.ServerCommand.get_registries_request_v1:type_name -> pb.GetRegistriesRequestV1
-	34, // 66: pb.ServerCommand.create_registry_request_v1:type_name -> pb.CreateRegistryRequestV1
-	36, // 67: pb.ServerCommand.delete_registry_request_v1:type_name -> pb.DeleteRegistryRequestV1
-	38, // 68: pb.ServerCommand.get_networks_request_v1:type_name -> pb.GetNetworksRequestV1
-	40, // 69: pb.ServerCommand.create_network_request_v1:type_name -> pb.CreateNetworkRequestV1
-	42, // 70: pb.ServerCommand.delete_network_request_v1:type_name -> pb.DeleteNetworkRequestV1
-	44, // 71: pb.ServerCommand.get_app_logs_request_v1:type_name -> pb.GetAppLogsRequestV1
-	9,  // 72: pb.AgentMessage.heartbeat_v1:type_name -> pb.AgentHeartbeatV1
-	11, // 73: pb.AgentMessage.metrics_v1:type_name -> pb.AgentMetricsV1
-	21, // 74: pb.AgentMessage.update_agent_response_v1:type_name -> pb.UpdateAgentResponseV1
-	19, // 75: pb.AgentMessage.get_app_response_v1:type_name -> pb.GetAppResponseV1
-	23, // 76: pb.AgentMessage.save_app_response_v1:type_name -> pb.SaveAppResponseV1
-	25, // 77: pb.AgentMessage.rename_app_response_v1:type_name -> pb.RenameAppResponseV1
-	27, // 78: pb.AgentMessage.delete_app_response_v1:type_name -> pb.DeleteAppResponseV1
-	29, // 79: pb.AgentMessage.control_app_response_v1:type_name -> pb.ControlAppResponseV1
-	31, // 80: pb.AgentMessage.get_apps_status_response_v1:type_name -> pb.GetAppsStatusResponseV1
-	33, // 81: pb.AgentMessage.get_registries_response_v1:type_name -> pb.GetRegistriesResponseV1
-	35, // 82: pb.AgentMessage.create_registry_response_v1:type_name -> pb.CreateRegistryResponseV1
-	37, // 83: pb.AgentMessage.delete_registry_response_v1:type_name -> pb.DeleteRegistryResponseV1
-	39, // 84: pb.AgentMessage.get_networks_response_v1:type_name -> pb.GetNetworksResponseV1
-	41, // 85: pb.AgentMessage.create_network_response_v1:type_name -> pb.CreateNetworkResponseV1
-	43, // 86: pb.AgentMessage.delete_network_response_v1:type_name -> pb.DeleteNetworkResponseV1
-	47, // 87: pb.AgentMessage.get_app_logs_response_v1:type_name -> pb.GetAppLogsResponseV1
-	7,  // 88: pb.AgentService.RegisterAgentV1:input_type -> pb.RegisterAgentRequestV1
-	49, // 89: pb.AgentService.AgentStream:input_type -> pb.AgentMessage
-	8,  // 90: pb.AgentService.RegisterAgentV1:output_type -> pb.RegisterAgentResponseV1
-	48, // 91: pb.AgentService.AgentStream:output_type -> pb.ServerCommand
-	90, // [90:92] is the sub-list for method output_type
-	88, // [88:90] is the sub-list for method input_type
-	88, // [88:88] is the sub-list for extension type_name
-	88, // [88:88] is the sub-list for extension extendee
-	0,  // [0:88] is the sub-list for field type_name
+	114, // 0: pb.BaseMessage.timestamp:type_name -> google.protobuf.Timestamp
+	114, // 1: pb.BaseResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0,   // 2: pb.BaseResponse.response_code:type_name -> pb.ResponseCode
+	7,   // 3: pb.RegisterAgentRequestV1.base:type_name -> pb.BaseMessage
+	110, // 4: pb.RegisterAgentRequestV1.capabilities:type_name -> pb.RegisterAgentRequestV1.CapabilitiesEntry
+	111, // 5: pb.RegisterAgentRequestV1.features:type_name -> pb.RegisterAgentRequestV1.FeaturesEntry
+	8,   // 6: pb.RegisterAgentResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 7: pb.AgentHeartbeatV1.base:type_name -> pb.BaseMessage
+	8,   // 8: pb.AgentHeartbeatResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 9: pb.AgentMetricsV1.base:type_name -> pb.BaseMessage
+	8,   // 10: pb.AgentMetricsResponseV1.base:type_name -> pb.BaseResponse
+	1,   // 11: pb.ContainerStatusV1.status_code:type_name -> pb.ContainerStatusCode
+	1,   // 12: pb.AppStatusV1.status_code:type_name -> pb.ContainerStatusCode
+	15,  // 13: pb.AppStatusV1.containers:type_name -> pb.ContainerStatusV1
+	18,  // 14: pb.AppV1.variables:type_name -> pb.AppVarV1
+	17,  // 15: pb.AppV1.files:type_name -> pb.AppFileV1
+	7,   // 16: pb.GetAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 17: pb.GetAppResponseV1.base:type_name -> pb.BaseResponse
+	19,  // 18: pb.GetAppResponseV1.app:type_name -> pb.AppV1
+	7,   // 19: pb.GetAppsRequestV1.base:type_name -> pb.BaseMessage
+	19,  // 20: pb.AppDetailsV1.app:type_name -> pb.AppV1
+	8,   // 21: pb.GetAppsResponseV1.base:type_name -> pb.BaseResponse
+	23,  // 22: pb.GetAppsResponseV1.apps:type_name -> pb.AppDetailsV1
+	7,   // 23: pb.ValidateAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 24: pb.ValidateAppResponseV1.base:type_name -> pb.BaseResponse
+	26,  // 25: pb.ValidateAppResponseV1.missing_variables:type_name -> pb.MissingVariableV1
+	7,   // 26: pb.GetAppRevisionsRequestV1.base:type_name -> pb.BaseMessage
+	114, // 27: pb.AppRevisionV1.created_at:type_name -> google.protobuf.Timestamp
+	8,   // 28: pb.GetAppRevisionsResponseV1.base:type_name -> pb.BaseResponse
+	29,  // 29: pb.GetAppRevisionsResponseV1.revisions:type_name -> pb.AppRevisionV1
+	7,   // 30: pb.GetRenderedComposeRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 31: pb.GetRenderedComposeResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 32: pb.GetAppResourcesRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 33: pb.GetAppResourcesResponseV1.base:type_name -> pb.BaseResponse
+	34,  // 34: pb.GetAppResourcesResponseV1.containers:type_name -> pb.ContainerResourcesV1
+	7,   // 35: pb.GetAppMetricsRequestV1.base:type_name -> pb.BaseMessage
+	37,  // 36: pb.AppOperationMetricsV1.duration_buckets:type_name -> pb.DurationBucketV1
+	8,   // 37: pb.GetAppMetricsResponseV1.base:type_name -> pb.BaseResponse
+	38,  // 38: pb.GetAppMetricsResponseV1.operations:type_name -> pb.AppOperationMetricsV1
+	7,   // 39: pb.GetSystemInfoRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 40: pb.GetSystemInfoResponseV1.base:type_name -> pb.BaseResponse
+	41,  // 41: pb.GetSystemInfoResponseV1.system_info:type_name -> pb.SystemInfoV1
+	7,   // 42: pb.GetConnectionStatsRequestV1.base:type_name -> pb.BaseMessage
+	114, // 43: pb.ConnectionDisconnectV1.at:type_name -> google.protobuf.Timestamp
+	114, // 44: pb.ConnectionStatsV1.connected_since:type_name -> google.protobuf.Timestamp
+	114, // 45: pb.ConnectionStatsV1.last_disconnect_at:type_name -> google.protobuf.Timestamp
+	114, // 46: pb.ConnectionStatsV1.last_error_at:type_name -> google.protobuf.Timestamp
+	44,  // 47: pb.ConnectionStatsV1.recent_disconnects:type_name -> pb.ConnectionDisconnectV1
+	8,   // 48: pb.GetConnectionStatsResponseV1.base:type_name -> pb.BaseResponse
+	45,  // 49: pb.GetConnectionStatsResponseV1.stats:type_name -> pb.ConnectionStatsV1
+	7,   // 50: pb.GetAgentConfigRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 51: pb.GetAgentConfigResponseV1.base:type_name -> pb.BaseResponse
+	112, // 52: pb.GetAgentConfigResponseV1.build_overrides:type_name -> pb.GetAgentConfigResponseV1.BuildOverridesEntry
+	7,   // 53: pb.CollectDiagnosticsRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 54: pb.CollectDiagnosticsResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 55: pb.GetVersionInfoRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 56: pb.GetVersionInfoResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 57: pb.CheckForUpdateRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 58: pb.CheckForUpdateResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 59: pb.SetMaintenanceModeRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 60: pb.SetMaintenanceModeResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 61: pb.ClearSafeModeRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 62: pb.ClearSafeModeResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 63: pb.ImportAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 64: pb.ImportAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 65: pb.ExportAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 66: pb.ExportAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 67: pb.UpdateAgentRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 68: pb.UpdateAgentResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 69: pb.SaveAppRequestV1.base:type_name -> pb.BaseMessage
+	19,  // 70: pb.SaveAppRequestV1.app:type_name -> pb.AppV1
+	8,   // 71: pb.SaveAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 72: pb.RenameAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 73: pb.RenameAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 74: pb.DeleteAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 75: pb.DeleteAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 76: pb.ControlAppRequestV1.base:type_name -> pb.BaseMessage
+	2,   // 77: pb.ControlAppRequestV1.action:type_name -> pb.AppAction
+	8,   // 78: pb.ControlAppResponseV1.base:type_name -> pb.BaseResponse
+	5,   // 79: pb.AppOutputLineV1.channel:type_name -> pb.LogChannel
+	8,   // 80: pb.AppOutputV1.base:type_name -> pb.BaseResponse
+	73,  // 81: pb.AppOutputV1.lines:type_name -> pb.AppOutputLineV1
+	8,   // 82: pb.AppProgressV1.base:type_name -> pb.BaseResponse
+	3,   // 83: pb.AppProgressV1.stage:type_name -> pb.DeployStage
+	7,   // 84: pb.CancelOperationRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 85: pb.CancelOperationResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 86: pb.StartAppsRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 87: pb.StartAppsResponseV1.base:type_name -> pb.BaseResponse
+	79,  // 88: pb.StartAppsResponseV1.results:type_name -> pb.StartAppResultV1
+	7,   // 89: pb.ReconcileAppRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 90: pb.ReconcileAppResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 91: pb.BackupVolumesRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 92: pb.BackupVolumesResponseV1.base:type_name -> pb.BaseResponse
+	84,  // 93: pb.BackupVolumesResponseV1.backups:type_name -> pb.VolumeBackupV1
+	4,   // 94: pb.DockerEventV1.action:type_name -> pb.DockerEventAction
+	114, // 95: pb.DockerEventV1.time:type_name -> google.protobuf.Timestamp
+	7,   // 96: pb.StreamDockerEventsRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 97: pb.StreamDockerEventsResponseV1.base:type_name -> pb.BaseResponse
+	86,  // 98: pb.StreamDockerEventsResponseV1.events:type_name -> pb.DockerEventV1
+	7,   // 99: pb.GetAppsStatusRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 100: pb.GetAppsStatusResponseV1.base:type_name -> pb.BaseResponse
+	16,  // 101: pb.GetAppsStatusResponseV1.apps:type_name -> pb.AppStatusV1
+	7,   // 102: pb.GetRegistriesRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 103: pb.GetRegistriesResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 104: pb.CreateRegistryRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 105: pb.CreateRegistryResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 106: pb.DeleteRegistryRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 107: pb.DeleteRegistryResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 108: pb.GetNetworksRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 109: pb.GetNetworksResponseV1.base:type_name -> pb.BaseResponse
+	98,  // 110: pb.GetNetworksResponseV1.networks:type_name -> pb.NetworkV1
+	7,   // 111: pb.CreateNetworkRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 112: pb.CreateNetworkResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 113: pb.DeleteNetworkRequestV1.base:type_name -> pb.BaseMessage
+	8,   // 114: pb.DeleteNetworkResponseV1.base:type_name -> pb.BaseResponse
+	7,   // 115: pb.GetAppLogsRequestV1.base:type_name -> pb.BaseMessage
+	114, // 116: pb.GetAppLogsRequestV1.since:type_name -> google.protobuf.Timestamp
+	114, // 117: pb.GetAppLogsRequestV1.until:type_name -> google.protobuf.Timestamp
+	6,   // 118: pb.GetAppLogsRequestV1.level_filter:type_name -> pb.LogLevel
+	113, // 119: pb.AppLogsV1.containers:type_name -> pb.AppLogsV1.ContainersEntry
+	106, // 120: pb.AppLogsV1.logs:type_name -> pb.LogEntryV1
+	114, // 121: pb.LogEntryV1.timestamp:type_name -> google.protobuf.Timestamp
+	5,   // 122: pb.LogEntryV1.channel:type_name -> pb.LogChannel
+	6,   // 123: pb.LogEntryV1.level:type_name -> pb.LogLevel
+	8,   // 124: pb.GetAppLogsResponseV1.base:type_name -> pb.BaseResponse
+	105, // 125: pb.GetAppLogsResponseV1.logs:type_name -> pb.AppLogsV1
+	12,  // 126: pb.ServerCommand.heartbeat_response_v1:type_name -> pb.AgentHeartbeatResponseV1
+	14,  // 127: pb.ServerCommand.metrics_response_v1:type_name -> pb.AgentMetricsResponseV1
+	63,  // 128: pb.ServerCommand.update_agent_request_v1:type_name -> pb.UpdateAgentRequestV1
+	20,  // 129: pb.ServerCommand.get_app_request_v1:type_name -> pb.GetAppRequestV1
+	65,  // 130: pb.ServerCommand.save_app_request_v1:type_name -> pb.SaveAppRequestV1
+	67,  // 131: pb.ServerCommand.rename_app_request_v1:type_name -> pb.RenameAppRequestV1
+	69,  // 132: pb.ServerCommand.delete_app_request_v1:type_name -> pb.DeleteAppRequestV1
+	71,  // 133: pb.ServerCommand.control_app_request_v1:type_name -> pb.ControlAppRequestV1
+	89,  // 134: pb.ServerCommand.get_apps_status_request_v1:type_name -> pb.GetAppsStatusRequestV1
+	91,  // 135: pb.ServerCommand.get_registries_request_v1:type_name -> pb.GetRegistriesRequestV1
+	93,  // 136: pb.ServerCommand.create_registry_request_v1:type_name -> pb.CreateRegistryRequestV1
+	95,  // 137: pb.ServerCommand.delete_registry_request_v1:type_name -> pb.DeleteRegistryRequestV1
+	97,  // 138: pb.ServerCommand.get_networks_request_v1:type_name -> pb.GetNetworksRequestV1
+	100, // 139: pb.ServerCommand.create_network_request_v1:type_name -> pb.CreateNetworkRequestV1
+	102, // 140: pb.ServerCommand.delete_network_request_v1:type_name -> pb.DeleteNetworkRequestV1
+	104, // 141: pb.ServerCommand.get_app_logs_request_v1:type_name -> pb.GetAppLogsRequestV1
+	28,  // 142: pb.ServerCommand.get_app_revisions_request_v1:type_name -> pb.GetAppRevisionsRequestV1
+	31,  // 143: pb.ServerCommand.get_rendered_compose_request_v1:type_name -> pb.GetRenderedComposeRequestV1
+	61,  // 144: pb.ServerCommand.export_app_request_v1:type_name -> pb.ExportAppRequestV1
+	59,  // 145: pb.ServerCommand.import_app_request_v1:type_name -> pb.ImportAppRequestV1
+	40,  // 146: pb.ServerCommand.get_system_info_request_v1:type_name -> pb.GetSystemInfoRequestV1
+	55,  // 147: pb.ServerCommand.set_maintenance_mode_request_v1:type_name -> pb.SetMaintenanceModeRequestV1
+	33,  // 148: pb.ServerCommand.get_app_resources_request_v1:type_name -> pb.GetAppResourcesRequestV1
+	22,  // 149: pb.ServerCommand.get_apps_request_v1:type_name -> pb.GetAppsRequestV1
+	25,  // 150: pb.ServerCommand.validate_app_request_v1:type_name -> pb.ValidateAppRequestV1
+	76,  // 151: pb.ServerCommand.cancel_operation_request_v1:type_name -> pb.CancelOperationRequestV1
+	43,  // 152: pb.ServerCommand.get_connection_stats_request_v1:type_name -> pb.GetConnectionStatsRequestV1
+	87,  // 153: pb.ServerCommand.stream_docker_events_request_v1:type_name -> pb.StreamDockerEventsRequestV1
+	81,  // 154: pb.ServerCommand.reconcile_app_request_v1:type_name -> pb.ReconcileAppRequestV1
+	47,  // 155: pb.ServerCommand.get_agent_config_request_v1:type_name -> pb.GetAgentConfigRequestV1
+	51,  // 156: pb.ServerCommand.get_version_info_request_v1:type_name -> pb.GetVersionInfoRequestV1
+	49,  // 157: pb.ServerCommand.collect_diagnostics_request_v1:type_name -> pb.CollectDiagnosticsRequestV1
+	78,  // 158: pb.ServerCommand.start_apps_request_v1:type_name -> pb.StartAppsRequestV1
+	53,  // 159: pb.ServerCommand.check_for_update_request_v1:type_name -> pb.CheckForUpdateRequestV1
+	83,  // 160: pb.ServerCommand.backup_volumes_request_v1:type_name -> pb.BackupVolumesRequestV1
+	36,  // 161: pb.ServerCommand.get_app_metrics_request_v1:type_name -> pb.GetAppMetricsRequestV1
+	57,  // 162: pb.ServerCommand.clear_safe_mode_request_v1:type_name -> pb.ClearSafeModeRequestV1
+	11,  // 163: pb.AgentMessage.heartbeat_v1:type_name -> pb.AgentHeartbeatV1
+	13,  // 164: pb.AgentMessage.metrics_v1:type_name -> pb.AgentMetricsV1
+	64,  // 165: pb.AgentMessage.update_agent_response_v1:type_name -> pb.UpdateAgentResponseV1
+	21,  // 166: pb.AgentMessage.get_app_response_v1:type_name -> pb.GetAppResponseV1
+	66,  // 167: pb.AgentMessage.save_app_response_v1:type_name -> pb.SaveAppResponseV1
+	68,  // 168: pb.AgentMessage.rename_app_response_v1:type_name -> pb.RenameAppResponseV1
+	70,  // 169: pb.AgentMessage.delete_app_response_v1:type_name -> pb.DeleteAppResponseV1
+	72,  // 170: pb.AgentMessage.control_app_response_v1:type_name -> pb.ControlAppResponseV1
+	90,  // 171: pb.AgentMessage.get_apps_status_response_v1:type_name -> pb.GetAppsStatusResponseV1
+	92,  // 172: pb.AgentMessage.get_registries_response_v1:type_name -> pb.GetRegistriesResponseV1
+	94,  // 173: pb.AgentMessage.create_registry_response_v1:type_name -> pb.CreateRegistryResponseV1
+	96,  // 174: pb.AgentMessage.delete_registry_response_v1:type_name -> pb.DeleteRegistryResponseV1
+	99,  // 175: pb.AgentMessage.get_networks_response_v1:type_name -> pb.GetNetworksResponseV1
+	101, // 176: pb.AgentMessage.create_network_response_v1:type_name -> pb.CreateNetworkResponseV1
+	103, // 177: pb.AgentMessage.delete_network_response_v1:type_name -> pb.DeleteNetworkResponseV1
+	107, // 178: pb.AgentMessage.get_app_logs_response_v1:type_name -> pb.GetAppLogsResponseV1
+	30,  // 179: pb.AgentMessage.get_app_revisions_response_v1:type_name -> pb.GetAppRevisionsResponseV1
+	32,  // 180: pb.AgentMessage.get_rendered_compose_response_v1:type_name -> pb.GetRenderedComposeResponseV1
+	62,  // 181: pb.AgentMessage.export_app_response_v1:type_name -> pb.ExportAppResponseV1
+	60,  // 182: pb.AgentMessage.import_app_response_v1:type_name -> pb.ImportAppResponseV1
+	42,  // 183: pb.AgentMessage.get_system_info_response_v1:type_name -> pb.GetSystemInfoResponseV1
+	56,  // 184: pb.AgentMessage.set_maintenance_mode_response_v1:type_name -> pb.SetMaintenanceModeResponseV1
+	35,  // 185: pb.AgentMessage.get_app_resources_response_v1:type_name -> pb.GetAppResourcesResponseV1
+	24,  // 186: pb.AgentMessage.get_apps_response_v1:type_name -> pb.GetAppsResponseV1
+	27,  // 187: pb.AgentMessage.validate_app_response_v1:type_name -> pb.ValidateAppResponseV1
+	77,  // 188: pb.AgentMessage.cancel_operation_response_v1:type_name -> pb.CancelOperationResponseV1
+	46,  // 189: pb.AgentMessage.get_connection_stats_response_v1:type_name -> pb.GetConnectionStatsResponseV1
+	88,  // 190: pb.AgentMessage.stream_docker_events_response_v1:type_name -> pb.StreamDockerEventsResponseV1
+	82,  // 191: pb.AgentMessage.reconcile_app_response_v1:type_name -> pb.ReconcileAppResponseV1
+	74,  // 192: pb.AgentMessage.app_output_v1:type_name -> pb.AppOutputV1
+	48,  // 193: pb.AgentMessage.get_agent_config_response_v1:type_name -> pb.GetAgentConfigResponseV1
+	75,  // 194: pb.AgentMessage.app_progress_v1:type_name -> pb.AppProgressV1
+	52,  // 195: pb.AgentMessage.get_version_info_response_v1:type_name -> pb.GetVersionInfoResponseV1
+	50,  // 196: pb.AgentMessage.collect_diagnostics_response_v1:type_name -> pb.CollectDiagnosticsResponseV1
+	80,  // 197: pb.AgentMessage.start_apps_response_v1:type_name -> pb.StartAppsResponseV1
+	54,  // 198: pb.AgentMessage.check_for_update_response_v1:type_name -> pb.CheckForUpdateResponseV1
+	85,  // 199: pb.AgentMessage.backup_volumes_response_v1:type_name -> pb.BackupVolumesResponseV1
+	39,  // 200: pb.AgentMessage.get_app_metrics_response_v1:type_name -> pb.GetAppMetricsResponseV1
+	58,  // 201: pb.AgentMessage.clear_safe_mode_response_v1:type_name -> pb.ClearSafeModeResponseV1
+	9,   // 202: pb.AgentService.RegisterAgentV1:input_type -> pb.RegisterAgentRequestV1
+	109, // 203: pb.AgentService.AgentStream:input_type -> pb.AgentMessage
+	10,  // 204: pb.AgentService.RegisterAgentV1:output_type -> pb.RegisterAgentResponseV1
+	108, // 205: pb.AgentService.AgentStream:output_type -> pb.ServerCommand
+	204, // [204:206] is the sub-list for method output_type
+	202, // [202:204] is the sub-list for method input_type
+	202, // [202:202] is the sub-list for extension type_name
+	202, // [202:202] is the sub-list for extension extendee
+	0,   // [0:202] is the sub-list for field type_name
 }
 
 func init() { file_internal_infra_winterflow_grpc_pb_server_proto_init() }
@@ -3660,7 +8895,7 @@ func file_internal_infra_winterflow_grpc_pb_server_proto_init() {
 	if File_internal_infra_winterflow_grpc_pb_server_proto != nil {
 		return
 	}
-	file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[43].OneofWrappers = []any{
+	file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[101].OneofWrappers = []any{
 		(*ServerCommand_HeartbeatResponseV1)(nil),
 		(*ServerCommand_MetricsResponseV1)(nil),
 		(*ServerCommand_UpdateAgentRequestV1)(nil),
@@ -3677,8 +8912,29 @@ func file_internal_infra_winterflow_grpc_pb_server_proto_init() {
 		(*ServerCommand_CreateNetworkRequestV1)(nil),
 		(*ServerCommand_DeleteNetworkRequestV1)(nil),
 		(*ServerCommand_GetAppLogsRequestV1)(nil),
-	}
-	file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[44].OneofWrappers = []any{
+		(*ServerCommand_GetAppRevisionsRequestV1)(nil),
+		(*ServerCommand_GetRenderedComposeRequestV1)(nil),
+		(*ServerCommand_ExportAppRequestV1)(nil),
+		(*ServerCommand_ImportAppRequestV1)(nil),
+		(*ServerCommand_GetSystemInfoRequestV1)(nil),
+		(*ServerCommand_SetMaintenanceModeRequestV1)(nil),
+		(*ServerCommand_GetAppResourcesRequestV1)(nil),
+		(*ServerCommand_GetAppsRequestV1)(nil),
+		(*ServerCommand_ValidateAppRequestV1)(nil),
+		(*ServerCommand_CancelOperationRequestV1)(nil),
+		(*ServerCommand_GetConnectionStatsRequestV1)(nil),
+		(*ServerCommand_StreamDockerEventsRequestV1)(nil),
+		(*ServerCommand_ReconcileAppRequestV1)(nil),
+		(*ServerCommand_GetAgentConfigRequestV1)(nil),
+		(*ServerCommand_GetVersionInfoRequestV1)(nil),
+		(*ServerCommand_CollectDiagnosticsRequestV1)(nil),
+		(*ServerCommand_StartAppsRequestV1)(nil),
+		(*ServerCommand_CheckForUpdateRequestV1)(nil),
+		(*ServerCommand_BackupVolumesRequestV1)(nil),
+		(*ServerCommand_GetAppMetricsRequestV1)(nil),
+		(*ServerCommand_ClearSafeModeRequestV1)(nil),
+	}
+	file_internal_infra_winterflow_grpc_pb_server_proto_msgTypes[102].OneofWrappers = []any{
 		(*AgentMessage_HeartbeatV1)(nil),
 		(*AgentMessage_MetricsV1)(nil),
 		(*AgentMessage_UpdateAgentResponseV1)(nil),
@@ -3695,14 +8951,37 @@ func file_internal_infra_winterflow_grpc_pb_server_proto_init() {
 		(*AgentMessage_CreateNetworkResponseV1)(nil),
 		(*AgentMessage_DeleteNetworkResponseV1)(nil),
 		(*AgentMessage_GetAppLogsResponseV1)(nil),
+		(*AgentMessage_GetAppRevisionsResponseV1)(nil),
+		(*AgentMessage_GetRenderedComposeResponseV1)(nil),
+		(*AgentMessage_ExportAppResponseV1)(nil),
+		(*AgentMessage_ImportAppResponseV1)(nil),
+		(*AgentMessage_GetSystemInfoResponseV1)(nil),
+		(*AgentMessage_SetMaintenanceModeResponseV1)(nil),
+		(*AgentMessage_GetAppResourcesResponseV1)(nil),
+		(*AgentMessage_GetAppsResponseV1)(nil),
+		(*AgentMessage_ValidateAppResponseV1)(nil),
+		(*AgentMessage_CancelOperationResponseV1)(nil),
+		(*AgentMessage_GetConnectionStatsResponseV1)(nil),
+		(*AgentMessage_StreamDockerEventsResponseV1)(nil),
+		(*AgentMessage_ReconcileAppResponseV1)(nil),
+		(*AgentMessage_AppOutputV1)(nil),
+		(*AgentMessage_GetAgentConfigResponseV1)(nil),
+		(*AgentMessage_AppProgressV1)(nil),
+		(*AgentMessage_GetVersionInfoResponseV1)(nil),
+		(*AgentMessage_CollectDiagnosticsResponseV1)(nil),
+		(*AgentMessage_StartAppsResponseV1)(nil),
+		(*AgentMessage_CheckForUpdateResponseV1)(nil),
+		(*AgentMessage_BackupVolumesResponseV1)(nil),
+		(*AgentMessage_GetAppMetricsResponseV1)(nil),
+		(*AgentMessage_ClearSafeModeResponseV1)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc), len(file_internal_infra_winterflow_grpc_pb_server_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   48,
+			NumEnums:      7,
+			NumMessages:   107,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
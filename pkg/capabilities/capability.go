@@ -5,16 +5,27 @@ const (
 	CapabilityDocker        = "docker"
 	CapabilityDockerCompose = "docker_compose"
 	CapabilityDockerSwarm   = "docker_swarm"
+	// CapabilityDockerComposePlugin is the version of the `docker compose` CLI plugin.
+	CapabilityDockerComposePlugin = "docker_compose_plugin"
+	// CapabilityDockerSwarmState is the swarm state of the Docker engine, e.g. "active" or "inactive".
+	CapabilityDockerSwarmState = "docker_swarm_state"
 	// System info capabilities
 	SystemCapabilityCpuCores    = "system_cpu_cores"
 	CapabilitySystemMemoryTotal = "system_memory_total"
 	CapabilitySystemDiskTotal   = "system_disk_total"
+	// CapabilitySystemMemoryAvailable and CapabilitySystemDiskAvailable report the free resources of the host
+	// when the capabilities are collected.
+	CapabilitySystemMemoryAvailable = "system_memory_available"
+	CapabilitySystemDiskAvailable   = "system_disk_available"
 	// OS capabilities
 	CapabilityOS     = "os"
 	CapabilityOSArch = "os_arch"
 	// Agent capabilities
 	CapabilityAgentVersion = "agent_version"
 	CapabilityServerIP     = "server_ip"
+	// Network capabilities
+	CapabilityNetworkInterfaces = "network_interfaces"
+	CapabilityPublicIP          = "public_ip"
 )
 
 // Capability represents a system capability that can be detected
@@ -0,0 +1,51 @@
+package capabilities
+
+import (
+	"net/http"
+	"reflect"
+
+	"winterflow-agent/internal/application/config"
+	"winterflow-agent/pkg/command"
+)
+
+// Collect detects the capabilities of the host the agent runs on: the installed orchestrators, the system
+// resources and the agent itself. Capabilities that cannot be detected are reported with an empty value.
+func Collect(cfg *config.Config) map[string]string {
+	runner := command.NewExecRunner()
+	return collect(
+		NewDockerCapability(),
+		NewDockerComposeCapability(),
+		NewDockerComposePluginCapability(runner, cfg.GetDockerContext()),
+		NewDockerSwarmCapability(),
+		NewDockerSwarmStateCapability(runner, cfg.GetDockerContext()),
+		// System info capabilities
+		NewSystemCpuCoresCapability(),
+		NewSystemMemoryTotalCapability(),
+		NewSystemMemoryAvailableCapability(),
+		NewSystemDiskTotalCapability("/"),
+		// Apps are deployed below the base path, which may be on another disk than the root.
+		NewSystemDiskAvailableCapability(cfg.BasePath),
+		// OS capabilities
+		NewSystemOSCapability(),
+		NewSystemOSArchCapability(),
+		// Agent capabilities
+		NewAgentVersionCapability(),
+		NewServerIPCapability(),
+		// Network capabilities; the public IP is only looked up when a lookup service is configured.
+		NewNetworkInterfacesCapability(),
+		NewPublicIPCapability(http.DefaultClient, cfg.PublicIPLookupURL),
+	)
+}
+
+// collect returns the values of the capabilities by name, skipping the capabilities unsupported on this OS.
+func collect(capabilities ...Capability) map[string]string {
+	result := make(map[string]string, len(capabilities))
+	for _, capability := range capabilities {
+		// Constructors return typed nil pointers for capabilities unsupported on this OS.
+		if capability == nil || reflect.ValueOf(capability).IsNil() {
+			continue
+		}
+		result[capability.Name()] = capability.Value()
+	}
+	return result
+}
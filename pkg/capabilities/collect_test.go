@@ -0,0 +1,82 @@
+package capabilities
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"winterflow-agent/pkg/command"
+)
+
+// probeRunner answers docker commands by their arguments and fails unknown ones.
+type probeRunner struct {
+	outputs  map[string]string
+	commands []string
+}
+
+func (r *probeRunner) Output(cmd command.Cmd) ([]byte, []byte, error) {
+	line := strings.Join(append([]string{cmd.Name}, cmd.Args...), " ")
+	r.commands = append(r.commands, line)
+	if out, ok := r.outputs[line]; ok {
+		return []byte(out), nil, nil
+	}
+	return nil, []byte("unknown command"), errors.New("exit status 1")
+}
+
+func (r *probeRunner) CombinedOutput(cmd command.Cmd) ([]byte, error) {
+	stdout, stderr, err := r.Output(cmd)
+	return append(stdout, stderr...), err
+}
+
+// fixedMetric reports a constant value.
+type fixedMetric string
+
+func (m fixedMetric) Name() string  { return "fixed" }
+func (m fixedMetric) Value() string { return string(m) }
+
+func TestCollectWithInjectedDetectors(t *testing.T) {
+	runner := &probeRunner{outputs: map[string]string{
+		"docker compose version --short":                 "2.29.1\n",
+		"docker info --format {{.Swarm.LocalNodeState}}": "active\n",
+	}}
+
+	got := collect(
+		NewDockerComposePluginCapability(runner, ""),
+		NewDockerSwarmStateCapability(runner, ""),
+		&SystemMemoryAvailableCapability{metric: fixedMetric("2048000")},
+		&SystemDiskAvailableCapability{metric: fixedMetric("53687091200")},
+		(*SystemMemoryTotalCapability)(nil),
+	)
+
+	expected := map[string]string{
+		CapabilityDockerComposePlugin:   "2.29.1",
+		CapabilityDockerSwarmState:      "active",
+		CapabilitySystemMemoryAvailable: "2048000",
+		CapabilitySystemDiskAvailable:   "53687091200",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestDockerProbeCapabilitiesWithoutDocker(t *testing.T) {
+	runner := &probeRunner{}
+
+	got := collect(NewDockerComposePluginCapability(runner, ""), NewDockerSwarmStateCapability(runner, ""))
+
+	expected := map[string]string{CapabilityDockerComposePlugin: "", CapabilityDockerSwarmState: ""}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected empty values when docker is not available, got %v", got)
+	}
+}
+
+func TestDockerProbeCapabilitiesUseDockerContext(t *testing.T) {
+	runner := &probeRunner{outputs: map[string]string{
+		"docker --context remote compose version --short": "2.30.0",
+	}}
+
+	if got := NewDockerComposePluginCapability(runner, "remote").Value(); got != "2.30.0" {
+		t.Errorf("Expected the version of the plugin of the remote context, got %q (commands %v)", got, runner.commands)
+	}
+}
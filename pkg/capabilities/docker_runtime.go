@@ -0,0 +1,54 @@
+package capabilities
+
+import (
+	"strings"
+	"time"
+
+	"winterflow-agent/pkg/command"
+)
+
+// dockerProbeTimeout bounds every docker command run to detect a capability.
+const dockerProbeTimeout = 30 * time.Second
+
+// DockerProbeCapability reports the output of a docker command probing the host, e.g. the version of the
+// compose plugin. The value is empty when the command fails.
+type DockerProbeCapability struct {
+	name  string
+	value string
+}
+
+// newDockerProbeCapability runs docker with args through runner against dockerContext (the default context
+// when empty) and reports its trimmed output as capability name.
+func newDockerProbeCapability(runner command.Runner, dockerContext, name string, args ...string) *DockerProbeCapability {
+	capability := &DockerProbeCapability{name: name}
+	if dockerContext != "" {
+		args = append([]string{"--context", dockerContext}, args...)
+	}
+	stdout, _, err := runner.Output(command.Cmd{Name: "docker", Args: args, Timeout: dockerProbeTimeout})
+	if err != nil {
+		return capability
+	}
+	capability.value = strings.TrimSpace(string(stdout))
+	return capability
+}
+
+// NewDockerComposePluginCapability detects the version of the `docker compose` CLI plugin.
+func NewDockerComposePluginCapability(runner command.Runner, dockerContext string) *DockerProbeCapability {
+	return newDockerProbeCapability(runner, dockerContext, CapabilityDockerComposePlugin, "compose", "version", "--short")
+}
+
+// NewDockerSwarmStateCapability detects the swarm state of the Docker engine: "inactive", "pending",
+// "active", "error" or "locked".
+func NewDockerSwarmStateCapability(runner command.Runner, dockerContext string) *DockerProbeCapability {
+	return newDockerProbeCapability(runner, dockerContext, CapabilityDockerSwarmState, "info", "--format", "{{.Swarm.LocalNodeState}}")
+}
+
+// Name implements Capability.
+func (c *DockerProbeCapability) Name() string {
+	return c.name
+}
+
+// Value implements Capability.
+func (c *DockerProbeCapability) Value() string {
+	return c.value
+}
@@ -0,0 +1,177 @@
+package capabilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"winterflow-agent/pkg/log"
+)
+
+const (
+	// publicIPLookupTimeout bounds the request to the public IP lookup service.
+	publicIPLookupTimeout = 10 * time.Second
+	// maxPublicIPResponseBytes caps the body read from the public IP lookup service.
+	maxPublicIPResponseBytes = 256
+)
+
+// NetworkInterface is a network interface of the host with the addresses assigned to it in CIDR notation.
+type NetworkInterface struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+// hostInterface is a network interface as listed by the operating system.
+type hostInterface struct {
+	name  string
+	flags net.Flags
+	addrs []net.Addr
+}
+
+// listHostInterfaces lists the network interfaces of the host with their addresses.
+func listHostInterfaces() ([]hostInterface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]hostInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			log.Warn("Failed to list the addresses of a network interface", "interface", iface.Name, "error", err)
+			continue
+		}
+		result = append(result, hostInterface{name: iface.Name, flags: iface.Flags, addrs: addrs})
+	}
+	return result, nil
+}
+
+// networkInterfaces returns the interfaces of list that are up and not loopback interfaces, with their
+// addresses. Interfaces without an address are left out.
+func networkInterfaces(list func() ([]hostInterface, error)) ([]NetworkInterface, error) {
+	interfaces, err := list()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]NetworkInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if iface.flags&net.FlagUp == 0 || iface.flags&net.FlagLoopback != 0 {
+			continue
+		}
+		var addresses []string
+		for _, addr := range iface.addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.IsLoopback() {
+				continue
+			}
+			addresses = append(addresses, addr.String())
+		}
+		if len(addresses) > 0 {
+			result = append(result, NetworkInterface{Name: iface.name, Addresses: addresses})
+		}
+	}
+	return result, nil
+}
+
+// NetworkInterfacesCapability reports the non-loopback network interfaces of the host and their addresses
+// as a JSON array of NetworkInterface.
+type NetworkInterfacesCapability struct {
+	value string
+}
+
+// NewNetworkInterfacesCapability detects the network interfaces of the host.
+func NewNetworkInterfacesCapability() *NetworkInterfacesCapability {
+	return newNetworkInterfacesCapability(listHostInterfaces)
+}
+
+// newNetworkInterfacesCapability detects the network interfaces returned by list. The value is empty when
+// they cannot be listed.
+func newNetworkInterfacesCapability(list func() ([]hostInterface, error)) *NetworkInterfacesCapability {
+	capability := &NetworkInterfacesCapability{}
+	interfaces, err := networkInterfaces(list)
+	if err != nil {
+		log.Warn("Failed to list network interfaces", "error", err)
+		return capability
+	}
+	value, err := json.Marshal(interfaces)
+	if err != nil {
+		return capability
+	}
+	capability.value = string(value)
+	return capability
+}
+
+// Name implements Capability.
+func (c *NetworkInterfacesCapability) Name() string {
+	return CapabilityNetworkInterfaces
+}
+
+// Value implements Capability.
+func (c *NetworkInterfacesCapability) Value() string {
+	return c.value
+}
+
+// PublicIPCapability reports the public IP address of the host as seen by a lookup service.
+type PublicIPCapability struct {
+	value string
+}
+
+// NewPublicIPCapability looks up the public IP address of the host at lookupURL, a service answering with
+// the address of the caller in plain text. It returns nil when lookupURL is empty, so that the address is
+// only looked up and reported when configured.
+func NewPublicIPCapability(httpClient *http.Client, lookupURL string) *PublicIPCapability {
+	if lookupURL == "" {
+		return nil
+	}
+	capability := &PublicIPCapability{}
+	ip, err := lookupPublicIP(httpClient, lookupURL)
+	if err != nil {
+		log.Warn("Failed to look up the public IP address", "error", err)
+		return capability
+	}
+	capability.value = ip
+	return capability
+}
+
+// lookupPublicIP requests lookupURL and returns the IP address it answers with.
+func lookupPublicIP(httpClient *http.Client, lookupURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), publicIPLookupTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid public IP lookup URL: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("public IP lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("public IP lookup failed with status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPublicIPResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read public IP lookup response: %w", err)
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return "", fmt.Errorf("public IP lookup answered with %q, not an IP address", strings.TrimSpace(string(body)))
+	}
+	return ip.String(), nil
+}
+
+// Name implements Capability.
+func (c *PublicIPCapability) Name() string {
+	return CapabilityPublicIP
+}
+
+// Value implements Capability.
+func (c *PublicIPCapability) Value() string {
+	return c.value
+}
@@ -0,0 +1,83 @@
+package capabilities
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeInterfaces returns a list of host interfaces: loopback, an interface that is down, one without
+// addresses, and two that are up with addresses.
+func fakeInterfaces() ([]hostInterface, error) {
+	cidr := func(s string) net.Addr {
+		ip, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			panic(err)
+		}
+		ipNet.IP = ip
+		return ipNet
+	}
+	return []hostInterface{
+		{name: "lo", flags: net.FlagUp | net.FlagLoopback, addrs: []net.Addr{cidr("127.0.0.1/8"), cidr("::1/128")}},
+		{name: "eth0", flags: net.FlagUp | net.FlagBroadcast, addrs: []net.Addr{cidr("192.168.1.10/24"), cidr("fe80::1/64")}},
+		{name: "eth1", flags: net.FlagBroadcast, addrs: []net.Addr{cidr("10.0.0.5/16")}},
+		{name: "wg0", flags: net.FlagUp},
+		{name: "docker0", flags: net.FlagUp | net.FlagBroadcast, addrs: []net.Addr{cidr("172.17.0.1/16")}},
+	}, nil
+}
+
+func TestNetworkInterfacesCapability(t *testing.T) {
+	capability := newNetworkInterfacesCapability(fakeInterfaces)
+
+	expected := `[{"name":"eth0","addresses":["192.168.1.10/24","fe80::1/64"]},{"name":"docker0","addresses":["172.17.0.1/16"]}]`
+	if capability.Name() != CapabilityNetworkInterfaces || capability.Value() != expected {
+		t.Errorf("Expected %s, got %s=%s", expected, capability.Name(), capability.Value())
+	}
+}
+
+func TestNetworkInterfacesCapabilityWithoutInterfaces(t *testing.T) {
+	failing := func() ([]hostInterface, error) { return nil, errors.New("not permitted") }
+	if got := newNetworkInterfacesCapability(failing).Value(); got != "" {
+		t.Errorf("Expected an empty value when the interfaces cannot be listed, got %q", got)
+	}
+}
+
+func TestPublicIPCapability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "203.0.113.7")
+	}))
+	defer server.Close()
+
+	capability := NewPublicIPCapability(server.Client(), server.URL)
+	if capability.Name() != CapabilityPublicIP || capability.Value() != "203.0.113.7" {
+		t.Errorf("Expected the public IP of the lookup service, got %s=%q", capability.Name(), capability.Value())
+	}
+}
+
+func TestPublicIPCapabilityIsOptIn(t *testing.T) {
+	if capability := NewPublicIPCapability(http.DefaultClient, ""); capability != nil {
+		t.Errorf("Expected no public IP capability without a lookup URL, got %q", capability.Value())
+	}
+	if got := collect(NewPublicIPCapability(http.DefaultClient, "")); len(got) != 0 {
+		t.Errorf("Expected the public IP not to be reported, got %v", got)
+	}
+}
+
+func TestPublicIPCapabilityRejectsInvalidResponses(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"not an IP":    func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "<html>hello</html>") },
+		"error status": func(w http.ResponseWriter, _ *http.Request) { http.Error(w, "203.0.113.7", http.StatusTooManyRequests) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			if got := NewPublicIPCapability(server.Client(), server.URL).Value(); got != "" {
+				t.Errorf("Expected an empty value, got %q", got)
+			}
+		})
+	}
+}
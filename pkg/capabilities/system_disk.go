@@ -35,3 +35,33 @@ func (c *SystemDiskTotalCapability) Value() string {
 	}
 	return c.metric.Value()
 }
+
+// SystemDiskAvailableCapability reports the bytes available to the agent in the given mount point.
+// On unsupported OSes (e.g., Windows) returns empty value.
+type SystemDiskAvailableCapability struct {
+	metric metrics.Metric
+}
+
+// NewSystemDiskAvailableCapability returns a new SystemDiskAvailableCapability.
+// Returns nil if the OS is Windows, as the implementation uses syscall.Statfs which is not available on Windows.
+func NewSystemDiskAvailableCapability(path string) *SystemDiskAvailableCapability {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	return &SystemDiskAvailableCapability{
+		metric: metrics.NewSystemDiskAvailableMetric(path),
+	}
+}
+
+// Name implements Capability.
+func (c *SystemDiskAvailableCapability) Name() string {
+	return CapabilitySystemDiskAvailable
+}
+
+// Value implements Capability: returns available disk space in bytes.
+func (c *SystemDiskAvailableCapability) Value() string {
+	if c == nil || c.metric == nil {
+		return ""
+	}
+	return c.metric.Value()
+}
@@ -34,3 +34,33 @@ func (c *SystemMemoryTotalCapability) Value() string {
 	}
 	return c.metric.Value()
 }
+
+// SystemMemoryAvailableCapability reports the memory available for new processes on Linux.
+// Returns empty value on unsupported OSes.
+type SystemMemoryAvailableCapability struct {
+	metric metrics.Metric
+}
+
+// NewSystemMemoryAvailableCapability returns a new SystemMemoryAvailableCapability.
+// Returns nil if the OS is not Linux, as the implementation reads from /proc/meminfo which is only available on Linux.
+func NewSystemMemoryAvailableCapability() *SystemMemoryAvailableCapability {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	return &SystemMemoryAvailableCapability{
+		metric: metrics.NewSystemMemoryAvailableMetric(),
+	}
+}
+
+// Name implements Capability.
+func (c *SystemMemoryAvailableCapability) Name() string {
+	return CapabilitySystemMemoryAvailable
+}
+
+// Value implements Capability: reads available memory from metrics.
+func (c *SystemMemoryAvailableCapability) Value() string {
+	if c == nil || c.metric == nil {
+		return ""
+	}
+	return c.metric.Value()
+}
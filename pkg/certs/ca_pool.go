@@ -0,0 +1,93 @@
+package certs
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"winterflow-agent/pkg/log"
+)
+
+// CAPoolOptions describes the CA certificates trusted in addition to the agent CA certificate.
+// The zero value trusts the agent CA certificate only.
+type CAPoolOptions struct {
+	// Dir names a directory whose PEM encoded certificate files are all added to the pool.
+	Dir string
+	// UseSystemRoots starts the pool from the CA certificates of the operating system.
+	UseSystemRoots bool
+}
+
+// BuildCAPool returns the pool of CA certificates used to verify the server.
+//
+// By default the CA certificate at caCertPath must exist and be valid. When additional sources are
+// configured in opts, a missing caCertPath is tolerated as long as the resulting pool is not empty,
+// which allows connecting through TLS inspection proxies trusted by the host.
+func BuildCAPool(caCertPath string, opts CAPoolOptions) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if opts.UseSystemRoots {
+		systemPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load system CA certificates: %v", err)
+		}
+		pool = systemPool
+	}
+
+	strict := opts.Dir == "" && !opts.UseSystemRoots
+	added := 0
+
+	caCert, err := os.ReadFile(caCertPath)
+	switch {
+	case err == nil:
+		if ok := pool.AppendCertsFromPEM(caCert); !ok {
+			return nil, fmt.Errorf("failed to append CA certificate to pool")
+		}
+		added++
+	case strict || !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+	default:
+		log.Warn("CA certificate not found, relying on additional CA certificates", "path", caCertPath)
+	}
+
+	if opts.Dir != "" {
+		count, err := appendCertsFromDir(pool, opts.Dir)
+		if err != nil {
+			return nil, err
+		}
+		added += count
+	}
+
+	if added == 0 && !opts.UseSystemRoots {
+		return nil, fmt.Errorf("no CA certificates found in %s", opts.Dir)
+	}
+	return pool, nil
+}
+
+// appendCertsFromDir adds every PEM file in dir to pool, in lexical order, and returns the number of
+// files added. Subdirectories and hidden files are skipped; a file without certificates is an error.
+func appendCertsFromDir(pool *x509.CertPool, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CA certificates directory: %v", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name()[0] == '.' {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read CA certificate %s: %v", name, err)
+		}
+		if ok := pool.AppendCertsFromPEM(data); !ok {
+			return 0, fmt.Errorf("failed to append CA certificate %s to pool", name)
+		}
+	}
+	return len(names), nil
+}
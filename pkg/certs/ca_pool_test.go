@@ -0,0 +1,163 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA is a self-signed CA able to issue leaf certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a server certificate for host signed by the CA.
+func (ca *testCA) issue(t *testing.T, host string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate leaf key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("Failed to create leaf certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse leaf certificate: %v", err)
+	}
+	return cert
+}
+
+func writeCA(t *testing.T, path string, ca *testCA) {
+	t.Helper()
+	if err := os.WriteFile(path, ca.pem, 0o644); err != nil {
+		t.Fatalf("Failed to write CA certificate: %v", err)
+	}
+}
+
+func trusts(pool *x509.CertPool, leaf *x509.Certificate) bool {
+	_, err := leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: leaf.DNSNames[0]})
+	return err == nil
+}
+
+func TestBuildCAPoolIsStrictByDefault(t *testing.T) {
+	dir := t.TempDir()
+	agentCA := newTestCA(t, "agent-ca")
+	caPath := filepath.Join(dir, "ca.crt")
+	writeCA(t, caPath, agentCA)
+
+	pool, err := BuildCAPool(caPath, CAPoolOptions{})
+	if err != nil {
+		t.Fatalf("BuildCAPool returned error: %v", err)
+	}
+	if !trusts(pool, agentCA.issue(t, "grpc.example.com")) {
+		t.Error("Expected the agent CA to be trusted")
+	}
+	if trusts(pool, newTestCA(t, "other-ca").issue(t, "grpc.example.com")) {
+		t.Error("Expected other CAs not to be trusted")
+	}
+
+	if _, err := BuildCAPool(filepath.Join(dir, "missing.crt"), CAPoolOptions{}); err == nil {
+		t.Error("Expected a missing CA certificate to fail")
+	}
+}
+
+func TestBuildCAPoolAppendsSystemRoots(t *testing.T) {
+	dir := t.TempDir()
+	agentCA := newTestCA(t, "agent-ca")
+	systemCA := newTestCA(t, "proxy-ca")
+	caPath := filepath.Join(dir, "ca.crt")
+	systemPath := filepath.Join(dir, "system.pem")
+	writeCA(t, caPath, agentCA)
+	writeCA(t, systemPath, systemCA)
+
+	// The system pool is loaded once per process, this is the only test reading it.
+	t.Setenv("SSL_CERT_FILE", systemPath)
+	t.Setenv("SSL_CERT_DIR", filepath.Join(dir, "empty"))
+
+	pool, err := BuildCAPool(caPath, CAPoolOptions{UseSystemRoots: true})
+	if err != nil {
+		t.Fatalf("BuildCAPool returned error: %v", err)
+	}
+	if !trusts(pool, agentCA.issue(t, "grpc.example.com")) {
+		t.Error("Expected the agent CA to be trusted")
+	}
+	if !trusts(pool, systemCA.issue(t, "grpc.example.com")) {
+		t.Error("Expected the system CA to be trusted")
+	}
+}
+
+func TestBuildCAPoolLoadsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	caDir := filepath.Join(dir, "cas")
+	if err := os.Mkdir(caDir, 0o755); err != nil {
+		t.Fatalf("Failed to create CA directory: %v", err)
+	}
+	first, second := newTestCA(t, "first-ca"), newTestCA(t, "second-ca")
+	writeCA(t, filepath.Join(caDir, "first.pem"), first)
+	writeCA(t, filepath.Join(caDir, "second.pem"), second)
+
+	// The agent CA is optional once a directory is configured.
+	pool, err := BuildCAPool(filepath.Join(dir, "missing.crt"), CAPoolOptions{Dir: caDir})
+	if err != nil {
+		t.Fatalf("BuildCAPool returned error: %v", err)
+	}
+	for _, ca := range []*testCA{first, second} {
+		if !trusts(pool, ca.issue(t, "grpc.example.com")) {
+			t.Errorf("Expected %s to be trusted", ca.cert.Subject.CommonName)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(caDir, "broken.pem"), []byte("not a certificate"), 0o644); err != nil {
+		t.Fatalf("Failed to write invalid CA file: %v", err)
+	}
+	if _, err := BuildCAPool(filepath.Join(dir, "missing.crt"), CAPoolOptions{Dir: caDir}); err == nil {
+		t.Error("Expected an invalid CA file to fail")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
@@ -169,22 +170,18 @@ func CreateCSR(certificateID string, privateKeyPath, csrPath string) (string, er
 	return csrBuffer.String(), nil
 }
 
-// LoadTLSCredentials loads TLS credentials from certificate and private key files
-func LoadTLSCredentials(caCertPath, certPath, keyPath, host string) (credentials.TransportCredentials, error) {
+// LoadTLSCredentials loads TLS credentials from certificate and private key files. The server is
+// verified against the CA pool described by caCertPath and caOptions, see BuildCAPool.
+func LoadTLSCredentials(caCertPath string, caOptions CAPoolOptions, certPath, keyPath, host string) (credentials.TransportCredentials, error) {
 	// Load certificate and private key
 	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load certificate and private key: %v", err)
 	}
 
-	// Load your CA certificate
-	caCert, err := os.ReadFile(caCertPath)
+	caCertPool, err := BuildCAPool(caCertPath, caOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
-	}
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM(caCert); !ok {
-		return nil, fmt.Errorf("failed to append CA certificate to pool")
+		return nil, err
 	}
 
 	// Create TLS configuration
@@ -330,6 +327,90 @@ func DecryptWithPrivateKey(privateKeyPath, encryptedBase64 string) (string, erro
 	return string(plaintext), nil
 }
 
+// DecryptWithPrivateKeys decrypts encryptedBase64 like DecryptWithPrivateKey, trying each of
+// privateKeyPaths in order until one succeeds. This allows content encrypted for either the old or the
+// new key to be decrypted while keys are rotated. An error is returned only when every key fails.
+func DecryptWithPrivateKeys(privateKeyPaths []string, encryptedBase64 string) (string, error) {
+	if len(privateKeyPaths) == 0 {
+		return "", fmt.Errorf("no private keys configured")
+	}
+
+	var errs []error
+	for _, keyPath := range privateKeyPaths {
+		plaintext, err := DecryptWithPrivateKey(keyPath, encryptedBase64)
+		if err == nil {
+			return plaintext, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(keyPath), err))
+	}
+	return "", fmt.Errorf("failed to decrypt with any of %d private keys: %w", len(privateKeyPaths), errors.Join(errs...))
+}
+
+// EncryptWithPrivateKey encrypts plaintext for the owner of the EC private key
+// stored at privateKeyPath, producing the exact payload layout understood by
+// DecryptWithPrivateKey. An ephemeral P-256 key pair is generated for every
+// call and ECDH is performed against the public half of the agent's key, so
+// the same key file is needed to decrypt the result.
+func EncryptWithPrivateKey(privateKeyPath, plaintext string) (string, error) {
+	keyData, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private key: %v", err)
+	}
+
+	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode private key PEM")
+	}
+
+	if block.Type != "EC PRIVATE KEY" {
+		return "", fmt.Errorf("unsupported private key type %q – only EC (P-256) keys are supported", block.Type)
+	}
+
+	ecKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse EC private key: %v", err)
+	}
+
+	curve := elliptic.P256()
+	ephemeral, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ephemeral key: %v", err)
+	}
+
+	// Derive shared secret (ECDH) and left-pad the X coordinate to 32 bytes.
+	const coordSize = 32 // for P-256
+	sharedX, _ := curve.ScalarMult(ecKey.PublicKey.X, ecKey.PublicKey.Y, ephemeral.D.Bytes())
+	if sharedX == nil {
+		return "", fmt.Errorf("failed to derive shared secret")
+	}
+	keyHash := sha256.Sum256(sharedX.FillBytes(make([]byte, coordSize)))
+
+	blockCipher, err := aes.NewCipher(keyHash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to create AES cipher: %v", err)
+	}
+
+	gcm, err := cipher.NewGCM(blockCipher)
+	if err != nil {
+		return "", fmt.Errorf("failed to create AES-GCM: %v", err)
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", fmt.Errorf("failed to generate IV: %v", err)
+	}
+
+	// Uncompressed ephemeral public key: 0x04 || X || Y.
+	payload := make([]byte, 0, 1+2*coordSize+len(iv)+len(plaintext)+gcm.Overhead())
+	payload = append(payload, 0x04)
+	payload = append(payload, ephemeral.PublicKey.X.FillBytes(make([]byte, coordSize))...)
+	payload = append(payload, ephemeral.PublicKey.Y.FillBytes(make([]byte, coordSize))...)
+	payload = append(payload, iv...)
+	payload = gcm.Seal(payload, iv, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(payload), nil
+}
+
 // SignWithPrivateKey creates an ASN.1-encoded ECDSA signature over msg using
 // the EC private key stored at keyPath.
 func SignWithPrivateKey(keyPath string, msg []byte) (string, error) {
@@ -357,3 +438,44 @@ func SignWithPrivateKey(keyPath string, msg []byte) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(sig), nil
 }
+
+// LoadECPublicKey reads the EC public key stored at path, either as a PEM encoded PKIX public key or as
+// the key of a PEM encoded certificate.
+func LoadECPublicKey(path string) (*ecdsa.PublicKey, error) {
+	keyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode public key PEM")
+	}
+
+	var key interface{}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		key = cert.PublicKey
+	} else {
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+	}
+
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not an EC key")
+	}
+	return ecKey, nil
+}
+
+// VerifyWithPublicKey reports whether sig is a valid ASN.1-encoded ECDSA signature over msg, as created by
+// SignWithPrivateKey, for the public key pub.
+func VerifyWithPublicKey(pub *ecdsa.PublicKey, msg, sig []byte) bool {
+	hash := sha256.Sum256(msg)
+	return ecdsa.VerifyASN1(pub, hash[:], sig)
+}
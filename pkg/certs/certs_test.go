@@ -0,0 +1,129 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateKeys writes one private key per name into a temporary directory and returns their paths.
+func generateKeys(t *testing.T, names ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := GeneratePrivateKey(path); err != nil {
+			t.Fatalf("GeneratePrivateKey returned error: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestDecryptWithPrivateKeysTriesKeysInOrder(t *testing.T) {
+	keys := generateKeys(t, "new.key", "old.key")
+	encrypted, err := EncryptWithPrivateKey(keys[1], "s3cret")
+	if err != nil {
+		t.Fatalf("EncryptWithPrivateKey returned error: %v", err)
+	}
+
+	plaintext, err := DecryptWithPrivateKeys(keys, encrypted)
+	if err != nil {
+		t.Fatalf("DecryptWithPrivateKeys returned error: %v", err)
+	}
+	if plaintext != "s3cret" {
+		t.Errorf("Expected %q, got %q", "s3cret", plaintext)
+	}
+}
+
+func TestDecryptWithPrivateKeysFailsWhenNoKeyMatches(t *testing.T) {
+	keys := generateKeys(t, "agent.key", "previous.key")
+	other := generateKeys(t, "other.key")
+	encrypted, err := EncryptWithPrivateKey(other[0], "s3cret")
+	if err != nil {
+		t.Fatalf("EncryptWithPrivateKey returned error: %v", err)
+	}
+
+	_, err = DecryptWithPrivateKeys(keys, encrypted)
+	if err == nil {
+		t.Fatal("Expected an error when no key decrypts the payload")
+	}
+	for _, want := range []string{"any of 2 private keys", "agent.key", "previous.key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got %v", want, err)
+		}
+	}
+
+	if _, err := DecryptWithPrivateKeys(nil, encrypted); err == nil {
+		t.Error("Expected an error without keys")
+	}
+}
+
+// writePublicKey writes the PEM encoded public key of the private key at keyPath and returns its path.
+func writePublicKey(t *testing.T, keyPath string) string {
+	t.Helper()
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("Failed to read private key: %v", err)
+	}
+	block, _ := pem.Decode(keyBytes)
+	privKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	path := keyPath + ".pub"
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+	return path
+}
+
+func TestVerifyWithPublicKey(t *testing.T) {
+	keys := generateKeys(t, "server.key", "other.key")
+	pub, err := LoadECPublicKey(writePublicKey(t, keys[0]))
+	if err != nil {
+		t.Fatalf("LoadECPublicKey returned error: %v", err)
+	}
+
+	msg := []byte("command")
+	signature, err := SignWithPrivateKey(keys[0], msg)
+	if err != nil {
+		t.Fatalf("SignWithPrivateKey returned error: %v", err)
+	}
+	sig, _ := base64.StdEncoding.DecodeString(signature)
+	if !VerifyWithPublicKey(pub, msg, sig) {
+		t.Error("Expected the signature to verify")
+	}
+	if VerifyWithPublicKey(pub, []byte("tampered"), sig) {
+		t.Error("Expected the signature of another message to be rejected")
+	}
+
+	otherSignature, err := SignWithPrivateKey(keys[1], msg)
+	if err != nil {
+		t.Fatalf("SignWithPrivateKey returned error: %v", err)
+	}
+	otherSig, _ := base64.StdEncoding.DecodeString(otherSignature)
+	if VerifyWithPublicKey(pub, msg, otherSig) {
+		t.Error("Expected the signature of another key to be rejected")
+	}
+}
+
+func TestLoadECPublicKeyRejectsInvalidFiles(t *testing.T) {
+	keys := generateKeys(t, "server.key")
+	// The private key itself is not a public key.
+	if _, err := LoadECPublicKey(keys[0]); err == nil {
+		t.Error("Expected an error for a private key")
+	}
+	if _, err := LoadECPublicKey(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,57 @@
+package certs
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// Fingerprint returns the SHA-256 fingerprint of the DER encoding of cert as lowercase hex.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseFingerprint decodes a SHA-256 fingerprint given as hex, optionally separated by colons as printed
+// by `openssl x509 -fingerprint -sha256`.
+func ParseFingerprint(fingerprint string) ([]byte, error) {
+	raw, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid fingerprint: %w", err)
+	}
+	if len(raw) != sha256.Size {
+		return nil, fmt.Errorf("invalid fingerprint: expected %d bytes, got %d", sha256.Size, len(raw))
+	}
+	return raw, nil
+}
+
+// VerifyPinnedCertificate parses pemData, which must contain exactly one PEM encoded certificate, and
+// returns it when its SHA-256 fingerprint matches the pinned fingerprint.
+func VerifyPinnedCertificate(pemData []byte, fingerprint string) (*x509.Certificate, error) {
+	pinned, err := ParseFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	block, rest := pem.Decode(pemData)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	if next, _ := pem.Decode(rest); next != nil {
+		return nil, fmt.Errorf("expected a single certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	if subtle.ConstantTimeCompare(sum[:], pinned) != 1 {
+		return nil, fmt.Errorf("certificate fingerprint %s does not match the pinned fingerprint", Fingerprint(cert))
+	}
+	return cert, nil
+}
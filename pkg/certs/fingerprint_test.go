@@ -0,0 +1,44 @@
+package certs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyPinnedCertificate(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	other := newTestCA(t, "other")
+	fingerprint := Fingerprint(ca.cert)
+
+	cert, err := VerifyPinnedCertificate(ca.pem, fingerprint)
+	if err != nil || !cert.Equal(ca.cert) {
+		t.Fatalf("Expected the pinned certificate to be accepted, got %v", err)
+	}
+
+	// Fingerprints printed by openssl are upper case and separated by colons.
+	var pairs []string
+	for i := 0; i < len(fingerprint); i += 2 {
+		pairs = append(pairs, strings.ToUpper(fingerprint[i:i+2]))
+	}
+	if _, err := VerifyPinnedCertificate(ca.pem, strings.Join(pairs, ":")); err != nil {
+		t.Errorf("Expected the openssl fingerprint format to be accepted, got %v", err)
+	}
+
+	if _, err := VerifyPinnedCertificate(other.pem, fingerprint); err == nil {
+		t.Error("Expected a certificate with another fingerprint to be rejected")
+	}
+	if _, err := VerifyPinnedCertificate(append(append([]byte{}, ca.pem...), other.pem...), fingerprint); err == nil {
+		t.Error("Expected a bundle of certificates to be rejected")
+	}
+	if _, err := VerifyPinnedCertificate([]byte("not a certificate"), fingerprint); err == nil {
+		t.Error("Expected data without a certificate to be rejected")
+	}
+}
+
+func TestParseFingerprintRejectsInvalidValues(t *testing.T) {
+	for _, fingerprint := range []string{"", "zz", "abcd", strings.Repeat("ab", 20)} {
+		if _, err := ParseFingerprint(fingerprint); err == nil {
+			t.Errorf("Expected fingerprint %q to be rejected", fingerprint)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package command
+
+import "sync"
+
+// FakeResult is the scripted outcome of a single FakeRunner invocation.
+type FakeResult struct {
+	Stdout []byte
+	Stderr []byte
+	Err    error
+}
+
+// FakeRunner is a Runner for tests. It records every command and replays Results in
+// order; once Results are exhausted every command succeeds without output. The scripted output is
+// also written to the Stdout and Stderr writers of the command, as if the program had streamed it.
+type FakeRunner struct {
+	mu       sync.Mutex
+	Results  []FakeResult
+	commands []Cmd
+}
+
+// Commands returns the commands issued so far.
+func (f *FakeRunner) Commands() []Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Cmd(nil), f.commands...)
+}
+
+// CombinedOutput implements Runner.
+func (f *FakeRunner) CombinedOutput(cmd Cmd) ([]byte, error) {
+	result := f.next(cmd)
+	return append(append([]byte(nil), result.Stdout...), result.Stderr...), result.Err
+}
+
+// Output implements Runner.
+func (f *FakeRunner) Output(cmd Cmd) ([]byte, []byte, error) {
+	result := f.next(cmd)
+	return result.Stdout, result.Stderr, result.Err
+}
+
+func (f *FakeRunner) next(cmd Cmd) FakeResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, cmd)
+	if len(f.commands) > len(f.Results) {
+		return FakeResult{}
+	}
+	result := f.Results[len(f.commands)-1]
+	if cmd.Stdout != nil && len(result.Stdout) > 0 {
+		_, _ = cmd.Stdout.Write(result.Stdout)
+	}
+	if cmd.Stderr != nil && len(result.Stderr) > 0 {
+		_, _ = cmd.Stderr.Write(result.Stderr)
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Cmd describes a single invocation of an external program.
+type Cmd struct {
+	// Name is the program to execute, looked up in PATH.
+	Name string
+	// Args are passed to the program as is.
+	Args []string
+	// Dir is the working directory; empty uses the current one.
+	Dir string
+	// Env is appended to the environment of the current process.
+	Env []string
+	// Timeout kills the program once elapsed; zero means no timeout.
+	Timeout time.Duration
+	// Context interrupts the program when it is canceled, killing it if it does not exit within
+	// cancelWaitDelay; nil means the program cannot be canceled.
+	Context context.Context
+	// Stdin, when set, is connected to the standard input of the program.
+	Stdin io.Reader
+	// Stdout and Stderr, when set, additionally receive the standard output and standard error of the
+	// program while it runs.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// cancelWaitDelay is how long a program may take to exit after being interrupted by its canceled Context.
+const cancelWaitDelay = 10 * time.Second
+
+// Runner executes external programs. It is the single place where the agent spawns
+// processes so that callers can be tested without running real binaries and so that
+// sandboxing or resource limits can be applied centrally.
+type Runner interface {
+	// CombinedOutput runs cmd and returns its standard output and standard error interleaved.
+	CombinedOutput(cmd Cmd) ([]byte, error)
+	// Output runs cmd and returns its standard output and standard error separately.
+	Output(cmd Cmd) (stdout []byte, stderr []byte, err error)
+}
+
+// ExecRunner is the Runner backed by os/exec.
+type ExecRunner struct{}
+
+// NewExecRunner creates a Runner that spawns real processes.
+func NewExecRunner() *ExecRunner {
+	return &ExecRunner{}
+}
+
+// CombinedOutput implements Runner.
+func (r *ExecRunner) CombinedOutput(cmd Cmd) ([]byte, error) {
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	c := r.command(ctx, cmd)
+	if cmd.Stdout == nil && cmd.Stderr == nil {
+		output, err := c.CombinedOutput()
+		return output, contextError(ctx, cmd, err)
+	}
+
+	var combined lockedBuffer
+	c.Stdout = teeWriter(&combined, cmd.Stdout)
+	c.Stderr = teeWriter(&combined, cmd.Stderr)
+	err := c.Run()
+	return combined.Bytes(), contextError(ctx, cmd, err)
+}
+
+// Output implements Runner.
+func (r *ExecRunner) Output(cmd Cmd) ([]byte, []byte, error) {
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	c := r.command(ctx, cmd)
+	var stderr bytes.Buffer
+	c.Stderr = teeWriter(&stderr, cmd.Stderr)
+	if cmd.Stdout == nil {
+		stdout, err := c.Output()
+		return stdout, stderr.Bytes(), contextError(ctx, cmd, err)
+	}
+
+	var stdout bytes.Buffer
+	c.Stdout = teeWriter(&stdout, cmd.Stdout)
+	err := c.Run()
+	return stdout.Bytes(), stderr.Bytes(), contextError(ctx, cmd, err)
+}
+
+// teeWriter returns a writer writing to buf and, when set, to w.
+func teeWriter(buf io.Writer, w io.Writer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(buf, w)
+}
+
+// lockedBuffer is a buffer that standard output and standard error can be written to concurrently.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Bytes returns the data written so far.
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
+func (r *ExecRunner) command(ctx context.Context, cmd Cmd) *exec.Cmd {
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	c.Dir = cmd.Dir
+	c.Stdin = cmd.Stdin
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+	if cmd.Context != nil {
+		// Give the program, e.g. `docker compose up`, the chance to stop cleanly before it is killed.
+		c.Cancel = func() error { return c.Process.Signal(os.Interrupt) }
+		c.WaitDelay = cancelWaitDelay
+	}
+	return c
+}
+
+// commandContext returns the context bounding the execution of cmd.
+func commandContext(cmd Cmd) (context.Context, context.CancelFunc) {
+	parent := cmd.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if cmd.Timeout > 0 {
+		return context.WithTimeout(parent, cmd.Timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// contextError reports a killed program as a timeout or cancellation rather than the resulting signal error.
+func contextError(ctx context.Context, cmd Cmd, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("%s timed out after %s: %w", cmd.Name, cmd.Timeout, context.DeadlineExceeded)
+	case context.Canceled:
+		return fmt.Errorf("%s canceled: %w", cmd.Name, context.Canceled)
+	}
+	return err
+}
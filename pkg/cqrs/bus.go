@@ -2,6 +2,7 @@
 package cqrs
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -31,6 +32,9 @@ type ActionProvider interface {
 	WaitForCompletion()
 }
 
+// contextType is the type of the optional context.Context parameter of Handle methods.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // Bus is a generic implementation that can be used by both command and query buses.
 type Bus struct {
 	handlers       map[string]interface{}
@@ -38,6 +42,8 @@ type Bus struct {
 	isShuttingDown bool
 	activeMessages sync.WaitGroup
 	busType        string // "command" or "query"
+	// contextHandlers allows Handle methods taking a context.Context before the message.
+	contextHandlers bool
 }
 
 // NewBus creates a new Bus with the specified type.
@@ -72,7 +78,11 @@ func (b *Bus) Register(handler interface{}, messageType reflect.Type, validateFu
 
 	// Check method signature
 	methodType := handleMethod.Type
-	if methodType.NumIn() != 2 { // receiver + message
+	params := 1
+	if b.contextHandlers && handlesWithContext(methodType) {
+		params = 2
+	}
+	if methodType.NumIn() != params+1 { // receiver + [context +] message
 		return fmt.Errorf("Handle method must have exactly one parameter (the %s)", b.busType)
 	}
 
@@ -91,6 +101,16 @@ func (b *Bus) Register(handler interface{}, messageType reflect.Type, validateFu
 	return nil
 }
 
+// handlesWithContext reports whether the Handle method of methodType takes a context.Context before the message.
+func handlesWithContext(methodType reflect.Type) bool {
+	return methodType.NumIn() == 3 && methodType.In(1) == contextType
+}
+
+// messageParam returns the type of the message parameter of the Handle method of methodType.
+func messageParam(methodType reflect.Type) reflect.Type {
+	return methodType.In(methodType.NumIn() - 1)
+}
+
 // Shutdown initiates a graceful shutdown of the bus.
 // New messages will be rejected, but existing messages will be allowed to complete.
 func (b *Bus) Shutdown() {
@@ -1,5 +1,7 @@
 package cqrs
 
+import "context"
+
 // Query represents a request for information that does not change the state of the system.
 // Queries are named with verbs in present tense (e.g., "GetUser").
 type Query interface {
@@ -18,4 +20,8 @@ type QueryBus interface {
 
 	// Dispatch sends a query to its appropriate handler and returns the result.
 	Dispatch(query Query) (interface{}, error)
+
+	// DispatchContext sends a query to its appropriate handler and returns the result. It fails when ctx is
+	// done or the query times out before the handler returns.
+	DispatchContext(ctx context.Context, query Query) (interface{}, error)
 }
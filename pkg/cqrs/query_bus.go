@@ -5,14 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 )
 
 // ErrQueryBusShuttingDown is returned when a query is dispatched to a bus that is shutting down.
 var ErrQueryBusShuttingDown = errors.New("query bus is shutting down")
 
+// ErrQueryTimeout is returned when a query does not complete within its timeout.
+var ErrQueryTimeout = errors.New("query timed out")
+
 // DefaultQueryBus is a simple implementation of the QueryBus interface.
 type DefaultQueryBus struct {
 	*Bus
+	timeoutMu sync.RWMutex
+	timeout   func(queryName string) time.Duration
 }
 
 // NewQueryBus creates a new DefaultQueryBus.
@@ -20,6 +27,7 @@ func NewQueryBus(ctx context.Context) *DefaultQueryBus {
 	b := &DefaultQueryBus{
 		Bus: NewBus("query"),
 	}
+	b.contextHandlers = true
 
 	// Listen for context cancellation and initiate graceful shutdown.
 	if ctx != nil {
@@ -32,6 +40,24 @@ func NewQueryBus(ctx context.Context) *DefaultQueryBus {
 	return b
 }
 
+// SetTimeout sets the function returning the timeout of queries by their name. Queries whose timeout is
+// not positive are not limited.
+func (b *DefaultQueryBus) SetTimeout(timeout func(queryName string) time.Duration) {
+	b.timeoutMu.Lock()
+	defer b.timeoutMu.Unlock()
+	b.timeout = timeout
+}
+
+// queryTimeout returns the timeout of the named query, zero when it is not limited.
+func (b *DefaultQueryBus) queryTimeout(queryName string) time.Duration {
+	b.timeoutMu.RLock()
+	defer b.timeoutMu.RUnlock()
+	if b.timeout == nil {
+		return 0
+	}
+	return b.timeout(queryName)
+}
+
 // validateQueryHandler checks if the handler implements QueryHandler[Q, R] and returns the query name.
 func validateQueryHandler(handler interface{}, queryType reflect.Type) (string, error) {
 	// Check if the query type implements Query
@@ -56,7 +82,8 @@ func validateQueryHandler(handler interface{}, queryType reflect.Type) (string,
 }
 
 // Register registers a query handler for a specific query type.
-// The handler must implement QueryHandler[Q, R] where Q is a Query type and R is the result type.
+// The handler must implement QueryHandler[Q, R] where Q is a Query type and R is the result type, or
+// take a context.Context before the query to be canceled with DispatchContext and on timeouts.
 func (b *DefaultQueryBus) Register(handler interface{}) error {
 	// Find the Handle method
 	handlerType := reflect.TypeOf(handler)
@@ -66,13 +93,20 @@ func (b *DefaultQueryBus) Register(handler interface{}) error {
 	}
 
 	// Get the query type
-	queryType := handleMethod.Type.In(1)
+	queryType := messageParam(handleMethod.Type)
 
 	return b.Bus.Register(handler, queryType, validateQueryHandler)
 }
 
 // Dispatch sends a query to its appropriate handler and returns the result.
 func (b *DefaultQueryBus) Dispatch(query Query) (interface{}, error) {
+	return b.DispatchContext(context.Background(), query)
+}
+
+// DispatchContext sends a query to its appropriate handler and returns the result. Handlers taking a
+// context are passed ctx, limited by the timeout of the query. When ctx is done or the timeout elapses
+// first, the error is returned without waiting for the handler.
+func (b *DefaultQueryBus) DispatchContext(ctx context.Context, query Query) (interface{}, error) {
 	if b.IsShuttingDown() {
 		return nil, ErrQueryBusShuttingDown
 	}
@@ -82,15 +116,48 @@ func (b *DefaultQueryBus) Dispatch(query Query) (interface{}, error) {
 		return nil, fmt.Errorf("no handler registered for query %s", query.Name())
 	}
 
-	// Increment the active queries counter
+	timeout := b.queryTimeout(query.Name())
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Increment the active queries counter; it is decremented once the handler returns.
 	b.IncrementActiveCount()
-	defer b.DecrementActiveCount()
 
-	// Call the handler's Handle method with the query
-	handlerValue := reflect.ValueOf(handler)
-	handleMethod := handlerValue.MethodByName("Handle")
+	type result struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer b.DecrementActiveCount()
+		value, err := b.call(ctx, handler, query)
+		done <- result{value: value, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && timeout > 0 {
+			return nil, fmt.Errorf("%w: %s did not complete within %s", ErrQueryTimeout, query.Name(), timeout)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// call calls the Handle method of handler with the query and returns its result.
+func (b *DefaultQueryBus) call(ctx context.Context, handler interface{}, query Query) (interface{}, error) {
+	handleMethod := reflect.ValueOf(handler).MethodByName("Handle")
+	method, _ := reflect.TypeOf(handler).MethodByName("Handle")
+	args := []reflect.Value{reflect.ValueOf(query)}
+	if handlesWithContext(method.Type) {
+		args = append([]reflect.Value{reflect.ValueOf(ctx)}, args...)
+	}
 
-	results := handleMethod.Call([]reflect.Value{reflect.ValueOf(query)})
+	results := handleMethod.Call(args)
 
 	// Check for error (second return value)
 	if !results[1].IsNil() {
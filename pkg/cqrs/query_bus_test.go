@@ -0,0 +1,115 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type slowQuery struct{}
+
+func (slowQuery) Name() string { return "Slow" }
+
+// slowQueryHandler blocks until its context is done and reports the error of the context.
+type slowQueryHandler struct {
+	canceled chan error
+}
+
+func (h *slowQueryHandler) Handle(ctx context.Context, _ slowQuery) (string, error) {
+	<-ctx.Done()
+	h.canceled <- ctx.Err()
+	return "", ctx.Err()
+}
+
+type quickQuery struct{}
+
+func (quickQuery) Name() string { return "Quick" }
+
+type quickQueryHandler struct{}
+
+func (h *quickQueryHandler) Handle(ctx context.Context, _ quickQuery) (bool, error) {
+	_, hasDeadline := ctx.Deadline()
+	return hasDeadline, nil
+}
+
+func TestDispatchCancelsSlowQueryAtTimeout(t *testing.T) {
+	bus := NewQueryBus(nil)
+	handler := &slowQueryHandler{canceled: make(chan error, 1)}
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+	bus.SetTimeout(func(string) time.Duration { return 20 * time.Millisecond })
+
+	start := time.Now()
+	_, err := bus.Dispatch(slowQuery{})
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("Expected ErrQueryTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the query to time out after 20ms, took %s", elapsed)
+	}
+	select {
+	case err := <-handler.canceled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the handler context to exceed its deadline, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the handler to be canceled")
+	}
+	bus.WaitForCompletion()
+}
+
+func TestDispatchContextReturnsWhenCanceled(t *testing.T) {
+	bus := NewQueryBus(nil)
+	handler := &slowQueryHandler{canceled: make(chan error, 1)}
+	if err := bus.Register(handler); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := bus.DispatchContext(ctx, slowQuery{}); !errors.Is(err, context.Canceled) || errors.Is(err, ErrQueryTimeout) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if err := <-handler.canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the handler to be canceled, got %v", err)
+	}
+}
+
+func TestDispatchAppliesTimeoutByQueryName(t *testing.T) {
+	bus := NewQueryBus(nil)
+	if err := bus.Register(&quickQueryHandler{}); err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	result, err := bus.Dispatch(quickQuery{})
+	if err != nil || result != false {
+		t.Errorf("Expected no deadline without a timeout, got %v, %v", result, err)
+	}
+
+	bus.SetTimeout(func(name string) time.Duration {
+		if name == "Quick" {
+			return time.Minute
+		}
+		return 0
+	})
+	result, err = bus.Dispatch(quickQuery{})
+	if err != nil || result != true {
+		t.Errorf("Expected a deadline from the query timeout, got %v, %v", result, err)
+	}
+}
+
+type contextCommand struct{}
+
+func (contextCommand) Name() string { return "Context" }
+
+type contextCommandHandler struct{}
+
+func (h *contextCommandHandler) Handle(context.Context, contextCommand) error { return nil }
+
+func TestCommandBusRejectsContextHandlers(t *testing.T) {
+	if err := NewCommandBus(nil).Register(&contextCommandHandler{}); err == nil {
+		t.Error("Expected command handlers taking a context to be rejected")
+	}
+}
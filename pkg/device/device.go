@@ -0,0 +1,68 @@
+package device
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// deviceIDSalt scopes the device ID to the agent so that the identifiers it is derived from are never disclosed.
+const deviceIDSalt = "winterflow-agent"
+
+// source lists alternative files holding one identifier of the host, in order of preference.
+type source []string
+
+// sources are the identifiers of the host the device ID is derived from: the machine ID written when the
+// operating system was installed, and the SMBIOS UUID of the (virtual) machine. A disk image cloned to
+// another host keeps the machine ID unless it is regenerated (see machine-id(5)), but runs with another
+// SMBIOS UUID.
+var sources = []source{
+	{"/etc/machine-id", "/var/lib/dbus/machine-id"},
+	{"/sys/class/dmi/id/product_uuid"},
+}
+
+// GetDeviceID returns an identifier of the host the agent runs on, derived from the identifiers of the host
+// that are available. It fails when none is.
+func GetDeviceID() (string, error) {
+	return deviceIDFromSources(sources)
+}
+
+func deviceIDFromSources(sources []source) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(deviceIDSalt))
+	found := false
+	for _, src := range sources {
+		value, err := readFirst(src)
+		if err != nil {
+			return "", err
+		}
+		// Every source contributes a line so that a value cannot be mistaken for the value of another source.
+		h.Write([]byte("\n" + value))
+		found = found || value != ""
+	}
+	if !found {
+		return "", errors.New("no host identifier found")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readFirst returns the trimmed content of the first non-empty file of src, or "" when there is none.
+// Files that are missing or not readable by the agent are skipped.
+func readFirst(src source) (string, error) {
+	for _, path := range src {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+				continue
+			}
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if value := strings.ToLower(strings.TrimSpace(string(data))); value != "" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
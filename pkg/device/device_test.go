@@ -0,0 +1,73 @@
+package device
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSources writes the given identifier files and returns the sources reading them, in order.
+func writeSources(t *testing.T, values ...string) []source {
+	t.Helper()
+	dir := t.TempDir()
+	var result []source
+	for i, value := range values {
+		path := filepath.Join(dir, string(rune('a'+i)))
+		if value != "" {
+			if err := os.WriteFile(path, []byte(value), 0o600); err != nil {
+				t.Fatalf("Failed to write identifier: %v", err)
+			}
+		}
+		result = append(result, source{path})
+	}
+	return result
+}
+
+func deviceID(t *testing.T, values ...string) string {
+	t.Helper()
+	id, err := deviceIDFromSources(writeSources(t, values...))
+	if err != nil {
+		t.Fatalf("deviceIDFromSources returned error: %v", err)
+	}
+	return id
+}
+
+func TestDeviceIDIsStable(t *testing.T) {
+	first := deviceID(t, "4c4c4544\n", "0A1B2C3D-0000")
+	if second := deviceID(t, "4c4c4544", "0a1b2c3d-0000\n"); first != second {
+		t.Errorf("Expected the same device ID for the same identifiers, got %q and %q", first, second)
+	}
+	if len(first) != 64 {
+		t.Errorf("Expected a hex encoded SHA-256, got %q", first)
+	}
+}
+
+func TestDeviceIDOfClonedDisk(t *testing.T) {
+	// A cloned disk keeps the machine ID but runs on a machine with another SMBIOS UUID.
+	if deviceID(t, "4c4c4544", "uuid-a") == deviceID(t, "4c4c4544", "uuid-b") {
+		t.Error("Expected another device ID on another machine")
+	}
+}
+
+func TestDeviceIDDoesNotMixUpSources(t *testing.T) {
+	if deviceID(t, "abc", "") == deviceID(t, "", "abc") {
+		t.Error("Expected the device ID to depend on the source of each identifier")
+	}
+}
+
+func TestDeviceIDFallsBackToAlternativeFiles(t *testing.T) {
+	src := writeSources(t, "", "4c4c4544")
+	id, err := deviceIDFromSources([]source{{src[0][0], src[1][0]}})
+	if err != nil {
+		t.Fatalf("deviceIDFromSources returned error: %v", err)
+	}
+	if expected := deviceID(t, "4c4c4544"); id != expected {
+		t.Errorf("Expected the alternative file to be used, got %q instead of %q", id, expected)
+	}
+}
+
+func TestDeviceIDWithoutIdentifiers(t *testing.T) {
+	if _, err := deviceIDFromSources(writeSources(t, "", "")); err == nil {
+		t.Error("Expected an error when no identifier is available")
+	}
+}
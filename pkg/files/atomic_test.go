@@ -0,0 +1,60 @@
+package files
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomicReplacesTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.config.json")
+	if err := os.WriteFile(path, []byte(`{"old":true}`), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte(`{"new":true}`), 0o600); err != nil {
+		t.Fatalf("WriteFileAtomic returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"new":true}` {
+		t.Fatalf("Expected the new content, got %q (%v)", data, err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Expected no temporary file to be left, got %d entries", len(entries))
+	}
+}
+
+func TestWriteFileAtomicKeepsTheOriginalWhenInterrupted(t *testing.T) {
+	// The crash happens after the temporary file is written, before it replaces the original.
+	rename = func(oldPath, newPath string) error { return errors.New("interrupted") }
+	t.Cleanup(func() { rename = os.Rename })
+
+	path := filepath.Join(t.TempDir(), "agent.config.json")
+	if err := os.WriteFile(path, []byte(`{"old":true}`), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte(`{"new":`), 0o600); err == nil {
+		t.Fatal("Expected the interrupted write to fail")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != `{"old":true}` {
+		t.Errorf("Expected the original content to be intact, got %q (%v)", data, err)
+	}
+}
+
+func TestWriteFileAtomicCreatesTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.json")
+	if err := WriteFileAtomic(path, []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic returned error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != `{}` {
+		t.Errorf("Expected the file to be created, got %q (%v)", data, err)
+	}
+}
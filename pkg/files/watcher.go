@@ -47,15 +47,16 @@ func (w *FileWatcher) Start(ctx context.Context) error {
 // Stop stops watching the file
 func (w *FileWatcher) Stop() {
 	w.mu.Lock()
-	defer w.mu.Unlock()
-
 	select {
 	case <-w.stopCh:
 		// Already stopped
+		w.mu.Unlock()
 		return
 	default:
 		close(w.stopCh)
 	}
+	// The watch loop takes the lock to check the file, so it is released before waiting for the loop.
+	w.mu.Unlock()
 
 	w.wg.Wait()
 	log.Info("File watcher stopped")
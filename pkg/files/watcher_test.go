@@ -0,0 +1,34 @@
+package files
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileWatcherStopsWhileChecking(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watched")
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	w := NewFileWatcher(path, func(string) {})
+	w.SetInterval(time.Millisecond)
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		w.Stop()
+		w.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Stop to return while the file is checked")
+	}
+}
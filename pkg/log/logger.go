@@ -2,15 +2,27 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
 	"sync"
 )
 
+// Supported log output formats.
+const (
+	// FormatJSON emits one JSON object per line with time, level, msg and the structured fields.
+	FormatJSON = "json"
+	// FormatText emits slog's key=value text format, which is easier to read in a terminal.
+	FormatText = "text"
+)
+
 var (
 	logger *slog.Logger
 	mu     sync.RWMutex
+
+	// output is the destination of all log records.
+	output io.Writer = os.Stdout
 )
 
 // ParseLogLevel converts a string log level to a slog.Level.
@@ -32,24 +44,43 @@ func ParseLogLevel(level string) slog.Level {
 	}
 }
 
-// InitLog initializes or reinitializes the logger with the specified log level.
+// SetOutput redirects log records to w, e.g. a RotatingFile, and takes effect on the next
+// InitLog call. A previously set RotatingFile is closed.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if previous, ok := output.(*RotatingFile); ok && previous != w {
+		previous.Close()
+	}
+	output = w
+}
+
+// newHandler creates the slog handler for the given format. Unknown formats fall back to JSON.
+func newHandler(w io.Writer, format string, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.ToLower(format) == FormatText {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+// InitLog initializes or reinitializes the logger with the specified log level and
+// output format (FormatJSON or FormatText, JSON when empty or unknown).
 // This can be called multiple times to change the log level at runtime.
 // It will override any previously configured logger instance.
-func InitLog(logLevel string) {
+func InitLog(logLevel, logFormat string) {
 	level := ParseLogLevel(logLevel)
 
 	mu.Lock()
 	defer mu.Unlock()
 
 	// Always create a new logger instance (override existing)
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
-	})
-	logger = slog.New(handler)
+	logger = slog.New(newHandler(output, logFormat, level))
 }
 
 // GetLog returns the slog.Logger instance configured for the application.
-// The logger emits JSON-formatted logs at the configured level to stdout.
+// Unless configured otherwise, the logger emits JSON-formatted logs at the configured level to stdout.
 // This format is easy to parse both by humans and log aggregation tools
 // while still being structured.
 // If the logger hasn't been initialized yet, it defaults to info level.
@@ -67,10 +98,7 @@ func GetLog() *slog.Logger {
 
 	// Double-check after acquiring write lock
 	if logger == nil {
-		handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		})
-		logger = slog.New(handler)
+		logger = slog.New(newHandler(output, FormatJSON, slog.LevelInfo))
 	}
 
 	return logger
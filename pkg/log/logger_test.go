@@ -0,0 +1,84 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// captureOutput redirects the logger to a buffer configured with format for the duration of the test.
+func captureOutput(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+
+	mu.Lock()
+	previousOutput, previousLogger := output, logger
+	output = &buf
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		output, logger = previousOutput, previousLogger
+		mu.Unlock()
+	})
+
+	InitLog("debug", format)
+	return &buf
+}
+
+func TestJSONFormatEmitsOneObjectPerLine(t *testing.T) {
+	buf := captureOutput(t, FormatJSON)
+
+	Info("Deployment started", "app_id", "app-1", "revision", 3)
+	Warn("Odd number of fields", "dangling")
+	Error("Operation failed", "error", errors.New("boom"), "details", map[string]int{"attempts": 2})
+	_ = Errorf("failed to register handler: %v", errors.New("duplicate"))
+	Printf("Loaded %d certificates\nfrom disk", 2)
+
+	scanner := bufio.NewScanner(buf)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v\n%s", lines, err, scanner.Text())
+		}
+		for _, key := range []string{"time", "level", "msg"} {
+			if _, ok := record[key]; !ok {
+				t.Errorf("Line %d lacks %q: %s", lines, key, scanner.Text())
+			}
+		}
+		if lines == 1 && (record["app_id"] != "app-1" || record["revision"] != float64(3)) {
+			t.Errorf("Expected structured fields, got %v", record)
+		}
+		if lines == 3 && record["error"] != "boom" {
+			t.Errorf("Expected error message as field, got %v", record["error"])
+		}
+	}
+	if lines != 5 {
+		t.Errorf("Expected 5 log lines, got %d", lines)
+	}
+}
+
+func TestTextFormat(t *testing.T) {
+	buf := captureOutput(t, FormatText)
+
+	Info("Deployment started", "app_id", "app-1")
+
+	line := strings.TrimSpace(buf.String())
+	if strings.HasPrefix(line, "{") || !strings.Contains(line, `msg="Deployment started"`) || !strings.Contains(line, "app_id=app-1") {
+		t.Errorf("Expected key=value text output, got %q", line)
+	}
+}
+
+func TestUnknownFormatFallsBackToJSON(t *testing.T) {
+	buf := captureOutput(t, "xml")
+
+	Info("Deployment started")
+
+	if !json.Valid(bytes.TrimSpace(buf.Bytes())) {
+		t.Errorf("Expected JSON output, got %q", buf.String())
+	}
+}
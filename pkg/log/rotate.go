@@ -0,0 +1,141 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotationTimeFormat names rotated backups so that lexical order is chronological.
+const rotationTimeFormat = "20060102T150405.000"
+
+// RotateOptions controls when a RotatingFile is rotated and which backups are kept.
+// Zero values disable the respective limit.
+type RotateOptions struct {
+	// MaxSizeBytes rotates the active file before a write would make it exceed this size.
+	MaxSizeBytes int64
+	// MaxBackups is the number of rotated files kept.
+	MaxBackups int
+	// MaxAge removes rotated files older than this duration.
+	MaxAge time.Duration
+}
+
+// RotatingFile is an io.Writer appending to a file that is rotated by size. Rotated files are
+// renamed to "<path>.<timestamp>" and pruned by count and age. It is safe for concurrent use.
+type RotatingFile struct {
+	mu   sync.Mutex
+	path string
+	opts RotateOptions
+	file *os.File
+	size int64
+	now  func() time.Time
+}
+
+// OpenRotatingFile opens path for appending, creating it and its directory when missing.
+func OpenRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, opts: opts, now: time.Now}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write implements io.Writer.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.opts.MaxSizeBytes > 0 && f.size > 0 && f.size+int64(len(p)) > f.opts.MaxSizeBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the active file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the active file to a timestamped backup, reopens the path and prunes old backups.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+
+	backup := f.path + "." + f.now().UTC().Format(rotationTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// prune removes backups beyond MaxBackups (oldest first) and backups older than MaxAge.
+func (f *RotatingFile) prune() error {
+	backups, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return fmt.Errorf("failed to list log backups: %w", err)
+	}
+	sort.Strings(backups)
+
+	var remove []string
+	if f.opts.MaxBackups > 0 && len(backups) > f.opts.MaxBackups {
+		remove = append(remove, backups[:len(backups)-f.opts.MaxBackups]...)
+		backups = backups[len(backups)-f.opts.MaxBackups:]
+	}
+	if f.opts.MaxAge > 0 {
+		cutoff := f.now().Add(-f.opts.MaxAge)
+		for _, backup := range backups {
+			if info, err := os.Stat(backup); err == nil && info.ModTime().Before(cutoff) {
+				remove = append(remove, backup)
+			}
+		}
+	}
+
+	for _, backup := range remove {
+		if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove log backup: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRotatingFile opens a rotating file in a temporary directory whose clock advances by a second per rotation.
+func newTestRotatingFile(t *testing.T, opts RotateOptions) (*RotatingFile, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "logs", "agent.log")
+	f, err := OpenRotatingFile(path, opts)
+	if err != nil {
+		t.Fatalf("OpenRotatingFile returned error: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return f, path
+}
+
+func writeLine(t *testing.T, f *RotatingFile, line string) {
+	t.Helper()
+	if _, err := f.Write([]byte(line + "\n")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+}
+
+func backups(t *testing.T, path string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+	return matches
+}
+
+func TestRotatingFileRotatesAtSizeThreshold(t *testing.T) {
+	f, path := newTestRotatingFile(t, RotateOptions{MaxSizeBytes: 10})
+
+	writeLine(t, f, "12345678") // 9 bytes, below the threshold
+	if got := backups(t, path); len(got) != 0 {
+		t.Fatalf("Expected no rotation below the threshold, got %v", got)
+	}
+
+	writeLine(t, f, "abc") // would exceed 10 bytes
+	got := backups(t, path)
+	if len(got) != 1 {
+		t.Fatalf("Expected a single backup after crossing the threshold, got %v", got)
+	}
+
+	rotated, _ := os.ReadFile(got[0])
+	active, _ := os.ReadFile(path)
+	if string(rotated) != "12345678\n" || string(active) != "abc\n" {
+		t.Errorf("Unexpected contents: rotated %q, active %q", rotated, active)
+	}
+}
+
+func TestRotatingFilePrunesBackups(t *testing.T) {
+	f, path := newTestRotatingFile(t, RotateOptions{MaxSizeBytes: 4, MaxBackups: 2})
+
+	for _, line := range []string{"one", "two", "six", "ten", "end"} {
+		writeLine(t, f, line)
+	}
+
+	got := backups(t, path)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 backups to be kept, got %v", got)
+	}
+	newest, _ := os.ReadFile(got[1])
+	if string(newest) != "ten\n" {
+		t.Errorf("Expected the newest backups to be kept, newest holds %q", newest)
+	}
+}
+
+func TestRotatingFilePrunesOldBackups(t *testing.T) {
+	f, path := newTestRotatingFile(t, RotateOptions{MaxSizeBytes: 4, MaxAge: time.Hour})
+
+	writeLine(t, f, "old")
+	writeLine(t, f, "new")
+	stale := backups(t, path)[0]
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(stale, past, past); err != nil {
+		t.Fatalf("Failed to age backup: %v", err)
+	}
+	f.now = time.Now
+
+	writeLine(t, f, "end")
+	for _, backup := range backups(t, path) {
+		if backup == stale {
+			t.Errorf("Expected backup older than the max age to be removed")
+		}
+	}
+}
+
+func TestRotatingFileAppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+	if err := os.WriteFile(path, []byte("previous run\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write existing log: %v", err)
+	}
+
+	f, err := OpenRotatingFile(path, RotateOptions{MaxSizeBytes: 16})
+	if err != nil {
+		t.Fatalf("OpenRotatingFile returned error: %v", err)
+	}
+	defer f.Close()
+
+	writeLine(t, f, "next run")
+	if got := backups(t, path); len(got) != 1 || !strings.HasPrefix(filepath.Base(got[0]), "agent.log.") {
+		t.Errorf("Expected existing size to count towards the threshold, got %v", got)
+	}
+}
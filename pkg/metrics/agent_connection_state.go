@@ -0,0 +1,24 @@
+package metrics
+
+// AgentConnectionStateMetric reports the current connectivity state of the
+// connection to the server, e.g. READY or TRANSIENT_FAILURE. Sampled on every
+// heartbeat it shows whether the agent flaps between connected and disconnected.
+type AgentConnectionStateMetric struct {
+	state func() string
+}
+
+// NewAgentConnectionStateMetric returns a new AgentConnectionStateMetric reading
+// the name of the current state from state.
+func NewAgentConnectionStateMetric(state func() string) *AgentConnectionStateMetric {
+	return &AgentConnectionStateMetric{state: state}
+}
+
+// Name implements Metric interface.
+func (m *AgentConnectionStateMetric) Name() string {
+	return "agent_connection_state"
+}
+
+// Value implements Metric interface.
+func (m *AgentConnectionStateMetric) Value() string {
+	return m.state()
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import "strconv"
+
+// AgentDeployQueueDepthMetric reports how many deployment operations are waiting
+// for a free slot because the maximum number of concurrent deploys is reached.
+type AgentDeployQueueDepthMetric struct {
+	depth func() int
+}
+
+// NewAgentDeployQueueDepthMetric returns a new AgentDeployQueueDepthMetric reading
+// the current queue depth from depth.
+func NewAgentDeployQueueDepthMetric(depth func() int) *AgentDeployQueueDepthMetric {
+	return &AgentDeployQueueDepthMetric{depth: depth}
+}
+
+// Name implements Metric interface.
+func (m *AgentDeployQueueDepthMetric) Name() string {
+	return "agent_deploy_queue_depth"
+}
+
+// Value implements Metric interface.
+func (m *AgentDeployQueueDepthMetric) Value() string {
+	return strconv.Itoa(m.depth())
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// AgentHeartbeatRTTMetric reports the average round-trip time of recent heartbeats
+// in milliseconds. A rising value points at a degraded link to the server.
+type AgentHeartbeatRTTMetric struct {
+	rtt func() time.Duration
+}
+
+// NewAgentHeartbeatRTTMetric returns a new AgentHeartbeatRTTMetric reading the
+// current average round-trip time from rtt.
+func NewAgentHeartbeatRTTMetric(rtt func() time.Duration) *AgentHeartbeatRTTMetric {
+	return &AgentHeartbeatRTTMetric{rtt: rtt}
+}
+
+// Name implements Metric interface.
+func (m *AgentHeartbeatRTTMetric) Name() string {
+	return "agent_heartbeat_rtt_ms"
+}
+
+// Value implements Metric interface.
+func (m *AgentHeartbeatRTTMetric) Value() string {
+	return strconv.FormatInt(m.rtt().Milliseconds(), 10)
+}
@@ -65,6 +65,12 @@ func NewSystemInfoFactory(startTime time.Time) *MetricFactory {
 	}
 }
 
+// Add registers an additional metric. It is meant for metrics that depend on
+// components created after the factory, such as the server connection.
+func (f *MetricFactory) Add(m Metric) {
+	f.metrics = append(f.metrics, m)
+}
+
 // Collect walks through all registered metrics and returns their current
 // values.  The function is intentionally lightweight so that it can be called
 // on every heartbeat tick without noticeable overhead.
@@ -5,64 +5,120 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// SystemCpuUsageMetric reports the percentage of CPU utilized since the last reading.
+// cpuSample is a reading of the aggregated "cpu" line of /proc/stat.
+type cpuSample struct {
+	at    time.Time
+	idle  uint64
+	total uint64
+}
+
+// SystemCpuUsageMetric reports the percentage of CPU utilized over a sliding window of /proc/stat readings.
 // Only supported on Linux; returns empty string on other platforms.
+//
+// The counters are read once when the metric is created so that the first reading after start covers the
+// time since then instead of being empty or skewed by a tiny delta.
 type SystemCpuUsageMetric struct {
-	prevIdle    uint64
-	prevTotal   uint64
-	initialized bool
+	window   time.Duration
+	statPath string
+	now      func() time.Time
+	samples  []cpuSample
 }
 
-// NewSystemCpuUsageMetric returns a new SystemCpuUsageMetric.
-func NewSystemCpuUsageMetric() *SystemCpuUsageMetric { return &SystemCpuUsageMetric{} }
+// NewSystemCpuUsageMetric returns a new SystemCpuUsageMetric averaging CPU usage over window.
+func NewSystemCpuUsageMetric(window time.Duration) *SystemCpuUsageMetric {
+	m := &SystemCpuUsageMetric{window: window, statPath: "/proc/stat", now: time.Now}
+	m.prime()
+	return m
+}
 
 // Name implements Metric.
 func (m *SystemCpuUsageMetric) Name() string { return "system_cpu_usage_percent" }
 
-// Value implements Metric: reads /proc/stat, parses total and idle jiffies,
-// computes the delta since last call, and returns the percentage of active time.
+// Value implements Metric: reads /proc/stat and returns the percentage of active time between this
+// reading and the newest earlier reading that is at least the window old (or the oldest one kept).
 func (m *SystemCpuUsageMetric) Value() string {
 	if runtime.GOOS != "linux" {
 		return ""
 	}
-	data, err := os.ReadFile("/proc/stat")
-	if err != nil {
+	current, ok := m.read()
+	if !ok {
 		return ""
 	}
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "cpu ") {
-			fields := strings.Fields(line)
-			var total uint64
-			for _, f := range fields[1:] {
-				v, err := strconv.ParseUint(f, 10, 64)
-				if err != nil {
-					continue
-				}
-				total += v
-			}
-			idle, err := strconv.ParseUint(fields[4], 10, 64)
+	m.samples = append(m.samples, current)
+
+	// Drop readings that a younger one can replace as the start of the window.
+	since := current.at.Add(-m.window)
+	for len(m.samples) > 2 && !m.samples[1].at.After(since) {
+		m.samples = m.samples[1:]
+	}
+	base := m.samples[0]
+	if len(m.samples) == 1 {
+		return ""
+	}
+	if current.idle < base.idle || current.total < base.total {
+		// The counters went backwards (e.g. a CPU was taken offline); start over.
+		m.samples = []cpuSample{current}
+		return ""
+	}
+	totalDelta := current.total - base.total
+	if totalDelta == 0 {
+		return "0"
+	}
+	idleDelta := current.idle - base.idle
+	if idleDelta > totalDelta {
+		idleDelta = totalDelta
+	}
+	usage := 100 * (float64(totalDelta-idleDelta) / float64(totalDelta))
+	return strconv.FormatFloat(usage, 'f', 2, 64)
+}
+
+// prime records the baseline reading the first value is computed against.
+func (m *SystemCpuUsageMetric) prime() {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	if sample, ok := m.read(); ok {
+		m.samples = []cpuSample{sample}
+	}
+}
+
+func (m *SystemCpuUsageMetric) read() (cpuSample, bool) {
+	data, err := os.ReadFile(m.statPath)
+	if err != nil {
+		return cpuSample{}, false
+	}
+	idle, total, ok := parseProcStat(string(data))
+	if !ok {
+		return cpuSample{}, false
+	}
+	return cpuSample{at: m.now(), idle: idle, total: total}, true
+}
+
+// parseProcStat returns the idle and total jiffies of the aggregated "cpu" line of /proc/stat.
+func parseProcStat(data string) (idle, total uint64, ok bool) {
+	for _, line := range strings.Split(data, "\n") {
+		if !strings.HasPrefix(line, "cpu ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			return 0, 0, false
+		}
+		for _, f := range fields[1:] {
+			v, err := strconv.ParseUint(f, 10, 64)
 			if err != nil {
-				return ""
+				continue
 			}
-			if !m.initialized {
-				m.prevIdle = idle
-				m.prevTotal = total
-				m.initialized = true
-				return ""
-			}
-			idleDelta := idle - m.prevIdle
-			totalDelta := total - m.prevTotal
-			m.prevIdle = idle
-			m.prevTotal = total
-			if totalDelta == 0 {
-				return "0"
-			}
-			usage := 100 * (float64(totalDelta-idleDelta) / float64(totalDelta))
-			return strconv.FormatFloat(usage, 'f', 2, 64)
+			total += v
+		}
+		idle, err := strconv.ParseUint(fields[4], 10, 64)
+		if err != nil {
+			return 0, 0, false
 		}
+		return idle, total, true
 	}
-	return ""
+	return 0, 0, false
 }
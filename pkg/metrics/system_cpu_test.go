@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+const (
+	procStatStart = `cpu  1000 0 500 8000 100 0 0 0 0 0
+cpu0 500 0 250 4000 50 0 0 0 0 0
+cpu1 500 0 250 4000 50 0 0 0 0 0
+intr 12345
+ctxt 6789
+btime 1700000000
+`
+	// 1400 jiffies later, 600 of them idle.
+	procStatLater = `cpu  1600 0 700 8600 100 0 0 0 0 0
+cpu0 800 0 350 4300 50 0 0 0 0 0
+cpu1 800 0 350 4300 50 0 0 0 0 0
+intr 23456
+ctxt 7890
+btime 1700000000
+`
+	// 1000 jiffies after procStatLater, 900 of them idle.
+	procStatIdle = `cpu  1650 0 750 9500 100 0 0 0 0 0
+intr 34567
+`
+)
+
+type cpuFixture struct {
+	t      *testing.T
+	path   string
+	clock  time.Time
+	metric *SystemCpuUsageMetric
+}
+
+func newCPUFixture(t *testing.T, window time.Duration, initial string) *cpuFixture {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("CPU usage is only reported on Linux")
+	}
+	f := &cpuFixture{t: t, path: filepath.Join(t.TempDir(), "stat"), clock: time.Unix(1700000000, 0)}
+	f.write(initial)
+	f.metric = &SystemCpuUsageMetric{window: window, statPath: f.path, now: func() time.Time { return f.clock }}
+	f.metric.prime()
+	return f
+}
+
+func (f *cpuFixture) write(content string) {
+	f.t.Helper()
+	if err := os.WriteFile(f.path, []byte(content), 0o600); err != nil {
+		f.t.Fatalf("Failed to write stat fixture: %v", err)
+	}
+}
+
+func (f *cpuFixture) valueAfter(elapsed time.Duration, content string) string {
+	f.write(content)
+	f.clock = f.clock.Add(elapsed)
+	return f.metric.Value()
+}
+
+func TestParseProcStat(t *testing.T) {
+	idle, total, ok := parseProcStat(procStatStart)
+	if !ok || idle != 8000 || total != 9600 {
+		t.Errorf("Expected idle 8000 and total 9600, got %d, %d, %v", idle, total, ok)
+	}
+	for _, data := range []string{"", "cpu0 1 2 3 4\n", "cpu  1 2\n", "cpu  1 2 3 x\n"} {
+		if _, _, ok := parseProcStat(data); ok {
+			t.Errorf("Expected %q to be rejected", data)
+		}
+	}
+}
+
+func TestSystemCpuUsageFromTwoSnapshots(t *testing.T) {
+	f := newCPUFixture(t, time.Minute, procStatStart)
+
+	// The first reading after start is computed against the snapshot taken at creation.
+	if got := f.valueAfter(time.Minute, procStatLater); got != "57.14" {
+		t.Errorf("Expected 57.14, got %q", got)
+	}
+}
+
+func TestSystemCpuUsageFirstReadingWithoutElapsedTicks(t *testing.T) {
+	f := newCPUFixture(t, time.Minute, procStatStart)
+
+	if got := f.valueAfter(time.Millisecond, procStatStart); got != "0" {
+		t.Errorf("Expected 0 when no time was accounted yet, got %q", got)
+	}
+	if got := f.valueAfter(time.Minute, procStatLater); got != "57.14" {
+		t.Errorf("Expected 57.14, got %q", got)
+	}
+}
+
+func TestSystemCpuUsageWindow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		window   time.Duration
+		expected string
+	}{
+		// Only the last interval falls into the window: 100 of 1000 jiffies busy.
+		{name: "window of one interval", window: time.Minute, expected: "10.00"},
+		// Both intervals fall into the window: 900 of 2400 jiffies busy.
+		{name: "window of two intervals", window: 2 * time.Minute, expected: "37.50"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newCPUFixture(t, tc.window, procStatStart)
+			f.valueAfter(time.Minute, procStatLater)
+			if got := f.valueAfter(time.Minute, procStatIdle); got != tc.expected {
+				t.Errorf("Expected %s, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSystemCpuUsageCountersGoingBackwards(t *testing.T) {
+	f := newCPUFixture(t, time.Minute, procStatLater)
+
+	if got := f.valueAfter(time.Minute, procStatStart); got != "" {
+		t.Errorf("Expected no value after the counters went backwards, got %q", got)
+	}
+	if got := f.valueAfter(time.Minute, procStatLater); got != "57.14" {
+		t.Errorf("Expected 57.14 against the new baseline, got %q", got)
+	}
+}
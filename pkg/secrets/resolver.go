@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReferencePrefix marks a variable value that names a secret instead of holding the value itself,
+// e.g. "secret://db/password".
+const ReferencePrefix = "secret://"
+
+// ErrNotFound is returned when a referenced secret does not exist.
+var ErrNotFound = errors.New("secret not found")
+
+// Resolver looks up secret values by path. Implementations may be backed by local files, a vault
+// agent or any other host secrets manager.
+type Resolver interface {
+	// Resolve returns the value of the secret at path, or an error wrapping ErrNotFound when it does not exist.
+	Resolve(path string) (string, error)
+}
+
+// ParseReference returns the secret path of value and true when value is a secret:// reference.
+func ParseReference(value string) (string, bool) {
+	if !strings.HasPrefix(value, ReferencePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, ReferencePrefix), true
+}
+
+// FileResolver resolves secrets from files below a directory: the secret "db/password" is the content
+// of <dir>/db/password without a single trailing newline.
+type FileResolver struct {
+	dir string
+}
+
+// NewFileResolver creates a Resolver reading secrets from files below dir.
+func NewFileResolver(dir string) *FileResolver {
+	return &FileResolver{dir: dir}
+}
+
+// Resolve reads the secret at path. Paths escaping the secrets directory are rejected.
+func (r *FileResolver) Resolve(path string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(path))
+	if path == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid secret path %q", path)
+	}
+
+	content, err := os.ReadFile(filepath.Join(r.dir, cleaned))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return "", fmt.Errorf("failed to read secret %s: %w", path, err)
+	}
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
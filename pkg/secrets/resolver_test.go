@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	if path, ok := ParseReference("secret://db/password"); !ok || path != "db/password" {
+		t.Errorf("Expected reference db/password, got %q (%v)", path, ok)
+	}
+	if _, ok := ParseReference("plain value"); ok {
+		t.Errorf("Expected a plain value not to be a reference")
+	}
+}
+
+func TestFileResolverResolve(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0o700); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+
+	value, err := NewFileResolver(dir).Resolve("db/password")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if value != "hunter2" {
+		t.Errorf("Expected hunter2, got %q", value)
+	}
+}
+
+func TestFileResolverMissingSecret(t *testing.T) {
+	_, err := NewFileResolver(t.TempDir()).Resolve("db/password")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFileResolverRejectsEscapingPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"", "../outside", "db/../../outside", "/etc/passwd"} {
+		if _, err := NewFileResolver(dir).Resolve(path); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected %q to be rejected, got %v", path, err)
+		}
+	}
+}
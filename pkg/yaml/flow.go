@@ -0,0 +1,225 @@
+package yaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// flowParser decodes flow collections such as [a, b] and {a: 1, b: [x]}.
+type flowParser struct {
+	s       string
+	pos     int
+	anchors map[string]interface{}
+}
+
+func parseFlow(text string, anchors map[string]interface{}) (interface{}, error) {
+	fp := &flowParser{s: text, anchors: anchors}
+	value, err := fp.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	fp.skipSpaces()
+	if fp.pos != len(fp.s) {
+		return nil, fmt.Errorf("unexpected content after flow collection: %q", fp.s[fp.pos:])
+	}
+	return value, nil
+}
+
+func (fp *flowParser) skipSpaces() {
+	for fp.pos < len(fp.s) && (fp.s[fp.pos] == ' ' || fp.s[fp.pos] == '\t') {
+		fp.pos++
+	}
+}
+
+func (fp *flowParser) peek() byte {
+	if fp.pos >= len(fp.s) {
+		return 0
+	}
+	return fp.s[fp.pos]
+}
+
+func (fp *flowParser) parseValue() (interface{}, error) {
+	fp.skipSpaces()
+	switch fp.peek() {
+	case '[':
+		return fp.parseSequence()
+	case '{':
+		return fp.parseMapping()
+	case '"', '\'':
+		return fp.parseQuoted()
+	case '*':
+		fp.pos++
+		name := fp.readPlain()
+		v, ok := fp.anchors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown alias %q", name)
+		}
+		return v, nil
+	case '&':
+		fp.pos++
+		name := fp.readProperty()
+		if name == "" {
+			return nil, fmt.Errorf("empty anchor name")
+		}
+		value, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		fp.anchors[name] = value
+		return value, nil
+	case '!':
+		tag := fp.readProperty()
+		fp.skipSpaces()
+		if c := fp.peek(); tag == "!!str" && !strings.ContainsRune("[{\"'*&", rune(c)) {
+			return fp.readPlain(), nil
+		}
+		return fp.parseValue()
+	case 0:
+		return nil, fmt.Errorf("unexpected end of flow collection")
+	}
+	return resolveScalar(fp.readPlain()), nil
+}
+
+// readProperty reads an anchor name or tag up to the next space or flow indicator.
+func (fp *flowParser) readProperty() string {
+	start := fp.pos
+	for fp.pos < len(fp.s) && !strings.ContainsRune(" \t,[]{}", rune(fp.s[fp.pos])) {
+		fp.pos++
+	}
+	return fp.s[start:fp.pos]
+}
+
+// readPlain reads a plain scalar up to the next flow indicator.
+func (fp *flowParser) readPlain() string {
+	start := fp.pos
+	for fp.pos < len(fp.s) {
+		c := fp.s[fp.pos]
+		if c == ',' || c == ']' || c == '}' {
+			break
+		}
+		if c == ':' && (fp.pos+1 == len(fp.s) || strings.ContainsRune(" ,]}", rune(fp.s[fp.pos+1]))) {
+			break
+		}
+		fp.pos++
+	}
+	return strings.TrimSpace(fp.s[start:fp.pos])
+}
+
+func (fp *flowParser) parseQuoted() (interface{}, error) {
+	rest := fp.s[fp.pos:]
+	end := closingQuote(rest, rest[0])
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated quoted scalar in flow collection")
+	}
+	fp.pos += end + 1
+	return unquote(rest[:end+1])
+}
+
+func (fp *flowParser) parseSequence() (interface{}, error) {
+	fp.pos++ // [
+	seq := make([]interface{}, 0)
+	for {
+		fp.skipSpaces()
+		if fp.peek() == ']' {
+			fp.pos++
+			return seq, nil
+		}
+		item, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		fp.skipSpaces()
+		if fp.peek() == ':' {
+			// A single-pair mapping such as [a: 1].
+			if item, err = fp.parsePairValue(item); err != nil {
+				return nil, err
+			}
+		}
+		seq = append(seq, item)
+		fp.skipSpaces()
+		switch fp.peek() {
+		case ',':
+			fp.pos++
+		case ']':
+			fp.pos++
+			return seq, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or ']' in flow sequence")
+		}
+	}
+}
+
+// parsePairValue parses the value following the key of a single-pair mapping
+// inside a flow sequence.
+func (fp *flowParser) parsePairValue(rawKey interface{}) (interface{}, error) {
+	fp.pos++ // :
+	fp.skipSpaces()
+	var value interface{}
+	if c := fp.peek(); c != ',' && c != ']' {
+		var err error
+		if value, err = fp.parseValue(); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]interface{}{flowKey(rawKey): value}, nil
+}
+
+// flowKey converts a decoded flow mapping key to its string form.
+func flowKey(rawKey interface{}) string {
+	if rawKey == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", rawKey)
+}
+
+func (fp *flowParser) parseMapping() (interface{}, error) {
+	fp.pos++ // {
+	m := make(map[string]interface{})
+	explicit := make(map[string]bool)
+	for {
+		fp.skipSpaces()
+		if fp.peek() == '}' {
+			fp.pos++
+			return m, nil
+		}
+		rawKey, err := fp.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		key := flowKey(rawKey)
+		if explicit[key] {
+			return nil, fmt.Errorf("duplicate key %q in flow mapping", key)
+		}
+
+		fp.skipSpaces()
+		var value interface{}
+		if fp.peek() == ':' {
+			fp.pos++
+			fp.skipSpaces()
+			if c := fp.peek(); c != ',' && c != '}' {
+				if value, err = fp.parseValue(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if key == "<<" {
+			if err := mergeInto(m, explicit, value); err != nil {
+				return nil, err
+			}
+		} else {
+			explicit[key] = true
+			m[key] = value
+		}
+
+		fp.skipSpaces()
+		switch fp.peek() {
+		case ',':
+			fp.pos++
+		case '}':
+			fp.pos++
+			return m, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' in flow mapping")
+		}
+	}
+}
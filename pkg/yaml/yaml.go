@@ -0,0 +1,760 @@
+// Package yaml implements a small, dependency-free YAML reader covering the
+// subset of YAML used by Docker Compose files and application templates:
+//
+//   - block mappings and sequences, indented with spaces
+//   - flow sequences and mappings, also spanning several lines, including
+//     single-pair mappings such as [a: 1]
+//   - plain, single- and double-quoted scalars, folded over several lines
+//   - literal (|) and folded (>) block scalars with chomping and indentation
+//     indicators
+//   - comments
+//   - anchors, aliases and merge keys (<<), in block and flow collections
+//   - tags: !!str keeps a plain scalar a string, all other tags such as
+//     Compose's !reset and !override are accepted and ignored
+//
+// Anything else is rejected with an error rather than misread, notably
+// multiple documents, explicit mapping keys (?), anchors, aliases and tags on
+// block mapping keys, tab indentation and ": " inside plain scalars.
+//
+// Documents are decoded into generic Go values: map[string]interface{},
+// []interface{}, string, int, float64, bool and nil.
+package yaml
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal parses a single YAML document and returns its generic value.
+// An empty document yields nil.
+func Unmarshal(data []byte) (interface{}, error) {
+	p, err := newParser(data)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseDocument()
+}
+
+// Validate reports whether data is a syntactically valid YAML document.
+func Validate(data []byte) error {
+	_, err := Unmarshal(data)
+	return err
+}
+
+// UnmarshalMap parses a YAML document whose root must be a mapping. An empty
+// document yields an empty map.
+func UnmarshalMap(data []byte) (map[string]interface{}, error) {
+	value, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("yaml: document root is %T, expected a mapping", value)
+	}
+	return m, nil
+}
+
+// line is a single source line with its indentation pre-computed.
+type line struct {
+	num    int    // 1-based line number for error messages
+	raw    string // original text without the trailing newline
+	indent int    // number of leading spaces
+	text   string // content without indentation and comments
+}
+
+type parser struct {
+	lines   []*line
+	pos     int
+	anchors map[string]interface{}
+}
+
+func newParser(data []byte) (*parser, error) {
+	src := strings.ReplaceAll(string(data), "\r\n", "\n")
+	src = strings.TrimPrefix(src, "\ufeff")
+	src = strings.TrimSuffix(src, "\n")
+	p := &parser{anchors: make(map[string]interface{})}
+	for i, raw := range strings.Split(src, "\n") {
+		indent := 0
+		for indent < len(raw) && raw[indent] == ' ' {
+			indent++
+		}
+		l := &line{num: i + 1, raw: raw, indent: indent, text: stripComment(raw[indent:])}
+		if strings.HasPrefix(l.text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", l.num)
+		}
+		p.lines = append(p.lines, l)
+	}
+	return p, nil
+}
+
+// stripComment removes a trailing comment that is not part of a quoted string.
+func stripComment(s string) string {
+	inSingle, inDouble := false, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'' && !inDouble:
+			if inSingle || opensQuote(s, i) {
+				inSingle = !inSingle
+			}
+		case c == '"' && !inSingle:
+			if inDouble && s[i-1] != '\\' || !inDouble && opensQuote(s, i) {
+				inDouble = !inDouble
+			}
+		case c == '#' && !inSingle && !inDouble && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimRight(s[:i], " \t")
+		}
+	}
+	return strings.TrimRight(s, " \t")
+}
+
+// opensQuote reports whether the quote at s[i] starts a quoted scalar rather
+// than being part of a plain one such as it's: it must start the text, a flow
+// collection entry, or follow a "- ", ": " or "? " indicator.
+func opensQuote(s string, i int) bool {
+	j := i
+	for j > 0 && (s[j-1] == ' ' || s[j-1] == '\t') {
+		j--
+	}
+	if j == 0 {
+		return true
+	}
+	switch s[j-1] {
+	case '[', '{', ',':
+		return true
+	case ':', '-', '?':
+		return j < i
+	}
+	return false
+}
+
+// current returns the next non-blank line without consuming it.
+func (p *parser) current() *line {
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.text != "" {
+			return l
+		}
+		p.pos++
+	}
+	return nil
+}
+
+func (p *parser) parseDocument() (interface{}, error) {
+	if l := p.current(); l != nil && l.indent == 0 && isDocumentMarker(l.text, "---") {
+		rest := strings.TrimSpace(l.text[3:])
+		if rest == "" {
+			p.pos++
+		} else {
+			l.text = rest
+		}
+	}
+
+	l := p.current()
+	if l == nil || (l.indent == 0 && isDocumentMarker(l.text, "...")) {
+		return nil, nil
+	}
+
+	value, err := p.parseNode(l.indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if l := p.current(); l != nil {
+		if l.indent == 0 && isDocumentMarker(l.text, "...") {
+			return value, nil
+		}
+		if l.indent == 0 && isDocumentMarker(l.text, "---") {
+			return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", l.num)
+		}
+		return nil, fmt.Errorf("yaml: line %d: unexpected content %q", l.num, l.text)
+	}
+	return value, nil
+}
+
+func isDocumentMarker(text, marker string) bool {
+	return text == marker || strings.HasPrefix(text, marker+" ")
+}
+
+// parseNode parses the block node starting at the current line, which must be
+// indented by exactly indent spaces.
+func (p *parser) parseNode(indent int) (interface{}, error) {
+	l := p.current()
+	if l == nil {
+		return nil, nil
+	}
+	if isSequenceEntry(l.text) {
+		return p.parseSequence(indent)
+	}
+	if isExplicitKey(l.text) {
+		return nil, fmt.Errorf("yaml: line %d: explicit mapping keys are not supported", l.num)
+	}
+	if _, _, ok, err := splitMappingKey(l.text); err != nil {
+		return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+	} else if ok {
+		return p.parseMapping(indent)
+	}
+
+	p.pos++
+	return p.parseInlineValue(l, l.text, indent)
+}
+
+func isSequenceEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *parser) parseSequence(indent int) (interface{}, error) {
+	seq := make([]interface{}, 0)
+	for {
+		l := p.current()
+		if l == nil || l.indent < indent {
+			return seq, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		if !isSequenceEntry(l.text) {
+			return seq, nil
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(l.text, "-"), " ")
+		content, anchor, _, err := splitProperties(rest)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+		}
+		if content == "" {
+			p.pos++
+			item, err := p.parseNested(indent, true)
+			if err != nil {
+				return nil, err
+			}
+			if anchor != "" {
+				p.anchors[anchor] = item
+			}
+			seq = append(seq, item)
+			continue
+		}
+
+		if _, _, isKey, _ := splitMappingKey(rest); !isKey && !isSequenceEntry(rest) && !isExplicitKey(rest) {
+			p.pos++
+			item, err := p.parseInlineValue(l, rest, indent)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, item)
+			continue
+		}
+
+		// Re-interpret "- key: value" / "- - item" as a node starting at the
+		// column of the content following the dash.
+		offset := len(l.text) - len(rest)
+		l.indent += offset
+		l.text = rest
+		item, err := p.parseNode(l.indent)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, item)
+	}
+}
+
+func (p *parser) parseMapping(indent int) (interface{}, error) {
+	m := make(map[string]interface{})
+	explicit := make(map[string]bool)
+	for {
+		l := p.current()
+		if l == nil || l.indent < indent {
+			return m, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		if isSequenceEntry(l.text) {
+			return m, nil
+		}
+
+		key, rest, ok, err := splitMappingKey(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: expected a mapping key, got %q", l.num, l.text)
+		}
+		p.pos++
+
+		content, anchor, _, err := splitProperties(rest)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+		}
+		var value interface{}
+		if content == "" {
+			value, err = p.parseNested(indent, false)
+			if err != nil {
+				return nil, err
+			}
+			if anchor != "" {
+				p.anchors[anchor] = value
+			}
+		} else {
+			value, err = p.parseInlineValue(l, rest, indent)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if key == "<<" {
+			if err := mergeInto(m, explicit, value); err != nil {
+				return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+			}
+			continue
+		}
+		if explicit[key] {
+			return nil, fmt.Errorf("yaml: line %d: duplicate key %q", l.num, key)
+		}
+		explicit[key] = true
+		m[key] = value
+	}
+}
+
+// mergeInto applies a "<<" merge key. Explicitly set keys always win.
+func mergeInto(m map[string]interface{}, explicit map[string]bool, value interface{}) error {
+	sources := []interface{}{value}
+	if seq, ok := value.([]interface{}); ok {
+		sources = seq
+	}
+	for _, src := range sources {
+		sm, ok := src.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("merge key value must be a mapping or a list of mappings")
+		}
+		for k, v := range sm {
+			if _, exists := m[k]; !exists && !explicit[k] {
+				m[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+// parseNested parses the value of a key or sequence entry whose content starts
+// on the following line. Sequences may share the parent mapping indentation.
+func (p *parser) parseNested(parentIndent int, inSequence bool) (interface{}, error) {
+	next := p.current()
+	if next == nil {
+		return nil, nil
+	}
+	if next.indent > parentIndent {
+		return p.parseNode(next.indent)
+	}
+	if !inSequence && next.indent == parentIndent && isSequenceEntry(next.text) {
+		return p.parseSequence(parentIndent)
+	}
+	return nil, nil
+}
+
+func isExplicitKey(text string) bool {
+	return text == "?" || strings.HasPrefix(text, "? ")
+}
+
+// splitMappingKey detects "key: value" / "key:" and returns the decoded key
+// and the remaining value text.
+func splitMappingKey(text string) (string, string, bool, error) {
+	if text == "" {
+		return "", "", false, nil
+	}
+	switch text[0] {
+	case '"', '\'':
+		end := closingQuote(text, text[0])
+		if end < 0 {
+			return "", "", false, nil
+		}
+		after := text[end+1:]
+		if !strings.HasPrefix(after, ":") || (len(after) > 1 && after[1] != ' ') {
+			return "", "", false, nil
+		}
+		key, err := unquote(text[:end+1])
+		if err != nil {
+			return "", "", false, err
+		}
+		return key, strings.TrimSpace(after[1:]), true, nil
+	case '[', '{':
+		return "", "", false, nil
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != ':' {
+			continue
+		}
+		if i+1 == len(text) || text[i+1] == ' ' {
+			key := strings.TrimSpace(text[:i])
+			if key == "" {
+				return "", "", false, fmt.Errorf("empty mapping key")
+			}
+			if strings.ContainsRune("&*!", rune(key[0])) {
+				return "", "", false, fmt.Errorf("anchors, aliases and tags on mapping keys are not supported")
+			}
+			return key, strings.TrimSpace(text[i+1:]), true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// closingQuote returns the index of the quote closing the string opened at
+// text[0], or -1 when the string is not terminated on this line.
+func closingQuote(text string, quote byte) int {
+	for i := 1; i < len(text); i++ {
+		if quote == '\'' && text[i] == '\'' {
+			if i+1 < len(text) && text[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+		if quote == '"' {
+			if text[i] == '\\' {
+				i++
+				continue
+			}
+			if text[i] == '"' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseInlineValue decodes the value text found on line l (after a key or a
+// dash), consuming continuation lines for block scalars, multi-line flow
+// collections, quoted strings and plain scalars.
+func (p *parser) parseInlineValue(l *line, text string, indent int) (interface{}, error) {
+	text, anchor, tag, err := splitProperties(strings.TrimSpace(text))
+	if err != nil {
+		return nil, fmt.Errorf("yaml: line %d: %v", l.num, err)
+	}
+
+	var value interface{}
+	switch {
+	case strings.HasPrefix(text, "*"):
+		name := text[1:]
+		v, ok := p.anchors[name]
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: unknown alias %q", l.num, name)
+		}
+		value = v
+	case strings.HasPrefix(text, "|") || strings.HasPrefix(text, ">"):
+		value, err = p.parseBlockScalar(l, text, indent)
+	case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{"):
+		text = p.collectUntilBalanced(text)
+		value, err = parseFlow(text, p.anchors)
+		if err != nil {
+			err = fmt.Errorf("yaml: line %d: %v", l.num, err)
+		}
+	case strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'"):
+		text = p.collectQuoted(text)
+		if end := closingQuote(text, text[0]); end != len(text)-1 {
+			return nil, fmt.Errorf("yaml: line %d: invalid quoted scalar %q", l.num, text)
+		}
+		value, err = unquote(text)
+		if err != nil {
+			err = fmt.Errorf("yaml: line %d: %v", l.num, err)
+		}
+	default:
+		if strings.Contains(text, ": ") {
+			return nil, fmt.Errorf("yaml: line %d: mapping values are not allowed in plain scalar %q", l.num, text)
+		}
+		text = p.collectPlain(text, indent)
+		if tag == "!!str" {
+			value = text
+		} else {
+			value = resolveScalar(text)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if anchor != "" {
+		p.anchors[anchor] = value
+	}
+	return value, nil
+}
+
+// splitProperties removes the leading "&name" anchor and "!tag" tag, in any
+// order, from text and returns the remaining content with both properties.
+func splitProperties(text string) (content, anchor, tag string, err error) {
+	for text != "" && (text[0] == '&' || text[0] == '!') {
+		end := strings.IndexAny(text, " \t")
+		if end < 0 {
+			end = len(text)
+		}
+		if text[0] == '!' {
+			tag = text[:end]
+		} else if anchor = text[1:end]; anchor == "" {
+			return "", "", "", fmt.Errorf("empty anchor name")
+		}
+		text = strings.TrimSpace(text[end:])
+	}
+	return text, anchor, tag, nil
+}
+
+// collectPlain joins continuation lines of a multi-line plain scalar. Line
+// breaks fold into a space, blank lines into a newline each. A comment line
+// ends the scalar.
+func (p *parser) collectPlain(text string, indent int) string {
+	blank := 0
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if strings.TrimSpace(next.raw) == "" {
+			blank++
+			p.pos++
+			continue
+		}
+		if next.text == "" || next.indent <= indent {
+			return text
+		}
+		if _, _, isKey, _ := splitMappingKey(next.text); isKey || isSequenceEntry(next.text) {
+			return text
+		}
+		text += foldedBreak(blank, "\n") + next.text
+		blank = 0
+		p.pos++
+	}
+	return text
+}
+
+// collectQuoted joins following lines until the quoted string is closed,
+// folding line breaks like collectPlain. In double-quoted strings the newlines
+// are written as escapes and an escaped line break joins the lines directly.
+func (p *parser) collectQuoted(text string) string {
+	quote := text[0]
+	newline := "\n"
+	if quote == '"' {
+		newline = `\n`
+	}
+	blank := 0
+	for closingQuote(text, quote) < 0 && p.pos < len(p.lines) {
+		next := strings.TrimSpace(p.lines[p.pos].raw)
+		p.pos++
+		if next == "" {
+			blank++
+			continue
+		}
+		if quote == '"' && escapedLineBreak(text) {
+			text = text[:len(text)-1] + strings.Repeat(newline, blank)
+		} else {
+			text += foldedBreak(blank, newline)
+		}
+		text += next
+		blank = 0
+	}
+	return text
+}
+
+// foldedBreak returns what a line break followed by blank empty lines folds
+// into: a space, or newline once per blank line.
+func foldedBreak(blank int, newline string) string {
+	if blank == 0 {
+		return " "
+	}
+	return strings.Repeat(newline, blank)
+}
+
+// escapedLineBreak reports whether text ends with an unescaped backslash.
+func escapedLineBreak(text string) bool {
+	n := len(text) - len(strings.TrimRight(text, `\`))
+	return n%2 == 1
+}
+
+// collectUntilBalanced joins following lines until all flow brackets close.
+func (p *parser) collectUntilBalanced(text string) string {
+	for !flowBalanced(text) && p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		p.pos++
+		if next.text != "" {
+			text += " " + strings.TrimSpace(next.text)
+		}
+	}
+	return text
+}
+
+func flowBalanced(text string) bool {
+	depth := 0
+	inSingle, inDouble := false, false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case inDouble:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inDouble = false
+			}
+		case inSingle:
+			if c == '\'' {
+				inSingle = false
+			}
+		case c == '"' && opensQuote(text, i):
+			inDouble = true
+		case c == '\'' && opensQuote(text, i):
+			inSingle = true
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// parseBlockScalar decodes literal (|) and folded (>) block scalars.
+func (p *parser) parseBlockScalar(l *line, header string, indent int) (interface{}, error) {
+	literal := header[0] == '|'
+	chomp := byte(0)
+	explicitIndent := 0
+	for _, c := range header[1:] {
+		switch {
+		case c == '-' || c == '+':
+			chomp = byte(c)
+		case c >= '1' && c <= '9':
+			explicitIndent = int(c - '0')
+		default:
+			return nil, fmt.Errorf("yaml: line %d: invalid block scalar header %q", l.num, header)
+		}
+	}
+
+	blockIndent := 0
+	if explicitIndent > 0 {
+		blockIndent = indent + explicitIndent
+	}
+	var content []string
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if strings.TrimSpace(next.raw) == "" {
+			// Spaces beyond the block indentation are content.
+			if blockIndent > 0 && next.indent > blockIndent {
+				content = append(content, next.raw[blockIndent:])
+			} else {
+				content = append(content, "")
+			}
+			p.pos++
+			continue
+		}
+		if blockIndent == 0 {
+			if next.indent <= indent {
+				break
+			}
+			blockIndent = next.indent
+		}
+		if next.indent < blockIndent {
+			break
+		}
+		content = append(content, next.raw[blockIndent:])
+		p.pos++
+	}
+
+	// Trailing blank lines are subject to chomping.
+	trailing := 0
+	for len(content) > 0 && content[len(content)-1] == "" {
+		content = content[:len(content)-1]
+		trailing++
+	}
+
+	var b strings.Builder
+	for i, c := range content {
+		if i > 0 {
+			switch {
+			case literal:
+				b.WriteString("\n")
+			case c == "" || content[i-1] == "":
+				if c == "" {
+					b.WriteString("\n")
+				}
+			case strings.HasPrefix(c, " ") || strings.HasPrefix(content[i-1], " "):
+				b.WriteString("\n")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(c)
+	}
+
+	result := b.String()
+	if len(content) > 0 {
+		switch chomp {
+		case '-':
+		case '+':
+			result += strings.Repeat("\n", trailing+1)
+		default:
+			result += "\n"
+		}
+	}
+	return result, nil
+}
+
+// unquote decodes a single- or double-quoted YAML scalar.
+func unquote(text string) (string, error) {
+	if len(text) < 2 {
+		return "", fmt.Errorf("invalid quoted scalar %q", text)
+	}
+	if text[0] == '\'' {
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	s, err := strconv.Unquote(text)
+	if err != nil {
+		// YAML allows a few escapes Go does not know, such as "\/" and "\ ".
+		fixed := strings.NewReplacer(`\/`, `/`, `\ `, ` `, `\e`, `\x1b`).Replace(text)
+		if s, err = strconv.Unquote(fixed); err != nil {
+			return "", fmt.Errorf("invalid double-quoted scalar %s", text)
+		}
+	}
+	return s, nil
+}
+
+// resolveScalar converts a plain scalar to its YAML 1.2 core schema type.
+func resolveScalar(text string) interface{} {
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+	if i, err := strconv.Atoi(text); err == nil {
+		return i
+	}
+	if strings.HasPrefix(text, "0x") {
+		if i, err := strconv.ParseInt(text[2:], 16, 64); err == nil {
+			return int(i)
+		}
+	}
+	if strings.HasPrefix(text, "0o") {
+		if i, err := strconv.ParseInt(text[2:], 8, 64); err == nil {
+			return int(i)
+		}
+	}
+	if isDecimal(text) {
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+	return text
+}
+
+// isDecimal reports whether text only consists of characters valid in a
+// decimal floating point literal, so that words like "inf" stay strings.
+func isDecimal(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return !(r >= '0' && r <= '9') && !strings.ContainsRune(".eE+-", r)
+	}) < 0
+}
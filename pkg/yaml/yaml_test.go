@@ -0,0 +1,229 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalComposeDocument(t *testing.T) {
+	input := `
+# Example compose file
+x-defaults: &defaults
+  restart: unless-stopped
+  labels:
+    - "com.example.team=web"
+
+services:
+  web:
+    <<: *defaults
+    image: "nginx:1.27" # pinned
+    ports: ["80:80", '443:443']
+    environment:
+      DEBUG: false
+      WORKERS: 4
+      RATIO: 0.5
+      EMPTY:
+    command: >
+      nginx -g
+      'daemon off;'
+    healthcheck:
+      test: [CMD, curl, -f, "http://localhost"]
+  worker:
+    image: busybox
+    entrypoint:
+      - sh
+      - -c
+      - |
+        echo start
+        sleep 10
+    deploy: {replicas: 2, resources: {limits: {cpus: "0.5"}}}
+`
+	got, err := Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"x-defaults": map[string]interface{}{
+			"restart": "unless-stopped",
+			"labels":  []interface{}{"com.example.team=web"},
+		},
+		"services": map[string]interface{}{
+			"web": map[string]interface{}{
+				"restart": "unless-stopped",
+				"labels":  []interface{}{"com.example.team=web"},
+				"image":   "nginx:1.27",
+				"ports":   []interface{}{"80:80", "443:443"},
+				"environment": map[string]interface{}{
+					"DEBUG":   false,
+					"WORKERS": 4,
+					"RATIO":   0.5,
+					"EMPTY":   nil,
+				},
+				"command": "nginx -g 'daemon off;'\n",
+				"healthcheck": map[string]interface{}{
+					"test": []interface{}{"CMD", "curl", "-f", "http://localhost"},
+				},
+			},
+			"worker": map[string]interface{}{
+				"image":      "busybox",
+				"entrypoint": []interface{}{"sh", "-c", "echo start\nsleep 10\n"},
+				"deploy": map[string]interface{}{
+					"replicas": 2,
+					"resources": map[string]interface{}{
+						"limits": map[string]interface{}{"cpus": "0.5"},
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected result:\n got: %#v\nwant: %#v", got, expected)
+	}
+}
+
+func TestUnmarshalSequenceOfMappings(t *testing.T) {
+	input := `volumes:
+- type: bind
+  source: ./data
+  target: /data
+- type: volume
+  source: cache
+  target: /cache
+`
+	got, err := UnmarshalMap([]byte(input))
+	if err != nil {
+		t.Fatalf("UnmarshalMap returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"volumes": []interface{}{
+			map[string]interface{}{"type": "bind", "source": "./data", "target": "/data"},
+			map[string]interface{}{"type": "volume", "source": "cache", "target": "/cache"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected result:\n got: %#v\nwant: %#v", got, expected)
+	}
+}
+
+func TestUnmarshalAnchorsAndTags(t *testing.T) {
+	input := `base: &base {restart: always, labels: [a]}
+tagged: &tagged !!str 1
+plain: !!str 2
+reset: !reset []
+services:
+  - &web
+    image: nginx
+  - *web
+  - &name cache
+  - *name
+flow: {<<: *base, restart: "no", copies: [&x 1, *x], tagged: *tagged}
+`
+	got, err := UnmarshalMap([]byte(input))
+	if err != nil {
+		t.Fatalf("UnmarshalMap returned error: %v", err)
+	}
+
+	web := map[string]interface{}{"image": "nginx"}
+	expected := map[string]interface{}{
+		"base":     map[string]interface{}{"restart": "always", "labels": []interface{}{"a"}},
+		"tagged":   "1",
+		"plain":    "2",
+		"reset":    []interface{}{},
+		"services": []interface{}{web, web, "cache", "cache"},
+		"flow": map[string]interface{}{
+			"restart": "no",
+			"labels":  []interface{}{"a"},
+			"copies":  []interface{}{1, 1},
+			"tagged":  "1",
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected result:\n got: %#v\nwant: %#v", got, expected)
+	}
+}
+
+func TestUnmarshalFlowSequences(t *testing.T) {
+	input := `words: [it's, "a, b", 'c]'] # it's a comment
+pairs: [a: 1, b]
+nested: [
+  [x, y],
+  [z],
+]
+note: it's # another comment
+`
+	got, err := UnmarshalMap([]byte(input))
+	if err != nil {
+		t.Fatalf("UnmarshalMap returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"words":  []interface{}{"it's", "a, b", "c]"},
+		"pairs":  []interface{}{map[string]interface{}{"a": 1}, "b"},
+		"nested": []interface{}{[]interface{}{"x", "y"}, []interface{}{"z"}},
+		"note":   "it's",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected result:\n got: %#v\nwant: %#v", got, expected)
+	}
+}
+
+func TestUnmarshalMultilineScalars(t *testing.T) {
+	input := "plain: one\n  two\n\n  three\n" +
+		"single: 'one\n\n  two'\n" +
+		"double: \"one\n  two\n\n  three\\\n  four\"\n" +
+		"literal: |\n  one\n   \n  two\n" +
+		"folded: >\n  one\n  two\n\n  three\n"
+	got, err := UnmarshalMap([]byte(input))
+	if err != nil {
+		t.Fatalf("UnmarshalMap returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"plain":   "one two\nthree",
+		"single":  "one\ntwo",
+		"double":  "one two\nthreefour",
+		"literal": "one\n \ntwo\n",
+		"folded":  "one two\nthree\n",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected result:\n got: %#v\nwant: %#v", got, expected)
+	}
+}
+
+func TestValidateRejectsInvalidDocuments(t *testing.T) {
+	testCases := map[string]string{
+		"Tab indentation":      "services:\n\tweb: {}\n",
+		"Unexpected indent":    "services:\n  web:\n    image: nginx\n      tag: latest\n",
+		"Duplicate key":        "image: nginx\nimage: busybox\n",
+		"Unclosed flow":        "ports: [80, 443\n",
+		"Unterminated quote":   "image: \"nginx\n",
+		"Unknown alias":        "service: *missing\n",
+		"Multiple documents":   "a: 1\n---\nb: 2\n",
+		"Mapping after scalar": "just a string\nkey: value\n",
+		"Empty anchor":         "image: & nginx\n",
+		"Anchor on key":        "&key image: nginx\n",
+		"Explicit key":         "? image\n: nginx\n",
+		"Nested mapping value": "image: nginx: latest\n",
+		"Comment in scalar":    "command: echo\n  # start\n  hello\n",
+		"Unknown flow alias":   "ports: [*missing]\n",
+	}
+
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := Validate([]byte(input)); err == nil {
+				t.Errorf("Expected %q to be rejected", input)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsEmptyDocument(t *testing.T) {
+	for _, input := range []string{"", "# only a comment\n", "---\n"} {
+		if err := Validate([]byte(input)); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", input, err)
+		}
+	}
+}